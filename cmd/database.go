@@ -69,6 +69,22 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] two-factor recovery code table maintained successfully")
 
+	err = datastore.Container.UserStore.SyncStructs(new(models.Ledger))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] ledger table maintained successfully")
+
+	err = datastore.Container.UserStore.SyncStructs(new(models.LedgerMember))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] ledger member table maintained successfully")
+
 	err = datastore.Container.TokenStore.SyncStructs(new(models.TokenRecord))
 
 	if err != nil {
@@ -85,6 +101,14 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] account table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.CreditCardOverdueStatement))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] credit card overdue statement table maintained successfully")
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.Transaction))
 
 	if err != nil {
@@ -149,6 +173,14 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction item index table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionSplit))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction split table maintained successfully")
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionTemplate))
 
 	if err != nil {
@@ -157,6 +189,14 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction template table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionRule))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction rule table maintained successfully")
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionPictureInfo))
 
 	if err != nil {
@@ -173,6 +213,14 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] user custom exchange rate table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.ExchangeRateHistory))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] exchange rate history table maintained successfully")
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.UserApplicationCloudSetting))
 
 	if err != nil {
@@ -197,5 +245,45 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] insights explorer table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.Budget))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] budget table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.Webhook))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] webhook table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.WebhookDelivery))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] webhook delivery table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.ImportBatch))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] import batch table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.ImportJob))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] import job table maintained successfully")
+
 	return nil
 }
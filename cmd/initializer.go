@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"os"
+	"strings"
 
 	"github.com/mayswind/ezbookkeeping/pkg/avatars"
 	"github.com/mayswind/ezbookkeeping/pkg/core"
@@ -12,6 +13,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/llm"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/mail"
+	"github.com/mayswind/ezbookkeeping/pkg/ocr"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/storage"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -59,7 +61,7 @@ func initializeSystem(c *core.CliContext) (*settings.Config, error) {
 	}
 
 	if !isDisableBootLog {
-		log.BootInfof(c, "[initializer.initializeSystem] config file: %s, transaction_from_ocr_image_recognition: %v", configFilePath, config.TransactionFromOCRImageRecognition)
+		log.BootInfof(c, "[initializer.initializeSystem] config file: %s, transaction_from_ocr_image_recognition: %v, ocr_engines: %s", configFilePath, config.TransactionFromOCRImageRecognition, strings.Join(config.OCREngines, ","))
 	}
 
 	if config.SecretKeyNoSet {
@@ -104,6 +106,15 @@ func initializeSystem(c *core.CliContext) (*settings.Config, error) {
 		return nil, err
 	}
 
+	err = ocr.InitializeOCREngine(config)
+
+	if err != nil {
+		if !isDisableBootLog {
+			log.BootErrorf(c, "[initializer.initializeSystem] initializes ocr engine failed, because %s", err.Error())
+		}
+		return nil, err
+	}
+
 	err = uuid.InitializeUuidGenerator(config)
 
 	if err != nil {
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 
@@ -272,6 +273,17 @@ var UserData = &cli.Command{
 					Required: true,
 					Usage:    "Token expiration time in seconds (0 - 4294967295, 0 means no expiration).",
 				},
+				&cli.BoolFlag{
+					Name:     "allowDestructiveTools",
+					Required: false,
+					Usage:    "Allow the mcp token to call the tools which modify or delete existing data (only for \"mcp\" token)",
+				},
+				&cli.StringFlag{
+					Name:     "scopes",
+					Aliases:  []string{"s"},
+					Required: false,
+					Usage:    "Comma-separated scopes granted to the api token, supports \"transactions:read\", \"transactions:write\", \"accounts:read\", \"accounts:write\" and \"data:export\", default is full access (only for \"api\" token)",
+				},
 			},
 		},
 		{
@@ -729,6 +741,8 @@ func createNewUserToken(c *core.CliContext) error {
 	username := c.String("username")
 	tokenType := c.String("type")
 	expiresInSeconds := c.Int64("expiresInSeconds")
+	allowDestructiveTools := c.Bool("allowDestructiveTools")
+	scopeNames := c.String("scopes")
 
 	if tokenType == "" {
 		tokenType = "api"
@@ -739,12 +753,39 @@ func createNewUserToken(c *core.CliContext) error {
 		return nil
 	}
 
+	if allowDestructiveTools && tokenType != "mcp" {
+		log.CliErrorf(c, "[user_data.createNewUserToken] allowDestructiveTools is only supported for mcp token")
+		return nil
+	}
+
+	if scopeNames != "" && tokenType != "api" {
+		log.CliErrorf(c, "[user_data.createNewUserToken] scopes is only supported for api token")
+		return nil
+	}
+
 	if expiresInSeconds < 0 || expiresInSeconds > 4294967295 {
 		log.CliErrorf(c, "[user_data.createNewUserToken] expiresInSeconds is out of range (0 - 4294967295)")
 		return nil
 	}
 
-	token, tokenString, err := clis.UserData.CreateNewUserToken(c, username, tokenType, expiresInSeconds)
+	var scopes []core.TokenScope
+
+	if allowDestructiveTools {
+		scopes = append(scopes, core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE)
+	}
+
+	if scopeNames != "" {
+		apiTokenScopes, valid := core.GetAPITokenScopes(strings.Split(scopeNames, ","))
+
+		if !valid {
+			log.CliErrorf(c, "[user_data.createNewUserToken] scopes is invalid")
+			return nil
+		}
+
+		scopes = append(scopes, apiTokenScopes...)
+	}
+
+	token, tokenString, err := clis.UserData.CreateNewUserToken(c, username, tokenType, expiresInSeconds, scopes)
 
 	if err != nil {
 		log.CliErrorf(c, "[user_data.createNewUserToken] error occurs when creating user token")
@@ -996,4 +1037,8 @@ func printTokenInfo(token *models.TokenRecord) {
 	fmt.Printf("[ExpiredAt] %s (%d)\n", utils.FormatUnixTimeToLongDateTimeInServerTimezone(token.ExpiredUnixTime), token.ExpiredUnixTime)
 	fmt.Printf("[LastSeen] %s (%d)\n", utils.FormatUnixTimeToLongDateTimeInServerTimezone(token.LastSeenUnixTime), token.LastSeenUnixTime)
 	fmt.Printf("[UserAgent] %s\n", token.UserAgent)
+
+	if token.Scopes != "" {
+		fmt.Printf("[Scopes] %s\n", token.Scopes)
+	}
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/cron"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/importjobs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/mcp"
 	"github.com/mayswind/ezbookkeeping/pkg/middlewares"
@@ -87,6 +88,15 @@ func startWebServer(c *core.CliContext) error {
 		return err
 	}
 
+	if config.EnableDataImport {
+		err = importjobs.InitializeImportJobWorkerPool(c, config)
+
+		if err != nil {
+			log.BootErrorf(c, "[webserver.startWebServer] initializes import job worker pool failed, because %s", err.Error())
+			return err
+		}
+	}
+
 	serverInfo := fmt.Sprintf("current server id is %d, current instance id is %d", requestid.Container.GetCurrentServerUniqId(), requestid.Container.GetCurrentInstanceUniqId())
 	uuidServerInfo := ""
 	if config.UuidGeneratorType == settings.InternalUuidGeneratorType {
@@ -243,12 +253,15 @@ func startWebServer(c *core.CliContext) error {
 		mcpRoute.Use(bindMiddleware(middlewares.JWTMCPAuthorization(config)))
 		{
 			mcpRoute.POST("", bindJSONRPCApi(map[string]core.JSONRPCApiHandlerFunc{
-				"initialize":     api.ModelContextProtocols.InitializeHandler,
-				"resources/list": api.ModelContextProtocols.ListResourcesHandler,
-				"resources/read": api.ModelContextProtocols.ReadResourceHandler,
-				"tools/list":     api.ModelContextProtocols.ListToolsHandler,
-				"tools/call":     api.ModelContextProtocols.CallToolHandler,
-				"ping":           api.ModelContextProtocols.PingHandler,
+				"initialize":               api.ModelContextProtocols.InitializeHandler,
+				"resources/list":           api.ModelContextProtocols.ListResourcesHandler,
+				"resources/templates/list": api.ModelContextProtocols.ListResourceTemplatesHandler,
+				"resources/read":           api.ModelContextProtocols.ReadResourceHandler,
+				"tools/list":               api.ModelContextProtocols.ListToolsHandler,
+				"tools/call":               api.ModelContextProtocols.CallToolHandler,
+				"prompts/list":             api.ModelContextProtocols.ListPromptsHandler,
+				"prompts/get":              api.ModelContextProtocols.GetPromptHandler,
+				"ping":                     api.ModelContextProtocols.PingHandler,
 			}, map[string]int{
 				"notifications/initialized": http.StatusAccepted,
 			}))
@@ -368,126 +381,219 @@ func startWebServer(c *core.CliContext) error {
 			apiV1Route.POST("/data/clear/transactions.json", bindApi(api.DataManagements.ClearAllTransactionsHandler))
 			apiV1Route.POST("/data/clear/transactions/by_account.json", bindApi(api.DataManagements.ClearAllTransactionsByAccountHandler))
 
-			if config.EnableDataExport {
-				apiV1Route.GET("/data/export.csv", bindCsv(api.DataManagements.ExportDataToEzbookkeepingCSVHandler))
-				apiV1Route.GET("/data/export.tsv", bindTsv(api.DataManagements.ExportDataToEzbookkeepingTSVHandler))
+			if config.EnableDataImport {
+				apiV1Route.POST("/data/import/archive.json", bindApi(api.DataManagements.ImportDataArchiveHandler))
+			}
+
+			// Insights Explorers
+			apiV1Route.GET("/insights/explorers/list.json", bindApi(api.InsightsExplorers.InsightsExplorerListHandler))
+			apiV1Route.GET("/insights/explorers/get.json", bindApi(api.InsightsExplorers.InsightsExplorerGetHandler))
+			apiV1Route.POST("/insights/explorers/add.json", bindApi(api.InsightsExplorers.InsightsExplorerCreateHandler))
+			apiV1Route.POST("/insights/explorers/modify.json", bindApi(api.InsightsExplorers.InsightsExplorerModifyHandler))
+			apiV1Route.POST("/insights/explorers/hide.json", bindApi(api.InsightsExplorers.InsightsExplorerHideHandler))
+			apiV1Route.POST("/insights/explorers/move.json", bindApi(api.InsightsExplorers.InsightsExplorerMoveHandler))
+			apiV1Route.POST("/insights/explorers/delete.json", bindApi(api.InsightsExplorers.InsightsExplorerDeleteHandler))
+
+			// Shared Ledgers
+			apiV1Route.GET("/ledgers/list.json", bindApi(api.Ledgers.LedgerListHandler))
+			apiV1Route.POST("/ledgers/add.json", bindApi(api.Ledgers.LedgerCreateHandler))
+			apiV1Route.POST("/ledgers/modify.json", bindApi(api.Ledgers.LedgerModifyHandler))
+			apiV1Route.POST("/ledgers/delete.json", bindApi(api.Ledgers.LedgerDeleteHandler))
+			apiV1Route.GET("/ledgers/members/list.json", bindApi(api.Ledgers.LedgerMemberListHandler))
+			apiV1Route.POST("/ledgers/members/add.json", bindApi(api.Ledgers.LedgerMemberAddHandler))
+			apiV1Route.POST("/ledgers/members/modify.json", bindApi(api.Ledgers.LedgerMemberModifyHandler))
+			apiV1Route.POST("/ledgers/members/delete.json", bindApi(api.Ledgers.LedgerMemberDeleteHandler))
+
+			// Budgets
+			apiV1Route.GET("/budgets/list.json", bindApi(api.Budgets.BudgetListHandler))
+			apiV1Route.GET("/budgets/get.json", bindApi(api.Budgets.BudgetGetHandler))
+			apiV1Route.GET("/budgets/progress.json", bindApi(api.Budgets.BudgetProgressHandler))
+			apiV1Route.POST("/budgets/add.json", bindApi(api.Budgets.BudgetCreateHandler))
+			apiV1Route.POST("/budgets/modify.json", bindApi(api.Budgets.BudgetModifyHandler))
+			apiV1Route.POST("/budgets/hide.json", bindApi(api.Budgets.BudgetHideHandler))
+			apiV1Route.POST("/budgets/move.json", bindApi(api.Budgets.BudgetMoveHandler))
+			apiV1Route.POST("/budgets/delete.json", bindApi(api.Budgets.BudgetDeleteHandler))
+
+			// Large Language Models (OCR bill recognition and AI text recognition; AI image recognition has been removed)
+			if config.TransactionFromOCRImageRecognition {
+				apiV1Route.POST("/llm/transactions/recognize_receipt_image_ocr.json", bindApi(api.LargeLanguageModels.RecognizeReceiptImageByOCRHandler))
+			}
+
+			if config.TransactionFromAITextRecognition {
+				apiV1Route.POST("/llm/transactions/recognize_text.json", bindApi(api.LargeLanguageModels.RecognizeTransactionTextHandler))
+			}
+
+			// Exchange Rates
+			apiV1Route.GET("/exchange_rates/latest.json", bindApi(api.ExchangeRates.LatestExchangeRateHandler))
+			apiV1Route.POST("/exchange_rates/user_custom/update.json", bindApi(api.ExchangeRates.UserCustomExchangeRateUpdateHandler))
+			apiV1Route.POST("/exchange_rates/user_custom/delete.json", bindApi(api.ExchangeRates.UserCustomExchangeRateDeleteHandler))
+
+			// Webhooks
+			if config.EnableWebhook {
+				apiV1Route.GET("/webhooks/list.json", bindApi(api.Webhooks.WebhookListHandler))
+				apiV1Route.GET("/webhooks/get.json", bindApi(api.Webhooks.WebhookGetHandler))
+				apiV1Route.POST("/webhooks/add.json", bindApi(api.Webhooks.WebhookCreateHandler))
+				apiV1Route.POST("/webhooks/modify.json", bindApi(api.Webhooks.WebhookModifyHandler))
+				apiV1Route.POST("/webhooks/delete.json", bindApi(api.Webhooks.WebhookDeleteHandler))
+				apiV1Route.GET("/webhooks/deliveries/list.json", bindApi(api.Webhooks.WebhookDeliveryListHandler))
+			}
+
+			// System
+			apiV1Route.GET("/systems/version.json", bindApi(api.Systems.VersionHandler))
+		}
+
+		if config.EnableDataExport {
+			apiV1DataExportRoute := apiRoute.Group("/v1")
+			apiV1DataExportRoute.Use(bindMiddleware(middlewares.JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_DATA_EXPORT, "")))
+			{
+				// Data Export
+				apiV1DataExportRoute.GET("/data/export.csv", bindCsv(api.DataManagements.ExportDataToEzbookkeepingCSVHandler))
+				apiV1DataExportRoute.GET("/data/export.tsv", bindTsv(api.DataManagements.ExportDataToEzbookkeepingTSVHandler))
+				apiV1DataExportRoute.GET("/data/export.beancount", bindPlainText(api.DataManagements.ExportDataToBeancountHandler))
+				apiV1DataExportRoute.GET("/data/export.qif", bindPlainText(api.DataManagements.ExportDataToQifHandler))
+				apiV1DataExportRoute.GET("/data/export.ofx", bindXml(api.DataManagements.ExportDataToOFXHandler))
+				apiV1DataExportRoute.GET("/data/export.gnucash", bindXml(api.DataManagements.ExportDataToGnuCashHandler))
+				apiV1DataExportRoute.GET("/data/export.zip", bindZip(api.DataManagements.ExportDataToArchiveHandler))
 			}
+		}
 
+		if config.EnableFinancialReport {
+			apiV1ReportsRoute := apiRoute.Group("/v1")
+			apiV1ReportsRoute.Use(bindMiddleware(middlewares.JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ, "")))
+			{
+				// Reports
+				apiV1ReportsRoute.GET("/reports/financial.html", bindHtml(api.Reports.FinancialReportHtmlHandler))
+				apiV1ReportsRoute.GET("/reports/financial.pdf", bindPdf(api.Reports.FinancialReportPdfHandler))
+			}
+		}
+
+		apiV1AccountsRoute := apiRoute.Group("/v1")
+		apiV1AccountsRoute.Use(bindMiddleware(middlewares.JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_ACCOUNTS_READ, core.USER_TOKEN_SCOPE_ACCOUNTS_WRITE)))
+		{
 			// Accounts
-			apiV1Route.GET("/accounts/list.json", bindApi(api.Accounts.AccountListHandler))
-			apiV1Route.GET("/accounts/get.json", bindApi(api.Accounts.AccountGetHandler))
-			apiV1Route.POST("/accounts/add.json", bindApi(api.Accounts.AccountCreateHandler))
-			apiV1Route.POST("/accounts/modify.json", bindApi(api.Accounts.AccountModifyHandler))
-			apiV1Route.POST("/accounts/hide.json", bindApi(api.Accounts.AccountHideHandler))
-			apiV1Route.POST("/accounts/move.json", bindApi(api.Accounts.AccountMoveHandler))
-			apiV1Route.POST("/accounts/delete.json", bindApi(api.Accounts.AccountDeleteHandler))
-			apiV1Route.POST("/accounts/sub_account/delete.json", bindApi(api.Accounts.SubAccountDeleteHandler))
+			apiV1AccountsRoute.GET("/accounts/list.json", bindApi(api.Accounts.AccountListHandler))
+			apiV1AccountsRoute.GET("/accounts/get.json", bindApi(api.Accounts.AccountGetHandler))
+			apiV1AccountsRoute.POST("/accounts/add.json", bindApi(api.Accounts.AccountCreateHandler))
+			apiV1AccountsRoute.POST("/accounts/modify.json", bindApi(api.Accounts.AccountModifyHandler))
+			apiV1AccountsRoute.POST("/accounts/hide.json", bindApi(api.Accounts.AccountHideHandler))
+			apiV1AccountsRoute.POST("/accounts/move.json", bindApi(api.Accounts.AccountMoveHandler))
+			apiV1AccountsRoute.POST("/accounts/delete.json", bindApi(api.Accounts.AccountDeleteHandler))
+			apiV1AccountsRoute.POST("/accounts/sub_account/delete.json", bindApi(api.Accounts.SubAccountDeleteHandler))
+			apiV1AccountsRoute.GET("/accounts/statements/list.json", bindApi(api.CreditCardStatements.StatementListHandler))
+			apiV1AccountsRoute.GET("/accounts/statements/overdue/list.json", bindApi(api.CreditCardStatements.OverdueStatementListHandler))
+		}
 
+		apiV1TransactionsRoute := apiRoute.Group("/v1")
+		apiV1TransactionsRoute.Use(bindMiddleware(middlewares.JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ, core.USER_TOKEN_SCOPE_TRANSACTIONS_WRITE)))
+		{
 			// Transactions
-			apiV1Route.GET("/transactions/count.json", bindApi(api.Transactions.TransactionCountHandler))
-			apiV1Route.GET("/transactions/list.json", bindApi(api.Transactions.TransactionListHandler))
-			apiV1Route.GET("/transactions/list/by_month.json", bindApi(api.Transactions.TransactionMonthListHandler))
-			apiV1Route.GET("/transactions/list/all.json", bindApi(api.Transactions.TransactionListAllHandler))
-			apiV1Route.GET("/transactions/reconciliation_statements.json", bindApi(api.Transactions.TransactionReconciliationStatementHandler))
-			apiV1Route.GET("/transactions/statistics.json", bindApi(api.Transactions.TransactionStatisticsHandler))
-			apiV1Route.GET("/transactions/statistics/trends.json", bindApi(api.Transactions.TransactionStatisticsTrendsHandler))
-			apiV1Route.GET("/transactions/statistics/asset_trends.json", bindApi(api.Transactions.TransactionStatisticsAssetTrendsHandler))
-			apiV1Route.GET("/transactions/amounts.json", bindApi(api.Transactions.TransactionAmountsHandler))
-			apiV1Route.GET("/transactions/get.json", bindApi(api.Transactions.TransactionGetHandler))
-			apiV1Route.POST("/transactions/add.json", bindApi(api.Transactions.TransactionCreateHandler))
-			apiV1Route.POST("/transactions/modify.json", bindApi(api.Transactions.TransactionModifyHandler))
-			apiV1Route.POST("/transactions/move/all.json", bindApi(api.Transactions.TransactionMoveAllBetweenAccountsHandler))
-			apiV1Route.POST("/transactions/delete.json", bindApi(api.Transactions.TransactionDeleteHandler))
+			apiV1TransactionsRoute.GET("/transactions/count.json", bindApi(api.Transactions.TransactionCountHandler))
+			apiV1TransactionsRoute.GET("/transactions/list.json", bindApi(api.Transactions.TransactionListHandler))
+			apiV1TransactionsRoute.GET("/transactions/list/by_month.json", bindApi(api.Transactions.TransactionMonthListHandler))
+			apiV1TransactionsRoute.GET("/transactions/list/all.json", bindApi(api.Transactions.TransactionListAllHandler))
+			apiV1TransactionsRoute.GET("/transactions/reconciliation_statements.json", bindApi(api.Transactions.TransactionReconciliationStatementHandler))
+			apiV1TransactionsRoute.GET("/transactions/statistics.json", bindApi(api.Transactions.TransactionStatisticsHandler))
+			apiV1TransactionsRoute.GET("/transactions/statistics/trends.json", bindApi(api.Transactions.TransactionStatisticsTrendsHandler))
+			apiV1TransactionsRoute.GET("/transactions/statistics/asset_trends.json", bindApi(api.Transactions.TransactionStatisticsAssetTrendsHandler))
+			apiV1TransactionsRoute.GET("/transactions/statistics/unrealised_exchange_gain_loss.json", bindApi(api.Transactions.TransactionUnrealisedExchangeGainLossHandler))
+			apiV1TransactionsRoute.GET("/transactions/forecast.json", bindApi(api.Transactions.TransactionForecastHandler))
+			apiV1TransactionsRoute.GET("/transactions/amounts.json", bindApi(api.Transactions.TransactionAmountsHandler))
+			apiV1TransactionsRoute.GET("/transactions/get.json", bindApi(api.Transactions.TransactionGetHandler))
+			apiV1TransactionsRoute.POST("/transactions/add.json", bindApi(api.Transactions.TransactionCreateHandler))
+			apiV1TransactionsRoute.POST("/transactions/modify.json", bindApi(api.Transactions.TransactionModifyHandler))
+			apiV1TransactionsRoute.POST("/transactions/move/all.json", bindApi(api.Transactions.TransactionMoveAllBetweenAccountsHandler))
+			apiV1TransactionsRoute.POST("/transactions/delete.json", bindApi(api.Transactions.TransactionDeleteHandler))
 
 			if config.EnableDataImport {
-				apiV1Route.POST("/transactions/parse_dsv_file.json", bindApi(api.Transactions.TransactionParseImportDsvFileDataHandler))
-				apiV1Route.POST("/transactions/parse_import.json", bindApi(api.Transactions.TransactionParseImportFileHandler))
-				apiV1Route.POST("/transactions/import.json", bindApi(api.Transactions.TransactionImportHandler))
-				apiV1Route.GET("/transactions/import/process.json", bindApi(api.Transactions.TransactionImportProcessHandler))
+				apiV1TransactionsRoute.POST("/transactions/parse_dsv_file.json", bindApi(api.Transactions.TransactionParseImportDsvFileDataHandler))
+				apiV1TransactionsRoute.POST("/transactions/parse_import.json", bindApi(api.Transactions.TransactionParseImportFileHandler))
+				apiV1TransactionsRoute.POST("/transactions/import.json", bindApi(api.Transactions.TransactionImportHandler))
+				apiV1TransactionsRoute.GET("/transactions/import/process.json", bindApi(api.Transactions.TransactionImportProcessHandler))
+				apiV1TransactionsRoute.GET("/transactions/import/jobs/list.json", bindApi(api.ImportJobs.ImportJobListHandler))
+				apiV1TransactionsRoute.GET("/transactions/import/jobs/get.json", bindApi(api.ImportJobs.ImportJobGetHandler))
+				apiV1TransactionsRoute.POST("/transactions/import/jobs/add.json", bindApi(api.Transactions.TransactionImportJobCreateHandler))
+				apiV1TransactionsRoute.GET("/transactions/import/batches/list.json", bindApi(api.ImportBatches.ImportBatchListHandler))
+				apiV1TransactionsRoute.POST("/transactions/import/batches/rollback.json", bindApi(api.ImportBatches.ImportBatchRollbackHandler))
 			}
 
 			// Transaction Pictures
 			if config.EnableTransactionPictures {
-				apiV1Route.POST("/transaction/pictures/upload.json", bindApi(api.TransactionPictures.TransactionPictureUploadHandler))
-				apiV1Route.POST("/transaction/pictures/remove_unused.json", bindApi(api.TransactionPictures.TransactionPictureRemoveUnusedHandler))
+				apiV1TransactionsRoute.POST("/transaction/pictures/upload.json", bindApi(api.TransactionPictures.TransactionPictureUploadHandler))
+				apiV1TransactionsRoute.POST("/transaction/pictures/remove_unused.json", bindApi(api.TransactionPictures.TransactionPictureRemoveUnusedHandler))
 			}
 
 			// Transaction Categories
-			apiV1Route.GET("/transaction/categories/list.json", bindApi(api.TransactionCategories.CategoryListHandler))
-			apiV1Route.GET("/transaction/categories/get.json", bindApi(api.TransactionCategories.CategoryGetHandler))
-			apiV1Route.POST("/transaction/categories/add.json", bindApi(api.TransactionCategories.CategoryCreateHandler))
-			apiV1Route.POST("/transaction/categories/add_batch.json", bindApi(api.TransactionCategories.CategoryCreateBatchHandler))
-			apiV1Route.POST("/transaction/categories/modify.json", bindApi(api.TransactionCategories.CategoryModifyHandler))
-			apiV1Route.POST("/transaction/categories/hide.json", bindApi(api.TransactionCategories.CategoryHideHandler))
-			apiV1Route.POST("/transaction/categories/move.json", bindApi(api.TransactionCategories.CategoryMoveHandler))
-			apiV1Route.POST("/transaction/categories/delete.json", bindApi(api.TransactionCategories.CategoryDeleteHandler))
+			apiV1TransactionsRoute.GET("/transaction/categories/list.json", bindApi(api.TransactionCategories.CategoryListHandler))
+			apiV1TransactionsRoute.GET("/transaction/categories/get.json", bindApi(api.TransactionCategories.CategoryGetHandler))
+			apiV1TransactionsRoute.POST("/transaction/categories/add.json", bindApi(api.TransactionCategories.CategoryCreateHandler))
+			apiV1TransactionsRoute.POST("/transaction/categories/add_batch.json", bindApi(api.TransactionCategories.CategoryCreateBatchHandler))
+			apiV1TransactionsRoute.POST("/transaction/categories/modify.json", bindApi(api.TransactionCategories.CategoryModifyHandler))
+			apiV1TransactionsRoute.POST("/transaction/categories/hide.json", bindApi(api.TransactionCategories.CategoryHideHandler))
+			apiV1TransactionsRoute.POST("/transaction/categories/move.json", bindApi(api.TransactionCategories.CategoryMoveHandler))
+			apiV1TransactionsRoute.POST("/transaction/categories/delete.json", bindApi(api.TransactionCategories.CategoryDeleteHandler))
 
 			// Transaction Tag Groups
-			apiV1Route.GET("/transaction/tags/groups/list.json", bindApi(api.TransactionTagGroups.TagGroupListHandler))
-			apiV1Route.GET("/transaction/tags/groups/get.json", bindApi(api.TransactionTagGroups.TagGroupGetHandler))
-			apiV1Route.POST("/transaction/tags/groups/add.json", bindApi(api.TransactionTagGroups.TagGroupCreateHandler))
-			apiV1Route.POST("/transaction/tags/groups/modify.json", bindApi(api.TransactionTagGroups.TagGroupModifyHandler))
-			apiV1Route.POST("/transaction/tags/groups/move.json", bindApi(api.TransactionTagGroups.TagGroupMoveHandler))
-			apiV1Route.POST("/transaction/tags/groups/delete.json", bindApi(api.TransactionTagGroups.TagGroupDeleteHandler))
+			apiV1TransactionsRoute.GET("/transaction/tags/groups/list.json", bindApi(api.TransactionTagGroups.TagGroupListHandler))
+			apiV1TransactionsRoute.GET("/transaction/tags/groups/get.json", bindApi(api.TransactionTagGroups.TagGroupGetHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/groups/add.json", bindApi(api.TransactionTagGroups.TagGroupCreateHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/groups/modify.json", bindApi(api.TransactionTagGroups.TagGroupModifyHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/groups/move.json", bindApi(api.TransactionTagGroups.TagGroupMoveHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/groups/delete.json", bindApi(api.TransactionTagGroups.TagGroupDeleteHandler))
 
 			// Transaction Tags
-			apiV1Route.GET("/transaction/tags/list.json", bindApi(api.TransactionTags.TagListHandler))
-			apiV1Route.GET("/transaction/tags/get.json", bindApi(api.TransactionTags.TagGetHandler))
-			apiV1Route.POST("/transaction/tags/add.json", bindApi(api.TransactionTags.TagCreateHandler))
-			apiV1Route.POST("/transaction/tags/add_batch.json", bindApi(api.TransactionTags.TagCreateBatchHandler))
-			apiV1Route.POST("/transaction/tags/modify.json", bindApi(api.TransactionTags.TagModifyHandler))
-			apiV1Route.POST("/transaction/tags/hide.json", bindApi(api.TransactionTags.TagHideHandler))
-			apiV1Route.POST("/transaction/tags/move.json", bindApi(api.TransactionTags.TagMoveHandler))
-			apiV1Route.POST("/transaction/tags/delete.json", bindApi(api.TransactionTags.TagDeleteHandler))
+			apiV1TransactionsRoute.GET("/transaction/tags/list.json", bindApi(api.TransactionTags.TagListHandler))
+			apiV1TransactionsRoute.GET("/transaction/tags/get.json", bindApi(api.TransactionTags.TagGetHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/add.json", bindApi(api.TransactionTags.TagCreateHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/add_batch.json", bindApi(api.TransactionTags.TagCreateBatchHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/modify.json", bindApi(api.TransactionTags.TagModifyHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/hide.json", bindApi(api.TransactionTags.TagHideHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/move.json", bindApi(api.TransactionTags.TagMoveHandler))
+			apiV1TransactionsRoute.POST("/transaction/tags/delete.json", bindApi(api.TransactionTags.TagDeleteHandler))
 
 			// Transaction Item Groups
-			apiV1Route.GET("/transaction/items/groups/list.json", bindApi(api.TransactionItemGroups.ItemGroupListHandler))
-			apiV1Route.GET("/transaction/items/groups/get.json", bindApi(api.TransactionItemGroups.ItemGroupGetHandler))
-			apiV1Route.POST("/transaction/items/groups/add.json", bindApi(api.TransactionItemGroups.ItemGroupCreateHandler))
-			apiV1Route.POST("/transaction/items/groups/modify.json", bindApi(api.TransactionItemGroups.ItemGroupModifyHandler))
-			apiV1Route.POST("/transaction/items/groups/move.json", bindApi(api.TransactionItemGroups.ItemGroupMoveHandler))
-			apiV1Route.POST("/transaction/items/groups/delete.json", bindApi(api.TransactionItemGroups.ItemGroupDeleteHandler))
+			apiV1TransactionsRoute.GET("/transaction/items/groups/list.json", bindApi(api.TransactionItemGroups.ItemGroupListHandler))
+			apiV1TransactionsRoute.GET("/transaction/items/groups/get.json", bindApi(api.TransactionItemGroups.ItemGroupGetHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/groups/add.json", bindApi(api.TransactionItemGroups.ItemGroupCreateHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/groups/modify.json", bindApi(api.TransactionItemGroups.ItemGroupModifyHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/groups/move.json", bindApi(api.TransactionItemGroups.ItemGroupMoveHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/groups/delete.json", bindApi(api.TransactionItemGroups.ItemGroupDeleteHandler))
 
 			// Transaction Items
-			apiV1Route.GET("/transaction/items/list.json", bindApi(api.TransactionItems.ItemListHandler))
-			apiV1Route.GET("/transaction/items/get.json", bindApi(api.TransactionItems.ItemGetHandler))
-			apiV1Route.POST("/transaction/items/add.json", bindApi(api.TransactionItems.ItemCreateHandler))
-			apiV1Route.POST("/transaction/items/add_batch.json", bindApi(api.TransactionItems.ItemCreateBatchHandler))
-			apiV1Route.POST("/transaction/items/modify.json", bindApi(api.TransactionItems.ItemModifyHandler))
-			apiV1Route.POST("/transaction/items/hide.json", bindApi(api.TransactionItems.ItemHideHandler))
-			apiV1Route.POST("/transaction/items/move.json", bindApi(api.TransactionItems.ItemMoveHandler))
-			apiV1Route.POST("/transaction/items/delete.json", bindApi(api.TransactionItems.ItemDeleteHandler))
+			apiV1TransactionsRoute.GET("/transaction/items/list.json", bindApi(api.TransactionItems.ItemListHandler))
+			apiV1TransactionsRoute.GET("/transaction/items/get.json", bindApi(api.TransactionItems.ItemGetHandler))
+			apiV1TransactionsRoute.GET("/transaction/items/statistics.json", bindApi(api.TransactionItems.ItemStatisticsHandler))
+			apiV1TransactionsRoute.GET("/transaction/items/price_history.json", bindApi(api.TransactionItems.ItemPriceHistoryHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/add.json", bindApi(api.TransactionItems.ItemCreateHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/add_batch.json", bindApi(api.TransactionItems.ItemCreateBatchHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/modify.json", bindApi(api.TransactionItems.ItemModifyHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/hide.json", bindApi(api.TransactionItems.ItemHideHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/move.json", bindApi(api.TransactionItems.ItemMoveHandler))
+			apiV1TransactionsRoute.POST("/transaction/items/delete.json", bindApi(api.TransactionItems.ItemDeleteHandler))
 
 			// Transaction Templates
-			apiV1Route.GET("/transaction/templates/list.json", bindApi(api.TransactionTemplates.TemplateListHandler))
-			apiV1Route.GET("/transaction/templates/get.json", bindApi(api.TransactionTemplates.TemplateGetHandler))
-			apiV1Route.POST("/transaction/templates/add.json", bindApi(api.TransactionTemplates.TemplateCreateHandler))
-			apiV1Route.POST("/transaction/templates/modify.json", bindApi(api.TransactionTemplates.TemplateModifyHandler))
-			apiV1Route.POST("/transaction/templates/hide.json", bindApi(api.TransactionTemplates.TemplateHideHandler))
-			apiV1Route.POST("/transaction/templates/move.json", bindApi(api.TransactionTemplates.TemplateMoveHandler))
-			apiV1Route.POST("/transaction/templates/delete.json", bindApi(api.TransactionTemplates.TemplateDeleteHandler))
+			apiV1TransactionsRoute.GET("/transaction/templates/list.json", bindApi(api.TransactionTemplates.TemplateListHandler))
+			apiV1TransactionsRoute.GET("/transaction/templates/get.json", bindApi(api.TransactionTemplates.TemplateGetHandler))
+			apiV1TransactionsRoute.POST("/transaction/templates/add.json", bindApi(api.TransactionTemplates.TemplateCreateHandler))
+			apiV1TransactionsRoute.POST("/transaction/templates/modify.json", bindApi(api.TransactionTemplates.TemplateModifyHandler))
+			apiV1TransactionsRoute.POST("/transaction/templates/hide.json", bindApi(api.TransactionTemplates.TemplateHideHandler))
+			apiV1TransactionsRoute.POST("/transaction/templates/move.json", bindApi(api.TransactionTemplates.TemplateMoveHandler))
+			apiV1TransactionsRoute.POST("/transaction/templates/delete.json", bindApi(api.TransactionTemplates.TemplateDeleteHandler))
+
+			// Transaction Rules
+			apiV1TransactionsRoute.GET("/transaction/rules/list.json", bindApi(api.TransactionRules.RuleListHandler))
+			apiV1TransactionsRoute.GET("/transaction/rules/get.json", bindApi(api.TransactionRules.RuleGetHandler))
+			apiV1TransactionsRoute.POST("/transaction/rules/add.json", bindApi(api.TransactionRules.RuleCreateHandler))
+			apiV1TransactionsRoute.POST("/transaction/rules/modify.json", bindApi(api.TransactionRules.RuleModifyHandler))
+			apiV1TransactionsRoute.POST("/transaction/rules/move.json", bindApi(api.TransactionRules.RuleMoveHandler))
+			apiV1TransactionsRoute.POST("/transaction/rules/delete.json", bindApi(api.TransactionRules.RuleDeleteHandler))
+			apiV1TransactionsRoute.POST("/transaction/rules/apply.json", bindApi(api.TransactionRules.RuleApplyHandler))
+		}
 
+		apiV1TransactionsQueryRoute := apiRoute.Group("/v1")
+		apiV1TransactionsQueryRoute.Use(bindMiddleware(middlewares.JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ)))
+		{
 			// Insights Explorers
-			apiV1Route.GET("/insights/explorers/list.json", bindApi(api.InsightsExplorers.InsightsExplorerListHandler))
-			apiV1Route.GET("/insights/explorers/get.json", bindApi(api.InsightsExplorers.InsightsExplorerGetHandler))
-			apiV1Route.POST("/insights/explorers/add.json", bindApi(api.InsightsExplorers.InsightsExplorerCreateHandler))
-			apiV1Route.POST("/insights/explorers/modify.json", bindApi(api.InsightsExplorers.InsightsExplorerModifyHandler))
-			apiV1Route.POST("/insights/explorers/hide.json", bindApi(api.InsightsExplorers.InsightsExplorerHideHandler))
-			apiV1Route.POST("/insights/explorers/move.json", bindApi(api.InsightsExplorers.InsightsExplorerMoveHandler))
-			apiV1Route.POST("/insights/explorers/delete.json", bindApi(api.InsightsExplorers.InsightsExplorerDeleteHandler))
-
-			// Large Language Models (only OCR bill recognition is kept; AI image recognition has been removed)
-			if config.TransactionFromOCRImageRecognition {
-				apiV1Route.POST("/llm/transactions/recognize_receipt_image_ocr.json", bindApi(api.LargeLanguageModels.RecognizeReceiptImageByOCRHandler))
-			}
-
-			// Exchange Rates
-			apiV1Route.GET("/exchange_rates/latest.json", bindApi(api.ExchangeRates.LatestExchangeRateHandler))
-			apiV1Route.POST("/exchange_rates/user_custom/update.json", bindApi(api.ExchangeRates.UserCustomExchangeRateUpdateHandler))
-			apiV1Route.POST("/exchange_rates/user_custom/delete.json", bindApi(api.ExchangeRates.UserCustomExchangeRateDeleteHandler))
-
-			// System
-			apiV1Route.GET("/systems/version.json", bindApi(api.Systems.VersionHandler))
+			apiV1TransactionsQueryRoute.POST("/insights/explorers/query.json", bindApi(api.InsightsExplorers.InsightsExplorerQueryHandler))
 		}
 	}
 
@@ -652,6 +758,71 @@ func bindTsv(fn core.DataHandlerFunc) gin.HandlerFunc {
 	}
 }
 
+func bindPlainText(fn core.DataHandlerFunc) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		c := core.WrapWebContext(ginCtx)
+		result, fileName, err := fn(c)
+
+		if err != nil {
+			utils.PrintDataErrorResult(c, "text/text", err)
+		} else {
+			utils.PrintDataSuccessResult(c, "text/plain; charset=utf-8", fileName, result)
+		}
+	}
+}
+
+func bindXml(fn core.DataHandlerFunc) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		c := core.WrapWebContext(ginCtx)
+		result, fileName, err := fn(c)
+
+		if err != nil {
+			utils.PrintDataErrorResult(c, "text/text", err)
+		} else {
+			utils.PrintDataSuccessResult(c, "application/xml; charset=utf-8", fileName, result)
+		}
+	}
+}
+
+func bindZip(fn core.DataHandlerFunc) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		c := core.WrapWebContext(ginCtx)
+		result, fileName, err := fn(c)
+
+		if err != nil {
+			utils.PrintDataErrorResult(c, "text/text", err)
+		} else {
+			utils.PrintDataSuccessResult(c, "application/zip", fileName, result)
+		}
+	}
+}
+
+func bindHtml(fn core.DataHandlerFunc) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		c := core.WrapWebContext(ginCtx)
+		result, fileName, err := fn(c)
+
+		if err != nil {
+			utils.PrintDataErrorResult(c, "text/text", err)
+		} else {
+			utils.PrintDataSuccessResult(c, "text/html; charset=utf-8", fileName, result)
+		}
+	}
+}
+
+func bindPdf(fn core.DataHandlerFunc) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		c := core.WrapWebContext(ginCtx)
+		result, fileName, err := fn(c)
+
+		if err != nil {
+			utils.PrintDataErrorResult(c, "text/text", err)
+		} else {
+			utils.PrintDataSuccessResult(c, "application/pdf", fileName, result)
+		}
+	}
+}
+
 func bindImage(fn core.ImageHandlerFunc) gin.HandlerFunc {
 	return func(ginCtx *gin.Context) {
 		c := core.WrapWebContext(ginCtx)
@@ -167,6 +167,16 @@ func (a *AccountsApi) AccountCreateHandler(c *core.WebContext) (any, *errs.Error
 		return nil, errs.ErrCannotSetStatementDateForNonCreditCard
 	}
 
+	if accountCreateReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && accountCreateReq.CreditCardPaymentDueDate != 0 {
+		log.Warnf(c, "[accounts.AccountCreateHandler] cannot set payment due date with category \"%d\"", accountCreateReq.Category)
+		return nil, errs.ErrCannotSetPaymentDueDateForNonCreditCard
+	}
+
+	if accountCreateReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && accountCreateReq.CreditCardLimit != 0 {
+		log.Warnf(c, "[accounts.AccountCreateHandler] cannot set credit limit with category \"%d\"", accountCreateReq.Category)
+		return nil, errs.ErrCannotSetCreditLimitForNonCreditCard
+	}
+
 	if accountCreateReq.Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
 		if len(accountCreateReq.SubAccounts) > 0 {
 			log.Warnf(c, "[accounts.AccountCreateHandler] account cannot have any sub-accounts")
@@ -225,6 +235,16 @@ func (a *AccountsApi) AccountCreateHandler(c *core.WebContext) (any, *errs.Error
 				log.Warnf(c, "[accounts.AccountCreateHandler] sub-account#%d cannot set statement date", i)
 				return nil, errs.ErrCannotSetStatementDateForSubAccount
 			}
+
+			if subAccount.CreditCardPaymentDueDate != 0 {
+				log.Warnf(c, "[accounts.AccountCreateHandler] sub-account#%d cannot set payment due date", i)
+				return nil, errs.ErrCannotSetPaymentDueDateForSubAccount
+			}
+
+			if subAccount.CreditCardLimit != 0 {
+				log.Warnf(c, "[accounts.AccountCreateHandler] sub-account#%d cannot set credit limit", i)
+				return nil, errs.ErrCannotSetCreditLimitForSubAccount
+			}
 		}
 	} else {
 		log.Warnf(c, "[accounts.AccountCreateHandler] account type invalid, type is %d", accountCreateReq.Type)
@@ -332,6 +352,16 @@ func (a *AccountsApi) AccountModifyHandler(c *core.WebContext) (any, *errs.Error
 		return nil, errs.ErrCannotSetStatementDateForNonCreditCard
 	}
 
+	if accountModifyReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && accountModifyReq.CreditCardPaymentDueDate != 0 {
+		log.Warnf(c, "[accounts.AccountModifyHandler] cannot set payment due date with category \"%d\"", accountModifyReq.Category)
+		return nil, errs.ErrCannotSetPaymentDueDateForNonCreditCard
+	}
+
+	if accountModifyReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && accountModifyReq.CreditCardLimit != 0 {
+		log.Warnf(c, "[accounts.AccountModifyHandler] cannot set credit limit with category \"%d\"", accountModifyReq.Category)
+		return nil, errs.ErrCannotSetCreditLimitForNonCreditCard
+	}
+
 	uid := c.GetCurrentUid()
 	accountAndSubAccounts, err := a.accounts.GetAccountAndSubAccountsByAccountId(c, uid, accountModifyReq.Id)
 
@@ -425,6 +455,16 @@ func (a *AccountsApi) AccountModifyHandler(c *core.WebContext) (any, *errs.Error
 				log.Warnf(c, "[accounts.AccountModifyHandler] sub-account#%d cannot set statement date", i)
 				return nil, errs.ErrCannotSetStatementDateForSubAccount
 			}
+
+			if subAccountReq.CreditCardPaymentDueDate != 0 {
+				log.Warnf(c, "[accounts.AccountModifyHandler] sub-account#%d cannot set payment due date", i)
+				return nil, errs.ErrCannotSetPaymentDueDateForSubAccount
+			}
+
+			if subAccountReq.CreditCardLimit != 0 {
+				log.Warnf(c, "[accounts.AccountModifyHandler] sub-account#%d cannot set credit limit", i)
+				return nil, errs.ErrCannotSetCreditLimitForSubAccount
+			}
 		}
 	}
 
@@ -713,6 +753,8 @@ func (a *AccountsApi) createNewAccountModel(uid int64, accountCreateReq *models.
 
 	if !isSubAccount && accountCreateReq.Category == models.ACCOUNT_CATEGORY_CREDIT_CARD {
 		accountExtend.CreditCardStatementDate = &accountCreateReq.CreditCardStatementDate
+		accountExtend.CreditCardPaymentDueDate = &accountCreateReq.CreditCardPaymentDueDate
+		accountExtend.CreditCardLimit = &accountCreateReq.CreditCardLimit
 	}
 
 	return &models.Account{
@@ -769,6 +811,8 @@ func (a *AccountsApi) getToUpdateAccount(uid int64, accountModifyReq *models.Acc
 
 	if !isSubAccount && accountModifyReq.Category == models.ACCOUNT_CATEGORY_CREDIT_CARD {
 		newAccountExtend.CreditCardStatementDate = &accountModifyReq.CreditCardStatementDate
+		newAccountExtend.CreditCardPaymentDueDate = &accountModifyReq.CreditCardPaymentDueDate
+		newAccountExtend.CreditCardLimit = &accountModifyReq.CreditCardLimit
 	}
 
 	newAccount := &models.Account{
@@ -804,9 +848,30 @@ func (a *AccountsApi) getToUpdateAccount(uid int64, accountModifyReq *models.Acc
 		return newAccount
 	}
 
+	if !a.isIntPointerValueEquals(newAccountExtend.CreditCardPaymentDueDate, oldAccountExtend.CreditCardPaymentDueDate) ||
+		!a.isInt64PointerValueEquals(newAccountExtend.CreditCardLimit, oldAccountExtend.CreditCardLimit) {
+		return newAccount
+	}
+
 	return nil
 }
 
+func (a *AccountsApi) isIntPointerValueEquals(value1 *int, value2 *int) bool {
+	if value1 == nil || value2 == nil {
+		return value1 == value2
+	}
+
+	return *value1 == *value2
+}
+
+func (a *AccountsApi) isInt64PointerValueEquals(value1 *int64, value2 *int64) bool {
+	if value1 == nil || value2 == nil {
+		return value1 == value2
+	}
+
+	return *value1 == *value2
+}
+
 func (a *AccountsApi) getToDeleteSubAccountIds(accountModifyReq *models.AccountModifyRequest, mainAccount *models.Account, accountAndSubAccounts []*models.Account) []int64 {
 	newSubAccountIds := make(map[int64]bool, len(accountModifyReq.SubAccounts))
 
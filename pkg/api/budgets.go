@@ -0,0 +1,434 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const pageCountForBudgetProgress = 1000
+
+// BudgetsApi represents budget api
+type BudgetsApi struct {
+	ApiUsingConfig
+	ApiUsingDuplicateChecker
+	budgets               *services.BudgetService
+	users                 *services.UserService
+	accounts              *services.AccountService
+	transactions          *services.TransactionService
+	transactionCategories *services.TransactionCategoryService
+}
+
+// Initialize a budget api singleton instance
+var (
+	Budgets = &BudgetsApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		ApiUsingDuplicateChecker: ApiUsingDuplicateChecker{
+			ApiUsingConfig: ApiUsingConfig{
+				container: settings.Container,
+			},
+			container: duplicatechecker.Container,
+		},
+		budgets:               services.Budgets,
+		users:                 services.Users,
+		accounts:              services.Accounts,
+		transactions:          services.Transactions,
+		transactionCategories: services.TransactionCategories,
+	}
+)
+
+// BudgetListHandler returns budget list of current user
+func (a *BudgetsApi) BudgetListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	budgets, err := a.budgets.GetAllBudgetsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetListHandler] failed to get budgets for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	budgetResps := make(models.BudgetInfoResponseSlice, len(budgets))
+
+	for i := 0; i < len(budgets); i++ {
+		budgetResps[i] = budgets[i].ToBudgetInfoResponse()
+	}
+
+	sort.Sort(budgetResps)
+
+	return budgetResps, nil
+}
+
+// BudgetGetHandler returns one specific budget of current user
+func (a *BudgetsApi) BudgetGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetGetReq models.BudgetGetRequest
+	err := c.ShouldBindQuery(&budgetGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	budget, err := a.budgets.GetBudgetByBudgetId(c, uid, budgetGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetGetHandler] failed to get budget \"id:%d\" for user \"uid:%d\", because %s", budgetGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return budget.ToBudgetInfoResponse(), nil
+}
+
+// BudgetCreateHandler saves a new budget by request parameters for current user
+func (a *BudgetsApi) BudgetCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetCreateReq models.BudgetCreateRequest
+	err := c.ShouldBindJSON(&budgetCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !budgetCreateReq.PeriodType.IsValid() {
+		log.Warnf(c, "[budgets.BudgetCreateHandler] budget period type invalid, type is %d", budgetCreateReq.PeriodType)
+		return nil, errs.ErrBudgetPeriodTypeInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.checkBudgetCategory(c, uid, budgetCreateReq.CategoryId)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetCreateHandler] budget category \"id:%d\" is invalid for user \"uid:%d\", because %s", budgetCreateReq.CategoryId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	maxOrderId, err := a.budgets.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetCreateHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	budget := a.createNewBudgetModel(uid, &budgetCreateReq, maxOrderId+1)
+
+	if a.CurrentConfig().EnableDuplicateSubmissionsCheck && budgetCreateReq.ClientSessionId != "" {
+		found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_BUDGET, uid, budgetCreateReq.ClientSessionId)
+
+		if found {
+			log.Infof(c, "[budgets.BudgetCreateHandler] another budget \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
+			budgetId, err := utils.StringToInt64(remark)
+
+			if err == nil {
+				budget, err = a.budgets.GetBudgetByBudgetId(c, uid, budgetId)
+
+				if err != nil {
+					log.Errorf(c, "[budgets.BudgetCreateHandler] failed to get existed budget \"id:%d\" for user \"uid:%d\", because %s", budgetId, uid, err.Error())
+					return nil, errs.Or(err, errs.ErrOperationFailed)
+				}
+
+				return budget.ToBudgetInfoResponse(), nil
+			}
+		}
+	}
+
+	err = a.budgets.CreateBudget(c, budget)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetCreateHandler] failed to create budget \"id:%d\" for user \"uid:%d\", because %s", budget.BudgetId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetCreateHandler] user \"uid:%d\" has created a new budget \"id:%d\" successfully", uid, budget.BudgetId)
+
+	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_BUDGET, uid, budgetCreateReq.ClientSessionId, utils.Int64ToString(budget.BudgetId))
+
+	return budget.ToBudgetInfoResponse(), nil
+}
+
+// BudgetModifyHandler saves an existed budget by request parameters for current user
+func (a *BudgetsApi) BudgetModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetModifyReq models.BudgetModifyRequest
+	err := c.ShouldBindJSON(&budgetModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !budgetModifyReq.PeriodType.IsValid() {
+		log.Warnf(c, "[budgets.BudgetModifyHandler] budget period type invalid, type is %d", budgetModifyReq.PeriodType)
+		return nil, errs.ErrBudgetPeriodTypeInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	budget, err := a.budgets.GetBudgetByBudgetId(c, uid, budgetModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetModifyHandler] failed to get budget \"id:%d\" for user \"uid:%d\", because %s", budgetModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if budgetModifyReq.CategoryId != budget.CategoryId {
+		err = a.checkBudgetCategory(c, uid, budgetModifyReq.CategoryId)
+
+		if err != nil {
+			log.Warnf(c, "[budgets.BudgetModifyHandler] budget category \"id:%d\" is invalid for user \"uid:%d\", because %s", budgetModifyReq.CategoryId, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	newBudget := &models.Budget{
+		BudgetId:       budget.BudgetId,
+		Uid:            uid,
+		Name:           budgetModifyReq.Name,
+		CategoryId:     budgetModifyReq.CategoryId,
+		PeriodType:     budgetModifyReq.PeriodType,
+		Amount:         budgetModifyReq.Amount,
+		Currency:       budgetModifyReq.Currency,
+		EnableRollover: budgetModifyReq.EnableRollover,
+		StartTime:      budget.StartTime,
+		Comment:        budgetModifyReq.Comment,
+		Hidden:         budgetModifyReq.Hidden,
+	}
+
+	if newBudget.Name == budget.Name &&
+		newBudget.CategoryId == budget.CategoryId &&
+		newBudget.PeriodType == budget.PeriodType &&
+		newBudget.Amount == budget.Amount &&
+		newBudget.Currency == budget.Currency &&
+		newBudget.EnableRollover == budget.EnableRollover &&
+		newBudget.Comment == budget.Comment &&
+		newBudget.Hidden == budget.Hidden {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	if newBudget.PeriodType != budget.PeriodType || (newBudget.EnableRollover && !budget.EnableRollover) {
+		newBudget.StartTime = time.Now().Unix()
+	}
+
+	err = a.budgets.ModifyBudget(c, newBudget)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetModifyHandler] failed to update budget \"id:%d\" for user \"uid:%d\", because %s", budgetModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetModifyHandler] user \"uid:%d\" has updated budget \"id:%d\" successfully", uid, budgetModifyReq.Id)
+
+	newBudget.DisplayOrder = budget.DisplayOrder
+
+	return newBudget.ToBudgetInfoResponse(), nil
+}
+
+// BudgetHideHandler hides a budget by request parameters for current user
+func (a *BudgetsApi) BudgetHideHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetHideReq models.BudgetHideRequest
+	err := c.ShouldBindJSON(&budgetHideReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetHideHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.budgets.HideBudget(c, uid, []int64{budgetHideReq.Id}, budgetHideReq.Hidden)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetHideHandler] failed to hide budget \"id:%d\" for user \"uid:%d\", because %s", budgetHideReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetHideHandler] user \"uid:%d\" has hidden budget \"id:%d\"", uid, budgetHideReq.Id)
+	return true, nil
+}
+
+// BudgetMoveHandler moves display order of existed budgets by request parameters for current user
+func (a *BudgetsApi) BudgetMoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetMoveReq models.BudgetMoveRequest
+	err := c.ShouldBindJSON(&budgetMoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetMoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	budgets := make([]*models.Budget, len(budgetMoveReq.NewDisplayOrders))
+
+	for i := 0; i < len(budgetMoveReq.NewDisplayOrders); i++ {
+		newDisplayOrder := budgetMoveReq.NewDisplayOrders[i]
+		budget := &models.Budget{
+			Uid:          uid,
+			BudgetId:     newDisplayOrder.Id,
+			DisplayOrder: newDisplayOrder.DisplayOrder,
+		}
+
+		budgets[i] = budget
+	}
+
+	err = a.budgets.ModifyBudgetDisplayOrders(c, uid, budgets)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetMoveHandler] failed to move budgets for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetMoveHandler] user \"uid:%d\" has moved budgets", uid)
+	return true, nil
+}
+
+// BudgetDeleteHandler deletes an existed budget by request parameters for current user
+func (a *BudgetsApi) BudgetDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetDeleteReq models.BudgetDeleteRequest
+	err := c.ShouldBindJSON(&budgetDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.budgets.DeleteBudget(c, uid, budgetDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetDeleteHandler] failed to delete budget \"id:%d\" for user \"uid:%d\", because %s", budgetDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetDeleteHandler] user \"uid:%d\" has deleted budget \"id:%d\"", uid, budgetDeleteReq.Id)
+	return true, nil
+}
+
+// BudgetProgressHandler returns the spent and remaining amount of one specific budget in current period of current user
+func (a *BudgetsApi) BudgetProgressHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetProgressReq models.BudgetProgressRequest
+	err := c.ShouldBindQuery(&budgetProgressReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetProgressHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetProgressHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := getCurrentDataUser(c, a.users)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[budgets.BudgetProgressHandler] failed to get user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	budget, err := a.budgets.GetBudgetByBudgetId(c, uid, budgetProgressReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetProgressHandler] failed to get budget \"id:%d\" for user \"uid:%d\", because %s", budgetProgressReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	currentTime := time.Now().In(clientTimezone)
+
+	if budgetProgressReq.Time > 0 {
+		currentTime = time.Unix(budgetProgressReq.Time, 0).In(clientTimezone)
+	}
+
+	categoryIds, err := a.budgets.GetBudgetCategoryIds(c, uid, budget)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetProgressHandler] get transaction category ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetProgressHandler] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	exchangeRateResponse, err := exchangerates.Container.GetLatestExchangeRates(c, c.GetCurrentOperatorUid(), a.CurrentConfig())
+	exchangeRateMap := models.ExchangeRateMap{}
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetProgressHandler] failed to get latest exchange rates for user \"uid:%d\", amounts in other currencies will be ignored, because %s", uid, err.Error())
+	} else if exchangeRateResponse != nil {
+		exchangeRateMap = exchangeRateResponse.ToExchangeRateMap()
+	}
+
+	periodStartTimes := a.budgets.GetBudgetPeriodStartTimes(budget, user.FirstDayOfWeek, user.FiscalYearStart, currentTime)
+	_, periodEndTime := budget.PeriodType.GetPeriodRange(currentTime, user.FirstDayOfWeek, user.FiscalYearStart)
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(periodStartTimes[0].Unix())
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(periodEndTime.Unix() - 1)
+
+	// the transactions are not filtered by categories, because the split lines of split transactions may be in the budget categories
+	transactions, err := a.transactions.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, models.TRANSACTION_TYPE_EXPENSE, nil, nil, nil, false, nil, false, "", "", pageCountForBudgetProgress, true)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetProgressHandler] failed to get transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	allSplits, err := a.transactions.GetTransactionSplitsMapByTimeRange(c, uid, maxTransactionTime, minTransactionTime)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetProgressHandler] failed to get transaction split lines for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accountMap := a.accounts.GetAccountMapByList(accounts)
+	budgetProgressResp := a.budgets.GetBudgetProgress(budget, transactions, allSplits, accountMap, categoryIds, exchangeRateMap, user.FirstDayOfWeek, user.FiscalYearStart, currentTime)
+
+	return budgetProgressResp, nil
+}
+
+func (a *BudgetsApi) checkBudgetCategory(c *core.WebContext, uid int64, categoryId int64) error {
+	if categoryId == models.BudgetAllExpenseCategoriesId {
+		return nil
+	}
+
+	category, err := a.transactionCategories.GetCategoryByCategoryId(c, uid, categoryId)
+
+	if err != nil {
+		return err
+	}
+
+	if category.Type != models.CATEGORY_TYPE_EXPENSE {
+		return errs.ErrBudgetCategoryInvalid
+	}
+
+	return nil
+}
+
+func (a *BudgetsApi) createNewBudgetModel(uid int64, budgetCreateReq *models.BudgetCreateRequest, order int32) *models.Budget {
+	return &models.Budget{
+		Uid:            uid,
+		Name:           budgetCreateReq.Name,
+		CategoryId:     budgetCreateReq.CategoryId,
+		PeriodType:     budgetCreateReq.PeriodType,
+		Amount:         budgetCreateReq.Amount,
+		Currency:       budgetCreateReq.Currency,
+		EnableRollover: budgetCreateReq.EnableRollover,
+		StartTime:      time.Now().Unix(),
+		Comment:        budgetCreateReq.Comment,
+		DisplayOrder:   order,
+	}
+}
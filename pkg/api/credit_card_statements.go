@@ -0,0 +1,91 @@
+package api
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// CreditCardStatementsApi represents credit card statement api
+type CreditCardStatementsApi struct {
+	statements *services.CreditCardStatementService
+	accounts   *services.AccountService
+}
+
+// Initialize a credit card statement api singleton instance
+var (
+	CreditCardStatements = &CreditCardStatementsApi{
+		statements: services.CreditCardStatements,
+		accounts:   services.Accounts,
+	}
+)
+
+// StatementListHandler returns the latest closed statements of specified credit card account of current user
+func (a *CreditCardStatementsApi) StatementListHandler(c *core.WebContext) (any, *errs.Error) {
+	var statementListReq models.CreditCardStatementListRequest
+	err := c.ShouldBindQuery(&statementListReq)
+
+	if err != nil {
+		log.Warnf(c, "[credit_card_statements.StatementListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[credit_card_statements.StatementListHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	account, err := a.accounts.GetAccountByAccountId(c, uid, statementListReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[credit_card_statements.StatementListHandler] failed to get account \"id:%d\" for user \"uid:%d\", because %s", statementListReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	count := int(statementListReq.Count)
+
+	if count < 1 {
+		count = models.DefaultCreditCardStatementCount
+	}
+
+	statements, err := a.statements.GetLatestStatements(c, account, count, time.Now().In(clientTimezone))
+
+	if err != nil {
+		log.Errorf(c, "[credit_card_statements.StatementListHandler] failed to get statements of account \"id:%d\" for user \"uid:%d\", because %s", statementListReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	statementResps := make([]*models.CreditCardStatementResponse, len(statements))
+
+	for i := 0; i < len(statements); i++ {
+		statementResps[i] = statements[i].ToCreditCardStatementResponse()
+	}
+
+	return statementResps, nil
+}
+
+// OverdueStatementListHandler returns all credit card statements of current user which are flagged as overdue
+func (a *CreditCardStatementsApi) OverdueStatementListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	overdueStatements, err := a.statements.GetAllOverdueStatementsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[credit_card_statements.OverdueStatementListHandler] failed to get overdue statements for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	overdueStatementResps := make([]*models.CreditCardOverdueStatementResponse, len(overdueStatements))
+
+	for i := 0; i < len(overdueStatements); i++ {
+		overdueStatementResps[i] = overdueStatements[i].ToCreditCardOverdueStatementResponse()
+	}
+
+	return overdueStatementResps, nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"strings"
 	"time"
@@ -33,8 +34,14 @@ type DataManagementsApi struct {
 	itemGroups              *services.TransactionItemGroupService
 	pictures                *services.TransactionPictureService
 	templates               *services.TransactionTemplateService
+	rules                   *services.TransactionRuleService
+	creditCardStatements    *services.CreditCardStatementService
 	userCustomExchangeRates *services.UserCustomExchangeRatesService
+	exchangeRateHistories   *services.ExchangeRateHistoryService
 	insightsExploreres      *services.InsightsExplorerService
+	budgets                 *services.BudgetService
+	webhooks                *services.WebhookService
+	dataArchives            *services.DataArchiveService
 }
 
 // Initialize a data management api singleton instance
@@ -54,8 +61,14 @@ var (
 		itemGroups:              services.TransactionItemGroups,
 		pictures:                services.TransactionPictures,
 		templates:               services.TransactionTemplates,
+		rules:                   services.TransactionRules,
+		creditCardStatements:    services.CreditCardStatements,
 		userCustomExchangeRates: services.UserCustomExchangeRates,
+		exchangeRateHistories:   services.ExchangeRateHistories,
 		insightsExploreres:      services.InsightsExplorers,
+		budgets:                 services.Budgets,
+		webhooks:                services.Webhooks,
+		dataArchives:            services.DataArchives,
 	}
 )
 
@@ -69,6 +82,138 @@ func (a *DataManagementsApi) ExportDataToEzbookkeepingTSVHandler(c *core.WebCont
 	return a.getExportedFileContent(c, "tsv")
 }
 
+// ExportDataToBeancountHandler returns exported data in beancount format
+func (a *DataManagementsApi) ExportDataToBeancountHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getExportedFileContent(c, "beancount")
+}
+
+// ExportDataToQifHandler returns exported data in quicken interchange format
+func (a *DataManagementsApi) ExportDataToQifHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getExportedFileContent(c, "qif")
+}
+
+// ExportDataToOFXHandler returns exported data in open financial exchange format
+func (a *DataManagementsApi) ExportDataToOFXHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getExportedFileContent(c, "ofx")
+}
+
+// ExportDataToGnuCashHandler returns exported data in gnucash xml format
+func (a *DataManagementsApi) ExportDataToGnuCashHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getExportedFileContent(c, "gnucash")
+}
+
+// ExportDataToArchiveHandler returns exported ezbookkeeping data archive which contains all book data and transaction pictures
+func (a *DataManagementsApi) ExportDataToArchiveHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	if !a.CurrentConfig().EnableDataExport {
+		return nil, "", errs.ErrDataExportNotAllowed
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[data_managements.ExportDataToArchiveHandler] cannot get client timezone, because %s", err.Error())
+		clientTimezone = time.Local
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[data_managements.ExportDataToArchiveHandler] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, "", errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_EXPORT_TRANSACTION) {
+		return nil, "", errs.ErrNotPermittedToPerformThisAction
+	}
+
+	result, err := a.dataArchives.ExportDataArchive(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ExportDataToArchiveHandler] failed to export data archive for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	fileName := a.getFileName(user, clientTimezone, "zip")
+
+	return result, fileName, nil
+}
+
+// ImportDataArchiveHandler imports all book data and transaction pictures in the ezbookkeeping data archive into the empty book of current user
+func (a *DataManagementsApi) ImportDataArchiveHandler(c *core.WebContext) (any, *errs.Error) {
+	if !a.CurrentConfig().EnableDataImport {
+		return nil, errs.ErrDataImportNotAllowed
+	}
+
+	uid := c.GetCurrentUid()
+	form, err := c.MultipartForm()
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ImportDataArchiveHandler] failed to get multi-part form data for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrParameterInvalid
+	}
+
+	importFiles := form.File["file"]
+
+	if len(importFiles) < 1 {
+		log.Warnf(c, "[data_managements.ImportDataArchiveHandler] there is no import file in request for user \"uid:%d\"", uid)
+		return nil, errs.ErrNoFilesUpload
+	}
+
+	if importFiles[0].Size < 1 {
+		log.Warnf(c, "[data_managements.ImportDataArchiveHandler] the size of import file in request is zero for user \"uid:%d\"", uid)
+		return nil, errs.ErrUploadedFileEmpty
+	}
+
+	if importFiles[0].Size > int64(a.CurrentConfig().MaxImportFileSize) {
+		log.Warnf(c, "[data_managements.ImportDataArchiveHandler] the upload file size \"%d\" exceeds the maximum size \"%d\" of import file for user \"uid:%d\"", importFiles[0].Size, a.CurrentConfig().MaxImportFileSize, uid)
+		return nil, errs.ErrExceedMaxUploadFileSize
+	}
+
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[data_managements.ImportDataArchiveHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_IMPORT_TRANSACTION) {
+		return nil, errs.ErrNotPermittedToPerformThisAction
+	}
+
+	importFile, err := importFiles[0].Open()
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ImportDataArchiveHandler] failed to get import file from request for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	defer importFile.Close()
+	fileData, err := io.ReadAll(importFile)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ImportDataArchiveHandler] failed to read import file data for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	result, err := a.dataArchives.ImportDataArchive(c, uid, fileData)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ImportDataArchiveHandler] failed to import data archive for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[data_managements.ImportDataArchiveHandler] user \"uid:%d\" has imported %d accounts and %d transactions from data archive", uid, result.AccountCount, result.TransactionCount)
+
+	return result, nil
+}
+
 // DataStatisticsHandler returns user data statistics
 func (a *DataManagementsApi) DataStatisticsHandler(c *core.WebContext) (any, *errs.Error) {
 	uid := c.GetCurrentUid()
@@ -186,6 +331,20 @@ func (a *DataManagementsApi) ClearAllDataHandler(c *core.WebContext) (any, *errs
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	err = a.rules.DeleteAllRules(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all transaction rules, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.creditCardStatements.DeleteAllOverdueStatements(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all credit card overdue statements, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	err = a.transactions.DeleteAllTransactions(c, uid, true)
 
 	if err != nil {
@@ -242,6 +401,13 @@ func (a *DataManagementsApi) ClearAllDataHandler(c *core.WebContext) (any, *errs
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	err = a.exchangeRateHistories.DeleteAllExchangeRatesHistory(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all exchange rates history, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	err = a.insightsExploreres.DeleteAllInsightsExplorers(c, uid)
 
 	if err != nil {
@@ -249,6 +415,20 @@ func (a *DataManagementsApi) ClearAllDataHandler(c *core.WebContext) (any, *errs
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	err = a.budgets.DeleteAllBudgets(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all budgets, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.webhooks.DeleteAllWebhooks(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all webhooks, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	log.Infof(c, "[data_managements.ClearAllDataHandler] user \"uid:%d\" has cleared all data", uid)
 	return true, nil
 }
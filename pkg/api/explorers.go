@@ -8,18 +8,28 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
 )
 
 // InsightsExplorersApi represents insights explorers api
 type InsightsExplorersApi struct {
-	insightsExploreres *services.InsightsExplorerService
+	ApiUsingConfig
+	insightsExploreres      *services.InsightsExplorerService
+	users                   *services.UserService
+	insightsExplorerQueries *reports.InsightsExplorerQueryExecutor
 }
 
 // Initialize a insights explorers api singleton instance
 var (
 	InsightsExplorers = &InsightsExplorersApi{
-		insightsExploreres: services.InsightsExplorers,
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		insightsExploreres:      services.InsightsExplorers,
+		users:                   services.Users,
+		insightsExplorerQueries: reports.InsightsExplorerQueries,
 	}
 )
 
@@ -77,6 +87,78 @@ func (a *InsightsExplorersApi) InsightsExplorerGetHandler(c *core.WebContext) (a
 	return explorerResp, nil
 }
 
+// InsightsExplorerQueryHandler returns the aggregated result of one saved insights explorer or the specified explorer data of current user
+func (a *InsightsExplorersApi) InsightsExplorerQueryHandler(c *core.WebContext) (any, *errs.Error) {
+	var explorerQueryReq models.InsightsExplorerQueryRequest
+	err := c.ShouldBindJSON(&explorerQueryReq)
+
+	if err != nil {
+		log.Warnf(c, "[explorers.InsightsExplorerQueryHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if explorerQueryReq.EndTime > 0 && explorerQueryReq.StartTime > explorerQueryReq.EndTime {
+		return nil, errs.ErrInsightsExplorerQueryTimeRangeInvalid
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[explorers.InsightsExplorerQueryHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := getCurrentDataUser(c, a.users)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[explorers.InsightsExplorerQueryHandler] failed to get user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	var definition *models.InsightsExplorerQueryDefinition
+
+	if explorerQueryReq.Id > 0 {
+		var explorer *models.InsightsExplorer
+		explorer, err = a.insightsExploreres.GetInsightsExplorerByExplorerId(c, uid, explorerQueryReq.Id)
+
+		if err != nil {
+			log.Errorf(c, "[explorers.InsightsExplorerQueryHandler] failed to get insights explorer \"id:%d\" for user \"uid:%d\", because %s", explorerQueryReq.Id, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		definition, err = explorer.GetQueryDefinition()
+	} else if explorerQueryReq.Data != nil {
+		definition, err = models.ParseInsightsExplorerQueryDefinitionFromMap(explorerQueryReq.Data)
+	} else {
+		return nil, errs.ErrInsightsExplorerQueryNotSpecified
+	}
+
+	if err != nil {
+		log.Warnf(c, "[explorers.InsightsExplorerQueryHandler] failed to parse insights explorer data for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrInsightsExplorerDataInvalid)
+	}
+
+	err = definition.SetDimensionsAndValueMetric(explorerQueryReq.CategoryDimension, explorerQueryReq.SeriesDimension, explorerQueryReq.ValueMetric)
+
+	if err != nil {
+		log.Warnf(c, "[explorers.InsightsExplorerQueryHandler] invalid dimensions or value metric for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrInsightsExplorerDataInvalid)
+	}
+
+	result, err := a.insightsExplorerQueries.ExecuteQuery(c, user, definition, explorerQueryReq.StartTime, explorerQueryReq.EndTime, clientTimezone, a.CurrentConfig())
+
+	if err != nil {
+		log.Errorf(c, "[explorers.InsightsExplorerQueryHandler] failed to execute insights explorer query for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return result, nil
+}
+
 // InsightsExplorerCreateHandler saves a new insights explorer by request parameters for current user
 func (a *InsightsExplorersApi) InsightsExplorerCreateHandler(c *core.WebContext) (any, *errs.Error) {
 	var explorerCreateReq models.InsightsExplorerCreateRequest
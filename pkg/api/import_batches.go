@@ -0,0 +1,124 @@
+package api
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+const defaultImportBatchListCount = 20
+
+// ImportBatchesApi represents import batch api
+type ImportBatchesApi struct {
+	importBatches *services.ImportBatchService
+	users         *services.UserService
+}
+
+// Initialize an import batch api singleton instance
+var (
+	ImportBatches = &ImportBatchesApi{
+		importBatches: services.ImportBatches,
+		users:         services.Users,
+	}
+)
+
+// ImportBatchListHandler returns the latest import batch list of current user
+func (a *ImportBatchesApi) ImportBatchListHandler(c *core.WebContext) (any, *errs.Error) {
+	var importBatchListReq models.ImportBatchListRequest
+	err := c.ShouldBindQuery(&importBatchListReq)
+
+	if err != nil {
+		log.Warnf(c, "[import_batches.ImportBatchListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if importBatchListReq.Count < 1 {
+		importBatchListReq.Count = defaultImportBatchListCount
+	}
+
+	uid := c.GetCurrentUid()
+	importBatches, err := a.importBatches.GetLatestImportBatchesByUid(c, uid, importBatchListReq.Count)
+
+	if err != nil {
+		log.Errorf(c, "[import_batches.ImportBatchListHandler] failed to get import batches for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	importBatchResps := make([]*models.ImportBatchInfoResponse, len(importBatches))
+
+	for i := 0; i < len(importBatches); i++ {
+		importBatchResps[i] = importBatches[i].ToImportBatchInfoResponse()
+	}
+
+	return importBatchResps, nil
+}
+
+// ImportBatchRollbackHandler deletes all transactions imported in the specified import batch for current user
+func (a *ImportBatchesApi) ImportBatchRollbackHandler(c *core.WebContext) (any, *errs.Error) {
+	var importBatchRollbackReq models.ImportBatchRollbackRequest
+	err := c.ShouldBindJSON(&importBatchRollbackReq)
+
+	if err != nil {
+		log.Warnf(c, "[import_batches.ImportBatchRollbackHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[import_batches.ImportBatchRollbackHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := getCurrentDataUser(c, a.users)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[import_batches.ImportBatchRollbackHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_IMPORT_TRANSACTION) {
+		return nil, errs.ErrNotPermittedToPerformThisAction
+	}
+
+	importBatch, err := a.importBatches.GetImportBatchByBatchId(c, uid, importBatchRollbackReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[import_batches.ImportBatchRollbackHandler] failed to get import batch \"id:%d\" for user \"uid:%d\", because %s", importBatchRollbackReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if importBatch.RolledBack {
+		return nil, errs.ErrImportBatchAlreadyRolledBack
+	}
+
+	transactions, err := a.importBatches.GetImportedTransactionsByImportBatch(c, uid, importBatch)
+
+	if err != nil {
+		log.Errorf(c, "[import_batches.ImportBatchRollbackHandler] failed to get transactions of import batch \"id:%d\" for user \"uid:%d\", because %s", importBatch.BatchId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		if !user.CanEditTransactionByTransactionTime(transactions[i].TransactionTime, clientTimezone) {
+			return nil, errs.ErrCannotDeleteTransactionWithThisTransactionTime
+		}
+	}
+
+	result, err := a.importBatches.RollbackImportBatch(c, uid, importBatch.BatchId)
+
+	if err != nil {
+		log.Errorf(c, "[import_batches.ImportBatchRollbackHandler] failed to roll back import batch \"id:%d\" for user \"uid:%d\", because %s", importBatch.BatchId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[import_batches.ImportBatchRollbackHandler] user \"uid:%d\" has rolled back import batch \"id:%d\", %d transactions, %d accounts, %d categories and %d tags are deleted", uid, importBatch.BatchId, result.DeletedTransactionCount, result.DeletedAccountCount, result.DeletedCategoryCount, result.DeletedTagCount)
+
+	return result, nil
+}
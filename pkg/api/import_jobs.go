@@ -0,0 +1,75 @@
+package api
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+const defaultImportJobListCount = 20
+
+// ImportJobsApi represents import job api
+type ImportJobsApi struct {
+	importJobs *services.ImportJobService
+}
+
+// Initialize an import job api singleton instance
+var (
+	ImportJobs = &ImportJobsApi{
+		importJobs: services.ImportJobs,
+	}
+)
+
+// ImportJobListHandler returns the latest import job list of current user
+func (a *ImportJobsApi) ImportJobListHandler(c *core.WebContext) (any, *errs.Error) {
+	var importJobListReq models.ImportJobListRequest
+	err := c.ShouldBindQuery(&importJobListReq)
+
+	if err != nil {
+		log.Warnf(c, "[import_jobs.ImportJobListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if importJobListReq.Count < 1 {
+		importJobListReq.Count = defaultImportJobListCount
+	}
+
+	uid := c.GetCurrentUid()
+	importJobs, err := a.importJobs.GetLatestImportJobsByUid(c, uid, importJobListReq.Count)
+
+	if err != nil {
+		log.Errorf(c, "[import_jobs.ImportJobListHandler] failed to get import jobs for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	importJobResps := make([]*models.ImportJobInfoResponse, len(importJobs))
+
+	for i := 0; i < len(importJobs); i++ {
+		importJobResps[i] = importJobs[i].ToImportJobInfoResponse()
+	}
+
+	return importJobResps, nil
+}
+
+// ImportJobGetHandler returns the status and process of the specified import job of current user
+func (a *ImportJobsApi) ImportJobGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var importJobGetReq models.ImportJobGetRequest
+	err := c.ShouldBindQuery(&importJobGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[import_jobs.ImportJobGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	importJob, err := a.importJobs.GetImportJobByJobId(c, uid, importJobGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[import_jobs.ImportJobGetHandler] failed to get import job \"id:%d\" for user \"uid:%d\", because %s", importJobGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return importJob.ToImportJobInfoResponse(), nil
+}
@@ -1,26 +1,48 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/llm"
+	"github.com/mayswind/ezbookkeeping/pkg/llm/data"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/ocr"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/templates"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
+type recognizedTransactionNameMaps struct {
+	accounts            []*models.Account
+	categories          []*models.TransactionCategory
+	tags                []*models.TransactionTag
+	accountMap          map[string]*models.Account
+	expenseCategoryMap  map[string]*models.TransactionCategory
+	incomeCategoryMap   map[string]*models.TransactionCategory
+	transferCategoryMap map[string]*models.TransactionCategory
+	tagMap              map[string]*models.TransactionTag
+	itemNameMap         map[string]*models.TransactionItem
+}
+
 // LargeLanguageModelsApi represents large language models api
 type LargeLanguageModelsApi struct {
 	ApiUsingConfig
 	transactionCategories *services.TransactionCategoryService
 	transactionTags       *services.TransactionTagService
 	transactionItems      *services.TransactionItemService
+	transactionRules      *services.TransactionRuleService
+	transactions          *services.TransactionService
 	accounts              *services.AccountService
 	users                 *services.UserService
 }
@@ -34,12 +56,14 @@ var (
 		transactionCategories: services.TransactionCategories,
 		transactionTags:       services.TransactionTags,
 		transactionItems:      services.TransactionItems,
+		transactionRules:      services.TransactionRules,
+		transactions:          services.Transactions,
 		accounts:              services.Accounts,
 		users:                 services.Users,
 	}
 )
 
-// RecognizeReceiptImageByOCRHandler returns recognized transactions from bill list screenshot using an external OCR service.
+// RecognizeReceiptImageByOCRHandler returns recognized transactions from bill list screenshot using the configured OCR engines.
 func (a *LargeLanguageModelsApi) RecognizeReceiptImageByOCRHandler(c *core.WebContext) (any, *errs.Error) {
 	if !a.CurrentConfig().TransactionFromOCRImageRecognition {
 		return nil, errs.ErrLargeLanguageModelProviderNotEnabled
@@ -104,158 +128,335 @@ func (a *LargeLanguageModelsApi) RecognizeReceiptImageByOCRHandler(c *core.WebCo
 		return nil, errs.ErrOperationFailed
 	}
 
-	if a.CurrentConfig().PaddleBillOCREndpoint == "" {
-		log.Errorf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] paddle ocr endpoint is not configured for user \"uid:%d\"", uid)
-		return nil, errs.ErrOperationFailed
+	nameMaps, err := a.getRecognizedTransactionNameMaps(c, uid)
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ocrRequest := &ocr.OCRRequest{
+		ImageData:             imageData,
+		ImageContentType:      contentType,
+		ReferenceTime:         time.Now().In(clientTimezone),
+		ExpenseCategoryNames:  getSortedNames(nameMaps.expenseCategoryMap),
+		IncomeCategoryNames:   getSortedNames(nameMaps.incomeCategoryMap),
+		TransferCategoryNames: getSortedNames(nameMaps.transferCategoryMap),
+		AccountNames:          getSortedNames(nameMaps.accountMap),
+		TagNames:              getSortedNames(nameMaps.tagMap),
 	}
 
-	rawItems, err := ocr.RunPaddleBillOCR(imageData, a.CurrentConfig().PaddleBillOCREndpoint)
+	parsedList, err := ocr.Container.RecognizeBillImage(c, uid, a.CurrentConfig(), ocrRequest)
 	if err != nil {
 		log.Warnf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] OCR failed for user \"uid:%d\", because %s", uid, err.Error())
-		return nil, errs.ErrOperationFailed
+		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	if len(rawItems) == 0 {
+	if len(parsedList) == 0 {
 		return nil, errs.ErrNoTransactionInformationInImage
 	}
 
-	refTime := time.Now().In(clientTimezone)
-
-	parsedList := make([]*models.RecognizedReceiptImageResult, 0, len(rawItems))
-	for _, raw := range rawItems {
-		// 基础清洗
-		amountStr := strings.TrimSpace(raw.Amount)
-		if amountStr == "" {
-			// 没有金额就无法生成交易，跳过
+	transactions := make([]models.RecognizedReceiptImageResponse, 0, len(parsedList))
+	for _, one := range parsedList {
+		resp, parseErr := a.parseRecognizedReceiptImageResponse(c, uid, clientTimezone, one, nameMaps.accountMap, nameMaps.expenseCategoryMap, nameMaps.incomeCategoryMap, nameMaps.transferCategoryMap, nameMaps.tagMap, nameMaps.itemNameMap)
+		if parseErr != nil {
 			continue
 		}
+		transactions = append(transactions, *resp)
+	}
+	if len(transactions) == 0 {
+		return nil, errs.ErrNoTransactionInformationInImage
+	}
 
-		result := &models.RecognizedReceiptImageResult{}
+	rules, err := a.transactionRules.GetAllEnabledRulesByUid(c, uid)
+	if err != nil {
+		log.Errorf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] failed to get transaction rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+	a.transactionRules.ApplyRulesToRecognizedReceiptImageResponses(rules, transactions, a.transactionCategories.GetCategoryMapByList(nameMaps.categories), a.accounts.GetAccountMapByList(nameMaps.accounts), a.transactionTags.GetTagMapByList(nameMaps.tags))
 
-		// 金额 & 收支类型
-		result.Amount = amountStr
-		if amountStr[0] == '-' {
-			result.Type = "expense"
-		} else {
-			result.Type = "income"
-		}
+	err = a.setProbableDuplicateTransactionIds(c, uid, transactions)
+	if err != nil {
+		log.Errorf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] failed to check duplicate transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
 
-		// 时间：优先用返回的 date；为空则用当前时间
-		dateStr := strings.TrimSpace(raw.Date)
-		if dateStr != "" {
-			result.Time = dateStr
-		} else {
-			result.Time = refTime.Format("2006-01-02 15:04:05")
-		}
+	config := a.CurrentConfig()
+	response := &models.RecognizedReceiptImageListResponse{
+		Transactions: transactions,
+		Config: &models.OCRBillRecognitionConfig{
+			HideCategoryColumn: config.OCRBillRecognitionHideCategoryColumn,
+			HideItemsColumn:    config.OCRBillRecognitionHideItemsColumn,
+			HideTagsColumn:     config.OCRBillRecognitionHideTagsColumn,
+			DialogMaxWidth:     config.OCRBillRecognitionDialogMaxWidth,
+		},
+	}
 
-		// 账户名称
-		result.AccountName = strings.TrimSpace(raw.Account)
+	return response, nil
+}
 
-		// 分类名称：从 classify 中取最后一段，例如 "食品饮料-食品" => "食品"
-		classify := strings.TrimSpace(raw.Classify)
-		if classify != "" {
-			parts := strings.Split(classify, "-")
-			name := strings.TrimSpace(parts[len(parts)-1])
-			result.CategoryName = name
-		}
+// RecognizeTransactionTextHandler returns a draft transaction recognized from natural-language text by large language model,
+// the text recognition shares the same large language model configured in "llm_image_recognition" with receipt image recognition
+func (a *LargeLanguageModelsApi) RecognizeTransactionTextHandler(c *core.WebContext) (any, *errs.Error) {
+	if !a.CurrentConfig().TransactionFromAITextRecognition {
+		return nil, errs.ErrLargeLanguageModelProviderNotEnabled
+	}
 
-		// 标签：目前只支持单个 label
-		label := strings.TrimSpace(raw.Label)
-		if label != "" {
-			result.TagNames = []string{label}
-		}
+	if a.CurrentConfig().ReceiptImageRecognitionLLMConfig == nil || a.CurrentConfig().ReceiptImageRecognitionLLMConfig.LLMProvider == "" {
+		return nil, errs.ErrLargeLanguageModelProviderNotEnabled
+	}
+
+	var recognizeReq models.RecognizeTransactionTextRequest
+	err := c.ShouldBindJSON(&recognizeReq)
+
+	if err != nil {
+		log.Warnf(c, "[large_language_models.RecognizeTransactionTextHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
 
-		// 描述(comment)：使用 text 映射为内部 comment
-		text := strings.TrimSpace(raw.Text)
-		result.Description = text
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[large_language_models.RecognizeTransactionTextHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
 
-		// 项目(transaction item)：使用 project 映射为内部交易项目
-		project := strings.TrimSpace(raw.Project)
-		if project != "" {
-			result.ItemNames = []string{project}
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[large_language_models.RecognizeTransactionTextHandler] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
 		}
 
-		parsedList = append(parsedList, result)
+		return nil, errs.ErrUserNotFound
 	}
 
-	if len(parsedList) == 0 {
-		return nil, errs.ErrNoTransactionInformationInImage
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_CREATE_TRANSACTION_FROM_AI_TEXT_RECOGNITION) {
+		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
 
-	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+	text := strings.TrimSpace(recognizeReq.Text)
+
+	if text == "" {
+		return nil, errs.ErrAIRecognitionTextIsEmpty
+	}
+
+	if utf8.RuneCountInString(text) > int(a.CurrentConfig().MaxAIRecognitionTextLength) {
+		log.Warnf(c, "[large_language_models.RecognizeTransactionTextHandler] the text length \"%d\" exceeds the maximum length \"%d\" for user \"uid:%d\"", utf8.RuneCountInString(text), a.CurrentConfig().MaxAIRecognitionTextLength, uid)
+		return nil, errs.ErrExceedMaxAIRecognitionTextLength
+	}
+
+	nameMaps, err := a.getRecognizedTransactionNameMaps(c, uid)
+
 	if err != nil {
-		log.Errorf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
-	accountMap := a.accounts.GetVisibleAccountNameMapByList(accounts)
 
-	categories, err := a.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+	systemPrompt, err := a.getTransactionTextRecognitionSystemPrompt(time.Now().Unix(), clientTimezone, nameMaps)
+
+	if err != nil {
+		log.Errorf(c, "[large_language_models.RecognizeTransactionTextHandler] failed to render system prompt for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	llmRequest := &data.LargeLanguageModelRequest{
+		Stream:                 false,
+		SystemPrompt:           systemPrompt,
+		UserPrompt:             []byte(text),
+		UserPromptType:         data.LARGE_LANGUAGE_MODEL_REQUEST_PROMPT_TYPE_TEXT,
+		ResponseJsonObjectType: reflect.TypeOf(&models.RecognizedReceiptImageResult{}),
+	}
+
+	llmResponse, err := llm.Container.GetJsonResponseByReceiptImageRecognitionModel(c, uid, a.CurrentConfig(), llmRequest)
+
 	if err != nil {
-		log.Errorf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		log.Errorf(c, "[large_language_models.RecognizeTransactionTextHandler] failed to get llm response for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
-	expenseCategoryMap := make(map[string]*models.TransactionCategory)
-	incomeCategoryMap := make(map[string]*models.TransactionCategory)
-	transferCategoryMap := make(map[string]*models.TransactionCategory)
-	for i := 0; i < len(categories); i++ {
-		cat := categories[i]
-		if cat.Hidden || cat.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+
+	if llmResponse == nil || strings.TrimSpace(llmResponse.Content) == "" {
+		return nil, errs.ErrNoTransactionInformationInText
+	}
+
+	recognizedResult := &models.RecognizedReceiptImageResult{}
+	err = json.Unmarshal([]byte(llmResponse.Content), recognizedResult)
+
+	if err != nil {
+		log.Errorf(c, "[large_language_models.RecognizeTransactionTextHandler] failed to parse llm response for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	if recognizedResult.Type == "" {
+		return nil, errs.ErrNoTransactionInformationInText
+	}
+
+	recognizedTransaction, parseErr := a.parseRecognizedReceiptImageResponse(c, uid, clientTimezone, recognizedResult, nameMaps.accountMap, nameMaps.expenseCategoryMap, nameMaps.incomeCategoryMap, nameMaps.transferCategoryMap, nameMaps.tagMap, nameMaps.itemNameMap)
+
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	if recognizedTransaction.Time == 0 {
+		recognizedTransaction.Time = time.Now().Unix()
+	}
+
+	rules, err := a.transactionRules.GetAllEnabledRulesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[large_language_models.RecognizeTransactionTextHandler] failed to get transaction rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactions := []models.RecognizedReceiptImageResponse{*recognizedTransaction}
+	a.transactionRules.ApplyRulesToRecognizedReceiptImageResponses(rules, transactions, a.transactionCategories.GetCategoryMapByList(nameMaps.categories), a.accounts.GetAccountMapByList(nameMaps.accounts), a.transactionTags.GetTagMapByList(nameMaps.tags))
+
+	err = a.setProbableDuplicateTransactionIds(c, uid, transactions)
+
+	if err != nil {
+		log.Errorf(c, "[large_language_models.RecognizeTransactionTextHandler] failed to check duplicate transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return transactions[0], nil
+}
+
+func (a *LargeLanguageModelsApi) setProbableDuplicateTransactionIds(c *core.WebContext, uid int64, recognizedTransactions []models.RecognizedReceiptImageResponse) error {
+	newTransactions := make([]*models.Transaction, 0, len(recognizedTransactions))
+	newTransactionIndexes := make([]int, 0, len(recognizedTransactions))
+
+	for i := 0; i < len(recognizedTransactions); i++ {
+		recognizedTransaction := recognizedTransactions[i]
+		transactionDbType, err := recognizedTransaction.Type.ToTransactionDbType()
+
+		if err != nil || recognizedTransaction.Time <= 0 {
 			continue
 		}
-		if cat.Type == models.CATEGORY_TYPE_EXPENSE {
-			expenseCategoryMap[cat.Name] = cat
-		} else if cat.Type == models.CATEGORY_TYPE_INCOME {
-			incomeCategoryMap[cat.Name] = cat
-		} else if cat.Type == models.CATEGORY_TYPE_TRANSFER {
-			transferCategoryMap[cat.Name] = cat
+
+		amount := recognizedTransaction.SourceAmount
+
+		if amount < 0 {
+			amount = -amount
 		}
+
+		newTransactions = append(newTransactions, &models.Transaction{
+			Type:            transactionDbType,
+			TransactionTime: utils.GetMinTransactionTimeFromUnixTime(recognizedTransaction.Time),
+			AccountId:       recognizedTransaction.SourceAccountId,
+			Amount:          amount,
+			Comment:         recognizedTransaction.Comment,
+		})
+		newTransactionIndexes = append(newTransactionIndexes, i)
+	}
+
+	duplicateTransactionIds, err := a.transactions.GetProbableDuplicateTransactionIds(c, uid, newTransactions)
+
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(duplicateTransactionIds); i++ {
+		recognizedTransactions[newTransactionIndexes[i]].ProbableDuplicateTransactionId = duplicateTransactionIds[i]
+	}
+
+	return nil
+}
+
+func (a *LargeLanguageModelsApi) getRecognizedTransactionNameMaps(c *core.WebContext, uid int64) (*recognizedTransactionNameMaps, error) {
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[large_language_models.getRecognizedTransactionNameMaps] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	categories, err := a.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[large_language_models.getRecognizedTransactionNameMaps] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
 	}
 
 	tags, err := a.transactionTags.GetAllTagsByUid(c, uid)
+
 	if err != nil {
-		log.Errorf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] failed to get tags for user \"uid:%d\", because %s", uid, err.Error())
-		return nil, errs.Or(err, errs.ErrOperationFailed)
+		log.Errorf(c, "[large_language_models.getRecognizedTransactionNameMaps] failed to get tags for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
 	}
-	tagMap := a.transactionTags.GetVisibleTagNameMapByList(tags)
 
 	items, err := a.transactionItems.GetAllItemsByUid(c, uid)
+
 	if err != nil {
-		log.Errorf(c, "[large_language_models.RecognizeReceiptImageByOCRHandler] failed to get transaction items for user \"uid:%d\", because %s", uid, err.Error())
-		return nil, errs.Or(err, errs.ErrOperationFailed)
+		log.Errorf(c, "[large_language_models.getRecognizedTransactionNameMaps] failed to get transaction items for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
 	}
-	itemNameMap := make(map[string]*models.TransactionItem)
-	for _, item := range items {
-		if item != nil && !item.Hidden && item.Name != "" {
-			if _, exists := itemNameMap[item.Name]; !exists {
-				itemNameMap[item.Name] = item
-			}
+
+	nameMaps := &recognizedTransactionNameMaps{
+		accounts:            accounts,
+		categories:          categories,
+		tags:                tags,
+		accountMap:          a.accounts.GetVisibleAccountNameMapByList(accounts),
+		expenseCategoryMap:  make(map[string]*models.TransactionCategory),
+		incomeCategoryMap:   make(map[string]*models.TransactionCategory),
+		transferCategoryMap: make(map[string]*models.TransactionCategory),
+		tagMap:              a.transactionTags.GetVisibleTagNameMapByList(tags),
+		itemNameMap:         make(map[string]*models.TransactionItem),
+	}
+
+	for i := 0; i < len(categories); i++ {
+		category := categories[i]
+
+		if category.Hidden || category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+			continue
+		}
+
+		if category.Type == models.CATEGORY_TYPE_EXPENSE {
+			nameMaps.expenseCategoryMap[category.Name] = category
+		} else if category.Type == models.CATEGORY_TYPE_INCOME {
+			nameMaps.incomeCategoryMap[category.Name] = category
+		} else if category.Type == models.CATEGORY_TYPE_TRANSFER {
+			nameMaps.transferCategoryMap[category.Name] = category
 		}
 	}
 
-	transactions := make([]models.RecognizedReceiptImageResponse, 0, len(parsedList))
-	for _, one := range parsedList {
-		resp, parseErr := a.parseRecognizedReceiptImageResponse(c, uid, clientTimezone, one, accountMap, expenseCategoryMap, incomeCategoryMap, transferCategoryMap, tagMap, itemNameMap)
-		if parseErr != nil {
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+
+		if item == nil || item.Hidden || item.Name == "" {
 			continue
 		}
-		transactions = append(transactions, *resp)
+
+		if _, exists := nameMaps.itemNameMap[item.Name]; !exists {
+			nameMaps.itemNameMap[item.Name] = item
+		}
 	}
-	if len(transactions) == 0 {
-		return nil, errs.ErrNoTransactionInformationInImage
+
+	return nameMaps, nil
+}
+
+func (a *LargeLanguageModelsApi) getTransactionTextRecognitionSystemPrompt(currentUnixTime int64, clientTimezone *time.Location, nameMaps *recognizedTransactionNameMaps) (string, error) {
+	tmpl, err := templates.GetTemplate(templates.SYSTEM_PROMPT_TRANSACTION_TEXT_RECOGNITION)
+
+	if err != nil {
+		return "", err
 	}
 
-	config := a.CurrentConfig()
-	response := &models.RecognizedReceiptImageListResponse{
-		Transactions: transactions,
-		Config: &models.OCRBillRecognitionConfig{
-			HideCategoryColumn: config.OCRBillRecognitionHideCategoryColumn,
-			HideItemsColumn:    config.OCRBillRecognitionHideItemsColumn,
-			HideTagsColumn:     config.OCRBillRecognitionHideTagsColumn,
-			DialogMaxWidth:     config.OCRBillRecognitionDialogMaxWidth,
-		},
+	templateParams := map[string]any{
+		"CurrentDateTime":          utils.FormatUnixTimeToLongDateTime(currentUnixTime, clientTimezone),
+		"AllExpenseCategoryNames":  strings.Join(getSortedNames(nameMaps.expenseCategoryMap), "\n"),
+		"AllIncomeCategoryNames":   strings.Join(getSortedNames(nameMaps.incomeCategoryMap), "\n"),
+		"AllTransferCategoryNames": strings.Join(getSortedNames(nameMaps.transferCategoryMap), "\n"),
+		"AllAccountNames":          strings.Join(getSortedNames(nameMaps.accountMap), "\n"),
+		"AllTagNames":              strings.Join(getSortedNames(nameMaps.tagMap), "\n"),
+		"AllItemNames":             strings.Join(getSortedNames(nameMaps.itemNameMap), "\n"),
 	}
 
-	return response, nil
+	var promptBuffer bytes.Buffer
+	err = tmpl.Execute(&promptBuffer, templateParams)
+
+	if err != nil {
+		return "", err
+	}
+
+	return promptBuffer.String(), nil
 }
 
 func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageResponse(c *core.WebContext, uid int64, clientTimezone *time.Location, recognizedResult *models.RecognizedReceiptImageResult, accountMap map[string]*models.Account, expenseCategoryMap map[string]*models.TransactionCategory, incomeCategoryMap map[string]*models.TransactionCategory, transferCategoryMap map[string]*models.TransactionCategory, tagMap map[string]*models.TransactionTag, itemNameMap map[string]*models.TransactionItem) (*models.RecognizedReceiptImageResponse, *errs.Error) {
@@ -403,3 +604,15 @@ func (a *LargeLanguageModelsApi) getLongDateTime(dateTime string) string {
 
 	return dateTime
 }
+
+func getSortedNames[T any](nameMap map[string]T) []string {
+	names := make([]string, 0, len(nameMap))
+
+	for name := range nameMap {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/llm"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const llmTestUid = 1
+
+const llmTestCashAccountId = 1001
+const llmTestExpenseCategoryId = 2002
+const llmTestTagId = 3001
+
+type llmTestFakeServer struct {
+	server          *httptest.Server
+	responseContent string
+	userPrompts     []string
+}
+
+func newLLMTestFakeServer(t *testing.T) *llmTestFakeServer {
+	fakeServer := &llmTestFakeServer{}
+
+	fakeServer.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.Nil(t, err)
+
+		request := &struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}{}
+		assert.Nil(t, json.Unmarshal(body, request))
+
+		for i := 0; i < len(request.Messages); i++ {
+			if request.Messages[i].Role == "user" {
+				fakeServer.userPrompts = append(fakeServer.userPrompts, request.Messages[i].Content)
+			}
+		}
+
+		response, err := json.Marshal(map[string]any{
+			"choices": []map[string]any{
+				{
+					"message": map[string]any{
+						"content": fakeServer.responseContent,
+					},
+				},
+			},
+		})
+		assert.Nil(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(response)
+	}))
+
+	t.Cleanup(fakeServer.server.Close)
+
+	return fakeServer
+}
+
+func initializeLLMTestEnvironment(t *testing.T, maxTextLength uint32) *llmTestFakeServer {
+	gin.SetMode(gin.TestMode)
+	t.Chdir(filepath.Join("..", ".."))
+
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		_ = v.RegisterValidation("notBlank", validators.NotBlank)
+	}
+
+	fakeServer := newLLMTestFakeServer(t)
+
+	config := &settings.Config{
+		DatabaseConfig: &settings.DatabaseConfig{
+			DatabaseType:      settings.Sqlite3DbType,
+			DatabasePath:      filepath.Join(t.TempDir(), "ezbookkeeping.db"),
+			MaxOpenConnection: 2,
+		},
+		UuidGeneratorType: settings.InternalUuidGeneratorType,
+		ReceiptImageRecognitionLLMConfig: &settings.LLMConfig{
+			LLMProvider:             settings.OpenAICompatibleLLMProvider,
+			OpenAICompatibleBaseURL: fakeServer.server.URL,
+			OpenAICompatibleModelID: "test",
+
+			LargeLanguageModelAPIRequestTimeout: 10000,
+			LargeLanguageModelAPIProxy:          "none",
+		},
+
+		TransactionFromAITextRecognition: true,
+		MaxAIRecognitionTextLength:       maxTextLength,
+	}
+
+	settings.SetCurrentConfig(config)
+	assert.Nil(t, datastore.InitializeDataStore(config))
+	assert.Nil(t, uuid.InitializeUuidGenerator(config))
+	assert.Nil(t, llm.InitializeLargeLanguageModelProvider(config))
+	assert.Nil(t, datastore.Container.UserStore.SyncStructs(new(models.User)))
+	assert.Nil(t, datastore.Container.UserDataStore.SyncStructs(new(models.Account), new(models.Transaction), new(models.TransactionCategory),
+		new(models.TransactionTag), new(models.TransactionItem), new(models.TransactionRule)))
+
+	userSess := datastore.Container.UserStore.Choose(llmTestUid).NewSession(core.NewNullContext())
+	defer userSess.Close()
+
+	_, err := userSess.Insert(&models.User{Uid: llmTestUid, Username: "user1", Email: "user1@example.com", Nickname: "user1"})
+	assert.Nil(t, err)
+
+	sess := datastore.Container.UserDataStore.Choose(llmTestUid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	_, err = sess.Insert(&models.Account{AccountId: llmTestCashAccountId, Uid: llmTestUid, Category: models.ACCOUNT_CATEGORY_CASH, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Cash", DisplayOrder: 1, Currency: "USD"})
+	assert.Nil(t, err)
+
+	_, err = sess.Insert([]*models.TransactionCategory{
+		{CategoryId: llmTestExpenseCategoryId - 1, Uid: llmTestUid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: models.LevelOneTransactionCategoryParentId, Name: "Food", DisplayOrder: 1},
+		{CategoryId: llmTestExpenseCategoryId, Uid: llmTestUid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: llmTestExpenseCategoryId - 1, Name: "Dining", DisplayOrder: 1},
+	})
+	assert.Nil(t, err)
+
+	_, err = sess.Insert(&models.TransactionTag{TagId: llmTestTagId, Uid: llmTestUid, Name: "Travel", DisplayOrder: 1})
+	assert.Nil(t, err)
+
+	return fakeServer
+}
+
+func callRecognizeTransactionTextHandler(t *testing.T, text string) (any, *errs.Error) {
+	body, err := json.Marshal(&models.RecognizeTransactionTextRequest{Text: text})
+	assert.Nil(t, err)
+
+	ginContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginContext.Request = httptest.NewRequest(http.MethodPost, "/api/v1/llm/transactions/recognize_text.json", strings.NewReader(string(body)))
+	ginContext.Request.Header.Set("Content-Type", "application/json")
+	ginContext.Request.Header.Set(core.ClientTimezoneNameHeaderName, "UTC")
+
+	c := core.WrapWebContext(ginContext)
+	c.SetTokenClaims(&core.UserTokenClaims{
+		Uid:  llmTestUid,
+		Type: core.USER_TOKEN_TYPE_NORMAL,
+	})
+
+	return LargeLanguageModels.RecognizeTransactionTextHandler(c)
+}
+
+func TestRecognizeTransactionTextHandler_EmptyTextAfterTrimming(t *testing.T) {
+	fakeServer := initializeLLMTestEnvironment(t, 100)
+
+	result, err := callRecognizeTransactionTextHandler(t, " \t\r\n ")
+	assert.Nil(t, result)
+	assert.Equal(t, errs.ErrAIRecognitionTextIsEmpty, err)
+	assert.Equal(t, 0, len(fakeServer.userPrompts))
+}
+
+func TestRecognizeTransactionTextHandler_ExceedMaxTextLength(t *testing.T) {
+	fakeServer := initializeLLMTestEnvironment(t, 5)
+
+	result, err := callRecognizeTransactionTextHandler(t, "午餐花了十二元")
+	assert.Nil(t, result)
+	assert.Equal(t, errs.ErrExceedMaxAIRecognitionTextLength, err)
+	assert.Equal(t, 0, len(fakeServer.userPrompts))
+}
+
+func TestRecognizeTransactionTextHandler_TextLengthCountedAfterTrimming(t *testing.T) {
+	fakeServer := initializeLLMTestEnvironment(t, 5)
+	fakeServer.responseContent = "{\"type\":\"expense\",\"time\":\"2024-05-10 12:00:00\",\"amount\":\"12\"}"
+
+	result, err := callRecognizeTransactionTextHandler(t, "   午餐十二元   ")
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, []string{"午餐十二元"}, fakeServer.userPrompts)
+}
+
+func TestRecognizeTransactionTextHandler_ParseResponse(t *testing.T) {
+	fakeServer := initializeLLMTestEnvironment(t, 100)
+	fakeServer.responseContent = "{\"type\":\"expense\",\"time\":\"2024-05-10 12:00:00\",\"amount\":\"12.34\",\"account\":\"Cash\",\"category\":\"Dining\",\"tags\":[\"Travel\",\"Unknown\"],\"description\":\"Lunch\"}"
+
+	result, err := callRecognizeTransactionTextHandler(t, "Lunch 12.34 by cash")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Lunch 12.34 by cash"}, fakeServer.userPrompts)
+
+	recognizedTransaction, ok := result.(models.RecognizedReceiptImageResponse)
+	assert.True(t, ok)
+	assert.Equal(t, models.TRANSACTION_TYPE_EXPENSE, recognizedTransaction.Type)
+	assert.Equal(t, int64(1715342400), recognizedTransaction.Time)
+	assert.Equal(t, int64(1234), recognizedTransaction.SourceAmount)
+	assert.Equal(t, int64(llmTestCashAccountId), recognizedTransaction.SourceAccountId)
+	assert.Equal(t, "Cash", recognizedTransaction.AccountName)
+	assert.Equal(t, int64(llmTestExpenseCategoryId), recognizedTransaction.CategoryId)
+	assert.Equal(t, []string{"3001"}, recognizedTransaction.TagIds)
+	assert.Equal(t, "Lunch", recognizedTransaction.Comment)
+}
+
+func TestRecognizeTransactionTextHandler_EmptyResponse(t *testing.T) {
+	fakeServer := initializeLLMTestEnvironment(t, 100)
+	fakeServer.responseContent = "  "
+
+	result, err := callRecognizeTransactionTextHandler(t, "hello")
+	assert.Nil(t, result)
+	assert.Equal(t, errs.ErrNoTransactionInformationInText, err)
+}
+
+func TestRecognizeTransactionTextHandler_ResponseWithoutTransactionType(t *testing.T) {
+	fakeServer := initializeLLMTestEnvironment(t, 100)
+	fakeServer.responseContent = "{\"amount\":\"12.34\"}"
+
+	result, err := callRecognizeTransactionTextHandler(t, "hello")
+	assert.Nil(t, result)
+	assert.Equal(t, errs.ErrNoTransactionInformationInText, err)
+}
+
+func TestRecognizeTransactionTextHandler_InvalidResponse(t *testing.T) {
+	fakeServer := initializeLLMTestEnvironment(t, 100)
+
+	fakeServer.responseContent = "not a json object"
+	result, err := callRecognizeTransactionTextHandler(t, "hello")
+	assert.Nil(t, result)
+	assert.Equal(t, errs.ErrOperationFailed, err)
+
+	fakeServer.responseContent = "{\"type\":\"refund\"}"
+	result, err = callRecognizeTransactionTextHandler(t, "hello")
+	assert.Nil(t, result)
+	assert.Equal(t, errs.ErrOperationFailed, err)
+
+	fakeServer.responseContent = "{\"type\":\"expense\",\"amount\":\"abc\"}"
+	result, err = callRecognizeTransactionTextHandler(t, "hello")
+	assert.Nil(t, result)
+	assert.Equal(t, errs.ErrOperationFailed, err)
+}
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// LedgersApi represents shared ledger api
+type LedgersApi struct {
+	ledgers *services.LedgerService
+	users   *services.UserService
+}
+
+// Initialize a shared ledger api singleton instance
+var (
+	Ledgers = &LedgersApi{
+		ledgers: services.Ledgers,
+		users:   services.Users,
+	}
+)
+
+// LedgerListHandler returns all shared ledgers which current user owns or joins
+func (a *LedgersApi) LedgerListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	ownLedgers, err := a.ledgers.GetAllLedgersByOwnerUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerListHandler] failed to get own ledgers for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	joinedMembers, err := a.ledgers.GetAllJoinedLedgerMembersByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerListHandler] failed to get joined ledgers for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ledgerIds := make([]int64, len(joinedMembers))
+
+	for i := 0; i < len(joinedMembers); i++ {
+		ledgerIds[i] = joinedMembers[i].LedgerId
+	}
+
+	joinedLedgers, err := a.ledgers.GetLedgersByLedgerIds(c, ledgerIds)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerListHandler] failed to get joined ledger models for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ownerUids := make([]int64, 0, len(joinedLedgers)+1)
+	ownerUids = append(ownerUids, uid)
+
+	for _, ledger := range joinedLedgers {
+		ownerUids = append(ownerUids, ledger.Uid)
+	}
+
+	owners, err := a.users.GetUsersByUids(c, ownerUids)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerListHandler] failed to get ledger owners for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ledgerResps := make([]*models.LedgerInfoResponse, 0, len(ownLedgers)+len(joinedMembers))
+
+	for i := 0; i < len(ownLedgers); i++ {
+		ledgerResps = append(ledgerResps, ownLedgers[i].ToLedgerInfoResponse(owners[uid], models.LEDGER_MEMBER_ROLE_OWNER))
+	}
+
+	for i := 0; i < len(joinedMembers); i++ {
+		ledger, exists := joinedLedgers[joinedMembers[i].LedgerId]
+
+		if !exists {
+			continue
+		}
+
+		ledgerResps = append(ledgerResps, ledger.ToLedgerInfoResponse(owners[ledger.Uid], joinedMembers[i].Role))
+	}
+
+	return ledgerResps, nil
+}
+
+// LedgerCreateHandler saves a new shared ledger of current user
+func (a *LedgersApi) LedgerCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var ledgerCreateReq models.LedgerCreateRequest
+	err := c.ShouldBindJSON(&ledgerCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[ledgers.LedgerCreateHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	ledger := &models.Ledger{
+		Uid:  uid,
+		Name: ledgerCreateReq.Name,
+	}
+
+	err = a.ledgers.CreateLedger(c, ledger)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerCreateHandler] failed to create ledger for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerCreateHandler] user \"uid:%d\" has created a new ledger \"id:%d\" successfully", uid, ledger.LedgerId)
+
+	return ledger.ToLedgerInfoResponse(user, models.LEDGER_MEMBER_ROLE_OWNER), nil
+}
+
+// LedgerModifyHandler saves an existed shared ledger of current user
+func (a *LedgersApi) LedgerModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var ledgerModifyReq models.LedgerModifyRequest
+	err := c.ShouldBindJSON(&ledgerModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	ledger, err := a.getOwnLedger(c, uid, ledgerModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerModifyHandler] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", ledgerModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if ledger.Name == ledgerModifyReq.Name {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	ledger.Name = ledgerModifyReq.Name
+	err = a.ledgers.ModifyLedger(c, ledger)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerModifyHandler] failed to update ledger \"id:%d\" for user \"uid:%d\", because %s", ledgerModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerModifyHandler] user \"uid:%d\" has updated ledger \"id:%d\" successfully", uid, ledgerModifyReq.Id)
+
+	return ledger.ToLedgerInfoResponse(nil, models.LEDGER_MEMBER_ROLE_OWNER), nil
+}
+
+// LedgerDeleteHandler deletes an existed shared ledger of current user, the personal data of current user will not be affected
+func (a *LedgersApi) LedgerDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var ledgerDeleteReq models.LedgerDeleteRequest
+	err := c.ShouldBindJSON(&ledgerDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.ledgers.DeleteLedger(c, uid, ledgerDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerDeleteHandler] failed to delete ledger \"id:%d\" for user \"uid:%d\", because %s", ledgerDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerDeleteHandler] user \"uid:%d\" has deleted ledger \"id:%d\"", uid, ledgerDeleteReq.Id)
+	return true, nil
+}
+
+// LedgerMemberListHandler returns all members of the specified shared ledger which current user owns or joins
+func (a *LedgersApi) LedgerMemberListHandler(c *core.WebContext) (any, *errs.Error) {
+	var memberListReq models.LedgerMemberListRequest
+	err := c.ShouldBindQuery(&memberListReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	_, ledger, err := a.ledgers.GetLedgerMemberRole(c, memberListReq.LedgerId, uid)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberListHandler] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", memberListReq.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	members, err := a.ledgers.GetAllLedgerMembers(c, ledger.LedgerId)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberListHandler] failed to get members of ledger \"id:%d\" for user \"uid:%d\", because %s", ledger.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	memberUids := make([]int64, 0, len(members)+1)
+	memberUids = append(memberUids, ledger.Uid)
+
+	for i := 0; i < len(members); i++ {
+		memberUids = append(memberUids, members[i].Uid)
+	}
+
+	users, err := a.users.GetUsersByUids(c, memberUids)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberListHandler] failed to get users of ledger \"id:%d\" for user \"uid:%d\", because %s", ledger.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	owner := &models.LedgerMember{
+		LedgerId: ledger.LedgerId,
+		Uid:      ledger.Uid,
+		Role:     models.LEDGER_MEMBER_ROLE_OWNER,
+	}
+
+	memberResps := make([]*models.LedgerMemberInfoResponse, 0, len(members)+1)
+	memberResps = append(memberResps, owner.ToLedgerMemberInfoResponse(users[ledger.Uid]))
+
+	for i := 0; i < len(members); i++ {
+		memberResps = append(memberResps, members[i].ToLedgerMemberInfoResponse(users[members[i].Uid]))
+	}
+
+	return memberResps, nil
+}
+
+// LedgerMemberAddHandler adds a user to the shared ledger of current user
+func (a *LedgersApi) LedgerMemberAddHandler(c *core.WebContext) (any, *errs.Error) {
+	var memberAddReq models.LedgerMemberAddRequest
+	err := c.ShouldBindJSON(&memberAddReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberAddHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !memberAddReq.Role.IsValidMemberRole() {
+		log.Warnf(c, "[ledgers.LedgerMemberAddHandler] ledger member role invalid, role is %d", memberAddReq.Role)
+		return nil, errs.ErrLedgerMemberRoleInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	ledger, err := a.getOwnLedger(c, uid, memberAddReq.LedgerId)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberAddHandler] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", memberAddReq.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var memberUser *models.User
+
+	if strings.Contains(memberAddReq.LoginName, "@") {
+		memberUser, err = a.users.GetUserByEmail(c, memberAddReq.LoginName)
+	} else {
+		memberUser, err = a.users.GetUserByUsername(c, memberAddReq.LoginName)
+	}
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[ledgers.LedgerMemberAddHandler] failed to get user \"%s\", because %s", memberAddReq.LoginName, err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	member := &models.LedgerMember{
+		Uid:  memberUser.Uid,
+		Role: memberAddReq.Role,
+	}
+
+	err = a.ledgers.AddLedgerMember(c, ledger, member)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberAddHandler] failed to add user \"uid:%d\" to ledger \"id:%d\" for user \"uid:%d\", because %s", memberUser.Uid, ledger.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerMemberAddHandler] user \"uid:%d\" has added user \"uid:%d\" to ledger \"id:%d\"", uid, memberUser.Uid, ledger.LedgerId)
+
+	return member.ToLedgerMemberInfoResponse(memberUser), nil
+}
+
+// LedgerMemberModifyHandler updates the role of a member in the shared ledger of current user
+func (a *LedgersApi) LedgerMemberModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var memberModifyReq models.LedgerMemberModifyRequest
+	err := c.ShouldBindJSON(&memberModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !memberModifyReq.Role.IsValidMemberRole() {
+		log.Warnf(c, "[ledgers.LedgerMemberModifyHandler] ledger member role invalid, role is %d", memberModifyReq.Role)
+		return nil, errs.ErrLedgerMemberRoleInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	ledger, err := a.getOwnLedger(c, uid, memberModifyReq.LedgerId)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberModifyHandler] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", memberModifyReq.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	member := &models.LedgerMember{
+		LedgerId: ledger.LedgerId,
+		Uid:      memberModifyReq.Uid,
+		Role:     memberModifyReq.Role,
+	}
+
+	err = a.ledgers.ModifyLedgerMemberRole(c, member)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberModifyHandler] failed to update role of user \"uid:%d\" in ledger \"id:%d\" for user \"uid:%d\", because %s", memberModifyReq.Uid, ledger.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerMemberModifyHandler] user \"uid:%d\" has updated role of user \"uid:%d\" in ledger \"id:%d\"", uid, memberModifyReq.Uid, ledger.LedgerId)
+	return true, nil
+}
+
+// LedgerMemberDeleteHandler removes a member from the shared ledger of current user, or lets current user leave the shared ledger
+func (a *LedgersApi) LedgerMemberDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var memberDeleteReq models.LedgerMemberDeleteRequest
+	err := c.ShouldBindJSON(&memberDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+
+	if memberDeleteReq.Uid != uid {
+		_, err = a.getOwnLedger(c, uid, memberDeleteReq.LedgerId)
+
+		if err != nil {
+			log.Errorf(c, "[ledgers.LedgerMemberDeleteHandler] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", memberDeleteReq.LedgerId, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	err = a.ledgers.DeleteLedgerMember(c, memberDeleteReq.LedgerId, memberDeleteReq.Uid)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberDeleteHandler] failed to remove user \"uid:%d\" from ledger \"id:%d\" for user \"uid:%d\", because %s", memberDeleteReq.Uid, memberDeleteReq.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerMemberDeleteHandler] user \"uid:%d\" has removed user \"uid:%d\" from ledger \"id:%d\"", uid, memberDeleteReq.Uid, memberDeleteReq.LedgerId)
+	return true, nil
+}
+
+// getCurrentDataUser returns the model of current operator with the uid of the data owner, so the settings of current operator are applied when current request operates on a shared ledger
+func getCurrentDataUser(c *core.WebContext, users *services.UserService) (*models.User, error) {
+	user, err := users.GetUserById(c, c.GetCurrentOperatorUid())
+
+	if err != nil {
+		return nil, err
+	}
+
+	user.Uid = c.GetCurrentUid()
+
+	return user, nil
+}
+
+func (a *LedgersApi) getOwnLedger(c *core.WebContext, uid int64, ledgerId int64) (*models.Ledger, error) {
+	ledger, err := a.ledgers.GetLedgerByLedgerId(c, ledgerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ledger.Uid != uid {
+		return nil, errs.ErrOnlyLedgerOwnerCanManageLedger
+	}
+
+	return ledger, nil
+}
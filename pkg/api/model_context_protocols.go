@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/mcp"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -18,12 +20,17 @@ const mcpServerName = core.ApplicationName + "-mcp"
 // ModelContextProtocolAPI represents model context protocol api
 type ModelContextProtocolAPI struct {
 	ApiUsingConfig
-	transactions          *services.TransactionService
-	transactionCategories *services.TransactionCategoryService
-	transactionTags       *services.TransactionTagService
-	accounts              *services.AccountService
-	users                 *services.UserService
-	tokens                *services.TokenService
+	ApiUsingDuplicateChecker
+	transactions            *services.TransactionService
+	transactionCategories   *services.TransactionCategoryService
+	transactionTags         *services.TransactionTagService
+	accounts                *services.AccountService
+	transactionPictures     *services.TransactionPictureService
+	users                   *services.UserService
+	tokens                  *services.TokenService
+	webhooks                *services.WebhookService
+	insightsExplorers       *services.InsightsExplorerService
+	insightsExplorerQueries *reports.InsightsExplorerQueryExecutor
 }
 
 // Initialize a model context protocol api singleton instance
@@ -32,12 +39,22 @@ var (
 		ApiUsingConfig: ApiUsingConfig{
 			container: settings.Container,
 		},
-		transactions:          services.Transactions,
-		transactionCategories: services.TransactionCategories,
-		transactionTags:       services.TransactionTags,
-		accounts:              services.Accounts,
-		users:                 services.Users,
-		tokens:                services.Tokens,
+		ApiUsingDuplicateChecker: ApiUsingDuplicateChecker{
+			ApiUsingConfig: ApiUsingConfig{
+				container: settings.Container,
+			},
+			container: duplicatechecker.Container,
+		},
+		transactions:            services.Transactions,
+		transactionCategories:   services.TransactionCategories,
+		transactionTags:         services.TransactionTags,
+		accounts:                services.Accounts,
+		transactionPictures:     services.TransactionPictures,
+		users:                   services.Users,
+		tokens:                  services.Tokens,
+		webhooks:                services.Webhooks,
+		insightsExplorers:       services.InsightsExplorers,
+		insightsExplorerQueries: reports.InsightsExplorerQueries,
 	}
 )
 
@@ -96,9 +113,16 @@ func (a *ModelContextProtocolAPI) InitializeHandler(c *core.WebContext, jsonRPCR
 	initResp := mcp.MCPInitializeResponse{
 		ProtocolVersion: string(protocolVersion),
 		Capabilities: &mcp.MCPCapabilities{
+			Resources: &mcp.MCPResourceCapabilities{
+				Subscribe:   false,
+				ListChanged: false,
+			},
 			Tools: &mcp.MCPToolCapabilities{
 				ListChanged: false,
 			},
+			Prompts: &mcp.MCPPromptCapabilities{
+				ListChanged: false,
+			},
 		},
 		ServerInfo: &mcp.MCPImplementation{
 			Name:    mcpServerName,
@@ -124,13 +148,41 @@ func (a *ModelContextProtocolAPI) ListResourcesHandler(c *core.WebContext, jsonR
 		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
 
+	resources, err := mcp.Container.GetMCPResources(c, user, a.CurrentConfig(), a)
+
+	if err != nil {
+		log.Errorf(c, "[model_context_protocols.ListResourcesHandler] failed to get resources for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	listResourcesResp := mcp.MCPListResourcesResponse{
-		Resources: make([]*mcp.MCPResource, 0),
+		Resources: resources,
 	}
 
 	return listResourcesResp, nil
 }
 
+// ListResourceTemplatesHandler returns the list of resource templates for model context protocol
+func (a *ModelContextProtocolAPI) ListResourceTemplatesHandler(c *core.WebContext, jsonRPCRequest *core.JSONRPCRequest) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[model_context_protocols.ListResourceTemplatesHandler] failed to get user \"uid:%d\" info, because %s", uid, err.Error())
+		return nil, errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_MCP_ACCESS) {
+		return nil, errs.ErrNotPermittedToPerformThisAction
+	}
+
+	listResourceTemplatesResp := mcp.MCPListResourceTemplatesResponse{
+		ResourceTemplates: mcp.Container.GetMCPResourceTemplates(),
+	}
+
+	return listResourceTemplatesResp, nil
+}
+
 // ReadResourceHandler returns the resource details for a specific resource in model context protocol
 func (a *ModelContextProtocolAPI) ReadResourceHandler(c *core.WebContext, jsonRPCRequest *core.JSONRPCRequest) (any, *errs.Error) {
 	var readResourceReq mcp.MCPReadResourceRequest
@@ -155,7 +207,67 @@ func (a *ModelContextProtocolAPI) ReadResourceHandler(c *core.WebContext, jsonRP
 		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
 
-	return nil, errs.ErrApiNotFound
+	result, err := mcp.Container.ReadResource(c, &readResourceReq, user, a.CurrentConfig(), a)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return result, nil
+}
+
+// ListPromptsHandler returns the list of prompts for model context protocol
+func (a *ModelContextProtocolAPI) ListPromptsHandler(c *core.WebContext, jsonRPCRequest *core.JSONRPCRequest) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[model_context_protocols.ListPromptsHandler] failed to get user \"uid:%d\" info, because %s", uid, err.Error())
+		return nil, errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_MCP_ACCESS) {
+		return nil, errs.ErrNotPermittedToPerformThisAction
+	}
+
+	listPromptsResp := mcp.MCPListPromptsResponse{
+		Prompts: mcp.Container.GetMCPPrompts(),
+	}
+
+	return listPromptsResp, nil
+}
+
+// GetPromptHandler returns the messages of a specific prompt for model context protocol
+func (a *ModelContextProtocolAPI) GetPromptHandler(c *core.WebContext, jsonRPCRequest *core.JSONRPCRequest) (any, *errs.Error) {
+	var getPromptReq mcp.MCPGetPromptRequest
+
+	if jsonRPCRequest.Params != nil {
+		if err := json.Unmarshal(jsonRPCRequest.Params, &getPromptReq); err != nil {
+			return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+		}
+	} else {
+		return nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[model_context_protocols.GetPromptHandler] failed to get user \"uid:%d\" info, because %s", uid, err.Error())
+		return nil, errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_MCP_ACCESS) {
+		return nil, errs.ErrNotPermittedToPerformThisAction
+	}
+
+	result, err := mcp.Container.GetPrompt(c, &getPromptReq, user, a.CurrentConfig(), a)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return result, nil
 }
 
 // ListToolsHandler returns the list of tools for model context protocol
@@ -173,7 +285,7 @@ func (a *ModelContextProtocolAPI) ListToolsHandler(c *core.WebContext, jsonRPCRe
 	}
 
 	mcpVersion := a.getMCPVersion(c)
-	toolsInfo := mcp.Container.GetMCPTools()
+	toolsInfo := mcp.Container.GetMCPTools(c.GetTokenClaims())
 	finalToolsInfos := make([]*mcp.MCPTool, len(toolsInfo))
 
 	for i := 0; i < len(toolsInfo); i++ {
@@ -254,11 +366,31 @@ func (a *ModelContextProtocolAPI) GetAccountService() *services.AccountService {
 	return a.accounts
 }
 
+// GetTransactionPictureService implements the MCPAvailableServices interface
+func (a *ModelContextProtocolAPI) GetTransactionPictureService() *services.TransactionPictureService {
+	return a.transactionPictures
+}
+
 // GetUserService implements the MCPAvailableServices interface
 func (a *ModelContextProtocolAPI) GetUserService() *services.UserService {
 	return a.users
 }
 
+// GetWebhookService implements the MCPAvailableServices interface
+func (a *ModelContextProtocolAPI) GetWebhookService() *services.WebhookService {
+	return a.webhooks
+}
+
+// GetInsightsExplorerService implements the MCPAvailableServices interface
+func (a *ModelContextProtocolAPI) GetInsightsExplorerService() *services.InsightsExplorerService {
+	return a.insightsExplorers
+}
+
+// GetInsightsExplorerQueryExecutor implements the MCPAvailableServices interface
+func (a *ModelContextProtocolAPI) GetInsightsExplorerQueryExecutor() *reports.InsightsExplorerQueryExecutor {
+	return a.insightsExplorerQueries
+}
+
 // getMCPVersion returns the MCP protocol version from the request header
 func (a *ModelContextProtocolAPI) getMCPVersion(c *core.WebContext) string {
 	return c.GetHeader(mcp.MCPProtocolVersionHeaderName)
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// ReportsApi represents report api
+type ReportsApi struct {
+	ApiUsingConfig
+	users            *services.UserService
+	financialReports *reports.FinancialReportGenerator
+}
+
+// Initialize a report api singleton instance
+var (
+	Reports = &ReportsApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		users:            services.Users,
+		financialReports: reports.FinancialReports,
+	}
+)
+
+// FinancialReportHtmlHandler returns the financial report of current user in html format
+func (a *ReportsApi) FinancialReportHtmlHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getFinancialReportFileContent(c, "html")
+}
+
+// FinancialReportPdfHandler returns the financial report of current user in pdf format
+func (a *ReportsApi) FinancialReportPdfHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
+	return a.getFinancialReportFileContent(c, "pdf")
+}
+
+func (a *ReportsApi) getFinancialReportFileContent(c *core.WebContext, fileType string) ([]byte, string, *errs.Error) {
+	if !a.CurrentConfig().EnableFinancialReport {
+		return nil, "", errs.ErrFinancialReportNotAllowed
+	}
+
+	var reportReq models.FinancialReportRequest
+	err := c.ShouldBindQuery(&reportReq)
+
+	if err != nil {
+		log.Warnf(c, "[reports.getFinancialReportFileContent] parse request failed, because %s", err.Error())
+		return nil, "", errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[reports.getFinancialReportFileContent] cannot get client timezone, because %s", err.Error())
+		return nil, "", errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	startTime, endTime, err := reportReq.GetTimeRange(clientTimezone)
+
+	if err != nil {
+		log.Warnf(c, "[reports.getFinancialReportFileContent] cannot get time range of report period, because %s", err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Warnf(c, "[reports.getFinancialReportFileContent] failed to get user for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, "", errs.ErrUserNotFound
+	}
+
+	if user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_EXPORT_TRANSACTION) {
+		return nil, "", errs.ErrNotPermittedToPerformThisAction
+	}
+
+	report, err := a.financialReports.GenerateFinancialReport(c, user, reportReq.PeriodType, startTime, endTime, a.CurrentConfig())
+
+	if err != nil {
+		log.Errorf(c, "[reports.getFinancialReportFileContent] failed to generate financial report for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var result []byte
+
+	if fileType == "pdf" {
+		result, err = reports.RenderFinancialReportToPdf(report, user, c.GetClientLocale())
+	} else {
+		result, err = reports.RenderFinancialReportToHtml(report, user, c.GetClientLocale())
+	}
+
+	if err != nil {
+		log.Errorf(c, "[reports.getFinancialReportFileContent] failed to render financial report for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.ErrOperationFailed
+	}
+
+	period := fmt.Sprintf("%04d", reportReq.Year)
+
+	if reportReq.PeriodType == models.FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY {
+		period = fmt.Sprintf("%04d_%02d", reportReq.Year, reportReq.Month)
+	}
+
+	fileName := fmt.Sprintf("%s_financial_report_%s.%s", user.Username, period, fileType)
+
+	return result, fileName, nil
+}
@@ -60,6 +60,10 @@ func (a *ServerSettingsApi) ServerSettingsJavascriptHandler(c *core.WebContext)
 		if config.TransactionFromAIImageRecognition {
 			a.appendBooleanSetting(builder, "llmt", config.TransactionFromAIImageRecognition)
 		}
+
+		if config.TransactionFromAITextRecognition {
+			a.appendBooleanSetting(builder, "llmx", config.TransactionFromAITextRecognition)
+		}
 	}
 
 	// Always output llmo (OCR bill recognition) so frontend can rely on the key; value 0 or 1
@@ -2,6 +2,7 @@ package api
 
 import (
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/avatars"
@@ -61,6 +62,7 @@ func (a *TokensApi) TokenListHandler(c *core.WebContext) (any, *errs.Error) {
 		tokenResp := &models.TokenInfoResponse{
 			TokenId:   a.tokens.GenerateTokenId(token),
 			TokenType: token.TokenType,
+			Scopes:    core.ParseTokenScopes(token.Scopes),
 			UserAgent: token.UserAgent,
 			LastSeen:  token.LastSeenUnixTime,
 		}
@@ -109,11 +111,18 @@ func (a *TokensApi) TokenGenerateAPIHandler(c *core.WebContext) (any, *errs.Erro
 		return false, errs.ErrNotPermittedToPerformThisAction
 	}
 
+	scopes, valid := core.GetAPITokenScopes(generateAPITokenReq.Scopes)
+
+	if !valid {
+		log.Warnf(c, "[tokens.TokenGenerateAPIHandler] token scopes \"%s\" are invalid", strings.Join(generateAPITokenReq.Scopes, ","))
+		return nil, errs.ErrInvalidTokenScope
+	}
+
 	if !a.users.IsPasswordEqualsUserPassword(generateAPITokenReq.Password, user) {
 		return nil, errs.ErrUserPasswordWrong
 	}
 
-	token, claims, err := a.tokens.CreateAPIToken(c, user, generateAPITokenReq.ExpiredInSeconds)
+	token, claims, err := a.tokens.CreateAPIToken(c, user, generateAPITokenReq.ExpiredInSeconds, scopes)
 
 	if err != nil {
 		log.Errorf(c, "[tokens.TokenGenerateAPIHandler] failed to create api token for user \"uid:%d\", because %s", user.Uid, err.Error())
@@ -160,7 +169,13 @@ func (a *TokensApi) TokenGenerateMCPHandler(c *core.WebContext) (any, *errs.Erro
 		return nil, errs.ErrUserPasswordWrong
 	}
 
-	token, claims, err := a.tokens.CreateMCPToken(c, user, generateMCPTokenReq.ExpiredInSeconds)
+	var scopes []core.TokenScope
+
+	if generateMCPTokenReq.AllowDestructiveTools {
+		scopes = append(scopes, core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE)
+	}
+
+	token, claims, err := a.tokens.CreateMCPToken(c, user, generateMCPTokenReq.ExpiredInSeconds, scopes)
 
 	if err != nil {
 		log.Errorf(c, "[tokens.TokenGenerateMCPHandler] failed to create mcp token for user \"uid:%d\", because %s", user.Uid, err.Error())
@@ -8,6 +8,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
 // TransactionItemsApi represents transaction item api
@@ -68,6 +69,83 @@ func (a *TransactionItemsApi) ItemGetHandler(c *core.WebContext) (any, *errs.Err
 	return itemResp, nil
 }
 
+// ItemStatisticsHandler returns the total quantity and subtotal of transaction items of current user
+func (a *TransactionItemsApi) ItemStatisticsHandler(c *core.WebContext) (any, *errs.Error) {
+	var itemStatisticReq models.TransactionItemStatisticRequest
+	err := c.ShouldBindQuery(&itemStatisticReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_items.ItemStatisticsHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	itemIds, err := a.items.GetItemIds(itemStatisticReq.ItemIds)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_items.ItemStatisticsHandler] get item ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var maxTransactionTime int64
+	var minTransactionTime int64
+
+	if itemStatisticReq.EndTime > 0 {
+		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(itemStatisticReq.EndTime)
+	}
+
+	if itemStatisticReq.StartTime > 0 {
+		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(itemStatisticReq.StartTime)
+	}
+
+	uid := c.GetCurrentUid()
+	statistics, err := a.items.GetItemStatistics(c, uid, itemIds, maxTransactionTime, minTransactionTime)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_items.ItemStatisticsHandler] failed to get item statistics for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return statistics, nil
+}
+
+// ItemPriceHistoryHandler returns the price history of one specific transaction item of current user
+func (a *TransactionItemsApi) ItemPriceHistoryHandler(c *core.WebContext) (any, *errs.Error) {
+	var itemPriceHistoryReq models.TransactionItemPriceHistoryRequest
+	err := c.ShouldBindQuery(&itemPriceHistoryReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_items.ItemPriceHistoryHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	var maxTransactionTime int64
+	var minTransactionTime int64
+
+	if itemPriceHistoryReq.EndTime > 0 {
+		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(itemPriceHistoryReq.EndTime)
+	}
+
+	if itemPriceHistoryReq.StartTime > 0 {
+		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(itemPriceHistoryReq.StartTime)
+	}
+
+	uid := c.GetCurrentUid()
+	itemIndexes, err := a.items.GetItemPriceHistory(c, uid, itemPriceHistoryReq.Id, maxTransactionTime, minTransactionTime)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_items.ItemPriceHistoryHandler] failed to get price history of item \"id:%d\" for user \"uid:%d\", because %s", itemPriceHistoryReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	priceHistoryResps := make([]*models.TransactionItemPriceHistoryResponseItem, len(itemIndexes))
+
+	for i := 0; i < len(itemIndexes); i++ {
+		priceHistoryResps[i] = itemIndexes[i].ToTransactionItemPriceHistoryResponseItem()
+	}
+
+	return priceHistoryResps, nil
+}
+
 // ItemCreateHandler saves a new transaction item by request parameters for current user
 func (a *TransactionItemsApi) ItemCreateHandler(c *core.WebContext) (any, *errs.Error) {
 	var itemCreateReq models.TransactionItemCreateRequest
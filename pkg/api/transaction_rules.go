@@ -0,0 +1,469 @@
+package api
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionRulesApi represents transaction rule api
+type TransactionRulesApi struct {
+	rules                 *services.TransactionRuleService
+	transactionCategories *services.TransactionCategoryService
+	transactionTags       *services.TransactionTagService
+	accounts              *services.AccountService
+}
+
+// Initialize a transaction rule api singleton instance
+var (
+	TransactionRules = &TransactionRulesApi{
+		rules:                 services.TransactionRules,
+		transactionCategories: services.TransactionCategories,
+		transactionTags:       services.TransactionTags,
+		accounts:              services.Accounts,
+	}
+)
+
+// RuleListHandler returns transaction rule list of current user
+func (a *TransactionRulesApi) RuleListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	rules, err := a.rules.GetAllRulesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleListHandler] failed to get rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ruleResps := make(models.TransactionRuleInfoResponseSlice, len(rules))
+
+	for i := 0; i < len(rules); i++ {
+		ruleResps[i] = rules[i].ToTransactionRuleInfoResponse()
+	}
+
+	sort.Sort(ruleResps)
+
+	return ruleResps, nil
+}
+
+// RuleGetHandler returns one specific transaction rule of current user
+func (a *TransactionRulesApi) RuleGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleGetReq models.TransactionRuleGetRequest
+	err := c.ShouldBindQuery(&ruleGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	rule, err := a.rules.GetRuleByRuleId(c, uid, ruleGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleGetHandler] failed to get rule \"id:%d\" for user \"uid:%d\", because %s", ruleGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ruleResp := rule.ToTransactionRuleInfoResponse()
+
+	return ruleResp, nil
+}
+
+// RuleCreateHandler saves a new transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleCreateReq models.TransactionRuleCreateRequest
+	err := c.ShouldBindJSON(&ruleCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	tagIds, err := utils.StringArrayToInt64Array(ruleCreateReq.TargetTagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleCreateHandler] parse tag ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionTagIdInvalid
+	}
+
+	maxOrderId, err := a.rules.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleCreateHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	rule := a.createNewRuleModel(uid, &ruleCreateReq, tagIds, maxOrderId+1)
+
+	if err := a.validateRule(c, rule, tagIds); err != nil {
+		return nil, err
+	}
+
+	err = a.rules.CreateRule(c, rule)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleCreateHandler] failed to create rule \"id:%d\" for user \"uid:%d\", because %s", rule.RuleId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleCreateHandler] user \"uid:%d\" has created a new rule \"id:%d\" successfully", uid, rule.RuleId)
+
+	ruleResp := rule.ToTransactionRuleInfoResponse()
+
+	return ruleResp, nil
+}
+
+// RuleModifyHandler saves an existed transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleModifyReq models.TransactionRuleModifyRequest
+	err := c.ShouldBindJSON(&ruleModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	rule, err := a.rules.GetRuleByRuleId(c, uid, ruleModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleModifyHandler] failed to get rule \"id:%d\" for user \"uid:%d\", because %s", ruleModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	tagIds, err := utils.StringArrayToInt64Array(ruleModifyReq.TargetTagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleModifyHandler] parse tag ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionTagIdInvalid
+	}
+
+	newRule := &models.TransactionRule{
+		RuleId:                    rule.RuleId,
+		Uid:                       uid,
+		Name:                      ruleModifyReq.Name,
+		ConditionTransactionType:  ruleModifyReq.TransactionType,
+		ConditionKeywordMatchType: ruleModifyReq.KeywordMatchType,
+		ConditionKeyword:          ruleModifyReq.Keyword,
+		ConditionMinAmount:        ruleModifyReq.MinAmount,
+		ConditionMaxAmount:        ruleModifyReq.MaxAmount,
+		ConditionAccountId:        ruleModifyReq.AccountId,
+		ActionCategoryId:          ruleModifyReq.TargetCategoryId,
+		ActionTagIds:              a.getTagIdsText(tagIds),
+		ActionAccountId:           ruleModifyReq.TargetAccountId,
+		DisplayOrder:              rule.DisplayOrder,
+		Disabled:                  ruleModifyReq.Disabled,
+	}
+
+	if newRule.Name == rule.Name &&
+		newRule.ConditionTransactionType == rule.ConditionTransactionType &&
+		newRule.ConditionKeywordMatchType == rule.ConditionKeywordMatchType &&
+		newRule.ConditionKeyword == rule.ConditionKeyword &&
+		a.isAmountEquals(newRule.ConditionMinAmount, rule.ConditionMinAmount) &&
+		a.isAmountEquals(newRule.ConditionMaxAmount, rule.ConditionMaxAmount) &&
+		newRule.ConditionAccountId == rule.ConditionAccountId &&
+		newRule.ActionCategoryId == rule.ActionCategoryId &&
+		newRule.ActionTagIds == rule.ActionTagIds &&
+		newRule.ActionAccountId == rule.ActionAccountId &&
+		newRule.Disabled == rule.Disabled {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	if err := a.validateRule(c, newRule, tagIds); err != nil {
+		return nil, err
+	}
+
+	err = a.rules.ModifyRule(c, newRule)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleModifyHandler] failed to update rule \"id:%d\" for user \"uid:%d\", because %s", ruleModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleModifyHandler] user \"uid:%d\" has updated rule \"id:%d\" successfully", uid, ruleModifyReq.Id)
+
+	newRule.CreatedUnixTime = rule.CreatedUnixTime
+	ruleResp := newRule.ToTransactionRuleInfoResponse()
+
+	return ruleResp, nil
+}
+
+// RuleMoveHandler moves display order of existed transaction rules by request parameters for current user
+func (a *TransactionRulesApi) RuleMoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleMoveReq models.TransactionRuleMoveRequest
+	err := c.ShouldBindJSON(&ruleMoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleMoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	rules := make([]*models.TransactionRule, len(ruleMoveReq.NewDisplayOrders))
+
+	for i := 0; i < len(ruleMoveReq.NewDisplayOrders); i++ {
+		newDisplayOrder := ruleMoveReq.NewDisplayOrders[i]
+		rule := &models.TransactionRule{
+			Uid:          uid,
+			RuleId:       newDisplayOrder.Id,
+			DisplayOrder: newDisplayOrder.DisplayOrder,
+		}
+
+		rules[i] = rule
+	}
+
+	err = a.rules.ModifyRuleDisplayOrders(c, uid, rules)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleMoveHandler] failed to move rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleMoveHandler] user \"uid:%d\" has moved rules", uid)
+	return true, nil
+}
+
+// RuleDeleteHandler deletes an existed transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleDeleteReq models.TransactionRuleDeleteRequest
+	err := c.ShouldBindJSON(&ruleDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.rules.DeleteRule(c, uid, ruleDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleDeleteHandler] failed to delete rule \"id:%d\" for user \"uid:%d\", because %s", ruleDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleDeleteHandler] user \"uid:%d\" has deleted rule \"id:%d\"", uid, ruleDeleteReq.Id)
+	return true, nil
+}
+
+// RuleApplyHandler applies transaction rules to existed transactions (or only previews the changes if dry run) by request parameters for current user
+func (a *TransactionRulesApi) RuleApplyHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleApplyReq models.TransactionRuleApplyRequest
+	err := c.ShouldBindJSON(&ruleApplyReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleApplyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if ruleApplyReq.StartTime > 0 && ruleApplyReq.EndTime > 0 && ruleApplyReq.StartTime > ruleApplyReq.EndTime {
+		return nil, errs.ErrParameterInvalid
+	}
+
+	ruleIds, err := utils.StringArrayToInt64Array(ruleApplyReq.RuleIds)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleApplyHandler] parse rule ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionRuleIdInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	allRules, err := a.rules.GetAllEnabledRulesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to get rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	rules := allRules
+
+	if len(ruleIds) > 0 {
+		rules = make([]*models.TransactionRule, 0, len(ruleIds))
+		ruleIdExists := make(map[int64]bool, len(ruleIds))
+
+		for i := 0; i < len(ruleIds); i++ {
+			ruleIdExists[ruleIds[i]] = true
+		}
+
+		for i := 0; i < len(allRules); i++ {
+			if ruleIdExists[allRules[i].RuleId] {
+				rules = append(rules, allRules[i])
+			}
+		}
+	}
+
+	categories, err := a.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	tags, err := a.transactionTags.GetAllTagsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to get tags for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var maxTransactionTime int64
+	var minTransactionTime int64
+
+	if ruleApplyReq.EndTime > 0 {
+		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(ruleApplyReq.EndTime)
+	}
+
+	if ruleApplyReq.StartTime > 0 {
+		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(ruleApplyReq.StartTime)
+	}
+
+	results, err := a.rules.ApplyRulesToTransactions(c, uid, rules, maxTransactionTime, minTransactionTime, a.transactionCategories.GetCategoryMapByList(categories), a.transactionTags.GetTagMapByList(tags), ruleApplyReq.DryRun)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleApplyHandler] failed to apply rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if !ruleApplyReq.DryRun {
+		log.Infof(c, "[transaction_rules.RuleApplyHandler] user \"uid:%d\" has applied rules to %d transactions", uid, len(results))
+	}
+
+	ruleApplyResp := &models.TransactionRuleApplyResponse{
+		DryRun:       ruleApplyReq.DryRun,
+		MatchedCount: len(results),
+		Items:        make([]*models.TransactionRuleApplyResponseItem, len(results)),
+	}
+
+	for i := 0; i < len(results); i++ {
+		ruleApplyResp.Items[i] = results[i].ToTransactionRuleApplyResponseItem()
+	}
+
+	return ruleApplyResp, nil
+}
+
+func (a *TransactionRulesApi) validateRule(c *core.WebContext, rule *models.TransactionRule, tagIds []int64) *errs.Error {
+	if !rule.IsKeywordValid() {
+		return errs.ErrTransactionRuleKeywordInvalid
+	}
+
+	if rule.ConditionMinAmount != nil && rule.ConditionMaxAmount != nil && *rule.ConditionMinAmount > *rule.ConditionMaxAmount {
+		return errs.ErrTransactionRuleAmountRangeInvalid
+	}
+
+	if !rule.HasAction() {
+		return errs.ErrTransactionRuleHasNoAction
+	}
+
+	if len(tagIds) > models.MaximumTagsCountOfTransactionRule {
+		return errs.ErrTransactionRuleHasTooManyTags
+	}
+
+	if rule.ActionCategoryId > 0 {
+		categories, err := a.transactionCategories.GetCategoriesByCategoryIds(c, rule.Uid, []int64{rule.ActionCategoryId})
+
+		if err != nil {
+			log.Errorf(c, "[transaction_rules.validateRule] failed to get category \"id:%d\" for user \"uid:%d\", because %s", rule.ActionCategoryId, rule.Uid, err.Error())
+			return errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		category, exists := categories[rule.ActionCategoryId]
+
+		if !exists {
+			return errs.ErrTransactionCategoryNotFound
+		}
+
+		if category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+			return errs.ErrCannotUsePrimaryCategoryForTransaction
+		}
+	}
+
+	if len(tagIds) > 0 {
+		tags, err := a.transactionTags.GetTagsByTagIds(c, rule.Uid, utils.ToUniqueInt64Slice(tagIds))
+
+		if err != nil {
+			log.Errorf(c, "[transaction_rules.validateRule] failed to get tags for user \"uid:%d\", because %s", rule.Uid, err.Error())
+			return errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		for i := 0; i < len(tagIds); i++ {
+			if _, exists := tags[tagIds[i]]; !exists {
+				return errs.ErrTransactionTagNotFound
+			}
+		}
+	}
+
+	accountIds := make([]int64, 0, 2)
+
+	if rule.ConditionAccountId > 0 {
+		accountIds = append(accountIds, rule.ConditionAccountId)
+	}
+
+	if rule.ActionAccountId > 0 {
+		accountIds = append(accountIds, rule.ActionAccountId)
+	}
+
+	if len(accountIds) > 0 {
+		accounts, err := a.accounts.GetAccountsByAccountIds(c, rule.Uid, utils.ToUniqueInt64Slice(accountIds))
+
+		if err != nil {
+			log.Errorf(c, "[transaction_rules.validateRule] failed to get accounts for user \"uid:%d\", because %s", rule.Uid, err.Error())
+			return errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		for i := 0; i < len(accountIds); i++ {
+			account, exists := accounts[accountIds[i]]
+
+			if !exists {
+				return errs.ErrAccountNotFound
+			}
+
+			if account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+				return errs.ErrAccountTypeInvalid
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *TransactionRulesApi) createNewRuleModel(uid int64, ruleCreateReq *models.TransactionRuleCreateRequest, tagIds []int64, order int32) *models.TransactionRule {
+	return &models.TransactionRule{
+		Uid:                       uid,
+		Name:                      ruleCreateReq.Name,
+		ConditionTransactionType:  ruleCreateReq.TransactionType,
+		ConditionKeywordMatchType: ruleCreateReq.KeywordMatchType,
+		ConditionKeyword:          ruleCreateReq.Keyword,
+		ConditionMinAmount:        ruleCreateReq.MinAmount,
+		ConditionMaxAmount:        ruleCreateReq.MaxAmount,
+		ConditionAccountId:        ruleCreateReq.AccountId,
+		ActionCategoryId:          ruleCreateReq.TargetCategoryId,
+		ActionTagIds:              a.getTagIdsText(tagIds),
+		ActionAccountId:           ruleCreateReq.TargetAccountId,
+		DisplayOrder:              order,
+		Disabled:                  ruleCreateReq.Disabled,
+	}
+}
+
+func (a *TransactionRulesApi) getTagIdsText(tagIds []int64) string {
+	tagIds = utils.ToUniqueInt64Slice(tagIds)
+	textualTagIds := make([]string, len(tagIds))
+
+	for i := 0; i < len(tagIds); i++ {
+		textualTagIds[i] = utils.Int64ToString(tagIds[i])
+	}
+
+	return strings.Join(textualTagIds, ",")
+}
+
+func (a *TransactionRulesApi) isAmountEquals(amount1 *int64, amount2 *int64) bool {
+	if amount1 == nil || amount2 == nil {
+		return amount1 == amount2
+	}
+
+	return *amount1 == *amount2
+}
@@ -138,10 +138,9 @@ func (a *TransactionTemplatesApi) TemplateCreateHandler(c *core.WebContext) (any
 			return nil, errs.ErrScheduledTransactionFrequencyInvalid
 		}
 
-		if *templateCreateReq.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateCreateReq.ScheduledFrequency != "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
-		} else if *templateCreateReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateCreateReq.ScheduledFrequency == "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		if err := models.IsScheduledFrequencyValid(*templateCreateReq.ScheduledFrequencyType, a.getNormalizedFrequency(*templateCreateReq.ScheduledFrequencyType, *templateCreateReq.ScheduledFrequency), templateCreateReq.ScheduledStartDate != nil); err != nil {
+			log.Warnf(c, "[transaction_templates.TemplateCreateHandler] scheduled frequency invalid, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrScheduledTransactionFrequencyInvalid)
 		}
 	}
 
@@ -237,10 +236,9 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 			return nil, errs.ErrScheduledTransactionFrequencyInvalid
 		}
 
-		if *templateModifyReq.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateModifyReq.ScheduledFrequency != "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
-		} else if *templateModifyReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateModifyReq.ScheduledFrequency == "" {
-			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		if err := models.IsScheduledFrequencyValid(*templateModifyReq.ScheduledFrequencyType, a.getNormalizedFrequency(*templateModifyReq.ScheduledFrequencyType, *templateModifyReq.ScheduledFrequency), templateModifyReq.ScheduledStartDate != nil); err != nil {
+			log.Warnf(c, "[transaction_templates.TemplateModifyHandler] scheduled frequency invalid, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrScheduledTransactionFrequencyInvalid)
 		}
 	}
 
@@ -265,10 +263,14 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 
 	if template.TemplateType == models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE {
 		newTemplate.ScheduledFrequencyType = *templateModifyReq.ScheduledFrequencyType
-		newTemplate.ScheduledFrequency = a.getOrderedFrequencyValues(*templateModifyReq.ScheduledFrequency)
+		newTemplate.ScheduledFrequency = a.getNormalizedFrequency(*templateModifyReq.ScheduledFrequencyType, *templateModifyReq.ScheduledFrequency)
 		newTemplate.ScheduledAt = a.getUTCScheduledAt(*templateModifyReq.ScheduledTimezoneUtcOffset)
 		newTemplate.ScheduledTimezoneUtcOffset = *templateModifyReq.ScheduledTimezoneUtcOffset
 
+		if templateModifyReq.ScheduledMaxOccurrences != nil {
+			newTemplate.ScheduledMaxOccurrences = *templateModifyReq.ScheduledMaxOccurrences
+		}
+
 		if templateModifyReq.ScheduledStartDate != nil {
 			startTime, err := utils.ParseFromLongDateFirstTime(*templateModifyReq.ScheduledStartDate, *templateModifyReq.ScheduledTimezoneUtcOffset)
 
@@ -316,7 +318,8 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 				newTemplate.ScheduledStartTime == template.ScheduledStartTime &&
 				newTemplate.ScheduledEndTime == template.ScheduledEndTime &&
 				newTemplate.ScheduledAt == template.ScheduledAt &&
-				newTemplate.ScheduledTimezoneUtcOffset == template.ScheduledTimezoneUtcOffset {
+				newTemplate.ScheduledTimezoneUtcOffset == template.ScheduledTimezoneUtcOffset &&
+				newTemplate.ScheduledMaxOccurrences == template.ScheduledMaxOccurrences {
 				return nil, errs.ErrNothingWillBeUpdated
 			}
 		}
@@ -476,10 +479,14 @@ func (a *TransactionTemplatesApi) createNewTemplateModel(uid int64, templateCrea
 
 	if templateCreateReq.TemplateType == models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE {
 		template.ScheduledFrequencyType = *templateCreateReq.ScheduledFrequencyType
-		template.ScheduledFrequency = a.getOrderedFrequencyValues(*templateCreateReq.ScheduledFrequency)
+		template.ScheduledFrequency = a.getNormalizedFrequency(*templateCreateReq.ScheduledFrequencyType, *templateCreateReq.ScheduledFrequency)
 		template.ScheduledAt = a.getUTCScheduledAt(*templateCreateReq.ScheduledTimezoneUtcOffset)
 		template.ScheduledTimezoneUtcOffset = *templateCreateReq.ScheduledTimezoneUtcOffset
 
+		if templateCreateReq.ScheduledMaxOccurrences != nil {
+			template.ScheduledMaxOccurrences = *templateCreateReq.ScheduledMaxOccurrences
+		}
+
 		if templateCreateReq.ScheduledStartDate != nil {
 			startTime, err := utils.ParseFromLongDateFirstTime(*templateCreateReq.ScheduledStartDate, *templateCreateReq.ScheduledTimezoneUtcOffset)
 
@@ -520,6 +527,14 @@ func (a *TransactionTemplatesApi) getUTCScheduledAt(scheduledTimezoneUtcOffset i
 	return int16(minutesElapsedOfDayInUtc)
 }
 
+func (a *TransactionTemplatesApi) getNormalizedFrequency(frequencyType models.TransactionScheduleFrequencyType, frequencyValue string) string {
+	if frequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE {
+		return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(frequencyValue)), "RRULE:")
+	}
+
+	return a.getOrderedFrequencyValues(frequencyValue)
+}
+
 func (a *TransactionTemplatesApi) getOrderedFrequencyValues(frequencyValue string) string {
 	if frequencyValue == "" {
 		return ""
@@ -2,7 +2,6 @@ package api
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"math"
 	"sort"
@@ -17,6 +16,8 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/importjobs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
@@ -25,6 +26,8 @@ import (
 )
 
 const pageCountForAccountStatement = 1000
+const importJobStatusCheckInterval = 500 * time.Millisecond
+const importFileParseRecordExpiration = 24 * time.Hour
 
 // TransactionsApi represents transaction api
 type TransactionsApi struct {
@@ -34,9 +37,14 @@ type TransactionsApi struct {
 	transactionCategories *services.TransactionCategoryService
 	transactionTags       *services.TransactionTagService
 	transactionItems      *services.TransactionItemService
+	transactionSplits     *services.TransactionSplitService
 	transactionPictures   *services.TransactionPictureService
+	transactionRules      *services.TransactionRuleService
 	accounts              *services.AccountService
 	users                 *services.UserService
+	webhooks              *services.WebhookService
+	importJobs            *services.ImportJobService
+	importJobWorkers      *importjobs.ImportJobWorkerPool
 }
 
 // Initialize a transaction api singleton instance
@@ -55,9 +63,14 @@ var (
 		transactionCategories: services.TransactionCategories,
 		transactionTags:       services.TransactionTags,
 		transactionItems:      services.TransactionItems,
+		transactionSplits:     services.TransactionSplits,
 		transactionPictures:   services.TransactionPictures,
+		transactionRules:      services.TransactionRules,
 		accounts:              services.Accounts,
 		users:                 services.Users,
+		webhooks:              services.Webhooks,
+		importJobs:            services.ImportJobs,
+		importJobWorkers:      importjobs.Container,
 	}
 )
 
@@ -143,7 +156,7 @@ func (a *TransactionsApi) TransactionListHandler(c *core.WebContext) (any, *errs
 	}
 
 	uid := c.GetCurrentUid()
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -258,7 +271,7 @@ func (a *TransactionsApi) TransactionMonthListHandler(c *core.WebContext) (any,
 	}
 
 	uid := c.GetCurrentUid()
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -346,7 +359,7 @@ func (a *TransactionsApi) TransactionListAllHandler(c *core.WebContext) (any, *e
 	}
 
 	uid := c.GetCurrentUid()
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -440,7 +453,7 @@ func (a *TransactionsApi) TransactionReconciliationStatementHandler(c *core.WebC
 	}
 
 	uid := c.GetCurrentUid()
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -572,7 +585,17 @@ func (a *TransactionsApi) TransactionStatisticsHandler(c *core.WebContext) (any,
 	}
 
 	uid := c.GetCurrentUid()
-	totalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalInflowAndOutflow(c, uid, statisticReq.StartTime, statisticReq.EndTime, tagFilters, noTags, itemFilters, noItems, statisticReq.Keyword, clientTimezone, statisticReq.UseTransactionTimezone)
+	var amountExchanger *models.TransactionAmountExchanger
+
+	if statisticReq.ExchangeAtTransactionDate {
+		amountExchanger, err = a.getTransactionAmountExchanger(c, uid, statisticReq.StartTime, statisticReq.EndTime)
+
+		if err != nil {
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	totalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalInflowAndOutflow(c, uid, statisticReq.StartTime, statisticReq.EndTime, tagFilters, noTags, itemFilters, noItems, statisticReq.Keyword, clientTimezone, statisticReq.UseTransactionTimezone, amountExchanger)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionStatisticsHandler] failed to get accounts and categories total income and expense for user \"uid:%d\", because %s", uid, err.Error())
@@ -598,6 +621,10 @@ func (a *TransactionsApi) TransactionStatisticsHandler(c *core.WebContext) (any,
 			statisticResp.Items[i].RelatedAccountId = totalAmountItem.RelatedAccountId
 			statisticResp.Items[i].RelatedAccountType, _ = totalAmountItem.Type.ToTransactionRelatedAccountType()
 		}
+
+		if amountExchanger != nil {
+			statisticResp.Items[i].Currency = amountExchanger.TargetCurrency
+		}
 	}
 
 	return statisticResp, nil
@@ -652,7 +679,29 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 	}
 
 	uid := c.GetCurrentUid()
-	allMonthlyTotalAmounts, err := a.transactions.GetAccountsAndCategoriesMonthlyInflowAndOutflow(c, uid, startYear, startMonth, endYear, endMonth, tagFilters, noTags, itemFilters, noItems, statisticTrendsReq.Keyword, clientTimezone, statisticTrendsReq.UseTransactionTimezone)
+	var amountExchanger *models.TransactionAmountExchanger
+
+	if statisticTrendsReq.ExchangeAtTransactionDate {
+		var startUnixTime, endUnixTime int64
+
+		if startYear > 0 && startMonth > 0 {
+			startTransactionTime, _, _ := utils.GetTransactionTimeRangeByYearMonth(startYear, startMonth)
+			startUnixTime = utils.GetUnixTimeFromTransactionTime(startTransactionTime)
+		}
+
+		if endYear > 0 && endMonth > 0 {
+			_, endTransactionTime, _ := utils.GetTransactionTimeRangeByYearMonth(endYear, endMonth)
+			endUnixTime = utils.GetUnixTimeFromTransactionTime(endTransactionTime)
+		}
+
+		amountExchanger, err = a.getTransactionAmountExchanger(c, uid, startUnixTime, endUnixTime)
+
+		if err != nil {
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	allMonthlyTotalAmounts, err := a.transactions.GetAccountsAndCategoriesMonthlyInflowAndOutflow(c, uid, startYear, startMonth, endYear, endMonth, tagFilters, noTags, itemFilters, noItems, statisticTrendsReq.Keyword, clientTimezone, statisticTrendsReq.UseTransactionTimezone, amountExchanger)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionStatisticsTrendsHandler] failed to get accounts and categories total income and expense for user \"uid:%d\", because %s", uid, err.Error())
@@ -680,6 +729,10 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 				monthlyStatisticResp.Items[i].RelatedAccountId = totalAmountItem.RelatedAccountId
 				monthlyStatisticResp.Items[i].RelatedAccountType, _ = totalAmountItem.Type.ToTransactionRelatedAccountType()
 			}
+
+			if amountExchanger != nil {
+				monthlyStatisticResp.Items[i].Currency = amountExchanger.TargetCurrency
+			}
 		}
 
 		statisticTrendsResp = append(statisticTrendsResp, monthlyStatisticResp)
@@ -755,6 +808,227 @@ func (a *TransactionsApi) TransactionStatisticsAssetTrendsHandler(c *core.WebCon
 	return statisticAssetTrendsResp, nil
 }
 
+// TransactionForecastHandler returns upcoming scheduled transactions and projected daily account balances of current user
+func (a *TransactionsApi) TransactionForecastHandler(c *core.WebContext) (any, *errs.Error) {
+	var forecastReq models.TransactionForecastRequest
+	err := c.ShouldBindQuery(&forecastReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionForecastHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionForecastHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	startUnixTime := time.Now().Unix()
+	endUnixTime := forecastReq.EndTime
+
+	if endUnixTime < startUnixTime || endUnixTime-startUnixTime > int64(models.MaximumTransactionForecastDays)*24*60*60 {
+		log.Warnf(c, "[transactions.TransactionForecastHandler] forecast end time \"%d\" is invalid", endUnixTime)
+		return nil, errs.ErrTransactionForecastTimeRangeInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	forecasts := make([]*models.ScheduledTransactionForecast, 0)
+
+	if a.CurrentConfig().EnableScheduledTransaction {
+		// the scheduled transactions of today which have not been created yet are also included
+		forecasts, err = a.transactions.GetScheduledTransactionForecasts(c, uid, startUnixTime, endUnixTime)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionForecastHandler] failed to get scheduled transaction forecasts for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	accountDailyBalances, err := a.transactions.GetAllAccountsForecastDailyOpeningAndClosingBalance(c, uid, forecasts, startUnixTime, endUnixTime, clientTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionForecastHandler] failed to get projected account balances for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionForecastHandler] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	forecastResp := &models.TransactionForecastResponse{
+		Transactions:            make([]*models.ScheduledTransactionForecastResponseItem, len(forecasts)),
+		DailyBalances:           make(models.TransactionStatisticAssetTrendsResponseItemSlice, 0, len(accountDailyBalances)),
+		NegativeBalanceAccounts: make([]*models.TransactionForecastNegativeBalanceResponseItem, 0),
+	}
+
+	for i := 0; i < len(forecasts); i++ {
+		forecastResp.Transactions[i] = forecasts[i].ToScheduledTransactionForecastResponseItem()
+	}
+
+	for yearMonthDay, dailyAccountBalances := range accountDailyBalances {
+		dailyStatisticResp := &models.TransactionStatisticAssetTrendsResponseItem{
+			Year:  yearMonthDay / 10000,
+			Month: (yearMonthDay % 10000) / 100,
+			Day:   yearMonthDay % 100,
+			Items: make([]*models.TransactionStatisticAssetTrendsResponseDataItem, len(dailyAccountBalances)),
+		}
+
+		for i := 0; i < len(dailyAccountBalances); i++ {
+			accountBalance := dailyAccountBalances[i]
+			dailyStatisticResp.Items[i] = &models.TransactionStatisticAssetTrendsResponseDataItem{
+				AccountId:             accountBalance.AccountId,
+				AccountOpeningBalance: accountBalance.AccountOpeningBalance,
+				AccountClosingBalance: accountBalance.AccountClosingBalance,
+			}
+		}
+
+		forecastResp.DailyBalances = append(forecastResp.DailyBalances, dailyStatisticResp)
+	}
+
+	sort.Sort(forecastResp.DailyBalances)
+
+	assetAccountIds := make(map[int64]bool)
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i].Category.IsAsset() {
+			assetAccountIds[accounts[i].AccountId] = true
+		}
+	}
+
+	negativeBalanceAccountIds := make(map[int64]bool)
+
+	for i := 0; i < len(forecastResp.DailyBalances); i++ {
+		dailyBalance := forecastResp.DailyBalances[i]
+
+		for j := 0; j < len(dailyBalance.Items); j++ {
+			accountBalance := dailyBalance.Items[j]
+
+			if accountBalance.AccountClosingBalance >= 0 || !assetAccountIds[accountBalance.AccountId] || negativeBalanceAccountIds[accountBalance.AccountId] {
+				continue
+			}
+
+			negativeBalanceAccountIds[accountBalance.AccountId] = true
+			forecastResp.NegativeBalanceAccounts = append(forecastResp.NegativeBalanceAccounts, &models.TransactionForecastNegativeBalanceResponseItem{
+				AccountId:             accountBalance.AccountId,
+				Year:                  dailyBalance.Year,
+				Month:                 dailyBalance.Month,
+				Day:                   dailyBalance.Day,
+				AccountClosingBalance: accountBalance.AccountClosingBalance,
+			})
+		}
+	}
+
+	return forecastResp, nil
+}
+
+// TransactionUnrealisedExchangeGainLossHandler returns the default currency values of all foreign currency accounts of current user, split into transaction flows and revaluation by exchange rate movement
+func (a *TransactionsApi) TransactionUnrealisedExchangeGainLossHandler(c *core.WebContext) (any, *errs.Error) {
+	var gainLossReq models.UnrealisedExchangeGainLossRequest
+	err := c.ShouldBindQuery(&gainLossReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionUnrealisedExchangeGainLossHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if gainLossReq.EndTime < gainLossReq.StartTime || gainLossReq.EndTime-gainLossReq.StartTime > int64(models.MaximumUnrealisedExchangeGainLossDays)*24*60*60 {
+		log.Warnf(c, "[transactions.TransactionUnrealisedExchangeGainLossHandler] time range from \"%d\" to \"%d\" is invalid", gainLossReq.StartTime, gainLossReq.EndTime)
+		return nil, errs.ErrUnrealisedExchangeGainLossTimeRangeInvalid
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionUnrealisedExchangeGainLossHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := getCurrentDataUser(c, a.users)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionUnrealisedExchangeGainLossHandler] failed to get user \"uid:%d\" info, because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionUnrealisedExchangeGainLossHandler] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	gainLossResp := &models.UnrealisedExchangeGainLossResponse{
+		Currency: user.DefaultCurrency,
+		Accounts: make([]*models.AccountUnrealisedExchangeGainLossResponse, 0),
+	}
+
+	foreignCurrencyAccounts := make([]*models.Account, 0)
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i].Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT && accounts[i].Currency != user.DefaultCurrency {
+			foreignCurrencyAccounts = append(foreignCurrencyAccounts, accounts[i])
+		}
+	}
+
+	if len(foreignCurrencyAccounts) < 1 {
+		return gainLossResp, nil
+	}
+
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(gainLossReq.EndTime)
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(gainLossReq.StartTime)
+	accountDailyBalances, err := a.transactions.GetAllAccountsDailyOpeningAndClosingBalance(c, uid, maxTransactionTime, minTransactionTime, clientTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionUnrealisedExchangeGainLossHandler] failed to get account balances from \"%d\" to \"%d\" for user \"uid:%d\", because %s", gainLossReq.StartTime, gainLossReq.EndTime, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	exchangeRates, err := exchangerates.Container.GetHistoricalExchangeRateMap(c, c.GetCurrentOperatorUid(), gainLossReq.StartTime, gainLossReq.EndTime, a.CurrentConfig())
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accountsDailyBalances := make(map[int64]map[int32]*models.TransactionWithAccountBalance)
+
+	for yearMonthDay, dailyAccountBalances := range accountDailyBalances {
+		for i := 0; i < len(dailyAccountBalances); i++ {
+			accountBalance := dailyAccountBalances[i]
+			dailyBalances, exists := accountsDailyBalances[accountBalance.AccountId]
+
+			if !exists {
+				dailyBalances = make(map[int32]*models.TransactionWithAccountBalance)
+				accountsDailyBalances[accountBalance.AccountId] = dailyBalances
+			}
+
+			dailyBalances[yearMonthDay] = accountBalance
+		}
+	}
+
+	startTime := time.Unix(gainLossReq.StartTime, 0).In(clientTimezone)
+	endTime := time.Unix(gainLossReq.EndTime, 0).In(clientTimezone)
+
+	for i := 0; i < len(foreignCurrencyAccounts); i++ {
+		account := foreignCurrencyAccounts[i]
+		accountGainLoss, ok := models.CalculateAccountUnrealisedExchangeGainLoss(account, user.DefaultCurrency, accountsDailyBalances[account.AccountId], startTime, endTime, exchangeRates)
+
+		if !ok {
+			log.Warnf(c, "[transactions.TransactionUnrealisedExchangeGainLossHandler] cannot exchange balance of account \"id:%d\" from \"%s\" to \"%s\" for user \"uid:%d\"", account.AccountId, account.Currency, user.DefaultCurrency, uid)
+			return nil, errs.ErrExchangeRateNotFoundForAccountBalance
+		}
+
+		gainLossResp.Accounts = append(gainLossResp.Accounts, accountGainLoss)
+	}
+
+	return gainLossResp, nil
+}
+
 // TransactionAmountsHandler returns transaction amounts of current user
 func (a *TransactionsApi) TransactionAmountsHandler(c *core.WebContext) (any, *errs.Error) {
 	var transactionAmountsReq models.TransactionAmountsRequest
@@ -912,7 +1186,7 @@ func (a *TransactionsApi) TransactionGetHandler(c *core.WebContext) (any, *errs.
 	}
 
 	uid := c.GetCurrentUid()
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -995,9 +1269,13 @@ func (a *TransactionsApi) TransactionGetHandler(c *core.WebContext) (any, *errs.
 
 	transactionEditable := transaction.IsEditable(user, clientTimezone, accountMap[transaction.AccountId], accountMap[transaction.RelatedAccountId])
 	transactionTagIds := allTransactionTagIds[transaction.TransactionId]
-	transactionItemIds, _ := a.transactionItems.GetAllItemIdsOfTransactions(c, uid, []int64{transaction.TransactionId})
-	transactionItemIdsSlice := transactionItemIds[transaction.TransactionId]
+	transactionItemIndexes, _ := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, []int64{transaction.TransactionId})
+	transactionItemIdsSlice := a.transactionItems.GetGroupedTransactionItemIds(transactionItemIndexes)[transaction.TransactionId]
+	transactionItemDetails := a.transactionItems.GetGroupedTransactionItemDetails(transactionItemIndexes)[transaction.TransactionId]
 	transactionResp := transaction.ToTransactionInfoResponse(transactionTagIds, transactionItemIdsSlice, transactionEditable)
+	transactionResp.ItemDetails = a.getTransactionItemDetailResponses(transactionItemIdsSlice, transactionItemDetails)
+	transactionSplits, _ := a.transactionSplits.GetAllSplitsOfTransactions(c, uid, []int64{transaction.TransactionId})
+	transactionResp.Splits = a.transactionSplits.GetTransactionSplitInfoResponses(transactionSplits[transaction.TransactionId])
 
 	if !transactionGetReq.TrimAccount {
 		if sourceAccount := accountMap[transaction.AccountId]; sourceAccount != nil {
@@ -1081,6 +1359,20 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrTransactionHasTooManyItems
 	}
 
+	itemDetails, err := models.GetTransactionItemDetails(transactionCreateReq.ItemDetails, itemIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCreateHandler] parse item details failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrTransactionItemDetailInvalid)
+	}
+
+	splits, err := models.GetTransactionSplits(transactionCreateReq.Splits)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCreateHandler] parse split lines failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	uid := c.GetCurrentUid()
 
 	if len(itemIds) > 0 {
@@ -1122,7 +1414,7 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrTransactionDestinationAmountCannotBeSet
 	}
 
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -1132,7 +1424,7 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrUserNotFound
 	}
 
-	transaction := a.createNewTransactionModel(uid, &transactionCreateReq, c.ClientIP())
+	transaction := a.createNewTransactionModel(uid, c.GetCurrentOperatorUid(), &transactionCreateReq, c.ClientIP())
 	transactionEditable := user.CanEditTransactionByTransactionTime(transaction.TransactionTime, clientTimezone)
 
 	if !transactionEditable {
@@ -1182,7 +1474,7 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		}
 	}
 
-	err = a.transactions.CreateTransaction(c, transaction, tagIds, itemIds, pictureIds)
+	err = a.transactions.CreateTransaction(c, transaction, tagIds, itemIds, itemDetails, splits, pictureIds)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionCreateHandler] failed to create transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
@@ -1191,8 +1483,12 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 
 	log.Infof(c, "[transactions.TransactionCreateHandler] user \"uid:%d\" has created a new transaction \"id:%d\" successfully", uid, transaction.TransactionId)
 
+	a.triggerWebhookEvent(c, uid, models.WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED, models.NewWebhookTransactionEventData(transaction, tagIds))
+
 	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION, uid, transactionCreateReq.ClientSessionId, utils.Int64ToString(transaction.TransactionId))
 	transactionResp := transaction.ToTransactionInfoResponse(tagIds, itemIds, transactionEditable)
+	transactionResp.ItemDetails = a.getTransactionItemDetailResponses(itemIds, itemDetails)
+	transactionResp.Splits = a.transactionSplits.GetTransactionSplitInfoResponses(splits)
 	transactionResp.Pictures = a.GetTransactionPictureInfoResponseList(pictureInfos)
 
 	return transactionResp, nil
@@ -1237,6 +1533,20 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrTransactionHasTooManyItems
 	}
 
+	itemDetails, err := models.GetTransactionItemDetails(transactionModifyReq.ItemDetails, itemIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] parse item details failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrTransactionItemDetailInvalid)
+	}
+
+	splits, err := models.GetTransactionSplits(transactionModifyReq.Splits)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] parse split lines failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	pictureIds, err := utils.StringArrayToInt64Array(transactionModifyReq.PictureIds)
 
 	if err != nil {
@@ -1249,7 +1559,7 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 	}
 
 	uid := c.GetCurrentUid()
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -1292,14 +1602,34 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		transactionTagIds = make([]int64, 0, 0)
 	}
 
-	allTransactionItemIds, err := a.transactionItems.GetAllItemIdsOfTransactions(c, uid, []int64{transaction.TransactionId})
+	transactionItemIndexes, err := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, []int64{transaction.TransactionId})
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionModifyHandler] failed to get transaction item ids for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	transactionItemIds := allTransactionItemIds[transaction.TransactionId]
+	transactionItemIds := a.transactionItems.GetGroupedTransactionItemIds(transactionItemIndexes)[transaction.TransactionId]
+	transactionItemDetails := a.transactionItems.GetGroupedTransactionItemDetails(transactionItemIndexes)[transaction.TransactionId]
+	newTransactionItemDetails := itemDetails
+
+	if newTransactionItemDetails == nil {
+		newTransactionItemDetails = transactionItemDetails
+	}
+
+	allTransactionSplits, err := a.transactionSplits.GetAllSplitsOfTransactions(c, uid, []int64{transaction.TransactionId})
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionModifyHandler] failed to get transaction split lines for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionSplits := allTransactionSplits[transaction.TransactionId]
+	newTransactionSplits := splits
+
+	if newTransactionSplits == nil {
+		newTransactionSplits = transactionSplits
+	}
 
 	if transactionItemIds == nil {
 		transactionItemIds = make([]int64, 0, 0)
@@ -1340,6 +1670,7 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		Amount:            transactionModifyReq.SourceAmount,
 		HideAmount:        transactionModifyReq.HideAmount,
 		Comment:           transactionModifyReq.Comment,
+		UpdatedByUid:      c.GetCurrentOperatorUid(),
 	}
 
 	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
@@ -1365,6 +1696,8 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		newTransaction.GeoLatitude == transaction.GeoLatitude &&
 		utils.Int64SliceEquals(tagIds, transactionTagIds) &&
 		utils.Int64SliceEquals(itemIds, transactionItemIds) &&
+		a.isTransactionItemDetailsEquals(itemIds, newTransactionItemDetails, transactionItemDetails) &&
+		a.isTransactionSplitsEquals(newTransactionSplits, transactionSplits) &&
 		utils.Int64SliceEquals(pictureIds, transactionPictureIds) {
 		return nil, errs.ErrNothingWillBeUpdated
 	}
@@ -1426,7 +1759,7 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		}
 	}
 
-	err = a.transactions.ModifyTransaction(c, newTransaction, len(transactionTagIds), addTransactionTagIds, removeTransactionTagIds, addTransactionItemIds, removeTransactionItemIds, addTransactionPictureIds, removeTransactionPictureIds)
+	err = a.transactions.ModifyTransaction(c, newTransaction, len(transactionTagIds), addTransactionTagIds, removeTransactionTagIds, addTransactionItemIds, removeTransactionItemIds, itemDetails, splits, addTransactionPictureIds, removeTransactionPictureIds)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionModifyHandler] failed to update transaction \"id:%d\" for user \"uid:%d\", because %s", transactionModifyReq.Id, uid, err.Error())
@@ -1436,7 +1769,11 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 	log.Infof(c, "[transactions.TransactionModifyHandler] user \"uid:%d\" has updated transaction \"id:%d\" successfully", uid, transactionModifyReq.Id)
 
 	newTransaction.Type = transaction.Type
+	a.triggerWebhookEvent(c, uid, models.WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED, models.NewWebhookTransactionEventData(newTransaction, tagIds))
+
 	newTransactionResp := newTransaction.ToTransactionInfoResponse(tagIds, itemIds, transactionEditable)
+	newTransactionResp.ItemDetails = a.getTransactionItemDetailResponses(itemIds, newTransactionItemDetails)
+	newTransactionResp.Splits = a.transactionSplits.GetTransactionSplitInfoResponses(newTransactionSplits)
 	newTransactionResp.Pictures = a.GetTransactionPictureInfoResponseList(newPictureInfos)
 
 	return newTransactionResp, nil
@@ -1517,7 +1854,7 @@ func (a *TransactionsApi) TransactionDeleteHandler(c *core.WebContext) (any, *er
 	}
 
 	uid := c.GetCurrentUid()
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -1553,6 +1890,9 @@ func (a *TransactionsApi) TransactionDeleteHandler(c *core.WebContext) (any, *er
 	}
 
 	log.Infof(c, "[transactions.TransactionDeleteHandler] user \"uid:%d\" has deleted transaction \"id:%d\"", uid, transactionDeleteReq.Id)
+
+	a.triggerWebhookEvent(c, uid, models.WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED, models.NewWebhookTransactionEventData(transaction, nil))
+
 	return true, nil
 }
 
@@ -1802,7 +2142,7 @@ func (a *TransactionsApi) TransactionParseImportFileHandler(c *core.WebContext)
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
@@ -1816,6 +2156,7 @@ func (a *TransactionsApi) TransactionParseImportFileHandler(c *core.WebContext)
 		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
 
+	parseUnixTime := time.Now().Unix()
 	accounts, err := a.accounts.GetAllAccountsByUid(c, user.Uid)
 
 	if err != nil {
@@ -1850,6 +2191,26 @@ func (a *TransactionsApi) TransactionParseImportFileHandler(c *core.WebContext)
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	rules, err := a.transactionRules.GetAllEnabledRulesByUid(c, user.Uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionParseImportFileHandler] failed to get transaction rules for user \"uid:%d\", because %s", user.Uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	a.transactionRules.ApplyRulesToImportTransactions(rules, parsedTransactions, a.transactionCategories.GetCategoryMapByList(categories), a.accounts.GetAccountMapByList(accounts), a.transactionTags.GetTagMapByList(tags))
+
+	duplicateTransactionIds, err := a.transactions.GetProbableDuplicateTransactionIds(c, user.Uid, parsedTransactions.ToTransactionsList())
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionParseImportFileHandler] failed to check duplicate transactions for user \"uid:%d\", because %s", user.Uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	for i := 0; i < len(parsedTransactions); i++ {
+		parsedTransactions[i].ProbableDuplicateTransactionId = duplicateTransactionIds[i]
+	}
+
 	parsedTransactionRespsList := parsedTransactions.ToImportTransactionResponseList()
 
 	if len(parsedTransactionRespsList) < 1 {
@@ -1859,50 +2220,108 @@ func (a *TransactionsApi) TransactionParseImportFileHandler(c *core.WebContext)
 	parsedTransactionResps := &models.ImportTransactionResponsePageWrapper{
 		Items:      parsedTransactionRespsList,
 		TotalCount: int64(len(parsedTransactionRespsList)),
+		FileHash:   utils.SHA256EncodeToString(fileData),
 	}
 
+	// the accounts, categories and tags created after parsing can be recorded as created by the import batch of this file
+	a.SetSubmissionRemarkWithCustomExpiration(duplicatechecker.DUPLICATE_CHECKER_TYPE_PARSE_IMPORT_FILE, user.Uid, parsedTransactionResps.FileHash, utils.Int64ToString(parseUnixTime), importFileParseRecordExpiration)
+
 	return parsedTransactionResps, nil
 }
 
-// TransactionImportHandler imports transactions by request parameters for current user
+// TransactionImportHandler imports transactions by request parameters for current user, it submits an import job and waits for the job to finish,
+// it is only kept as a compatibility shim for the clients which expect the imported count in the response of import request,
+// the request may be blocked for a long time, so the clients should submit the import job by TransactionImportJobCreateHandler and check the job status instead
 func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *errs.Error) {
+	importJob, err := a.createImportJob(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	importJob, err = a.waitImportJobFinished(c, importJob)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if importJob.Status == models.IMPORT_JOB_STATUS_FAILED {
+		return nil, importJob.GetError()
+	}
+
+	return int(importJob.ImportedCount), nil
+}
+
+// TransactionImportJobCreateHandler submits an import job by request parameters for current user and returns it without waiting for the job to finish
+func (a *TransactionsApi) TransactionImportJobCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	importJob, err := a.createImportJob(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return importJob.ToImportJobInfoResponse(), nil
+}
+
+// TransactionImportProcessHandler returns the process of specified transaction import task by request parameters for current user
+func (a *TransactionsApi) TransactionImportProcessHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionImportProcessReq models.TransactionImportProcessRequest
+	err := c.ShouldBindQuery(&transactionImportProcessReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionImportProcessHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	importJob, err := a.importJobs.GetImportJobByClientSessionId(c, uid, transactionImportProcessReq.ClientSessionId)
+
+	if err != nil {
+		if err != errs.ErrImportJobNotFound {
+			log.Warnf(c, "[transactions.TransactionImportProcessHandler] failed to get import job for user \"uid:%d\", because %s", uid, err.Error())
+		}
+
+		return nil, nil
+	}
+
+	if importJob.Status == models.IMPORT_JOB_STATUS_SUCCEEDED {
+		return 100, nil
+	} else if importJob.Status == models.IMPORT_JOB_STATUS_PENDING {
+		return 0, nil
+	} else if importJob.Status != models.IMPORT_JOB_STATUS_PROCESSING {
+		return nil, nil
+	}
+
+	return importJob.Process, nil
+}
+
+func (a *TransactionsApi) createImportJob(c *core.WebContext) (*models.ImportJob, *errs.Error) {
 	var transactionImportReq models.TransactionImportRequest
 	err := c.ShouldBindJSON(&transactionImportReq)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionImportHandler] parse request failed, because %s", err.Error())
+		log.Warnf(c, "[transactions.createImportJob] parse request failed, because %s", err.Error())
 		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
 	clientTimezone, err := c.GetClientTimezone()
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionImportHandler] cannot get client timezone, because %s", err.Error())
+		log.Warnf(c, "[transactions.createImportJob] cannot get client timezone, because %s", err.Error())
 		return nil, errs.ErrClientTimezoneOffsetInvalid
 	}
 
 	uid := c.GetCurrentUid()
 
-	if a.CurrentConfig().EnableDuplicateSubmissionsCheck && transactionImportReq.ClientSessionId != "" {
-		found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportReq.ClientSessionId)
+	if transactionImportReq.ClientSessionId != "" {
+		importJob, err := a.importJobs.GetImportJobByClientSessionId(c, uid, transactionImportReq.ClientSessionId)
 
-		if found {
-			items := strings.Split(remark, ":")
-
-			if len(items) >= 2 {
-				if items[0] == "finished" {
-					log.Infof(c, "[transactions.TransactionImportHandler] another \"%s\" transactions has been imported for user \"uid:%d\"", items[1], uid)
-					count, err := utils.StringToInt(items[1])
-
-					if err == nil {
-						return count, nil
-					}
-				} else if items[0] == "processing" {
-					return nil, errs.ErrRepeatedRequest
-				}
-			} else {
-				log.Warnf(c, "[transactions.TransactionImportHandler] another transaction import task may be executing, but remark \"%s\" is invalid", remark)
-			}
+		if err == nil && importJob.Status != models.IMPORT_JOB_STATUS_FAILED {
+			log.Infof(c, "[transactions.createImportJob] another import job \"id:%d\" has been submitted for user \"uid:%d\"", importJob.JobId, uid)
+			return importJob, nil
+		} else if err != nil && err != errs.ErrImportJobNotFound {
+			log.Errorf(c, "[transactions.createImportJob] failed to get import job for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
 		}
 	}
 
@@ -1913,7 +2332,7 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 		tagIds, err := utils.StringArrayToInt64Array(transactionCreateReq.TagIds)
 
 		if err != nil {
-			log.Warnf(c, "[transactions.TransactionImportHandler] parse tag ids failed of transaction \"index:%d\", because %s", i, err.Error())
+			log.Warnf(c, "[transactions.createImportJob] parse tag ids failed of transaction \"index:%d\", because %s", i, err.Error())
 			return nil, errs.ErrTransactionTagIdInvalid
 		}
 
@@ -1922,36 +2341,36 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 		}
 
 		if transactionCreateReq.Type < models.TRANSACTION_TYPE_MODIFY_BALANCE || transactionCreateReq.Type > models.TRANSACTION_TYPE_TRANSFER {
-			log.Warnf(c, "[transactions.TransactionImportHandler] transaction type of transaction \"index:%d\" is invalid", i)
+			log.Warnf(c, "[transactions.createImportJob] transaction type of transaction \"index:%d\" is invalid", i)
 			return nil, errs.ErrTransactionTypeInvalid
 		}
 
 		if transactionCreateReq.Type == models.TRANSACTION_TYPE_MODIFY_BALANCE && transactionCreateReq.CategoryId != 0 {
-			log.Warnf(c, "[transactions.TransactionImportHandler] balance modification transaction \"index:%d\" cannot set category id", i)
+			log.Warnf(c, "[transactions.createImportJob] balance modification transaction \"index:%d\" cannot set category id", i)
 			return nil, errs.ErrBalanceModificationTransactionCannotSetCategory
 		}
 
 		if transactionCreateReq.Type != models.TRANSACTION_TYPE_TRANSFER && transactionCreateReq.DestinationAccountId != 0 {
-			log.Warnf(c, "[transactions.TransactionImportHandler] non-transfer transaction \"index:%d\" destination account cannot be set", i)
+			log.Warnf(c, "[transactions.createImportJob] non-transfer transaction \"index:%d\" destination account cannot be set", i)
 			return nil, errs.ErrTransactionDestinationAccountCannotBeSet
 		} else if transactionCreateReq.Type == models.TRANSACTION_TYPE_TRANSFER && transactionCreateReq.SourceAccountId == transactionCreateReq.DestinationAccountId {
-			log.Warnf(c, "[transactions.TransactionImportHandler] transfer transaction \"index:%d\" source account must not be destination account", i)
+			log.Warnf(c, "[transactions.createImportJob] transfer transaction \"index:%d\" source account must not be destination account", i)
 			return nil, errs.ErrTransactionSourceAndDestinationIdCannotBeEqual
 		}
 
 		if transactionCreateReq.Type != models.TRANSACTION_TYPE_TRANSFER && transactionCreateReq.DestinationAmount != 0 {
-			log.Warnf(c, "[transactions.TransactionImportHandler] non-transfer transaction \"index:%d\" destination amount cannot be set", i)
+			log.Warnf(c, "[transactions.createImportJob] non-transfer transaction \"index:%d\" destination amount cannot be set", i)
 			return nil, errs.ErrTransactionDestinationAmountCannotBeSet
 		}
 
 		newTransactionTagIdsMap[i] = tagIds
 	}
 
-	user, err := a.users.GetUserById(c, uid)
+	user, err := getCurrentDataUser(c, a.users)
 
 	if err != nil {
 		if !errs.IsCustomError(err) {
-			log.Errorf(c, "[transactions.TransactionImportHandler] failed to get user, because %s", err.Error())
+			log.Errorf(c, "[transactions.createImportJob] failed to get user, because %s", err.Error())
 		}
 
 		return nil, errs.ErrUserNotFound
@@ -1961,11 +2380,32 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
 
+	createdAccountIds, err := utils.StringArrayToInt64Array(transactionImportReq.CreatedAccountIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.createImportJob] parse created account ids failed, because %s", err.Error())
+		return nil, errs.ErrAccountIdInvalid
+	}
+
+	createdCategoryIds, err := utils.StringArrayToInt64Array(transactionImportReq.CreatedCategoryIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.createImportJob] parse created category ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionCategoryIdInvalid
+	}
+
+	createdTagIds, err := utils.StringArrayToInt64Array(transactionImportReq.CreatedTagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.createImportJob] parse created tag ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionTagIdInvalid
+	}
+
 	newTransactions := make([]*models.Transaction, len(transactionImportReq.Transactions))
 
 	for i := 0; i < len(transactionImportReq.Transactions); i++ {
 		transactionCreateReq := transactionImportReq.Transactions[i]
-		transaction := a.createNewTransactionModel(uid, transactionCreateReq, c.ClientIP())
+		transaction := a.createNewTransactionModel(uid, c.GetCurrentOperatorUid(), transactionCreateReq, c.ClientIP())
 		transactionEditable := user.CanEditTransactionByTransactionTime(transaction.TransactionTime, clientTimezone)
 
 		if !transactionEditable {
@@ -1975,72 +2415,240 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 		newTransactions[i] = transaction
 	}
 
-	err = a.transactions.BatchCreateTransactions(c, user.Uid, newTransactions, newTransactionTagIdsMap, func(currentProcess float64) {
-		a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportReq.ClientSessionId, fmt.Sprintf("processing:%.2f", currentProcess))
+	createdAccountIds, createdCategoryIds, createdTagIds, err = a.getCreatedIdsOfImportBatch(c, uid, transactionImportReq.FileHash, createdAccountIds, createdCategoryIds, createdTagIds, newTransactions, newTransactionTagIdsMap)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.createImportJob] failed to check created accounts, categories and tags for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if transactionImportReq.SkipProbableDuplicates {
+		duplicateTransactionIds, err := a.transactions.GetProbableDuplicateTransactionIds(c, user.Uid, newTransactions)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.createImportJob] failed to check duplicate transactions for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		nonDuplicateTransactions := make([]*models.Transaction, 0, len(newTransactions))
+		nonDuplicateTransactionTagIdsMap := make(map[int][]int64, len(newTransactions))
+
+		for i := 0; i < len(newTransactions); i++ {
+			if duplicateTransactionIds[i] != 0 {
+				log.Infof(c, "[transactions.createImportJob] skip transaction \"index:%d\" which is probably duplicate of transaction \"id:%d\" for user \"uid:%d\"", i, duplicateTransactionIds[i], uid)
+				continue
+			}
+
+			nonDuplicateTransactionTagIdsMap[len(nonDuplicateTransactions)] = newTransactionTagIdsMap[i]
+			nonDuplicateTransactions = append(nonDuplicateTransactions, newTransactions[i])
+		}
+
+		newTransactions = nonDuplicateTransactions
+		newTransactionTagIdsMap = nonDuplicateTransactionTagIdsMap
+
+		if len(newTransactions) < 1 {
+			log.Infof(c, "[transactions.createImportJob] all transactions are probably duplicates for user \"uid:%d\"", uid)
+		}
+	}
+
+	importBatch := &models.ImportBatch{
+		Uid:          user.Uid,
+		FileType:     transactionImportReq.FileType,
+		FileHash:     transactionImportReq.FileHash,
+		TotalCount:   int32(len(transactionImportReq.Transactions)),
+		SkippedCount: int32(len(transactionImportReq.Transactions) - len(newTransactions)),
+	}
+
+	importBatch.SetCreatedAccountIds(createdAccountIds)
+	importBatch.SetCreatedCategoryIds(createdCategoryIds)
+	importBatch.SetCreatedTagIds(createdTagIds)
+
+	importJob := &models.ImportJob{
+		Uid:             user.Uid,
+		ClientSessionId: transactionImportReq.ClientSessionId,
+		TotalCount:      int32(len(newTransactions)),
+	}
+
+	err = importJob.SetPayload(&models.ImportJobPayload{
+		Transactions: newTransactions,
+		TagIds:       newTransactionTagIdsMap,
+		ImportBatch:  importBatch,
 	})
-	count := len(newTransactions)
 
 	if err != nil {
-		a.RemoveSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportReq.ClientSessionId)
-		log.Errorf(c, "[transactions.TransactionImportHandler] failed to import %d transactions for user \"uid:%d\", because %s", count, uid, err.Error())
+		log.Errorf(c, "[transactions.createImportJob] failed to serialize import job payload for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	err = a.importJobs.CreateImportJob(c, importJob)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.createImportJob] failed to create import job for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	log.Infof(c, "[transactions.TransactionImportHandler] user \"uid:%d\" has imported %d transactions successfully", uid, count)
+	log.Infof(c, "[transactions.createImportJob] user \"uid:%d\" has submitted import job \"id:%d\" with %d transactions", uid, importJob.JobId, len(newTransactions))
 
-	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportReq.ClientSessionId, fmt.Sprintf("finished:%d", count))
+	a.importJobWorkers.Notify()
 
-	return count, nil
+	return importJob, nil
 }
 
-// TransactionImportProcessHandler returns the process of specified transaction import task by request parameters for current user
-func (a *TransactionsApi) TransactionImportProcessHandler(c *core.WebContext) (any, *errs.Error) {
-	var transactionImportProcessReq models.TransactionImportProcessRequest
-	err := c.ShouldBindQuery(&transactionImportProcessReq)
+// getCreatedIdsOfImportBatch returns the ids of accounts, categories and tags which are created after parsing the import file and are used by the imported transactions,
+// other ids submitted by client are ignored, so rolling back the import batch never deletes the data which is not created for the import batch
+func (a *TransactionsApi) getCreatedIdsOfImportBatch(c *core.WebContext, uid int64, fileHash string, accountIds []int64, categoryIds []int64, tagIds []int64, transactions []*models.Transaction, transactionTagIdsMap map[int][]int64) ([]int64, []int64, []int64, error) {
+	if len(accountIds) < 1 && len(categoryIds) < 1 && len(tagIds) < 1 {
+		return accountIds, categoryIds, tagIds, nil
+	}
 
-	if err != nil {
-		log.Warnf(c, "[transactions.TransactionImportProcessHandler] parse request failed, because %s", err.Error())
-		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_PARSE_IMPORT_FILE, uid, fileHash)
+	parseUnixTime, err := utils.StringToInt64(remark)
+
+	if !found || err != nil {
+		log.Warnf(c, "[transactions.getCreatedIdsOfImportBatch] cannot find the parse record of import file \"%s\" for user \"uid:%d\", created accounts, categories and tags will not be recorded", fileHash, uid)
+		return nil, nil, nil, nil
 	}
 
-	uid := c.GetCurrentUid()
+	usedAccountIds := make(map[int64]bool)
+	usedCategoryIds := make(map[int64]bool)
+	usedTagIds := make(map[int64]bool)
 
-	if !a.CurrentConfig().EnableDuplicateSubmissionsCheck {
-		return nil, nil
+	for i := 0; i < len(transactions); i++ {
+		usedAccountIds[transactions[i].AccountId] = true
+		usedCategoryIds[transactions[i].CategoryId] = true
+
+		if transactions[i].RelatedAccountId > 0 {
+			usedAccountIds[transactions[i].RelatedAccountId] = true
+		}
+
+		for _, tagId := range transactionTagIdsMap[i] {
+			usedTagIds[tagId] = true
+		}
 	}
 
-	found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportProcessReq.ClientSessionId)
+	var createdAccountIds []int64
 
-	if !found {
-		return nil, nil
+	if len(accountIds) > 0 {
+		accounts, err := a.accounts.GetAccountsByAccountIds(c, uid, accountIds)
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, account := range accounts {
+			if usedAccountIds[account.AccountId] && account.ParentAccountId > models.LevelOneAccountParentId {
+				usedAccountIds[account.ParentAccountId] = true
+			}
+		}
+
+		for _, accountId := range accountIds {
+			if account, exists := accounts[accountId]; exists && account.CreatedUnixTime >= parseUnixTime && usedAccountIds[accountId] {
+				createdAccountIds = append(createdAccountIds, accountId)
+			} else {
+				log.Warnf(c, "[transactions.getCreatedIdsOfImportBatch] account \"id:%d\" is not created for the import batch of user \"uid:%d\"", accountId, uid)
+			}
+		}
 	}
 
-	items := strings.Split(remark, ":")
+	var createdCategoryIds []int64
 
-	if len(items) < 2 {
-		return nil, nil
+	if len(categoryIds) > 0 {
+		categories, err := a.transactionCategories.GetCategoriesByCategoryIds(c, uid, categoryIds)
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, category := range categories {
+			if usedCategoryIds[category.CategoryId] && category.ParentCategoryId > models.LevelOneTransactionCategoryParentId {
+				usedCategoryIds[category.ParentCategoryId] = true
+			}
+		}
+
+		for _, categoryId := range categoryIds {
+			if category, exists := categories[categoryId]; exists && category.CreatedUnixTime >= parseUnixTime && usedCategoryIds[categoryId] {
+				createdCategoryIds = append(createdCategoryIds, categoryId)
+			} else {
+				log.Warnf(c, "[transactions.getCreatedIdsOfImportBatch] category \"id:%d\" is not created for the import batch of user \"uid:%d\"", categoryId, uid)
+			}
+		}
 	}
 
-	if items[0] == "finished" {
-		return 100, nil
-	} else if items[0] != "processing" {
-		return nil, nil
+	var createdTagIds []int64
+
+	if len(tagIds) > 0 {
+		tags, err := a.transactionTags.GetTagsByTagIds(c, uid, tagIds)
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, tagId := range tagIds {
+			if tag, exists := tags[tagId]; exists && tag.CreatedUnixTime >= parseUnixTime && usedTagIds[tagId] {
+				createdTagIds = append(createdTagIds, tagId)
+			} else {
+				log.Warnf(c, "[transactions.getCreatedIdsOfImportBatch] tag \"id:%d\" is not created for the import batch of user \"uid:%d\"", tagId, uid)
+			}
+		}
 	}
 
-	process, err := utils.StringToFloat64(items[1])
+	return createdAccountIds, createdCategoryIds, createdTagIds, nil
+}
+
+func (a *TransactionsApi) waitImportJobFinished(c *core.WebContext, importJob *models.ImportJob) (*models.ImportJob, *errs.Error) {
+	ticker := time.NewTicker(importJobStatusCheckInterval)
+	defer ticker.Stop()
+
+	for !importJob.IsFinished() {
+		select {
+		case <-c.Request.Context().Done():
+			log.Warnf(c, "[transactions.waitImportJobFinished] request has been ended before import job \"id:%d\" finished for user \"uid:%d\"", importJob.JobId, importJob.Uid)
+			return nil, errs.ErrOperationFailed
+		case <-ticker.C:
+		}
+
+		var err error
+		importJob, err = a.importJobs.GetImportJobByJobId(c, importJob.Uid, importJob.JobId)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.waitImportJobFinished] failed to get import job for user \"uid:%d\", because %s", c.GetCurrentUid(), err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	return importJob, nil
+}
+
+func (a *TransactionsApi) triggerWebhookEvent(c *core.WebContext, uid int64, eventType models.WebhookEventType, data any) {
+	err := a.webhooks.TriggerEvent(c, uid, eventType, data)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionImportProcessHandler] parse process failed, because %s", err.Error())
-		return nil, nil
+		log.Warnf(c, "[transactions.triggerWebhookEvent] failed to trigger webhook event \"%s\" for user \"uid:%d\", because %s", eventType, uid, err.Error())
 	}
+}
 
-	if process < 0 {
-		return nil, nil
-	} else if process >= 100 {
-		process = 100
+func (a *TransactionsApi) getTransactionAmountExchanger(c *core.WebContext, uid int64, minUnixTime int64, maxUnixTime int64) (*models.TransactionAmountExchanger, error) {
+	user, err := getCurrentDataUser(c, a.users)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getTransactionAmountExchanger] failed to get user \"uid:%d\" info, because %s", uid, err.Error())
+		return nil, err
 	}
 
-	return process, nil
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getTransactionAmountExchanger] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	exchangeRates, err := exchangerates.Container.GetHistoricalExchangeRateMap(c, c.GetCurrentOperatorUid(), minUnixTime, maxUnixTime, a.CurrentConfig())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewTransactionAmountExchanger(user.DefaultCurrency, accounts, exchangeRates), nil
 }
 
 func (a *TransactionsApi) filterTransactions(c *core.WebContext, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account) []*models.Transaction {
@@ -2083,6 +2691,54 @@ func (a *TransactionsApi) getTransactionTagInfoResponses(tagIds []int64, allTran
 	return allTags
 }
 
+func (a *TransactionsApi) getTransactionItemDetailResponses(itemIds []int64, itemDetails map[int64]*models.TransactionItemDetail) []*models.TransactionItemDetailResponse {
+	var allItemDetails []*models.TransactionItemDetailResponse
+
+	for i := 0; i < len(itemIds); i++ {
+		itemDetail := itemDetails[itemIds[i]]
+
+		if itemDetail.IsEmpty() {
+			continue
+		}
+
+		allItemDetails = append(allItemDetails, &models.TransactionItemDetailResponse{
+			ItemId:    itemIds[i],
+			Quantity:  itemDetail.Quantity,
+			UnitPrice: itemDetail.UnitPrice,
+			Amount:    itemDetail.Amount,
+		})
+	}
+
+	return allItemDetails
+}
+
+func (a *TransactionsApi) isTransactionItemDetailsEquals(itemIds []int64, itemDetails map[int64]*models.TransactionItemDetail, otherItemDetails map[int64]*models.TransactionItemDetail) bool {
+	for i := 0; i < len(itemIds); i++ {
+		if !itemDetails[itemIds[i]].Equals(otherItemDetails[itemIds[i]]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *TransactionsApi) isTransactionSplitsEquals(splits []*models.TransactionSplit, otherSplits []*models.TransactionSplit) bool {
+	if len(splits) != len(otherSplits) {
+		return false
+	}
+
+	for i := 0; i < len(splits); i++ {
+		if splits[i].CategoryId != otherSplits[i].CategoryId ||
+			splits[i].Amount != otherSplits[i].Amount ||
+			splits[i].TagIds != otherSplits[i].TagIds ||
+			splits[i].Comment != otherSplits[i].Comment {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (a *TransactionsApi) getTransactionItemInfoResponses(itemIds []int64, allTransactionItems map[int64]*models.TransactionItem) []*models.TransactionItemInfoResponse {
 	allItems := make([]*models.TransactionItemInfoResponse, 0, len(itemIds))
 
@@ -2138,13 +2794,23 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 		return nil, err
 	}
 
-	allTransactionItemIds, err := a.transactionItems.GetAllItemIdsOfTransactions(c, uid, transactionIds)
+	allTransactionItemIndexes, err := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, transactionIds)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.getTransactionResponseListResult] failed to get transactions item ids for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, err
 	}
 
+	allTransactionItemIds := a.transactionItems.GetGroupedTransactionItemIds(allTransactionItemIndexes)
+	allTransactionItemDetails := a.transactionItems.GetGroupedTransactionItemDetails(allTransactionItemIndexes)
+
+	allTransactionSplits, err := a.transactionSplits.GetAllSplitsOfTransactions(c, uid, transactionIds)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getTransactionResponseListResult] failed to get transactions split lines for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
 	var categoryMap map[int64]*models.TransactionCategory
 	var tagMap map[int64]*models.TransactionTag
 	var itemMap map[int64]*models.TransactionItem
@@ -2203,6 +2869,8 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 		transactionTagIds := allTransactionTagIds[transaction.TransactionId]
 		transactionItemIds := allTransactionItemIds[transaction.TransactionId]
 		result[i] = transaction.ToTransactionInfoResponse(transactionTagIds, transactionItemIds, transactionEditable)
+		result[i].ItemDetails = a.getTransactionItemDetailResponses(transactionItemIds, allTransactionItemDetails[transaction.TransactionId])
+		result[i].Splits = a.transactionSplits.GetTransactionSplitInfoResponses(allTransactionSplits[transaction.TransactionId])
 
 		if !trimAccount {
 			if sourceAccount := allAccounts[transaction.AccountId]; sourceAccount != nil {
@@ -2242,7 +2910,7 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 	return result, nil
 }
 
-func (a *TransactionsApi) createNewTransactionModel(uid int64, transactionCreateReq *models.TransactionCreateRequest, clientIp string) *models.Transaction {
+func (a *TransactionsApi) createNewTransactionModel(uid int64, operatorUid int64, transactionCreateReq *models.TransactionCreateRequest, clientIp string) *models.Transaction {
 	var transactionDbType models.TransactionDbType
 
 	if transactionCreateReq.Type == models.TRANSACTION_TYPE_MODIFY_BALANCE {
@@ -2266,6 +2934,8 @@ func (a *TransactionsApi) createNewTransactionModel(uid int64, transactionCreate
 		HideAmount:        transactionCreateReq.HideAmount,
 		Comment:           transactionCreateReq.Comment,
 		CreatedIp:         clientIp,
+		CreatedByUid:      operatorUid,
+		UpdatedByUid:      operatorUid,
 	}
 
 	if transactionCreateReq.Type == models.TRANSACTION_TYPE_TRANSFER {
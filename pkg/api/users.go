@@ -496,6 +496,30 @@ func (a *UsersApi) UserUpdateProfileHandler(c *core.WebContext) (any, *errs.Erro
 		userNew.IncomeAmountColor = models.AMOUNT_COLOR_TYPE_INVALID
 	}
 
+	var summaryEmailUtcOffset int16
+
+	if userUpdateReq.SummaryEmailFrequency != nil {
+		clientTimezone, err := c.GetClientTimezone()
+
+		if err != nil {
+			log.Warnf(c, "[users.UserUpdateProfileHandler] cannot get client timezone, because %s", err.Error())
+			return nil, errs.ErrClientTimezoneOffsetInvalid
+		}
+
+		summaryEmailUtcOffset = utils.GetTimezoneOffsetMinutes(time.Now().Unix(), clientTimezone)
+	}
+
+	if userUpdateReq.SummaryEmailFrequency != nil && (*userUpdateReq.SummaryEmailFrequency != user.SummaryEmailFrequency || summaryEmailUtcOffset != user.SummaryEmailUtcOffset) {
+		user.SummaryEmailFrequency = *userUpdateReq.SummaryEmailFrequency
+		userNew.SummaryEmailFrequency = *userUpdateReq.SummaryEmailFrequency
+		user.SummaryEmailUtcOffset = summaryEmailUtcOffset
+		userNew.SummaryEmailUtcOffset = summaryEmailUtcOffset
+		modifyProfileBasicInfo = true
+		anythingUpdate = true
+	} else {
+		userNew.SummaryEmailFrequency = models.SUMMARY_EMAIL_FREQUENCY_INVALID
+	}
+
 	if modifyProfileBasicInfo && user.FeatureRestriction.Contains(core.USER_FEATURE_RESTRICTION_TYPE_UPDATE_PROFILE_BASIC_INFO) {
 		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
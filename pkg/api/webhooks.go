@@ -0,0 +1,246 @@
+package api
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+const defaultWebhookDeliveryListCount = 20
+
+// WebhooksApi represents webhook api
+type WebhooksApi struct {
+	webhooks *services.WebhookService
+}
+
+// Initialize a webhook api singleton instance
+var (
+	Webhooks = &WebhooksApi{
+		webhooks: services.Webhooks,
+	}
+)
+
+// WebhookListHandler returns webhook list of current user
+func (a *WebhooksApi) WebhookListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	webhooks, err := a.webhooks.GetAllWebhooksByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookListHandler] failed to get webhooks for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	webhookResps := make([]*models.WebhookInfoResponse, len(webhooks))
+
+	for i := 0; i < len(webhooks); i++ {
+		webhookResps[i] = webhooks[i].ToWebhookInfoResponse()
+	}
+
+	return webhookResps, nil
+}
+
+// WebhookGetHandler returns one specific webhook of current user
+func (a *WebhooksApi) WebhookGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookGetReq models.WebhookGetRequest
+	err := c.ShouldBindQuery(&webhookGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	webhook, err := a.webhooks.GetWebhookByWebhookId(c, uid, webhookGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookGetHandler] failed to get webhook \"id:%d\" for user \"uid:%d\", because %s", webhookGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return webhook.ToWebhookInfoResponse(), nil
+}
+
+// WebhookCreateHandler saves a new webhook by request parameters for current user
+func (a *WebhooksApi) WebhookCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookCreateReq models.WebhookCreateRequest
+	err := c.ShouldBindJSON(&webhookCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	err = a.webhooks.CheckWebhookUrl(c, webhookCreateReq.Url)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookCreateHandler] webhook url \"%s\" is not allowed, because %s", webhookCreateReq.Url, err.Error())
+		return nil, errs.Or(err, errs.ErrWebhookUrlInvalid)
+	}
+
+	uid := c.GetCurrentUid()
+	webhooks, err := a.webhooks.GetAllWebhooksByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookCreateHandler] failed to get webhooks for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if len(webhooks) >= models.MaximumWebhookCountPerUser {
+		return nil, errs.ErrExceedMaxWebhookCount
+	}
+
+	secret, err := a.webhooks.GenerateSecret()
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookCreateHandler] failed to generate webhook secret for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	webhook := &models.Webhook{
+		Uid:      uid,
+		Name:     webhookCreateReq.Name,
+		Url:      webhookCreateReq.Url,
+		Secret:   secret,
+		Disabled: webhookCreateReq.Disabled,
+	}
+
+	webhook.SetEventTypes(webhookCreateReq.EventTypes)
+
+	err = a.webhooks.CreateWebhook(c, webhook)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookCreateHandler] failed to create webhook \"id:%d\" for user \"uid:%d\", because %s", webhook.WebhookId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[webhooks.WebhookCreateHandler] user \"uid:%d\" has created a new webhook \"id:%d\" successfully", uid, webhook.WebhookId)
+
+	return webhook.ToWebhookInfoResponse(), nil
+}
+
+// WebhookModifyHandler saves an existed webhook by request parameters for current user
+func (a *WebhooksApi) WebhookModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookModifyReq models.WebhookModifyRequest
+	err := c.ShouldBindJSON(&webhookModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	err = a.webhooks.CheckWebhookUrl(c, webhookModifyReq.Url)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookModifyHandler] webhook url \"%s\" is not allowed, because %s", webhookModifyReq.Url, err.Error())
+		return nil, errs.Or(err, errs.ErrWebhookUrlInvalid)
+	}
+
+	uid := c.GetCurrentUid()
+	webhook, err := a.webhooks.GetWebhookByWebhookId(c, uid, webhookModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookModifyHandler] failed to get webhook \"id:%d\" for user \"uid:%d\", because %s", webhookModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newWebhook := &models.Webhook{
+		WebhookId: webhook.WebhookId,
+		Uid:       uid,
+		Name:      webhookModifyReq.Name,
+		Url:       webhookModifyReq.Url,
+		Secret:    webhook.Secret,
+		Disabled:  webhookModifyReq.Disabled,
+	}
+
+	newWebhook.SetEventTypes(webhookModifyReq.EventTypes)
+
+	if webhookModifyReq.RegenerateSecret {
+		newWebhook.Secret, err = a.webhooks.GenerateSecret()
+
+		if err != nil {
+			log.Errorf(c, "[webhooks.WebhookModifyHandler] failed to generate webhook secret for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.ErrOperationFailed
+		}
+	}
+
+	if newWebhook.Name == webhook.Name &&
+		newWebhook.Url == webhook.Url &&
+		newWebhook.Secret == webhook.Secret &&
+		newWebhook.EventTypes == webhook.EventTypes &&
+		newWebhook.Disabled == webhook.Disabled {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.webhooks.ModifyWebhook(c, newWebhook)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookModifyHandler] failed to update webhook \"id:%d\" for user \"uid:%d\", because %s", webhookModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[webhooks.WebhookModifyHandler] user \"uid:%d\" has updated webhook \"id:%d\" successfully", uid, webhookModifyReq.Id)
+
+	return newWebhook.ToWebhookInfoResponse(), nil
+}
+
+// WebhookDeleteHandler deletes an existed webhook by request parameters for current user
+func (a *WebhooksApi) WebhookDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var webhookDeleteReq models.WebhookDeleteRequest
+	err := c.ShouldBindJSON(&webhookDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.webhooks.DeleteWebhook(c, uid, webhookDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookDeleteHandler] failed to delete webhook \"id:%d\" for user \"uid:%d\", because %s", webhookDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[webhooks.WebhookDeleteHandler] user \"uid:%d\" has deleted webhook \"id:%d\"", uid, webhookDeleteReq.Id)
+	return true, nil
+}
+
+// WebhookDeliveryListHandler returns the latest delivery logs of specified webhook of current user
+func (a *WebhooksApi) WebhookDeliveryListHandler(c *core.WebContext) (any, *errs.Error) {
+	var deliveryListReq models.WebhookDeliveryListRequest
+	err := c.ShouldBindQuery(&deliveryListReq)
+
+	if err != nil {
+		log.Warnf(c, "[webhooks.WebhookDeliveryListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if deliveryListReq.Count < 1 {
+		deliveryListReq.Count = defaultWebhookDeliveryListCount
+	}
+
+	uid := c.GetCurrentUid()
+	webhook, err := a.webhooks.GetWebhookByWebhookId(c, uid, deliveryListReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookDeliveryListHandler] failed to get webhook \"id:%d\" for user \"uid:%d\", because %s", deliveryListReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	deliveries, err := a.webhooks.GetLatestDeliveriesByWebhookId(c, uid, webhook.WebhookId, deliveryListReq.Count)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.WebhookDeliveryListHandler] failed to get deliveries of webhook \"id:%d\" for user \"uid:%d\", because %s", webhook.WebhookId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	deliveryResps := make([]*models.WebhookDeliveryInfoResponse, len(deliveries))
+
+	for i := 0; i < len(deliveries); i++ {
+		deliveryResps[i] = deliveries[i].ToWebhookDeliveryInfoResponse()
+	}
+
+	return deliveryResps, nil
+}
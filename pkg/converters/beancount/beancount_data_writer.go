@@ -0,0 +1,133 @@
+package beancount
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+const beancountLineSeparator = "\n"
+const beancountPostingIndent = "  "
+
+// beancountDataWriter defines the structure of Beancount data writer
+type beancountDataWriter struct {
+	data *beancountData
+}
+
+// write returns the Beancount file content of the data
+// Reference: https://beancount.github.io/docs/beancount_language_syntax.html
+func (w *beancountDataWriter) write(ctx core.Context) ([]byte, error) {
+	if w.data == nil {
+		return nil, errs.ErrOperationFailed
+	}
+
+	var buffer bytes.Buffer
+
+	accounts := make([]*beancountAccount, 0, len(w.data.Accounts))
+
+	for _, account := range w.data.Accounts {
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].OpenDate != accounts[j].OpenDate {
+			return accounts[i].OpenDate < accounts[j].OpenDate
+		}
+
+		return accounts[i].Name < accounts[j].Name
+	})
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+		buffer.WriteString(account.OpenDate + " " + string(beancountDirectiveOpen) + " " + account.Name + beancountLineSeparator)
+	}
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.CloseDate != "" {
+			buffer.WriteString(account.CloseDate + " " + string(beancountDirectiveClose) + " " + account.Name + beancountLineSeparator)
+		}
+	}
+
+	for i := 0; i < len(w.data.Transactions); i++ {
+		buffer.WriteString(beancountLineSeparator)
+		w.writeTransaction(&buffer, w.data.Transactions[i])
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (w *beancountDataWriter) writeTransaction(buffer *bytes.Buffer, transactionEntry *beancountTransactionEntry) {
+	// YYYY-MM-DD [txn|Flag] [[Payee] Narration] [#tag] [ˆlink]
+	buffer.WriteString(transactionEntry.Date + " " + string(transactionEntry.Directive))
+
+	if transactionEntry.Payee != "" {
+		buffer.WriteString(" " + w.getQuotedString(transactionEntry.Payee))
+	}
+
+	buffer.WriteString(" " + w.getQuotedString(transactionEntry.Narration))
+
+	for i := 0; i < len(transactionEntry.Tags); i++ {
+		buffer.WriteString(" " + string(beancountTagPrefix) + transactionEntry.Tags[i])
+	}
+
+	for i := 0; i < len(transactionEntry.Links); i++ {
+		buffer.WriteString(" " + string(beancountLinkPrefix) + transactionEntry.Links[i])
+	}
+
+	buffer.WriteString(beancountLineSeparator)
+	w.writeMetadata(buffer, beancountPostingIndent, transactionEntry.Metadata)
+
+	for i := 0; i < len(transactionEntry.Postings); i++ {
+		posting := transactionEntry.Postings[i]
+
+		// [Flag] Account Amount [{Cost}] [@ Price]
+		buffer.WriteString(beancountPostingIndent + posting.Account + "  " + posting.Amount + " " + posting.Commodity)
+
+		if posting.TotalCost != "" && posting.TotalCostCommodity != "" {
+			buffer.WriteString(" " + string(beancountPricePrefix) + string(beancountPricePrefix) + " " + posting.TotalCost + " " + posting.TotalCostCommodity)
+		} else if posting.Price != "" && posting.PriceCommodity != "" {
+			buffer.WriteString(" " + string(beancountPricePrefix) + " " + posting.Price + " " + posting.PriceCommodity)
+		}
+
+		buffer.WriteString(beancountLineSeparator)
+		w.writeMetadata(buffer, beancountPostingIndent+beancountPostingIndent, posting.Metadata)
+	}
+}
+
+func (w *beancountDataWriter) writeMetadata(buffer *bytes.Buffer, indent string, metadata map[string]string) {
+	if len(metadata) < 1 {
+		return
+	}
+
+	keys := make([]string, 0, len(metadata))
+
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for i := 0; i < len(keys); i++ {
+		buffer.WriteString(indent + keys[i] + string(beancountMetadataKeySuffix) + " " + w.getQuotedString(metadata[keys[i]]) + beancountLineSeparator)
+	}
+}
+
+func (w *beancountDataWriter) getQuotedString(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	value = strings.ReplaceAll(value, "\r", " ")
+	value = strings.ReplaceAll(value, "\n", " ")
+
+	return "\"" + value + "\""
+}
+
+func createNewBeancountDataWriter(data *beancountData) *beancountDataWriter {
+	return &beancountDataWriter{
+		data: data,
+	}
+}
@@ -0,0 +1,232 @@
+package beancount
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const beancountUnknownAccountNameItem = "Unknown"
+
+// beancountTransactionDataExporter defines the structure of Beancount exporter for transaction data
+type beancountTransactionDataExporter struct {
+}
+
+// Initialize a beancount transaction data exporter singleton instance
+var (
+	BeancountTransactionDataExporter = &beancountTransactionDataExporter{}
+)
+
+// ToExportedContent returns the exported transaction data in Beancount format
+func (e *beancountTransactionDataExporter) ToExportedContent(ctx core.Context, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, allTagIndexes map[int64][]int64) ([]byte, error) {
+	data := &beancountData{
+		Accounts:     make(map[string]*beancountAccount),
+		Transactions: make([]*beancountTransactionEntry, 0, len(transactions)),
+	}
+
+	// transactions are sorted by time in descending order, but Beancount entries are written in ascending order
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			continue
+		}
+
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			log.Warnf(ctx, "[beancount_transaction_data_file_exporter.ToExportedContent] cannot find account \"id:%d\" of transaction \"id:%d\" for user \"uid:%d\", skip this transaction", transaction.AccountId, transaction.TransactionId, uid)
+			continue
+		}
+
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+		transactionTimeZone := time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		date := utils.FormatUnixTimeToLongDate(transactionUnixTime, transactionTimeZone)
+
+		accountName := e.addAccount(data, e.getAccountName(account, accountMap), e.getAccountType(account), date)
+
+		transactionEntry := &beancountTransactionEntry{
+			Date:      date,
+			Directive: beancountDirectiveCompletedTransaction,
+			Narration: transaction.Comment,
+			Tags:      e.getTagNames(transaction.TransactionId, allTagIndexes, tagMap),
+		}
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+			equityAccountName := e.addAccount(data, beancountDefaultEquityAccountTypeName+beancountAccountNameItemsSeparator+beancountEquityAccountNameOpeningBalance, beancountEquityAccountType, date)
+
+			transactionEntry.Postings = []*beancountPosting{
+				e.createPosting(accountName, transaction.RelatedAccountAmount, account.Currency),
+				e.createPosting(equityAccountName, -transaction.RelatedAccountAmount, account.Currency),
+			}
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			categoryAccountName := e.addAccount(data, e.getCategoryAccountName(beancountDefaultIncomeAccountTypeName, transaction.CategoryId, categoryMap), beancountIncomeAccountType, date)
+
+			transactionEntry.Postings = []*beancountPosting{
+				e.createPosting(accountName, transaction.Amount, account.Currency),
+				e.createPosting(categoryAccountName, -transaction.Amount, account.Currency),
+			}
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			categoryAccountName := e.addAccount(data, e.getCategoryAccountName(beancountDefaultExpenseAccountTypeName, transaction.CategoryId, categoryMap), beancountExpensesAccountType, date)
+
+			transactionEntry.Postings = []*beancountPosting{
+				e.createPosting(accountName, -transaction.Amount, account.Currency),
+				e.createPosting(categoryAccountName, transaction.Amount, account.Currency),
+			}
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			relatedAccount, exists := accountMap[transaction.RelatedAccountId]
+
+			if !exists {
+				log.Warnf(ctx, "[beancount_transaction_data_file_exporter.ToExportedContent] cannot find related account \"id:%d\" of transaction \"id:%d\" for user \"uid:%d\", skip this transaction", transaction.RelatedAccountId, transaction.TransactionId, uid)
+				continue
+			}
+
+			relatedAccountName := e.addAccount(data, e.getAccountName(relatedAccount, accountMap), e.getAccountType(relatedAccount), date)
+			relatedPosting := e.createPosting(relatedAccountName, transaction.RelatedAccountAmount, relatedAccount.Currency)
+
+			if relatedAccount.Currency != account.Currency {
+				relatedPosting.TotalCost = utils.FormatAmount(transaction.Amount)
+				relatedPosting.TotalCostCommodity = account.Currency
+			}
+
+			transactionEntry.Postings = []*beancountPosting{
+				e.createPosting(accountName, -transaction.Amount, account.Currency),
+				relatedPosting,
+			}
+		} else {
+			continue
+		}
+
+		data.Transactions = append(data.Transactions, transactionEntry)
+	}
+
+	return createNewBeancountDataWriter(data).write(ctx)
+}
+
+func (e *beancountTransactionDataExporter) addAccount(data *beancountData, accountName string, accountType beancountAccountType, openDate string) string {
+	account, exists := data.Accounts[accountName]
+
+	if !exists {
+		data.Accounts[accountName] = &beancountAccount{
+			Name:        accountName,
+			AccountType: accountType,
+			OpenDate:    openDate,
+		}
+	} else if openDate < account.OpenDate {
+		account.OpenDate = openDate
+	}
+
+	return accountName
+}
+
+func (e *beancountTransactionDataExporter) createPosting(accountName string, amount int64, currency string) *beancountPosting {
+	return &beancountPosting{
+		Account:   accountName,
+		Amount:    utils.FormatAmount(amount),
+		Commodity: currency,
+	}
+}
+
+func (e *beancountTransactionDataExporter) getAccountType(account *models.Account) beancountAccountType {
+	if account.Category.IsLiability() {
+		return beancountLiabilitiesAccountType
+	}
+
+	return beancountAssetsAccountType
+}
+
+func (e *beancountTransactionDataExporter) getAccountName(account *models.Account, accountMap map[int64]*models.Account) string {
+	accountTypeName := beancountDefaultAssetsAccountTypeName
+
+	if account.Category.IsLiability() {
+		accountTypeName = beancountDefaultLiabilitiesAccountTypeName
+	}
+
+	if account.ParentAccountId != models.LevelOneAccountParentId {
+		if parentAccount, exists := accountMap[account.ParentAccountId]; exists {
+			return accountTypeName + beancountAccountNameItemsSeparator + e.getAccountNameItem(parentAccount.Name) + beancountAccountNameItemsSeparator + e.getAccountNameItem(account.Name)
+		}
+	}
+
+	return accountTypeName + beancountAccountNameItemsSeparator + e.getAccountNameItem(account.Name)
+}
+
+func (e *beancountTransactionDataExporter) getCategoryAccountName(accountTypeName string, categoryId int64, categoryMap map[int64]*models.TransactionCategory) string {
+	category, exists := categoryMap[categoryId]
+
+	if !exists {
+		return accountTypeName + beancountAccountNameItemsSeparator + beancountUnknownAccountNameItem
+	}
+
+	if category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+		if parentCategory, exists := categoryMap[category.ParentCategoryId]; exists {
+			return accountTypeName + beancountAccountNameItemsSeparator + e.getAccountNameItem(parentCategory.Name) + beancountAccountNameItemsSeparator + e.getAccountNameItem(category.Name)
+		}
+	}
+
+	return accountTypeName + beancountAccountNameItemsSeparator + e.getAccountNameItem(category.Name)
+}
+
+// getAccountNameItem returns the account name component which only contains letters, numbers or dashes and starts with a capital letter or a number
+func (e *beancountTransactionDataExporter) getAccountNameItem(name string) string {
+	var builder strings.Builder
+
+	for _, ch := range strings.TrimSpace(name) {
+		if unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '-' {
+			if builder.Len() == 0 {
+				ch = unicode.ToUpper(ch)
+			}
+
+			builder.WriteRune(ch)
+		} else {
+			builder.WriteRune('-')
+		}
+	}
+
+	nameItem := builder.String()
+
+	if nameItem == "" {
+		return beancountUnknownAccountNameItem
+	}
+
+	if nameItem[0] == '-' {
+		return beancountUnknownAccountNameItem + nameItem
+	}
+
+	return nameItem
+}
+
+func (e *beancountTransactionDataExporter) getTagNames(transactionId int64, allTagIndexes map[int64][]int64, tagMap map[int64]*models.TransactionTag) []string {
+	tagIndexes, exists := allTagIndexes[transactionId]
+
+	if !exists {
+		return nil
+	}
+
+	tagNames := make([]string, 0, len(tagIndexes))
+
+	for i := 0; i < len(tagIndexes); i++ {
+		tag, exists := tagMap[tagIndexes[i]]
+
+		if !exists {
+			continue
+		}
+
+		tagName := strings.Map(func(ch rune) rune {
+			if unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '-' || ch == '_' || ch == '/' || ch == '.' {
+				return ch
+			}
+
+			return '-'
+		}, tag.Name)
+
+		tagNames = append(tagNames, tagName)
+	}
+
+	return tagNames
+}
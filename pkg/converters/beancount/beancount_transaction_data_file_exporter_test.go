@@ -0,0 +1,127 @@
+package beancount
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/converters/converter"
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func getBeancountExporterTestData() ([]*models.Transaction, map[int64]*models.Account, map[int64]*models.TransactionCategory, map[int64]*models.TransactionTag, map[int64][]int64) {
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Test Account", Category: models.ACCOUNT_CATEGORY_CASH, Currency: "CNY"},
+		2: {AccountId: 2, Name: "credit card", Category: models.ACCOUNT_CATEGORY_CREDIT_CARD, Currency: "CNY"},
+		3: {AccountId: 3, Name: "Foreign", Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Currency: "USD"},
+	}
+
+	categoryMap := map[int64]*models.TransactionCategory{
+		10: {CategoryId: 10, Name: "Salary", Type: models.CATEGORY_TYPE_INCOME},
+		20: {CategoryId: 20, Name: "Food", Type: models.CATEGORY_TYPE_EXPENSE},
+		21: {CategoryId: 21, ParentCategoryId: 20, Name: "Dinner", Type: models.CATEGORY_TYPE_EXPENSE},
+	}
+
+	tagMap := map[int64]*models.TransactionTag{
+		100: {TagId: 100, Name: "Trip 2024"},
+	}
+
+	allTagIndexes := map[int64][]int64{
+		1003: {100},
+	}
+
+	// transactions are sorted by time in descending order
+	transactions := []*models.Transaction{
+		{TransactionId: 1005, Type: models.TRANSACTION_DB_TYPE_TRANSFER_IN, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), AccountId: 3, Amount: 100, RelatedAccountId: 1, RelatedAccountAmount: 700},
+		{TransactionId: 1004, Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), AccountId: 1, Amount: 700, RelatedAccountId: 3, RelatedAccountAmount: 100},
+		{TransactionId: 1003, Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725321600), AccountId: 2, CategoryId: 21, Amount: 100, Comment: "Dinner with friends"},
+		{TransactionId: 1002, Type: models.TRANSACTION_DB_TYPE_INCOME, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725235200), AccountId: 1, CategoryId: 10, Amount: 12},
+		{TransactionId: 1001, Type: models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725148800), AccountId: 1, Amount: 12345, RelatedAccountAmount: 12345},
+	}
+
+	return transactions, accountMap, categoryMap, tagMap, allTagIndexes
+}
+
+func TestBeancountTransactionDataExporterToExportedContent(t *testing.T) {
+	exporter := BeancountTransactionDataExporter
+	context := core.NewNullContext()
+	transactions, accountMap, categoryMap, tagMap, allTagIndexes := getBeancountExporterTestData()
+
+	content, err := exporter.ToExportedContent(context, 1234567890, transactions, accountMap, categoryMap, tagMap, allTagIndexes)
+	assert.Nil(t, err)
+
+	expected := "2024-09-01 open Assets:Test-Account\n" +
+		"2024-09-01 open Equity:Opening-Balances\n" +
+		"2024-09-02 open Income:Salary\n" +
+		"2024-09-03 open Expenses:Food:Dinner\n" +
+		"2024-09-03 open Liabilities:Credit-card\n" +
+		"2024-09-04 open Assets:Foreign\n" +
+		"\n" +
+		"2024-09-01 * \"\"\n" +
+		"  Assets:Test-Account  123.45 CNY\n" +
+		"  Equity:Opening-Balances  -123.45 CNY\n" +
+		"\n" +
+		"2024-09-02 * \"\"\n" +
+		"  Assets:Test-Account  0.12 CNY\n" +
+		"  Income:Salary  -0.12 CNY\n" +
+		"\n" +
+		"2024-09-03 * \"Dinner with friends\" #Trip-2024\n" +
+		"  Liabilities:Credit-card  -1.00 CNY\n" +
+		"  Expenses:Food:Dinner  1.00 CNY\n" +
+		"\n" +
+		"2024-09-04 * \"\"\n" +
+		"  Assets:Test-Account  -7.00 CNY\n" +
+		"  Assets:Foreign  1.00 USD @@ 7.00 CNY\n"
+
+	assert.Equal(t, expected, string(content))
+}
+
+func TestBeancountTransactionDataExporterToExportedContent_ImportExportedData(t *testing.T) {
+	exporter := BeancountTransactionDataExporter
+	importer := BeancountTransactionDataImporter
+	context := core.NewNullContext()
+	transactions, accountMap, categoryMap, tagMap, allTagIndexes := getBeancountExporterTestData()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	content, err := exporter.ToExportedContent(context, user.Uid, transactions, accountMap, categoryMap, tagMap, allTagIndexes)
+	assert.Nil(t, err)
+
+	allNewTransactions, _, _, _, _, _, err := importer.ParseImportedData(context, user, content, time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4, len(allNewTransactions))
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, allNewTransactions[0].Type)
+	assert.Equal(t, int64(12345), allNewTransactions[0].Amount)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_INCOME, allNewTransactions[1].Type)
+	assert.Equal(t, int64(12), allNewTransactions[1].Amount)
+	assert.Equal(t, "Income:Salary", allNewTransactions[1].OriginalCategoryName)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_EXPENSE, allNewTransactions[2].Type)
+	assert.Equal(t, int64(100), allNewTransactions[2].Amount)
+	assert.Equal(t, "Liabilities:Credit-card", allNewTransactions[2].OriginalSourceAccountName)
+	assert.Equal(t, "Dinner with friends", allNewTransactions[2].Comment)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_TRANSFER_OUT, allNewTransactions[3].Type)
+	assert.Equal(t, int64(700), allNewTransactions[3].Amount)
+	assert.Equal(t, int64(100), allNewTransactions[3].RelatedAccountAmount)
+	assert.Equal(t, "Assets:Foreign", allNewTransactions[3].OriginalDestinationAccountName)
+}
+
+func TestBeancountTransactionDataExporterGetAccountNameItem(t *testing.T) {
+	exporter := BeancountTransactionDataExporter
+
+	assert.Equal(t, "Cash", exporter.getAccountNameItem("cash"))
+	assert.Equal(t, "My-Bank-Card", exporter.getAccountNameItem(" My Bank:Card "))
+	assert.Equal(t, "Unknown-Card", exporter.getAccountNameItem("*Card"))
+	assert.Equal(t, "Unknown", exporter.getAccountNameItem(""))
+	assert.Equal(t, "现金", exporter.getAccountNameItem("现金"))
+}
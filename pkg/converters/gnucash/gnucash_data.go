@@ -8,6 +8,14 @@ const gnucashEquityAccountType = "EQUITY"
 const gnucashIncomeAccountType = "INCOME"
 const gnucashExpenseAccountType = "EXPENSE"
 
+const gnucashBankAccountType = "BANK"
+const gnucashCashAccountType = "CASH"
+const gnucashCreditAccountType = "CREDIT"
+const gnucashAssetAccountType = "ASSET"
+const gnucashLiabilityAccountType = "LIABILITY"
+const gnucashReceivableAccountType = "RECEIVABLE"
+const gnucashMutualAccountType = "MUTUAL"
+
 const gnucashSlotEquityType = "equity-type"
 const gnucashSlotEquityTypeOpeningBalance = "opening-balance"
 
@@ -40,6 +48,7 @@ type gnucashCountData struct {
 type gnucashBookData struct {
 	Id           string                    `xml:"id"`
 	Counts       []*gnucashCountData       `xml:"count-data"`
+	Commodities  []*gnucashCommodityData   `xml:"commodity"`
 	Accounts     []*gnucashAccountData     `xml:"account"`
 	Transactions []*gnucashTransactionData `xml:"transaction"`
 }
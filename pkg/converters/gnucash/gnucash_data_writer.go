@@ -0,0 +1,171 @@
+package gnucash
+
+import (
+	"bytes"
+	"encoding/xml"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+const gnucashLineSeparator = "\n"
+const gnucashDataVersion = "2.0.0"
+
+var gnucashXmlNamespaces = []string{
+	"gnc", "act", "book", "cd", "cmdty", "price", "slot", "split", "trn", "ts",
+}
+
+// gnucashDatabaseWriter defines the structure of gnucash database writer
+type gnucashDatabaseWriter struct {
+	database *gnucashDatabase
+}
+
+// write returns the uncompressed gnucash xml file content of the database
+func (w *gnucashDatabaseWriter) write(ctx core.Context) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\" ?>" + gnucashLineSeparator)
+	buffer.WriteString("<gnc-v2")
+
+	for i := 0; i < len(gnucashXmlNamespaces); i++ {
+		buffer.WriteString(gnucashLineSeparator + "     xmlns:" + gnucashXmlNamespaces[i] + "=\"http://www.gnucash.org/XML/" + gnucashXmlNamespaces[i] + "\"")
+	}
+
+	buffer.WriteString(">" + gnucashLineSeparator)
+	w.writeCounts(&buffer, w.database.Counts)
+
+	for i := 0; i < len(w.database.Books); i++ {
+		book := w.database.Books[i]
+
+		buffer.WriteString("<gnc:book version=\"" + gnucashDataVersion + "\">" + gnucashLineSeparator)
+		w.writeGuidElement(&buffer, "  ", "book:id", book.Id)
+		w.writeCounts(&buffer, book.Counts)
+
+		for j := 0; j < len(book.Commodities); j++ {
+			buffer.WriteString("<gnc:commodity version=\"" + gnucashDataVersion + "\">" + gnucashLineSeparator)
+			w.writeElement(&buffer, "  ", "cmdty:space", book.Commodities[j].Space)
+			w.writeElement(&buffer, "  ", "cmdty:id", book.Commodities[j].Id)
+			buffer.WriteString("</gnc:commodity>" + gnucashLineSeparator)
+		}
+
+		for j := 0; j < len(book.Accounts); j++ {
+			w.writeAccount(&buffer, book.Accounts[j])
+		}
+
+		for j := 0; j < len(book.Transactions); j++ {
+			w.writeTransaction(&buffer, book.Transactions[j])
+		}
+
+		buffer.WriteString("</gnc:book>" + gnucashLineSeparator)
+	}
+
+	buffer.WriteString("</gnc-v2>" + gnucashLineSeparator)
+
+	return buffer.Bytes(), nil
+}
+
+func (w *gnucashDatabaseWriter) writeCounts(buffer *bytes.Buffer, counts []*gnucashCountData) {
+	for i := 0; i < len(counts); i++ {
+		buffer.WriteString("<gnc:count-data cd:type=\"" + counts[i].Key + "\">" + counts[i].Value + "</gnc:count-data>" + gnucashLineSeparator)
+	}
+}
+
+func (w *gnucashDatabaseWriter) writeAccount(buffer *bytes.Buffer, account *gnucashAccountData) {
+	buffer.WriteString("<gnc:account version=\"" + gnucashDataVersion + "\">" + gnucashLineSeparator)
+	w.writeElement(buffer, "  ", "act:name", account.Name)
+	w.writeGuidElement(buffer, "  ", "act:id", account.Id)
+	w.writeElement(buffer, "  ", "act:type", account.AccountType)
+
+	if account.Commodity != nil {
+		w.writeCommodity(buffer, "  ", "act:commodity", account.Commodity)
+	}
+
+	w.writeElement(buffer, "  ", "act:description", account.Description)
+
+	if len(account.Slots) > 0 {
+		buffer.WriteString("  <act:slots>" + gnucashLineSeparator)
+
+		for i := 0; i < len(account.Slots); i++ {
+			buffer.WriteString("    <slot>" + gnucashLineSeparator)
+			w.writeElement(buffer, "      ", "slot:key", account.Slots[i].Key)
+			buffer.WriteString("      <slot:value type=\"string\">")
+			_ = xml.EscapeText(buffer, []byte(account.Slots[i].Value))
+			buffer.WriteString("</slot:value>" + gnucashLineSeparator)
+			buffer.WriteString("    </slot>" + gnucashLineSeparator)
+		}
+
+		buffer.WriteString("  </act:slots>" + gnucashLineSeparator)
+	}
+
+	w.writeGuidElement(buffer, "  ", "act:parent", account.ParentId)
+	buffer.WriteString("</gnc:account>" + gnucashLineSeparator)
+}
+
+func (w *gnucashDatabaseWriter) writeTransaction(buffer *bytes.Buffer, transaction *gnucashTransactionData) {
+	buffer.WriteString("<gnc:transaction version=\"" + gnucashDataVersion + "\">" + gnucashLineSeparator)
+	w.writeGuidElement(buffer, "  ", "trn:id", transaction.Id)
+
+	if transaction.Currency != nil {
+		w.writeCommodity(buffer, "  ", "trn:currency", transaction.Currency)
+	}
+
+	w.writeDateElement(buffer, "  ", "trn:date-posted", transaction.PostedDate)
+	w.writeDateElement(buffer, "  ", "trn:date-entered", transaction.EnteredDate)
+	w.writeElement(buffer, "  ", "trn:description", transaction.Description)
+	buffer.WriteString("  <trn:splits>" + gnucashLineSeparator)
+
+	for i := 0; i < len(transaction.Splits); i++ {
+		split := transaction.Splits[i]
+
+		buffer.WriteString("    <trn:split>" + gnucashLineSeparator)
+		w.writeGuidElement(buffer, "      ", "split:id", split.Id)
+		w.writeElement(buffer, "      ", "split:reconciled-state", split.ReconciledState)
+		w.writeElement(buffer, "      ", "split:value", split.Value)
+		w.writeElement(buffer, "      ", "split:quantity", split.Quantity)
+		w.writeGuidElement(buffer, "      ", "split:account", split.Account)
+		buffer.WriteString("    </trn:split>" + gnucashLineSeparator)
+	}
+
+	buffer.WriteString("  </trn:splits>" + gnucashLineSeparator)
+	buffer.WriteString("</gnc:transaction>" + gnucashLineSeparator)
+}
+
+func (w *gnucashDatabaseWriter) writeCommodity(buffer *bytes.Buffer, indent string, elementName string, commodity *gnucashCommodityData) {
+	buffer.WriteString(indent + "<" + elementName + ">" + gnucashLineSeparator)
+	w.writeElement(buffer, indent+"  ", "cmdty:space", commodity.Space)
+	w.writeElement(buffer, indent+"  ", "cmdty:id", commodity.Id)
+	buffer.WriteString(indent + "</" + elementName + ">" + gnucashLineSeparator)
+}
+
+func (w *gnucashDatabaseWriter) writeDateElement(buffer *bytes.Buffer, indent string, elementName string, value string) {
+	if value == "" {
+		return
+	}
+
+	buffer.WriteString(indent + "<" + elementName + ">" + gnucashLineSeparator)
+	w.writeElement(buffer, indent+"  ", "ts:date", value)
+	buffer.WriteString(indent + "</" + elementName + ">" + gnucashLineSeparator)
+}
+
+func (w *gnucashDatabaseWriter) writeGuidElement(buffer *bytes.Buffer, indent string, elementName string, value string) {
+	if value == "" {
+		return
+	}
+
+	buffer.WriteString(indent + "<" + elementName + " type=\"guid\">" + value + "</" + elementName + ">" + gnucashLineSeparator)
+}
+
+func (w *gnucashDatabaseWriter) writeElement(buffer *bytes.Buffer, indent string, elementName string, value string) {
+	if value == "" {
+		return
+	}
+
+	buffer.WriteString(indent + "<" + elementName + ">")
+	_ = xml.EscapeText(buffer, []byte(value))
+	buffer.WriteString("</" + elementName + ">" + gnucashLineSeparator)
+}
+
+func createNewGnuCashDatabaseWriter(database *gnucashDatabase) *gnucashDatabaseWriter {
+	return &gnucashDatabaseWriter{
+		database: database,
+	}
+}
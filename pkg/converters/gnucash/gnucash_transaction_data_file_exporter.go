@@ -0,0 +1,280 @@
+package gnucash
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const gnucashDateTimeFormat = "2006-01-02 15:04:05 -0700"
+const gnucashRootAccountName = "Root Account"
+const gnucashOpeningBalancesAccountName = "Opening Balances"
+const gnucashReconciledStateNotReconciled = "n"
+
+var gnucashAccountTypeMapping = map[models.AccountCategory]string{
+	models.ACCOUNT_CATEGORY_CASH:                   gnucashCashAccountType,
+	models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT:       gnucashBankAccountType,
+	models.ACCOUNT_CATEGORY_CREDIT_CARD:            gnucashCreditAccountType,
+	models.ACCOUNT_CATEGORY_VIRTUAL:                gnucashAssetAccountType,
+	models.ACCOUNT_CATEGORY_DEBT:                   gnucashLiabilityAccountType,
+	models.ACCOUNT_CATEGORY_RECEIVABLES:            gnucashReceivableAccountType,
+	models.ACCOUNT_CATEGORY_INVESTMENT:             gnucashMutualAccountType,
+	models.ACCOUNT_CATEGORY_SAVINGS_ACCOUNT:        gnucashBankAccountType,
+	models.ACCOUNT_CATEGORY_CERTIFICATE_OF_DEPOSIT: gnucashBankAccountType,
+}
+
+// gnucashTransactionDataExporter defines the structure of gnucash exporter for transaction data
+type gnucashTransactionDataExporter struct {
+}
+
+// gnucashExportedBook defines the structure of the book which is being exported and the gnucash accounts mapped from accounts and categories
+type gnucashExportedBook struct {
+	book                         *gnucashBookData
+	lastGuid                     int64
+	rootAccount                  *gnucashAccountData
+	commodities                  map[string]*gnucashCommodityData
+	accounts                     map[int64]*gnucashAccountData
+	categoryAccounts             map[int64]*gnucashAccountData
+	openingBalanceEquityAccounts map[string]*gnucashAccountData
+}
+
+// Initialize a gnucash transaction data exporter singleton instance
+var (
+	GnuCashTransactionDataExporter = &gnucashTransactionDataExporter{}
+)
+
+// ToExportedContent returns the exported transaction data in uncompressed gnucash xml format
+func (e *gnucashTransactionDataExporter) ToExportedContent(ctx core.Context, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, allTagIndexes map[int64][]int64) ([]byte, error) {
+	exportedBook := &gnucashExportedBook{
+		book:                         &gnucashBookData{},
+		commodities:                  make(map[string]*gnucashCommodityData),
+		accounts:                     make(map[int64]*gnucashAccountData),
+		categoryAccounts:             make(map[int64]*gnucashAccountData),
+		openingBalanceEquityAccounts: make(map[string]*gnucashAccountData),
+	}
+
+	exportedBook.book.Id = exportedBook.nextGuid()
+	exportedBook.rootAccount = exportedBook.addAccount(gnucashRootAccountName, gnucashRootAccountType, "", nil)
+
+	// transactions are sorted by time in descending order, but gnucash transactions are written in ascending order
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			continue
+		}
+
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			log.Warnf(ctx, "[gnucash_transaction_data_file_exporter.ToExportedContent] cannot find account \"id:%d\" of transaction \"id:%d\" for user \"uid:%d\", skip this transaction", transaction.AccountId, transaction.TransactionId, uid)
+			continue
+		}
+
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+		transactionTimeZone := time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+
+		transactionData := &gnucashTransactionData{
+			Id:          exportedBook.nextGuid(),
+			Currency:    exportedBook.getCommodity(account.Currency),
+			PostedDate:  time.Unix(transactionUnixTime, 0).In(transactionTimeZone).Format(gnucashDateTimeFormat),
+			Description: transaction.Comment,
+		}
+
+		if transaction.CreatedUnixTime > 0 {
+			transactionData.EnteredDate = time.Unix(transaction.CreatedUnixTime, 0).In(transactionTimeZone).Format(gnucashDateTimeFormat)
+		}
+
+		gnucashAccount := exportedBook.getAccount(account, accountMap)
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+			equityAccount := exportedBook.getOpeningBalanceEquityAccount(account.Currency)
+			transactionData.Splits = []*gnucashTransactionSplitData{
+				exportedBook.createSplit(gnucashAccount, transaction.RelatedAccountAmount, transaction.RelatedAccountAmount),
+				exportedBook.createSplit(equityAccount, -transaction.RelatedAccountAmount, -transaction.RelatedAccountAmount),
+			}
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			categoryAccount := exportedBook.getCategoryAccount(transaction.CategoryId, categoryMap, gnucashIncomeAccountType, account.Currency)
+			transactionData.Splits = []*gnucashTransactionSplitData{
+				exportedBook.createSplit(gnucashAccount, transaction.Amount, transaction.Amount),
+				exportedBook.createSplit(categoryAccount, -transaction.Amount, -transaction.Amount),
+			}
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			categoryAccount := exportedBook.getCategoryAccount(transaction.CategoryId, categoryMap, gnucashExpenseAccountType, account.Currency)
+			transactionData.Splits = []*gnucashTransactionSplitData{
+				exportedBook.createSplit(gnucashAccount, -transaction.Amount, -transaction.Amount),
+				exportedBook.createSplit(categoryAccount, transaction.Amount, transaction.Amount),
+			}
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			relatedAccount, exists := accountMap[transaction.RelatedAccountId]
+
+			if !exists {
+				log.Warnf(ctx, "[gnucash_transaction_data_file_exporter.ToExportedContent] cannot find related account \"id:%d\" of transaction \"id:%d\" for user \"uid:%d\", skip this transaction", transaction.RelatedAccountId, transaction.TransactionId, uid)
+				continue
+			}
+
+			relatedGnuCashAccount := exportedBook.getAccount(relatedAccount, accountMap)
+			transactionData.Splits = []*gnucashTransactionSplitData{
+				exportedBook.createSplit(gnucashAccount, -transaction.Amount, -transaction.Amount),
+				exportedBook.createSplit(relatedGnuCashAccount, transaction.Amount, transaction.RelatedAccountAmount),
+			}
+		} else {
+			continue
+		}
+
+		exportedBook.book.Transactions = append(exportedBook.book.Transactions, transactionData)
+	}
+
+	exportedBook.book.Counts = []*gnucashCountData{
+		e.getCountData("commodity", len(exportedBook.book.Commodities)),
+		e.getCountData("account", len(exportedBook.book.Accounts)),
+		e.getCountData("transaction", len(exportedBook.book.Transactions)),
+	}
+
+	database := &gnucashDatabase{
+		Counts: []*gnucashCountData{e.getCountData("book", 1)},
+		Books:  []*gnucashBookData{exportedBook.book},
+	}
+
+	return createNewGnuCashDatabaseWriter(database).write(ctx)
+}
+
+func (e *gnucashTransactionDataExporter) getCountData(key string, count int) *gnucashCountData {
+	return &gnucashCountData{
+		Key:   key,
+		Value: utils.IntToString(count),
+	}
+}
+
+func (b *gnucashExportedBook) nextGuid() string {
+	b.lastGuid++
+	return fmt.Sprintf("%032x", b.lastGuid)
+}
+
+func (b *gnucashExportedBook) getCommodity(currency string) *gnucashCommodityData {
+	if commodity, exists := b.commodities[currency]; exists {
+		return commodity
+	}
+
+	commodity := &gnucashCommodityData{
+		Space: gnucashCommodityCurrencySpace,
+		Id:    currency,
+	}
+
+	b.commodities[currency] = commodity
+	b.book.Commodities = append(b.book.Commodities, commodity)
+
+	return commodity
+}
+
+func (b *gnucashExportedBook) addAccount(name string, accountType string, parentId string, commodity *gnucashCommodityData) *gnucashAccountData {
+	accountData := &gnucashAccountData{
+		Name:        name,
+		Id:          b.nextGuid(),
+		AccountType: accountType,
+		ParentId:    parentId,
+		Commodity:   commodity,
+	}
+
+	b.book.Accounts = append(b.book.Accounts, accountData)
+
+	return accountData
+}
+
+func (b *gnucashExportedBook) getAccount(account *models.Account, accountMap map[int64]*models.Account) *gnucashAccountData {
+	if accountData, exists := b.accounts[account.AccountId]; exists {
+		return accountData
+	}
+
+	accountType, exists := gnucashAccountTypeMapping[account.Category]
+
+	if !exists {
+		accountType = gnucashAssetAccountType
+	}
+
+	parentId := b.rootAccount.Id
+
+	if account.ParentAccountId != models.LevelOneAccountParentId {
+		if parentAccount, exists := accountMap[account.ParentAccountId]; exists {
+			parentAccountData, exists := b.accounts[parentAccount.AccountId]
+
+			if !exists {
+				parentAccountData = b.addAccount(parentAccount.Name, accountType, b.rootAccount.Id, b.getCommodity(account.Currency))
+				b.accounts[parentAccount.AccountId] = parentAccountData
+			}
+
+			parentId = parentAccountData.Id
+		}
+	}
+
+	accountData := b.addAccount(account.Name, accountType, parentId, b.getCommodity(account.Currency))
+	accountData.Description = account.Comment
+	b.accounts[account.AccountId] = accountData
+
+	return accountData
+}
+
+func (b *gnucashExportedBook) getCategoryAccount(categoryId int64, categoryMap map[int64]*models.TransactionCategory, accountType string, currency string) *gnucashAccountData {
+	if accountData, exists := b.categoryAccounts[categoryId]; exists {
+		return accountData
+	}
+
+	category, exists := categoryMap[categoryId]
+
+	if !exists {
+		category = &models.TransactionCategory{
+			CategoryId: categoryId,
+		}
+	}
+
+	parentId := b.rootAccount.Id
+
+	if category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+		if parentCategory, exists := categoryMap[category.ParentCategoryId]; exists {
+			parentId = b.getCategoryAccount(parentCategory.CategoryId, categoryMap, accountType, currency).Id
+		}
+	}
+
+	accountData := b.addAccount(category.Name, accountType, parentId, b.getCommodity(currency))
+	accountData.Description = category.Comment
+	b.categoryAccounts[categoryId] = accountData
+
+	return accountData
+}
+
+func (b *gnucashExportedBook) getOpeningBalanceEquityAccount(currency string) *gnucashAccountData {
+	if accountData, exists := b.openingBalanceEquityAccounts[currency]; exists {
+		return accountData
+	}
+
+	name := gnucashOpeningBalancesAccountName
+
+	if len(b.openingBalanceEquityAccounts) > 0 {
+		name = name + " - " + currency
+	}
+
+	accountData := b.addAccount(name, gnucashEquityAccountType, b.rootAccount.Id, b.getCommodity(currency))
+	accountData.Slots = []*gnucashSlotData{
+		{
+			Key:   gnucashSlotEquityType,
+			Value: gnucashSlotEquityTypeOpeningBalance,
+		},
+	}
+	b.openingBalanceEquityAccounts[currency] = accountData
+
+	return accountData
+}
+
+func (b *gnucashExportedBook) createSplit(account *gnucashAccountData, value int64, quantity int64) *gnucashTransactionSplitData {
+	return &gnucashTransactionSplitData{
+		Id:              b.nextGuid(),
+		ReconciledState: gnucashReconciledStateNotReconciled,
+		Value:           utils.Int64ToString(value) + "/100",
+		Quantity:        utils.Int64ToString(quantity) + "/100",
+		Account:         account.Id,
+	}
+}
@@ -0,0 +1,219 @@
+package gnucash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/converters/converter"
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestGnuCashTransactionDataExporterToExportedContent(t *testing.T) {
+	exporter := GnuCashTransactionDataExporter
+	context := core.NewNullContext()
+
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Test Account", Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Currency: "CNY"},
+	}
+
+	categoryMap := map[int64]*models.TransactionCategory{
+		20: {CategoryId: 20, Name: "Food & Drink", Type: models.CATEGORY_TYPE_EXPENSE},
+	}
+
+	transactions := []*models.Transaction{
+		{TransactionId: 1002, Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725235200), TimezoneUtcOffset: 480, AccountId: 1, CategoryId: 20, Amount: 100, Comment: "Dinner"},
+		{TransactionId: 1001, Type: models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725148800), TimezoneUtcOffset: 0, AccountId: 1, Amount: 12345, RelatedAccountAmount: 12345},
+	}
+
+	content, err := exporter.ToExportedContent(context, 1234567890, transactions, accountMap, categoryMap, nil, nil)
+	assert.Nil(t, err)
+
+	expected := "<?xml version=\"1.0\" encoding=\"utf-8\" ?>\n" +
+		"<gnc-v2\n" +
+		"     xmlns:gnc=\"http://www.gnucash.org/XML/gnc\"\n" +
+		"     xmlns:act=\"http://www.gnucash.org/XML/act\"\n" +
+		"     xmlns:book=\"http://www.gnucash.org/XML/book\"\n" +
+		"     xmlns:cd=\"http://www.gnucash.org/XML/cd\"\n" +
+		"     xmlns:cmdty=\"http://www.gnucash.org/XML/cmdty\"\n" +
+		"     xmlns:price=\"http://www.gnucash.org/XML/price\"\n" +
+		"     xmlns:slot=\"http://www.gnucash.org/XML/slot\"\n" +
+		"     xmlns:split=\"http://www.gnucash.org/XML/split\"\n" +
+		"     xmlns:trn=\"http://www.gnucash.org/XML/trn\"\n" +
+		"     xmlns:ts=\"http://www.gnucash.org/XML/ts\">\n" +
+		"<gnc:count-data cd:type=\"book\">1</gnc:count-data>\n" +
+		"<gnc:book version=\"2.0.0\">\n" +
+		"  <book:id type=\"guid\">00000000000000000000000000000001</book:id>\n" +
+		"<gnc:count-data cd:type=\"commodity\">1</gnc:count-data>\n" +
+		"<gnc:count-data cd:type=\"account\">4</gnc:count-data>\n" +
+		"<gnc:count-data cd:type=\"transaction\">2</gnc:count-data>\n" +
+		"<gnc:commodity version=\"2.0.0\">\n" +
+		"  <cmdty:space>CURRENCY</cmdty:space>\n" +
+		"  <cmdty:id>CNY</cmdty:id>\n" +
+		"</gnc:commodity>\n" +
+		"<gnc:account version=\"2.0.0\">\n" +
+		"  <act:name>Root Account</act:name>\n" +
+		"  <act:id type=\"guid\">00000000000000000000000000000002</act:id>\n" +
+		"  <act:type>ROOT</act:type>\n" +
+		"</gnc:account>\n" +
+		"<gnc:account version=\"2.0.0\">\n" +
+		"  <act:name>Test Account</act:name>\n" +
+		"  <act:id type=\"guid\">00000000000000000000000000000004</act:id>\n" +
+		"  <act:type>BANK</act:type>\n" +
+		"  <act:commodity>\n" +
+		"    <cmdty:space>CURRENCY</cmdty:space>\n" +
+		"    <cmdty:id>CNY</cmdty:id>\n" +
+		"  </act:commodity>\n" +
+		"  <act:parent type=\"guid\">00000000000000000000000000000002</act:parent>\n" +
+		"</gnc:account>\n" +
+		"<gnc:account version=\"2.0.0\">\n" +
+		"  <act:name>Opening Balances</act:name>\n" +
+		"  <act:id type=\"guid\">00000000000000000000000000000005</act:id>\n" +
+		"  <act:type>EQUITY</act:type>\n" +
+		"  <act:commodity>\n" +
+		"    <cmdty:space>CURRENCY</cmdty:space>\n" +
+		"    <cmdty:id>CNY</cmdty:id>\n" +
+		"  </act:commodity>\n" +
+		"  <act:slots>\n" +
+		"    <slot>\n" +
+		"      <slot:key>equity-type</slot:key>\n" +
+		"      <slot:value type=\"string\">opening-balance</slot:value>\n" +
+		"    </slot>\n" +
+		"  </act:slots>\n" +
+		"  <act:parent type=\"guid\">00000000000000000000000000000002</act:parent>\n" +
+		"</gnc:account>\n" +
+		"<gnc:account version=\"2.0.0\">\n" +
+		"  <act:name>Food &amp; Drink</act:name>\n" +
+		"  <act:id type=\"guid\">00000000000000000000000000000009</act:id>\n" +
+		"  <act:type>EXPENSE</act:type>\n" +
+		"  <act:commodity>\n" +
+		"    <cmdty:space>CURRENCY</cmdty:space>\n" +
+		"    <cmdty:id>CNY</cmdty:id>\n" +
+		"  </act:commodity>\n" +
+		"  <act:parent type=\"guid\">00000000000000000000000000000002</act:parent>\n" +
+		"</gnc:account>\n" +
+		"<gnc:transaction version=\"2.0.0\">\n" +
+		"  <trn:id type=\"guid\">00000000000000000000000000000003</trn:id>\n" +
+		"  <trn:currency>\n" +
+		"    <cmdty:space>CURRENCY</cmdty:space>\n" +
+		"    <cmdty:id>CNY</cmdty:id>\n" +
+		"  </trn:currency>\n" +
+		"  <trn:date-posted>\n" +
+		"    <ts:date>2024-09-01 00:00:00 +0000</ts:date>\n" +
+		"  </trn:date-posted>\n" +
+		"  <trn:splits>\n" +
+		"    <trn:split>\n" +
+		"      <split:id type=\"guid\">00000000000000000000000000000006</split:id>\n" +
+		"      <split:reconciled-state>n</split:reconciled-state>\n" +
+		"      <split:value>12345/100</split:value>\n" +
+		"      <split:quantity>12345/100</split:quantity>\n" +
+		"      <split:account type=\"guid\">00000000000000000000000000000004</split:account>\n" +
+		"    </trn:split>\n" +
+		"    <trn:split>\n" +
+		"      <split:id type=\"guid\">00000000000000000000000000000007</split:id>\n" +
+		"      <split:reconciled-state>n</split:reconciled-state>\n" +
+		"      <split:value>-12345/100</split:value>\n" +
+		"      <split:quantity>-12345/100</split:quantity>\n" +
+		"      <split:account type=\"guid\">00000000000000000000000000000005</split:account>\n" +
+		"    </trn:split>\n" +
+		"  </trn:splits>\n" +
+		"</gnc:transaction>\n" +
+		"<gnc:transaction version=\"2.0.0\">\n" +
+		"  <trn:id type=\"guid\">00000000000000000000000000000008</trn:id>\n" +
+		"  <trn:currency>\n" +
+		"    <cmdty:space>CURRENCY</cmdty:space>\n" +
+		"    <cmdty:id>CNY</cmdty:id>\n" +
+		"  </trn:currency>\n" +
+		"  <trn:date-posted>\n" +
+		"    <ts:date>2024-09-02 08:00:00 +0800</ts:date>\n" +
+		"  </trn:date-posted>\n" +
+		"  <trn:description>Dinner</trn:description>\n" +
+		"  <trn:splits>\n" +
+		"    <trn:split>\n" +
+		"      <split:id type=\"guid\">0000000000000000000000000000000a</split:id>\n" +
+		"      <split:reconciled-state>n</split:reconciled-state>\n" +
+		"      <split:value>-100/100</split:value>\n" +
+		"      <split:quantity>-100/100</split:quantity>\n" +
+		"      <split:account type=\"guid\">00000000000000000000000000000004</split:account>\n" +
+		"    </trn:split>\n" +
+		"    <trn:split>\n" +
+		"      <split:id type=\"guid\">0000000000000000000000000000000b</split:id>\n" +
+		"      <split:reconciled-state>n</split:reconciled-state>\n" +
+		"      <split:value>100/100</split:value>\n" +
+		"      <split:quantity>100/100</split:quantity>\n" +
+		"      <split:account type=\"guid\">00000000000000000000000000000009</split:account>\n" +
+		"    </trn:split>\n" +
+		"  </trn:splits>\n" +
+		"</gnc:transaction>\n" +
+		"</gnc:book>\n" +
+		"</gnc-v2>\n"
+
+	assert.Equal(t, expected, string(content))
+}
+
+func TestGnuCashTransactionDataExporterToExportedContent_ImportExportedData(t *testing.T) {
+	exporter := GnuCashTransactionDataExporter
+	importer := GnuCashTransactionDataImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Test Account", Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Currency: "CNY"},
+		2: {AccountId: 2, Name: "Test Card", Category: models.ACCOUNT_CATEGORY_CREDIT_CARD, Currency: "USD"},
+	}
+
+	categoryMap := map[int64]*models.TransactionCategory{
+		10: {CategoryId: 10, Name: "Salary", Type: models.CATEGORY_TYPE_INCOME},
+		20: {CategoryId: 20, Name: "Food", Type: models.CATEGORY_TYPE_EXPENSE},
+		21: {CategoryId: 21, ParentCategoryId: 20, Name: "Dinner", Type: models.CATEGORY_TYPE_EXPENSE},
+	}
+
+	transactions := []*models.Transaction{
+		{TransactionId: 1005, Type: models.TRANSACTION_DB_TYPE_TRANSFER_IN, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), AccountId: 2, Amount: 70, RelatedAccountId: 1, RelatedAccountAmount: 500},
+		{TransactionId: 1004, Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), AccountId: 1, Amount: 500, RelatedAccountId: 2, RelatedAccountAmount: 70},
+		{TransactionId: 1003, Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725321600), AccountId: 2, CategoryId: 21, Amount: 100, Comment: "Dinner with friends"},
+		{TransactionId: 1002, Type: models.TRANSACTION_DB_TYPE_INCOME, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725235200), AccountId: 1, CategoryId: 10, Amount: 12},
+		{TransactionId: 1001, Type: models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725148800), AccountId: 1, Amount: 12345, RelatedAccountAmount: 12345},
+	}
+
+	content, err := exporter.ToExportedContent(context, user.Uid, transactions, accountMap, categoryMap, nil, nil)
+	assert.Nil(t, err)
+
+	allNewTransactions, allNewAccounts, allNewSubExpenseCategories, allNewSubIncomeCategories, _, _, err := importer.ParseImportedData(context, user, content, time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4, len(allNewTransactions))
+	assert.Equal(t, 2, len(allNewAccounts))
+	assert.Equal(t, 1, len(allNewSubExpenseCategories))
+	assert.Equal(t, 1, len(allNewSubIncomeCategories))
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, allNewTransactions[0].Type)
+	assert.Equal(t, int64(1725148800), utils.GetUnixTimeFromTransactionTime(allNewTransactions[0].TransactionTime))
+	assert.Equal(t, int64(12345), allNewTransactions[0].Amount)
+	assert.Equal(t, "Test Account", allNewTransactions[0].OriginalSourceAccountName)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_INCOME, allNewTransactions[1].Type)
+	assert.Equal(t, int64(12), allNewTransactions[1].Amount)
+	assert.Equal(t, "Salary", allNewTransactions[1].OriginalCategoryName)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_EXPENSE, allNewTransactions[2].Type)
+	assert.Equal(t, int64(100), allNewTransactions[2].Amount)
+	assert.Equal(t, "Test Card", allNewTransactions[2].OriginalSourceAccountName)
+	assert.Equal(t, "Dinner", allNewTransactions[2].OriginalCategoryName)
+	assert.Equal(t, "Dinner with friends", allNewTransactions[2].Comment)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_TRANSFER_OUT, allNewTransactions[3].Type)
+	assert.Equal(t, int64(500), allNewTransactions[3].Amount)
+	assert.Equal(t, int64(70), allNewTransactions[3].RelatedAccountAmount)
+	assert.Equal(t, "Test Account", allNewTransactions[3].OriginalSourceAccountName)
+	assert.Equal(t, "Test Card", allNewTransactions[3].OriginalDestinationAccountName)
+
+	assert.Equal(t, "USD", allNewAccounts[1].Currency)
+}
@@ -0,0 +1,171 @@
+package ofx
+
+import (
+	"bytes"
+	"encoding/xml"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const ofxLineSeparator = "\n"
+const ofxDefaultDataVersion = "220"
+const ofxDefaultLanguage = "ENG"
+const ofxStatusCodeSuccess = "0"
+const ofxStatusSeverityInfo = "INFO"
+
+// ofxFileWriter defines the structure of open financial exchange (ofx) 2.x file writer
+type ofxFileWriter struct {
+	serverTime           string
+	bankStatements       []*ofxBankStatementResponse
+	creditCardStatements []*ofxCreditCardStatementResponse
+}
+
+// write returns the open financial exchange (ofx) 2.x file content of the statements
+// Reference: https://financialdataexchange.org/common/Uploaded%20files/OFX%20files/OFX%20Banking%20Specification%20v2.3.pdf
+func (w *ofxFileWriter) write(ctx core.Context) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>" + ofxLineSeparator)
+	buffer.WriteString("<?OFX OFXHEADER=\"" + string(ofxVersion2) + "\" VERSION=\"" + ofxDefaultDataVersion + "\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>" + ofxLineSeparator)
+	buffer.WriteString("<OFX>" + ofxLineSeparator)
+
+	buffer.WriteString("<SIGNONMSGSRSV1>" + ofxLineSeparator)
+	buffer.WriteString("<SONRS>" + ofxLineSeparator)
+	w.writeStatus(&buffer)
+	w.writeElement(&buffer, "DTSERVER", w.serverTime)
+	w.writeElement(&buffer, "LANGUAGE", ofxDefaultLanguage)
+	buffer.WriteString("</SONRS>" + ofxLineSeparator)
+	buffer.WriteString("</SIGNONMSGSRSV1>" + ofxLineSeparator)
+
+	transactionUid := 0
+
+	if len(w.bankStatements) > 0 {
+		buffer.WriteString("<BANKMSGSRSV1>" + ofxLineSeparator)
+
+		for i := 0; i < len(w.bankStatements); i++ {
+			statement := w.bankStatements[i]
+			transactionUid++
+
+			buffer.WriteString("<STMTTRNRS>" + ofxLineSeparator)
+			w.writeElement(&buffer, "TRNUID", utils.IntToString(transactionUid))
+			w.writeStatus(&buffer)
+			buffer.WriteString("<STMTRS>" + ofxLineSeparator)
+			w.writeElement(&buffer, "CURDEF", statement.DefaultCurrency)
+			w.writeBankAccount(&buffer, "BANKACCTFROM", statement.AccountFrom)
+			buffer.WriteString("<BANKTRANLIST>" + ofxLineSeparator)
+			w.writeElement(&buffer, "DTSTART", statement.TransactionList.StartDate)
+			w.writeElement(&buffer, "DTEND", statement.TransactionList.EndDate)
+
+			for j := 0; j < len(statement.TransactionList.StatementTransactions); j++ {
+				transaction := statement.TransactionList.StatementTransactions[j]
+
+				buffer.WriteString("<STMTTRN>" + ofxLineSeparator)
+				w.writeBaseStatementTransaction(&buffer, &transaction.ofxBaseStatementTransaction)
+
+				if transaction.AccountTo != nil {
+					w.writeBankAccount(&buffer, "BANKACCTTO", transaction.AccountTo)
+				}
+
+				w.writeElement(&buffer, "MEMO", transaction.Memo)
+				buffer.WriteString("</STMTTRN>" + ofxLineSeparator)
+			}
+
+			buffer.WriteString("</BANKTRANLIST>" + ofxLineSeparator)
+			buffer.WriteString("</STMTRS>" + ofxLineSeparator)
+			buffer.WriteString("</STMTTRNRS>" + ofxLineSeparator)
+		}
+
+		buffer.WriteString("</BANKMSGSRSV1>" + ofxLineSeparator)
+	}
+
+	if len(w.creditCardStatements) > 0 {
+		buffer.WriteString("<CREDITCARDMSGSRSV1>" + ofxLineSeparator)
+
+		for i := 0; i < len(w.creditCardStatements); i++ {
+			statement := w.creditCardStatements[i]
+			transactionUid++
+
+			buffer.WriteString("<CCSTMTTRNRS>" + ofxLineSeparator)
+			w.writeElement(&buffer, "TRNUID", utils.IntToString(transactionUid))
+			w.writeStatus(&buffer)
+			buffer.WriteString("<CCSTMTRS>" + ofxLineSeparator)
+			w.writeElement(&buffer, "CURDEF", statement.DefaultCurrency)
+			w.writeCreditCardAccount(&buffer, "CCACCTFROM", statement.AccountFrom)
+			buffer.WriteString("<BANKTRANLIST>" + ofxLineSeparator)
+			w.writeElement(&buffer, "DTSTART", statement.TransactionList.StartDate)
+			w.writeElement(&buffer, "DTEND", statement.TransactionList.EndDate)
+
+			for j := 0; j < len(statement.TransactionList.StatementTransactions); j++ {
+				transaction := statement.TransactionList.StatementTransactions[j]
+
+				buffer.WriteString("<STMTTRN>" + ofxLineSeparator)
+				w.writeBaseStatementTransaction(&buffer, &transaction.ofxBaseStatementTransaction)
+
+				if transaction.AccountTo != nil {
+					w.writeCreditCardAccount(&buffer, "CCACCTTO", transaction.AccountTo)
+				}
+
+				w.writeElement(&buffer, "MEMO", transaction.Memo)
+				buffer.WriteString("</STMTTRN>" + ofxLineSeparator)
+			}
+
+			buffer.WriteString("</BANKTRANLIST>" + ofxLineSeparator)
+			buffer.WriteString("</CCSTMTRS>" + ofxLineSeparator)
+			buffer.WriteString("</CCSTMTTRNRS>" + ofxLineSeparator)
+		}
+
+		buffer.WriteString("</CREDITCARDMSGSRSV1>" + ofxLineSeparator)
+	}
+
+	buffer.WriteString("</OFX>" + ofxLineSeparator)
+
+	return buffer.Bytes(), nil
+}
+
+func (w *ofxFileWriter) writeStatus(buffer *bytes.Buffer) {
+	buffer.WriteString("<STATUS>" + ofxLineSeparator)
+	w.writeElement(buffer, "CODE", ofxStatusCodeSuccess)
+	w.writeElement(buffer, "SEVERITY", ofxStatusSeverityInfo)
+	buffer.WriteString("</STATUS>" + ofxLineSeparator)
+}
+
+func (w *ofxFileWriter) writeBaseStatementTransaction(buffer *bytes.Buffer, transaction *ofxBaseStatementTransaction) {
+	w.writeElement(buffer, "TRNTYPE", string(transaction.TransactionType))
+	w.writeElement(buffer, "DTPOSTED", transaction.PostedDate)
+	w.writeElement(buffer, "TRNAMT", transaction.Amount)
+	w.writeElement(buffer, "FITID", transaction.TransactionId)
+	w.writeElement(buffer, "NAME", transaction.Name)
+}
+
+func (w *ofxFileWriter) writeBankAccount(buffer *bytes.Buffer, elementName string, account *ofxBankAccount) {
+	buffer.WriteString("<" + elementName + ">" + ofxLineSeparator)
+	w.writeElement(buffer, "BANKID", account.BankId)
+	w.writeElement(buffer, "ACCTID", account.AccountId)
+	w.writeElement(buffer, "ACCTTYPE", string(account.AccountType))
+	buffer.WriteString("</" + elementName + ">" + ofxLineSeparator)
+}
+
+func (w *ofxFileWriter) writeCreditCardAccount(buffer *bytes.Buffer, elementName string, account *ofxCreditCardAccount) {
+	buffer.WriteString("<" + elementName + ">" + ofxLineSeparator)
+	w.writeElement(buffer, "ACCTID", account.AccountId)
+	buffer.WriteString("</" + elementName + ">" + ofxLineSeparator)
+}
+
+func (w *ofxFileWriter) writeElement(buffer *bytes.Buffer, elementName string, value string) {
+	if value == "" {
+		return
+	}
+
+	buffer.WriteString("<" + elementName + ">")
+	_ = xml.EscapeText(buffer, []byte(value))
+	buffer.WriteString("</" + elementName + ">" + ofxLineSeparator)
+}
+
+func createNewOFXFileWriter(serverTime string, bankStatements []*ofxBankStatementResponse, creditCardStatements []*ofxCreditCardStatementResponse) *ofxFileWriter {
+	return &ofxFileWriter{
+		serverTime:           serverTime,
+		bankStatements:       bankStatements,
+		creditCardStatements: creditCardStatements,
+	}
+}
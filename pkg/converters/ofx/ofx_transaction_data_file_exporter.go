@@ -0,0 +1,206 @@
+package ofx
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const ofxDefaultBankId = "000000000"
+const ofxDateTimeFormat = "20060102150405"
+
+var ofxAccountTypeMapping = map[models.AccountCategory]ofxAccountType{
+	models.ACCOUNT_CATEGORY_CASH:                   ofxCheckingAccount,
+	models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT:       ofxCheckingAccount,
+	models.ACCOUNT_CATEGORY_CREDIT_CARD:            ofxLineOfCreditAccount,
+	models.ACCOUNT_CATEGORY_VIRTUAL:                ofxCheckingAccount,
+	models.ACCOUNT_CATEGORY_DEBT:                   ofxLineOfCreditAccount,
+	models.ACCOUNT_CATEGORY_RECEIVABLES:            ofxCheckingAccount,
+	models.ACCOUNT_CATEGORY_INVESTMENT:             ofxMoneyMarketAccount,
+	models.ACCOUNT_CATEGORY_SAVINGS_ACCOUNT:        ofxSavingsAccount,
+	models.ACCOUNT_CATEGORY_CERTIFICATE_OF_DEPOSIT: ofxCertificateOfDepositAccount,
+}
+
+// ofxTransactionDataExporter defines the structure of open financial exchange (ofx) exporter for transaction data
+type ofxTransactionDataExporter struct {
+}
+
+// ofxExportedAccountStatement defines the structure of the statement and the transactions of one exported account
+type ofxExportedAccountStatement struct {
+	account      *models.Account
+	transactions []*ofxBaseStatementTransaction
+	accountsTo   []*models.Account
+	minTime      string
+	maxTime      string
+}
+
+// Initialize an open financial exchange (ofx) transaction data exporter singleton instance
+var (
+	OFXTransactionDataExporter = &ofxTransactionDataExporter{}
+)
+
+// ToExportedContent returns the exported transaction data in open financial exchange (ofx) 2.x format, each account is exported as a statement
+func (e *ofxTransactionDataExporter) ToExportedContent(ctx core.Context, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, allTagIndexes map[int64][]int64) ([]byte, error) {
+	accountStatements := make([]*ofxExportedAccountStatement, 0)
+	accountStatementMap := make(map[int64]*ofxExportedAccountStatement)
+
+	// transactions are sorted by time in descending order, but statement transactions are written in ascending order
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			log.Warnf(ctx, "[ofx_transaction_data_file_exporter.ToExportedContent] cannot find account \"id:%d\" of transaction \"id:%d\" for user \"uid:%d\", skip this transaction", transaction.AccountId, transaction.TransactionId, uid)
+			continue
+		}
+
+		statementTransaction := &ofxBaseStatementTransaction{
+			TransactionId: utils.Int64ToString(transaction.TransactionId),
+			PostedDate:    e.getOFXDateTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), transaction.TimezoneUtcOffset),
+			Name:          e.getCategoryName(transaction.CategoryId, categoryMap),
+			Memo:          transaction.Comment,
+		}
+
+		var accountTo *models.Account
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+			statementTransaction.TransactionType = ofxOtherTransaction
+			statementTransaction.Amount = utils.FormatAmount(transaction.RelatedAccountAmount)
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			statementTransaction.TransactionType = ofxDepositTransaction
+			statementTransaction.Amount = utils.FormatAmount(transaction.Amount)
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			statementTransaction.TransactionType = ofxGenericDebitTransaction
+			statementTransaction.Amount = utils.FormatAmount(-transaction.Amount)
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			statementTransaction.TransactionType = ofxTransferTransaction
+			statementTransaction.Amount = utils.FormatAmount(-transaction.Amount)
+			accountTo = accountMap[transaction.RelatedAccountId]
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			statementTransaction.TransactionType = ofxTransferTransaction
+			statementTransaction.Amount = utils.FormatAmount(transaction.Amount)
+		} else {
+			continue
+		}
+
+		accountStatement, exists := accountStatementMap[account.AccountId]
+
+		if !exists {
+			accountStatement = &ofxExportedAccountStatement{
+				account: account,
+				minTime: statementTransaction.PostedDate,
+			}
+
+			accountStatementMap[account.AccountId] = accountStatement
+			accountStatements = append(accountStatements, accountStatement)
+		}
+
+		accountStatement.transactions = append(accountStatement.transactions, statementTransaction)
+		accountStatement.accountsTo = append(accountStatement.accountsTo, accountTo)
+		accountStatement.maxTime = statementTransaction.PostedDate
+	}
+
+	bankStatements := make([]*ofxBankStatementResponse, 0)
+	creditCardStatements := make([]*ofxCreditCardStatementResponse, 0)
+
+	for i := 0; i < len(accountStatements); i++ {
+		accountStatement := accountStatements[i]
+
+		if accountStatement.account.Category == models.ACCOUNT_CATEGORY_CREDIT_CARD {
+			statement := &ofxCreditCardStatementResponse{
+				DefaultCurrency: accountStatement.account.Currency,
+				AccountFrom: &ofxCreditCardAccount{
+					AccountId: accountStatement.account.Name,
+				},
+				TransactionList: &ofxCreditCardTransactionList{
+					StartDate:             accountStatement.minTime,
+					EndDate:               accountStatement.maxTime,
+					StatementTransactions: make([]*ofxCreditCardStatementTransaction, len(accountStatement.transactions)),
+				},
+			}
+
+			for j := 0; j < len(accountStatement.transactions); j++ {
+				statementTransaction := &ofxCreditCardStatementTransaction{
+					ofxBaseStatementTransaction: *accountStatement.transactions[j],
+				}
+
+				if accountStatement.accountsTo[j] != nil {
+					statementTransaction.AccountTo = &ofxCreditCardAccount{
+						AccountId: accountStatement.accountsTo[j].Name,
+					}
+				}
+
+				statement.TransactionList.StatementTransactions[j] = statementTransaction
+			}
+
+			creditCardStatements = append(creditCardStatements, statement)
+		} else {
+			statement := &ofxBankStatementResponse{
+				DefaultCurrency: accountStatement.account.Currency,
+				AccountFrom:     e.getBankAccount(accountStatement.account),
+				TransactionList: &ofxBankTransactionList{
+					StartDate:             accountStatement.minTime,
+					EndDate:               accountStatement.maxTime,
+					StatementTransactions: make([]*ofxBankStatementTransaction, len(accountStatement.transactions)),
+				},
+			}
+
+			for j := 0; j < len(accountStatement.transactions); j++ {
+				statementTransaction := &ofxBankStatementTransaction{
+					ofxBaseStatementTransaction: *accountStatement.transactions[j],
+				}
+
+				if accountStatement.accountsTo[j] != nil {
+					statementTransaction.AccountTo = e.getBankAccount(accountStatement.accountsTo[j])
+				}
+
+				statement.TransactionList.StatementTransactions[j] = statementTransaction
+			}
+
+			bankStatements = append(bankStatements, statement)
+		}
+	}
+
+	serverTime := e.getOFXDateTime(time.Now().Unix(), 0)
+
+	return createNewOFXFileWriter(serverTime, bankStatements, creditCardStatements).write(ctx)
+}
+
+func (e *ofxTransactionDataExporter) getBankAccount(account *models.Account) *ofxBankAccount {
+	accountType, exists := ofxAccountTypeMapping[account.Category]
+
+	if !exists {
+		accountType = ofxCheckingAccount
+	}
+
+	return &ofxBankAccount{
+		BankId:      ofxDefaultBankId,
+		AccountId:   account.Name,
+		AccountType: accountType,
+	}
+}
+
+func (e *ofxTransactionDataExporter) getCategoryName(categoryId int64, categoryMap map[int64]*models.TransactionCategory) string {
+	category, exists := categoryMap[categoryId]
+
+	if !exists {
+		return ""
+	}
+
+	return category.Name
+}
+
+// getOFXDateTime returns the datetime in "YYYYMMDDHHMMSS.XXX[gmt offset]" format
+func (e *ofxTransactionDataExporter) getOFXDateTime(unixTime int64, utcOffset int16) string {
+	timezone := time.FixedZone("Transaction Timezone", int(utcOffset)*60)
+	hoursOffset := utils.Float64ToString(float64(utcOffset) / 60)
+
+	if utcOffset >= 0 {
+		hoursOffset = "+" + hoursOffset
+	}
+
+	return time.Unix(unixTime, 0).In(timezone).Format(ofxDateTimeFormat) + ".000[" + hoursOffset + "]"
+}
@@ -0,0 +1,153 @@
+package ofx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/converters/converter"
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestOFXTransactionDataExporterToExportedContent(t *testing.T) {
+	exporter := OFXTransactionDataExporter
+	context := core.NewNullContext()
+
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Test Account", Category: models.ACCOUNT_CATEGORY_SAVINGS_ACCOUNT, Currency: "CNY"},
+		2: {AccountId: 2, Name: "Test Card", Category: models.ACCOUNT_CATEGORY_CREDIT_CARD, Currency: "CNY"},
+	}
+
+	categoryMap := map[int64]*models.TransactionCategory{
+		20: {CategoryId: 20, Name: "Food & Drink", Type: models.CATEGORY_TYPE_EXPENSE},
+	}
+
+	transactions := []*models.Transaction{
+		{TransactionId: 1003, Type: models.TRANSACTION_DB_TYPE_TRANSFER_IN, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), TimezoneUtcOffset: 480, AccountId: 2, Amount: 5, RelatedAccountId: 1, RelatedAccountAmount: 5},
+		{TransactionId: 1002, Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), TimezoneUtcOffset: 480, AccountId: 1, Amount: 5, RelatedAccountId: 2, RelatedAccountAmount: 5},
+		{TransactionId: 1001, Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725321600), TimezoneUtcOffset: -330, AccountId: 1, CategoryId: 20, Amount: 100, Comment: "<Dinner>"},
+	}
+
+	content, err := exporter.ToExportedContent(context, 1234567890, transactions, accountMap, categoryMap, nil, nil)
+	assert.Nil(t, err)
+
+	actualContent := string(content)
+
+	assert.True(t, strings.HasPrefix(actualContent, "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n"+
+		"<?OFX OFXHEADER=\"200\" VERSION=\"220\" SECURITY=\"NONE\" OLDFILEUID=\"NONE\" NEWFILEUID=\"NONE\"?>\n"+
+		"<OFX>\n"))
+
+	assert.Contains(t, actualContent, "<BANKMSGSRSV1>\n"+
+		"<STMTTRNRS>\n"+
+		"<TRNUID>1</TRNUID>\n"+
+		"<STATUS>\n"+
+		"<CODE>0</CODE>\n"+
+		"<SEVERITY>INFO</SEVERITY>\n"+
+		"</STATUS>\n"+
+		"<STMTRS>\n"+
+		"<CURDEF>CNY</CURDEF>\n"+
+		"<BANKACCTFROM>\n"+
+		"<BANKID>000000000</BANKID>\n"+
+		"<ACCTID>Test Account</ACCTID>\n"+
+		"<ACCTTYPE>SAVINGS</ACCTTYPE>\n"+
+		"</BANKACCTFROM>\n"+
+		"<BANKTRANLIST>\n"+
+		"<DTSTART>20240902183000.000[-5.5]</DTSTART>\n"+
+		"<DTEND>20240904080000.000[+8]</DTEND>\n"+
+		"<STMTTRN>\n"+
+		"<TRNTYPE>DEBIT</TRNTYPE>\n"+
+		"<DTPOSTED>20240902183000.000[-5.5]</DTPOSTED>\n"+
+		"<TRNAMT>-1.00</TRNAMT>\n"+
+		"<FITID>1001</FITID>\n"+
+		"<NAME>Food &amp; Drink</NAME>\n"+
+		"<MEMO>&lt;Dinner&gt;</MEMO>\n"+
+		"</STMTTRN>\n"+
+		"<STMTTRN>\n"+
+		"<TRNTYPE>XFER</TRNTYPE>\n"+
+		"<DTPOSTED>20240904080000.000[+8]</DTPOSTED>\n"+
+		"<TRNAMT>-0.05</TRNAMT>\n"+
+		"<FITID>1002</FITID>\n"+
+		"<BANKACCTTO>\n"+
+		"<BANKID>000000000</BANKID>\n"+
+		"<ACCTID>Test Card</ACCTID>\n"+
+		"<ACCTTYPE>CREDITLINE</ACCTTYPE>\n"+
+		"</BANKACCTTO>\n"+
+		"</STMTTRN>\n"+
+		"</BANKTRANLIST>\n"+
+		"</STMTRS>\n"+
+		"</STMTTRNRS>\n"+
+		"</BANKMSGSRSV1>\n")
+
+	assert.Contains(t, actualContent, "<CREDITCARDMSGSRSV1>\n"+
+		"<CCSTMTTRNRS>\n"+
+		"<TRNUID>2</TRNUID>\n"+
+		"<STATUS>\n"+
+		"<CODE>0</CODE>\n"+
+		"<SEVERITY>INFO</SEVERITY>\n"+
+		"</STATUS>\n"+
+		"<CCSTMTRS>\n"+
+		"<CURDEF>CNY</CURDEF>\n"+
+		"<CCACCTFROM>\n"+
+		"<ACCTID>Test Card</ACCTID>\n"+
+		"</CCACCTFROM>\n"+
+		"<BANKTRANLIST>\n"+
+		"<DTSTART>20240904080000.000[+8]</DTSTART>\n"+
+		"<DTEND>20240904080000.000[+8]</DTEND>\n"+
+		"<STMTTRN>\n"+
+		"<TRNTYPE>XFER</TRNTYPE>\n"+
+		"<DTPOSTED>20240904080000.000[+8]</DTPOSTED>\n"+
+		"<TRNAMT>0.05</TRNAMT>\n"+
+		"<FITID>1003</FITID>\n"+
+		"</STMTTRN>\n"+
+		"</BANKTRANLIST>\n"+
+		"</CCSTMTRS>\n"+
+		"</CCSTMTTRNRS>\n"+
+		"</CREDITCARDMSGSRSV1>\n"+
+		"</OFX>\n")
+}
+
+func TestOFXTransactionDataExporterToExportedContent_ImportExportedData(t *testing.T) {
+	exporter := OFXTransactionDataExporter
+	importer := OFXTransactionDataImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Test Account", Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Currency: "USD"},
+	}
+
+	transactions := []*models.Transaction{
+		{TransactionId: 1002, Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), TimezoneUtcOffset: 480, AccountId: 1, Amount: 100, Comment: "Dinner"},
+		{TransactionId: 1001, Type: models.TRANSACTION_DB_TYPE_INCOME, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725321600), TimezoneUtcOffset: 480, AccountId: 1, Amount: 12345},
+	}
+
+	content, err := exporter.ToExportedContent(context, user.Uid, transactions, accountMap, nil, nil, nil)
+	assert.Nil(t, err)
+
+	allNewTransactions, allNewAccounts, _, _, _, _, err := importer.ParseImportedData(context, user, content, time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(allNewTransactions))
+	assert.Equal(t, 1, len(allNewAccounts))
+
+	assert.Equal(t, "Test Account", allNewAccounts[0].Name)
+	assert.Equal(t, "USD", allNewAccounts[0].Currency)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_INCOME, allNewTransactions[0].Type)
+	assert.Equal(t, int64(1725321600), utils.GetUnixTimeFromTransactionTime(allNewTransactions[0].TransactionTime))
+	assert.Equal(t, int16(480), allNewTransactions[0].TimezoneUtcOffset)
+	assert.Equal(t, int64(12345), allNewTransactions[0].Amount)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_EXPENSE, allNewTransactions[1].Type)
+	assert.Equal(t, int64(1725408000), utils.GetUnixTimeFromTransactionTime(allNewTransactions[1].TransactionTime))
+	assert.Equal(t, int64(100), allNewTransactions[1].Amount)
+	assert.Equal(t, "Dinner", allNewTransactions[1].Comment)
+}
@@ -23,6 +23,15 @@ const (
 	qifElectronicPayeeTransactionType qifTransactionType = "KE"
 )
 
+// Quicken interchange format account types
+const (
+	qifBankAccountType       = "Bank"
+	qifCashAccountType       = "Cash"
+	qifCreditCardAccountType = "CCard"
+	qifAssetAccountType      = "Oth A"
+	qifLiabilityAccountType  = "Oth L"
+)
+
 // qifCategoryType represents the quicken interchange format (qif) category type
 type qifCategoryType string
 
@@ -0,0 +1,123 @@
+package qif
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+const qifLineSeparator = "\n"
+
+// qifDataWriter defines the structure of quicken interchange format (qif) data writer
+type qifDataWriter struct {
+	data *qifData
+}
+
+// write returns the quicken interchange format (qif) file content of the data
+// Reference: https://www.w3.org/2000/10/swap/pim/qif-doc/QIF-doc.htm
+func (w *qifDataWriter) write(ctx core.Context) ([]byte, error) {
+	if w.data == nil {
+		return nil, errs.ErrOperationFailed
+	}
+
+	var buffer bytes.Buffer
+
+	if len(w.data.Categories) > 0 {
+		buffer.WriteString(qifCategoryHeader + qifLineSeparator)
+
+		for i := 0; i < len(w.data.Categories); i++ {
+			category := w.data.Categories[i]
+
+			w.writeLine(&buffer, 'N', category.Name)
+			w.writeLine(&buffer, 'D', category.Description)
+
+			if category.CategoryType != "" {
+				buffer.WriteString(string(category.CategoryType) + qifLineSeparator)
+			}
+
+			buffer.WriteString(string(qifEntryEnd) + qifLineSeparator)
+		}
+	}
+
+	for i := 0; i < len(w.data.Accounts); i++ {
+		account := w.data.Accounts[i]
+
+		buffer.WriteString(qifAccountHeader + qifLineSeparator)
+		w.writeLine(&buffer, 'N', account.Name)
+		w.writeLine(&buffer, 'T', account.AccountType)
+		w.writeLine(&buffer, 'D', account.Description)
+		buffer.WriteString(string(qifEntryEnd) + qifLineSeparator)
+
+		transactions := w.getAccountTransactions(account)
+
+		if len(transactions) < 1 {
+			continue
+		}
+
+		buffer.WriteString(qifTypeHeaderPrefix + account.AccountType + qifLineSeparator)
+
+		for j := 0; j < len(transactions); j++ {
+			transaction := transactions[j]
+
+			w.writeLine(&buffer, 'D', transaction.Date)
+			w.writeLine(&buffer, 'T', transaction.Amount)
+			w.writeLine(&buffer, 'C', string(transaction.ClearedStatus))
+			w.writeLine(&buffer, 'N', transaction.Num)
+			w.writeLine(&buffer, 'P', transaction.Payee)
+			w.writeLine(&buffer, 'M', transaction.Memo)
+			w.writeLine(&buffer, 'L', transaction.Category)
+			buffer.WriteString(string(qifEntryEnd) + qifLineSeparator)
+		}
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (w *qifDataWriter) getAccountTransactions(account *qifAccountData) []*qifTransactionData {
+	var allTransactions []*qifTransactionData
+
+	switch account.AccountType {
+	case qifBankAccountType:
+		allTransactions = w.data.BankAccountTransactions
+	case qifCashAccountType:
+		allTransactions = w.data.CashAccountTransactions
+	case qifCreditCardAccountType:
+		allTransactions = w.data.CreditCardAccountTransactions
+	case qifAssetAccountType:
+		allTransactions = w.data.AssetAccountTransactions
+	case qifLiabilityAccountType:
+		allTransactions = w.data.LiabilityAccountTransactions
+	default:
+		return nil
+	}
+
+	transactions := make([]*qifTransactionData, 0, len(allTransactions))
+
+	for i := 0; i < len(allTransactions); i++ {
+		if allTransactions[i].Account == account {
+			transactions = append(transactions, allTransactions[i])
+		}
+	}
+
+	return transactions
+}
+
+func (w *qifDataWriter) writeLine(buffer *bytes.Buffer, fieldCode rune, value string) {
+	if value == "" {
+		return
+	}
+
+	value = strings.ReplaceAll(value, "\r", " ")
+	value = strings.ReplaceAll(value, "\n", " ")
+
+	buffer.WriteRune(fieldCode)
+	buffer.WriteString(value + qifLineSeparator)
+}
+
+func createNewQifDataWriter(data *qifData) *qifDataWriter {
+	return &qifDataWriter{
+		data: data,
+	}
+}
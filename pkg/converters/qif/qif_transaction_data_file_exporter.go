@@ -0,0 +1,157 @@
+package qif
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const qifCategoryNameSeparator = ":"
+
+var qifAccountTypeMapping = map[models.AccountCategory]string{
+	models.ACCOUNT_CATEGORY_CASH:                   qifCashAccountType,
+	models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT:       qifBankAccountType,
+	models.ACCOUNT_CATEGORY_CREDIT_CARD:            qifCreditCardAccountType,
+	models.ACCOUNT_CATEGORY_VIRTUAL:                qifAssetAccountType,
+	models.ACCOUNT_CATEGORY_DEBT:                   qifLiabilityAccountType,
+	models.ACCOUNT_CATEGORY_RECEIVABLES:            qifAssetAccountType,
+	models.ACCOUNT_CATEGORY_INVESTMENT:             qifAssetAccountType,
+	models.ACCOUNT_CATEGORY_SAVINGS_ACCOUNT:        qifBankAccountType,
+	models.ACCOUNT_CATEGORY_CERTIFICATE_OF_DEPOSIT: qifBankAccountType,
+}
+
+// qifTransactionDataExporter defines the structure of quicken interchange format (qif) exporter for transaction data
+type qifTransactionDataExporter struct {
+}
+
+// Initialize a quicken interchange format (qif) transaction data exporter singleton instance
+var (
+	QifTransactionDataExporter = &qifTransactionDataExporter{}
+)
+
+// ToExportedContent returns the exported transaction data in quicken interchange format (qif), the dates are in year-month-day format
+func (e *qifTransactionDataExporter) ToExportedContent(ctx core.Context, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, allTagIndexes map[int64][]int64) ([]byte, error) {
+	data := &qifData{}
+	accountDataMap := make(map[int64]*qifAccountData)
+	categoryDataMap := make(map[int64]*qifCategoryData)
+
+	// transactions are sorted by time in descending order, but qif entries are written in ascending order
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			continue
+		}
+
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			log.Warnf(ctx, "[qif_transaction_data_file_exporter.ToExportedContent] cannot find account \"id:%d\" of transaction \"id:%d\" for user \"uid:%d\", skip this transaction", transaction.AccountId, transaction.TransactionId, uid)
+			continue
+		}
+
+		accountData, exists := accountDataMap[account.AccountId]
+
+		if !exists {
+			accountData = &qifAccountData{
+				Name:        account.Name,
+				AccountType: e.getAccountType(account),
+			}
+
+			accountDataMap[account.AccountId] = accountData
+			data.Accounts = append(data.Accounts, accountData)
+		}
+
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+		transactionTimeZone := time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+
+		transactionData := &qifTransactionData{
+			Date:    utils.FormatUnixTimeToLongDate(transactionUnixTime, transactionTimeZone),
+			Memo:    transaction.Comment,
+			Account: accountData,
+		}
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+			transactionData.Amount = utils.FormatAmount(transaction.RelatedAccountAmount)
+			transactionData.Payee = qifOpeningBalancePayeeText
+			transactionData.Category = "[" + account.Name + "]"
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			transactionData.Amount = utils.FormatAmount(transaction.Amount)
+			transactionData.Category = e.getCategoryName(data, categoryDataMap, transaction.CategoryId, categoryMap, qifIncomeTransaction)
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			transactionData.Amount = utils.FormatAmount(-transaction.Amount)
+			transactionData.Category = e.getCategoryName(data, categoryDataMap, transaction.CategoryId, categoryMap, qifExpenseTransaction)
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			relatedAccount, exists := accountMap[transaction.RelatedAccountId]
+
+			if !exists {
+				log.Warnf(ctx, "[qif_transaction_data_file_exporter.ToExportedContent] cannot find related account \"id:%d\" of transaction \"id:%d\" for user \"uid:%d\", skip this transaction", transaction.RelatedAccountId, transaction.TransactionId, uid)
+				continue
+			}
+
+			transactionData.Amount = utils.FormatAmount(-transaction.Amount)
+			transactionData.Category = "[" + relatedAccount.Name + "]"
+		} else {
+			continue
+		}
+
+		switch accountData.AccountType {
+		case qifBankAccountType:
+			data.BankAccountTransactions = append(data.BankAccountTransactions, transactionData)
+		case qifCashAccountType:
+			data.CashAccountTransactions = append(data.CashAccountTransactions, transactionData)
+		case qifCreditCardAccountType:
+			data.CreditCardAccountTransactions = append(data.CreditCardAccountTransactions, transactionData)
+		case qifAssetAccountType:
+			data.AssetAccountTransactions = append(data.AssetAccountTransactions, transactionData)
+		case qifLiabilityAccountType:
+			data.LiabilityAccountTransactions = append(data.LiabilityAccountTransactions, transactionData)
+		}
+	}
+
+	return createNewQifDataWriter(data).write(ctx)
+}
+
+func (e *qifTransactionDataExporter) getAccountType(account *models.Account) string {
+	accountType, exists := qifAccountTypeMapping[account.Category]
+
+	if !exists {
+		return qifBankAccountType
+	}
+
+	return accountType
+}
+
+func (e *qifTransactionDataExporter) getCategoryName(data *qifData, categoryDataMap map[int64]*qifCategoryData, categoryId int64, categoryMap map[int64]*models.TransactionCategory, categoryType qifCategoryType) string {
+	if categoryData, exists := categoryDataMap[categoryId]; exists {
+		return categoryData.Name
+	}
+
+	category, exists := categoryMap[categoryId]
+
+	if !exists {
+		return ""
+	}
+
+	categoryName := strings.ReplaceAll(category.Name, qifCategoryNameSeparator, " ")
+
+	if category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+		if parentCategory, exists := categoryMap[category.ParentCategoryId]; exists {
+			categoryName = strings.ReplaceAll(parentCategory.Name, qifCategoryNameSeparator, " ") + qifCategoryNameSeparator + categoryName
+		}
+	}
+
+	categoryData := &qifCategoryData{
+		Name:         categoryName,
+		CategoryType: categoryType,
+	}
+
+	categoryDataMap[categoryId] = categoryData
+	data.Categories = append(data.Categories, categoryData)
+
+	return categoryName
+}
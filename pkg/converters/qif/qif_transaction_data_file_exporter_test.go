@@ -0,0 +1,124 @@
+package qif
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/converters/converter"
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func getQifExporterTestData() ([]*models.Transaction, map[int64]*models.Account, map[int64]*models.TransactionCategory) {
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Name: "Test Account", Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Currency: "CNY"},
+		2: {AccountId: 2, Name: "Test Card", Category: models.ACCOUNT_CATEGORY_CREDIT_CARD, Currency: "CNY"},
+	}
+
+	categoryMap := map[int64]*models.TransactionCategory{
+		10: {CategoryId: 10, Name: "Salary", Type: models.CATEGORY_TYPE_INCOME},
+		20: {CategoryId: 20, Name: "Food", Type: models.CATEGORY_TYPE_EXPENSE},
+		21: {CategoryId: 21, ParentCategoryId: 20, Name: "Dinner", Type: models.CATEGORY_TYPE_EXPENSE},
+	}
+
+	// transactions are sorted by time in descending order
+	transactions := []*models.Transaction{
+		{TransactionId: 1005, Type: models.TRANSACTION_DB_TYPE_TRANSFER_IN, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), AccountId: 2, Amount: 5, RelatedAccountId: 1, RelatedAccountAmount: 5},
+		{TransactionId: 1004, Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725408000), AccountId: 1, Amount: 5, RelatedAccountId: 2, RelatedAccountAmount: 5},
+		{TransactionId: 1003, Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725321600), AccountId: 2, CategoryId: 21, Amount: 100, Comment: "Dinner with friends"},
+		{TransactionId: 1002, Type: models.TRANSACTION_DB_TYPE_INCOME, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725235200), AccountId: 1, CategoryId: 10, Amount: 12},
+		{TransactionId: 1001, Type: models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1725148800), AccountId: 1, Amount: 12345, RelatedAccountAmount: 12345},
+	}
+
+	return transactions, accountMap, categoryMap
+}
+
+func TestQifTransactionDataExporterToExportedContent(t *testing.T) {
+	exporter := QifTransactionDataExporter
+	context := core.NewNullContext()
+	transactions, accountMap, categoryMap := getQifExporterTestData()
+
+	content, err := exporter.ToExportedContent(context, 1234567890, transactions, accountMap, categoryMap, nil, nil)
+	assert.Nil(t, err)
+
+	expected := "!Type:Cat\n" +
+		"NSalary\n" +
+		"I\n" +
+		"^\n" +
+		"NFood:Dinner\n" +
+		"E\n" +
+		"^\n" +
+		"!Account\n" +
+		"NTest Account\n" +
+		"TBank\n" +
+		"^\n" +
+		"!Type:Bank\n" +
+		"D2024-09-01\n" +
+		"T123.45\n" +
+		"POpening Balance\n" +
+		"L[Test Account]\n" +
+		"^\n" +
+		"D2024-09-02\n" +
+		"T0.12\n" +
+		"LSalary\n" +
+		"^\n" +
+		"D2024-09-04\n" +
+		"T-0.05\n" +
+		"L[Test Card]\n" +
+		"^\n" +
+		"!Account\n" +
+		"NTest Card\n" +
+		"TCCard\n" +
+		"^\n" +
+		"!Type:CCard\n" +
+		"D2024-09-03\n" +
+		"T-1.00\n" +
+		"MDinner with friends\n" +
+		"LFood:Dinner\n" +
+		"^\n"
+
+	assert.Equal(t, expected, string(content))
+}
+
+func TestQifTransactionDataExporterToExportedContent_ImportExportedData(t *testing.T) {
+	exporter := QifTransactionDataExporter
+	importer := QifYearMonthDayTransactionDataImporter
+	context := core.NewNullContext()
+	transactions, accountMap, categoryMap := getQifExporterTestData()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	content, err := exporter.ToExportedContent(context, user.Uid, transactions, accountMap, categoryMap, nil, nil)
+	assert.Nil(t, err)
+
+	allNewTransactions, allNewAccounts, _, _, _, _, err := importer.ParseImportedData(context, user, content, time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4, len(allNewTransactions))
+	assert.Equal(t, 2, len(allNewAccounts))
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, allNewTransactions[0].Type)
+	assert.Equal(t, int64(12345), allNewTransactions[0].Amount)
+	assert.Equal(t, "Test Account", allNewTransactions[0].OriginalSourceAccountName)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_INCOME, allNewTransactions[1].Type)
+	assert.Equal(t, int64(12), allNewTransactions[1].Amount)
+	assert.Equal(t, "Salary", allNewTransactions[1].OriginalCategoryName)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_EXPENSE, allNewTransactions[2].Type)
+	assert.Equal(t, int64(100), allNewTransactions[2].Amount)
+	assert.Equal(t, "Test Card", allNewTransactions[2].OriginalSourceAccountName)
+	assert.Equal(t, "Dinner", allNewTransactions[2].OriginalCategoryName)
+	assert.Equal(t, "Dinner with friends", allNewTransactions[2].Comment)
+
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_TRANSFER_OUT, allNewTransactions[3].Type)
+	assert.Equal(t, int64(5), allNewTransactions[3].Amount)
+	assert.Equal(t, "Test Account", allNewTransactions[3].OriginalSourceAccountName)
+	assert.Equal(t, "Test Card", allNewTransactions[3].OriginalDestinationAccountName)
+}
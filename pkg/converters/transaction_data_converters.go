@@ -27,6 +27,14 @@ func GetTransactionDataExporter(fileType string) converter.TransactionDataExport
 		return _default.DefaultTransactionDataCSVFileConverter
 	} else if fileType == "tsv" {
 		return _default.DefaultTransactionDataTSVFileConverter
+	} else if fileType == "beancount" {
+		return beancount.BeancountTransactionDataExporter
+	} else if fileType == "qif" {
+		return qif.QifTransactionDataExporter
+	} else if fileType == "ofx" {
+		return ofx.OFXTransactionDataExporter
+	} else if fileType == "gnucash" {
+		return gnucash.GnuCashTransactionDataExporter
 	} else {
 		return nil
 	}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"context"
+	"strconv"
+)
+
+// ImportJobContext represents the background import job context
+type ImportJobContext struct {
+	context.Context
+	contextId string
+}
+
+// GetContextId returns the current context id
+func (c *ImportJobContext) GetContextId() string {
+	return c.contextId
+}
+
+// GetClientLocale returns the client locale name
+func (c *ImportJobContext) GetClientLocale() string {
+	return ""
+}
+
+// NewImportJobContext returns a new background import job context
+func NewImportJobContext(jobId int64) *ImportJobContext {
+	return &ImportJobContext{
+		Context:   context.Background(),
+		contextId: "import-job-" + strconv.FormatInt(jobId, 10),
+	}
+}
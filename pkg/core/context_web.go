@@ -15,6 +15,7 @@ const webContextRequestIdFieldKey = "REQUEST_ID"
 const webContextTextualTokenFieldKey = "TOKEN_STRING"
 const webContextTokenClaimsFieldKey = "TOKEN_CLAIMS"
 const webContextTokenContextFieldKey = "TOKEN_CONTEXT"
+const webContextLedgerDataUidFieldKey = "LEDGER_DATA_UID"
 const webContextResponseErrorFieldKey = "RESPONSE_ERROR"
 
 // AcceptLanguageHeaderName represents the header name of accept language
@@ -29,6 +30,9 @@ const ClientTimezoneOffsetHeaderName = "X-Timezone-Offset"
 // ClientTimezoneNameHeaderName represents the header name of client timezone name
 const ClientTimezoneNameHeaderName = "X-Timezone-Name"
 
+// LedgerIdHeaderName represents the header name of the shared ledger which current request operates on
+const LedgerIdHeaderName = "X-Ledger-Id"
+
 const tokenHeaderName = "Authorization"
 const tokenHeaderValuePrefix = "bearer "
 const tokenQueryStringParam = "token"
@@ -134,8 +138,25 @@ func (c *WebContext) GetTokenContext() string {
 	return context.(string)
 }
 
-// GetCurrentUid returns the current user uid by the current user token
+// SetCurrentLedgerDataUid sets the data uid of the shared ledger which current request operates on
+func (c *WebContext) SetCurrentLedgerDataUid(uid int64) {
+	c.Set(webContextLedgerDataUidFieldKey, uid)
+}
+
+// GetCurrentUid returns the uid of the data owner, which is the data uid of the shared ledger if current request operates on a shared ledger,
+// otherwise returns the current user uid by the current user token
 func (c *WebContext) GetCurrentUid() int64 {
+	ledgerDataUid, exists := c.Get(webContextLedgerDataUidFieldKey)
+
+	if exists {
+		return ledgerDataUid.(int64)
+	}
+
+	return c.GetCurrentOperatorUid()
+}
+
+// GetCurrentOperatorUid returns the current user uid by the current user token
+func (c *WebContext) GetCurrentOperatorUid() int64 {
 	claims := c.GetTokenClaims()
 
 	if claims == nil {
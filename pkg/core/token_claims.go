@@ -1,6 +1,7 @@
 package core
 
 import (
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -21,14 +22,114 @@ const (
 	USER_TOKEN_TYPE_API                            TokenType = 8
 )
 
+// TokenScope represents the additional permission granted to token
+type TokenScope string
+
+// Token scopes
+const (
+	USER_TOKEN_SCOPE_MCP_DESTRUCTIVE    TokenScope = "mcp:destructive"
+	USER_TOKEN_SCOPE_TRANSACTIONS_READ  TokenScope = "transactions:read"
+	USER_TOKEN_SCOPE_TRANSACTIONS_WRITE TokenScope = "transactions:write"
+	USER_TOKEN_SCOPE_ACCOUNTS_READ      TokenScope = "accounts:read"
+	USER_TOKEN_SCOPE_ACCOUNTS_WRITE     TokenScope = "accounts:write"
+	USER_TOKEN_SCOPE_DATA_EXPORT        TokenScope = "data:export"
+)
+
+// APITokenScopes represents all the scopes which can be granted to api token
+var APITokenScopes = map[TokenScope]bool{
+	USER_TOKEN_SCOPE_TRANSACTIONS_READ:  true,
+	USER_TOKEN_SCOPE_TRANSACTIONS_WRITE: true,
+	USER_TOKEN_SCOPE_ACCOUNTS_READ:      true,
+	USER_TOKEN_SCOPE_ACCOUNTS_WRITE:     true,
+	USER_TOKEN_SCOPE_DATA_EXPORT:        true,
+}
+
 // UserTokenClaims represents user token
 type UserTokenClaims struct {
-	UserTokenId string    `json:"userTokenId"`
-	Uid         int64     `json:"jti,string"`
-	Username    string    `json:"username,omitempty"`
-	Type        TokenType `json:"type"`
-	IssuedAt    int64     `json:"iat"`
-	ExpiresAt   int64     `json:"exp"`
+	UserTokenId string       `json:"userTokenId"`
+	Uid         int64        `json:"jti,string"`
+	Username    string       `json:"username,omitempty"`
+	Type        TokenType    `json:"type"`
+	Scopes      []TokenScope `json:"scopes,omitempty"`
+	IssuedAt    int64        `json:"iat"`
+	ExpiresAt   int64        `json:"exp"`
+}
+
+// ParseTokenScopes returns the token scopes from the comma-separated string
+func ParseTokenScopes(scopes string) []TokenScope {
+	if scopes == "" {
+		return nil
+	}
+
+	items := strings.Split(scopes, ",")
+	tokenScopes := make([]TokenScope, 0, len(items))
+
+	for i := 0; i < len(items); i++ {
+		if items[i] != "" {
+			tokenScopes = append(tokenScopes, TokenScope(items[i]))
+		}
+	}
+
+	return tokenScopes
+}
+
+// GetAPITokenScopes returns the api token scopes from the scope names, and returns false if any scope cannot be granted to api token
+func GetAPITokenScopes(scopeNames []string) ([]TokenScope, bool) {
+	if len(scopeNames) < 1 {
+		return nil, true
+	}
+
+	tokenScopes := make([]TokenScope, 0, len(scopeNames))
+	existedScopes := make(map[TokenScope]bool, len(scopeNames))
+
+	for i := 0; i < len(scopeNames); i++ {
+		scope := TokenScope(strings.TrimSpace(scopeNames[i]))
+
+		if !APITokenScopes[scope] {
+			return nil, false
+		}
+
+		if existedScopes[scope] {
+			continue
+		}
+
+		existedScopes[scope] = true
+		tokenScopes = append(tokenScopes, scope)
+	}
+
+	return tokenScopes, true
+}
+
+// FormatTokenScopes returns the comma-separated string of the token scopes
+func FormatTokenScopes(tokenScopes []TokenScope) string {
+	items := make([]string, len(tokenScopes))
+
+	for i := 0; i < len(tokenScopes); i++ {
+		items[i] = string(tokenScopes[i])
+	}
+
+	return strings.Join(items, ",")
+}
+
+// HasScope returns whether this token has been granted the specified scope
+func (c *UserTokenClaims) HasScope(scope TokenScope) bool {
+	for i := 0; i < len(c.Scopes); i++ {
+		if c.Scopes[i] == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsScopeRestricted returns whether this token can only access the routes which require the granted scopes,
+// api token without any scope can access all the routes as before
+func (c *UserTokenClaims) IsScopeRestricted() bool {
+	if c.Type != USER_TOKEN_TYPE_API {
+		return false
+	}
+
+	return len(c.Scopes) > 0
 }
 
 // GetExpirationTime returns the expiration time of this token
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTokenScopes(t *testing.T) {
+	assert.Nil(t, ParseTokenScopes(""))
+	assert.Equal(t, []TokenScope{USER_TOKEN_SCOPE_MCP_DESTRUCTIVE}, ParseTokenScopes("mcp:destructive"))
+	assert.Equal(t, []TokenScope{"a", "b"}, ParseTokenScopes("a,,b"))
+}
+
+func TestFormatTokenScopes(t *testing.T) {
+	assert.Equal(t, "", FormatTokenScopes(nil))
+	assert.Equal(t, "mcp:destructive,a", FormatTokenScopes([]TokenScope{USER_TOKEN_SCOPE_MCP_DESTRUCTIVE, "a"}))
+}
+
+func TestUserTokenClaimsHasScope(t *testing.T) {
+	claims := &UserTokenClaims{}
+	assert.False(t, claims.HasScope(USER_TOKEN_SCOPE_MCP_DESTRUCTIVE))
+
+	claims.Scopes = []TokenScope{USER_TOKEN_SCOPE_MCP_DESTRUCTIVE}
+	assert.True(t, claims.HasScope(USER_TOKEN_SCOPE_MCP_DESTRUCTIVE))
+}
+
+func TestGetAPITokenScopes(t *testing.T) {
+	scopes, valid := GetAPITokenScopes(nil)
+	assert.True(t, valid)
+	assert.Nil(t, scopes)
+
+	scopes, valid = GetAPITokenScopes([]string{"transactions:read", " accounts:read", "transactions:read"})
+	assert.True(t, valid)
+	assert.Equal(t, []TokenScope{USER_TOKEN_SCOPE_TRANSACTIONS_READ, USER_TOKEN_SCOPE_ACCOUNTS_READ}, scopes)
+
+	_, valid = GetAPITokenScopes([]string{"transactions:read", "mcp:destructive"})
+	assert.False(t, valid)
+
+	_, valid = GetAPITokenScopes([]string{"unknown"})
+	assert.False(t, valid)
+}
+
+func TestUserTokenClaimsIsScopeRestricted(t *testing.T) {
+	assert.False(t, (&UserTokenClaims{Type: USER_TOKEN_TYPE_API}).IsScopeRestricted())
+	assert.True(t, (&UserTokenClaims{Type: USER_TOKEN_TYPE_API, Scopes: []TokenScope{USER_TOKEN_SCOPE_TRANSACTIONS_READ}}).IsScopeRestricted())
+	assert.False(t, (&UserTokenClaims{Type: USER_TOKEN_TYPE_MCP, Scopes: []TokenScope{USER_TOKEN_SCOPE_MCP_DESTRUCTIVE}}).IsScopeRestricted())
+	assert.False(t, (&UserTokenClaims{Type: USER_TOKEN_TYPE_NORMAL}).IsScopeRestricted())
+}
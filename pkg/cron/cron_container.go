@@ -85,6 +85,27 @@ func (c *CronJobSchedulerContainer) registerAllJobs(ctx core.Context, config *se
 		Container.registerIntervalJob(ctx, CreateScheduledTransactionJob)
 	}
 
+	if config.EnableCheckOverdueCreditCardStatements {
+		Container.registerIntervalJob(ctx, CheckOverdueCreditCardStatementsJob)
+	}
+
+	if config.EnableSaveExchangeRatesHistory {
+		Container.registerIntervalJob(ctx, SaveExchangeRatesHistoryJob)
+	}
+
+	if config.EnableWebhook {
+		Container.registerIntervalJob(ctx, DeliverWebhookEventsJob)
+	}
+
+	if config.EnableSendSummaryReportEmails {
+		// clone the template job to avoid modifying the global instance
+		job := *SendSummaryReportEmailsJob
+		job.Period = CronJobFixedHourPeriod{
+			Hour: config.SendSummaryReportEmailsHour,
+		}
+		Container.registerIntervalJob(ctx, &job)
+	}
+
 	if config.EnableDailyEmailBackup {
 		// clone the template job to avoid modifying the global instance
 		job := *EmailBackupJob
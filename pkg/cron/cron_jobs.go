@@ -4,7 +4,12 @@ import (
 	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
 )
 
 // RemoveExpiredTokensJob represents the cron job which periodically remove expired user tokens from the database
@@ -27,7 +32,62 @@ var CreateScheduledTransactionJob = &CronJob{
 		Second: 0,
 	},
 	Run: func(c *core.CronContext) error {
-		return services.Transactions.CreateScheduledTransactions(c, time.Now().Unix(), c.GetInterval())
+		return services.Transactions.CreateScheduledTransactions(c, time.Now().Unix(), c.GetInterval(), func(transaction *models.Transaction, tagIds []int64) {
+			err := services.Webhooks.TriggerEvent(c, transaction.Uid, models.WEBHOOK_EVENT_TYPE_SCHEDULED_TRANSACTION_CREATED, models.NewWebhookTransactionEventData(transaction, tagIds))
+
+			if err != nil {
+				log.Warnf(c, "[cron_jobs.CreateScheduledTransactionJob] failed to trigger webhook event for transaction \"id:%d\", because %s", transaction.TransactionId, err.Error())
+			}
+		})
+	},
+}
+
+// DeliverWebhookEventsJob represents the cron job which periodically retry delivering the webhook events which failed to deliver before
+var DeliverWebhookEventsJob = &CronJob{
+	Name:        "DeliverWebhookEvents",
+	Description: "Periodically retry delivering the webhook events which failed to deliver before.",
+	Period: CronJobIntervalPeriod{
+		Interval: time.Minute,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.Webhooks.DeliverPendingDeliveries(c, time.Now())
+	},
+}
+
+// CheckOverdueCreditCardStatementsJob represents the cron job which periodically flag credit card statements which are still unpaid after the due date
+var CheckOverdueCreditCardStatementsJob = &CronJob{
+	Name:        "CheckOverdueCreditCardStatements",
+	Description: "Periodically flag credit card statements which are still unpaid after the due date.",
+	Period: CronJobFixedHourPeriod{
+		Hour: 1,
+	},
+	Run: func(c *core.CronContext) error {
+		return services.CreditCardStatements.CheckOverdueStatements(c, time.Now())
+	},
+}
+
+// SaveExchangeRatesHistoryJob represents the cron job which periodically save the exchange rates from exchange rates data source to exchange rates history
+var SaveExchangeRatesHistoryJob = &CronJob{
+	Name:        "SaveExchangeRatesHistory",
+	Description: "Periodically save the exchange rates from exchange rates data source to exchange rates history.",
+	Period: CronJobFixedHourPeriod{
+		Hour: 3,
+	},
+	Run: func(c *core.CronContext) error {
+		return exchangerates.Container.SaveExchangeRatesHistory(c, settings.Container.GetCurrentConfig(), time.Now())
+	},
+}
+
+// SendSummaryReportEmailsJob represents the cron job which periodically send weekly or monthly summary report emails to the users who enabled it
+var SendSummaryReportEmailsJob = &CronJob{
+	Name:        "SendSummaryReportEmails",
+	Description: "Daily send weekly or monthly summary report emails to the users who enabled summary report email.",
+	// The actual hour will be adjusted according to configuration when registering the job
+	Period: CronJobFixedHourPeriod{
+		Hour: 8,
+	},
+	Run: func(c *core.CronContext) error {
+		return reports.SummaryReports.SendSummaryReportEmails(c, time.Now(), settings.Container.GetCurrentConfig())
 	},
 }
 
@@ -14,5 +14,8 @@ const (
 	DUPLICATE_CHECKER_TYPE_NEW_PICTURE         DuplicateCheckerType = 6
 	DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS DuplicateCheckerType = 7
 	DUPLICATE_CHECKER_TYPE_OAUTH2_REDIRECT     DuplicateCheckerType = 8
+	DUPLICATE_CHECKER_TYPE_NEW_BUDGET          DuplicateCheckerType = 9
+	DUPLICATE_CHECKER_TYPE_NEW_TAG             DuplicateCheckerType = 10
+	DUPLICATE_CHECKER_TYPE_PARSE_IMPORT_FILE   DuplicateCheckerType = 11
 	DUPLICATE_CHECKER_TYPE_FAILURE_CHECK       DuplicateCheckerType = 255
 )
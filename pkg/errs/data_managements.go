@@ -9,4 +9,8 @@ var (
 	ErrDataExportNotAllowed     = NewNormalError(NormalSubcategoryDataManagement, 1, http.StatusBadRequest, "data export not allowed")
 	ErrDataImportNotAllowed     = NewNormalError(NormalSubcategoryDataManagement, 2, http.StatusBadRequest, "data import not allowed")
 	ErrImportTooManyTransaction = NewNormalError(NormalSubcategoryDataManagement, 3, http.StatusBadRequest, "import too many transactions")
+	ErrInvalidDataArchiveFile   = NewNormalError(NormalSubcategoryDataManagement, 4, http.StatusBadRequest, "invalid data archive file")
+	ErrDataArchiveNotSupported  = NewNormalError(NormalSubcategoryDataManagement, 5, http.StatusBadRequest, "data archive version not supported")
+	ErrDataArchiveBookNotEmpty  = NewNormalError(NormalSubcategoryDataManagement, 6, http.StatusBadRequest, "data archive can only be imported into an empty book")
+	ErrDataArchiveTooLarge      = NewNormalError(NormalSubcategoryDataManagement, 7, http.StatusBadRequest, "data archive content is too large")
 )
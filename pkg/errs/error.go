@@ -47,6 +47,14 @@ const (
 	NormalSubcategoryTagGroup               = 19
 	NormalSubcategoryItem                   = 20
 	NormalSubcategoryItemGroup              = 21
+	NormalSubcategoryBudget                 = 22
+	NormalSubcategoryLedger                 = 23
+	NormalSubcategoryTransactionRule        = 24
+	NormalSubcategoryExchangeRate           = 25
+	NormalSubcategoryWebhook                = 26
+	NormalSubcategoryReport                 = 27
+	NormalSubcategoryImportBatch            = 28
+	NormalSubcategoryImportJob              = 29
 )
 
 // Error represents the specific error returned to user
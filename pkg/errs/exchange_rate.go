@@ -0,0 +1,10 @@
+package errs
+
+import "net/http"
+
+// Error codes related to exchange rates
+var (
+	ErrHistoricalExchangeRatesNotSupported   = NewNormalError(NormalSubcategoryExchangeRate, 0, http.StatusBadRequest, "exchange rates data source does not support historical exchange rates")
+	ErrExchangeRateNotFoundForTransaction    = NewNormalError(NormalSubcategoryExchangeRate, 1, http.StatusBadRequest, "exchange rate for transaction date not found")
+	ErrExchangeRateNotFoundForAccountBalance = NewNormalError(NormalSubcategoryExchangeRate, 2, http.StatusBadRequest, "exchange rate for account balance date not found")
+)
@@ -4,7 +4,13 @@ import "net/http"
 
 // Error codes related to insights explorers
 var (
-	ErrInsightsExplorerIdInvalid   = NewNormalError(NormalSubcategoryInsightsExplorer, 0, http.StatusBadRequest, "explorer id is invalid")
-	ErrInsightsExplorerNotFound    = NewNormalError(NormalSubcategoryInsightsExplorer, 1, http.StatusBadRequest, "explorer not found")
-	ErrInsightsExplorerDataInvalid = NewNormalError(NormalSubcategoryInsightsExplorer, 2, http.StatusBadRequest, "explorer data is invalid")
+	ErrInsightsExplorerIdInvalid             = NewNormalError(NormalSubcategoryInsightsExplorer, 0, http.StatusBadRequest, "explorer id is invalid")
+	ErrInsightsExplorerNotFound              = NewNormalError(NormalSubcategoryInsightsExplorer, 1, http.StatusBadRequest, "explorer not found")
+	ErrInsightsExplorerDataInvalid           = NewNormalError(NormalSubcategoryInsightsExplorer, 2, http.StatusBadRequest, "explorer data is invalid")
+	ErrInsightsExplorerQueryNotSpecified     = NewNormalError(NormalSubcategoryInsightsExplorer, 3, http.StatusBadRequest, "explorer id or explorer data must be specified")
+	ErrInsightsExplorerQueryConditionInvalid = NewNormalError(NormalSubcategoryInsightsExplorer, 4, http.StatusBadRequest, "explorer query condition is invalid")
+	ErrInsightsExplorerDataDimensionInvalid  = NewNormalError(NormalSubcategoryInsightsExplorer, 5, http.StatusBadRequest, "explorer data dimension is invalid")
+	ErrInsightsExplorerValueMetricInvalid    = NewNormalError(NormalSubcategoryInsightsExplorer, 6, http.StatusBadRequest, "explorer value metric is invalid")
+	ErrInsightsExplorerTimezoneTypeInvalid   = NewNormalError(NormalSubcategoryInsightsExplorer, 7, http.StatusBadRequest, "explorer timezone type is invalid")
+	ErrInsightsExplorerQueryTimeRangeInvalid = NewNormalError(NormalSubcategoryInsightsExplorer, 8, http.StatusBadRequest, "explorer query time range is invalid")
 )
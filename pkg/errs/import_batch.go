@@ -0,0 +1,10 @@
+package errs
+
+import "net/http"
+
+// Error codes related to import batches
+var (
+	ErrImportBatchIdInvalid         = NewNormalError(NormalSubcategoryImportBatch, 0, http.StatusBadRequest, "import batch id is invalid")
+	ErrImportBatchNotFound          = NewNormalError(NormalSubcategoryImportBatch, 1, http.StatusBadRequest, "import batch not found")
+	ErrImportBatchAlreadyRolledBack = NewNormalError(NormalSubcategoryImportBatch, 2, http.StatusBadRequest, "import batch has already been rolled back")
+)
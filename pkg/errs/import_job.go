@@ -0,0 +1,11 @@
+package errs
+
+import "net/http"
+
+// Error codes related to import jobs
+var (
+	ErrImportJobIdInvalid       = NewNormalError(NormalSubcategoryImportJob, 0, http.StatusBadRequest, "import job id is invalid")
+	ErrImportJobNotFound        = NewNormalError(NormalSubcategoryImportJob, 1, http.StatusBadRequest, "import job not found")
+	ErrImportJobInterrupted     = NewNormalError(NormalSubcategoryImportJob, 2, http.StatusInternalServerError, "import job has been interrupted too many times")
+	ErrImportJobClaimedByOthers = NewNormalError(NormalSubcategoryImportJob, 3, http.StatusInternalServerError, "import job has been claimed by another worker")
+)
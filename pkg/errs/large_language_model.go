@@ -9,5 +9,8 @@ var (
 	ErrAIRecognitionImageIsEmpty            = NewNormalError(NormalSubcategoryLargeLanguageModel, 2, http.StatusBadRequest, "image for AI recognition is empty")
 	ErrExceedMaxAIRecognitionImageFileSize  = NewNormalError(NormalSubcategoryLargeLanguageModel, 3, http.StatusBadRequest, "exceed the maximum size of image file for AI recognition")
 	ErrNoTransactionInformationInImage      = NewNormalError(NormalSubcategoryLargeLanguageModel, 4, http.StatusBadRequest, "no transaction information detected")
+	ErrExceedMaxAIRecognitionTextLength     = NewNormalError(NormalSubcategoryLargeLanguageModel, 5, http.StatusBadRequest, "exceed the maximum length of text for AI recognition")
+	ErrNoTransactionInformationInText       = NewNormalError(NormalSubcategoryLargeLanguageModel, 6, http.StatusBadRequest, "no transaction information detected in text")
 	ErrTesseractNotAvailable                 = NewSystemError(SystemSubcategoryDefault, 7, http.StatusServiceUnavailable, "tesseract OCR is not available")
+	ErrAIRecognitionTextIsEmpty             = NewNormalError(NormalSubcategoryLargeLanguageModel, 8, http.StatusBadRequest, "text for AI recognition is empty")
 )
@@ -0,0 +1,15 @@
+package errs
+
+import "net/http"
+
+// Error codes related to shared ledgers
+var (
+	ErrLedgerIdInvalid                = NewNormalError(NormalSubcategoryLedger, 0, http.StatusBadRequest, "ledger id is invalid")
+	ErrLedgerNotFound                 = NewNormalError(NormalSubcategoryLedger, 1, http.StatusBadRequest, "ledger not found")
+	ErrLedgerMemberRoleInvalid        = NewNormalError(NormalSubcategoryLedger, 2, http.StatusBadRequest, "ledger member role is invalid")
+	ErrLedgerMemberNotFound           = NewNormalError(NormalSubcategoryLedger, 3, http.StatusBadRequest, "ledger member not found")
+	ErrLedgerMemberAlreadyExists      = NewNormalError(NormalSubcategoryLedger, 4, http.StatusBadRequest, "ledger member already exists")
+	ErrCannotAddLedgerOwnerAsMember   = NewNormalError(NormalSubcategoryLedger, 5, http.StatusBadRequest, "cannot add ledger owner as member")
+	ErrLedgerPermissionDenied         = NewNormalError(NormalSubcategoryLedger, 6, http.StatusForbidden, "no permission to operate this ledger")
+	ErrOnlyLedgerOwnerCanManageLedger = NewNormalError(NormalSubcategoryLedger, 7, http.StatusForbidden, "only ledger owner can manage ledger")
+)
@@ -4,5 +4,8 @@ import "net/http"
 
 // Error codes related to model context protocol server
 var (
-	ErrMCPServerNotEnabled = NewNormalError(NormalSubcategoryModelContextProtocol, 0, http.StatusBadRequest, "mcp server is not enabled")
+	ErrMCPServerNotEnabled           = NewNormalError(NormalSubcategoryModelContextProtocol, 0, http.StatusBadRequest, "mcp server is not enabled")
+	ErrMCPToolNotAllowedByTokenScope = NewNormalError(NormalSubcategoryModelContextProtocol, 1, http.StatusForbidden, "mcp tool is not allowed by current token scope")
+	ErrMCPResourceNotFound           = NewNormalError(NormalSubcategoryModelContextProtocol, 2, http.StatusBadRequest, "mcp resource not found")
+	ErrMCPPromptNotFound             = NewNormalError(NormalSubcategoryModelContextProtocol, 3, http.StatusBadRequest, "mcp prompt not found")
 )
@@ -0,0 +1,10 @@
+package errs
+
+import "net/http"
+
+// Error codes related to reports
+var (
+	ErrReportPeriodTypeInvalid   = NewNormalError(NormalSubcategoryReport, 0, http.StatusBadRequest, "report period type is invalid")
+	ErrReportPeriodInvalid       = NewNormalError(NormalSubcategoryReport, 1, http.StatusBadRequest, "report period is invalid")
+	ErrFinancialReportNotAllowed = NewNormalError(NormalSubcategoryReport, 2, http.StatusBadRequest, "financial report not allowed")
+)
@@ -22,4 +22,6 @@ var (
 	ErrEmailVerifyTokenIsInvalidOrExpired   = NewNormalError(NormalSubcategoryToken, 13, http.StatusBadRequest, "email verify token is invalid or expired")
 	ErrPasswordResetTokenIsInvalidOrExpired = NewNormalError(NormalSubcategoryToken, 14, http.StatusBadRequest, "password reset token is invalid or expired")
 	ErrAPITokenNotEnabled                   = NewNormalError(NormalSubcategoryToken, 15, http.StatusForbidden, "api token is not enabled")
+	ErrInvalidTokenScope                    = NewNormalError(NormalSubcategoryToken, 16, http.StatusBadRequest, "token scope is invalid")
+	ErrCurrentTokenScopeNotGranted          = NewNormalError(NormalSubcategoryToken, 17, http.StatusForbidden, "current token has not been granted the scope to access this api")
 )
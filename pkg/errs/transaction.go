@@ -46,4 +46,10 @@ var (
 	ErrCannotMoveTransactionFromOrToHiddenAccount                  = NewNormalError(NormalSubcategoryTransaction, 38, http.StatusBadRequest, "cannot move transaction from or to hidden account")
 	ErrCannotMoveTransactionFromOrToParentAccount                  = NewNormalError(NormalSubcategoryTransaction, 39, http.StatusBadRequest, "cannot move transaction from or to parent account")
 	ErrCannotMoveTransactionBetweenAccountsWithDifferentCurrencies = NewNormalError(NormalSubcategoryTransaction, 40, http.StatusBadRequest, "cannot move transaction between accounts with different currencies")
+	ErrTransactionHasTooManySplits                                 = NewNormalError(NormalSubcategoryTransaction, 41, http.StatusBadRequest, "transaction has too many split lines")
+	ErrTransactionHasTooFewSplits                                  = NewNormalError(NormalSubcategoryTransaction, 42, http.StatusBadRequest, "transaction must have at least two split lines")
+	ErrTransactionSplitsNotSupported                               = NewNormalError(NormalSubcategoryTransaction, 43, http.StatusBadRequest, "only income or expense transaction can be split")
+	ErrTransactionSplitsAmountNotEqual                             = NewNormalError(NormalSubcategoryTransaction, 44, http.StatusBadRequest, "total amount of split lines is not equal to transaction amount")
+	ErrTransactionForecastTimeRangeInvalid                         = NewNormalError(NormalSubcategoryTransaction, 45, http.StatusBadRequest, "transaction forecast time range is invalid")
+	ErrUnrealisedExchangeGainLossTimeRangeInvalid                  = NewNormalError(NormalSubcategoryTransaction, 46, http.StatusBadRequest, "unrealised exchange gain and loss time range is invalid")
 )
@@ -11,4 +11,7 @@ var (
 	ErrTransactionItemInUseCannotBeDeleted = NewNormalError(NormalSubcategoryItem, 4, http.StatusBadRequest, "transaction item is in use and cannot be deleted")
 	ErrTransactionItemIndexNotFound        = NewNormalError(NormalSubcategoryItem, 5, http.StatusBadRequest, "transaction item index not found")
 	ErrCannotUseHiddenTransactionItem      = NewNormalError(NormalSubcategoryItem, 6, http.StatusBadRequest, "cannot use hidden transaction item")
+	ErrTransactionItemDetailInvalid        = NewNormalError(NormalSubcategoryItem, 7, http.StatusBadRequest, "transaction item detail is invalid")
+	ErrTransactionItemsAmountExceedsTransactionAmount = NewNormalError(NormalSubcategoryItem, 8, http.StatusBadRequest, "total amount of transaction items exceeds transaction amount")
+	ErrTransactionItemSubtotalTooLarge     = NewNormalError(NormalSubcategoryItem, 9, http.StatusBadRequest, "transaction item subtotal is too large")
 )
@@ -0,0 +1,13 @@
+package errs
+
+import "net/http"
+
+// Error codes related to transaction rules
+var (
+	ErrTransactionRuleIdInvalid          = NewNormalError(NormalSubcategoryTransactionRule, 0, http.StatusBadRequest, "transaction rule id is invalid")
+	ErrTransactionRuleNotFound           = NewNormalError(NormalSubcategoryTransactionRule, 1, http.StatusBadRequest, "transaction rule not found")
+	ErrTransactionRuleKeywordInvalid     = NewNormalError(NormalSubcategoryTransactionRule, 2, http.StatusBadRequest, "transaction rule keyword is invalid")
+	ErrTransactionRuleAmountRangeInvalid = NewNormalError(NormalSubcategoryTransactionRule, 3, http.StatusBadRequest, "transaction rule amount range is invalid")
+	ErrTransactionRuleHasNoAction        = NewNormalError(NormalSubcategoryTransactionRule, 4, http.StatusBadRequest, "transaction rule has no action")
+	ErrTransactionRuleHasTooManyTags     = NewNormalError(NormalSubcategoryTransactionRule, 5, http.StatusBadRequest, "transaction rule has too many tags")
+)
@@ -11,4 +11,7 @@ var (
 	ErrScheduledTransactionFrequencyInvalid                  = NewNormalError(NormalSubcategoryTemplate, 4, http.StatusBadRequest, "scheduled transaction frequency is invalid")
 	ErrTransactionTemplateHasTooManyTags                     = NewNormalError(NormalSubcategoryTemplate, 5, http.StatusBadRequest, "transaction template has too many tags")
 	ErrScheduledTransactionTemplateStartDataLaterThanEndDate = NewNormalError(NormalSubcategoryTemplate, 6, http.StatusBadRequest, "scheduled transaction start date is later than end time")
+	ErrScheduledTransactionRecurrenceRuleInvalid             = NewNormalError(NormalSubcategoryTemplate, 7, http.StatusBadRequest, "scheduled transaction recurrence rule is invalid")
+	ErrScheduledTransactionStartDateRequired                 = NewNormalError(NormalSubcategoryTemplate, 8, http.StatusBadRequest, "scheduled transaction start date is required for this frequency")
+	ErrScheduledTransactionMaxOccurrencesReached             = NewNormalError(NormalSubcategoryTemplate, 9, http.StatusBadRequest, "scheduled transaction has reached the maximum occurrences")
 )
@@ -0,0 +1,12 @@
+package errs
+
+import "net/http"
+
+// Error codes related to webhooks
+var (
+	ErrWebhookIdInvalid      = NewNormalError(NormalSubcategoryWebhook, 0, http.StatusBadRequest, "webhook id is invalid")
+	ErrWebhookNotFound       = NewNormalError(NormalSubcategoryWebhook, 1, http.StatusBadRequest, "webhook not found")
+	ErrWebhookUrlInvalid     = NewNormalError(NormalSubcategoryWebhook, 2, http.StatusBadRequest, "webhook url is invalid")
+	ErrExceedMaxWebhookCount = NewNormalError(NormalSubcategoryWebhook, 3, http.StatusBadRequest, "exceed the maximum count of webhooks")
+	ErrWebhookUrlNotAllowed  = NewNormalError(NormalSubcategoryWebhook, 4, http.StatusBadRequest, "webhook url points to an address which is not allowed")
+)
@@ -2,6 +2,7 @@ package exchangerates
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"net/http"
 	"strings"
@@ -16,11 +17,13 @@ import (
 )
 
 const bankOfCanadaExchangeRateUrl = "https://www.bankofcanada.ca/valet/observations/group/FX_RATES_DAILY/json?recent=1"
+const bankOfCanadaHistoricalExchangeRateUrlFormat = "https://www.bankofcanada.ca/valet/observations/group/FX_RATES_DAILY/json?start_date=%s&end_date=%s"
 const bankOfCanadaExchangeRateReferenceUrl = "https://www.bankofcanada.ca/rates/exchange/daily-exchange-rates/"
 const bankOfCanadaDataSource = "Bank of Canada"
 const bankOfCanadaBaseCurrency = "CAD"
 
 const bankOfCanadaDataUpdateDateFormat = "2006-01-02 15:04"
+const bankOfCanadaDataDateFormat = "2006-01-02"
 const bankOfCanadaDataUpdateDateTimezone = "America/Toronto"
 
 // BankOfCanadaDataSource defines the structure of exchange rates data source of bank of Canada
@@ -56,54 +59,10 @@ func (e *BankOfCanadaExchangeRateData) ToLatestExchangeRateResponse(c core.Conte
 			}
 		}
 
-		for typeName, exchangeRateData := range observation {
-			if len(typeName) < 8 || !strings.HasPrefix(typeName, "FX") || !strings.HasSuffix(typeName, bankOfCanadaBaseCurrency) {
-				continue
-			}
-
-			currencyCode := utils.SubString(typeName, 2, 3)
-
-			if data, ok := exchangeRateData.(map[string]any); ok {
-				exchangeRate := data["v"]
-
-				if exchangeRateValue, ok2 := exchangeRate.(string); ok2 {
-					exchangeRateMap[currencyCode] = exchangeRateValue
-				}
-			}
-		}
-	}
-
-	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(exchangeRateMap))
-
-	for currencyCode, exchangeRate := range exchangeRateMap {
-		if _, exists := validators.AllCurrencyNames[currencyCode]; !exists {
-			continue
-		}
-
-		rate, err := utils.StringToFloat64(exchangeRate)
-
-		if err != nil {
-			log.Warnf(c, "[bank_of_canada_datasource.ToLatestExchangeRateResponse] failed to parse rate, rate is %s", exchangeRate)
-			continue
-		}
-
-		if rate <= 0 {
-			log.Warnf(c, "[bank_of_canada_datasource.ToLatestExchangeRateResponse] rate is invalid, rate is %s", exchangeRate)
-			continue
-		}
-
-		finalRate := 1 / rate
-
-		if math.IsInf(finalRate, 0) {
-			continue
-		}
-
-		exchangeRates = append(exchangeRates, &models.LatestExchangeRate{
-			Currency: currencyCode,
-			Rate:     utils.Float64ToString(finalRate),
-		})
+		observation.fillExchangeRateMap(exchangeRateMap)
 	}
 
+	exchangeRates := toBankOfCanadaLatestExchangeRateSlice(c, exchangeRateMap)
 	timezone, err := time.LoadLocation(bankOfCanadaDataUpdateDateTimezone)
 
 	if err != nil {
@@ -130,6 +89,43 @@ func (e *BankOfCanadaExchangeRateData) ToLatestExchangeRateResponse(c core.Conte
 	return latestExchangeRateResp
 }
 
+// ToHistoricalExchangeRates returns the exchange rates of each date according to original data from bank of Canada
+func (e *BankOfCanadaExchangeRateData) ToHistoricalExchangeRates(c core.Context) []*models.HistoricalExchangeRates {
+	allHistoricalExchangeRates := make([]*models.HistoricalExchangeRates, 0, len(e.Observations))
+
+	for i := 0; i < len(e.Observations); i++ {
+		observation := e.Observations[i]
+		updateDate, ok := observation["d"].(string)
+
+		if !ok {
+			continue
+		}
+
+		rateDate, err := time.Parse(bankOfCanadaDataDateFormat, updateDate)
+
+		if err != nil {
+			log.Warnf(c, "[bank_of_canada_datasource.ToHistoricalExchangeRates] failed to parse date, date is %s", updateDate)
+			continue
+		}
+
+		exchangeRateMap := make(map[string]string)
+		observation.fillExchangeRateMap(exchangeRateMap)
+		exchangeRates := toBankOfCanadaLatestExchangeRateSlice(c, exchangeRateMap)
+
+		if len(exchangeRates) < 1 {
+			continue
+		}
+
+		allHistoricalExchangeRates = append(allHistoricalExchangeRates, &models.HistoricalExchangeRates{
+			RateDate:      utils.FormatUnixTimeToNumericYearMonthDay(rateDate.Unix(), time.UTC),
+			BaseCurrency:  bankOfCanadaBaseCurrency,
+			ExchangeRates: exchangeRates,
+		})
+	}
+
+	return allHistoricalExchangeRates
+}
+
 // BuildRequests returns the bank of Canada exchange rates http requests
 func (e *BankOfCanadaDataSource) BuildRequests() ([]*http.Request, error) {
 	req, err := http.NewRequest("GET", bankOfCanadaExchangeRateUrl, nil)
@@ -160,3 +156,81 @@ func (e *BankOfCanadaDataSource) Parse(c core.Context, content []byte) (*models.
 
 	return latestExchangeRateResponse, nil
 }
+
+// BuildHistoricalRequests returns the bank of Canada historical exchange rates http requests
+func (e *BankOfCanadaDataSource) BuildHistoricalRequests(startTime time.Time, endTime time.Time) ([]*http.Request, error) {
+	url := fmt.Sprintf(bankOfCanadaHistoricalExchangeRateUrlFormat, startTime.Format(bankOfCanadaDataDateFormat), endTime.Format(bankOfCanadaDataDateFormat))
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// ParseHistorical returns the exchange rates of each date according to the bank of Canada data source raw response
+func (e *BankOfCanadaDataSource) ParseHistorical(c core.Context, content []byte) ([]*models.HistoricalExchangeRates, error) {
+	bankOfCanadaData := &BankOfCanadaExchangeRateData{}
+	err := json.Unmarshal(content, bankOfCanadaData)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_canada_datasource.ParseHistorical] failed to parse json data, because %s", err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return bankOfCanadaData.ToHistoricalExchangeRates(c), nil
+}
+
+func (o BankOfCanadaObservationData) fillExchangeRateMap(exchangeRateMap map[string]string) {
+	for typeName, exchangeRateData := range o {
+		if len(typeName) < 8 || !strings.HasPrefix(typeName, "FX") || !strings.HasSuffix(typeName, bankOfCanadaBaseCurrency) {
+			continue
+		}
+
+		currencyCode := utils.SubString(typeName, 2, 3)
+
+		if data, ok := exchangeRateData.(map[string]any); ok {
+			exchangeRate := data["v"]
+
+			if exchangeRateValue, ok2 := exchangeRate.(string); ok2 {
+				exchangeRateMap[currencyCode] = exchangeRateValue
+			}
+		}
+	}
+}
+
+func toBankOfCanadaLatestExchangeRateSlice(c core.Context, exchangeRateMap map[string]string) models.LatestExchangeRateSlice {
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(exchangeRateMap))
+
+	for currencyCode, exchangeRate := range exchangeRateMap {
+		if _, exists := validators.AllCurrencyNames[currencyCode]; !exists {
+			continue
+		}
+
+		rate, err := utils.StringToFloat64(exchangeRate)
+
+		if err != nil {
+			log.Warnf(c, "[bank_of_canada_datasource.toBankOfCanadaLatestExchangeRateSlice] failed to parse rate, rate is %s", exchangeRate)
+			continue
+		}
+
+		if rate <= 0 {
+			log.Warnf(c, "[bank_of_canada_datasource.toBankOfCanadaLatestExchangeRateSlice] rate is invalid, rate is %s", exchangeRate)
+			continue
+		}
+
+		finalRate := 1 / rate
+
+		if math.IsInf(finalRate, 0) {
+			continue
+		}
+
+		exchangeRates = append(exchangeRates, &models.LatestExchangeRate{
+			Currency: currencyCode,
+			Rate:     utils.Float64ToString(finalRate),
+		})
+	}
+
+	return exchangeRates
+}
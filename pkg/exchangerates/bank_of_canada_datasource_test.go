@@ -195,3 +195,23 @@ func TestBankOfCanadaDataSource_InvalidRate(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
 }
+
+func TestBankOfCanadaDataSource_HistoricalDataExtractExchangeRates(t *testing.T) {
+	dataSource := &BankOfCanadaDataSource{}
+	context := core.NewNullContext()
+
+	actualHistoricalExchangeRates, err := dataSource.ParseHistorical(context, []byte(bankOfCanadaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(actualHistoricalExchangeRates))
+
+	assert.Equal(t, int32(20191231), actualHistoricalExchangeRates[0].RateDate)
+	assert.Equal(t, "CAD", actualHistoricalExchangeRates[0].BaseCurrency)
+	assert.Equal(t, models.LatestExchangeRateSlice{{Currency: "VND", Rate: "17857.14285714286"}}, actualHistoricalExchangeRates[0].ExchangeRates)
+
+	assert.Equal(t, int32(20210401), actualHistoricalExchangeRates[1].RateDate)
+	assert.Equal(t, 2, len(actualHistoricalExchangeRates[1].ExchangeRates))
+	assert.Contains(t, actualHistoricalExchangeRates[1].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.7958615200955034",
+	})
+}
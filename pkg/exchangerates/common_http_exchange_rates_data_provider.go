@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
@@ -11,6 +12,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
 // HttpExchangeRatesDataSource defines the structure of http exchange rates data source
@@ -22,6 +24,15 @@ type HttpExchangeRatesDataSource interface {
 	Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error)
 }
 
+// HistoricalHttpExchangeRatesDataSource defines the structure of http exchange rates data source which supports querying historical exchange rates
+type HistoricalHttpExchangeRatesDataSource interface {
+	// BuildHistoricalRequests returns the http requests of historical exchange rates between the specified times
+	BuildHistoricalRequests(startTime time.Time, endTime time.Time) ([]*http.Request, error)
+
+	// ParseHistorical returns the exchange rates of each date according to the data source raw response
+	ParseHistorical(c core.Context, content []byte) ([]*models.HistoricalExchangeRates, error)
+}
+
 // CommonHttpExchangeRatesDataProvider defines the structure of common http exchange rates data provider
 type CommonHttpExchangeRatesDataProvider struct {
 	ExchangeRatesDataProvider
@@ -40,24 +51,10 @@ func (e *CommonHttpExchangeRatesDataProvider) GetLatestExchangeRates(c core.Cont
 	exchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(requests))
 
 	for i := 0; i < len(requests); i++ {
-		req := requests[i]
-		req = req.WithContext(httpclient.CustomHttpResponseLog(c, func(data []byte) {
-			log.Debugf(c, "[common_http_exchange_rates_data_provider.GetLatestExchangeRates] response#%d is %s", i, data)
-		}))
-
-		resp, err := e.httpClient.Do(req)
+		body, err := e.requestData(c, uid, i, requests[i])
 
 		if err != nil {
-			log.Errorf(c, "[common_http_exchange_rates_data_provider.GetLatestExchangeRates] failed to request latest exchange rate data for user \"uid:%d\", because %s", uid, err.Error())
-			return nil, errs.ErrFailedToRequestRemoteApi
-		}
-
-		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
-
-		if resp.StatusCode != 200 {
-			log.Errorf(c, "[common_http_exchange_rates_data_provider.GetLatestExchangeRates] failed to get latest exchange rate data response for user \"uid:%d\", because response code is %d", uid, resp.StatusCode)
-			return nil, errs.ErrFailedToRequestRemoteApi
+			return nil, err
 		}
 
 		exchangeRateResp, err := e.dataSource.Parse(c, body)
@@ -105,6 +102,114 @@ func (e *CommonHttpExchangeRatesDataProvider) GetLatestExchangeRates(c core.Cont
 	return finalExchangeRateResponse, nil
 }
 
+// GetHistoricalExchangeRates returns the exchange rates of each date between the specified times
+func (e *CommonHttpExchangeRatesDataProvider) GetHistoricalExchangeRates(c core.Context, uid int64, currentConfig *settings.Config, startTime time.Time, endTime time.Time) ([]*models.HistoricalExchangeRates, error) {
+	historicalDataSource, ok := e.dataSource.(HistoricalHttpExchangeRatesDataSource)
+
+	if !ok {
+		return nil, errs.ErrHistoricalExchangeRatesNotSupported
+	}
+
+	requests, err := historicalDataSource.BuildHistoricalRequests(startTime, endTime)
+
+	if err != nil {
+		log.Errorf(c, "[common_http_exchange_rates_data_provider.GetHistoricalExchangeRates] failed to build requests for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	startRateDate := utils.FormatUnixTimeToNumericYearMonthDay(startTime.Unix(), startTime.Location())
+	endRateDate := utils.FormatUnixTimeToNumericYearMonthDay(endTime.Unix(), endTime.Location())
+	allHistoricalExchangeRatesMap := make(map[int32]*models.HistoricalExchangeRates)
+	allExchangeRatesMap := make(map[int32]map[string]string)
+
+	for i := 0; i < len(requests); i++ {
+		body, err := e.requestData(c, uid, i, requests[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		allHistoricalExchangeRates, err := historicalDataSource.ParseHistorical(c, body)
+
+		if err != nil {
+			log.Errorf(c, "[common_http_exchange_rates_data_provider.GetHistoricalExchangeRates] failed to parse response for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrFailedToRequestRemoteApi)
+		}
+
+		for j := 0; j < len(allHistoricalExchangeRates); j++ {
+			historicalExchangeRates := allHistoricalExchangeRates[j]
+
+			if historicalExchangeRates.RateDate < startRateDate || historicalExchangeRates.RateDate > endRateDate {
+				continue
+			}
+
+			exchangeRatesMap, exists := allExchangeRatesMap[historicalExchangeRates.RateDate]
+
+			if !exists {
+				exchangeRatesMap = make(map[string]string)
+				allExchangeRatesMap[historicalExchangeRates.RateDate] = exchangeRatesMap
+			}
+
+			for k := 0; k < len(historicalExchangeRates.ExchangeRates); k++ {
+				exchangeRate := historicalExchangeRates.ExchangeRates[k]
+				exchangeRatesMap[exchangeRate.Currency] = exchangeRate.Rate
+			}
+
+			exchangeRatesMap[historicalExchangeRates.BaseCurrency] = "1"
+			allHistoricalExchangeRatesMap[historicalExchangeRates.RateDate] = historicalExchangeRates
+		}
+	}
+
+	finalHistoricalExchangeRates := make(models.HistoricalExchangeRatesSlice, 0, len(allHistoricalExchangeRatesMap))
+
+	for rateDate, historicalExchangeRates := range allHistoricalExchangeRatesMap {
+		exchangeRatesMap := allExchangeRatesMap[rateDate]
+		allExchangeRates := make(models.LatestExchangeRateSlice, 0, len(exchangeRatesMap))
+
+		for currency, rate := range exchangeRatesMap {
+			allExchangeRates = append(allExchangeRates, &models.LatestExchangeRate{
+				Currency: currency,
+				Rate:     rate,
+			})
+		}
+
+		sort.Sort(allExchangeRates)
+
+		finalHistoricalExchangeRates = append(finalHistoricalExchangeRates, &models.HistoricalExchangeRates{
+			RateDate:      rateDate,
+			BaseCurrency:  historicalExchangeRates.BaseCurrency,
+			ExchangeRates: allExchangeRates,
+		})
+	}
+
+	sort.Sort(finalHistoricalExchangeRates)
+
+	return finalHistoricalExchangeRates, nil
+}
+
+func (e *CommonHttpExchangeRatesDataProvider) requestData(c core.Context, uid int64, index int, req *http.Request) ([]byte, error) {
+	req = req.WithContext(httpclient.CustomHttpResponseLog(c, func(data []byte) {
+		log.Debugf(c, "[common_http_exchange_rates_data_provider.requestData] response#%d is %s", index, data)
+	}))
+
+	resp, err := e.httpClient.Do(req)
+
+	if err != nil {
+		log.Errorf(c, "[common_http_exchange_rates_data_provider.requestData] failed to request exchange rate data for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != 200 {
+		log.Errorf(c, "[common_http_exchange_rates_data_provider.requestData] failed to get exchange rate data response for user \"uid:%d\", because response code is %d", uid, resp.StatusCode)
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return body, nil
+}
+
 func newCommonHttpExchangeRatesDataProvider(config *settings.Config, dataSource HttpExchangeRatesDataSource) *CommonHttpExchangeRatesDataProvider {
 	return &CommonHttpExchangeRatesDataProvider{
 		dataSource: dataSource,
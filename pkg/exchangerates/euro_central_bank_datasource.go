@@ -17,11 +17,14 @@ import (
 )
 
 const euroCentralBankExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+const euroCentralBankLast90DaysExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+const euroCentralBankAllHistoricalExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
 const euroCentralBankExchangeRateReferenceUrl = "https://www.ecb.europa.eu/stats/policy_and_exchange_rates/euro_reference_exchange_rates/html/index.en.html"
 const euroCentralBankDataSource = "European Central Bank"
 const euroCentralBankBaseCurrency = "EUR"
 
 const euroCentralBankDataUpdateDateFormat = "2006-01-02 15"
+const euroCentralBankDataDateFormat = "2006-01-02"
 const euroCentralBankDataUpdateDateTimezone = "Europe/Berlin"
 
 // EuroCentralBankDataSource defines the structure of exchange rates data source of euro central bank
@@ -61,22 +64,7 @@ func (e *EuroCentralBankExchangeRateData) ToLatestExchangeRateResponse(c core.Co
 		return nil
 	}
 
-	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(latestEuroCentralBankExchangeRate.ExchangeRates))
-
-	for i := 0; i < len(latestEuroCentralBankExchangeRate.ExchangeRates); i++ {
-		exchangeRate := latestEuroCentralBankExchangeRate.ExchangeRates[i]
-
-		if _, exists := validators.AllCurrencyNames[exchangeRate.Currency]; !exists {
-			continue
-		}
-
-		if _, err := utils.StringToFloat64(exchangeRate.Rate); err != nil {
-			continue
-		}
-
-		exchangeRates = append(exchangeRates, exchangeRate.ToLatestExchangeRate())
-	}
-
+	exchangeRates := latestEuroCentralBankExchangeRate.ToLatestExchangeRateSlice()
 	timezone, err := time.LoadLocation(euroCentralBankDataUpdateDateTimezone)
 
 	if err != nil {
@@ -103,6 +91,56 @@ func (e *EuroCentralBankExchangeRateData) ToLatestExchangeRateResponse(c core.Co
 	return latestExchangeRateResp
 }
 
+// ToHistoricalExchangeRates returns the exchange rates of each date according to original data from euro central bank
+func (e *EuroCentralBankExchangeRateData) ToHistoricalExchangeRates(c core.Context) []*models.HistoricalExchangeRates {
+	allHistoricalExchangeRates := make([]*models.HistoricalExchangeRates, 0, len(e.AllExchangeRates))
+
+	for i := 0; i < len(e.AllExchangeRates); i++ {
+		euroCentralBankExchangeRates := e.AllExchangeRates[i]
+		rateDate, err := time.Parse(euroCentralBankDataDateFormat, euroCentralBankExchangeRates.Date)
+
+		if err != nil {
+			log.Warnf(c, "[euro_central_bank_datasource.ToHistoricalExchangeRates] failed to parse date, date is %s", euroCentralBankExchangeRates.Date)
+			continue
+		}
+
+		exchangeRates := euroCentralBankExchangeRates.ToLatestExchangeRateSlice()
+
+		if len(exchangeRates) < 1 {
+			continue
+		}
+
+		allHistoricalExchangeRates = append(allHistoricalExchangeRates, &models.HistoricalExchangeRates{
+			RateDate:      utils.FormatUnixTimeToNumericYearMonthDay(rateDate.Unix(), time.UTC),
+			BaseCurrency:  euroCentralBankBaseCurrency,
+			ExchangeRates: exchangeRates,
+		})
+	}
+
+	return allHistoricalExchangeRates
+}
+
+// ToLatestExchangeRateSlice returns all valid data pairs according to original data of a date from euro central bank
+func (e *EuroCentralBankExchangeRates) ToLatestExchangeRateSlice() models.LatestExchangeRateSlice {
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(e.ExchangeRates))
+
+	for i := 0; i < len(e.ExchangeRates); i++ {
+		exchangeRate := e.ExchangeRates[i]
+
+		if _, exists := validators.AllCurrencyNames[exchangeRate.Currency]; !exists {
+			continue
+		}
+
+		if _, err := utils.StringToFloat64(exchangeRate.Rate); err != nil {
+			continue
+		}
+
+		exchangeRates = append(exchangeRates, exchangeRate.ToLatestExchangeRate())
+	}
+
+	return exchangeRates
+}
+
 // ToLatestExchangeRate returns a data pair according to original data from euro central bank
 func (e *EuroCentralBankExchangeRate) ToLatestExchangeRate() *models.LatestExchangeRate {
 	return &models.LatestExchangeRate{
@@ -144,3 +182,36 @@ func (e *EuroCentralBankDataSource) Parse(c core.Context, content []byte) (*mode
 
 	return latestExchangeRateResponse, nil
 }
+
+// BuildHistoricalRequests returns the euro central bank historical exchange rates http requests, the exchange rates of last 90 days are requested if possible, otherwise all historical exchange rates are requested
+func (e *EuroCentralBankDataSource) BuildHistoricalRequests(startTime time.Time, endTime time.Time) ([]*http.Request, error) {
+	url := euroCentralBankAllHistoricalExchangeRateUrl
+
+	if startTime.After(time.Now().AddDate(0, 0, -90)) {
+		url = euroCentralBankLast90DaysExchangeRateUrl
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// ParseHistorical returns the exchange rates of each date according to the euro central bank data source raw response
+func (e *EuroCentralBankDataSource) ParseHistorical(c core.Context, content []byte) ([]*models.HistoricalExchangeRates, error) {
+	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
+	xmlDecoder.CharsetReader = charset.NewReaderLabel
+
+	euroCentralBankData := &EuroCentralBankExchangeRateData{}
+	err := xmlDecoder.Decode(euroCentralBankData)
+
+	if err != nil {
+		log.Errorf(c, "[euro_central_bank_datasource.ParseHistorical] failed to parse xml data, because %s", err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return euroCentralBankData.ToHistoricalExchangeRates(c), nil
+}
@@ -138,3 +138,32 @@ func TestEuroCentralBankDataSource_InvalidRate(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
 }
+
+func TestEuroCentralBankDataSource_HistoricalDataExtractExchangeRates(t *testing.T) {
+	dataSource := &EuroCentralBankDataSource{}
+	context := core.NewNullContext()
+
+	content := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+		"<gesmes:Envelope xmlns:gesmes=\"http://www.gesmes.org/xml/2002-08-01\" xmlns=\"http://www.ecb.int/vocabulary/2002-08-01/eurofxref\">\n" +
+		"  <Cube>\n" +
+		"    <Cube time=\"2021-04-01\">\n" +
+		"      <Cube currency=\"USD\" rate=\"1.1746\" />\n" +
+		"    </Cube>\n" +
+		"    <Cube time=\"2021-03-31\">\n" +
+		"      <Cube currency=\"USD\" rate=\"1.1725\" />\n" +
+		"      <Cube currency=\"XXX\" rate=\"1\" />\n" +
+		"    </Cube>\n" +
+		"  </Cube>\n" +
+		"</gesmes:Envelope>"
+
+	actualHistoricalExchangeRates, err := dataSource.ParseHistorical(context, []byte(content))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(actualHistoricalExchangeRates))
+
+	assert.Equal(t, int32(20210401), actualHistoricalExchangeRates[0].RateDate)
+	assert.Equal(t, "EUR", actualHistoricalExchangeRates[0].BaseCurrency)
+	assert.Equal(t, models.LatestExchangeRateSlice{{Currency: "USD", Rate: "1.1746"}}, actualHistoricalExchangeRates[0].ExchangeRates)
+
+	assert.Equal(t, int32(20210331), actualHistoricalExchangeRates[1].RateDate)
+	assert.Equal(t, models.LatestExchangeRateSlice{{Currency: "USD", Rate: "1.1725"}}, actualHistoricalExchangeRates[1].ExchangeRates)
+}
@@ -1,6 +1,8 @@
 package exchangerates
 
 import (
+	"time"
+
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
@@ -11,3 +13,9 @@ type ExchangeRatesDataProvider interface {
 	// GetLatestExchangeRates returns the common response entities
 	GetLatestExchangeRates(c core.Context, uid int64, currentConfig *settings.Config) (*models.LatestExchangeRateResponse, error)
 }
+
+// HistoricalExchangeRatesDataProvider defines the structure of exchange rates data provider which may support querying historical exchange rates
+type HistoricalExchangeRatesDataProvider interface {
+	// GetHistoricalExchangeRates returns the exchange rates of each date between the specified times
+	GetHistoricalExchangeRates(c core.Context, uid int64, currentConfig *settings.Config, startTime time.Time, endTime time.Time) ([]*models.HistoricalExchangeRates, error)
+}
@@ -1,10 +1,16 @@
 package exchangerates
 
 import (
+	"errors"
+	"time"
+
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
 // ExchangeRatesDataProviderContainer contains the current exchange rates data provider
@@ -83,3 +89,167 @@ func (e *ExchangeRatesDataProviderContainer) GetLatestExchangeRates(c core.Conte
 
 	return e.current.GetLatestExchangeRates(c, uid, currentConfig)
 }
+
+// GetHistoricalExchangeRates returns the exchange rates of each date between the specified times from the current exchange rates data source
+func (e *ExchangeRatesDataProviderContainer) GetHistoricalExchangeRates(c core.Context, uid int64, currentConfig *settings.Config, startTime time.Time, endTime time.Time) ([]*models.HistoricalExchangeRates, error) {
+	if Container.current == nil {
+		return nil, errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	historicalDataProvider, ok := e.current.(HistoricalExchangeRatesDataProvider)
+
+	if !ok {
+		return nil, errs.ErrHistoricalExchangeRatesNotSupported
+	}
+
+	return historicalDataProvider.GetHistoricalExchangeRates(c, uid, currentConfig, startTime, endTime)
+}
+
+// GetHistoricalExchangeRateMap returns the historical exchange rate map which contains the exchange rates history between the specified times and the latest exchange rates,
+// zero time means unbounded, and only the exchange rates history will be used if the latest exchange rates cannot be retrieved
+func (e *ExchangeRatesDataProviderContainer) GetHistoricalExchangeRateMap(c core.Context, uid int64, minUnixTime int64, maxUnixTime int64, currentConfig *settings.Config) (*models.HistoricalExchangeRateMap, error) {
+	latestExchangeRateResponse, err := e.GetLatestExchangeRates(c, uid, currentConfig)
+	var latestExchangeRateMap models.ExchangeRateMap
+
+	if err != nil {
+		log.Warnf(c, "[exchange_rates_data_provider_container.GetHistoricalExchangeRateMap] failed to get latest exchange rates for user \"uid:%d\", only exchange rates history will be used, because %s", uid, err.Error())
+	} else if latestExchangeRateResponse != nil {
+		latestExchangeRateMap = latestExchangeRateResponse.ToExchangeRateMap()
+	}
+
+	var minRateDate, maxRateDate int32
+
+	// the transaction date may be different in the transaction timezone, so the exchange rates of one more day on both sides are required
+	if minUnixTime > 0 {
+		minRateDate = utils.FormatUnixTimeToNumericYearMonthDay(minUnixTime-86400, time.UTC)
+	}
+
+	if maxUnixTime > 0 {
+		maxRateDate = utils.FormatUnixTimeToNumericYearMonthDay(maxUnixTime+86400, time.UTC)
+	}
+
+	historyUid := e.GetExchangeRatesHistoryUid(uid, currentConfig)
+	histories, err := services.ExchangeRateHistories.GetExchangeRatesHistoryByDateRange(c, historyUid, currentConfig.ExchangeRatesDataSource, minRateDate, maxRateDate)
+
+	if err != nil {
+		log.Errorf(c, "[exchange_rates_data_provider_container.GetHistoricalExchangeRateMap] failed to get exchange rates history for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	return models.NewHistoricalExchangeRateMap(histories, latestExchangeRateMap), nil
+}
+
+// GetExchangeRatesHistoryUid returns the uid which the exchange rates history of current user belongs to, the history of public exchange rates data sources is shared by all users
+func (e *ExchangeRatesDataProviderContainer) GetExchangeRatesHistoryUid(uid int64, currentConfig *settings.Config) int64 {
+	if currentConfig.ExchangeRatesDataSource == settings.UserCustomExchangeRatesDataSource {
+		return uid
+	}
+
+	return 0
+}
+
+// SaveExchangeRatesHistory saves the exchange rates from the current exchange rates data source to exchange rates history
+func (e *ExchangeRatesDataProviderContainer) SaveExchangeRatesHistory(c core.Context, currentConfig *settings.Config, now time.Time) error {
+	if Container.current == nil {
+		return errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	if currentConfig.ExchangeRatesDataSource == settings.UserCustomExchangeRatesDataSource {
+		return e.saveUserCustomExchangeRatesHistory(c, currentConfig, now)
+	}
+
+	dataSource := currentConfig.ExchangeRatesDataSource
+	latestRateDate, err := services.ExchangeRateHistories.GetLatestRateDate(c, 0, dataSource)
+
+	if err != nil {
+		log.Errorf(c, "[exchange_rates_data_provider_container.SaveExchangeRatesHistory] failed to get latest rate date of data source \"%s\", because %s", dataSource, err.Error())
+		return err
+	}
+
+	startTime := now.AddDate(0, 0, -int(currentConfig.ExchangeRatesHistoryBackfillDays))
+
+	if latestRateDate > 0 {
+		latestRateTime := models.ParseNumericRateDate(latestRateDate, now.Location())
+
+		// the exchange rates on the latest saved date would be fetched again, in case they are revised by data source
+		if latestRateTime.After(startTime) {
+			startTime = latestRateTime
+		}
+	}
+
+	allHistoricalExchangeRates, err := e.GetHistoricalExchangeRates(c, 0, currentConfig, startTime, now)
+
+	if errors.Is(err, errs.ErrHistoricalExchangeRatesNotSupported) {
+		latestExchangeRateResponse, err := e.GetLatestExchangeRates(c, 0, currentConfig)
+
+		if err != nil {
+			log.Errorf(c, "[exchange_rates_data_provider_container.SaveExchangeRatesHistory] failed to get latest exchange rates of data source \"%s\", because %s", dataSource, err.Error())
+			return err
+		}
+
+		allHistoricalExchangeRates = []*models.HistoricalExchangeRates{
+			{
+				RateDate:      utils.FormatUnixTimeToNumericYearMonthDay(latestExchangeRateResponse.UpdateTime, now.Location()),
+				BaseCurrency:  latestExchangeRateResponse.BaseCurrency,
+				ExchangeRates: latestExchangeRateResponse.ExchangeRates,
+			},
+		}
+	} else if err != nil {
+		log.Errorf(c, "[exchange_rates_data_provider_container.SaveExchangeRatesHistory] failed to get historical exchange rates of data source \"%s\", because %s", dataSource, err.Error())
+		return err
+	}
+
+	savedCount, err := services.ExchangeRateHistories.SaveExchangeRatesHistory(c, 0, dataSource, allHistoricalExchangeRates)
+
+	if err != nil {
+		log.Errorf(c, "[exchange_rates_data_provider_container.SaveExchangeRatesHistory] failed to save exchange rates history of data source \"%s\", because %s", dataSource, err.Error())
+		return err
+	}
+
+	log.Infof(c, "[exchange_rates_data_provider_container.SaveExchangeRatesHistory] %d exchange rates of %d dates saved from data source \"%s\"", savedCount, len(allHistoricalExchangeRates), dataSource)
+
+	return nil
+}
+
+func (e *ExchangeRatesDataProviderContainer) saveUserCustomExchangeRatesHistory(c core.Context, currentConfig *settings.Config, now time.Time) error {
+	uids, err := services.UserCustomExchangeRates.GetAllUidsWithCustomExchangeRates(c)
+
+	if err != nil {
+		log.Errorf(c, "[exchange_rates_data_provider_container.saveUserCustomExchangeRatesHistory] failed to get all users who have custom exchange rates, because %s", err.Error())
+		return err
+	}
+
+	rateDate := utils.FormatUnixTimeToNumericYearMonthDay(now.Unix(), now.Location())
+	totalSavedCount := 0
+
+	for i := 0; i < len(uids); i++ {
+		uid := uids[i]
+		latestExchangeRateResponse, err := e.current.GetLatestExchangeRates(c, uid, currentConfig)
+
+		if err != nil {
+			log.Warnf(c, "[exchange_rates_data_provider_container.saveUserCustomExchangeRatesHistory] failed to get custom exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+			continue
+		}
+
+		allHistoricalExchangeRates := []*models.HistoricalExchangeRates{
+			{
+				RateDate:      rateDate,
+				BaseCurrency:  latestExchangeRateResponse.BaseCurrency,
+				ExchangeRates: latestExchangeRateResponse.ExchangeRates,
+			},
+		}
+
+		savedCount, err := services.ExchangeRateHistories.SaveExchangeRatesHistory(c, uid, currentConfig.ExchangeRatesDataSource, allHistoricalExchangeRates)
+
+		if err != nil {
+			log.Errorf(c, "[exchange_rates_data_provider_container.saveUserCustomExchangeRatesHistory] failed to save custom exchange rates history for user \"uid:%d\", because %s", uid, err.Error())
+			return err
+		}
+
+		totalSavedCount += savedCount
+	}
+
+	log.Infof(c, "[exchange_rates_data_provider_container.saveUserCustomExchangeRatesHistory] %d custom exchange rates of %d users saved", totalSavedCount, len(uids))
+
+	return nil
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"crypto/tls"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"syscall"
 	"time"
 )
 
@@ -44,8 +46,70 @@ func (t *defaultTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	return resp, err
 }
 
+// dialControlTransport sends the request directly with the dial control function, or checks the target host by the dial control function before sending the request via a proxy,
+// because the dial control function only sees the address of proxy when the request is sent via a proxy
+type dialControlTransport struct {
+	dialControl      func(network string, address string, conn syscall.RawConn) error
+	directTransport  *http.Transport
+	proxiedTransport *http.Transport
+}
+
+func (t *dialControlTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	proxyUrl, err := t.proxiedTransport.Proxy(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyUrl == nil {
+		return t.directTransport.RoundTrip(req)
+	}
+
+	host := req.URL.Hostname()
+	port := req.URL.Port()
+
+	if port == "" {
+		if req.URL.Scheme == "https" {
+			port = "443"
+		} else {
+			port = "80"
+		}
+	}
+
+	ips := make([]net.IP, 0, 1)
+
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		ipAddrs, err := net.DefaultResolver.LookupIPAddr(req.Context(), host)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < len(ipAddrs); i++ {
+			ips = append(ips, ipAddrs[i].IP)
+		}
+	}
+
+	for i := 0; i < len(ips); i++ {
+		if err := t.dialControl("tcp", net.JoinHostPort(ips[i].String(), port), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.proxiedTransport.RoundTrip(req)
+}
+
 // NewHttpClient creates and returns a new http client with specified settings
 func NewHttpClient(requestTimeout uint32, proxy string, skipTLSVerify bool, defaultUserAgent string, enableHttpResponseLog bool) *http.Client {
+	return NewHttpClientWithDialControl(requestTimeout, proxy, skipTLSVerify, defaultUserAgent, enableHttpResponseLog, nil)
+}
+
+// NewHttpClientWithDialControl creates and returns a new http client with specified settings,
+// the dial control function is called with the resolved address before every direct connection is established,
+// and for the request sent via a proxy, it is called (with nil conn) with every resolved address of the target host before handing the request to the proxy
+func NewHttpClientWithDialControl(requestTimeout uint32, proxy string, skipTLSVerify bool, defaultUserAgent string, enableHttpResponseLog bool, dialControl func(network string, address string, conn syscall.RawConn) error) *http.Client {
 	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
 	SetProxyUrl(baseTransport, proxy)
 
@@ -55,11 +119,36 @@ func NewHttpClient(requestTimeout uint32, proxy string, skipTLSVerify bool, defa
 		}
 	}
 
+	var transport http.RoundTripper = baseTransport
+
+	if dialControl != nil {
+		proxiedTransport := baseTransport.Clone()
+
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   dialControl,
+		}
+
+		baseTransport.Proxy = nil
+		baseTransport.DialContext = dialer.DialContext
+
+		if proxiedTransport.Proxy != nil {
+			transport = &dialControlTransport{
+				dialControl:      dialControl,
+				directTransport:  baseTransport,
+				proxiedTransport: proxiedTransport,
+			}
+		} else {
+			transport = baseTransport
+		}
+	}
+
 	return &http.Client{
 		Transport: &defaultTransport{
 			defaultUserAgent:      defaultUserAgent,
 			enableHttpResponseLog: enableHttpResponseLog,
-			baseTransport:         baseTransport,
+			baseTransport:         transport,
 		},
 		Timeout: time.Duration(requestTimeout) * time.Millisecond,
 	}
@@ -0,0 +1,228 @@
+package importjobs
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+const importJobPollInterval = 10 * time.Second
+const importJobHeartbeatInterval = 2 * time.Second
+const importJobStaleTimeout = 10 * time.Minute
+const importJobMaxAttempts = 3
+
+// ImportJobWorkerPool represents the worker pool which processes the pending import jobs in background
+type ImportJobWorkerPool struct {
+	importJobs    *services.ImportJobService
+	importBatches *services.ImportBatchService
+	transactions  *services.TransactionService
+	webhooks      *services.WebhookService
+	notifyChan    chan struct{}
+	started       atomic.Bool
+}
+
+// Initialize an import job worker pool singleton instance
+var (
+	Container = &ImportJobWorkerPool{
+		importJobs:    services.ImportJobs,
+		importBatches: services.ImportBatches,
+		transactions:  services.Transactions,
+		webhooks:      services.Webhooks,
+	}
+)
+
+// InitializeImportJobWorkerPool starts the workers of import job worker pool according to the config
+func InitializeImportJobWorkerPool(ctx core.Context, config *settings.Config) error {
+	if !Container.started.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	workerCount := int(config.ImportJobWorkerCount)
+	Container.notifyChan = make(chan struct{}, workerCount)
+
+	for i := 0; i < workerCount; i++ {
+		go Container.runWorker(i)
+	}
+
+	log.BootInfof(ctx, "[import_job_worker_pool.InitializeImportJobWorkerPool] %d import job workers have been started", workerCount)
+
+	return nil
+}
+
+// Notify wakes up an idle worker to process the pending import jobs immediately
+func (p *ImportJobWorkerPool) Notify() {
+	select {
+	case p.notifyChan <- struct{}{}:
+	default:
+	}
+}
+
+func (p *ImportJobWorkerPool) runWorker(workerIndex int) {
+	ticker := time.NewTicker(importJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// only the first worker is responsible for recovering the import jobs interrupted by server restart or crash
+		if workerIndex == 0 {
+			p.resetStaleImportJobs()
+		}
+
+		for p.processNextImportJob() {
+		}
+
+		select {
+		case <-p.notifyChan:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ImportJobWorkerPool) resetStaleImportJobs() {
+	c := core.NewNullContext()
+	resetCount, failedCount, err := p.importJobs.ResetStaleImportJobs(c, time.Now().Add(-importJobStaleTimeout), importJobMaxAttempts)
+
+	if err != nil {
+		log.Errorf(c, "[import_job_worker_pool.resetStaleImportJobs] failed to reset stale import jobs, because %s", err.Error())
+		return
+	}
+
+	if resetCount > 0 || failedCount > 0 {
+		log.Warnf(c, "[import_job_worker_pool.resetStaleImportJobs] %d interrupted import jobs have been reset to pending and %d import jobs have been marked as failed", resetCount, failedCount)
+	}
+}
+
+func (p *ImportJobWorkerPool) processNextImportJob() bool {
+	c := core.NewNullContext()
+	importJob, err := p.importJobs.ClaimNextPendingImportJob(c)
+
+	if err != nil {
+		log.Errorf(c, "[import_job_worker_pool.processNextImportJob] failed to claim pending import job, because %s", err.Error())
+		return false
+	} else if importJob == nil {
+		return false
+	}
+
+	p.processImportJob(core.NewImportJobContext(importJob.JobId), importJob)
+
+	return true
+}
+
+func (p *ImportJobWorkerPool) processImportJob(c core.Context, importJob *models.ImportJob) {
+	log.Infof(c, "[import_job_worker_pool.processImportJob] start processing import job \"id:%d\" of user \"uid:%d\" (attempt %d)", importJob.JobId, importJob.Uid, importJob.Attempts)
+
+	// the previous attempt may have committed all transactions but failed to update the import job before it was interrupted
+	importBatch, err := p.importBatches.GetImportBatchByImportJobId(c, importJob.Uid, importJob.JobId)
+
+	if err != nil {
+		log.Errorf(c, "[import_job_worker_pool.processImportJob] failed to get import batch of import job \"id:%d\" for user \"uid:%d\", because %s", importJob.JobId, importJob.Uid, err.Error())
+		p.failImportJob(c, importJob, err)
+		return
+	}
+
+	if importBatch != nil {
+		log.Infof(c, "[import_job_worker_pool.processImportJob] import job \"id:%d\" of user \"uid:%d\" has already created import batch \"id:%d\"", importJob.JobId, importJob.Uid, importBatch.BatchId)
+		p.succeedImportJob(c, importJob, importBatch)
+		return
+	}
+
+	payload, err := importJob.GetPayload()
+
+	if err != nil {
+		log.Errorf(c, "[import_job_worker_pool.processImportJob] failed to parse payload of import job \"id:%d\" for user \"uid:%d\", because %s", importJob.JobId, importJob.Uid, err.Error())
+		p.failImportJob(c, importJob, errs.ErrOperationFailed)
+		return
+	}
+
+	if len(payload.Transactions) < 1 {
+		p.succeedImportJob(c, importJob, nil)
+		return
+	}
+
+	if payload.ImportBatch == nil {
+		payload.ImportBatch = &models.ImportBatch{
+			Uid:        importJob.Uid,
+			TotalCount: int32(len(payload.Transactions)),
+		}
+	}
+
+	var currentProcess atomic.Uint64
+	var heartbeatWaitGroup sync.WaitGroup
+	heartbeatDone := make(chan struct{})
+
+	// the process is saved in another goroutine, because the database may be locked by the transaction which is importing transactions
+	heartbeatWaitGroup.Add(1)
+	go func() {
+		defer heartbeatWaitGroup.Done()
+		p.keepImportJobAlive(c, importJob, &currentProcess, heartbeatDone)
+	}()
+
+	err = p.transactions.BatchCreateTransactionsByImportJob(c, importJob, payload.Transactions, payload.TagIds, payload.ImportBatch, func(process float64) {
+		currentProcess.Store(math.Float64bits(process))
+	})
+
+	close(heartbeatDone)
+	heartbeatWaitGroup.Wait()
+
+	if err == errs.ErrImportJobClaimedByOthers {
+		log.Warnf(c, "[import_job_worker_pool.processImportJob] import job \"id:%d\" of user \"uid:%d\" has been reset and claimed again during importing, all imported transactions of attempt %d have been rolled back", importJob.JobId, importJob.Uid, importJob.Attempts)
+		return
+	} else if err != nil {
+		log.Errorf(c, "[import_job_worker_pool.processImportJob] failed to import %d transactions of import job \"id:%d\" for user \"uid:%d\", because %s", len(payload.Transactions), importJob.JobId, importJob.Uid, err.Error())
+		p.failImportJob(c, importJob, err)
+		return
+	}
+
+	log.Infof(c, "[import_job_worker_pool.processImportJob] user \"uid:%d\" has imported %d transactions in import batch \"id:%d\" by import job \"id:%d\" successfully", importJob.Uid, payload.ImportBatch.ImportedCount, payload.ImportBatch.BatchId, importJob.JobId)
+
+	p.succeedImportJob(c, importJob, payload.ImportBatch)
+
+	err = p.webhooks.TriggerEvent(c, importJob.Uid, models.WEBHOOK_EVENT_TYPE_TRANSACTIONS_IMPORTED, &models.WebhookTransactionsImportedEventData{
+		Count: int(payload.ImportBatch.ImportedCount),
+	})
+
+	if err != nil {
+		log.Warnf(c, "[import_job_worker_pool.processImportJob] failed to trigger webhook event \"%s\" for user \"uid:%d\", because %s", models.WEBHOOK_EVENT_TYPE_TRANSACTIONS_IMPORTED, importJob.Uid, err.Error())
+	}
+}
+
+func (p *ImportJobWorkerPool) keepImportJobAlive(c core.Context, importJob *models.ImportJob, currentProcess *atomic.Uint64, done <-chan struct{}) {
+	ticker := time.NewTicker(importJobHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			err := p.importJobs.UpdateImportJobProcess(c, importJob, math.Float64frombits(currentProcess.Load()))
+
+			if err != nil {
+				log.Warnf(c, "[import_job_worker_pool.keepImportJobAlive] failed to update process of import job \"id:%d\" for user \"uid:%d\", because %s", importJob.JobId, importJob.Uid, err.Error())
+			}
+		}
+	}
+}
+
+func (p *ImportJobWorkerPool) succeedImportJob(c core.Context, importJob *models.ImportJob, importBatch *models.ImportBatch) {
+	err := p.importJobs.SucceedImportJob(c, importJob, importBatch)
+
+	if err != nil {
+		log.Errorf(c, "[import_job_worker_pool.succeedImportJob] failed to mark import job \"id:%d\" of user \"uid:%d\" as succeeded, because %s", importJob.JobId, importJob.Uid, err.Error())
+	}
+}
+
+func (p *ImportJobWorkerPool) failImportJob(c core.Context, importJob *models.ImportJob, jobError error) {
+	err := p.importJobs.FailImportJob(c, importJob, jobError)
+
+	if err != nil {
+		log.Errorf(c, "[import_job_worker_pool.failImportJob] failed to mark import job \"id:%d\" of user \"uid:%d\" as failed, because %s", importJob.JobId, importJob.Uid, err.Error())
+	}
+}
@@ -11,6 +11,8 @@ type LocaleTextItems struct {
 	DataConverterTextItems      *DataConverterTextItems
 	VerifyEmailTextItems        *VerifyEmailTextItems
 	ForgetPasswordMailTextItems *ForgetPasswordMailTextItems
+	FinancialReportTextItems    *FinancialReportTextItems
+	SummaryReportMailTextItems  *SummaryReportMailTextItems
 }
 
 // GlobalTextItems represents global text items need to be translated
@@ -22,6 +24,7 @@ type GlobalTextItems struct {
 type DefaultTypes struct {
 	DecimalSeparator    core.DecimalSeparator
 	DigitGroupingSymbol core.DigitGroupingSymbol
+	LongDateFormat      core.LongDateFormat
 }
 
 // DataConverterTextItems represents text items need to be translated in data converter
@@ -47,3 +50,48 @@ type ForgetPasswordMailTextItems struct {
 	ResetPassword             string
 	DescriptionBelowBtnFormat string
 }
+
+// FinancialReportTextItems represents text items need to be translated in financial report
+type FinancialReportTextItems struct {
+	Title             string
+	Period            string
+	Currency          string
+	IncomeStatement   string
+	BalanceSheet      string
+	CategoryBreakdown string
+	Income            string
+	Expense           string
+	NetIncome         string
+	Assets            string
+	Liabilities       string
+	NetAssets         string
+	Total             string
+	Category          string
+	Account           string
+	Amount            string
+	Percentage        string
+	AsOfDateFormat    string
+	NoData            string
+}
+
+// SummaryReportMailTextItems represents text items need to be translated in summary report mail
+type SummaryReportMailTextItems struct {
+	WeeklyTitle            string
+	MonthlyTitle           string
+	SalutationFormat       string
+	DescriptionFormat      string
+	Income                 string
+	Expense                string
+	NetIncome              string
+	TopIncomeCategories    string
+	TopExpenseCategories   string
+	BiggestTransactions    string
+	BudgetStatus           string
+	Spent                  string
+	Remaining              string
+	Overspent              string
+	AccountBalanceChanges  string
+	Change                 string
+	NoData                 string
+	UnsubscribeDescription string
+}
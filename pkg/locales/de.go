@@ -11,6 +11,7 @@ var de = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var de = &LocaleTextItems{
 		ResetPassword:             "Passwort zurücksetzen",
 		DescriptionBelowBtnFormat: "Wenn Sie nicht angefordert haben, Ihr Passwort zurückzusetzen, ignorieren Sie bitte diese E-Mail. Wenn Sie den obigen Link nicht anklicken können, kopieren Sie bitte die obige URL und fügen Sie sie in Ihren Browser ein. Der Link zum Zurücksetzen des Passworts wird nach %v Minuten ablaufen.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Finanzbericht",
+		Period:            "Zeitraum",
+		Currency:          "Währung",
+		IncomeStatement:   "Einnahmen-Ausgaben-Rechnung",
+		BalanceSheet:      "Bilanz",
+		CategoryBreakdown: "Aufschlüsselung nach Kategorien",
+		Income:            "Einnahmen",
+		Expense:           "Ausgaben",
+		NetIncome:         "Nettoergebnis",
+		Assets:            "Vermögen",
+		Liabilities:       "Verbindlichkeiten",
+		NetAssets:         "Nettovermögen",
+		Total:             "Summe",
+		Category:          "Kategorie",
+		Account:           "Konto",
+		Amount:            "Betrag",
+		Percentage:        "Anteil",
+		AsOfDateFormat:    "Stand: %s",
+		NoData:            "Keine Daten",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Wöchentliche Übersicht",
+		MonthlyTitle:           "Monatliche Übersicht",
+		SalutationFormat:       "Hallo %s,",
+		DescriptionFormat:      "Hier ist Ihre Finanzübersicht für %s.",
+		Income:                 "Einnahmen",
+		Expense:                "Ausgaben",
+		NetIncome:              "Nettoeinkommen",
+		TopIncomeCategories:    "Top-Einnahmenkategorien",
+		TopExpenseCategories:   "Top-Ausgabenkategorien",
+		BiggestTransactions:    "Größte Transaktionen",
+		BudgetStatus:           "Budgetstatus",
+		Spent:                  "Ausgegeben",
+		Remaining:              "Verbleibend",
+		Overspent:              "Überschritten",
+		AccountBalanceChanges:  "Änderungen der Kontostände",
+		Change:                 "Änderung",
+		NoData:                 "Keine Daten",
+		UnsubscribeDescription: "Sie erhalten diese E-Mail, weil Sie Übersichts-E-Mails aktiviert haben. Sie können sie jederzeit in den Einstellungen deaktivieren.",
+	},
 }
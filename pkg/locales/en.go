@@ -11,6 +11,7 @@ var en = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_M_D_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var en = &LocaleTextItems{
 		ResetPassword:             "Reset Password",
 		DescriptionBelowBtnFormat: "If you did not request to reset your password, please simply disregard this email. If you cannot click the link above, please copy the above url and paste it into your browser. The password reset link will be expired after %v minutes.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Financial Report",
+		Period:            "Period",
+		Currency:          "Currency",
+		IncomeStatement:   "Income Statement",
+		BalanceSheet:      "Balance Sheet",
+		CategoryBreakdown: "Category Breakdown",
+		Income:            "Income",
+		Expense:           "Expense",
+		NetIncome:         "Net Income",
+		Assets:            "Assets",
+		Liabilities:       "Liabilities",
+		NetAssets:         "Net Assets",
+		Total:             "Total",
+		Category:          "Category",
+		Account:           "Account",
+		Amount:            "Amount",
+		Percentage:        "Percentage",
+		AsOfDateFormat:    "As of %s",
+		NoData:            "No data",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Weekly Summary",
+		MonthlyTitle:           "Monthly Summary",
+		SalutationFormat:       "Hi %s,",
+		DescriptionFormat:      "Here is your financial summary for %s.",
+		Income:                 "Income",
+		Expense:                "Expense",
+		NetIncome:              "Net Income",
+		TopIncomeCategories:    "Top Income Categories",
+		TopExpenseCategories:   "Top Expense Categories",
+		BiggestTransactions:    "Biggest Transactions",
+		BudgetStatus:           "Budget Status",
+		Spent:                  "Spent",
+		Remaining:              "Remaining",
+		Overspent:              "Overspent",
+		AccountBalanceChanges:  "Account Balance Changes",
+		Change:                 "Change",
+		NoData:                 "No data",
+		UnsubscribeDescription: "You received this email because you have enabled summary emails. You can turn it off in the settings at any time.",
+	},
 }
@@ -11,6 +11,7 @@ var es = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var es = &LocaleTextItems{
 		ResetPassword:             "Restablecer Contraseña",
 		DescriptionBelowBtnFormat: "Si no solicitó un restablecimiento de contraseña, simplemente descarte este correo. Si no puede hacer click en el link anterior, copie la url arriba mostrada y péguela en su navegadror. El enlace de restablecimiento de contraseña expira pasados %v minutos.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Informe financiero",
+		Period:            "Período",
+		Currency:          "Moneda",
+		IncomeStatement:   "Estado de resultados",
+		BalanceSheet:      "Balance general",
+		CategoryBreakdown: "Desglose por categoría",
+		Income:            "Ingresos",
+		Expense:           "Gastos",
+		NetIncome:         "Resultado neto",
+		Assets:            "Activos",
+		Liabilities:       "Pasivos",
+		NetAssets:         "Patrimonio neto",
+		Total:             "Total",
+		Category:          "Categoría",
+		Account:           "Cuenta",
+		Amount:            "Importe",
+		Percentage:        "Porcentaje",
+		AsOfDateFormat:    "A fecha de %s",
+		NoData:            "Sin datos",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Resumen semanal",
+		MonthlyTitle:           "Resumen mensual",
+		SalutationFormat:       "Hola %s,",
+		DescriptionFormat:      "Este es tu resumen financiero de %s.",
+		Income:                 "Ingresos",
+		Expense:                "Gastos",
+		NetIncome:              "Ingreso neto",
+		TopIncomeCategories:    "Principales categorías de ingresos",
+		TopExpenseCategories:   "Principales categorías de gastos",
+		BiggestTransactions:    "Transacciones más grandes",
+		BudgetStatus:           "Estado del presupuesto",
+		Spent:                  "Gastado",
+		Remaining:              "Restante",
+		Overspent:              "Excedido",
+		AccountBalanceChanges:  "Cambios en los saldos de las cuentas",
+		Change:                 "Cambio",
+		NoData:                 "Sin datos",
+		UnsubscribeDescription: "Recibes este correo porque has activado los correos de resumen. Puedes desactivarlos en la configuración en cualquier momento.",
+	},
 }
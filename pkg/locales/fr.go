@@ -11,6 +11,7 @@ var fr = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_SPACE,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var fr = &LocaleTextItems{
 		ResetPassword:             "Réinitialiser le mot de passe",
 		DescriptionBelowBtnFormat: "Si vous n'avez pas demandé la réinitialisation de votre mot de passe, vous pouvez ignorer cet e-mail. Si vous ne pouvez pas cliquer sur le lien ci-dessus, copiez l'URL ci-dessus et collez-la dans votre navigateur. Le lien de réinitialisation du mot de passe expire après %v minutes.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Rapport financier",
+		Period:            "Période",
+		Currency:          "Devise",
+		IncomeStatement:   "Compte de résultat",
+		BalanceSheet:      "Bilan",
+		CategoryBreakdown: "Répartition par catégorie",
+		Income:            "Revenus",
+		Expense:           "Dépenses",
+		NetIncome:         "Résultat net",
+		Assets:            "Actifs",
+		Liabilities:       "Passifs",
+		NetAssets:         "Actif net",
+		Total:             "Total",
+		Category:          "Catégorie",
+		Account:           "Compte",
+		Amount:            "Montant",
+		Percentage:        "Pourcentage",
+		AsOfDateFormat:    "Au %s",
+		NoData:            "Aucune donnée",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Résumé hebdomadaire",
+		MonthlyTitle:           "Résumé mensuel",
+		SalutationFormat:       "Bonjour %s,",
+		DescriptionFormat:      "Voici votre résumé financier pour %s.",
+		Income:                 "Revenus",
+		Expense:                "Dépenses",
+		NetIncome:              "Revenu net",
+		TopIncomeCategories:    "Principales catégories de revenus",
+		TopExpenseCategories:   "Principales catégories de dépenses",
+		BiggestTransactions:    "Plus grandes transactions",
+		BudgetStatus:           "État des budgets",
+		Spent:                  "Dépensé",
+		Remaining:              "Restant",
+		Overspent:              "Dépassé",
+		AccountBalanceChanges:  "Variations des soldes des comptes",
+		Change:                 "Variation",
+		NoData:                 "Aucune donnée",
+		UnsubscribeDescription: "Vous recevez cet e-mail car vous avez activé les e-mails de résumé. Vous pouvez les désactiver à tout moment dans les paramètres.",
+	},
 }
@@ -11,6 +11,7 @@ var it = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var it = &LocaleTextItems{
 		ResetPassword:             "Reimposta password",
 		DescriptionBelowBtnFormat: "Se non hai chiesto alcun cambio della password, puoi ignorare questa mail. Se non riesci a cliccare il link, copia l'indirizzo URL qui sopra e incollalo nel tuo browser preferito. Il link di verifica scadrà tra %v minuti.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Rapporto finanziario",
+		Period:            "Periodo",
+		Currency:          "Valuta",
+		IncomeStatement:   "Conto economico",
+		BalanceSheet:      "Stato patrimoniale",
+		CategoryBreakdown: "Ripartizione per categoria",
+		Income:            "Entrate",
+		Expense:           "Uscite",
+		NetIncome:         "Risultato netto",
+		Assets:            "Attività",
+		Liabilities:       "Passività",
+		NetAssets:         "Patrimonio netto",
+		Total:             "Totale",
+		Category:          "Categoria",
+		Account:           "Conto",
+		Amount:            "Importo",
+		Percentage:        "Percentuale",
+		AsOfDateFormat:    "Al %s",
+		NoData:            "Nessun dato",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Riepilogo settimanale",
+		MonthlyTitle:           "Riepilogo mensile",
+		SalutationFormat:       "Ciao %s,",
+		DescriptionFormat:      "Ecco il tuo riepilogo finanziario per %s.",
+		Income:                 "Entrate",
+		Expense:                "Uscite",
+		NetIncome:              "Reddito netto",
+		TopIncomeCategories:    "Principali categorie di entrata",
+		TopExpenseCategories:   "Principali categorie di spesa",
+		BiggestTransactions:    "Transazioni più grandi",
+		BudgetStatus:           "Stato dei budget",
+		Spent:                  "Speso",
+		Remaining:              "Rimanente",
+		Overspent:              "Superato",
+		AccountBalanceChanges:  "Variazioni dei saldi dei conti",
+		Change:                 "Variazione",
+		NoData:                 "Nessun dato",
+		UnsubscribeDescription: "Ricevi questa email perché hai attivato le email di riepilogo. Puoi disattivarle in qualsiasi momento nelle impostazioni.",
+	},
 }
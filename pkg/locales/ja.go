@@ -11,6 +11,7 @@ var ja = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_YYYY_M_D,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var ja = &LocaleTextItems{
 		ResetPassword:             "パスワードをリセット",
 		DescriptionBelowBtnFormat: "パスワードのリセットをリクエストしていない場合はこのメールを無視してください。上記のリンクをクリックできない場合は、上記のURLをコピーしてブラウザに貼り付けてください。パスワードリセットのリンクは%v分後に期限切れになります。",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "財務レポート",
+		Period:            "期間",
+		Currency:          "通貨",
+		IncomeStatement:   "損益計算書",
+		BalanceSheet:      "貸借対照表",
+		CategoryBreakdown: "カテゴリ別内訳",
+		Income:            "収入",
+		Expense:           "支出",
+		NetIncome:         "純利益",
+		Assets:            "資産",
+		Liabilities:       "負債",
+		NetAssets:         "純資産",
+		Total:             "合計",
+		Category:          "カテゴリ",
+		Account:           "口座",
+		Amount:            "金額",
+		Percentage:        "割合",
+		AsOfDateFormat:    "%s 時点",
+		NoData:            "データなし",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "週次サマリー",
+		MonthlyTitle:           "月次サマリー",
+		SalutationFormat:       "%s 様",
+		DescriptionFormat:      "%s の家計サマリーをお届けします。",
+		Income:                 "収入",
+		Expense:                "支出",
+		NetIncome:              "純収入",
+		TopIncomeCategories:    "収入の上位カテゴリ",
+		TopExpenseCategories:   "支出の上位カテゴリ",
+		BiggestTransactions:    "金額の大きい取引",
+		BudgetStatus:           "予算の状況",
+		Spent:                  "使用済み",
+		Remaining:              "残り",
+		Overspent:              "超過",
+		AccountBalanceChanges:  "口座残高の変動",
+		Change:                 "変動",
+		NoData:                 "データなし",
+		UnsubscribeDescription: "サマリーメールを有効にしているため、このメールをお送りしています。設定からいつでも無効にできます。",
+	},
 }
@@ -11,6 +11,7 @@ var kn = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var kn = &LocaleTextItems{
 		ResetPassword:             "Reset Password",
 		DescriptionBelowBtnFormat: "If you did not request to reset your password, please simply disregard this email. If you cannot click the link above, please copy the above url and paste it into your browser. The password reset link will be expired after %v minutes.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "ಹಣಕಾಸು ವರದಿ",
+		Period:            "ಅವಧಿ",
+		Currency:          "ಕರೆನ್ಸಿ",
+		IncomeStatement:   "ಆದಾಯ ಹೇಳಿಕೆ",
+		BalanceSheet:      "ಆಸ್ತಿ ಮತ್ತು ಹೊಣೆಗಾರಿಕೆ ಪಟ್ಟಿ",
+		CategoryBreakdown: "ವರ್ಗವಾರು ವಿಂಗಡಣೆ",
+		Income:            "ಆದಾಯ",
+		Expense:           "ವೆಚ್ಚ",
+		NetIncome:         "ನಿವ್ವಳ ಆದಾಯ",
+		Assets:            "ಆಸ್ತಿಗಳು",
+		Liabilities:       "ಹೊಣೆಗಾರಿಕೆಗಳು",
+		NetAssets:         "ನಿವ್ವಳ ಆಸ್ತಿ",
+		Total:             "ಒಟ್ಟು",
+		Category:          "ವರ್ಗ",
+		Account:           "ಖಾತೆ",
+		Amount:            "ಮೊತ್ತ",
+		Percentage:        "ಶೇಕಡಾವಾರು",
+		AsOfDateFormat:    "%s ರಂತೆ",
+		NoData:            "ಯಾವುದೇ ಡೇಟಾ ಇಲ್ಲ",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "ವಾರದ ಸಾರಾಂಶ",
+		MonthlyTitle:           "ಮಾಸಿಕ ಸಾರಾಂಶ",
+		SalutationFormat:       "ನಮಸ್ಕಾರ %s,",
+		DescriptionFormat:      "%s ಅವಧಿಯ ನಿಮ್ಮ ಹಣಕಾಸು ಸಾರಾಂಶ ಇಲ್ಲಿದೆ.",
+		Income:                 "ಆದಾಯ",
+		Expense:                "ವೆಚ್ಚ",
+		NetIncome:              "ನಿವ್ವಳ ಆದಾಯ",
+		TopIncomeCategories:    "ಪ್ರಮುಖ ಆದಾಯ ವರ್ಗಗಳು",
+		TopExpenseCategories:   "ಪ್ರಮುಖ ವೆಚ್ಚ ವರ್ಗಗಳು",
+		BiggestTransactions:    "ಅತಿದೊಡ್ಡ ವಹಿವಾಟುಗಳು",
+		BudgetStatus:           "ಬಜೆಟ್ ಸ್ಥಿತಿ",
+		Spent:                  "ಖರ್ಚಾಗಿದೆ",
+		Remaining:              "ಉಳಿದಿದೆ",
+		Overspent:              "ಮಿತಿ ಮೀರಿದೆ",
+		AccountBalanceChanges:  "ಖಾತೆ ಬಾಕಿ ಬದಲಾವಣೆಗಳು",
+		Change:                 "ಬದಲಾವಣೆ",
+		NoData:                 "ಡೇಟಾ ಇಲ್ಲ",
+		UnsubscribeDescription: "ನೀವು ಸಾರಾಂಶ ಇಮೇಲ್‌ಗಳನ್ನು ಸಕ್ರಿಯಗೊಳಿಸಿರುವುದರಿಂದ ಈ ಇಮೇಲ್ ಬಂದಿದೆ. ನೀವು ಯಾವಾಗ ಬೇಕಾದರೂ ಸೆಟ್ಟಿಂಗ್‌ಗಳಲ್ಲಿ ಇದನ್ನು ನಿಷ್ಕ್ರಿಯಗೊಳಿಸಬಹುದು.",
+	},
 }
@@ -11,6 +11,7 @@ var ko = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_YYYY_M_D,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var ko = &LocaleTextItems{
 		ResetPassword:             "비밀번호 재설정",
 		DescriptionBelowBtnFormat: "비밀번호 재설정을 요청하지 않으셨다면 이 이메일을 무시해주세요. 위 링크를 클릭할 수 없는 경우, 위 URL을 복사하여 브라우저에 붙여넣어 주세요. 비밀번호 재설정 링크는 %v분 후에 만료됩니다.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "재무 보고서",
+		Period:            "기간",
+		Currency:          "통화",
+		IncomeStatement:   "손익계산서",
+		BalanceSheet:      "대차대조표",
+		CategoryBreakdown: "카테고리별 내역",
+		Income:            "수입",
+		Expense:           "지출",
+		NetIncome:         "순이익",
+		Assets:            "자산",
+		Liabilities:       "부채",
+		NetAssets:         "순자산",
+		Total:             "합계",
+		Category:          "카테고리",
+		Account:           "계좌",
+		Amount:            "금액",
+		Percentage:        "비율",
+		AsOfDateFormat:    "%s 기준",
+		NoData:            "데이터 없음",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "주간 요약",
+		MonthlyTitle:           "월간 요약",
+		SalutationFormat:       "%s님, 안녕하세요.",
+		DescriptionFormat:      "%s 기간의 재무 요약입니다.",
+		Income:                 "수입",
+		Expense:                "지출",
+		NetIncome:              "순수입",
+		TopIncomeCategories:    "상위 수입 카테고리",
+		TopExpenseCategories:   "상위 지출 카테고리",
+		BiggestTransactions:    "가장 큰 거래",
+		BudgetStatus:           "예산 현황",
+		Spent:                  "사용",
+		Remaining:              "남음",
+		Overspent:              "초과",
+		AccountBalanceChanges:  "계좌 잔액 변동",
+		Change:                 "변동",
+		NoData:                 "데이터 없음",
+		UnsubscribeDescription: "요약 이메일을 활성화하셨기 때문에 이 이메일을 받으셨습니다. 설정에서 언제든지 끌 수 있습니다.",
+	},
 }
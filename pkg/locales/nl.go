@@ -11,6 +11,7 @@ var nl = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var nl = &LocaleTextItems{
 		ResetPassword:             "Wachtwoord opnieuw instellen",
 		DescriptionBelowBtnFormat: "Als je geen verzoek hebt gedaan om je wachtwoord te resetten, kun je deze e-mail negeren. Als je niet op de bovenstaande link kunt klikken, kopieer dan de URL hierboven en plak deze in je browser. De link voor het opnieuw instellen van het wachtwoord verloopt na  %v minuten.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Financieel rapport",
+		Period:            "Periode",
+		Currency:          "Valuta",
+		IncomeStatement:   "Resultatenrekening",
+		BalanceSheet:      "Balans",
+		CategoryBreakdown: "Uitsplitsing per categorie",
+		Income:            "Inkomsten",
+		Expense:           "Uitgaven",
+		NetIncome:         "Nettoresultaat",
+		Assets:            "Activa",
+		Liabilities:       "Passiva",
+		NetAssets:         "Nettovermogen",
+		Total:             "Totaal",
+		Category:          "Categorie",
+		Account:           "Rekening",
+		Amount:            "Bedrag",
+		Percentage:        "Percentage",
+		AsOfDateFormat:    "Per %s",
+		NoData:            "Geen gegevens",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Wekelijks overzicht",
+		MonthlyTitle:           "Maandelijks overzicht",
+		SalutationFormat:       "Hallo %s,",
+		DescriptionFormat:      "Hier is je financiële overzicht voor %s.",
+		Income:                 "Inkomsten",
+		Expense:                "Uitgaven",
+		NetIncome:              "Netto-inkomen",
+		TopIncomeCategories:    "Belangrijkste inkomstencategorieën",
+		TopExpenseCategories:   "Belangrijkste uitgavencategorieën",
+		BiggestTransactions:    "Grootste transacties",
+		BudgetStatus:           "Budgetstatus",
+		Spent:                  "Uitgegeven",
+		Remaining:              "Resterend",
+		Overspent:              "Overschreden",
+		AccountBalanceChanges:  "Wijzigingen in rekeningsaldi",
+		Change:                 "Wijziging",
+		NoData:                 "Geen gegevens",
+		UnsubscribeDescription: "Je ontvangt deze e-mail omdat je overzichtsmails hebt ingeschakeld. Je kunt dit op elk moment uitschakelen in de instellingen.",
+	},
 }
@@ -11,6 +11,7 @@ var ptBR = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_SPACE,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var ptBR = &LocaleTextItems{
 		ResetPassword:             "Redefinir Senha",
 		DescriptionBelowBtnFormat: "Se você não solicitou a redefinição de senha, basta ignorar este e-mail. Se não conseguir clicar no link acima, copie a URL acima e cole no seu navegador. O link de redefinição de senha expirará após %v minutos.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Relatório financeiro",
+		Period:            "Período",
+		Currency:          "Moeda",
+		IncomeStatement:   "Demonstração de resultados",
+		BalanceSheet:      "Balanço patrimonial",
+		CategoryBreakdown: "Detalhamento por categoria",
+		Income:            "Receitas",
+		Expense:           "Despesas",
+		NetIncome:         "Resultado líquido",
+		Assets:            "Ativos",
+		Liabilities:       "Passivos",
+		NetAssets:         "Patrimônio líquido",
+		Total:             "Total",
+		Category:          "Categoria",
+		Account:           "Conta",
+		Amount:            "Valor",
+		Percentage:        "Percentual",
+		AsOfDateFormat:    "Em %s",
+		NoData:            "Sem dados",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Resumo semanal",
+		MonthlyTitle:           "Resumo mensal",
+		SalutationFormat:       "Olá %s,",
+		DescriptionFormat:      "Aqui está o seu resumo financeiro de %s.",
+		Income:                 "Receitas",
+		Expense:                "Despesas",
+		NetIncome:              "Receita líquida",
+		TopIncomeCategories:    "Principais categorias de receita",
+		TopExpenseCategories:   "Principais categorias de despesa",
+		BiggestTransactions:    "Maiores transações",
+		BudgetStatus:           "Status dos orçamentos",
+		Spent:                  "Gasto",
+		Remaining:              "Restante",
+		Overspent:              "Excedido",
+		AccountBalanceChanges:  "Variações dos saldos das contas",
+		Change:                 "Variação",
+		NoData:                 "Sem dados",
+		UnsubscribeDescription: "Você recebeu este e-mail porque ativou os e-mails de resumo. Você pode desativá-los nas configurações a qualquer momento.",
+	},
 }
@@ -11,6 +11,7 @@ var ru = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_SPACE,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var ru = &LocaleTextItems{
 		ResetPassword:             "Сбросить пароль",
 		DescriptionBelowBtnFormat: "Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо. Если вы не можете нажать на ссылку выше, скопируйте указанный выше URL и вставьте его в браузер. Ссылка для сброса пароля истечет через %v минут.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Финансовый отчёт",
+		Period:            "Период",
+		Currency:          "Валюта",
+		IncomeStatement:   "Отчёт о доходах и расходах",
+		BalanceSheet:      "Баланс",
+		CategoryBreakdown: "Разбивка по категориям",
+		Income:            "Доходы",
+		Expense:           "Расходы",
+		NetIncome:         "Чистый доход",
+		Assets:            "Активы",
+		Liabilities:       "Обязательства",
+		NetAssets:         "Чистые активы",
+		Total:             "Итого",
+		Category:          "Категория",
+		Account:           "Счёт",
+		Amount:            "Сумма",
+		Percentage:        "Доля",
+		AsOfDateFormat:    "По состоянию на %s",
+		NoData:            "Нет данных",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Еженедельная сводка",
+		MonthlyTitle:           "Ежемесячная сводка",
+		SalutationFormat:       "Здравствуйте, %s!",
+		DescriptionFormat:      "Ваша финансовая сводка за %s.",
+		Income:                 "Доходы",
+		Expense:                "Расходы",
+		NetIncome:              "Чистый доход",
+		TopIncomeCategories:    "Основные категории доходов",
+		TopExpenseCategories:   "Основные категории расходов",
+		BiggestTransactions:    "Крупнейшие транзакции",
+		BudgetStatus:           "Состояние бюджетов",
+		Spent:                  "Потрачено",
+		Remaining:              "Осталось",
+		Overspent:              "Превышено",
+		AccountBalanceChanges:  "Изменения остатков на счетах",
+		Change:                 "Изменение",
+		NoData:                 "Нет данных",
+		UnsubscribeDescription: "Вы получили это письмо, потому что включили рассылку сводок. Вы можете отключить её в настройках в любое время.",
+	},
 }
@@ -11,6 +11,7 @@ var sl = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var sl = &LocaleTextItems{
 		ResetPassword:             "Ponastavi geslo",
 		DescriptionBelowBtnFormat: "Če niste zahtevali ponastavitve gesla, prosimo, da to e-poštno sporočilo preprosto prezrete. Če ne morete klikniti zgornje povezave, kopirajte zgornji URL in ga prilepite v brskalnik. Povezava za ponastavitev gesla bo potekla po %v minutah.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Finančno poročilo",
+		Period:            "Obdobje",
+		Currency:          "Valuta",
+		IncomeStatement:   "Izkaz prihodkov in odhodkov",
+		BalanceSheet:      "Bilanca stanja",
+		CategoryBreakdown: "Razčlenitev po kategorijah",
+		Income:            "Prihodki",
+		Expense:           "Odhodki",
+		NetIncome:         "Čisti rezultat",
+		Assets:            "Sredstva",
+		Liabilities:       "Obveznosti",
+		NetAssets:         "Čista sredstva",
+		Total:             "Skupaj",
+		Category:          "Kategorija",
+		Account:           "Račun",
+		Amount:            "Znesek",
+		Percentage:        "Delež",
+		AsOfDateFormat:    "Na dan %s",
+		NoData:            "Ni podatkov",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Tedenski povzetek",
+		MonthlyTitle:           "Mesečni povzetek",
+		SalutationFormat:       "Pozdravljeni, %s,",
+		DescriptionFormat:      "Tukaj je vaš finančni povzetek za %s.",
+		Income:                 "Prihodki",
+		Expense:                "Odhodki",
+		NetIncome:              "Neto dohodek",
+		TopIncomeCategories:    "Glavne kategorije prihodkov",
+		TopExpenseCategories:   "Glavne kategorije odhodkov",
+		BiggestTransactions:    "Največje transakcije",
+		BudgetStatus:           "Stanje proračunov",
+		Spent:                  "Porabljeno",
+		Remaining:              "Preostalo",
+		Overspent:              "Prekoračeno",
+		AccountBalanceChanges:  "Spremembe stanj računov",
+		Change:                 "Sprememba",
+		NoData:                 "Ni podatkov",
+		UnsubscribeDescription: "To e-poštno sporočilo ste prejeli, ker ste omogočili povzetke po e-pošti. V nastavitvah jih lahko kadar koli izklopite.",
+	},
 }
@@ -11,6 +11,7 @@ var ta = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var ta = &LocaleTextItems{
 		ResetPassword:             "கடவுச்சொல்லை மீட்டமை",
 		DescriptionBelowBtnFormat: "உங்கள் கடவுச்சொல்லை மீட்டமைக்க நீங்கள் கோரவில்லை என்றால், இந்த மின்னஞ்சலை புறக்கணிக்கவும். மேலே உள்ள இணைப்பைக் கிளிக் செய்ய முடியவில்லை என்றால், மேலே உள்ள URL ஐ நகலெடுத்து உங்கள் உலாவியில் ஒட்டவும். கடவுச்சொல் மீட்டமைப்பு இணைப்பு %v நிமிடங்களுக்குப் பிறகு காலாவதியாகும்.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "நிதி அறிக்கை",
+		Period:            "காலம்",
+		Currency:          "நாணயம்",
+		IncomeStatement:   "வருமான அறிக்கை",
+		BalanceSheet:      "இருப்புநிலைக் குறிப்பு",
+		CategoryBreakdown: "வகை வாரியான பகுப்பு",
+		Income:            "வருமானம்",
+		Expense:           "செலவு",
+		NetIncome:         "நிகர வருமானம்",
+		Assets:            "சொத்துகள்",
+		Liabilities:       "பொறுப்புகள்",
+		NetAssets:         "நிகர சொத்துகள்",
+		Total:             "மொத்தம்",
+		Category:          "வகை",
+		Account:           "கணக்கு",
+		Amount:            "தொகை",
+		Percentage:        "சதவீதம்",
+		AsOfDateFormat:    "%s நிலவரப்படி",
+		NoData:            "தரவு இல்லை",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "வாராந்திர சுருக்கம்",
+		MonthlyTitle:           "மாதாந்திர சுருக்கம்",
+		SalutationFormat:       "வணக்கம் %s,",
+		DescriptionFormat:      "%s காலத்திற்கான உங்கள் நிதி சுருக்கம் இதோ.",
+		Income:                 "வருமானம்",
+		Expense:                "செலவு",
+		NetIncome:              "நிகர வருமானம்",
+		TopIncomeCategories:    "முதன்மை வருமான வகைகள்",
+		TopExpenseCategories:   "முதன்மை செலவு வகைகள்",
+		BiggestTransactions:    "மிகப்பெரிய பரிவர்த்தனைகள்",
+		BudgetStatus:           "பட்ஜெட் நிலை",
+		Spent:                  "செலவழிக்கப்பட்டது",
+		Remaining:              "மீதமுள்ளது",
+		Overspent:              "மீறியது",
+		AccountBalanceChanges:  "கணக்கு இருப்பு மாற்றங்கள்",
+		Change:                 "மாற்றம்",
+		NoData:                 "தரவு இல்லை",
+		UnsubscribeDescription: "நீங்கள் சுருக்க மின்னஞ்சல்களை இயக்கியுள்ளதால் இந்த மின்னஞ்சலைப் பெற்றுள்ளீர்கள். அமைப்புகளில் எப்போது வேண்டுமானாலும் இதை முடக்கலாம்.",
+	},
 }
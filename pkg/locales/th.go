@@ -11,6 +11,7 @@ var th = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var th = &LocaleTextItems{
 		ResetPassword:             "ตั้งรหัสผ่านใหม่",
 		DescriptionBelowBtnFormat: "หากคุณไม่ได้ร้องขอให้รีเซ็ตรหัสผ่าน โปรดละเว้นอีเมลนี้ หากคุณไม่สามารถคลิกลิงก์ด้านบน โปรดคัดลอก URL ด้านบนและวางลงในเบราว์เซอร์ของคุณ ลิงก์รีเซ็ตรหัสผ่านจะหมดอายุหลังจาก %v นาที",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "รายงานการเงิน",
+		Period:            "ช่วงเวลา",
+		Currency:          "สกุลเงิน",
+		IncomeStatement:   "งบรายรับรายจ่าย",
+		BalanceSheet:      "งบดุล",
+		CategoryBreakdown: "รายละเอียดตามหมวดหมู่",
+		Income:            "รายรับ",
+		Expense:           "รายจ่าย",
+		NetIncome:         "รายได้สุทธิ",
+		Assets:            "สินทรัพย์",
+		Liabilities:       "หนี้สิน",
+		NetAssets:         "สินทรัพย์สุทธิ",
+		Total:             "รวม",
+		Category:          "หมวดหมู่",
+		Account:           "บัญชี",
+		Amount:            "จำนวนเงิน",
+		Percentage:        "สัดส่วน",
+		AsOfDateFormat:    "ณ วันที่ %s",
+		NoData:            "ไม่มีข้อมูล",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "สรุปรายสัปดาห์",
+		MonthlyTitle:           "สรุปรายเดือน",
+		SalutationFormat:       "สวัสดี %s",
+		DescriptionFormat:      "นี่คือสรุปการเงินของคุณสำหรับ %s",
+		Income:                 "รายรับ",
+		Expense:                "รายจ่าย",
+		NetIncome:              "รายได้สุทธิ",
+		TopIncomeCategories:    "หมวดหมู่รายรับสูงสุด",
+		TopExpenseCategories:   "หมวดหมู่รายจ่ายสูงสุด",
+		BiggestTransactions:    "ธุรกรรมที่ใหญ่ที่สุด",
+		BudgetStatus:           "สถานะงบประมาณ",
+		Spent:                  "ใช้ไป",
+		Remaining:              "คงเหลือ",
+		Overspent:              "เกินงบ",
+		AccountBalanceChanges:  "การเปลี่ยนแปลงยอดคงเหลือของบัญชี",
+		Change:                 "การเปลี่ยนแปลง",
+		NoData:                 "ไม่มีข้อมูล",
+		UnsubscribeDescription: "คุณได้รับอีเมลนี้เนื่องจากคุณเปิดใช้งานอีเมลสรุป คุณสามารถปิดได้ทุกเมื่อในการตั้งค่า",
+	},
 }
@@ -11,6 +11,7 @@ var tr = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var tr = &LocaleTextItems{
 		ResetPassword:             "Şifreyi Sıfırla",
 		DescriptionBelowBtnFormat: "Eğer şifre sıfırlama talebinde bulunmadıysanız, lütfen bu e-postayı dikkate almayın. Eğer yukarıdaki bağlantıya tıklayamıyorsanız, lütfen adresi kopyalayıp tarayıcınıza yapıştırın. Şifre sıfırlama bağlantısının süresi %v dakika sonra dolacaktır.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Finansal Rapor",
+		Period:            "Dönem",
+		Currency:          "Para Birimi",
+		IncomeStatement:   "Gelir Tablosu",
+		BalanceSheet:      "Bilanço",
+		CategoryBreakdown: "Kategori Dağılımı",
+		Income:            "Gelir",
+		Expense:           "Gider",
+		NetIncome:         "Net Gelir",
+		Assets:            "Varlıklar",
+		Liabilities:       "Yükümlülükler",
+		NetAssets:         "Net Varlıklar",
+		Total:             "Toplam",
+		Category:          "Kategori",
+		Account:           "Hesap",
+		Amount:            "Tutar",
+		Percentage:        "Yüzde",
+		AsOfDateFormat:    "%s itibarıyla",
+		NoData:            "Veri yok",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Haftalık Özet",
+		MonthlyTitle:           "Aylık Özet",
+		SalutationFormat:       "Merhaba %s,",
+		DescriptionFormat:      "%s dönemine ait finansal özetiniz aşağıdadır.",
+		Income:                 "Gelir",
+		Expense:                "Gider",
+		NetIncome:              "Net Gelir",
+		TopIncomeCategories:    "En Çok Gelir Getiren Kategoriler",
+		TopExpenseCategories:   "En Çok Harcama Yapılan Kategoriler",
+		BiggestTransactions:    "En Büyük İşlemler",
+		BudgetStatus:           "Bütçe Durumu",
+		Spent:                  "Harcanan",
+		Remaining:              "Kalan",
+		Overspent:              "Aşıldı",
+		AccountBalanceChanges:  "Hesap Bakiyesi Değişiklikleri",
+		Change:                 "Değişiklik",
+		NoData:                 "Veri yok",
+		UnsubscribeDescription: "Özet e-postalarını etkinleştirdiğiniz için bu e-postayı aldınız. Ayarlardan istediğiniz zaman kapatabilirsiniz.",
+	},
 }
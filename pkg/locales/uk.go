@@ -11,6 +11,7 @@ var uk = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_SPACE,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var uk = &LocaleTextItems{
 		ResetPassword:             "Скинути пароль",
 		DescriptionBelowBtnFormat: "Якщо ви не надсилали запит на скидання пароля, просто проігноруйте цей лист. Якщо ви не можете натиснути на посилання вище, скопіюйте вказану URL-адресу та вставте її у свій браузер. Посилання для скидання пароля буде дійсне протягом %v хвилин.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Фінансовий звіт",
+		Period:            "Період",
+		Currency:          "Валюта",
+		IncomeStatement:   "Звіт про доходи та витрати",
+		BalanceSheet:      "Баланс",
+		CategoryBreakdown: "Розподіл за категоріями",
+		Income:            "Доходи",
+		Expense:           "Витрати",
+		NetIncome:         "Чистий дохід",
+		Assets:            "Активи",
+		Liabilities:       "Зобов'язання",
+		NetAssets:         "Чисті активи",
+		Total:             "Разом",
+		Category:          "Категорія",
+		Account:           "Рахунок",
+		Amount:            "Сума",
+		Percentage:        "Частка",
+		AsOfDateFormat:    "Станом на %s",
+		NoData:            "Немає даних",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Щотижневий підсумок",
+		MonthlyTitle:           "Щомісячний підсумок",
+		SalutationFormat:       "Вітаємо, %s!",
+		DescriptionFormat:      "Ваш фінансовий підсумок за %s.",
+		Income:                 "Доходи",
+		Expense:                "Витрати",
+		NetIncome:              "Чистий дохід",
+		TopIncomeCategories:    "Основні категорії доходів",
+		TopExpenseCategories:   "Основні категорії витрат",
+		BiggestTransactions:    "Найбільші транзакції",
+		BudgetStatus:           "Стан бюджетів",
+		Spent:                  "Витрачено",
+		Remaining:              "Залишилось",
+		Overspent:              "Перевищено",
+		AccountBalanceChanges:  "Зміни залишків на рахунках",
+		Change:                 "Зміна",
+		NoData:                 "Немає даних",
+		UnsubscribeDescription: "Ви отримали цей лист, оскільки увімкнули розсилку підсумків. Ви можете вимкнути її в налаштуваннях будь-коли.",
+	},
 }
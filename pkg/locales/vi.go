@@ -11,6 +11,7 @@ var vi = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_COMMA,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_DOT,
+		LongDateFormat:      core.LONG_DATE_FORMAT_D_M_YYYY,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "Alipay",
@@ -30,4 +31,45 @@ var vi = &LocaleTextItems{
 		ResetPassword:             "Đặt lại Mật khẩu",
 		DescriptionBelowBtnFormat: "Nếu bạn không yêu cầu đặt lại mật khẩu, vui lòng bỏ qua email này. Nếu bạn không thể nhấp vào liên kết trên, hãy sao chép và dán liên kết vào trình duyệt của bạn. Liên kết đặt lại mật khẩu sẽ hết hạn sau %v phút.",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "Báo cáo tài chính",
+		Period:            "Kỳ",
+		Currency:          "Tiền tệ",
+		IncomeStatement:   "Báo cáo thu chi",
+		BalanceSheet:      "Bảng cân đối",
+		CategoryBreakdown: "Phân tích theo danh mục",
+		Income:            "Thu nhập",
+		Expense:           "Chi tiêu",
+		NetIncome:         "Thu nhập ròng",
+		Assets:            "Tài sản",
+		Liabilities:       "Nợ phải trả",
+		NetAssets:         "Tài sản ròng",
+		Total:             "Tổng cộng",
+		Category:          "Danh mục",
+		Account:           "Tài khoản",
+		Amount:            "Số tiền",
+		Percentage:        "Tỷ lệ",
+		AsOfDateFormat:    "Tính đến %s",
+		NoData:            "Không có dữ liệu",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "Tóm tắt hàng tuần",
+		MonthlyTitle:           "Tóm tắt hàng tháng",
+		SalutationFormat:       "Xin chào %s,",
+		DescriptionFormat:      "Đây là bản tóm tắt tài chính của bạn cho %s.",
+		Income:                 "Thu nhập",
+		Expense:                "Chi tiêu",
+		NetIncome:              "Thu nhập ròng",
+		TopIncomeCategories:    "Danh mục thu nhập hàng đầu",
+		TopExpenseCategories:   "Danh mục chi tiêu hàng đầu",
+		BiggestTransactions:    "Giao dịch lớn nhất",
+		BudgetStatus:           "Tình trạng ngân sách",
+		Spent:                  "Đã chi",
+		Remaining:              "Còn lại",
+		Overspent:              "Vượt mức",
+		AccountBalanceChanges:  "Thay đổi số dư tài khoản",
+		Change:                 "Thay đổi",
+		NoData:                 "Không có dữ liệu",
+		UnsubscribeDescription: "Bạn nhận được email này vì đã bật email tóm tắt. Bạn có thể tắt bất cứ lúc nào trong phần cài đặt.",
+	},
 }
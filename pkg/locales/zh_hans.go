@@ -11,6 +11,7 @@ var zhHans = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_YYYY_M_D,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "支付宝",
@@ -30,4 +31,45 @@ var zhHans = &LocaleTextItems{
 		ResetPassword:             "重置密码",
 		DescriptionBelowBtnFormat: "如果您没有请求重置密码，请直接忽略本邮件。如果您无法点击上述链接，请复制下方的地址然后在您的浏览器中粘贴。重置密码链接将在 %v 分钟后过期。",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "财务报告",
+		Period:            "期间",
+		Currency:          "货币",
+		IncomeStatement:   "收支表",
+		BalanceSheet:      "资产负债表",
+		CategoryBreakdown: "分类明细",
+		Income:            "收入",
+		Expense:           "支出",
+		NetIncome:         "净收入",
+		Assets:            "资产",
+		Liabilities:       "负债",
+		NetAssets:         "净资产",
+		Total:             "合计",
+		Category:          "分类",
+		Account:           "账户",
+		Amount:            "金额",
+		Percentage:        "占比",
+		AsOfDateFormat:    "截至 %s",
+		NoData:            "暂无数据",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "每周摘要",
+		MonthlyTitle:           "每月摘要",
+		SalutationFormat:       "%s，您好：",
+		DescriptionFormat:      "以下是您在 %s 期间的财务摘要。",
+		Income:                 "收入",
+		Expense:                "支出",
+		NetIncome:              "净收入",
+		TopIncomeCategories:    "主要收入分类",
+		TopExpenseCategories:   "主要支出分类",
+		BiggestTransactions:    "最大的交易",
+		BudgetStatus:           "预算状态",
+		Spent:                  "已支出",
+		Remaining:              "剩余",
+		Overspent:              "超支",
+		AccountBalanceChanges:  "账户余额变化",
+		Change:                 "变化",
+		NoData:                 "暂无数据",
+		UnsubscribeDescription: "您收到此邮件是因为您启用了摘要邮件，您可以随时在设置中关闭。",
+	},
 }
@@ -11,6 +11,7 @@ var zhHant = &LocaleTextItems{
 	DefaultTypes: &DefaultTypes{
 		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
 		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_COMMA,
+		LongDateFormat:      core.LONG_DATE_FORMAT_YYYY_M_D,
 	},
 	DataConverterTextItems: &DataConverterTextItems{
 		Alipay:       "支付寶",
@@ -30,4 +31,45 @@ var zhHant = &LocaleTextItems{
 		ResetPassword:             "重設密碼",
 		DescriptionBelowBtnFormat: "如果您沒有請求重設密碼，請直接忽略本郵件。如果您無法點擊上述連結，請複製下方的地址然後在您的瀏覽器中貼上。重設密碼連結將在 %v 分鐘後過期。",
 	},
+	FinancialReportTextItems: &FinancialReportTextItems{
+		Title:             "財務報告",
+		Period:            "期間",
+		Currency:          "貨幣",
+		IncomeStatement:   "收支表",
+		BalanceSheet:      "資產負債表",
+		CategoryBreakdown: "分類明細",
+		Income:            "收入",
+		Expense:           "支出",
+		NetIncome:         "淨收入",
+		Assets:            "資產",
+		Liabilities:       "負債",
+		NetAssets:         "淨資產",
+		Total:             "合計",
+		Category:          "分類",
+		Account:           "帳戶",
+		Amount:            "金額",
+		Percentage:        "佔比",
+		AsOfDateFormat:    "截至 %s",
+		NoData:            "暫無資料",
+	},
+	SummaryReportMailTextItems: &SummaryReportMailTextItems{
+		WeeklyTitle:            "每週摘要",
+		MonthlyTitle:           "每月摘要",
+		SalutationFormat:       "%s，您好：",
+		DescriptionFormat:      "以下是您在 %s 期間的財務摘要。",
+		Income:                 "收入",
+		Expense:                "支出",
+		NetIncome:              "淨收入",
+		TopIncomeCategories:    "主要收入分類",
+		TopExpenseCategories:   "主要支出分類",
+		BiggestTransactions:    "最大的交易",
+		BudgetStatus:           "預算狀態",
+		Spent:                  "已支出",
+		Remaining:              "剩餘",
+		Overspent:              "超支",
+		AccountBalanceChanges:  "帳戶餘額變化",
+		Change:                 "變化",
+		NoData:                 "暫無資料",
+		UnsubscribeDescription: "您收到此郵件是因為您啟用了摘要郵件，您可以隨時在設定中關閉。",
+	},
 }
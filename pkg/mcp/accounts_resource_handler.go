@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+const mcpAccountsResourceUri = mcpResourceUriPrefix + "accounts"
+
+type mcpAccountsResourceHandler struct{}
+
+var MCPAccountsResourceHandler = &mcpAccountsResourceHandler{}
+
+// Name returns the name of the MCP resource
+func (h *mcpAccountsResourceHandler) Name() string {
+	return "accounts"
+}
+
+// Description returns the description of the MCP resource
+func (h *mcpAccountsResourceHandler) Description() string {
+	return "All visible accounts of the current user in ezBookkeeping, grouped by account category, with the current balance and currency of each account."
+}
+
+// MimeType returns the MIME type of the MCP resource
+func (h *mcpAccountsResourceHandler) MimeType() string {
+	return mcpResourceJsonMimeType
+}
+
+// URITemplate returns the uri template of the MCP resource
+func (h *mcpAccountsResourceHandler) URITemplate() string {
+	return ""
+}
+
+// ListResources returns the resources which can be listed directly
+func (h *mcpAccountsResourceHandler) ListResources(c *core.WebContext, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPResource, error) {
+	return []*MCPResource{
+		{
+			URI:         mcpAccountsResourceUri,
+			Name:        h.Name(),
+			Title:       "Accounts",
+			Description: h.Description(),
+			MimeType:    h.MimeType(),
+		},
+	}, nil
+}
+
+// MatchURI returns whether the uri can be read by this MCP resource handler
+func (h *mcpAccountsResourceHandler) MatchURI(uri string) bool {
+	return uri == mcpAccountsResourceUri
+}
+
+// Read processes the MCP read resource request and returns the resource contents
+func (h *mcpAccountsResourceHandler) Read(c *core.WebContext, readResourceReq *MCPReadResourceRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPTextResourceContents, error) {
+	uid := user.Uid
+	accounts, err := services.GetAccountService().GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[accounts_resource.Read] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	structuredResponse, _, err := MCPQueryAllAccountsBalanceToolHandler.createNewMCPQueryAllAccountsBalanceResponse(c, accounts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(structuredResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*MCPTextResourceContents{
+		NewMCPTextResourceContents(readResourceReq.URI, string(content), h.MimeType()),
+	}, nil
+}
@@ -0,0 +1,201 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const defaultTransactionCategoryIcon = 1
+const defaultTransactionCategoryColor = "000000"
+
+// MCPAddTransactionCategoryRequest represents all parameters of the add transaction category request
+type MCPAddTransactionCategoryRequest struct {
+	Name                string `json:"name" jsonschema_description:"Category name (maximum 64 characters)"`
+	Type                string `json:"type" jsonschema:"enum=income,enum=expense,enum=transfer" jsonschema_description:"Category type (income, expense, transfer)"`
+	PrimaryCategoryName string `json:"primary_category_name,omitempty" jsonschema_description:"Primary category name which the new secondary category belongs to (optional, leave empty to add a primary category)"`
+	Comment             string `json:"comment,omitempty" jsonschema_description:"Category description (optional)"`
+	ClientSessionId     string `json:"client_session_id,omitempty" jsonschema_description:"Unique identifier of this request, the category will not be created repeatedly if the same identifier is submitted again (optional)"`
+}
+
+// MCPAddTransactionCategoryResponse represents the response structure for add transaction category
+type MCPAddTransactionCategoryResponse struct {
+	Success             bool   `json:"success" jsonschema_description:"Indicates whether this operation is successful"`
+	CategoryId          string `json:"category_id" jsonschema_description:"ID of the created category"`
+	Name                string `json:"name" jsonschema_description:"Category name"`
+	Type                string `json:"type" jsonschema:"enum=income,enum=expense,enum=transfer" jsonschema_description:"Category type (income, expense, transfer)"`
+	PrimaryCategoryName string `json:"primary_category_name,omitempty" jsonschema_description:"Primary category name which the category belongs to (only for secondary category)"`
+}
+
+type mcpAddTransactionCategoryToolHandler struct{}
+
+var MCPAddTransactionCategoryToolHandler = &mcpAddTransactionCategoryToolHandler{}
+
+// Name returns the name of the MCP tool
+func (h *mcpAddTransactionCategoryToolHandler) Name() string {
+	return "add_category"
+}
+
+// Description returns the description of the MCP tool
+func (h *mcpAddTransactionCategoryToolHandler) Description() string {
+	return "Add a new primary or secondary transaction category in ezBookkeeping."
+}
+
+// InputType returns the input type for the MCP tool request
+func (h *mcpAddTransactionCategoryToolHandler) InputType() reflect.Type {
+	return reflect.TypeOf(&MCPAddTransactionCategoryRequest{})
+}
+
+// OutputType returns the output type for the MCP tool response
+func (h *mcpAddTransactionCategoryToolHandler) OutputType() reflect.Type {
+	return reflect.TypeOf(&MCPAddTransactionCategoryResponse{})
+}
+
+// Handle processes the MCP call tool request and returns the response
+func (h *mcpAddTransactionCategoryToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	var addCategoryRequest MCPAddTransactionCategoryRequest
+
+	if callToolReq.Arguments != nil {
+		if err := json.Unmarshal(callToolReq.Arguments, &addCategoryRequest); err != nil {
+			return nil, nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+		}
+	} else {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	addCategoryRequest.Name = strings.TrimSpace(addCategoryRequest.Name)
+
+	if addCategoryRequest.Name == "" || utf8.RuneCountInString(addCategoryRequest.Name) > 64 || utf8.RuneCountInString(addCategoryRequest.Comment) > 255 {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	var categoryType models.TransactionCategoryType
+
+	if addCategoryRequest.Type == transactionTypeIncome {
+		categoryType = models.CATEGORY_TYPE_INCOME
+	} else if addCategoryRequest.Type == transactionTypeExpense {
+		categoryType = models.CATEGORY_TYPE_EXPENSE
+	} else if addCategoryRequest.Type == transactionTypeTransfer {
+		categoryType = models.CATEGORY_TYPE_TRANSFER
+	} else {
+		return nil, nil, errs.ErrTransactionCategoryTypeInvalid
+	}
+
+	uid := user.Uid
+	category := &models.TransactionCategory{
+		Uid:              uid,
+		Name:             addCategoryRequest.Name,
+		Type:             categoryType,
+		ParentCategoryId: models.LevelOneTransactionCategoryParentId,
+		Icon:             defaultTransactionCategoryIcon,
+		Color:            defaultTransactionCategoryColor,
+		Comment:          addCategoryRequest.Comment,
+	}
+
+	var primaryCategory *models.TransactionCategory
+
+	if addCategoryRequest.PrimaryCategoryName != "" {
+		primaryCategories, err := services.GetTransactionCategoryService().GetAllCategoriesByUid(c, uid, categoryType, models.LevelOneTransactionCategoryParentId)
+
+		if err != nil {
+			log.Warnf(c, "[add_category.Handle] get transaction category error, because %s", err.Error())
+			return nil, nil, err
+		}
+
+		for i := 0; i < len(primaryCategories); i++ {
+			if !primaryCategories[i].Hidden && primaryCategories[i].Name == addCategoryRequest.PrimaryCategoryName {
+				primaryCategory = primaryCategories[i]
+				break
+			}
+		}
+
+		if primaryCategory == nil {
+			log.Warnf(c, "[add_category.Handle] primary category \"%s\" not found for user \"uid:%d\"", addCategoryRequest.PrimaryCategoryName, uid)
+			return nil, nil, errs.ErrParentTransactionCategoryNotFound
+		}
+
+		category.ParentCategoryId = primaryCategory.CategoryId
+		category.Icon = primaryCategory.Icon
+		category.Color = primaryCategory.Color
+	}
+
+	var maxOrderId int32
+	var err error
+
+	if primaryCategory == nil {
+		maxOrderId, err = services.GetTransactionCategoryService().GetMaxDisplayOrder(c, uid, categoryType)
+	} else {
+		maxOrderId, err = services.GetTransactionCategoryService().GetMaxSubCategoryDisplayOrder(c, uid, categoryType, primaryCategory.CategoryId)
+	}
+
+	if err != nil {
+		log.Errorf(c, "[add_category.Handle] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, nil, err
+	}
+
+	category.DisplayOrder = maxOrderId + 1
+	created := false
+
+	if currentConfig.EnableDuplicateSubmissionsCheck && addCategoryRequest.ClientSessionId != "" {
+		found, remark := services.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_CATEGORY, uid, addCategoryRequest.ClientSessionId)
+
+		if found {
+			log.Infof(c, "[add_category.Handle] another category \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
+			categoryId, err := utils.StringToInt64(remark)
+
+			if err == nil {
+				category, err = services.GetTransactionCategoryService().GetCategoryByCategoryId(c, uid, categoryId)
+
+				if err != nil {
+					log.Errorf(c, "[add_category.Handle] failed to get existed category \"id:%d\" for user \"uid:%d\", because %s", categoryId, uid, err.Error())
+					return nil, nil, err
+				}
+
+				created = true
+			}
+		}
+	}
+
+	if !created {
+		err = services.GetTransactionCategoryService().CreateCategory(c, category)
+
+		if err != nil {
+			log.Errorf(c, "[add_category.Handle] failed to create category \"id:%d\" for user \"uid:%d\", because %s", category.CategoryId, uid, err.Error())
+			return nil, nil, err
+		}
+
+		log.Infof(c, "[add_category.Handle] user \"uid:%d\" has created a new category \"id:%d\" successfully", uid, category.CategoryId)
+
+		services.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_CATEGORY, uid, addCategoryRequest.ClientSessionId, utils.Int64ToString(category.CategoryId))
+	}
+
+	response := MCPAddTransactionCategoryResponse{
+		Success:    true,
+		CategoryId: utils.Int64ToString(category.CategoryId),
+		Name:       category.Name,
+		Type:       addCategoryRequest.Type,
+	}
+
+	if primaryCategory != nil {
+		response.PrimaryCategoryName = primaryCategory.Name
+	}
+
+	content, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, []*MCPTextContent{
+		NewMCPTextContent(string(content)),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+func TestAddTransactionCategoryToolHandler_InvalidArguments(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := callMCPTestTool(c, MCPAddTransactionCategoryToolHandler.Name(), "", user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionCategoryToolHandler.Name(), `{"name":"   ","type":"expense"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionCategoryToolHandler.Name(), `{"name":"`+strings.Repeat("a", 65)+`","type":"expense"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionCategoryToolHandler.Name(), `{"name":"Coffee","type":"expense","comment":"`+strings.Repeat("a", 256)+`"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionCategoryToolHandler.Name(), `{"name":"Coffee","type":"unknown"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionCategoryTypeInvalid, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionCategoryToolHandler.Name(), `{"name":"Coffee","type":"expense","primary_category_name":"Salary"}`, user, config)
+	assert.Equal(t, errs.ErrParentTransactionCategoryNotFound, err)
+}
+
+func TestAddTransactionCategoryToolHandler_AddSecondaryCategory(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := callMCPTestTool(c, MCPAddTransactionCategoryToolHandler.Name(), `{"name":"  Coffee  ","type":"expense","primary_category_name":"Food"}`, user, config)
+	assert.Nil(t, err)
+
+	response := result.(MCPCallToolResponse[MCPTextContent])
+	assert.Contains(t, response.Content[0].Text, `"name":"Coffee"`)
+	assert.Contains(t, response.Content[0].Text, `"primary_category_name":"Food"`)
+
+	categories, err := services.TransactionCategories.GetAllCategoriesByUid(c, mcpTestUid, models.CATEGORY_TYPE_EXPENSE, mcpTestExpenseCategoryId-1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(categories))
+	assert.Equal(t, "Coffee", categories[1].Name)
+	assert.Equal(t, int32(2), categories[1].DisplayOrder)
+	assert.True(t, categories[1].CategoryId > 0)
+}
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MCPAddTransactionTagRequest represents all parameters of the add transaction tag request
+type MCPAddTransactionTagRequest struct {
+	Name            string `json:"name" jsonschema_description:"Tag name (maximum 64 characters)"`
+	ClientSessionId string `json:"client_session_id,omitempty" jsonschema_description:"Unique identifier of this request, the tag will not be created repeatedly if the same identifier is submitted again (optional)"`
+}
+
+// MCPAddTransactionTagResponse represents the response structure for add transaction tag
+type MCPAddTransactionTagResponse struct {
+	Success bool   `json:"success" jsonschema_description:"Indicates whether this operation is successful"`
+	TagId   string `json:"tag_id" jsonschema_description:"ID of the created tag"`
+	Name    string `json:"name" jsonschema_description:"Tag name"`
+}
+
+type mcpAddTransactionTagToolHandler struct{}
+
+var MCPAddTransactionTagToolHandler = &mcpAddTransactionTagToolHandler{}
+
+// Name returns the name of the MCP tool
+func (h *mcpAddTransactionTagToolHandler) Name() string {
+	return "add_tag"
+}
+
+// Description returns the description of the MCP tool
+func (h *mcpAddTransactionTagToolHandler) Description() string {
+	return "Add a new transaction tag in ezBookkeeping."
+}
+
+// InputType returns the input type for the MCP tool request
+func (h *mcpAddTransactionTagToolHandler) InputType() reflect.Type {
+	return reflect.TypeOf(&MCPAddTransactionTagRequest{})
+}
+
+// OutputType returns the output type for the MCP tool response
+func (h *mcpAddTransactionTagToolHandler) OutputType() reflect.Type {
+	return reflect.TypeOf(&MCPAddTransactionTagResponse{})
+}
+
+// Handle processes the MCP call tool request and returns the response
+func (h *mcpAddTransactionTagToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	var addTagRequest MCPAddTransactionTagRequest
+
+	if callToolReq.Arguments != nil {
+		if err := json.Unmarshal(callToolReq.Arguments, &addTagRequest); err != nil {
+			return nil, nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+		}
+	} else {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	addTagRequest.Name = strings.TrimSpace(addTagRequest.Name)
+
+	if addTagRequest.Name == "" {
+		return nil, nil, errs.ErrTransactionTagNameIsEmpty
+	}
+
+	if utf8.RuneCountInString(addTagRequest.Name) > 64 {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	uid := user.Uid
+	var tag *models.TransactionTag
+
+	if currentConfig.EnableDuplicateSubmissionsCheck && addTagRequest.ClientSessionId != "" {
+		found, remark := services.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TAG, uid, addTagRequest.ClientSessionId)
+
+		if found {
+			log.Infof(c, "[add_tag.Handle] another tag \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
+			tagId, err := utils.StringToInt64(remark)
+
+			if err == nil {
+				tag, err = services.GetTransactionTagService().GetTagByTagId(c, uid, tagId)
+
+				if err != nil {
+					log.Errorf(c, "[add_tag.Handle] failed to get existed tag \"id:%d\" for user \"uid:%d\", because %s", tagId, uid, err.Error())
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	if tag == nil {
+		maxOrderId, err := services.GetTransactionTagService().GetMaxDisplayOrder(c, uid, 0)
+
+		if err != nil {
+			log.Errorf(c, "[add_tag.Handle] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, nil, err
+		}
+
+		tag = &models.TransactionTag{
+			Uid:          uid,
+			TagGroupId:   0,
+			Name:         addTagRequest.Name,
+			DisplayOrder: maxOrderId + 1,
+		}
+
+		err = services.GetTransactionTagService().CreateTag(c, tag)
+
+		if err != nil {
+			log.Errorf(c, "[add_tag.Handle] failed to create tag \"id:%d\" for user \"uid:%d\", because %s", tag.TagId, uid, err.Error())
+			return nil, nil, err
+		}
+
+		log.Infof(c, "[add_tag.Handle] user \"uid:%d\" has created a new tag \"id:%d\" successfully", uid, tag.TagId)
+
+		services.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TAG, uid, addTagRequest.ClientSessionId, utils.Int64ToString(tag.TagId))
+	}
+
+	response := MCPAddTransactionTagResponse{
+		Success: true,
+		TagId:   utils.Int64ToString(tag.TagId),
+		Name:    tag.Name,
+	}
+
+	content, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, []*MCPTextContent{
+		NewMCPTextContent(string(content)),
+	}, nil
+}
@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+func TestAddTransactionTagToolHandler_InvalidArguments(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := callMCPTestTool(c, MCPAddTransactionTagToolHandler.Name(), "", user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionTagToolHandler.Name(), `{"name":1}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission.Code(), err.(*errs.Error).Code())
+
+	_, err = callMCPTestTool(c, MCPAddTransactionTagToolHandler.Name(), `{"name":"   "}`, user, config)
+	assert.Equal(t, errs.ErrTransactionTagNameIsEmpty, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionTagToolHandler.Name(), `{"name":"`+strings.Repeat("a", 65)+`"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPAddTransactionTagToolHandler.Name(), `{"name":"Travel"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionTagNameAlreadyExists, err)
+}
+
+func TestAddTransactionTagToolHandler_AddTag(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := callMCPTestTool(c, MCPAddTransactionTagToolHandler.Name(), `{"name":"  Business  "}`, user, config)
+	assert.Nil(t, err)
+
+	response := result.(MCPCallToolResponse[MCPTextContent])
+	assert.Contains(t, response.Content[0].Text, `"name":"Business"`)
+
+	tags, err := services.TransactionTags.GetAllTagsByUid(c, mcpTestUid)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(tags))
+	assert.Equal(t, "Business", tags[1].Name)
+	assert.Equal(t, int32(2), tags[1].DisplayOrder)
+}
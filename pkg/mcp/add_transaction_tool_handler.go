@@ -3,9 +3,11 @@ package mcp
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
@@ -28,6 +30,7 @@ type MCPAddTransactionRequest struct {
 	DestinationAmount      string   `json:"destination_amount,omitempty" jsonschema_description:"Destination amount for transfer transactions (optional)"`
 	Tags                   []string `json:"tags,omitempty" jsonschema_description:"List of tags associated with the transaction (optional, maximum 10 tags allowed)"`
 	Comment                string   `json:"comment,omitempty" jsonschema_description:"Transaction description"`
+	ClientSessionId        string   `json:"client_session_id,omitempty" jsonschema_description:"Unique identifier of this request, the transaction will not be created repeatedly if the same identifier is submitted again (optional)"`
 	DryRun                 bool     `json:"dry_run,omitempty" jsonschema_description:"If true, the transaction will not be saved, only validated (optional)"`
 }
 
@@ -35,6 +38,7 @@ type MCPAddTransactionRequest struct {
 type MCPAddTransactionResponse struct {
 	Success                   bool   `json:"success" jsonschema_description:"Indicates whether this operation is successful"`
 	DryRun                    bool   `json:"dry_run,omitempty" jsonschema_description:"Indicates whether this operation is a dry run (transaction not saved actually)"`
+	TransactionId             string `json:"transaction_id,omitempty" jsonschema_description:"ID of the created transaction"`
 	AccountBalance            string `json:"account_balance,omitempty" jsonschema_description:"Account balance (or outstanding balance for debt accounts) after the transaction"`
 	DestinationAccountBalance string `json:"destination_account_balance,omitempty" jsonschema_description:"Destination account balance (or outstanding balance for debt accounts) after the transaction (only for transfer transactions)"`
 }
@@ -75,6 +79,10 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
 	}
 
+	return h.addTransaction(c, &addTransactionRequest, user, currentConfig, services)
+}
+
+func (h *mcpAddTransactionToolHandler) addTransaction(c *core.WebContext, addTransactionRequest *MCPAddTransactionRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
 	if addTransactionRequest.Type == transactionTypeTransfer {
 		if addTransactionRequest.DestinationAccountName == "" || addTransactionRequest.DestinationAmount == "" {
 			return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
@@ -122,57 +130,24 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 		return nil, nil, err
 	}
 
-	var transactionCategory *models.TransactionCategory = nil
-
-	for i := 0; i < len(allCategories); i++ {
-		category := allCategories[i]
-
-		if category.Hidden || category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
-			continue
-		}
-
-		if category.Name == addTransactionRequest.SecondaryCategoryName {
-			if category.Type == models.CATEGORY_TYPE_INCOME && addTransactionRequest.Type == transactionTypeIncome {
-				transactionCategory = category
-				break
-			} else if category.Type == models.CATEGORY_TYPE_EXPENSE && addTransactionRequest.Type == transactionTypeExpense {
-				transactionCategory = category
-				break
-			} else if category.Type == models.CATEGORY_TYPE_TRANSFER && addTransactionRequest.Type == transactionTypeTransfer {
-				transactionCategory = category
-				break
-			}
-		}
-	}
+	transactionCategory := getVisibleSecondaryCategoryByName(allCategories, addTransactionRequest.SecondaryCategoryName, addTransactionRequest.Type)
 
 	if transactionCategory == nil {
 		log.Warnf(c, "[add_transaction.Handle] secondary category \"%s\" not found for user \"uid:%d\"", addTransactionRequest.SecondaryCategoryName, uid)
 		return nil, nil, errs.ErrTransactionCategoryNotFound
 	}
 
-	var tagIds []int64
+	tagIds, notFoundTagNames, err := getVisibleTagIdsByNames(c, uid, addTransactionRequest.Tags, services)
 
-	if len(addTransactionRequest.Tags) > 0 {
-		allTags, err := services.GetTransactionTagService().GetAllTagsByUid(c, uid)
-
-		if err != nil {
-			log.Warnf(c, "[add_transaction.Handle] get transaction tag ids error, because %s", err.Error())
-			return nil, nil, err
-		}
-
-		tagMaps := services.GetTransactionTagService().GetVisibleTagNameMapByList(allTags)
-		tagIds = make([]int64, 0, len(addTransactionRequest.Tags))
+	if err != nil {
+		return nil, nil, err
+	}
 
-		for _, tagName := range addTransactionRequest.Tags {
-			if tag, exists := tagMaps[tagName]; exists {
-				tagIds = append(tagIds, tag.TagId)
-			} else {
-				log.Warnf(c, "[add_transaction.Handle] transaction tag \"%s\" not found for user \"uid:%d\"", tagName, uid)
-			}
-		}
+	if len(notFoundTagNames) > 0 {
+		log.Warnf(c, "[add_transaction.Handle] transaction tags \"%s\" not found for user \"uid:%d\"", strings.Join(notFoundTagNames, ","), uid)
 	}
 
-	transaction, err := h.createNewTransactionModel(uid, &addTransactionRequest, transactionCategory.CategoryId, sourceAccount.AccountId, destinationAccountId, c.ClientIP())
+	transaction, err := h.createNewTransactionModel(uid, addTransactionRequest, transactionCategory.CategoryId, sourceAccount.AccountId, destinationAccountId, c.ClientIP())
 
 	if err != nil {
 		return nil, nil, err
@@ -185,19 +160,51 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 	}
 
 	if !addTransactionRequest.DryRun {
-		err = services.GetTransactionService().CreateTransaction(c, transaction, tagIds, nil, nil)
+		created := false
 
-		if err != nil {
-			log.Errorf(c, "[add_transaction.Handle] failed to create transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
-			return nil, nil, err
+		if currentConfig.EnableDuplicateSubmissionsCheck && addTransactionRequest.ClientSessionId != "" {
+			found, remark := services.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION, uid, addTransactionRequest.ClientSessionId)
+
+			if found {
+				log.Infof(c, "[add_transaction.Handle] another transaction \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
+				transactionId, err := utils.StringToInt64(remark)
+
+				if err == nil {
+					transaction, err = services.GetTransactionService().GetTransactionByTransactionId(c, uid, transactionId)
+
+					if err != nil {
+						log.Errorf(c, "[add_transaction.Handle] failed to get existed transaction \"id:%d\" for user \"uid:%d\", because %s", transactionId, uid, err.Error())
+						return nil, nil, err
+					}
+
+					created = true
+				}
+			}
 		}
 
-		log.Infof(c, "[add_transaction.Handle] user \"uid:%d\" has created a new transaction \"id:%d\" successfully", uid, transaction.TransactionId)
+		if !created {
+			err = services.GetTransactionService().CreateTransaction(c, transaction, tagIds, nil, nil, nil, nil)
+
+			if err != nil {
+				log.Errorf(c, "[add_transaction.Handle] failed to create transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
+				return nil, nil, err
+			}
+
+			log.Infof(c, "[add_transaction.Handle] user \"uid:%d\" has created a new transaction \"id:%d\" successfully", uid, transaction.TransactionId)
 
-		accountIds := []int64{sourceAccount.AccountId}
+			err = services.GetWebhookService().TriggerEvent(c, uid, models.WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED, models.NewWebhookTransactionEventData(transaction, tagIds))
+
+			if err != nil {
+				log.Warnf(c, "[add_transaction.Handle] failed to trigger webhook event for transaction \"id:%d\", because %s", transaction.TransactionId, err.Error())
+			}
+
+			services.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION, uid, addTransactionRequest.ClientSessionId, utils.Int64ToString(transaction.TransactionId))
+		}
+
+		accountIds := []int64{transaction.AccountId}
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-			accountIds = append(accountIds, destinationAccountId)
+			accountIds = append(accountIds, transaction.RelatedAccountId)
 		}
 
 		newAccounts, err := services.GetAccountService().GetAccountsByAccountIds(c, uid, accountIds)
@@ -308,6 +315,10 @@ func (h *mcpAddTransactionToolHandler) createNewMCPAddTransactionResponse(c *cor
 		DryRun:  dryRun,
 	}
 
+	if !dryRun {
+		response.TransactionId = utils.Int64ToString(transaction.TransactionId)
+	}
+
 	if sourceAccountInfo != nil {
 		if sourceAccountInfo.IsAsset {
 			response.AccountBalance = utils.FormatAmount(sourceAccountInfo.Balance)
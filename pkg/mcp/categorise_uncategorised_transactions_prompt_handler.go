@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+type mcpCategoriseUncategorisedTransactionsPromptHandler struct{}
+
+var MCPCategoriseUncategorisedTransactionsPromptHandler = &mcpCategoriseUncategorisedTransactionsPromptHandler{}
+
+// Name returns the name of the MCP prompt
+func (h *mcpCategoriseUncategorisedTransactionsPromptHandler) Name() string {
+	return "categorise_uncategorised_transactions"
+}
+
+// Title returns the title of the MCP prompt
+func (h *mcpCategoriseUncategorisedTransactionsPromptHandler) Title() string {
+	return "Categorise Uncategorised Transactions"
+}
+
+// Description returns the description of the MCP prompt
+func (h *mcpCategoriseUncategorisedTransactionsPromptHandler) Description() string {
+	return "Suggest proper categories for the transactions which are recorded in a catch-all category, and recategorise them after confirmation."
+}
+
+// Arguments returns the arguments of the MCP prompt
+func (h *mcpCategoriseUncategorisedTransactionsPromptHandler) Arguments() []*MCPPromptArgument {
+	return []*MCPPromptArgument{
+		{
+			Name:        "category_name",
+			Description: "The catch-all category name which the uncategorised transactions are recorded in (e.g. Other Expense)",
+			Required:    true,
+		},
+		{
+			Name:        "start_time",
+			Description: "Start time of the transactions in RFC 3339 format (optional)",
+			Required:    false,
+		},
+		{
+			Name:        "end_time",
+			Description: "End time of the transactions in RFC 3339 format (optional)",
+			Required:    false,
+		},
+	}
+}
+
+// Get processes the MCP get prompt request and returns the prompt messages
+func (h *mcpCategoriseUncategorisedTransactionsPromptHandler) Get(c *core.WebContext, getPromptReq *MCPGetPromptRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (*MCPGetPromptResponse, error) {
+	categoryName := getPromptReq.Arguments["category_name"]
+	startTime := getPromptReq.Arguments["start_time"]
+	endTime := getPromptReq.Arguments["end_time"]
+	timeRange := "of all time"
+
+	if startTime != "" {
+		if _, err := utils.ParseFromLongDateTimeWithTimezoneRFC3339Format(startTime); err != nil {
+			return nil, errs.ErrIncompleteOrIncorrectSubmission
+		}
+	}
+
+	if endTime != "" {
+		if _, err := utils.ParseFromLongDateTimeWithTimezoneRFC3339Format(endTime); err != nil {
+			return nil, errs.ErrIncompleteOrIncorrectSubmission
+		}
+	}
+
+	if startTime != "" && endTime != "" {
+		timeRange = fmt.Sprintf("from %s to %s", startTime, endTime)
+	} else if startTime != "" {
+		timeRange = fmt.Sprintf("since %s", startTime)
+	} else if endTime != "" {
+		timeRange = fmt.Sprintf("until %s", endTime)
+	}
+
+	categories, err := MCPTransactionCategoriesResourceHandler.Read(c, &MCPReadResourceRequest{URI: mcpTransactionCategoriesResourceUri}, user, currentConfig, services)
+
+	if err != nil {
+		return nil, err
+	}
+
+	instruction := fmt.Sprintf("In ezBookkeeping, I record transactions which I haven't categorised yet in the \"%s\" category. "+
+		"Please use the query_transactions tool to find my transactions in this category %s. "+
+		"My current transaction category tree is attached. "+
+		"For each transaction, suggest the most suitable secondary category of the same transaction type based on its description, amount and account, "+
+		"and list your suggestions in a table with the transaction id, time, amount, description and suggested category. "+
+		"Do not change anything until I confirm. "+
+		"After I confirm, use the modify_transaction tool to change the category of each confirmed transaction. "+
+		"If a proper category does not exist, ask me whether to create it with the add_category tool.", categoryName, timeRange)
+
+	messages := []*MCPPromptMessage{
+		NewMCPUserPromptMessage(NewMCPTextContent(instruction)),
+	}
+
+	for i := 0; i < len(categories); i++ {
+		messages = append(messages, NewMCPUserPromptMessage(NewMCPEmbeddedResource(categories[i])))
+	}
+
+	return &MCPGetPromptResponse{
+		Description: "Categorise the transactions in \"" + categoryName + "\" " + timeRange,
+		Messages:    messages,
+	}, nil
+}
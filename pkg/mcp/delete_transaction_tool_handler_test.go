@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+func TestDeleteTransactionToolHandler_InvalidArguments(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext([]core.TokenScope{core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE})
+
+	_, err := callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), "", user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), `{"id":"-1"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionIdInvalid, err)
+
+	_, err = callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), `{"id":"9999"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionNotFound, err)
+
+	_, err = callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), `{"id":"4003"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionTypeInvalid, err)
+
+	user.TransactionEditScope = models.TRANSACTION_EDIT_SCOPE_NONE
+	_, err = callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), `{"id":"4001"}`, user, config)
+	assert.Equal(t, errs.ErrCannotDeleteTransactionWithThisTransactionTime, err)
+
+	_, err = services.Transactions.GetTransactionByTransactionId(c, mcpTestUid, mcpTestExpenseTransactionId)
+	assert.Nil(t, err)
+}
+
+func TestDeleteTransactionToolHandler_AnotherUserTransaction(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext([]core.TokenScope{core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE})
+	user.Uid = mcpTestAnotherUid
+
+	_, err := callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), `{"id":"4001"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionNotFound, err)
+
+	_, err = services.Transactions.GetTransactionByTransactionId(c, mcpTestUid, mcpTestExpenseTransactionId)
+	assert.Nil(t, err)
+}
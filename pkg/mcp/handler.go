@@ -4,18 +4,29 @@ import (
 	"reflect"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 )
 
+const mcpResourceUriPrefix = "ezbookkeeping://"
+const mcpResourceJsonMimeType = "application/json"
+
 // MCPAvailableServices holds the services available for MCP tools
 type MCPAvailableServices interface {
 	GetTransactionService() *services.TransactionService
 	GetTransactionCategoryService() *services.TransactionCategoryService
 	GetTransactionTagService() *services.TransactionTagService
 	GetAccountService() *services.AccountService
+	GetTransactionPictureService() *services.TransactionPictureService
 	GetUserService() *services.UserService
+	GetWebhookService() *services.WebhookService
+	GetInsightsExplorerService() *services.InsightsExplorerService
+	GetInsightsExplorerQueryExecutor() *reports.InsightsExplorerQueryExecutor
+	GetSubmissionRemark(checkerType duplicatechecker.DuplicateCheckerType, uid int64, identification string) (bool, string)
+	SetSubmissionRemarkIfEnable(checkerType duplicatechecker.DuplicateCheckerType, uid int64, identification string, remark string)
 }
 
 // MCPToolHandler defines the MCP tool handler
@@ -35,3 +46,45 @@ type MCPToolHandler[T MCPTextContent | MCPImageContent | MCPAudioContent | MCPRe
 	// Handle processes the MCP call tool request and returns the response
 	Handle(*core.WebContext, *MCPCallToolRequest, *models.User, *settings.Config, MCPAvailableServices) (any, []*T, error)
 }
+
+// MCPResourceHandler defines the MCP resource handler
+type MCPResourceHandler[T MCPTextResourceContents | MCPBlobResourceContents] interface {
+	// Name returns the name of the MCP resource
+	Name() string
+
+	// Description returns the description of the MCP resource
+	Description() string
+
+	// MimeType returns the MIME type of the MCP resource, or empty if it depends on each resource
+	MimeType() string
+
+	// URITemplate returns the RFC 6570 uri template of the MCP resource, or empty if all resources can be listed
+	URITemplate() string
+
+	// ListResources returns the resources which can be listed directly
+	ListResources(*core.WebContext, *models.User, *settings.Config, MCPAvailableServices) ([]*MCPResource, error)
+
+	// MatchURI returns whether the uri can be read by this MCP resource handler
+	MatchURI(uri string) bool
+
+	// Read processes the MCP read resource request and returns the resource contents
+	Read(*core.WebContext, *MCPReadResourceRequest, *models.User, *settings.Config, MCPAvailableServices) ([]*T, error)
+}
+
+// MCPPromptHandler defines the MCP prompt handler
+type MCPPromptHandler interface {
+	// Name returns the name of the MCP prompt
+	Name() string
+
+	// Title returns the title of the MCP prompt
+	Title() string
+
+	// Description returns the description of the MCP prompt
+	Description() string
+
+	// Arguments returns the arguments of the MCP prompt
+	Arguments() []*MCPPromptArgument
+
+	// Get processes the MCP get prompt request and returns the prompt messages
+	Get(*core.WebContext, *MCPGetPromptRequest, *models.User, *settings.Config, MCPAvailableServices) (*MCPGetPromptResponse, error)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 )
@@ -17,7 +18,12 @@ type MCPContainer struct {
 	mcpAudioContentTools     *orderedmap.OrderedMap[string, MCPToolHandler[MCPAudioContent]]
 	mcpResourceLinkTools     *orderedmap.OrderedMap[string, MCPToolHandler[MCPResourceLink]]
 	mcpEmbeddedResourceTools *orderedmap.OrderedMap[string, MCPToolHandler[MCPEmbeddedResource]]
+	mcpToolRequiredScopes    map[string]core.TokenScope
 	mcpTools                 []*MCPTool
+	mcpTextResources         *orderedmap.OrderedMap[string, MCPResourceHandler[MCPTextResourceContents]]
+	mcpBlobResources         *orderedmap.OrderedMap[string, MCPResourceHandler[MCPBlobResourceContents]]
+	mcpResourceTemplates     []*MCPResourceTemplate
+	mcpPrompts               *orderedmap.OrderedMap[string, MCPPromptHandler]
 }
 
 // Initialize a mcp handler container singleton instance
@@ -25,17 +31,29 @@ var (
 	Container = &MCPContainer{}
 )
 
-// GetMCPTools returns the registered MCP tools
-func (c *MCPContainer) GetMCPTools() []*MCPTool {
+// GetMCPTools returns the registered MCP tools which are allowed by the scopes of current token
+func (c *MCPContainer) GetMCPTools(tokenClaims *core.UserTokenClaims) []*MCPTool {
 	if len(c.mcpTools) == 0 {
 		return nil
 	}
 
-	return c.mcpTools
+	mcpTools := make([]*MCPTool, 0, len(c.mcpTools))
+
+	for i := 0; i < len(c.mcpTools); i++ {
+		if c.isToolAllowed(c.mcpTools[i].Name, tokenClaims) {
+			mcpTools = append(mcpTools, c.mcpTools[i])
+		}
+	}
+
+	return mcpTools
 }
 
 // HandleTool returns the result of the MCP tool handler based on the tool name
 func (c *MCPContainer) HandleTool(ctx *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, error) {
+	if !c.isToolAllowed(callToolReq.Name, ctx.GetTokenClaims()) {
+		return nil, errs.ErrMCPToolNotAllowedByTokenScope
+	}
+
 	if handler, exists := c.mcpTextContentTools.Get(callToolReq.Name); exists {
 		return handleTool(ctx, handler, currentConfig, services, callToolReq, user)
 	}
@@ -59,6 +77,106 @@ func (c *MCPContainer) HandleTool(ctx *core.WebContext, callToolReq *MCPCallTool
 	return nil, errs.ErrApiNotFound
 }
 
+// GetMCPResources returns the resources of all registered MCP resource handlers which can be listed directly
+func (c *MCPContainer) GetMCPResources(ctx *core.WebContext, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPResource, error) {
+	resources := make([]*MCPResource, 0)
+
+	for pair := c.mcpTextResources.Oldest(); pair != nil; pair = pair.Next() {
+		handlerResources, err := pair.Value.ListResources(ctx, user, currentConfig, services)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, handlerResources...)
+	}
+
+	for pair := c.mcpBlobResources.Oldest(); pair != nil; pair = pair.Next() {
+		handlerResources, err := pair.Value.ListResources(ctx, user, currentConfig, services)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, handlerResources...)
+	}
+
+	return resources, nil
+}
+
+// GetMCPResourceTemplates returns the resource templates of all registered MCP resource handlers
+func (c *MCPContainer) GetMCPResourceTemplates() []*MCPResourceTemplate {
+	return c.mcpResourceTemplates
+}
+
+// ReadResource returns the resource contents of the MCP resource handler based on the resource uri
+func (c *MCPContainer) ReadResource(ctx *core.WebContext, readResourceReq *MCPReadResourceRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, error) {
+	for pair := c.mcpTextResources.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.MatchURI(readResourceReq.URI) {
+			return readResource(ctx, pair.Value, currentConfig, services, readResourceReq, user)
+		}
+	}
+
+	for pair := c.mcpBlobResources.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.MatchURI(readResourceReq.URI) {
+			return readResource(ctx, pair.Value, currentConfig, services, readResourceReq, user)
+		}
+	}
+
+	return nil, errs.ErrMCPResourceNotFound
+}
+
+// GetMCPPrompts returns the registered MCP prompts
+func (c *MCPContainer) GetMCPPrompts() []*MCPPrompt {
+	prompts := make([]*MCPPrompt, 0, c.mcpPrompts.Len())
+
+	for pair := c.mcpPrompts.Oldest(); pair != nil; pair = pair.Next() {
+		prompts = append(prompts, &MCPPrompt{
+			Name:        pair.Value.Name(),
+			Title:       pair.Value.Title(),
+			Description: pair.Value.Description(),
+			Arguments:   pair.Value.Arguments(),
+		})
+	}
+
+	return prompts
+}
+
+// GetPrompt returns the prompt messages of the MCP prompt handler based on the prompt name
+func (c *MCPContainer) GetPrompt(ctx *core.WebContext, getPromptReq *MCPGetPromptRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, error) {
+	handler, exists := c.mcpPrompts.Get(getPromptReq.Name)
+
+	if !exists {
+		return nil, errs.ErrMCPPromptNotFound
+	}
+
+	arguments := handler.Arguments()
+
+	for i := 0; i < len(arguments); i++ {
+		if arguments[i].Required && getPromptReq.Arguments[arguments[i].Name] == "" {
+			return nil, errs.ErrIncompleteOrIncorrectSubmission
+		}
+	}
+
+	getPromptResp, err := handler.Get(ctx, getPromptReq, user, currentConfig, services)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return getPromptResp, nil
+}
+
+func (c *MCPContainer) isToolAllowed(name string, tokenClaims *core.UserTokenClaims) bool {
+	requiredScope, exists := c.mcpToolRequiredScopes[name]
+
+	if !exists {
+		return true
+	}
+
+	return tokenClaims != nil && tokenClaims.HasScope(requiredScope)
+}
+
 // InitializeMCPHandlers initializes the all mcp handlers according to the config
 func InitializeMCPHandlers(config *settings.Config) error {
 	container := &MCPContainer{
@@ -67,16 +185,40 @@ func InitializeMCPHandlers(config *settings.Config) error {
 		mcpAudioContentTools:     orderedmap.New[string, MCPToolHandler[MCPAudioContent]](),
 		mcpResourceLinkTools:     orderedmap.New[string, MCPToolHandler[MCPResourceLink]](),
 		mcpEmbeddedResourceTools: orderedmap.New[string, MCPToolHandler[MCPEmbeddedResource]](),
+		mcpToolRequiredScopes:    make(map[string]core.TokenScope),
 		mcpTools:                 make([]*MCPTool, 0),
+		mcpTextResources:         orderedmap.New[string, MCPResourceHandler[MCPTextResourceContents]](),
+		mcpBlobResources:         orderedmap.New[string, MCPResourceHandler[MCPBlobResourceContents]](),
+		mcpResourceTemplates:     make([]*MCPResourceTemplate, 0),
+		mcpPrompts:               orderedmap.New[string, MCPPromptHandler](),
 	}
 
 	registerMCPTextContentToolHandler(container, MCPAddTransactionToolHandler)
+	registerMCPTextContentToolHandler(container, MCPTransferBetweenAccountsToolHandler)
+	registerDestructiveMCPTextContentToolHandler(container, MCPModifyTransactionToolHandler)
+	registerDestructiveMCPTextContentToolHandler(container, MCPDeleteTransactionToolHandler)
+	registerMCPTextContentToolHandler(container, MCPAddTransactionCategoryToolHandler)
+	registerMCPTextContentToolHandler(container, MCPAddTransactionTagToolHandler)
 	registerMCPTextContentToolHandler(container, MCPQueryTransactionsToolHandler)
+	registerMCPTextContentToolHandler(container, MCPQueryTransactionStatisticsToolHandler)
 	registerMCPTextContentToolHandler(container, MCPQueryAllAccountsToolHandler)
 	registerMCPTextContentToolHandler(container, MCPQueryAllAccountsBalanceToolHandler)
 	registerMCPTextContentToolHandler(container, MCPQueryAllTransactionCategoriesToolHandler)
 	registerMCPTextContentToolHandler(container, MCPQueryAllTransactionTagsToolHandler)
 	registerMCPTextContentToolHandler(container, MCPQueryLatestExchangeRatesToolHandler)
+	registerMCPTextContentToolHandler(container, MCPQueryAllInsightsExplorersToolHandler)
+	registerMCPTextContentToolHandler(container, MCPQueryInsightsExplorerToolHandler)
+
+	registerMCPTextResourceHandler(container, MCPAccountsResourceHandler)
+	registerMCPTextResourceHandler(container, MCPTransactionCategoriesResourceHandler)
+	registerMCPTextResourceHandler(container, MCPMonthlySummaryResourceHandler)
+
+	if config.EnableTransactionPictures {
+		registerMCPBlobResourceHandler(container, MCPTransactionPictureResourceHandler)
+	}
+
+	registerMCPPromptHandler(container, MCPMonthlySpendingReviewPromptHandler)
+	registerMCPPromptHandler(container, MCPCategoriseUncategorisedTransactionsPromptHandler)
 
 	Container = container
 	return nil
@@ -86,6 +228,11 @@ func registerMCPTextContentToolHandler(c *MCPContainer, handler MCPToolHandler[M
 	registerMCPToolHandler(c, c.mcpTextContentTools, handler)
 }
 
+func registerDestructiveMCPTextContentToolHandler(c *MCPContainer, handler MCPToolHandler[MCPTextContent]) {
+	registerMCPToolHandler(c, c.mcpTextContentTools, handler)
+	c.mcpToolRequiredScopes[handler.Name()] = core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE
+}
+
 func registerMCPImageContentToolHandler(c *MCPContainer, handler MCPToolHandler[MCPImageContent]) {
 	registerMCPToolHandler(c, c.mcpImageContentTools, handler)
 }
@@ -111,6 +258,39 @@ func registerMCPToolHandler[T MCPTextContent | MCPImageContent | MCPAudioContent
 	c.mcpTools = append(c.mcpTools, createNewMCPToolInfo(handler.Name(), handler))
 }
 
+func registerMCPTextResourceHandler(c *MCPContainer, handler MCPResourceHandler[MCPTextResourceContents]) {
+	registerMCPResourceHandler(c, c.mcpTextResources, handler)
+}
+
+func registerMCPBlobResourceHandler(c *MCPContainer, handler MCPResourceHandler[MCPBlobResourceContents]) {
+	registerMCPResourceHandler(c, c.mcpBlobResources, handler)
+}
+
+func registerMCPResourceHandler[T MCPTextResourceContents | MCPBlobResourceContents](c *MCPContainer, mcpResourceHandlerMap *orderedmap.OrderedMap[string, MCPResourceHandler[T]], handler MCPResourceHandler[T]) {
+	if _, exists := mcpResourceHandlerMap.Get(handler.Name()); exists {
+		return
+	}
+
+	mcpResourceHandlerMap.Set(handler.Name(), handler)
+
+	if handler.URITemplate() != "" {
+		c.mcpResourceTemplates = append(c.mcpResourceTemplates, &MCPResourceTemplate{
+			URITemplate: handler.URITemplate(),
+			Name:        handler.Name(),
+			MimeType:    handler.MimeType(),
+			Description: handler.Description(),
+		})
+	}
+}
+
+func registerMCPPromptHandler(c *MCPContainer, handler MCPPromptHandler) {
+	if _, exists := c.mcpPrompts.Get(handler.Name()); exists {
+		return
+	}
+
+	c.mcpPrompts.Set(handler.Name(), handler)
+}
+
 func handleTool[T MCPTextContent | MCPImageContent | MCPAudioContent | MCPResourceLink | MCPEmbeddedResource](ctx *core.WebContext, handler MCPToolHandler[T], currentConfig *settings.Config, services MCPAvailableServices, callToolReq *MCPCallToolRequest, user *models.User) (any, error) {
 	structuredResponse, result, err := handler.Handle(ctx, callToolReq, user, currentConfig, services)
 
@@ -130,6 +310,20 @@ func handleTool[T MCPTextContent | MCPImageContent | MCPAudioContent | MCPResour
 	return callToolResp, nil
 }
 
+func readResource[T MCPTextResourceContents | MCPBlobResourceContents](ctx *core.WebContext, handler MCPResourceHandler[T], currentConfig *settings.Config, services MCPAvailableServices, readResourceReq *MCPReadResourceRequest, user *models.User) (any, error) {
+	contents, err := handler.Read(ctx, readResourceReq, user, currentConfig, services)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	readResourceResp := MCPReadResourceResponse[T]{
+		Contents: contents,
+	}
+
+	return readResourceResp, nil
+}
+
 func createNewMCPToolInfo[T MCPTextContent | MCPImageContent | MCPAudioContent | MCPResourceLink | MCPEmbeddedResource](name string, handler MCPToolHandler[T]) *MCPTool {
 	mcpTool := &MCPTool{
 		Name:        name,
@@ -164,3 +358,52 @@ func createNewMCPToolInfo[T MCPTextContent | MCPImageContent | MCPAudioContent |
 
 	return mcpTool
 }
+
+func getVisibleSecondaryCategoryByName(allCategories []*models.TransactionCategory, secondaryCategoryName string, transactionType string) *models.TransactionCategory {
+	for i := 0; i < len(allCategories); i++ {
+		category := allCategories[i]
+
+		if category.Hidden || category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+			continue
+		}
+
+		if category.Name == secondaryCategoryName {
+			if category.Type == models.CATEGORY_TYPE_INCOME && transactionType == transactionTypeIncome {
+				return category
+			} else if category.Type == models.CATEGORY_TYPE_EXPENSE && transactionType == transactionTypeExpense {
+				return category
+			} else if category.Type == models.CATEGORY_TYPE_TRANSFER && transactionType == transactionTypeTransfer {
+				return category
+			}
+		}
+	}
+
+	return nil
+}
+
+func getVisibleTagIdsByNames(c *core.WebContext, uid int64, tagNames []string, services MCPAvailableServices) ([]int64, []string, error) {
+	if len(tagNames) < 1 {
+		return nil, nil, nil
+	}
+
+	allTags, err := services.GetTransactionTagService().GetAllTagsByUid(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[mcp_container.getVisibleTagIdsByNames] get transaction tag ids error, because %s", err.Error())
+		return nil, nil, err
+	}
+
+	tagMaps := services.GetTransactionTagService().GetVisibleTagNameMapByList(allTags)
+	tagIds := make([]int64, 0, len(tagNames))
+	var notFoundTagNames []string
+
+	for _, tagName := range tagNames {
+		if tag, exists := tagMaps[tagName]; exists {
+			tagIds = append(tagIds, tag.TagId)
+		} else {
+			notFoundTagNames = append(notFoundTagNames, tagName)
+		}
+	}
+
+	return tagIds, notFoundTagNames, nil
+}
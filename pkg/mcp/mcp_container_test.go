@@ -0,0 +1,471 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/reports"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/storage"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const mcpTestUid = 1
+const mcpTestAnotherUid = 2
+
+const mcpTestCashAccountId = 1001
+const mcpTestBankAccountId = 1002
+const mcpTestEuroAccountId = 1003
+const mcpTestHiddenAccountId = 1004
+
+const mcpTestExpenseCategoryId = 2002
+const mcpTestTransferCategoryId = 2004
+const mcpTestIncomeCategoryId = 2006
+
+const mcpTestTagId = 3001
+
+const mcpTestExpenseTransactionId = 4001
+const mcpTestTransferTransactionId = 4002
+const mcpTestTransactionUnixTime = 1715342400 // 2024-05-10T12:00:00Z
+
+type mcpTestAvailableServices struct{}
+
+func (s *mcpTestAvailableServices) GetTransactionService() *services.TransactionService {
+	return services.Transactions
+}
+
+func (s *mcpTestAvailableServices) GetTransactionCategoryService() *services.TransactionCategoryService {
+	return services.TransactionCategories
+}
+
+func (s *mcpTestAvailableServices) GetTransactionTagService() *services.TransactionTagService {
+	return services.TransactionTags
+}
+
+func (s *mcpTestAvailableServices) GetAccountService() *services.AccountService {
+	return services.Accounts
+}
+
+func (s *mcpTestAvailableServices) GetTransactionPictureService() *services.TransactionPictureService {
+	return services.TransactionPictures
+}
+
+func (s *mcpTestAvailableServices) GetUserService() *services.UserService {
+	return services.Users
+}
+
+func (s *mcpTestAvailableServices) GetWebhookService() *services.WebhookService {
+	return services.Webhooks
+}
+
+func (s *mcpTestAvailableServices) GetInsightsExplorerService() *services.InsightsExplorerService {
+	return services.InsightsExplorers
+}
+
+func (s *mcpTestAvailableServices) GetInsightsExplorerQueryExecutor() *reports.InsightsExplorerQueryExecutor {
+	return reports.InsightsExplorerQueries
+}
+
+func (s *mcpTestAvailableServices) GetSubmissionRemark(checkerType duplicatechecker.DuplicateCheckerType, uid int64, identification string) (bool, string) {
+	return false, ""
+}
+
+func (s *mcpTestAvailableServices) SetSubmissionRemarkIfEnable(checkerType duplicatechecker.DuplicateCheckerType, uid int64, identification string, remark string) {
+}
+
+func initializeMCPTestEnvironment(t *testing.T) (*settings.Config, *models.User) {
+	gin.SetMode(gin.TestMode)
+
+	config := &settings.Config{
+		DatabaseConfig: &settings.DatabaseConfig{
+			DatabaseType:      settings.Sqlite3DbType,
+			DatabasePath:      filepath.Join(t.TempDir(), "ezbookkeeping.db"),
+			MaxOpenConnection: 2,
+		},
+		UuidGeneratorType:   settings.InternalUuidGeneratorType,
+		StorageType:         settings.LocalFileSystemObjectStorageType,
+		LocalFileSystemPath: t.TempDir(),
+
+		EnableTransactionPictures: true,
+	}
+
+	settings.SetCurrentConfig(config)
+	assert.Nil(t, datastore.InitializeDataStore(config))
+	assert.Nil(t, uuid.InitializeUuidGenerator(config))
+	assert.Nil(t, storage.InitializeStorageContainer(config))
+	assert.Nil(t, InitializeMCPHandlers(config))
+	assert.Nil(t, datastore.Container.UserDataStore.SyncStructs(new(models.Account), new(models.Transaction), new(models.TransactionCategory),
+		new(models.TransactionTagGroup), new(models.TransactionTag), new(models.TransactionTagIndex), new(models.TransactionItemIndex),
+		new(models.TransactionSplit), new(models.TransactionPictureInfo), new(models.Webhook), new(models.WebhookDelivery)))
+
+	sess := datastore.Container.UserDataStore.Choose(mcpTestUid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	_, err := sess.Insert([]*models.Account{
+		{AccountId: mcpTestCashAccountId, Uid: mcpTestUid, Category: models.ACCOUNT_CATEGORY_CASH, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Cash", DisplayOrder: 1, Currency: "USD", Balance: 10000},
+		{AccountId: mcpTestBankAccountId, Uid: mcpTestUid, Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Bank", DisplayOrder: 2, Currency: "USD", Balance: 50000},
+		{AccountId: mcpTestEuroAccountId, Uid: mcpTestUid, Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Euro Wallet", DisplayOrder: 3, Currency: "EUR", Balance: 20000},
+		{AccountId: mcpTestHiddenAccountId, Uid: mcpTestUid, Category: models.ACCOUNT_CATEGORY_CASH, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Old Wallet", DisplayOrder: 4, Currency: "USD", Hidden: true},
+	})
+	assert.Nil(t, err)
+
+	_, err = sess.Insert([]*models.TransactionCategory{
+		{CategoryId: mcpTestExpenseCategoryId - 1, Uid: mcpTestUid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: models.LevelOneTransactionCategoryParentId, Name: "Food", DisplayOrder: 1},
+		{CategoryId: mcpTestExpenseCategoryId, Uid: mcpTestUid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: mcpTestExpenseCategoryId - 1, Name: "Dining", DisplayOrder: 1},
+		{CategoryId: mcpTestTransferCategoryId - 1, Uid: mcpTestUid, Type: models.CATEGORY_TYPE_TRANSFER, ParentCategoryId: models.LevelOneTransactionCategoryParentId, Name: "Transfer", DisplayOrder: 1},
+		{CategoryId: mcpTestTransferCategoryId, Uid: mcpTestUid, Type: models.CATEGORY_TYPE_TRANSFER, ParentCategoryId: mcpTestTransferCategoryId - 1, Name: "Bank Transfer", DisplayOrder: 1},
+		{CategoryId: mcpTestIncomeCategoryId - 1, Uid: mcpTestUid, Type: models.CATEGORY_TYPE_INCOME, ParentCategoryId: models.LevelOneTransactionCategoryParentId, Name: "Salary", DisplayOrder: 1},
+		{CategoryId: mcpTestIncomeCategoryId, Uid: mcpTestUid, Type: models.CATEGORY_TYPE_INCOME, ParentCategoryId: mcpTestIncomeCategoryId - 1, Name: "Wages", DisplayOrder: 1},
+	})
+	assert.Nil(t, err)
+
+	_, err = sess.Insert(&models.TransactionTag{TagId: mcpTestTagId, Uid: mcpTestUid, Name: "Travel", DisplayOrder: 1})
+	assert.Nil(t, err)
+
+	transactionTime := utils.GetMinTransactionTimeFromUnixTime(mcpTestTransactionUnixTime)
+
+	_, err = sess.Insert([]*models.Transaction{
+		{TransactionId: mcpTestExpenseTransactionId, Uid: mcpTestUid, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: mcpTestExpenseCategoryId, AccountId: mcpTestCashAccountId, TransactionTime: transactionTime, Amount: 1234, Comment: "Lunch"},
+		{TransactionId: mcpTestTransferTransactionId, Uid: mcpTestUid, Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, CategoryId: mcpTestTransferCategoryId, AccountId: mcpTestBankAccountId, TransactionTime: transactionTime + 1000, Amount: 5000, RelatedId: mcpTestTransferTransactionId + 1, RelatedAccountId: mcpTestCashAccountId, RelatedAccountAmount: 5000},
+		{TransactionId: mcpTestTransferTransactionId + 1, Uid: mcpTestUid, Type: models.TRANSACTION_DB_TYPE_TRANSFER_IN, CategoryId: mcpTestTransferCategoryId, AccountId: mcpTestCashAccountId, TransactionTime: transactionTime + 1001, Amount: 5000, RelatedId: mcpTestTransferTransactionId, RelatedAccountId: mcpTestBankAccountId, RelatedAccountAmount: 5000},
+	})
+	assert.Nil(t, err)
+
+	user := &models.User{
+		Uid:                  mcpTestUid,
+		Username:             "mcp_test",
+		TransactionEditScope: models.TRANSACTION_EDIT_SCOPE_ALL,
+	}
+
+	return config, user
+}
+
+func createMCPTestWebContext(scopes []core.TokenScope) *core.WebContext {
+	ginContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginContext.Request = httptest.NewRequest(http.MethodPost, "/mcp", nil)
+
+	c := core.WrapWebContext(ginContext)
+	c.SetTokenClaims(&core.UserTokenClaims{
+		Uid:    mcpTestUid,
+		Type:   core.USER_TOKEN_TYPE_MCP,
+		Scopes: scopes,
+	})
+
+	return c
+}
+
+func callMCPTestTool(c *core.WebContext, name string, arguments string, user *models.User, config *settings.Config) (any, error) {
+	callToolReq := &MCPCallToolRequest{
+		Name: name,
+	}
+
+	if arguments != "" {
+		callToolReq.Arguments = json.RawMessage(arguments)
+	}
+
+	return Container.HandleTool(c, callToolReq, user, config, &mcpTestAvailableServices{})
+}
+
+func getMCPTestToolNames(tools []*MCPTool) []string {
+	names := make([]string, len(tools))
+
+	for i := 0; i < len(tools); i++ {
+		names[i] = tools[i].Name
+	}
+
+	return names
+}
+
+func TestGetMCPTools_WithoutDestructiveScope(t *testing.T) {
+	assert.Nil(t, InitializeMCPHandlers(&settings.Config{}))
+
+	toolNames := getMCPTestToolNames(Container.GetMCPTools(&core.UserTokenClaims{Uid: mcpTestUid, Type: core.USER_TOKEN_TYPE_MCP}))
+	assert.Contains(t, toolNames, MCPAddTransactionToolHandler.Name())
+	assert.Contains(t, toolNames, MCPTransferBetweenAccountsToolHandler.Name())
+	assert.NotContains(t, toolNames, MCPModifyTransactionToolHandler.Name())
+	assert.NotContains(t, toolNames, MCPDeleteTransactionToolHandler.Name())
+
+	toolNames = getMCPTestToolNames(Container.GetMCPTools(nil))
+	assert.NotContains(t, toolNames, MCPModifyTransactionToolHandler.Name())
+	assert.NotContains(t, toolNames, MCPDeleteTransactionToolHandler.Name())
+}
+
+func TestGetMCPTools_WithDestructiveScope(t *testing.T) {
+	assert.Nil(t, InitializeMCPHandlers(&settings.Config{}))
+
+	toolNames := getMCPTestToolNames(Container.GetMCPTools(&core.UserTokenClaims{Uid: mcpTestUid, Type: core.USER_TOKEN_TYPE_MCP, Scopes: []core.TokenScope{core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE}}))
+	assert.Contains(t, toolNames, MCPAddTransactionToolHandler.Name())
+	assert.Contains(t, toolNames, MCPModifyTransactionToolHandler.Name())
+	assert.Contains(t, toolNames, MCPDeleteTransactionToolHandler.Name())
+}
+
+func TestHandleTool_DestructiveToolWithoutDestructiveScope(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","comment":"Dinner"}`, user, config)
+	assert.Equal(t, errs.ErrMCPToolNotAllowedByTokenScope, err)
+
+	_, err = callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), `{"id":"4001"}`, user, config)
+	assert.Equal(t, errs.ErrMCPToolNotAllowedByTokenScope, err)
+
+	transaction, err := services.Transactions.GetTransactionByTransactionId(c, mcpTestUid, mcpTestExpenseTransactionId)
+	assert.Nil(t, err)
+	assert.Equal(t, "Lunch", transaction.Comment)
+}
+
+func TestHandleTool_DestructiveToolWithDestructiveScope(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext([]core.TokenScope{core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE})
+
+	_, err := callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","comment":"Dinner"}`, user, config)
+	assert.Nil(t, err)
+
+	transaction, err := services.Transactions.GetTransactionByTransactionId(c, mcpTestUid, mcpTestExpenseTransactionId)
+	assert.Nil(t, err)
+	assert.Equal(t, "Dinner", transaction.Comment)
+
+	_, err = callMCPTestTool(c, MCPDeleteTransactionToolHandler.Name(), `{"id":"4001"}`, user, config)
+	assert.Nil(t, err)
+
+	_, err = services.Transactions.GetTransactionByTransactionId(c, mcpTestUid, mcpTestExpenseTransactionId)
+	assert.Equal(t, errs.ErrTransactionNotFound, err)
+}
+
+func TestHandleTool_NotExistedTool(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := callMCPTestTool(c, "not_existed_tool", `{}`, user, config)
+	assert.Equal(t, errs.ErrApiNotFound, err)
+}
+
+func TestGetMCPResources(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	resources, err := Container.GetMCPResources(c, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2+mcpMonthlySummaryListedMonthCount, len(resources))
+
+	assert.Equal(t, "ezbookkeeping://accounts", resources[0].URI)
+	assert.Equal(t, MCPAccountsResourceHandler.Name(), resources[0].Name)
+	assert.Equal(t, mcpResourceJsonMimeType, resources[0].MimeType)
+
+	assert.Equal(t, "ezbookkeeping://categories", resources[1].URI)
+	assert.Equal(t, MCPTransactionCategoriesResourceHandler.Name(), resources[1].Name)
+
+	now := time.Now()
+	currentYearMonth := MCPMonthlySummaryResourceHandler.formatYearMonth(int32(now.Year()), int32(now.Month()))
+	assert.Equal(t, "ezbookkeeping://summaries/monthly/"+currentYearMonth, resources[2].URI)
+	assert.Equal(t, MCPMonthlySummaryResourceHandler.Name()+"_"+currentYearMonth, resources[2].Name)
+
+	for i := 0; i < len(resources); i++ {
+		assert.False(t, strings.HasPrefix(resources[i].URI, mcpTransactionPictureResourceUriPrefix))
+	}
+}
+
+func TestGetMCPResourceTemplates(t *testing.T) {
+	initializeMCPTestEnvironment(t)
+
+	resourceTemplates := Container.GetMCPResourceTemplates()
+	assert.Equal(t, 2, len(resourceTemplates))
+	assert.Equal(t, "ezbookkeeping://summaries/monthly/{year_month}", resourceTemplates[0].URITemplate)
+	assert.Equal(t, "ezbookkeeping://pictures/{file_name}", resourceTemplates[1].URITemplate)
+
+	assert.Nil(t, InitializeMCPHandlers(&settings.Config{EnableTransactionPictures: false}))
+
+	resourceTemplates = Container.GetMCPResourceTemplates()
+	assert.Equal(t, 1, len(resourceTemplates))
+	assert.Equal(t, "ezbookkeeping://summaries/monthly/{year_month}", resourceTemplates[0].URITemplate)
+}
+
+func TestReadResource_Accounts(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://accounts"}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response := result.(MCPReadResourceResponse[MCPTextResourceContents])
+	assert.Equal(t, 1, len(response.Contents))
+	assert.Equal(t, "ezbookkeeping://accounts", response.Contents[0].URI)
+	assert.Equal(t, mcpResourceJsonMimeType, response.Contents[0].MimeType)
+	assert.Contains(t, response.Contents[0].Text, `"Cash"`)
+	assert.Contains(t, response.Contents[0].Text, `"Euro Wallet"`)
+	assert.NotContains(t, response.Contents[0].Text, `"Old Wallet"`)
+}
+
+func TestReadResource_TransactionCategories(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://categories"}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response := result.(MCPReadResourceResponse[MCPTextResourceContents])
+	assert.Equal(t, 1, len(response.Contents))
+	assert.Contains(t, response.Contents[0].Text, `"Dining"`)
+	assert.Contains(t, response.Contents[0].Text, `"Bank Transfer"`)
+	assert.Contains(t, response.Contents[0].Text, `"Wages"`)
+}
+
+func TestReadResource_MonthlySummary(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://summaries/monthly/2024-05"}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response := result.(MCPReadResourceResponse[MCPTextResourceContents])
+	assert.Equal(t, 1, len(response.Contents))
+	assert.Equal(t, `{"month":"2024-05","totals":[{"currency":"USD","total_income":"0.00","total_expense":"12.34"}],"items":[{"type":"expense","primary_category_name":"Food","category_name":"Dining","currency":"USD","amount":"12.34"}]}`, response.Contents[0].Text)
+
+	result, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://summaries/monthly/2024-04"}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response = result.(MCPReadResourceResponse[MCPTextResourceContents])
+	assert.Equal(t, `{"month":"2024-04","totals":[],"items":[]}`, response.Contents[0].Text)
+}
+
+func TestReadResource_InvalidURI(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := Container.ReadResource(c, &MCPReadResourceRequest{URI: ""}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://not_existed"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://accounts/1001"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "https://example.com/accounts"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://summaries/monthly/2024"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://summaries/monthly/2024-13"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://summaries/monthly/0-05"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+}
+
+func TestGetMCPPrompts(t *testing.T) {
+	assert.Nil(t, InitializeMCPHandlers(&settings.Config{}))
+
+	prompts := Container.GetMCPPrompts()
+	assert.Equal(t, 2, len(prompts))
+
+	assert.Equal(t, "monthly_spending_review", prompts[0].Name)
+	assert.Equal(t, 1, len(prompts[0].Arguments))
+	assert.Equal(t, "month", prompts[0].Arguments[0].Name)
+	assert.False(t, prompts[0].Arguments[0].Required)
+
+	assert.Equal(t, "categorise_uncategorised_transactions", prompts[1].Name)
+	assert.Equal(t, 3, len(prompts[1].Arguments))
+	assert.Equal(t, "category_name", prompts[1].Arguments[0].Name)
+	assert.True(t, prompts[1].Arguments[0].Required)
+	assert.Equal(t, "start_time", prompts[1].Arguments[1].Name)
+	assert.False(t, prompts[1].Arguments[1].Required)
+	assert.Equal(t, "end_time", prompts[1].Arguments[2].Name)
+	assert.False(t, prompts[1].Arguments[2].Required)
+}
+
+func TestGetPrompt_NotExistedPrompt(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := Container.GetPrompt(c, &MCPGetPromptRequest{Name: "not_existed_prompt"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPPromptNotFound, err)
+}
+
+func TestGetPrompt_MonthlySpendingReview(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := Container.GetPrompt(c, &MCPGetPromptRequest{Name: "monthly_spending_review", Arguments: map[string]string{"month": "2024-05"}}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response := result.(*MCPGetPromptResponse)
+	assert.Equal(t, "Monthly spending review of 2024-05", response.Description)
+	assert.Equal(t, 3, len(response.Messages))
+	assert.Contains(t, response.Messages[0].Content.(*MCPTextContent).Text, "the previous month 2024-04")
+	assert.Equal(t, "ezbookkeeping://summaries/monthly/2024-05", response.Messages[1].Content.(*MCPEmbeddedResource).Resource.(*MCPTextResourceContents).URI)
+	assert.Equal(t, "ezbookkeeping://summaries/monthly/2024-04", response.Messages[2].Content.(*MCPEmbeddedResource).Resource.(*MCPTextResourceContents).URI)
+
+	result, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "monthly_spending_review", Arguments: map[string]string{"month": "2024-01"}}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response = result.(*MCPGetPromptResponse)
+	assert.Equal(t, "ezbookkeeping://summaries/monthly/2023-12", response.Messages[2].Content.(*MCPEmbeddedResource).Resource.(*MCPTextResourceContents).URI)
+
+	now := time.Now()
+	currentYearMonth := MCPMonthlySummaryResourceHandler.formatYearMonth(int32(now.Year()), int32(now.Month()))
+	result, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "monthly_spending_review"}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response = result.(*MCPGetPromptResponse)
+	assert.Equal(t, "Monthly spending review of "+currentYearMonth, response.Description)
+
+	_, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "monthly_spending_review", Arguments: map[string]string{"month": "2024/05"}}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "monthly_spending_review", Arguments: map[string]string{"month": "2024-00"}}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+}
+
+func TestGetPrompt_CategoriseUncategorised(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions", Arguments: map[string]string{"category_name": ""}}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions", Arguments: map[string]string{"category_name": "Dining", "start_time": "2024-05-01"}}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions", Arguments: map[string]string{"category_name": "Dining", "end_time": "yesterday"}}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	result, err := Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions", Arguments: map[string]string{"category_name": "Dining"}}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response := result.(*MCPGetPromptResponse)
+	assert.Equal(t, "Categorise the transactions in \"Dining\" of all time", response.Description)
+	assert.Equal(t, 2, len(response.Messages))
+	assert.Equal(t, "ezbookkeeping://categories", response.Messages[1].Content.(*MCPEmbeddedResource).Resource.(*MCPTextResourceContents).URI)
+
+	result, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions", Arguments: map[string]string{"category_name": "Dining", "start_time": "2024-05-01T00:00:00Z"}}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Categorise the transactions in \"Dining\" since 2024-05-01T00:00:00Z", result.(*MCPGetPromptResponse).Description)
+
+	result, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions", Arguments: map[string]string{"category_name": "Dining", "end_time": "2024-05-31T23:59:59Z"}}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Categorise the transactions in \"Dining\" until 2024-05-31T23:59:59Z", result.(*MCPGetPromptResponse).Description)
+
+	result, err = Container.GetPrompt(c, &MCPGetPromptRequest{Name: "categorise_uncategorised_transactions", Arguments: map[string]string{"category_name": "Dining", "start_time": "2024-05-01T00:00:00Z", "end_time": "2024-05-31T23:59:59Z"}}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Categorise the transactions in \"Dining\" from 2024-05-01T00:00:00Z to 2024-05-31T23:59:59Z", result.(*MCPGetPromptResponse).Description)
+}
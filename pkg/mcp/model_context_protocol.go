@@ -92,6 +92,21 @@ type MCPResource struct {
 	Description string `json:"description,omitempty"`
 }
 
+// MCPListResourceTemplatesResponse defines the response structure for listing resource templates in the MCP
+type MCPListResourceTemplatesResponse struct {
+	ResourceTemplates []*MCPResourceTemplate `json:"resourceTemplates"`
+	NextCursor        string                 `json:"nextCursor,omitempty"`
+}
+
+// MCPResourceTemplate defines the structure of a resource template in the MCP
+type MCPResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	MimeType    string `json:"mimeType,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
 // MCPReadResourceRequest defines the request structure for reading a resource in the MCP
 type MCPReadResourceRequest struct {
 	URI string `json:"uri"`
@@ -116,6 +131,45 @@ type MCPBlobResourceContents struct {
 	MimeType string `json:"mimeType,omitempty"`
 }
 
+// MCPListPromptsResponse defines the response structure for listing prompts in the MCP
+type MCPListPromptsResponse struct {
+	Prompts    []*MCPPrompt `json:"prompts"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// MCPPrompt defines the structure of a prompt in the MCP
+type MCPPrompt struct {
+	Name        string               `json:"name"`
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Arguments   []*MCPPromptArgument `json:"arguments,omitempty"`
+}
+
+// MCPPromptArgument defines the structure of a prompt argument in the MCP
+type MCPPromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// MCPGetPromptRequest defines the request structure for getting a prompt in the MCP
+type MCPGetPromptRequest struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// MCPGetPromptResponse defines the response structure for getting a prompt in the MCP
+type MCPGetPromptResponse struct {
+	Description string              `json:"description,omitempty"`
+	Messages    []*MCPPromptMessage `json:"messages"`
+}
+
+// MCPPromptMessage defines the structure of a prompt message in the MCP
+type MCPPromptMessage struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
 // MCPListToolsResponse defines the response structure for listing tools in the MCP
 type MCPListToolsResponse struct {
 	Tools      []*MCPTool `json:"tools"`
@@ -216,6 +270,32 @@ func NewMCPResourceLink(uri string, name string) *MCPResourceLink {
 	}
 }
 
+// NewMCPTextResourceContents creates a new instance of MCPTextResourceContents with the given uri, text and MIME type
+func NewMCPTextResourceContents(uri string, text string, mimeType string) *MCPTextResourceContents {
+	return &MCPTextResourceContents{
+		URI:      uri,
+		Text:     text,
+		MimeType: mimeType,
+	}
+}
+
+// NewMCPBlobResourceContents creates a new instance of MCPBlobResourceContents with the given uri, data and MIME type
+func NewMCPBlobResourceContents(uri string, data []byte, mimeType string) *MCPBlobResourceContents {
+	return &MCPBlobResourceContents{
+		URI:      uri,
+		Blob:     base64.StdEncoding.EncodeToString(data),
+		MimeType: mimeType,
+	}
+}
+
+// NewMCPUserPromptMessage creates a new instance of MCPPromptMessage sent by user with the given content
+func NewMCPUserPromptMessage(content any) *MCPPromptMessage {
+	return &MCPPromptMessage{
+		Role:    "user",
+		Content: content,
+	}
+}
+
 // NewMCPEmbeddedResource creates a new instance of MCPEmbeddedResource with the given resource
 func NewMCPEmbeddedResource[T MCPTextResourceContents | MCPBlobResourceContents](resource *T) *MCPEmbeddedResource {
 	return &MCPEmbeddedResource{
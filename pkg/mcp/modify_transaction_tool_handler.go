@@ -0,0 +1,303 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MCPModifyTransactionRequest represents all parameters of the modify transaction request
+type MCPModifyTransactionRequest struct {
+	Id                     string    `json:"id" jsonschema_description:"ID of the transaction to modify (returned by query_transactions or add_transaction)"`
+	Time                   string    `json:"time,omitempty" jsonschema:"format=date-time" jsonschema_description:"New transaction time in RFC 3339 format (e.g. 2023-01-01T12:00:00Z) (optional, leave empty to keep unchanged)"`
+	SecondaryCategoryName  string    `json:"category_name,omitempty" jsonschema_description:"New secondary category name for the transaction (optional, leave empty to keep unchanged)"`
+	AccountName            string    `json:"account_name,omitempty" jsonschema_description:"New account name for the transaction (optional, leave empty to keep unchanged)"`
+	Amount                 string    `json:"amount,omitempty" jsonschema_description:"New transaction amount (optional, leave empty to keep unchanged)"`
+	DestinationAccountName string    `json:"destination_account_name,omitempty" jsonschema_description:"New destination account name for transfer transactions (optional, leave empty to keep unchanged)"`
+	DestinationAmount      string    `json:"destination_amount,omitempty" jsonschema_description:"New destination amount for transfer transactions (optional, leave empty to keep unchanged)"`
+	Tags                   *[]string `json:"tags,omitempty" jsonschema_description:"New list of tags associated with the transaction, which replaces all existing tags, all tags must already exist (optional, omit to keep unchanged, maximum 10 tags allowed)"`
+	Comment                *string   `json:"comment,omitempty" jsonschema_description:"New transaction description (optional, omit to keep unchanged)"`
+	DryRun                 bool      `json:"dry_run,omitempty" jsonschema_description:"If true, the transaction will not be saved, only validated (optional)"`
+}
+
+// MCPModifyTransactionResponse represents the response structure for modify transaction
+type MCPModifyTransactionResponse struct {
+	Success     bool                `json:"success" jsonschema_description:"Indicates whether this operation is successful"`
+	DryRun      bool                `json:"dry_run,omitempty" jsonschema_description:"Indicates whether this operation is a dry run (transaction not saved actually)"`
+	Transaction *MCPTransactionInfo `json:"transaction" jsonschema_description:"Transaction information after modification"`
+}
+
+type mcpModifyTransactionToolHandler struct{}
+
+var MCPModifyTransactionToolHandler = &mcpModifyTransactionToolHandler{}
+
+// Name returns the name of the MCP tool
+func (h *mcpModifyTransactionToolHandler) Name() string {
+	return "modify_transaction"
+}
+
+// Description returns the description of the MCP tool
+func (h *mcpModifyTransactionToolHandler) Description() string {
+	return "Modify an existing income, expense or transfer transaction in ezBookkeeping."
+}
+
+// InputType returns the input type for the MCP tool request
+func (h *mcpModifyTransactionToolHandler) InputType() reflect.Type {
+	return reflect.TypeOf(&MCPModifyTransactionRequest{})
+}
+
+// OutputType returns the output type for the MCP tool response
+func (h *mcpModifyTransactionToolHandler) OutputType() reflect.Type {
+	return reflect.TypeOf(&MCPModifyTransactionResponse{})
+}
+
+// Handle processes the MCP call tool request and returns the response
+func (h *mcpModifyTransactionToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	var modifyTransactionRequest MCPModifyTransactionRequest
+
+	if callToolReq.Arguments != nil {
+		if err := json.Unmarshal(callToolReq.Arguments, &modifyTransactionRequest); err != nil {
+			return nil, nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+		}
+	} else {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	transactionId, err := utils.StringToInt64(modifyTransactionRequest.Id)
+
+	if err != nil || transactionId <= 0 {
+		return nil, nil, errs.ErrTransactionIdInvalid
+	}
+
+	if modifyTransactionRequest.Tags != nil && len(*modifyTransactionRequest.Tags) > models.MaximumTagsCountOfTransaction {
+		return nil, nil, errs.ErrTransactionHasTooManyTags
+	}
+
+	uid := user.Uid
+	transaction, err := services.GetTransactionService().GetTransactionByTransactionId(c, uid, transactionId)
+
+	if err != nil {
+		log.Warnf(c, "[modify_transaction.Handle] failed to get transaction \"id:%d\" for user \"uid:%d\", because %s", transactionId, uid, err.Error())
+		return nil, nil, err
+	}
+
+	transactionType := ""
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+		transactionType = transactionTypeIncome
+	} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+		transactionType = transactionTypeExpense
+	} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		transactionType = transactionTypeTransfer
+	} else {
+		log.Warnf(c, "[modify_transaction.Handle] cannot modify transaction \"id:%d\" for user \"uid:%d\", because transaction type is %d", transactionId, uid, transaction.Type)
+		return nil, nil, errs.ErrTransactionTypeInvalid
+	}
+
+	newTransaction := &models.Transaction{
+		TransactionId:        transaction.TransactionId,
+		Uid:                  uid,
+		CategoryId:           transaction.CategoryId,
+		TransactionTime:      transaction.TransactionTime,
+		TimezoneUtcOffset:    transaction.TimezoneUtcOffset,
+		AccountId:            transaction.AccountId,
+		Amount:               transaction.Amount,
+		RelatedAccountId:     transaction.RelatedAccountId,
+		RelatedAccountAmount: transaction.RelatedAccountAmount,
+		HideAmount:           transaction.HideAmount,
+		Comment:              transaction.Comment,
+		GeoLongitude:         transaction.GeoLongitude,
+		GeoLatitude:          transaction.GeoLatitude,
+		UpdatedByUid:         c.GetCurrentOperatorUid(),
+	}
+
+	if modifyTransactionRequest.Time != "" {
+		transactionTime, err := utils.ParseFromLongDateTimeWithTimezoneRFC3339Format(modifyTransactionRequest.Time)
+
+		if err != nil {
+			return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+		}
+
+		newTransaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(transactionTime.Unix())
+		newTransaction.TimezoneUtcOffset = utils.GetTimezoneOffsetMinutes(transactionTime.Unix(), transactionTime.Location())
+	}
+
+	if modifyTransactionRequest.Amount != "" {
+		newTransaction.Amount, err = utils.ParseAmount(modifyTransactionRequest.Amount)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if modifyTransactionRequest.Comment != nil {
+		newTransaction.Comment = *modifyTransactionRequest.Comment
+	}
+
+	allAccounts, err := services.GetAccountService().GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[modify_transaction.Handle] get account error, because %s", err.Error())
+		return nil, nil, err
+	}
+
+	accountsMap := services.GetAccountService().GetVisibleAccountNameMapByList(allAccounts)
+
+	if modifyTransactionRequest.AccountName != "" {
+		sourceAccount, exists := accountsMap[modifyTransactionRequest.AccountName]
+
+		if !exists {
+			log.Warnf(c, "[modify_transaction.Handle] source account \"%s\" not found for user \"uid:%d\"", modifyTransactionRequest.AccountName, uid)
+			return nil, nil, errs.ErrSourceAccountNotFound
+		}
+
+		newTransaction.AccountId = sourceAccount.AccountId
+	}
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		if modifyTransactionRequest.DestinationAccountName != "" {
+			destinationAccount, exists := accountsMap[modifyTransactionRequest.DestinationAccountName]
+
+			if !exists {
+				log.Warnf(c, "[modify_transaction.Handle] destination account \"%s\" not found for user \"uid:%d\"", modifyTransactionRequest.DestinationAccountName, uid)
+				return nil, nil, errs.ErrDestinationAccountNotFound
+			}
+
+			newTransaction.RelatedAccountId = destinationAccount.AccountId
+		}
+
+		if modifyTransactionRequest.DestinationAmount != "" {
+			newTransaction.RelatedAccountAmount, err = utils.ParseAmount(modifyTransactionRequest.DestinationAmount)
+
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if newTransaction.AccountId == newTransaction.RelatedAccountId {
+			return nil, nil, errs.ErrTransactionSourceAndDestinationIdCannotBeEqual
+		}
+	} else if modifyTransactionRequest.DestinationAccountName != "" {
+		return nil, nil, errs.ErrTransactionDestinationAccountCannotBeSet
+	} else if modifyTransactionRequest.DestinationAmount != "" {
+		return nil, nil, errs.ErrTransactionDestinationAmountCannotBeSet
+	}
+
+	allCategories, err := services.GetTransactionCategoryService().GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Warnf(c, "[modify_transaction.Handle] get transaction category error, because %s", err.Error())
+		return nil, nil, err
+	}
+
+	if modifyTransactionRequest.SecondaryCategoryName != "" {
+		transactionCategory := getVisibleSecondaryCategoryByName(allCategories, modifyTransactionRequest.SecondaryCategoryName, transactionType)
+
+		if transactionCategory == nil {
+			log.Warnf(c, "[modify_transaction.Handle] secondary category \"%s\" not found for user \"uid:%d\"", modifyTransactionRequest.SecondaryCategoryName, uid)
+			return nil, nil, errs.ErrTransactionCategoryNotFound
+		}
+
+		newTransaction.CategoryId = transactionCategory.CategoryId
+	}
+
+	allTransactionTagIds, err := services.GetTransactionTagService().GetAllTagIdsOfTransactions(c, uid, []int64{transaction.TransactionId})
+
+	if err != nil {
+		log.Errorf(c, "[modify_transaction.Handle] failed to get transactions tag ids for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, nil, err
+	}
+
+	transactionTagIds := allTransactionTagIds[transaction.TransactionId]
+	tagIds := transactionTagIds
+
+	if modifyTransactionRequest.Tags != nil {
+		var notFoundTagNames []string
+		tagIds, notFoundTagNames, err = getVisibleTagIdsByNames(c, uid, *modifyTransactionRequest.Tags, services)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(notFoundTagNames) > 0 {
+			log.Warnf(c, "[modify_transaction.Handle] transaction tags \"%s\" not found for user \"uid:%d\"", strings.Join(notFoundTagNames, ","), uid)
+			return nil, nil, errs.New(errs.ErrParameterInvalid.Category, errs.ErrParameterInvalid.SubCategory, errs.ErrParameterInvalid.Index, errs.ErrParameterInvalid.HttpStatusCode,
+				fmt.Sprintf("transaction tags not found: %s", strings.Join(notFoundTagNames, ", ")))
+		}
+	}
+
+	if newTransaction.CategoryId == transaction.CategoryId &&
+		utils.GetUnixTimeFromTransactionTime(newTransaction.TransactionTime) == utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime) &&
+		newTransaction.TimezoneUtcOffset == transaction.TimezoneUtcOffset &&
+		newTransaction.AccountId == transaction.AccountId &&
+		newTransaction.Amount == transaction.Amount &&
+		newTransaction.RelatedAccountId == transaction.RelatedAccountId &&
+		newTransaction.RelatedAccountAmount == transaction.RelatedAccountAmount &&
+		newTransaction.Comment == transaction.Comment &&
+		utils.Int64SliceEquals(tagIds, transactionTagIds) {
+		return nil, nil, errs.ErrNothingWillBeUpdated
+	}
+
+	transactionEditable := user.CanEditTransactionByTransactionTime(transaction.TransactionTime, time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60))
+	newTransactionEditable := user.CanEditTransactionByTransactionTime(newTransaction.TransactionTime, time.FixedZone("Transaction Timezone", int(newTransaction.TimezoneUtcOffset)*60))
+
+	if !transactionEditable || !newTransactionEditable {
+		return nil, nil, errs.ErrCannotModifyTransactionWithThisTransactionTime
+	}
+
+	if !modifyTransactionRequest.DryRun {
+		var addTransactionTagIds []int64
+		var removeTransactionTagIds []int64
+
+		if !utils.Int64SliceEquals(tagIds, transactionTagIds) {
+			removeTransactionTagIds = transactionTagIds
+			addTransactionTagIds = tagIds
+		}
+
+		err = services.GetTransactionService().ModifyTransaction(c, newTransaction, len(transactionTagIds), addTransactionTagIds, removeTransactionTagIds, nil, nil, nil, nil, nil, nil)
+
+		if err != nil {
+			log.Errorf(c, "[modify_transaction.Handle] failed to update transaction \"id:%d\" for user \"uid:%d\", because %s", transactionId, uid, err.Error())
+			return nil, nil, err
+		}
+
+		log.Infof(c, "[modify_transaction.Handle] user \"uid:%d\" has updated transaction \"id:%d\" successfully", uid, transactionId)
+	}
+
+	newTransaction.Type = transaction.Type
+
+	if !modifyTransactionRequest.DryRun {
+		err = services.GetWebhookService().TriggerEvent(c, uid, models.WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED, models.NewWebhookTransactionEventData(newTransaction, tagIds))
+
+		if err != nil {
+			log.Warnf(c, "[modify_transaction.Handle] failed to trigger webhook event for transaction \"id:%d\", because %s", transactionId, err.Error())
+		}
+	}
+
+	transactionInfo := newMCPTransactionInfo(newTransaction, services.GetAccountService().GetAccountMapByList(allAccounts), services.GetTransactionCategoryService().GetCategoryMapByList(allCategories), nil)
+
+	response := MCPModifyTransactionResponse{
+		Success:     true,
+		DryRun:      modifyTransactionRequest.DryRun,
+		Transaction: transactionInfo,
+	}
+
+	content, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, []*MCPTextContent{
+		NewMCPTextContent(string(content)),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+func TestModifyTransactionToolHandler_InvalidArguments(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext([]core.TokenScope{core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE})
+
+	_, err := callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), "", user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":4001}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission.Code(), err.(*errs.Error).Code())
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"abc"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionIdInvalid, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"0"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionIdInvalid, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","tags":["1","2","3","4","5","6","7","8","9","10","11"]}`, user, config)
+	assert.Equal(t, errs.ErrTransactionHasTooManyTags, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"9999","comment":"Dinner"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionNotFound, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4003","comment":"Dinner"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionTypeInvalid, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","time":"2024-05-10"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","amount":"abc"}`, user, config)
+	assert.NotNil(t, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","account_name":"Not Existed"}`, user, config)
+	assert.Equal(t, errs.ErrSourceAccountNotFound, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","account_name":"Old Wallet"}`, user, config)
+	assert.Equal(t, errs.ErrSourceAccountNotFound, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","destination_account_name":"Bank"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionDestinationAccountCannotBeSet, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","destination_amount":"1.00"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionDestinationAmountCannotBeSet, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4002","destination_account_name":"Bank"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionSourceAndDestinationIdCannotBeEqual, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","category_name":"Bank Transfer"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionCategoryNotFound, err)
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","tags":["Travel","Not Existed","Unknown"]}`, user, config)
+	assert.Equal(t, errs.ErrParameterInvalid.Code(), err.(*errs.Error).Code())
+	assert.Equal(t, "transaction tags not found: Not Existed, Unknown", err.Error())
+
+	_, err = callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","comment":"Lunch"}`, user, config)
+	assert.Equal(t, errs.ErrNothingWillBeUpdated, err)
+}
+
+func TestModifyTransactionToolHandler_DryRun(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext([]core.TokenScope{core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE})
+
+	result, err := callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","amount":"20.50","dry_run":true}`, user, config)
+	assert.Nil(t, err)
+
+	response := result.(MCPCallToolResponse[MCPTextContent])
+	assert.Equal(t, 1, len(response.Content))
+	assert.Contains(t, response.Content[0].Text, `"dry_run":true`)
+
+	transaction, err := services.Transactions.GetTransactionByTransactionId(c, mcpTestUid, mcpTestExpenseTransactionId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1234), transaction.Amount)
+}
+
+func TestModifyTransactionToolHandler_CannotModifyWithTransactionEditScope(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext([]core.TokenScope{core.USER_TOKEN_SCOPE_MCP_DESTRUCTIVE})
+	user.TransactionEditScope = models.TRANSACTION_EDIT_SCOPE_NONE
+
+	_, err := callMCPTestTool(c, MCPModifyTransactionToolHandler.Name(), `{"id":"4001","comment":"Dinner"}`, user, config)
+	assert.Equal(t, errs.ErrCannotModifyTransactionWithThisTransactionTime, err)
+}
@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+type mcpMonthlySpendingReviewPromptHandler struct{}
+
+var MCPMonthlySpendingReviewPromptHandler = &mcpMonthlySpendingReviewPromptHandler{}
+
+// Name returns the name of the MCP prompt
+func (h *mcpMonthlySpendingReviewPromptHandler) Name() string {
+	return "monthly_spending_review"
+}
+
+// Title returns the title of the MCP prompt
+func (h *mcpMonthlySpendingReviewPromptHandler) Title() string {
+	return "Monthly Spending Review"
+}
+
+// Description returns the description of the MCP prompt
+func (h *mcpMonthlySpendingReviewPromptHandler) Description() string {
+	return "Review the income and expense of a month compared with the previous month."
+}
+
+// Arguments returns the arguments of the MCP prompt
+func (h *mcpMonthlySpendingReviewPromptHandler) Arguments() []*MCPPromptArgument {
+	return []*MCPPromptArgument{
+		{
+			Name:        "month",
+			Description: "The month to review in YYYY-MM format (optional, default is the current month)",
+			Required:    false,
+		},
+	}
+}
+
+// Get processes the MCP get prompt request and returns the prompt messages
+func (h *mcpMonthlySpendingReviewPromptHandler) Get(c *core.WebContext, getPromptReq *MCPGetPromptRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (*MCPGetPromptResponse, error) {
+	now := time.Now()
+	year := int32(now.Year())
+	month := int32(now.Month())
+
+	if getPromptReq.Arguments["month"] != "" {
+		var err error
+		year, month, err = utils.ParseNumericYearMonth(getPromptReq.Arguments["month"])
+
+		if err != nil || year < 1 || month < 1 || month > 12 {
+			return nil, errs.ErrIncompleteOrIncorrectSubmission
+		}
+	}
+
+	previousYear := year
+	previousMonth := month - 1
+
+	if previousMonth < 1 {
+		previousYear--
+		previousMonth = 12
+	}
+
+	currentYearMonth := MCPMonthlySummaryResourceHandler.formatYearMonth(year, month)
+	previousYearMonth := MCPMonthlySummaryResourceHandler.formatYearMonth(previousYear, previousMonth)
+
+	currentSummary, err := MCPMonthlySummaryResourceHandler.Read(c, &MCPReadResourceRequest{URI: mcpMonthlySummaryResourceUriPrefix + currentYearMonth}, user, currentConfig, services)
+
+	if err != nil {
+		return nil, err
+	}
+
+	previousSummary, err := MCPMonthlySummaryResourceHandler.Read(c, &MCPReadResourceRequest{URI: mcpMonthlySummaryResourceUriPrefix + previousYearMonth}, user, currentConfig, services)
+
+	if err != nil {
+		return nil, err
+	}
+
+	instruction := fmt.Sprintf("Please review my spending in ezBookkeeping for %s. "+
+		"The monthly summaries of %s and the previous month %s are attached, amounts are grouped by currency and by category. "+
+		"Compare the total income and expense of each currency with the previous month, "+
+		"point out the categories with the largest expense and the largest changes, "+
+		"and give me some practical suggestions on where I could spend less. "+
+		"If you need the details of a category, use the query_transactions tool with the time range of that month.", currentYearMonth, currentYearMonth, previousYearMonth)
+
+	messages := []*MCPPromptMessage{
+		NewMCPUserPromptMessage(NewMCPTextContent(instruction)),
+	}
+
+	for i := 0; i < len(currentSummary); i++ {
+		messages = append(messages, NewMCPUserPromptMessage(NewMCPEmbeddedResource(currentSummary[i])))
+	}
+
+	for i := 0; i < len(previousSummary); i++ {
+		messages = append(messages, NewMCPUserPromptMessage(NewMCPEmbeddedResource(previousSummary[i])))
+	}
+
+	return &MCPGetPromptResponse{
+		Description: "Monthly spending review of " + currentYearMonth,
+		Messages:    messages,
+	}, nil
+}
@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const mcpMonthlySummaryResourceUriPrefix = mcpResourceUriPrefix + "summaries/monthly/"
+const mcpMonthlySummaryListedMonthCount = 12
+
+// MCPMonthlySummaryResponse represents the resource contents structure for monthly summary
+type MCPMonthlySummaryResponse struct {
+	Month  string                           `json:"month"`
+	Totals []*MCPMonthlySummaryCurrencyInfo `json:"totals"`
+	Items  []*MCPTransactionStatisticItem   `json:"items"`
+}
+
+// MCPMonthlySummaryCurrencyInfo defines the structure of monthly total income and expense in specified currency
+type MCPMonthlySummaryCurrencyInfo struct {
+	Currency     string `json:"currency"`
+	TotalIncome  string `json:"total_income"`
+	TotalExpense string `json:"total_expense"`
+}
+
+type mcpMonthlySummaryResourceHandler struct{}
+
+var MCPMonthlySummaryResourceHandler = &mcpMonthlySummaryResourceHandler{}
+
+// Name returns the name of the MCP resource
+func (h *mcpMonthlySummaryResourceHandler) Name() string {
+	return "monthly_summary"
+}
+
+// Description returns the description of the MCP resource
+func (h *mcpMonthlySummaryResourceHandler) Description() string {
+	return "Total income and expense of the current user in ezBookkeeping in the specified month (year_month format is YYYY-MM), grouped by currency and by category."
+}
+
+// MimeType returns the MIME type of the MCP resource
+func (h *mcpMonthlySummaryResourceHandler) MimeType() string {
+	return mcpResourceJsonMimeType
+}
+
+// URITemplate returns the uri template of the MCP resource
+func (h *mcpMonthlySummaryResourceHandler) URITemplate() string {
+	return mcpMonthlySummaryResourceUriPrefix + "{year_month}"
+}
+
+// ListResources returns the monthly summaries of recent months
+func (h *mcpMonthlySummaryResourceHandler) ListResources(c *core.WebContext, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPResource, error) {
+	now := time.Now()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	resources := make([]*MCPResource, 0, mcpMonthlySummaryListedMonthCount)
+
+	for i := 0; i < mcpMonthlySummaryListedMonthCount; i++ {
+		month := currentMonth.AddDate(0, -i, 0)
+		yearMonth := h.formatYearMonth(int32(month.Year()), int32(month.Month()))
+
+		resources = append(resources, &MCPResource{
+			URI:         mcpMonthlySummaryResourceUriPrefix + yearMonth,
+			Name:        h.Name() + "_" + yearMonth,
+			Title:       "Monthly Summary of " + yearMonth,
+			Description: "Total income and expense of the current user in ezBookkeeping in " + yearMonth + ", grouped by currency and by category.",
+			MimeType:    h.MimeType(),
+		})
+	}
+
+	return resources, nil
+}
+
+// MatchURI returns whether the uri can be read by this MCP resource handler
+func (h *mcpMonthlySummaryResourceHandler) MatchURI(uri string) bool {
+	return strings.HasPrefix(uri, mcpMonthlySummaryResourceUriPrefix)
+}
+
+// Read processes the MCP read resource request and returns the resource contents
+func (h *mcpMonthlySummaryResourceHandler) Read(c *core.WebContext, readResourceReq *MCPReadResourceRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPTextResourceContents, error) {
+	year, month, err := utils.ParseNumericYearMonth(strings.TrimPrefix(readResourceReq.URI, mcpMonthlySummaryResourceUriPrefix))
+
+	if err != nil || year < 1 || month < 1 || month > 12 {
+		return nil, errs.ErrMCPResourceNotFound
+	}
+
+	uid := user.Uid
+	allAccounts, err := services.GetAccountService().GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[monthly_summary_resource.Read] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	allCategories, err := services.GetTransactionCategoryService().GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[monthly_summary_resource.Read] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	monthlyAmounts, err := services.GetTransactionService().GetAccountsAndCategoriesMonthlyInflowAndOutflow(c, uid, year, month, year, month, nil, false, nil, false, "", time.UTC, true, nil)
+
+	if err != nil {
+		log.Errorf(c, "[monthly_summary_resource.Read] failed to get accounts and categories monthly inflow and outflow for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	statisticsResponse := MCPQueryTransactionStatisticsToolHandler.createNewMCPQueryTransactionStatisticsResponse(&MCPQueryTransactionStatisticsRequest{}, monthlyAmounts[year*100+month], services.GetAccountService().GetAccountMapByList(allAccounts), services.GetTransactionCategoryService().GetCategoryMapByList(allCategories))
+	response := MCPMonthlySummaryResponse{
+		Month:  h.formatYearMonth(year, month),
+		Totals: h.getCurrencyTotals(statisticsResponse.Items),
+		Items:  statisticsResponse.Items,
+	}
+
+	content, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*MCPTextResourceContents{
+		NewMCPTextResourceContents(readResourceReq.URI, string(content), h.MimeType()),
+	}, nil
+}
+
+func (h *mcpMonthlySummaryResourceHandler) getCurrencyTotals(items []*MCPTransactionStatisticItem) []*MCPMonthlySummaryCurrencyInfo {
+	totalAmounts := make(map[string][]int64)
+	currencies := make([]string, 0)
+
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+		amounts, exists := totalAmounts[item.Currency]
+
+		if !exists {
+			amounts = make([]int64, 2)
+			totalAmounts[item.Currency] = amounts
+			currencies = append(currencies, item.Currency)
+		}
+
+		if item.Type == transactionTypeIncome {
+			amounts[0] += item.amount
+		} else if item.Type == transactionTypeExpense {
+			amounts[1] += item.amount
+		}
+	}
+
+	sort.Strings(currencies)
+	totals := make([]*MCPMonthlySummaryCurrencyInfo, len(currencies))
+
+	for i := 0; i < len(currencies); i++ {
+		amounts := totalAmounts[currencies[i]]
+		totals[i] = &MCPMonthlySummaryCurrencyInfo{
+			Currency:     currencies[i],
+			TotalIncome:  utils.FormatAmount(amounts[0]),
+			TotalExpense: utils.FormatAmount(amounts[1]),
+		}
+	}
+
+	return totals
+}
+
+func (h *mcpMonthlySummaryResourceHandler) formatYearMonth(year int32, month int32) string {
+	return fmt.Sprintf("%04d-%02d", year, month)
+}
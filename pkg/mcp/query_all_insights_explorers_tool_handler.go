@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// MCPQueryAllInsightsExplorersResponse represents the response structure for querying insights explorers
+type MCPQueryAllInsightsExplorersResponse struct {
+	Explorers []string `json:"explorers" jsonschema_description:"List of saved insights explorer names"`
+}
+
+type mcpQueryAllInsightsExplorersToolHandler struct{}
+
+var MCPQueryAllInsightsExplorersToolHandler = &mcpQueryAllInsightsExplorersToolHandler{}
+
+// Name returns the name of the MCP tool
+func (h *mcpQueryAllInsightsExplorersToolHandler) Name() string {
+	return "query_all_insights_explorers"
+}
+
+// Description returns the description of the MCP tool
+func (h *mcpQueryAllInsightsExplorersToolHandler) Description() string {
+	return "Query saved insights explorers for the current user in ezBookkeeping."
+}
+
+// InputType returns the input type for the MCP tool request
+func (h *mcpQueryAllInsightsExplorersToolHandler) InputType() reflect.Type {
+	return nil
+}
+
+// OutputType returns the output type for the MCP tool response
+func (h *mcpQueryAllInsightsExplorersToolHandler) OutputType() reflect.Type {
+	return reflect.TypeOf(&MCPQueryAllInsightsExplorersResponse{})
+}
+
+// Handle processes the MCP call tool request and returns the response
+func (h *mcpQueryAllInsightsExplorersToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	uid := user.Uid
+	explorers, err := services.GetInsightsExplorerService().GetAllInsightsExplorerNamesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[query_all_insights_explorers.Handle] failed to get insights explorers for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, nil, err
+	}
+
+	sort.SliceStable(explorers, func(i, j int) bool {
+		return explorers[i].DisplayOrder < explorers[j].DisplayOrder
+	})
+
+	explorerNames := make([]string, 0, len(explorers))
+
+	for i := 0; i < len(explorers); i++ {
+		if explorers[i].Hidden {
+			continue
+		}
+
+		explorerNames = append(explorerNames, explorers[i].Name)
+	}
+
+	response := MCPQueryAllInsightsExplorersResponse{
+		Explorers: explorerNames,
+	}
+
+	content, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, []*MCPTextContent{
+		NewMCPTextContent(string(content)),
+	}, nil
+}
@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MCPQueryInsightsExplorerRequest represents all parameters of the query insights explorer request
+type MCPQueryInsightsExplorerRequest struct {
+	ExplorerName      string `json:"explorer_name" jsonschema_description:"Name of the saved insights explorer"`
+	StartTime         string `json:"start_time" jsonschema:"format=date-time" jsonschema_description:"Start time for the query in RFC 3339 format (e.g. 2023-01-01T12:00:00Z)"`
+	EndTime           string `json:"end_time" jsonschema:"format=date-time" jsonschema_description:"End time for the query in RFC 3339 format (e.g. 2023-01-31T23:59:59Z)"`
+	CategoryDimension string `json:"category_dimension,omitempty" jsonschema_description:"Dimension to group transactions by (e.g. query, primaryCategory, secondaryCategory, sourceAccount, transactionTag, transactionItem, dateTimeByYearMonth), overrides the one saved in the explorer (optional)"`
+	SeriesDimension   string `json:"series_dimension,omitempty" jsonschema_description:"Dimension to further group transactions by within each group (e.g. none, transactionType, dateTimeByYearMonth), overrides the one saved in the explorer (optional)"`
+	ValueMetric       string `json:"value_metric,omitempty" jsonschema:"enum=transactionCount,enum=sourceAmountSum,enum=sourceAmountAverage,enum=sourceAmountMedian,enum=sourceAmountMinimum,enum=sourceAmountMaximum" jsonschema_description:"Measure calculated for each group, overrides the one saved in the explorer (optional)"`
+}
+
+// MCPQueryInsightsExplorerResponse represents the response structure for querying insights explorer
+type MCPQueryInsightsExplorerResponse struct {
+	CategoryDimension string                                `json:"category_dimension" jsonschema_description:"Dimension which transactions are grouped by"`
+	SeriesDimension   string                                `json:"series_dimension" jsonschema_description:"Dimension which transactions are further grouped by within each group"`
+	ValueMetric       string                                `json:"value_metric" jsonschema_description:"Measure calculated for each group"`
+	Currency          string                                `json:"currency,omitempty" jsonschema_description:"Currency code of all the amounts (e.g. USD, EUR)"`
+	TransactionCount  int                                   `json:"transaction_count" jsonschema_description:"Count of transactions matching the explorer queries"`
+	Items             []*MCPInsightsExplorerResultGroupItem `json:"items" jsonschema_description:"Groups of transactions and their values"`
+}
+
+// MCPInsightsExplorerResultGroupItem defines the structure of insights explorer result group item
+type MCPInsightsExplorerResultGroupItem struct {
+	Name   string                                 `json:"name" jsonschema_description:"Group name"`
+	Series []*MCPInsightsExplorerResultSeriesItem `json:"series" jsonschema_description:"Values of the group, split by the series dimension"`
+}
+
+// MCPInsightsExplorerResultSeriesItem defines the structure of insights explorer result series item
+type MCPInsightsExplorerResultSeriesItem struct {
+	Name  string `json:"name" jsonschema_description:"Series name"`
+	Value string `json:"value" jsonschema_description:"Amount or transaction count of the series"`
+}
+
+type mcpQueryInsightsExplorerToolHandler struct{}
+
+var MCPQueryInsightsExplorerToolHandler = &mcpQueryInsightsExplorerToolHandler{}
+
+// Name returns the name of the MCP tool
+func (h *mcpQueryInsightsExplorerToolHandler) Name() string {
+	return "query_insights_explorer"
+}
+
+// Description returns the description of the MCP tool
+func (h *mcpQueryInsightsExplorerToolHandler) Description() string {
+	return "Run a saved insights explorer within a time range and return the aggregated values grouped by its dimensions."
+}
+
+// InputType returns the input type for the MCP tool request
+func (h *mcpQueryInsightsExplorerToolHandler) InputType() reflect.Type {
+	return reflect.TypeOf(&MCPQueryInsightsExplorerRequest{})
+}
+
+// OutputType returns the output type for the MCP tool response
+func (h *mcpQueryInsightsExplorerToolHandler) OutputType() reflect.Type {
+	return reflect.TypeOf(&MCPQueryInsightsExplorerResponse{})
+}
+
+// Handle processes the MCP call tool request and returns the response
+func (h *mcpQueryInsightsExplorerToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	var queryExplorerRequest MCPQueryInsightsExplorerRequest
+
+	if callToolReq.Arguments != nil {
+		if err := json.Unmarshal(callToolReq.Arguments, &queryExplorerRequest); err != nil {
+			return nil, nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+		}
+	} else {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	uid := user.Uid
+	maxTime, err := utils.ParseFromLongDateTimeWithTimezoneRFC3339Format(queryExplorerRequest.EndTime)
+
+	if err != nil {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	minTime, err := utils.ParseFromLongDateTimeWithTimezoneRFC3339Format(queryExplorerRequest.StartTime)
+
+	if err != nil {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	if minTime.After(maxTime) {
+		return nil, nil, errs.ErrInsightsExplorerQueryTimeRangeInvalid
+	}
+
+	explorers, err := services.GetInsightsExplorerService().GetAllInsightsExplorerNamesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[query_insights_explorer.Handle] failed to get insights explorers for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, nil, err
+	}
+
+	explorerId := int64(0)
+
+	for i := 0; i < len(explorers); i++ {
+		if explorers[i].Name == queryExplorerRequest.ExplorerName {
+			explorerId = explorers[i].ExplorerId
+			break
+		}
+	}
+
+	if explorerId == 0 {
+		return nil, nil, errs.ErrInsightsExplorerNotFound
+	}
+
+	explorer, err := services.GetInsightsExplorerService().GetInsightsExplorerByExplorerId(c, uid, explorerId)
+
+	if err != nil {
+		log.Errorf(c, "[query_insights_explorer.Handle] failed to get insights explorer \"id:%d\" for user \"uid:%d\", because %s", explorerId, uid, err.Error())
+		return nil, nil, err
+	}
+
+	definition, err := explorer.GetQueryDefinition()
+
+	if err != nil {
+		log.Warnf(c, "[query_insights_explorer.Handle] failed to parse insights explorer \"id:%d\" for user \"uid:%d\", because %s", explorerId, uid, err.Error())
+		return nil, nil, err
+	}
+
+	err = definition.SetDimensionsAndValueMetric(models.InsightsExplorerDataDimension(queryExplorerRequest.CategoryDimension), models.InsightsExplorerDataDimension(queryExplorerRequest.SeriesDimension), models.InsightsExplorerValueMetric(queryExplorerRequest.ValueMetric))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := services.GetInsightsExplorerQueryExecutor().ExecuteQuery(c, user, definition, minTime.Unix(), maxTime.Unix(), minTime.Location(), currentConfig)
+
+	if err != nil {
+		log.Errorf(c, "[query_insights_explorer.Handle] failed to execute insights explorer \"id:%d\" for user \"uid:%d\", because %s", explorerId, uid, err.Error())
+		return nil, nil, err
+	}
+
+	structuredResponse := h.createNewMCPQueryInsightsExplorerResponse(result)
+	content, err := json.Marshal(structuredResponse)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return structuredResponse, []*MCPTextContent{
+		NewMCPTextContent(string(content)),
+	}, nil
+}
+
+func (h *mcpQueryInsightsExplorerToolHandler) createNewMCPQueryInsightsExplorerResponse(result *models.InsightsExplorerQueryResultResponse) *MCPQueryInsightsExplorerResponse {
+	items := make([]*MCPInsightsExplorerResultGroupItem, len(result.Items))
+
+	for i := 0; i < len(result.Items); i++ {
+		categoryItem := result.Items[i]
+		series := make([]*MCPInsightsExplorerResultSeriesItem, len(categoryItem.Series))
+
+		for j := 0; j < len(categoryItem.Series); j++ {
+			seriesItem := categoryItem.Series[j]
+			value := utils.Int64ToString(seriesItem.Value)
+
+			if result.ValueMetric.IsAmount() {
+				value = utils.FormatAmount(seriesItem.Value)
+			}
+
+			series[j] = &MCPInsightsExplorerResultSeriesItem{
+				Name:  seriesItem.SeriesName,
+				Value: value,
+			}
+		}
+
+		items[i] = &MCPInsightsExplorerResultGroupItem{
+			Name:   categoryItem.CategoryName,
+			Series: series,
+		}
+	}
+
+	return &MCPQueryInsightsExplorerResponse{
+		CategoryDimension: string(result.CategoryDimension),
+		SeriesDimension:   string(result.SeriesDimension),
+		ValueMetric:       string(result.ValueMetric),
+		Currency:          result.Currency,
+		TransactionCount:  result.TransactionCount,
+		Items:             items,
+	}
+}
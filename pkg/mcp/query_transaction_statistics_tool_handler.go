@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MCPQueryTransactionStatisticsRequest represents all parameters of the query transaction statistics request
+type MCPQueryTransactionStatisticsRequest struct {
+	StartTime string `json:"start_time" jsonschema:"format=date-time" jsonschema_description:"Start time for the query in RFC 3339 format (e.g. 2023-01-01T12:00:00Z)"`
+	EndTime   string `json:"end_time" jsonschema:"format=date-time" jsonschema_description:"End time for the query in RFC 3339 format (e.g. 2023-01-31T23:59:59Z)"`
+	Type      string `json:"type,omitempty" jsonschema:"enum=income,enum=expense" jsonschema_description:"Transaction type to filter by (income, expense) (optional)"`
+}
+
+// MCPQueryTransactionStatisticsResponse represents the response structure for querying transaction statistics
+type MCPQueryTransactionStatisticsResponse struct {
+	Items []*MCPTransactionStatisticItem `json:"items" jsonschema_description:"Total amounts grouped by transaction type, category and currency"`
+}
+
+// MCPTransactionStatisticItem defines the structure of transaction statistic item
+type MCPTransactionStatisticItem struct {
+	Type                  string `json:"type" jsonschema:"enum=income,enum=expense" jsonschema_description:"Transaction type (income, expense)"`
+	PrimaryCategoryName   string `json:"primary_category_name,omitempty" jsonschema_description:"Primary category name"`
+	SecondaryCategoryName string `json:"category_name" jsonschema_description:"Secondary category name"`
+	Currency              string `json:"currency" jsonschema_description:"Currency code of the amount (e.g. USD, EUR)"`
+	Amount                string `json:"amount" jsonschema_description:"Total amount in the specified currency"`
+	amount                int64
+}
+
+type mcpQueryTransactionStatisticsToolHandler struct{}
+
+var MCPQueryTransactionStatisticsToolHandler = &mcpQueryTransactionStatisticsToolHandler{}
+
+// Name returns the name of the MCP tool
+func (h *mcpQueryTransactionStatisticsToolHandler) Name() string {
+	return "query_statistics"
+}
+
+// Description returns the description of the MCP tool
+func (h *mcpQueryTransactionStatisticsToolHandler) Description() string {
+	return "Query total income and expense amounts grouped by category and currency within a time range."
+}
+
+// InputType returns the input type for the MCP tool request
+func (h *mcpQueryTransactionStatisticsToolHandler) InputType() reflect.Type {
+	return reflect.TypeOf(&MCPQueryTransactionStatisticsRequest{})
+}
+
+// OutputType returns the output type for the MCP tool response
+func (h *mcpQueryTransactionStatisticsToolHandler) OutputType() reflect.Type {
+	return reflect.TypeOf(&MCPQueryTransactionStatisticsResponse{})
+}
+
+// Handle processes the MCP call tool request and returns the response
+func (h *mcpQueryTransactionStatisticsToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	var queryStatisticsRequest MCPQueryTransactionStatisticsRequest
+
+	if callToolReq.Arguments != nil {
+		if err := json.Unmarshal(callToolReq.Arguments, &queryStatisticsRequest); err != nil {
+			return nil, nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+		}
+	} else {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	if queryStatisticsRequest.Type != "" && queryStatisticsRequest.Type != transactionTypeIncome && queryStatisticsRequest.Type != transactionTypeExpense {
+		return nil, nil, errs.ErrTransactionTypeInvalid
+	}
+
+	uid := user.Uid
+	maxTime, err := utils.ParseFromLongDateTimeWithTimezoneRFC3339Format(queryStatisticsRequest.EndTime)
+
+	if err != nil {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	minTime, err := utils.ParseFromLongDateTimeWithTimezoneRFC3339Format(queryStatisticsRequest.StartTime)
+
+	if err != nil {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	allAccounts, err := services.GetAccountService().GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[query_statistics.Handle] get account error, because %s", err.Error())
+		return nil, nil, err
+	}
+
+	allCategories, err := services.GetTransactionCategoryService().GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Warnf(c, "[query_statistics.Handle] get transaction category error, because %s", err.Error())
+		return nil, nil, err
+	}
+
+	totalAmounts, err := services.GetTransactionService().GetAccountsAndCategoriesTotalInflowAndOutflow(c, uid, minTime.Unix(), maxTime.Unix(), nil, false, nil, false, "", minTime.Location(), false, nil)
+
+	if err != nil {
+		log.Errorf(c, "[query_statistics.Handle] failed to get accounts and categories total inflow and outflow for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, nil, err
+	}
+
+	structuredResponse := h.createNewMCPQueryTransactionStatisticsResponse(&queryStatisticsRequest, totalAmounts, services.GetAccountService().GetAccountMapByList(allAccounts), services.GetTransactionCategoryService().GetCategoryMapByList(allCategories))
+	content, err := json.Marshal(structuredResponse)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return structuredResponse, []*MCPTextContent{
+		NewMCPTextContent(string(content)),
+	}, nil
+}
+
+func (h *mcpQueryTransactionStatisticsToolHandler) createNewMCPQueryTransactionStatisticsResponse(request *MCPQueryTransactionStatisticsRequest, totalAmounts []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory) *MCPQueryTransactionStatisticsResponse {
+	itemsMap := make(map[string]*MCPTransactionStatisticItem)
+	items := make([]*MCPTransactionStatisticItem, 0)
+
+	for i := 0; i < len(totalAmounts); i++ {
+		totalAmount := totalAmounts[i]
+		transactionType := ""
+
+		if totalAmount.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			transactionType = transactionTypeIncome
+		} else if totalAmount.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			transactionType = transactionTypeExpense
+		} else {
+			continue
+		}
+
+		if request.Type != "" && request.Type != transactionType {
+			continue
+		}
+
+		account, exists := accountMap[totalAmount.AccountId]
+
+		if !exists {
+			continue
+		}
+
+		item := &MCPTransactionStatisticItem{
+			Type:     transactionType,
+			Currency: account.Currency,
+		}
+
+		if category, exists := categoryMap[totalAmount.CategoryId]; exists {
+			item.SecondaryCategoryName = category.Name
+
+			if primaryCategory, exists := categoryMap[category.ParentCategoryId]; exists {
+				item.PrimaryCategoryName = primaryCategory.Name
+			}
+		}
+
+		groupKey := transactionType + "_" + utils.Int64ToString(totalAmount.CategoryId) + "_" + account.Currency
+
+		if existedItem, exists := itemsMap[groupKey]; exists {
+			item = existedItem
+		} else {
+			itemsMap[groupKey] = item
+			items = append(items, item)
+		}
+
+		item.amount += totalAmount.Amount
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+
+		if items[i].Currency != items[j].Currency {
+			return items[i].Currency < items[j].Currency
+		}
+
+		return items[i].amount > items[j].amount
+	})
+
+	for i := 0; i < len(items); i++ {
+		items[i].Amount = utils.FormatAmount(items[i].amount)
+	}
+
+	return &MCPQueryTransactionStatisticsResponse{
+		Items: items,
+	}
+}
@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestQueryTransactionStatisticsToolHandler_InvalidArguments(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := callMCPTestTool(c, MCPQueryTransactionStatisticsToolHandler.Name(), "", user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPQueryTransactionStatisticsToolHandler.Name(), `{"start_time":"2024-05-01T00:00:00Z","end_time":"2024-05-31T23:59:59Z","type":"transfer"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionTypeInvalid, err)
+
+	_, err = callMCPTestTool(c, MCPQueryTransactionStatisticsToolHandler.Name(), `{"start_time":"2024-05-01T00:00:00Z"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPQueryTransactionStatisticsToolHandler.Name(), `{"start_time":"2024-05-01","end_time":"2024-05-31T23:59:59Z"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+}
+
+func TestQueryTransactionStatisticsToolHandler_QueryStatistics(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := callMCPTestTool(c, MCPQueryTransactionStatisticsToolHandler.Name(), `{"start_time":"2024-05-01T00:00:00Z","end_time":"2024-05-31T23:59:59Z"}`, user, config)
+	assert.Nil(t, err)
+
+	response := result.(MCPCallToolResponse[MCPTextContent])
+	assert.Equal(t, `{"items":[{"type":"expense","primary_category_name":"Food","category_name":"Dining","currency":"USD","amount":"12.34"}]}`, response.Content[0].Text)
+
+	result, err = callMCPTestTool(c, MCPQueryTransactionStatisticsToolHandler.Name(), `{"start_time":"2024-05-01T00:00:00Z","end_time":"2024-05-31T23:59:59Z","type":"income"}`, user, config)
+	assert.Nil(t, err)
+
+	response = result.(MCPCallToolResponse[MCPTextContent])
+	assert.Equal(t, `{"items":[]}`, response.Content[0].Text)
+}
+
+func TestQueryTransactionStatisticsToolHandler_CreateNewMCPQueryTransactionStatisticsResponse(t *testing.T) {
+	accountMap := map[int64]*models.Account{
+		1: {AccountId: 1, Currency: "USD"},
+		2: {AccountId: 2, Currency: "USD"},
+		3: {AccountId: 3, Currency: "EUR"},
+	}
+	categoryMap := map[int64]*models.TransactionCategory{
+		10: {CategoryId: 10, Name: "Food", ParentCategoryId: models.LevelOneTransactionCategoryParentId},
+		11: {CategoryId: 11, Name: "Dining", ParentCategoryId: 10},
+		12: {CategoryId: 12, Name: "Groceries", ParentCategoryId: 10},
+		20: {CategoryId: 20, Name: "Salary", ParentCategoryId: models.LevelOneTransactionCategoryParentId},
+		21: {CategoryId: 21, Name: "Wages", ParentCategoryId: 20},
+	}
+	totalAmounts := []*models.Transaction{
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 1, Amount: 1000},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 2, Amount: 500},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 12, AccountId: 1, Amount: 2000},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 3, Amount: 300},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 4, Amount: 9999},
+		{Type: models.TRANSACTION_DB_TYPE_INCOME, CategoryId: 21, AccountId: 1, Amount: 100000},
+		{Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, CategoryId: 30, AccountId: 1, Amount: 700},
+	}
+
+	response := MCPQueryTransactionStatisticsToolHandler.createNewMCPQueryTransactionStatisticsResponse(&MCPQueryTransactionStatisticsRequest{}, totalAmounts, accountMap, categoryMap)
+	assert.Equal(t, 4, len(response.Items))
+
+	assert.Equal(t, "expense", response.Items[0].Type)
+	assert.Equal(t, "Dining", response.Items[0].SecondaryCategoryName)
+	assert.Equal(t, "EUR", response.Items[0].Currency)
+	assert.Equal(t, "3.00", response.Items[0].Amount)
+
+	assert.Equal(t, "expense", response.Items[1].Type)
+	assert.Equal(t, "Groceries", response.Items[1].SecondaryCategoryName)
+	assert.Equal(t, "USD", response.Items[1].Currency)
+	assert.Equal(t, "20.00", response.Items[1].Amount)
+
+	assert.Equal(t, "expense", response.Items[2].Type)
+	assert.Equal(t, "Food", response.Items[2].PrimaryCategoryName)
+	assert.Equal(t, "Dining", response.Items[2].SecondaryCategoryName)
+	assert.Equal(t, "USD", response.Items[2].Currency)
+	assert.Equal(t, "15.00", response.Items[2].Amount)
+
+	assert.Equal(t, "income", response.Items[3].Type)
+	assert.Equal(t, "Salary", response.Items[3].PrimaryCategoryName)
+	assert.Equal(t, "Wages", response.Items[3].SecondaryCategoryName)
+	assert.Equal(t, "1000.00", response.Items[3].Amount)
+
+	response = MCPQueryTransactionStatisticsToolHandler.createNewMCPQueryTransactionStatisticsResponse(&MCPQueryTransactionStatisticsRequest{Type: "income"}, totalAmounts, accountMap, categoryMap)
+	assert.Equal(t, 1, len(response.Items))
+	assert.Equal(t, "income", response.Items[0].Type)
+}
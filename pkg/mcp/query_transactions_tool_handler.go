@@ -24,7 +24,7 @@ type MCPQueryTransactionsRequest struct {
 	Keyword               string `json:"keyword,omitempty" jsonschema_description:"Keyword to search in transaction description (optional)"`
 	Count                 int32  `json:"count,omitempty" jsonschema:"default=100" jsonschema_description:"Maximum number of results to return (default: 100)"`
 	Page                  int32  `json:"page,omitempty" jsonschema:"default=1" jsonschema_description:"Page number for pagination (default: 1)"`
-	ResponseFields        string `json:"response_fields,omitempty" jsonschema_description:"Comma-separated list of optional fields to include in the response (optional, leave empty for all fields, available fields: time, currency, category_name, account_name, comment)"`
+	ResponseFields        string `json:"response_fields,omitempty" jsonschema_description:"Comma-separated list of optional fields to include in the response (optional, leave empty for all fields, available fields: time, currency, category_name, account_name, comment, pictures)"`
 }
 
 // MCPQueryTransactionsResponse represents the response structure for querying transactions
@@ -37,16 +37,18 @@ type MCPQueryTransactionsResponse struct {
 
 // MCPTransactionInfo defines the structure of transaction information
 type MCPTransactionInfo struct {
-	Time                   string `json:"time,omitempty" jsonschema_description:"Time of the transaction in RFC 3339 format (e.g. 2023-01-01T12:00:00Z)"`
-	Type                   string `json:"type" jsonschema:"enum=income,enum=expense,enum=transfer" jsonschema_description:"Transaction type (income, expense, transfer)"`
-	Amount                 string `json:"amount" jsonschema_description:"Amount of the transaction in the specified currency"`
-	Currency               string `json:"currency,omitempty" jsonschema_description:"Currency code of the transaction (e.g. USD, EUR)"`
-	SecondaryCategoryName  string `json:"category_name,omitempty" jsonschema_description:"Secondary category name for the transaction"`
-	AccountName            string `json:"account_name,omitempty" jsonschema_description:"Account name for the transaction"`
-	DestinationAmount      string `json:"destination_amount,omitempty" jsonschema_description:"Destination amount for transfer transactions (optional)"`
-	DestinationCurrency    string `json:"destination_currency,omitempty" jsonschema_description:"Currency code of the destination amount for transfer transactions (optional)"`
-	DestinationAccountName string `json:"destination_account_name,omitempty" jsonschema_description:"Destination account name for transfer transactions (optional)"`
-	Comment                string `json:"comment,omitempty" jsonschema_description:"Description of the transaction"`
+	Id                     string   `json:"id" jsonschema_description:"ID of the transaction"`
+	Time                   string   `json:"time,omitempty" jsonschema_description:"Time of the transaction in RFC 3339 format (e.g. 2023-01-01T12:00:00Z)"`
+	Type                   string   `json:"type" jsonschema:"enum=income,enum=expense,enum=transfer" jsonschema_description:"Transaction type (income, expense, transfer)"`
+	Amount                 string   `json:"amount" jsonschema_description:"Amount of the transaction in the specified currency"`
+	Currency               string   `json:"currency,omitempty" jsonschema_description:"Currency code of the transaction (e.g. USD, EUR)"`
+	SecondaryCategoryName  string   `json:"category_name,omitempty" jsonschema_description:"Secondary category name for the transaction"`
+	AccountName            string   `json:"account_name,omitempty" jsonschema_description:"Account name for the transaction"`
+	DestinationAmount      string   `json:"destination_amount,omitempty" jsonschema_description:"Destination amount for transfer transactions (optional)"`
+	DestinationCurrency    string   `json:"destination_currency,omitempty" jsonschema_description:"Currency code of the destination amount for transfer transactions (optional)"`
+	DestinationAccountName string   `json:"destination_account_name,omitempty" jsonschema_description:"Destination account name for transfer transactions (optional)"`
+	Comment                string   `json:"comment,omitempty" jsonschema_description:"Description of the transaction"`
+	Pictures               []string `json:"pictures,omitempty" jsonschema_description:"Resource URIs of the pictures attached to the transaction, which can be read by MCP resources/read (optional)"`
 }
 
 type mcpQueryTransactionsToolHandler struct{}
@@ -161,7 +163,30 @@ func (h *mcpQueryTransactionsToolHandler) Handle(c *core.WebContext, callToolReq
 	}
 
 	transactions, err := services.GetTransactionService().GetTransactionsByMaxTime(c, uid, maxTransactionTime, minTransactionTime, transactionType, filterCategoryIds, filterAccountIds, nil, false, nil, false, "", queryTransactionsRequest.Keyword, queryTransactionsRequest.Page, queryTransactionsRequest.Count, false, true)
-	structuredResponse, response, err := h.createNewMCPQueryTransactionsResponse(c, &queryTransactionsRequest, transactions, totalCount, services.GetAccountService().GetAccountMapByList(allAccounts), services.GetTransactionCategoryService().GetCategoryMapByList(allCategories))
+
+	if err != nil {
+		log.Errorf(c, "[query_transactions.Handle] failed to get transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, nil, err
+	}
+
+	var pictureInfosMap map[int64][]*models.TransactionPictureInfo
+
+	if currentConfig.EnableTransactionPictures && len(transactions) > 0 {
+		transactionIds := make([]int64, len(transactions))
+
+		for i := 0; i < len(transactions); i++ {
+			transactionIds[i] = transactions[i].TransactionId
+		}
+
+		pictureInfosMap, err = services.GetTransactionPictureService().GetPictureInfosByTransactionIds(c, uid, transactionIds)
+
+		if err != nil {
+			log.Errorf(c, "[query_transactions.Handle] failed to get transaction pictures for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, nil, err
+		}
+	}
+
+	structuredResponse, response, err := h.createNewMCPQueryTransactionsResponse(c, &queryTransactionsRequest, transactions, totalCount, services.GetAccountService().GetAccountMapByList(allAccounts), services.GetTransactionCategoryService().GetCategoryMapByList(allCategories), pictureInfosMap)
 
 	if err != nil {
 		return nil, nil, err
@@ -170,7 +195,7 @@ func (h *mcpQueryTransactionsToolHandler) Handle(c *core.WebContext, callToolReq
 	return structuredResponse, response, nil
 }
 
-func (h *mcpQueryTransactionsToolHandler) createNewMCPQueryTransactionsResponse(c *core.WebContext, queryTransactionsRequest *MCPQueryTransactionsRequest, transactions []*models.Transaction, totalCount int64, accountsMap map[int64]*models.Account, categoriesMap map[int64]*models.TransactionCategory) (any, []*MCPTextContent, error) {
+func (h *mcpQueryTransactionsToolHandler) createNewMCPQueryTransactionsResponse(c *core.WebContext, queryTransactionsRequest *MCPQueryTransactionsRequest, transactions []*models.Transaction, totalCount int64, accountsMap map[int64]*models.Account, categoriesMap map[int64]*models.TransactionCategory, pictureInfosMap map[int64][]*models.TransactionPictureInfo) (any, []*MCPTextContent, error) {
 	response := MCPQueryTransactionsResponse{
 		TotalCount:   totalCount,
 		CurrentPage:  queryTransactionsRequest.Page,
@@ -187,73 +212,87 @@ func (h *mcpQueryTransactionsToolHandler) createNewMCPQueryTransactionsResponse(
 	}
 
 	for i := 0; i < len(transactions); i++ {
-		transaction := transactions[i]
-		transactionInfo := MCPTransactionInfo{
-			Amount: utils.FormatAmount(transaction.Amount),
-		}
+		transactionInfo := newMCPTransactionInfo(transactions[i], accountsMap, categoriesMap, filteredFields)
 
-		if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
-			transactionInfo.Type = transactionTypeExpense
-		} else if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
-			transactionInfo.Type = transactionTypeIncome
-		} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-			transactionInfo.Type = transactionTypeTransfer
-		}
+		if _, exists := filteredFields["pictures"]; exists || len(filteredFields) == 0 {
+			pictureInfos := pictureInfosMap[transactions[i].TransactionId]
 
-		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-			transactionInfo.DestinationAmount = utils.FormatAmount(transaction.RelatedAccountAmount)
+			for j := 0; j < len(pictureInfos); j++ {
+				transactionInfo.Pictures = append(transactionInfo.Pictures, mcpTransactionPictureResourceUriPrefix+utils.Int64ToString(pictureInfos[j].PictureId)+"."+pictureInfos[j].PictureExtension)
+			}
 		}
 
-		if _, exists := filteredFields["time"]; exists || len(filteredFields) == 0 {
-			transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
-			transactionTimeZone := time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
-			transactionInfo.Time = utils.FormatUnixTimeToLongDateTimeWithTimezoneRFC3339Format(transactionUnixTime, transactionTimeZone)
-		}
+		response.Transactions = append(response.Transactions, transactionInfo)
+	}
 
-		if _, exists := filteredFields["currency"]; exists || len(filteredFields) == 0 {
-			if account, exists := accountsMap[transaction.AccountId]; exists && account != nil {
-				transactionInfo.Currency = account.Currency
-			}
+	content, err := json.Marshal(response)
 
-			if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && transaction.RelatedAccountId > 0 {
-				if destinationAccount, exists := accountsMap[transaction.RelatedAccountId]; exists && destinationAccount != nil {
-					transactionInfo.DestinationCurrency = destinationAccount.Currency
-				}
-			}
-		}
+	if err != nil {
+		return nil, nil, err
+	}
 
-		if _, exists := filteredFields["category_name"]; exists || len(filteredFields) == 0 {
-			if category, exists := categoriesMap[transaction.CategoryId]; exists && category != nil {
-				transactionInfo.SecondaryCategoryName = category.Name
-			}
+	return response, []*MCPTextContent{
+		NewMCPTextContent(string(content)),
+	}, nil
+}
+
+func newMCPTransactionInfo(transaction *models.Transaction, accountsMap map[int64]*models.Account, categoriesMap map[int64]*models.TransactionCategory, filteredFields map[string]bool) *MCPTransactionInfo {
+	transactionInfo := &MCPTransactionInfo{
+		Id:     utils.Int64ToString(transaction.TransactionId),
+		Amount: utils.FormatAmount(transaction.Amount),
+	}
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+		transactionInfo.Type = transactionTypeExpense
+	} else if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+		transactionInfo.Type = transactionTypeIncome
+	} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		transactionInfo.Type = transactionTypeTransfer
+	}
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		transactionInfo.DestinationAmount = utils.FormatAmount(transaction.RelatedAccountAmount)
+	}
+
+	if _, exists := filteredFields["time"]; exists || len(filteredFields) == 0 {
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+		transactionTimeZone := time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		transactionInfo.Time = utils.FormatUnixTimeToLongDateTimeWithTimezoneRFC3339Format(transactionUnixTime, transactionTimeZone)
+	}
+
+	if _, exists := filteredFields["currency"]; exists || len(filteredFields) == 0 {
+		if account, exists := accountsMap[transaction.AccountId]; exists && account != nil {
+			transactionInfo.Currency = account.Currency
 		}
 
-		if _, exists := filteredFields["account_name"]; exists || len(filteredFields) == 0 {
-			if account, exists := accountsMap[transaction.AccountId]; exists && account != nil {
-				transactionInfo.AccountName = account.Name
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && transaction.RelatedAccountId > 0 {
+			if destinationAccount, exists := accountsMap[transaction.RelatedAccountId]; exists && destinationAccount != nil {
+				transactionInfo.DestinationCurrency = destinationAccount.Currency
 			}
+		}
+	}
 
-			if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && transaction.RelatedAccountId > 0 {
-				if destinationAccount, exists := accountsMap[transaction.RelatedAccountId]; exists && destinationAccount != nil {
-					transactionInfo.DestinationAccountName = destinationAccount.Name
-				}
-			}
+	if _, exists := filteredFields["category_name"]; exists || len(filteredFields) == 0 {
+		if category, exists := categoriesMap[transaction.CategoryId]; exists && category != nil {
+			transactionInfo.SecondaryCategoryName = category.Name
 		}
+	}
 
-		if _, exists := filteredFields["comment"]; exists || len(filteredFields) == 0 {
-			transactionInfo.Comment = transaction.Comment
+	if _, exists := filteredFields["account_name"]; exists || len(filteredFields) == 0 {
+		if account, exists := accountsMap[transaction.AccountId]; exists && account != nil {
+			transactionInfo.AccountName = account.Name
 		}
 
-		response.Transactions = append(response.Transactions, &transactionInfo)
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && transaction.RelatedAccountId > 0 {
+			if destinationAccount, exists := accountsMap[transaction.RelatedAccountId]; exists && destinationAccount != nil {
+				transactionInfo.DestinationAccountName = destinationAccount.Name
+			}
+		}
 	}
 
-	content, err := json.Marshal(response)
-
-	if err != nil {
-		return nil, nil, err
+	if _, exists := filteredFields["comment"]; exists || len(filteredFields) == 0 {
+		transactionInfo.Comment = transaction.Comment
 	}
 
-	return response, []*MCPTextContent{
-		NewMCPTextContent(string(content)),
-	}, nil
+	return transactionInfo
 }
@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"encoding/json"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+const mcpTransactionCategoriesResourceUri = mcpResourceUriPrefix + "categories"
+
+type mcpTransactionCategoriesResourceHandler struct{}
+
+var MCPTransactionCategoriesResourceHandler = &mcpTransactionCategoriesResourceHandler{}
+
+// Name returns the name of the MCP resource
+func (h *mcpTransactionCategoriesResourceHandler) Name() string {
+	return "categories"
+}
+
+// Description returns the description of the MCP resource
+func (h *mcpTransactionCategoriesResourceHandler) Description() string {
+	return "The transaction category tree of the current user in ezBookkeeping, primary category names are mapped to the list of their secondary category names for income, expense and transfer."
+}
+
+// MimeType returns the MIME type of the MCP resource
+func (h *mcpTransactionCategoriesResourceHandler) MimeType() string {
+	return mcpResourceJsonMimeType
+}
+
+// URITemplate returns the uri template of the MCP resource
+func (h *mcpTransactionCategoriesResourceHandler) URITemplate() string {
+	return ""
+}
+
+// ListResources returns the resources which can be listed directly
+func (h *mcpTransactionCategoriesResourceHandler) ListResources(c *core.WebContext, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPResource, error) {
+	return []*MCPResource{
+		{
+			URI:         mcpTransactionCategoriesResourceUri,
+			Name:        h.Name(),
+			Title:       "Transaction Categories",
+			Description: h.Description(),
+			MimeType:    h.MimeType(),
+		},
+	}, nil
+}
+
+// MatchURI returns whether the uri can be read by this MCP resource handler
+func (h *mcpTransactionCategoriesResourceHandler) MatchURI(uri string) bool {
+	return uri == mcpTransactionCategoriesResourceUri
+}
+
+// Read processes the MCP read resource request and returns the resource contents
+func (h *mcpTransactionCategoriesResourceHandler) Read(c *core.WebContext, readResourceReq *MCPReadResourceRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPTextResourceContents, error) {
+	uid := user.Uid
+	categories, err := services.GetTransactionCategoryService().GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_categories_resource.Read] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	structuredResponse, _, err := MCPQueryAllTransactionCategoriesToolHandler.createNewMCPQueryAllTransactionCategoriesResponse(c, categories)
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(structuredResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*MCPTextResourceContents{
+		NewMCPTextResourceContents(readResourceReq.URI, string(content), h.MimeType()),
+	}, nil
+}
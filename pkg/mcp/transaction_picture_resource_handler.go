@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const mcpTransactionPictureResourceUriPrefix = mcpResourceUriPrefix + "pictures/"
+
+type mcpTransactionPictureResourceHandler struct{}
+
+var MCPTransactionPictureResourceHandler = &mcpTransactionPictureResourceHandler{}
+
+// Name returns the name of the MCP resource
+func (h *mcpTransactionPictureResourceHandler) Name() string {
+	return "transaction_picture"
+}
+
+// Description returns the description of the MCP resource
+func (h *mcpTransactionPictureResourceHandler) Description() string {
+	return "The picture attached to a transaction in ezBookkeeping (file_name format is {picture_id}.{extension})."
+}
+
+// MimeType returns the MIME type of the MCP resource, which depends on the picture extension
+func (h *mcpTransactionPictureResourceHandler) MimeType() string {
+	return ""
+}
+
+// URITemplate returns the uri template of the MCP resource
+func (h *mcpTransactionPictureResourceHandler) URITemplate() string {
+	return mcpTransactionPictureResourceUriPrefix + "{file_name}"
+}
+
+// ListResources returns nothing, transaction pictures can only be read by uri template
+func (h *mcpTransactionPictureResourceHandler) ListResources(c *core.WebContext, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPResource, error) {
+	return nil, nil
+}
+
+// MatchURI returns whether the uri can be read by this MCP resource handler
+func (h *mcpTransactionPictureResourceHandler) MatchURI(uri string) bool {
+	return strings.HasPrefix(uri, mcpTransactionPictureResourceUriPrefix)
+}
+
+// Read processes the MCP read resource request and returns the resource contents
+func (h *mcpTransactionPictureResourceHandler) Read(c *core.WebContext, readResourceReq *MCPReadResourceRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) ([]*MCPBlobResourceContents, error) {
+	fileName := strings.TrimPrefix(readResourceReq.URI, mcpTransactionPictureResourceUriPrefix)
+
+	if strings.Contains(fileName, "/") {
+		return nil, errs.ErrMCPResourceNotFound
+	}
+
+	fileExtension := utils.GetFileNameExtension(fileName)
+	contentType := utils.GetImageContentType(fileExtension)
+
+	if contentType == "" {
+		return nil, errs.ErrImageTypeNotSupported
+	}
+
+	pictureId, err := utils.StringToInt64(utils.GetFileNameWithoutExtension(fileName))
+
+	if err != nil {
+		return nil, errs.ErrTransactionPictureIdInvalid
+	}
+
+	uid := user.Uid
+	pictureData, err := services.GetTransactionPictureService().GetPictureByPictureId(c, uid, pictureId, fileExtension)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transaction_picture_resource.Read] failed to get transaction picture \"id:%d\" for user \"uid:%d\", because %s", pictureId, uid, err.Error())
+		}
+
+		return nil, err
+	}
+
+	return []*MCPBlobResourceContents{
+		NewMCPBlobResourceContents(readResourceReq.URI, pictureData, contentType),
+	}, nil
+}
@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+const mcpTestPictureId = 5001
+const mcpTestAnotherUserPictureId = 5002
+const mcpTestDeletedPictureId = 5003
+
+func initializeMCPTestTransactionPictures(t *testing.T, c *core.WebContext) []byte {
+	pictureData := []byte("\x89PNG\r\n\x1a\nezbookkeeping")
+	picturePath := filepath.Join(t.TempDir(), "picture.png")
+	assert.Nil(t, os.WriteFile(picturePath, pictureData, 0644))
+
+	pictureInfos := []*models.TransactionPictureInfo{
+		{Uid: mcpTestUid, TransactionId: mcpTestExpenseTransactionId, PictureId: mcpTestPictureId, PictureExtension: "png"},
+		{Uid: mcpTestAnotherUid, PictureId: mcpTestAnotherUserPictureId, PictureExtension: "png"},
+		{Uid: mcpTestUid, PictureId: mcpTestDeletedPictureId, PictureExtension: "png", Deleted: true},
+	}
+
+	for i := 0; i < len(pictureInfos); i++ {
+		pictureInfo := pictureInfos[i]
+		_, err := datastore.Container.UserDataStore.Choose(pictureInfo.Uid).NewSession(c).Insert(pictureInfo)
+		assert.Nil(t, err)
+
+		pictureFile, err := os.Open(picturePath)
+		assert.Nil(t, err)
+		assert.Nil(t, services.TransactionPictures.SaveTransactionPicture(c, pictureInfo.Uid, pictureInfo.PictureId, pictureFile, pictureInfo.PictureExtension))
+		_ = pictureFile.Close()
+	}
+
+	return pictureData
+}
+
+func TestTransactionPictureResourceHandler_Read(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+	pictureData := initializeMCPTestTransactionPictures(t, c)
+
+	result, err := Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/5001.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Nil(t, err)
+
+	response := result.(MCPReadResourceResponse[MCPBlobResourceContents])
+	assert.Equal(t, 1, len(response.Contents))
+	assert.Equal(t, "ezbookkeeping://pictures/5001.png", response.Contents[0].URI)
+	assert.Equal(t, "image/png", response.Contents[0].MimeType)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(pictureData), response.Contents[0].Blob)
+}
+
+func TestTransactionPictureResourceHandler_ReadAnotherUserPicture(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+	initializeMCPTestTransactionPictures(t, c)
+
+	_, err := Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/5002.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrTransactionPictureNotFound, err)
+
+	user.Uid = mcpTestAnotherUid
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/5001.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrTransactionPictureNotFound, err)
+}
+
+func TestTransactionPictureResourceHandler_ReadInvalidURI(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+	initializeMCPTestTransactionPictures(t, c)
+
+	_, err := Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrImageTypeNotSupported, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/5001"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrImageTypeNotSupported, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/5001.txt"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrImageTypeNotSupported, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/abc.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrTransactionPictureIdInvalid, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/../../5001.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/2/5002.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrMCPResourceNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/0.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrTransactionPictureIdInvalid, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/5001.jpg"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrTransactionPictureExtensionInvalid, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/5003.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrTransactionPictureNotFound, err)
+
+	_, err = Container.ReadResource(c, &MCPReadResourceRequest{URI: "ezbookkeeping://pictures/9999.png"}, user, config, &mcpTestAvailableServices{})
+	assert.Equal(t, errs.ErrTransactionPictureNotFound, err)
+}
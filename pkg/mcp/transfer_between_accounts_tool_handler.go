@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// MCPTransferBetweenAccountsRequest represents all parameters of the transfer between accounts request
+type MCPTransferBetweenAccountsRequest struct {
+	Time                   string   `json:"time" jsonschema:"format=date-time" jsonschema_description:"Transaction time in RFC 3339 format (e.g. 2023-01-01T12:00:00Z)"`
+	SourceAccountName      string   `json:"source_account_name" jsonschema_description:"Account name which the amount is transferred from"`
+	DestinationAccountName string   `json:"destination_account_name" jsonschema_description:"Account name which the amount is transferred to"`
+	Amount                 string   `json:"amount" jsonschema_description:"Amount transferred out from the source account"`
+	DestinationAmount      string   `json:"destination_amount,omitempty" jsonschema_description:"Amount transferred into the destination account (optional, required when the currencies of the two accounts are different, default is the same as amount)"`
+	SecondaryCategoryName  string   `json:"category_name,omitempty" jsonschema_description:"Secondary transfer category name for the transaction (optional, default is the first visible transfer category)"`
+	Tags                   []string `json:"tags,omitempty" jsonschema_description:"List of tags associated with the transaction (optional, maximum 10 tags allowed)"`
+	Comment                string   `json:"comment,omitempty" jsonschema_description:"Transaction description"`
+	ClientSessionId        string   `json:"client_session_id,omitempty" jsonschema_description:"Unique identifier of this request, the transaction will not be created repeatedly if the same identifier is submitted again (optional)"`
+	DryRun                 bool     `json:"dry_run,omitempty" jsonschema_description:"If true, the transaction will not be saved, only validated (optional)"`
+}
+
+type mcpTransferBetweenAccountsToolHandler struct{}
+
+var MCPTransferBetweenAccountsToolHandler = &mcpTransferBetweenAccountsToolHandler{}
+
+// Name returns the name of the MCP tool
+func (h *mcpTransferBetweenAccountsToolHandler) Name() string {
+	return "transfer_between_accounts"
+}
+
+// Description returns the description of the MCP tool
+func (h *mcpTransferBetweenAccountsToolHandler) Description() string {
+	return "Transfer an amount from one account to another account in ezBookkeeping."
+}
+
+// InputType returns the input type for the MCP tool request
+func (h *mcpTransferBetweenAccountsToolHandler) InputType() reflect.Type {
+	return reflect.TypeOf(&MCPTransferBetweenAccountsRequest{})
+}
+
+// OutputType returns the output type for the MCP tool response
+func (h *mcpTransferBetweenAccountsToolHandler) OutputType() reflect.Type {
+	return reflect.TypeOf(&MCPAddTransactionResponse{})
+}
+
+// Handle processes the MCP call tool request and returns the response
+func (h *mcpTransferBetweenAccountsToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	var transferRequest MCPTransferBetweenAccountsRequest
+
+	if callToolReq.Arguments != nil {
+		if err := json.Unmarshal(callToolReq.Arguments, &transferRequest); err != nil {
+			return nil, nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+		}
+	} else {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	if transferRequest.SourceAccountName == "" || transferRequest.DestinationAccountName == "" || transferRequest.Amount == "" {
+		return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	if transferRequest.SourceAccountName == transferRequest.DestinationAccountName {
+		return nil, nil, errs.ErrTransactionSourceAndDestinationIdCannotBeEqual
+	}
+
+	uid := user.Uid
+	allAccounts, err := services.GetAccountService().GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Warnf(c, "[transfer_between_accounts.Handle] get account error, because %s", err.Error())
+		return nil, nil, err
+	}
+
+	accountsMap := services.GetAccountService().GetVisibleAccountNameMapByList(allAccounts)
+	sourceAccount, sourceAccountExists := accountsMap[transferRequest.SourceAccountName]
+	destinationAccount, destinationAccountExists := accountsMap[transferRequest.DestinationAccountName]
+
+	if transferRequest.DestinationAmount == "" {
+		if sourceAccountExists && destinationAccountExists && sourceAccount.Currency != destinationAccount.Currency {
+			log.Warnf(c, "[transfer_between_accounts.Handle] destination amount is required when transferring from \"%s\" to \"%s\" for user \"uid:%d\"", sourceAccount.Currency, destinationAccount.Currency, uid)
+			return nil, nil, errs.ErrIncompleteOrIncorrectSubmission
+		}
+
+		transferRequest.DestinationAmount = transferRequest.Amount
+	}
+
+	if transferRequest.SecondaryCategoryName == "" {
+		allCategories, err := services.GetTransactionCategoryService().GetAllCategoriesByUid(c, uid, models.CATEGORY_TYPE_TRANSFER, -1)
+
+		if err != nil {
+			log.Warnf(c, "[transfer_between_accounts.Handle] get transaction category error, because %s", err.Error())
+			return nil, nil, err
+		}
+
+		for i := 0; i < len(allCategories); i++ {
+			category := allCategories[i]
+
+			if !category.Hidden && category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+				transferRequest.SecondaryCategoryName = category.Name
+				break
+			}
+		}
+	}
+
+	addTransactionRequest := &MCPAddTransactionRequest{
+		Type:                   transactionTypeTransfer,
+		Time:                   transferRequest.Time,
+		SecondaryCategoryName:  transferRequest.SecondaryCategoryName,
+		AccountName:            transferRequest.SourceAccountName,
+		Amount:                 transferRequest.Amount,
+		DestinationAccountName: transferRequest.DestinationAccountName,
+		DestinationAmount:      transferRequest.DestinationAmount,
+		Tags:                   transferRequest.Tags,
+		Comment:                transferRequest.Comment,
+		ClientSessionId:        transferRequest.ClientSessionId,
+		DryRun:                 transferRequest.DryRun,
+	}
+
+	return MCPAddTransactionToolHandler.addTransaction(c, addTransactionRequest, user, currentConfig, services)
+}
@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestTransferBetweenAccountsToolHandler_InvalidArguments(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	_, err := callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), "", user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","destination_account_name":"Bank","amount":"10.00"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","amount":"10.00"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","destination_account_name":"Bank"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","destination_account_name":"Cash","amount":"10.00"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionSourceAndDestinationIdCannotBeEqual, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Not Existed","destination_account_name":"Bank","amount":"10.00"}`, user, config)
+	assert.Equal(t, errs.ErrSourceAccountNotFound, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","destination_account_name":"Old Wallet","amount":"10.00"}`, user, config)
+	assert.Equal(t, errs.ErrDestinationAccountNotFound, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","destination_account_name":"Euro Wallet","amount":"10.00"}`, user, config)
+	assert.Equal(t, errs.ErrIncompleteOrIncorrectSubmission, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","destination_account_name":"Bank","amount":"1.2.3"}`, user, config)
+	assert.Equal(t, errs.ErrNumberInvalid, err)
+
+	_, err = callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","destination_account_name":"Bank","amount":"10.00","category_name":"Dining"}`, user, config)
+	assert.Equal(t, errs.ErrTransactionCategoryNotFound, err)
+}
+
+func TestTransferBetweenAccountsToolHandler_DryRunWithDefaultDestinationAmountAndCategory(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Bank","destination_account_name":"Cash","amount":"25.50","dry_run":true}`, user, config)
+	assert.Nil(t, err)
+
+	response := result.(MCPCallToolResponse[MCPTextContent])
+	assert.Contains(t, response.Content[0].Text, `"dry_run":true`)
+	assert.Contains(t, response.Content[0].Text, `"account_balance":"474.50"`)
+	assert.Contains(t, response.Content[0].Text, `"destination_account_balance":"125.50"`)
+
+	accounts, err := services.Accounts.GetAccountsByAccountIds(c, mcpTestUid, []int64{mcpTestCashAccountId, mcpTestBankAccountId})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10000), accounts[mcpTestCashAccountId].Balance)
+	assert.Equal(t, int64(50000), accounts[mcpTestBankAccountId].Balance)
+}
+
+func TestTransferBetweenAccountsToolHandler_TransferBetweenDifferentCurrencies(t *testing.T) {
+	config, user := initializeMCPTestEnvironment(t)
+	c := createMCPTestWebContext(nil)
+
+	result, err := callMCPTestTool(c, MCPTransferBetweenAccountsToolHandler.Name(), `{"time":"2024-05-11T08:00:00Z","source_account_name":"Cash","destination_account_name":"Euro Wallet","amount":"20.00","destination_amount":"18.40","category_name":"Bank Transfer"}`, user, config)
+	assert.Nil(t, err)
+
+	response := result.(MCPCallToolResponse[MCPTextContent])
+	assert.Contains(t, response.Content[0].Text, `"account_balance":"80.00"`)
+	assert.Contains(t, response.Content[0].Text, `"destination_account_balance":"218.40"`)
+
+	var addTransactionResponse MCPAddTransactionResponse
+	assert.Nil(t, json.Unmarshal([]byte(response.Content[0].Text), &addTransactionResponse))
+
+	transactionId, err := utils.StringToInt64(addTransactionResponse.TransactionId)
+	assert.Nil(t, err)
+
+	transaction, err := services.Transactions.GetTransactionByTransactionId(c, mcpTestUid, transactionId)
+	assert.Nil(t, err)
+	assert.Equal(t, models.TRANSACTION_DB_TYPE_TRANSFER_OUT, transaction.Type)
+	assert.Equal(t, int64(mcpTestCashAccountId), transaction.AccountId)
+	assert.Equal(t, int64(2000), transaction.Amount)
+	assert.Equal(t, int64(mcpTestEuroAccountId), transaction.RelatedAccountId)
+	assert.Equal(t, int64(1840), transaction.RelatedAccountAmount)
+	assert.Equal(t, int64(mcpTestTransferCategoryId), transaction.CategoryId)
+	assert.Equal(t, utils.GetMinTransactionTimeFromUnixTime(1715414400), transaction.TransactionTime)
+}
@@ -1,11 +1,15 @@
 package middlewares
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -21,19 +25,57 @@ const (
 	TOKEN_SOURCE_TYPE_COOKIE   TokenSourceType = 3
 )
 
+// tokenScopeRequirement represents the scopes which the scope-restricted token requires to access the route group
+type tokenScopeRequirement struct {
+	readScope  core.TokenScope
+	writeScope core.TokenScope
+}
+
+// ledgerSupportedRequestPathPrefixes represents the request paths which can operate on a shared ledger
+var ledgerSupportedRequestPathPrefixes = []string{
+	"/api/v1/accounts/",
+	"/api/v1/transactions/",
+	"/api/v1/transaction/",
+	"/api/v1/budgets/",
+	"/api/v1/insights/",
+}
+
+// ledgerEditorWritableRequestPaths represents the request paths which the editor of a shared ledger can use to modify data,
+// the editor can only add, modify and delete transactions, and other data (e.g. accounts and budgets) can only be modified by the owner
+var ledgerEditorWritableRequestPaths = map[string]bool{
+	"/api/v1/transactions/add.json":            true,
+	"/api/v1/transactions/modify.json":         true,
+	"/api/v1/transactions/delete.json":         true,
+	"/api/v1/transaction/pictures/upload.json": true,
+}
+
+// ledgerReadOnlyNonGetRequestPaths represents the request paths which use non-GET method but do not modify any data of a shared ledger
+var ledgerReadOnlyNonGetRequestPaths = map[string]bool{
+	"/api/v1/insights/explorers/query.json": true,
+}
+
 // JWTAuthorization verifies whether current request is valid by jwt token in header
 func JWTAuthorization(config *settings.Config) core.MiddlewareHandlerFunc {
-	return jwtAuthorization(config, TOKEN_SOURCE_TYPE_HEADER)
+	return jwtAuthorization(config, TOKEN_SOURCE_TYPE_HEADER, nil)
+}
+
+// JWTAuthorizationWithScopes verifies whether current request is valid by jwt token in header,
+// and the scope-restricted token must have been granted the read scope for GET requests or the write scope for other requests
+func JWTAuthorizationWithScopes(config *settings.Config, readScope core.TokenScope, writeScope core.TokenScope) core.MiddlewareHandlerFunc {
+	return jwtAuthorization(config, TOKEN_SOURCE_TYPE_HEADER, &tokenScopeRequirement{
+		readScope:  readScope,
+		writeScope: writeScope,
+	})
 }
 
 // JWTAuthorizationByQueryString verifies whether current request is valid by jwt token in query string
 func JWTAuthorizationByQueryString(config *settings.Config) core.MiddlewareHandlerFunc {
-	return jwtAuthorization(config, TOKEN_SOURCE_TYPE_ARGUMENT)
+	return jwtAuthorization(config, TOKEN_SOURCE_TYPE_ARGUMENT, nil)
 }
 
 // JWTAuthorizationByCookie verifies whether current request is valid by jwt token in cookie
 func JWTAuthorizationByCookie(config *settings.Config) core.MiddlewareHandlerFunc {
-	return jwtAuthorization(config, TOKEN_SOURCE_TYPE_COOKIE)
+	return jwtAuthorization(config, TOKEN_SOURCE_TYPE_COOKIE, nil)
 }
 
 // JWTTwoFactorAuthorization verifies whether current request is valid by 2fa passcode
@@ -146,7 +188,7 @@ func JWTOAuth2CallbackAuthorization(config *settings.Config) core.MiddlewareHand
 	}
 }
 
-func jwtAuthorization(config *settings.Config, source TokenSourceType) core.MiddlewareHandlerFunc {
+func jwtAuthorization(config *settings.Config, source TokenSourceType, scopeRequirement *tokenScopeRequirement) core.MiddlewareHandlerFunc {
 	return func(c *core.WebContext) {
 		claims, tokenContext, err := getTokenClaims(c, source)
 
@@ -173,12 +215,101 @@ func jwtAuthorization(config *settings.Config, source TokenSourceType) core.Midd
 			return
 		}
 
+		if claims.IsScopeRestricted() && !isTokenScopeGranted(c, claims, scopeRequirement) {
+			log.Warnf(c, "[authorization.jwtAuthorization] user \"uid:%d\" token has not been granted the scope to access \"%s %s\"", claims.Uid, c.Request.Method, c.Request.URL.Path)
+			utils.PrintJsonErrorResult(c, errs.ErrCurrentTokenScopeNotGranted)
+			return
+		}
+
+		err = resolveCurrentLedger(c, claims.Uid)
+
+		if err != nil {
+			utils.PrintJsonErrorResult(c, err)
+			return
+		}
+
 		c.SetTokenClaims(claims)
 		c.SetTokenContext(tokenContext)
 		c.Next()
 	}
 }
 
+func isTokenScopeGranted(c *core.WebContext, claims *core.UserTokenClaims, scopeRequirement *tokenScopeRequirement) bool {
+	if scopeRequirement == nil {
+		return false
+	}
+
+	requiredScope := scopeRequirement.writeScope
+
+	if c.Request.Method == http.MethodGet {
+		requiredScope = scopeRequirement.readScope
+	}
+
+	if requiredScope == "" {
+		return false
+	}
+
+	return claims.HasScope(requiredScope)
+}
+
+func resolveCurrentLedger(c *core.WebContext, uid int64) *errs.Error {
+	ledgerIdValue := c.GetHeader(core.LedgerIdHeaderName)
+
+	if ledgerIdValue == "" || ledgerIdValue == "0" || !isLedgerSupportedRequestPath(c.Request.URL.Path) {
+		return nil
+	}
+
+	ledgerId, err := utils.StringToInt64(ledgerIdValue)
+
+	if err != nil || ledgerId <= 0 {
+		log.Warnf(c, "[authorization.resolveCurrentLedger] ledger id \"%s\" is invalid", ledgerIdValue)
+		return errs.ErrLedgerIdInvalid
+	}
+
+	role, ledger, err := services.Ledgers.GetLedgerMemberRole(c, ledgerId, uid)
+
+	if err != nil {
+		log.Warnf(c, "[authorization.resolveCurrentLedger] failed to get role of user \"uid:%d\" in ledger \"id:%d\", because %s", uid, ledgerId, err.Error())
+		return errs.Or(err, errs.ErrLedgerNotFound)
+	}
+
+	if ledger.DataUid <= 0 {
+		log.Warnf(c, "[authorization.resolveCurrentLedger] ledger \"id:%d\" has no data uid", ledgerId)
+		return errs.ErrLedgerNotFound
+	}
+
+	if !isLedgerRequestPermitted(role, c.Request.Method, c.Request.URL.Path) {
+		log.Warnf(c, "[authorization.resolveCurrentLedger] user \"uid:%d\" has no permission to \"%s %s\" in ledger \"id:%d\"", uid, c.Request.Method, c.Request.URL.Path, ledgerId)
+		return errs.ErrLedgerPermissionDenied
+	}
+
+	c.SetCurrentLedgerDataUid(ledger.DataUid)
+
+	return nil
+}
+
+func isLedgerRequestPermitted(role models.LedgerMemberRole, method string, path string) bool {
+	if method == http.MethodGet || ledgerReadOnlyNonGetRequestPaths[path] {
+		return true
+	}
+
+	if role == models.LEDGER_MEMBER_ROLE_OWNER {
+		return true
+	}
+
+	return role.CanModifyData() && ledgerEditorWritableRequestPaths[path]
+}
+
+func isLedgerSupportedRequestPath(path string) bool {
+	for i := 0; i < len(ledgerSupportedRequestPathPrefixes); i++ {
+		if strings.HasPrefix(path, ledgerSupportedRequestPathPrefixes[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getTokenClaims(c *core.WebContext, source TokenSourceType) (*core.UserTokenClaims, string, *errs.Error) {
 	token, claims, tokenContext, err := parseToken(c, source)
 
@@ -0,0 +1,276 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const authorizationTestLedgerOwnerUid = 1
+const authorizationTestLedgerEditorUid = 2
+const authorizationTestLedgerViewerUid = 3
+const authorizationTestNonMemberUid = 4
+const authorizationTestLedgerId = 100
+const authorizationTestLedgerDataUid = 1000
+
+func initializeAuthorizationTestEnvironment(t *testing.T) *settings.Config {
+	gin.SetMode(gin.TestMode)
+
+	config := &settings.Config{
+		DatabaseConfig: &settings.DatabaseConfig{
+			DatabaseType:      settings.Sqlite3DbType,
+			DatabasePath:      filepath.Join(t.TempDir(), "ezbookkeeping.db"),
+			MaxOpenConnection: 2,
+		},
+		TokenExpiredTimeDuration: time.Hour,
+		EnableAPIToken:           true,
+	}
+
+	settings.SetCurrentConfig(config)
+	assert.Nil(t, datastore.InitializeDataStore(config))
+	assert.Nil(t, datastore.Container.UserStore.SyncStructs(new(models.Ledger), new(models.LedgerMember)))
+	assert.Nil(t, datastore.Container.TokenStore.SyncStructs(new(models.TokenRecord)))
+
+	sess := datastore.Container.UserStore.Choose(0).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	_, err := sess.Insert(&models.Ledger{LedgerId: authorizationTestLedgerId, Uid: authorizationTestLedgerOwnerUid, DataUid: authorizationTestLedgerDataUid, Name: "Family"})
+	assert.Nil(t, err)
+	_, err = sess.Insert(&models.LedgerMember{LedgerId: authorizationTestLedgerId, Uid: authorizationTestLedgerEditorUid, Role: models.LEDGER_MEMBER_ROLE_EDITOR})
+	assert.Nil(t, err)
+	_, err = sess.Insert(&models.LedgerMember{LedgerId: authorizationTestLedgerId, Uid: authorizationTestLedgerViewerUid, Role: models.LEDGER_MEMBER_ROLE_VIEWER})
+	assert.Nil(t, err)
+
+	return config
+}
+
+func createAuthorizationTestToken(t *testing.T, uid int64, scopes []core.TokenScope) string {
+	ginContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginContext.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c := core.WrapWebContext(ginContext)
+	user := &models.User{Uid: uid, Username: "user" + utils.Int64ToString(uid)}
+
+	var token string
+	var err error
+
+	if scopes != nil {
+		token, _, err = services.Tokens.CreateAPIToken(c, user, 0, scopes)
+	} else {
+		token, _, err = services.Tokens.CreateToken(c, user)
+	}
+
+	assert.Nil(t, err)
+
+	return token
+}
+
+// executeAuthorizationTestRequest executes the request on the route protected by the middleware, and returns the http status code, and the error code or the uid of data owner
+func executeAuthorizationTestRequest(t *testing.T, middleware core.MiddlewareHandlerFunc, method string, path string, token string, ledgerId int64) (int, int64) {
+	router := gin.New()
+	router.Handle(method, path, func(ginContext *gin.Context) {
+		middleware(core.WrapWebContext(ginContext))
+	}, func(ginContext *gin.Context) {
+		ginContext.JSON(http.StatusOK, gin.H{"uid": core.WrapWebContext(ginContext).GetCurrentUid()})
+	})
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	if ledgerId > 0 {
+		req.Header.Set(core.LedgerIdHeaderName, utils.Int64ToString(ledgerId))
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	result := make(map[string]any)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+
+	if recorder.Code == http.StatusOK {
+		return recorder.Code, int64(result["uid"].(float64))
+	}
+
+	return recorder.Code, int64(result["errorCode"].(float64))
+}
+
+func TestResolveCurrentLedger_Owner(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	token := createAuthorizationTestToken(t, authorizationTestLedgerOwnerUid, nil)
+
+	statusCode, uid := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/transactions/list.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, uid = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/accounts/add.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, uid = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/transactions/list.json", token, 0)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerOwnerUid), uid)
+}
+
+func TestResolveCurrentLedger_Editor(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	token := createAuthorizationTestToken(t, authorizationTestLedgerEditorUid, nil)
+
+	statusCode, uid := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/transactions/list.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, uid = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/accounts/list.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, uid = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/transactions/add.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, uid = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/transactions/delete.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, errorCode := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/accounts/add.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerPermissionDenied.Code()), errorCode)
+
+	statusCode, errorCode = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/budgets/delete.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerPermissionDenied.Code()), errorCode)
+
+	statusCode, errorCode = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/transaction/categories/delete.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerPermissionDenied.Code()), errorCode)
+
+	statusCode, errorCode = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/transactions/import.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerPermissionDenied.Code()), errorCode)
+}
+
+func TestResolveCurrentLedger_Viewer(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	token := createAuthorizationTestToken(t, authorizationTestLedgerViewerUid, nil)
+
+	statusCode, uid := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/transactions/list.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, uid = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/insights/explorers/query.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerDataUid), uid)
+
+	statusCode, errorCode := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/transactions/add.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerPermissionDenied.Code()), errorCode)
+
+	statusCode, errorCode = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/accounts/add.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerPermissionDenied.Code()), errorCode)
+}
+
+func TestResolveCurrentLedger_NonMember(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	token := createAuthorizationTestToken(t, authorizationTestNonMemberUid, nil)
+
+	statusCode, errorCode := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/transactions/list.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerNotFound.Code()), errorCode)
+
+	statusCode, errorCode = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/transactions/add.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, int64(errs.ErrLedgerNotFound.Code()), errorCode)
+
+	statusCode, uid := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/transactions/list.json", token, 0)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestNonMemberUid), uid)
+}
+
+func TestResolveCurrentLedger_NotSupportedRequestPath(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	token := createAuthorizationTestToken(t, authorizationTestLedgerEditorUid, nil)
+
+	statusCode, uid := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/users/profile/update.json", token, authorizationTestLedgerId)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestLedgerEditorUid), uid)
+}
+
+func TestJWTAuthorization_ScopedTokenOnRouteWithoutScopeRequirement(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	token := createAuthorizationTestToken(t, authorizationTestNonMemberUid, []core.TokenScope{core.USER_TOKEN_SCOPE_TRANSACTIONS_READ, core.USER_TOKEN_SCOPE_TRANSACTIONS_WRITE})
+
+	statusCode, errorCode := executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodGet, "/api/v1/users/profile/get.json", token, 0)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrCurrentTokenScopeNotGranted.Code()), errorCode)
+
+	statusCode, errorCode = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/users/profile/update.json", token, 0)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrCurrentTokenScopeNotGranted.Code()), errorCode)
+}
+
+func TestJWTAuthorizationWithScopes_ReadOnlyScopedToken(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	token := createAuthorizationTestToken(t, authorizationTestNonMemberUid, []core.TokenScope{core.USER_TOKEN_SCOPE_TRANSACTIONS_READ})
+	middleware := JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ, core.USER_TOKEN_SCOPE_TRANSACTIONS_WRITE)
+
+	statusCode, uid := executeAuthorizationTestRequest(t, middleware, http.MethodGet, "/api/v1/transactions/list.json", token, 0)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestNonMemberUid), uid)
+
+	statusCode, errorCode := executeAuthorizationTestRequest(t, middleware, http.MethodPost, "/api/v1/transactions/add.json", token, 0)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrCurrentTokenScopeNotGranted.Code()), errorCode)
+
+	statusCode, errorCode = executeAuthorizationTestRequest(t, JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_ACCOUNTS_READ, core.USER_TOKEN_SCOPE_ACCOUNTS_WRITE), http.MethodGet, "/api/v1/accounts/list.json", token, 0)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrCurrentTokenScopeNotGranted.Code()), errorCode)
+}
+
+func TestJWTAuthorizationWithScopes_UnscopedToken(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	apiToken := createAuthorizationTestToken(t, authorizationTestNonMemberUid, []core.TokenScope{})
+	normalToken := createAuthorizationTestToken(t, authorizationTestNonMemberUid, nil)
+	middleware := JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ, core.USER_TOKEN_SCOPE_TRANSACTIONS_WRITE)
+
+	for _, token := range []string{apiToken, normalToken} {
+		statusCode, uid := executeAuthorizationTestRequest(t, middleware, http.MethodGet, "/api/v1/transactions/list.json", token, 0)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, int64(authorizationTestNonMemberUid), uid)
+
+		statusCode, uid = executeAuthorizationTestRequest(t, middleware, http.MethodPost, "/api/v1/transactions/add.json", token, 0)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, int64(authorizationTestNonMemberUid), uid)
+
+		statusCode, uid = executeAuthorizationTestRequest(t, JWTAuthorization(config), http.MethodPost, "/api/v1/users/profile/update.json", token, 0)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, int64(authorizationTestNonMemberUid), uid)
+	}
+}
+
+func TestJWTAuthorizationWithScopes_ReadOnlyPostRequest(t *testing.T) {
+	config := initializeAuthorizationTestEnvironment(t)
+	readOnlyToken := createAuthorizationTestToken(t, authorizationTestNonMemberUid, []core.TokenScope{core.USER_TOKEN_SCOPE_TRANSACTIONS_READ})
+	accountsToken := createAuthorizationTestToken(t, authorizationTestNonMemberUid, []core.TokenScope{core.USER_TOKEN_SCOPE_ACCOUNTS_READ})
+	middleware := JWTAuthorizationWithScopes(config, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ, core.USER_TOKEN_SCOPE_TRANSACTIONS_READ)
+
+	statusCode, uid := executeAuthorizationTestRequest(t, middleware, http.MethodPost, "/api/v1/insights/explorers/query.json", readOnlyToken, 0)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, int64(authorizationTestNonMemberUid), uid)
+
+	statusCode, errorCode := executeAuthorizationTestRequest(t, middleware, http.MethodPost, "/api/v1/insights/explorers/query.json", accountsToken, 0)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, int64(errs.ErrCurrentTokenScopeNotGranted.Code()), errorCode)
+}
@@ -1,6 +1,9 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // LevelOneAccountParentId represents the parent id of level-one account
 const LevelOneAccountParentId = 0
@@ -55,6 +58,32 @@ func (c AccountCategory) IsLiability() bool {
 	return liabilityAccountCategory[c]
 }
 
+// String returns a textual representation of the account category enum
+func (c AccountCategory) String() string {
+	switch c {
+	case ACCOUNT_CATEGORY_CASH:
+		return "Cash"
+	case ACCOUNT_CATEGORY_CHECKING_ACCOUNT:
+		return "Checking Account"
+	case ACCOUNT_CATEGORY_CREDIT_CARD:
+		return "Credit Card"
+	case ACCOUNT_CATEGORY_VIRTUAL:
+		return "Virtual Account"
+	case ACCOUNT_CATEGORY_DEBT:
+		return "Debt Account"
+	case ACCOUNT_CATEGORY_RECEIVABLES:
+		return "Receivables"
+	case ACCOUNT_CATEGORY_INVESTMENT:
+		return "Investment Account"
+	case ACCOUNT_CATEGORY_SAVINGS_ACCOUNT:
+		return "Savings Account"
+	case ACCOUNT_CATEGORY_CERTIFICATE_OF_DEPOSIT:
+		return "Certificate of Deposit"
+	default:
+		return fmt.Sprintf("Invalid(%d)", int(c))
+	}
+}
+
 // AccountType represents account type
 type AccountType byte
 
@@ -90,40 +119,46 @@ type Account struct {
 
 // AccountExtend represents account extend data stored in database
 type AccountExtend struct {
-	CreditCardStatementDate *int `json:"creditCardStatementDate"`
+	CreditCardStatementDate  *int   `json:"creditCardStatementDate"`
+	CreditCardPaymentDueDate *int   `json:"creditCardPaymentDueDate,omitempty"`
+	CreditCardLimit          *int64 `json:"creditCardLimit,omitempty"`
 }
 
 // AccountCreateRequest represents all parameters of account creation request
 type AccountCreateRequest struct {
-	Name                    string                  `json:"name" binding:"required,notBlank,max=64"`
-	Category                AccountCategory         `json:"category" binding:"required"`
-	Type                    AccountType             `json:"type" binding:"required"`
-	Icon                    int64                   `json:"icon,string" binding:"required,min=1"`
-	Color                   string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
-	Currency                string                  `json:"currency" binding:"required,len=3,validCurrency"`
-	Balance                 int64                   `json:"balance"`
-	BalanceTime             int64                   `json:"balanceTime"`
-	Comment                 string                  `json:"comment" binding:"max=255"`
-	CreditCardStatementDate int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
-	SubAccounts             []*AccountCreateRequest `json:"subAccounts" binding:"omitempty"`
-	ClientSessionId         string                  `json:"clientSessionId"`
+	Name                     string                  `json:"name" binding:"required,notBlank,max=64"`
+	Category                 AccountCategory         `json:"category" binding:"required"`
+	Type                     AccountType             `json:"type" binding:"required"`
+	Icon                     int64                   `json:"icon,string" binding:"required,min=1"`
+	Color                    string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
+	Currency                 string                  `json:"currency" binding:"required,len=3,validCurrency"`
+	Balance                  int64                   `json:"balance"`
+	BalanceTime              int64                   `json:"balanceTime"`
+	Comment                  string                  `json:"comment" binding:"max=255"`
+	CreditCardStatementDate  int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
+	CreditCardPaymentDueDate int                     `json:"creditCardPaymentDueDate" binding:"min=0,max=28"`
+	CreditCardLimit          int64                   `json:"creditCardLimit" binding:"min=0,max=99999999999"`
+	SubAccounts              []*AccountCreateRequest `json:"subAccounts" binding:"omitempty"`
+	ClientSessionId          string                  `json:"clientSessionId"`
 }
 
 // AccountModifyRequest represents all parameters of account modification request
 type AccountModifyRequest struct {
-	Id                      int64                   `json:"id,string" binding:"required,min=0"`
-	Name                    string                  `json:"name" binding:"required,notBlank,max=64"`
-	Category                AccountCategory         `json:"category" binding:"required"`
-	Icon                    int64                   `json:"icon,string" binding:"min=1"`
-	Color                   string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
-	Currency                *string                 `json:"currency" binding:"omitempty,len=3,validCurrency"`
-	Balance                 *int64                  `json:"balance" binding:"omitempty"`
-	BalanceTime             *int64                  `json:"balanceTime" binding:"omitempty"`
-	Comment                 string                  `json:"comment" binding:"max=255"`
-	CreditCardStatementDate int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
-	Hidden                  bool                    `json:"hidden"`
-	SubAccounts             []*AccountModifyRequest `json:"subAccounts" binding:"omitempty"`
-	ClientSessionId         string                  `json:"clientSessionId"`
+	Id                       int64                   `json:"id,string" binding:"required,min=0"`
+	Name                     string                  `json:"name" binding:"required,notBlank,max=64"`
+	Category                 AccountCategory         `json:"category" binding:"required"`
+	Icon                     int64                   `json:"icon,string" binding:"min=1"`
+	Color                    string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
+	Currency                 *string                 `json:"currency" binding:"omitempty,len=3,validCurrency"`
+	Balance                  *int64                  `json:"balance" binding:"omitempty"`
+	BalanceTime              *int64                  `json:"balanceTime" binding:"omitempty"`
+	Comment                  string                  `json:"comment" binding:"max=255"`
+	CreditCardStatementDate  int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
+	CreditCardPaymentDueDate int                     `json:"creditCardPaymentDueDate" binding:"min=0,max=28"`
+	CreditCardLimit          int64                   `json:"creditCardLimit" binding:"min=0,max=99999999999"`
+	Hidden                   bool                    `json:"hidden"`
+	SubAccounts              []*AccountModifyRequest `json:"subAccounts" binding:"omitempty"`
+	ClientSessionId          string                  `json:"clientSessionId"`
 }
 
 // AccountListRequest represents all parameters of account listing request
@@ -160,53 +195,75 @@ type AccountDeleteRequest struct {
 
 // AccountInfoResponse represents a view-object of account
 type AccountInfoResponse struct {
-	Id                      int64                    `json:"id,string"`
-	Name                    string                   `json:"name"`
-	ParentId                int64                    `json:"parentId,string"`
-	Category                AccountCategory          `json:"category"`
-	Type                    AccountType              `json:"type"`
-	Icon                    int64                    `json:"icon,string"`
-	Color                   string                   `json:"color"`
-	Currency                string                   `json:"currency"`
-	Balance                 int64                    `json:"balance"`
-	Comment                 string                   `json:"comment"`
-	CreditCardStatementDate *int                     `json:"creditCardStatementDate,omitempty"`
-	DisplayOrder            int32                    `json:"displayOrder"`
-	IsAsset                 bool                     `json:"isAsset,omitempty"`
-	IsLiability             bool                     `json:"isLiability,omitempty"`
-	Hidden                  bool                     `json:"hidden"`
-	SubAccounts             AccountInfoResponseSlice `json:"subAccounts,omitempty"`
+	Id                       int64                    `json:"id,string"`
+	Name                     string                   `json:"name"`
+	ParentId                 int64                    `json:"parentId,string"`
+	Category                 AccountCategory          `json:"category"`
+	Type                     AccountType              `json:"type"`
+	Icon                     int64                    `json:"icon,string"`
+	Color                    string                   `json:"color"`
+	Currency                 string                   `json:"currency"`
+	Balance                  int64                    `json:"balance"`
+	Comment                  string                   `json:"comment"`
+	CreditCardStatementDate  *int                     `json:"creditCardStatementDate,omitempty"`
+	CreditCardPaymentDueDate *int                     `json:"creditCardPaymentDueDate,omitempty"`
+	CreditCardLimit          *int64                   `json:"creditCardLimit,omitempty"`
+	DisplayOrder             int32                    `json:"displayOrder"`
+	IsAsset                  bool                     `json:"isAsset,omitempty"`
+	IsLiability              bool                     `json:"isLiability,omitempty"`
+	Hidden                   bool                     `json:"hidden"`
+	SubAccounts              AccountInfoResponseSlice `json:"subAccounts,omitempty"`
 }
 
 // ToAccountInfoResponse returns a view-object according to database model
 func (a *Account) ToAccountInfoResponse() *AccountInfoResponse {
 	var creditCardStatementDate *int
+	var creditCardPaymentDueDate *int
+	var creditCardLimit *int64
 
 	if a.ParentAccountId == LevelOneAccountParentId && a.Category == ACCOUNT_CATEGORY_CREDIT_CARD {
 		if a.Extend != nil {
 			creditCardStatementDate = a.Extend.CreditCardStatementDate
+			creditCardPaymentDueDate = a.Extend.CreditCardPaymentDueDate
+			creditCardLimit = a.Extend.CreditCardLimit
 		} else {
 			creditCardStatementDate = &defaultCreditCardAccountStatementDate
 		}
 	}
 
 	return &AccountInfoResponse{
-		Id:                      a.AccountId,
-		Name:                    a.Name,
-		ParentId:                a.ParentAccountId,
-		Category:                a.Category,
-		Type:                    a.Type,
-		Icon:                    a.Icon,
-		Color:                   a.Color,
-		Currency:                a.Currency,
-		Balance:                 a.Balance,
-		Comment:                 a.Comment,
-		CreditCardStatementDate: creditCardStatementDate,
-		DisplayOrder:            a.DisplayOrder,
-		IsAsset:                 assetAccountCategory[a.Category],
-		IsLiability:             liabilityAccountCategory[a.Category],
-		Hidden:                  a.Hidden,
+		Id:                       a.AccountId,
+		Name:                     a.Name,
+		ParentId:                 a.ParentAccountId,
+		Category:                 a.Category,
+		Type:                     a.Type,
+		Icon:                     a.Icon,
+		Color:                    a.Color,
+		Currency:                 a.Currency,
+		Balance:                  a.Balance,
+		Comment:                  a.Comment,
+		CreditCardStatementDate:  creditCardStatementDate,
+		CreditCardPaymentDueDate: creditCardPaymentDueDate,
+		CreditCardLimit:          creditCardLimit,
+		DisplayOrder:             a.DisplayOrder,
+		IsAsset:                  assetAccountCategory[a.Category],
+		IsLiability:              liabilityAccountCategory[a.Category],
+		Hidden:                   a.Hidden,
+	}
+}
+
+// GetCreditCardStatementSettings returns the statement date and payment due date of credit card account, returns false if the account does not have statement settings
+func (a *Account) GetCreditCardStatementSettings() (int, int, bool) {
+	if a.ParentAccountId != LevelOneAccountParentId || a.Category != ACCOUNT_CATEGORY_CREDIT_CARD || a.Type != ACCOUNT_TYPE_SINGLE_ACCOUNT || a.Extend == nil {
+		return 0, 0, false
 	}
+
+	if a.Extend.CreditCardStatementDate == nil || *a.Extend.CreditCardStatementDate < 1 ||
+		a.Extend.CreditCardPaymentDueDate == nil || *a.Extend.CreditCardPaymentDueDate < 1 {
+		return 0, 0, false
+	}
+
+	return *a.Extend.CreditCardStatementDate, *a.Extend.CreditCardPaymentDueDate, true
 }
 
 // FromDB fills the fields from the data stored in database
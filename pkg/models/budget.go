@@ -0,0 +1,200 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+// BudgetPeriodType represents budget period type
+type BudgetPeriodType byte
+
+// Budget period types
+const (
+	BUDGET_PERIOD_TYPE_WEEKLY  BudgetPeriodType = 1
+	BUDGET_PERIOD_TYPE_MONTHLY BudgetPeriodType = 2
+	BUDGET_PERIOD_TYPE_YEARLY  BudgetPeriodType = 3
+)
+
+// BudgetAllExpenseCategoriesId represents the category id of budget which tracks all expense categories
+const BudgetAllExpenseCategoriesId = int64(0)
+
+// Budget represents budget data stored in database
+type Budget struct {
+	BudgetId        int64            `xorm:"PK"`
+	Uid             int64            `xorm:"INDEX(IDX_budget_uid_deleted_order) NOT NULL"`
+	Deleted         bool             `xorm:"INDEX(IDX_budget_uid_deleted_order) NOT NULL"`
+	Name            string           `xorm:"VARCHAR(64) NOT NULL"`
+	CategoryId      int64            `xorm:"NOT NULL"`
+	PeriodType      BudgetPeriodType `xorm:"TINYINT NOT NULL"`
+	Amount          int64            `xorm:"NOT NULL"`
+	Currency        string           `xorm:"VARCHAR(3) NOT NULL"`
+	EnableRollover  bool             `xorm:"NOT NULL"`
+	StartTime       int64            `xorm:"NOT NULL"`
+	DisplayOrder    int32            `xorm:"INDEX(IDX_budget_uid_deleted_order) NOT NULL"`
+	Hidden          bool             `xorm:"NOT NULL"`
+	Comment         string           `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// BudgetCreateRequest represents all parameters of budget creation request
+type BudgetCreateRequest struct {
+	Name            string           `json:"name" binding:"required,notBlank,max=64"`
+	CategoryId      int64            `json:"categoryId,string" binding:"min=0"`
+	PeriodType      BudgetPeriodType `json:"periodType" binding:"required"`
+	Amount          int64            `json:"amount" binding:"min=1,max=99999999999"`
+	Currency        string           `json:"currency" binding:"required,len=3,validCurrency"`
+	EnableRollover  bool             `json:"enableRollover"`
+	Comment         string           `json:"comment" binding:"max=255"`
+	ClientSessionId string           `json:"clientSessionId"`
+}
+
+// BudgetModifyRequest represents all parameters of budget modification request
+type BudgetModifyRequest struct {
+	Id             int64            `json:"id,string" binding:"required,min=1"`
+	Name           string           `json:"name" binding:"required,notBlank,max=64"`
+	CategoryId     int64            `json:"categoryId,string" binding:"min=0"`
+	PeriodType     BudgetPeriodType `json:"periodType" binding:"required"`
+	Amount         int64            `json:"amount" binding:"min=1,max=99999999999"`
+	Currency       string           `json:"currency" binding:"required,len=3,validCurrency"`
+	EnableRollover bool             `json:"enableRollover"`
+	Comment        string           `json:"comment" binding:"max=255"`
+	Hidden         bool             `json:"hidden"`
+}
+
+// BudgetGetRequest represents all parameters of budget getting request
+type BudgetGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// BudgetHideRequest represents all parameters of budget hiding request
+type BudgetHideRequest struct {
+	Id     int64 `json:"id,string" binding:"required,min=1"`
+	Hidden bool  `json:"hidden"`
+}
+
+// BudgetMoveRequest represents all parameters of budget moving request
+type BudgetMoveRequest struct {
+	NewDisplayOrders []*BudgetNewDisplayOrderRequest `json:"newDisplayOrders" binding:"required,min=1"`
+}
+
+// BudgetNewDisplayOrderRequest represents a data pair of id and display order
+type BudgetNewDisplayOrderRequest struct {
+	Id           int64 `json:"id,string" binding:"required,min=1"`
+	DisplayOrder int32 `json:"displayOrder"`
+}
+
+// BudgetDeleteRequest represents all parameters of budget deleting request
+type BudgetDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// BudgetProgressRequest represents all parameters of budget progress getting request
+type BudgetProgressRequest struct {
+	Id   int64 `form:"id,string" binding:"required,min=1"`
+	Time int64 `form:"time" binding:"min=0"`
+}
+
+// BudgetInfoResponse represents a view-object of budget
+type BudgetInfoResponse struct {
+	Id             int64            `json:"id,string"`
+	Name           string           `json:"name"`
+	CategoryId     int64            `json:"categoryId,string"`
+	PeriodType     BudgetPeriodType `json:"periodType"`
+	Amount         int64            `json:"amount"`
+	Currency       string           `json:"currency"`
+	EnableRollover bool             `json:"enableRollover"`
+	StartTime      int64            `json:"startTime"`
+	Comment        string           `json:"comment"`
+	DisplayOrder   int32            `json:"displayOrder"`
+	Hidden         bool             `json:"hidden"`
+}
+
+// BudgetProgressResponse represents a view-object of budget progress in one period
+type BudgetProgressResponse struct {
+	BudgetId        int64  `json:"budgetId,string"`
+	PeriodStartTime int64  `json:"periodStartTime"`
+	PeriodEndTime   int64  `json:"periodEndTime"`
+	Currency        string `json:"currency"`
+	Amount          int64  `json:"amount"`
+	RolloverAmount  int64  `json:"rolloverAmount"`
+	AvailableAmount int64  `json:"availableAmount"`
+	SpentAmount     int64  `json:"spentAmount"`
+	RemainingAmount int64  `json:"remainingAmount"`
+	Overspent       bool   `json:"overspent"`
+}
+
+// IsValid returns whether the budget period type is valid
+func (t BudgetPeriodType) IsValid() bool {
+	return t == BUDGET_PERIOD_TYPE_WEEKLY || t == BUDGET_PERIOD_TYPE_MONTHLY || t == BUDGET_PERIOD_TYPE_YEARLY
+}
+
+// GetPeriodRange returns the start time (inclusive) and end time (exclusive) of the budget period which contains the specified time,
+// the week starts at the specified first day of week and the year starts at the specified fiscal year start
+func (t BudgetPeriodType) GetPeriodRange(currentTime time.Time, firstDayOfWeek core.WeekDay, fiscalYearStart core.FiscalYearStart) (time.Time, time.Time) {
+	year, month, day := currentTime.Date()
+	location := currentTime.Location()
+
+	switch t {
+	case BUDGET_PERIOD_TYPE_WEEKLY:
+		offset := (int(currentTime.Weekday()) - int(firstDayOfWeek) + 7) % 7
+		startTime := time.Date(year, month, day-offset, 0, 0, 0, 0, location)
+		return startTime, startTime.AddDate(0, 0, 7)
+	case BUDGET_PERIOD_TYPE_MONTHLY:
+		startTime := time.Date(year, month, 1, 0, 0, 0, 0, location)
+		return startTime, startTime.AddDate(0, 1, 0)
+	case BUDGET_PERIOD_TYPE_YEARLY:
+		fiscalYearStartMonth, fiscalYearStartDay, err := fiscalYearStart.GetMonthDay()
+
+		if err != nil {
+			fiscalYearStartMonth, fiscalYearStartDay, _ = core.FISCAL_YEAR_START_DEFAULT.GetMonthDay()
+		}
+
+		startTime := time.Date(year, time.Month(fiscalYearStartMonth), int(fiscalYearStartDay), 0, 0, 0, 0, location)
+
+		if currentTime.Before(startTime) {
+			startTime = startTime.AddDate(-1, 0, 0)
+		}
+
+		return startTime, startTime.AddDate(1, 0, 0)
+	default:
+		return currentTime, currentTime
+	}
+}
+
+// ToBudgetInfoResponse returns a view-object according to database model
+func (b *Budget) ToBudgetInfoResponse() *BudgetInfoResponse {
+	return &BudgetInfoResponse{
+		Id:             b.BudgetId,
+		Name:           b.Name,
+		CategoryId:     b.CategoryId,
+		PeriodType:     b.PeriodType,
+		Amount:         b.Amount,
+		Currency:       b.Currency,
+		EnableRollover: b.EnableRollover,
+		StartTime:      b.StartTime,
+		Comment:        b.Comment,
+		DisplayOrder:   b.DisplayOrder,
+		Hidden:         b.Hidden,
+	}
+}
+
+// BudgetInfoResponseSlice represents the slice data structure of BudgetInfoResponse
+type BudgetInfoResponseSlice []*BudgetInfoResponse
+
+// Len returns the count of items
+func (s BudgetInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s BudgetInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s BudgetInfoResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+func TestBudgetPeriodTypeGetPeriodRange_Weekly(t *testing.T) {
+	currentTime := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC) // Wednesday
+
+	startTime, endTime := BUDGET_PERIOD_TYPE_WEEKLY.GetPeriodRange(currentTime, core.WEEKDAY_MONDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), endTime)
+
+	startTime, endTime = BUDGET_PERIOD_TYPE_WEEKLY.GetPeriodRange(currentTime, core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Equal(t, time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC), endTime)
+
+	startTime, endTime = BUDGET_PERIOD_TYPE_WEEKLY.GetPeriodRange(currentTime, core.WEEKDAY_THURSDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Equal(t, time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC), endTime)
+}
+
+func TestBudgetPeriodTypeGetPeriodRange_Monthly(t *testing.T) {
+	currentTime := time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	startTime, endTime := BUDGET_PERIOD_TYPE_MONTHLY.GetPeriodRange(currentTime, core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), endTime)
+}
+
+func TestBudgetPeriodTypeGetPeriodRange_Yearly(t *testing.T) {
+	fiscalYearStart, _ := core.NewFiscalYearStart(4, 1)
+
+	startTime, endTime := BUDGET_PERIOD_TYPE_YEARLY.GetPeriodRange(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), core.WEEKDAY_SUNDAY, fiscalYearStart)
+	assert.Equal(t, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), endTime)
+
+	startTime, endTime = BUDGET_PERIOD_TYPE_YEARLY.GetPeriodRange(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), core.WEEKDAY_SUNDAY, fiscalYearStart)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), endTime)
+
+	startTime, endTime = BUDGET_PERIOD_TYPE_YEARLY.GetPeriodRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_INVALID)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), endTime)
+}
+
+func TestBudgetPeriodTypeIsValid(t *testing.T) {
+	assert.True(t, BUDGET_PERIOD_TYPE_WEEKLY.IsValid())
+	assert.True(t, BUDGET_PERIOD_TYPE_MONTHLY.IsValid())
+	assert.True(t, BUDGET_PERIOD_TYPE_YEARLY.IsValid())
+	assert.False(t, BudgetPeriodType(0).IsValid())
+	assert.False(t, BudgetPeriodType(4).IsValid())
+}
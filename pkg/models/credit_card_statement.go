@@ -0,0 +1,236 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// CreditCardStatementMinimumPaymentRate represents the percentage of statement balance which is the minimum payment due
+const CreditCardStatementMinimumPaymentRate = 10
+
+// MaximumCreditCardStatementCount represents the maximum count of credit card statements which can be queried at once
+const MaximumCreditCardStatementCount = 24
+
+// DefaultCreditCardStatementCount represents the default count of credit card statements when not specified
+const DefaultCreditCardStatementCount = 12
+
+// CreditCardStatementStatus represents the payment status of credit card statement
+type CreditCardStatementStatus byte
+
+// Credit card statement statuses
+const (
+	CREDIT_CARD_STATEMENT_STATUS_NO_BALANCE CreditCardStatementStatus = 1
+	CREDIT_CARD_STATEMENT_STATUS_PAID       CreditCardStatementStatus = 2
+	CREDIT_CARD_STATEMENT_STATUS_UNPAID     CreditCardStatementStatus = 3
+	CREDIT_CARD_STATEMENT_STATUS_OVERDUE    CreditCardStatementStatus = 4
+)
+
+// CreditCardStatementCycle represents the time range of a credit card statement cycle and its payment due time
+type CreditCardStatementCycle struct {
+	StartUnixTime int64
+	EndUnixTime   int64
+	DueUnixTime   int64
+}
+
+// CreditCardStatement represents a credit card statement calculated from account transactions
+type CreditCardStatement struct {
+	AccountId        int64
+	StartUnixTime    int64
+	EndUnixTime      int64
+	DueUnixTime      int64
+	OpeningBalance   int64
+	Charges          int64
+	Payments         int64
+	ClosingBalance   int64
+	StatementBalance int64
+	MinimumDue       int64
+	PaidAmount       int64
+	Status           CreditCardStatementStatus
+}
+
+// CreditCardOverdueStatement represents a credit card statement which is still unpaid after the due date, stored in database
+type CreditCardOverdueStatement struct {
+	Uid               int64 `xorm:"PK INDEX(IDX_credit_card_overdue_statement_uid_deleted_due_time)"`
+	AccountId         int64 `xorm:"PK"`
+	StatementUnixTime int64 `xorm:"PK"`
+	Deleted           bool  `xorm:"INDEX(IDX_credit_card_overdue_statement_uid_deleted_due_time) NOT NULL"`
+	DueUnixTime       int64 `xorm:"INDEX(IDX_credit_card_overdue_statement_uid_deleted_due_time) NOT NULL"`
+	StatementBalance  int64 `xorm:"NOT NULL"`
+	MinimumDue        int64 `xorm:"NOT NULL"`
+	PaidAmount        int64 `xorm:"NOT NULL"`
+	CreatedUnixTime   int64
+	UpdatedUnixTime   int64
+	DeletedUnixTime   int64
+}
+
+// CreditCardStatementListRequest represents all parameters of credit card statement listing request
+type CreditCardStatementListRequest struct {
+	Id    int64 `form:"id,string" binding:"required,min=1"`
+	Count int32 `form:"count" binding:"omitempty,min=1,max=24"`
+}
+
+// CreditCardStatementResponse represents a view-object of credit card statement
+type CreditCardStatementResponse struct {
+	AccountId        int64                     `json:"accountId,string"`
+	StartTime        int64                     `json:"startTime"`
+	EndTime          int64                     `json:"endTime"`
+	DueTime          int64                     `json:"dueTime"`
+	OpeningBalance   int64                     `json:"openingBalance"`
+	Charges          int64                     `json:"charges"`
+	Payments         int64                     `json:"payments"`
+	ClosingBalance   int64                     `json:"closingBalance"`
+	StatementBalance int64                     `json:"statementBalance"`
+	MinimumDue       int64                     `json:"minimumDue"`
+	PaidAmount       int64                     `json:"paidAmount"`
+	Status           CreditCardStatementStatus `json:"status"`
+}
+
+// CreditCardOverdueStatementResponse represents a view-object of credit card overdue statement
+type CreditCardOverdueStatementResponse struct {
+	AccountId        int64 `json:"accountId,string"`
+	StatementTime    int64 `json:"statementTime"`
+	DueTime          int64 `json:"dueTime"`
+	StatementBalance int64 `json:"statementBalance"`
+	MinimumDue       int64 `json:"minimumDue"`
+	PaidAmount       int64 `json:"paidAmount"`
+}
+
+// GetCreditCardStatementCycle returns the statement cycle which closes on the statement date of specified month, the due date is in the same month if it is later than statement date, otherwise in the next month
+func GetCreditCardStatementCycle(statementDate int, paymentDueDate int, year int, month time.Month, location *time.Location) *CreditCardStatementCycle {
+	startTime := time.Date(year, month-1, statementDate+1, 0, 0, 0, 0, location)
+	nextStartTime := time.Date(year, month, statementDate+1, 0, 0, 0, 0, location)
+	dueMonth := month
+
+	if paymentDueDate <= statementDate {
+		dueMonth = month + 1
+	}
+
+	nextDueTime := time.Date(year, dueMonth, paymentDueDate+1, 0, 0, 0, 0, location)
+
+	return &CreditCardStatementCycle{
+		StartUnixTime: startTime.Unix(),
+		EndUnixTime:   nextStartTime.Unix() - 1,
+		DueUnixTime:   nextDueTime.Unix() - 1,
+	}
+}
+
+// GetLatestClosedCreditCardStatementCycles returns the cycles of latest closed credit card statements before specified time (the latest one is the first)
+func GetLatestClosedCreditCardStatementCycles(statementDate int, paymentDueDate int, now time.Time, count int) []*CreditCardStatementCycle {
+	year := now.Year()
+	month := now.Month()
+
+	if now.Unix() < time.Date(year, month, statementDate+1, 0, 0, 0, 0, now.Location()).Unix() {
+		month = month - 1
+	}
+
+	cycles := make([]*CreditCardStatementCycle, count)
+
+	for i := 0; i < count; i++ {
+		cycles[i] = GetCreditCardStatementCycle(statementDate, paymentDueDate, year, month-time.Month(i), now.Location())
+	}
+
+	return cycles
+}
+
+// CalculateCreditCardStatement returns the credit card statement of specified cycle according to all transactions (in ascending order of transaction time) of the account
+func CalculateCreditCardStatement(accountId int64, transactions []*Transaction, cycle *CreditCardStatementCycle, nowUnixTime int64) *CreditCardStatement {
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(cycle.StartUnixTime)
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(cycle.EndUnixTime)
+	maxDueTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(cycle.DueUnixTime)
+
+	statement := &CreditCardStatement{
+		AccountId:     accountId,
+		StartUnixTime: cycle.StartUnixTime,
+		EndUnixTime:   cycle.EndUnixTime,
+		DueUnixTime:   cycle.DueUnixTime,
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.AccountId != accountId {
+			continue
+		}
+
+		if transaction.TransactionTime > maxDueTransactionTime {
+			break
+		}
+
+		inflow, outflow := getCreditCardTransactionInflowAndOutflow(transaction)
+
+		if transaction.TransactionTime < minTransactionTime {
+			statement.OpeningBalance = statement.OpeningBalance + inflow - outflow
+		} else if transaction.TransactionTime <= maxTransactionTime {
+			statement.Payments = statement.Payments + inflow
+			statement.Charges = statement.Charges + outflow
+		} else {
+			statement.PaidAmount = statement.PaidAmount + inflow
+		}
+	}
+
+	statement.ClosingBalance = statement.OpeningBalance - statement.Charges + statement.Payments
+
+	if statement.ClosingBalance < 0 {
+		statement.StatementBalance = -statement.ClosingBalance
+		statement.MinimumDue = (statement.StatementBalance*CreditCardStatementMinimumPaymentRate + 99) / 100
+	}
+
+	if statement.StatementBalance == 0 {
+		statement.Status = CREDIT_CARD_STATEMENT_STATUS_NO_BALANCE
+	} else if statement.PaidAmount >= statement.StatementBalance {
+		statement.Status = CREDIT_CARD_STATEMENT_STATUS_PAID
+	} else if nowUnixTime > statement.DueUnixTime {
+		statement.Status = CREDIT_CARD_STATEMENT_STATUS_OVERDUE
+	} else {
+		statement.Status = CREDIT_CARD_STATEMENT_STATUS_UNPAID
+	}
+
+	return statement
+}
+
+// ToCreditCardStatementResponse returns a view-object according to credit card statement
+func (s *CreditCardStatement) ToCreditCardStatementResponse() *CreditCardStatementResponse {
+	return &CreditCardStatementResponse{
+		AccountId:        s.AccountId,
+		StartTime:        s.StartUnixTime,
+		EndTime:          s.EndUnixTime,
+		DueTime:          s.DueUnixTime,
+		OpeningBalance:   s.OpeningBalance,
+		Charges:          s.Charges,
+		Payments:         s.Payments,
+		ClosingBalance:   s.ClosingBalance,
+		StatementBalance: s.StatementBalance,
+		MinimumDue:       s.MinimumDue,
+		PaidAmount:       s.PaidAmount,
+		Status:           s.Status,
+	}
+}
+
+// ToCreditCardOverdueStatementResponse returns a view-object according to database model
+func (s *CreditCardOverdueStatement) ToCreditCardOverdueStatementResponse() *CreditCardOverdueStatementResponse {
+	return &CreditCardOverdueStatementResponse{
+		AccountId:        s.AccountId,
+		StatementTime:    s.StatementUnixTime,
+		DueTime:          s.DueUnixTime,
+		StatementBalance: s.StatementBalance,
+		MinimumDue:       s.MinimumDue,
+		PaidAmount:       s.PaidAmount,
+	}
+}
+
+func getCreditCardTransactionInflowAndOutflow(transaction *Transaction) (int64, int64) {
+	if transaction.Type == TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+		if transaction.RelatedAccountAmount >= 0 {
+			return transaction.RelatedAccountAmount, 0
+		} else {
+			return 0, -transaction.RelatedAccountAmount
+		}
+	} else if transaction.Type == TRANSACTION_DB_TYPE_INCOME || transaction.Type == TRANSACTION_DB_TYPE_TRANSFER_IN {
+		return transaction.Amount, 0
+	} else if transaction.Type == TRANSACTION_DB_TYPE_EXPENSE || transaction.Type == TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		return 0, transaction.Amount
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestGetCreditCardStatementCycle_DueDateInSameMonth(t *testing.T) {
+	cycle := GetCreditCardStatementCycle(5, 25, 2024, time.March, time.UTC)
+
+	assert.Equal(t, time.Date(2024, time.February, 6, 0, 0, 0, 0, time.UTC).Unix(), cycle.StartUnixTime)
+	assert.Equal(t, time.Date(2024, time.March, 5, 23, 59, 59, 0, time.UTC).Unix(), cycle.EndUnixTime)
+	assert.Equal(t, time.Date(2024, time.March, 25, 23, 59, 59, 0, time.UTC).Unix(), cycle.DueUnixTime)
+}
+
+func TestGetCreditCardStatementCycle_DueDateInNextMonth(t *testing.T) {
+	cycle := GetCreditCardStatementCycle(20, 8, 2024, time.December, time.UTC)
+
+	assert.Equal(t, time.Date(2024, time.November, 21, 0, 0, 0, 0, time.UTC).Unix(), cycle.StartUnixTime)
+	assert.Equal(t, time.Date(2024, time.December, 20, 23, 59, 59, 0, time.UTC).Unix(), cycle.EndUnixTime)
+	assert.Equal(t, time.Date(2025, time.January, 8, 23, 59, 59, 0, time.UTC).Unix(), cycle.DueUnixTime)
+}
+
+func TestGetLatestClosedCreditCardStatementCycles(t *testing.T) {
+	cycles := GetLatestClosedCreditCardStatementCycles(10, 28, time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC), 2)
+
+	assert.Equal(t, 2, len(cycles))
+	assert.Equal(t, time.Date(2023, time.December, 10, 23, 59, 59, 0, time.UTC).Unix(), cycles[0].EndUnixTime)
+	assert.Equal(t, time.Date(2023, time.November, 10, 23, 59, 59, 0, time.UTC).Unix(), cycles[1].EndUnixTime)
+
+	cycles = GetLatestClosedCreditCardStatementCycles(10, 28, time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC), 1)
+
+	assert.Equal(t, 1, len(cycles))
+	assert.Equal(t, time.Date(2024, time.January, 10, 23, 59, 59, 0, time.UTC).Unix(), cycles[0].EndUnixTime)
+}
+
+func TestCalculateCreditCardStatement(t *testing.T) {
+	cycle := GetCreditCardStatementCycle(5, 25, 2024, time.March, time.UTC)
+	transactions := []*Transaction{
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_MODIFY_BALANCE, RelatedAccountAmount: -10000, TransactionTime: getTestTransactionTime(2024, time.January, 1)},
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 2000, TransactionTime: getTestTransactionTime(2024, time.February, 5)},
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 3000, TransactionTime: getTestTransactionTime(2024, time.February, 6)},
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_TRANSFER_IN, Amount: 12000, TransactionTime: getTestTransactionTime(2024, time.February, 10)},
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_TRANSFER_OUT, Amount: 1000, TransactionTime: getTestTransactionTime(2024, time.March, 5)},
+		{AccountId: 2, Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 9999, TransactionTime: getTestTransactionTime(2024, time.March, 1)},
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_TRANSFER_IN, Amount: 2000, TransactionTime: getTestTransactionTime(2024, time.March, 20)},
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_TRANSFER_IN, Amount: 5000, TransactionTime: getTestTransactionTime(2024, time.March, 26)},
+	}
+
+	statement := CalculateCreditCardStatement(1, transactions, cycle, time.Date(2024, time.March, 26, 0, 0, 0, 0, time.UTC).Unix())
+
+	assert.Equal(t, int64(-12000), statement.OpeningBalance)
+	assert.Equal(t, int64(4000), statement.Charges)
+	assert.Equal(t, int64(12000), statement.Payments)
+	assert.Equal(t, int64(-4000), statement.ClosingBalance)
+	assert.Equal(t, int64(4000), statement.StatementBalance)
+	assert.Equal(t, int64(400), statement.MinimumDue)
+	assert.Equal(t, int64(2000), statement.PaidAmount)
+	assert.Equal(t, CREDIT_CARD_STATEMENT_STATUS_OVERDUE, statement.Status)
+
+	statement = CalculateCreditCardStatement(1, transactions, cycle, time.Date(2024, time.March, 21, 0, 0, 0, 0, time.UTC).Unix())
+	assert.Equal(t, CREDIT_CARD_STATEMENT_STATUS_UNPAID, statement.Status)
+
+	transactions[6].Amount = 4000
+	statement = CalculateCreditCardStatement(1, transactions, cycle, time.Date(2024, time.March, 26, 0, 0, 0, 0, time.UTC).Unix())
+	assert.Equal(t, CREDIT_CARD_STATEMENT_STATUS_PAID, statement.Status)
+}
+
+func TestCalculateCreditCardStatement_NoBalance(t *testing.T) {
+	cycle := GetCreditCardStatementCycle(5, 25, 2024, time.March, time.UTC)
+	transactions := []*Transaction{
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 2000, TransactionTime: getTestTransactionTime(2024, time.February, 10)},
+		{AccountId: 1, Type: TRANSACTION_DB_TYPE_INCOME, Amount: 3000, TransactionTime: getTestTransactionTime(2024, time.February, 11)},
+	}
+
+	statement := CalculateCreditCardStatement(1, transactions, cycle, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC).Unix())
+
+	assert.Equal(t, int64(1000), statement.ClosingBalance)
+	assert.Equal(t, int64(0), statement.StatementBalance)
+	assert.Equal(t, int64(0), statement.MinimumDue)
+	assert.Equal(t, CREDIT_CARD_STATEMENT_STATUS_NO_BALANCE, statement.Status)
+}
+
+func getTestTransactionTime(year int, month time.Month, day int) int64 {
+	return utils.GetMinTransactionTimeFromUnixTime(time.Date(year, month, day, 12, 0, 0, 0, time.UTC).Unix())
+}
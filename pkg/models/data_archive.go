@@ -0,0 +1,50 @@
+package models
+
+// DataArchiveCurrentVersion represents the current version of ezbookkeeping data archive,
+// version 2 adds transaction splits, transaction rules, credit card overdue statements and webhooks
+const DataArchiveCurrentVersion = 2
+
+// DataArchiveMinimumSupportedVersion represents the minimum version of ezbookkeeping data archive which can be imported
+const DataArchiveMinimumSupportedVersion = 1
+
+// DataArchiveDataFileName represents the file name of book data in ezbookkeeping data archive
+const DataArchiveDataFileName = "data.json"
+
+// DataArchivePictureDirectory represents the directory of transaction pictures in ezbookkeeping data archive
+const DataArchivePictureDirectory = "pictures/"
+
+// DataArchive represents all book data of a user in ezbookkeeping data archive
+type DataArchive struct {
+	Version                     int                           `json:"version"`
+	ExportedUnixTime            int64                         `json:"exportedUnixTime"`
+	Accounts                    []*Account                    `json:"accounts"`
+	TransactionCategories       []*TransactionCategory        `json:"transactionCategories"`
+	TransactionTagGroups        []*TransactionTagGroup        `json:"transactionTagGroups"`
+	TransactionTags             []*TransactionTag             `json:"transactionTags"`
+	TransactionItemGroups       []*TransactionItemGroup       `json:"transactionItemGroups"`
+	TransactionItems            []*TransactionItem            `json:"transactionItems"`
+	Transactions                []*Transaction                `json:"transactions"`
+	TransactionTagIndexes       []*TransactionTagIndex        `json:"transactionTagIndexes"`
+	TransactionItemIndexes      []*TransactionItemIndex       `json:"transactionItemIndexes"`
+	TransactionSplits           []*TransactionSplit           `json:"transactionSplits"`
+	TransactionPictureInfos     []*TransactionPictureInfo     `json:"transactionPictureInfos"`
+	TransactionTemplates        []*TransactionTemplate        `json:"transactionTemplates"`
+	TransactionRules            []*TransactionRule            `json:"transactionRules"`
+	Budgets                     []*Budget                     `json:"budgets"`
+	CreditCardOverdueStatements []*CreditCardOverdueStatement `json:"creditCardOverdueStatements"`
+	Webhooks                    []*Webhook                    `json:"webhooks"`
+}
+
+// DataArchiveImportResponse represents a view-object of data archive import result
+type DataArchiveImportResponse struct {
+	AccountCount             int `json:"accountCount"`
+	TransactionCategoryCount int `json:"transactionCategoryCount"`
+	TransactionTagCount      int `json:"transactionTagCount"`
+	TransactionItemCount     int `json:"transactionItemCount"`
+	TransactionCount         int `json:"transactionCount"`
+	TransactionPictureCount  int `json:"transactionPictureCount"`
+	TransactionTemplateCount int `json:"transactionTemplateCount"`
+	TransactionRuleCount     int `json:"transactionRuleCount"`
+	BudgetCount              int `json:"budgetCount"`
+	WebhookCount             int `json:"webhookCount"`
+}
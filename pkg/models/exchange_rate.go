@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"strings"
 
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -114,3 +115,49 @@ func (s LatestExchangeRateSlice) Swap(i, j int) {
 func (s LatestExchangeRateSlice) Less(i, j int) bool {
 	return strings.Compare(s[i].Currency, s[j].Currency) < 0
 }
+
+// ExchangeRateMap represents the exchange rates of all currencies relative to the same base currency
+type ExchangeRateMap map[string]float64
+
+// ToExchangeRateMap returns the exchange rate map which includes base currency
+func (r *LatestExchangeRateResponse) ToExchangeRateMap() ExchangeRateMap {
+	exchangeRateMap := make(ExchangeRateMap, len(r.ExchangeRates)+1)
+
+	for i := 0; i < len(r.ExchangeRates); i++ {
+		exchangeRate := r.ExchangeRates[i]
+		rate, err := utils.StringToFloat64(exchangeRate.Rate)
+
+		if err != nil || rate <= 0 {
+			continue
+		}
+
+		exchangeRateMap[exchangeRate.Currency] = rate
+	}
+
+	if r.BaseCurrency != "" {
+		exchangeRateMap[r.BaseCurrency] = 1
+	}
+
+	return exchangeRateMap
+}
+
+// ExchangeAmount returns the amount exchanged from the source currency to the target currency, returns false if any exchange rate is missing
+func (m ExchangeRateMap) ExchangeAmount(amount int64, fromCurrency string, toCurrency string) (int64, bool) {
+	if fromCurrency == toCurrency {
+		return amount, true
+	}
+
+	fromRate, exists := m[fromCurrency]
+
+	if !exists || fromRate <= 0 {
+		return 0, false
+	}
+
+	toRate, exists := m[toCurrency]
+
+	if !exists || toRate <= 0 {
+		return 0, false
+	}
+
+	return int64(math.Round(float64(amount) / fromRate * toRate)), true
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// ExchangeRateHistory represents the exchange rate of a currency on a specified date saved from exchange rates data source
+type ExchangeRateHistory struct {
+	Uid             int64  `xorm:"PK NOT NULL"`
+	DataSource      string `xorm:"PK VARCHAR(32) NOT NULL"`
+	RateDate        int32  `xorm:"PK NOT NULL"`
+	Currency        string `xorm:"PK VARCHAR(3) NOT NULL"`
+	BaseCurrency    string `xorm:"VARCHAR(3) NOT NULL"`
+	Rate            string `xorm:"VARCHAR(32) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+}
+
+// HistoricalExchangeRates represents the exchange rates of all currencies on a specified date (in yyyymmdd format)
+type HistoricalExchangeRates struct {
+	RateDate      int32
+	BaseCurrency  string
+	ExchangeRates LatestExchangeRateSlice
+}
+
+// HistoricalExchangeRatesSlice represents the slice data structure of HistoricalExchangeRates
+type HistoricalExchangeRatesSlice []*HistoricalExchangeRates
+
+// HistoricalExchangeRateMap represents the exchange rates of all currencies on each date
+type HistoricalExchangeRateMap struct {
+	rateDates             []int32
+	exchangeRateMaps      map[int32]ExchangeRateMap
+	latestExchangeRateMap ExchangeRateMap
+}
+
+// TransactionAmountExchanger represents the exchanger which exchanges transaction amounts to the target currency at the exchange rates on the transaction date
+type TransactionAmountExchanger struct {
+	TargetCurrency    string
+	accountCurrencies map[int64]string
+	exchangeRates     *HistoricalExchangeRateMap
+}
+
+// ToExchangeRateHistories returns the exchange rates history database models of all currencies on the date
+func (r *HistoricalExchangeRates) ToExchangeRateHistories(uid int64, dataSource string) []*ExchangeRateHistory {
+	histories := make([]*ExchangeRateHistory, 0, len(r.ExchangeRates))
+
+	for i := 0; i < len(r.ExchangeRates); i++ {
+		exchangeRate := r.ExchangeRates[i]
+
+		histories = append(histories, &ExchangeRateHistory{
+			Uid:          uid,
+			DataSource:   dataSource,
+			RateDate:     r.RateDate,
+			Currency:     exchangeRate.Currency,
+			BaseCurrency: r.BaseCurrency,
+			Rate:         exchangeRate.Rate,
+		})
+	}
+
+	return histories
+}
+
+// Len returns the count of items
+func (s HistoricalExchangeRatesSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s HistoricalExchangeRatesSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s HistoricalExchangeRatesSlice) Less(i, j int) bool {
+	return s[i].RateDate < s[j].RateDate
+}
+
+// NewHistoricalExchangeRateMap returns a new historical exchange rate map according to exchange rates history models and the latest exchange rates
+func NewHistoricalExchangeRateMap(histories []*ExchangeRateHistory, latestExchangeRateMap ExchangeRateMap) *HistoricalExchangeRateMap {
+	exchangeRateMaps := make(map[int32]ExchangeRateMap)
+
+	for i := 0; i < len(histories); i++ {
+		history := histories[i]
+		rate, err := utils.StringToFloat64(history.Rate)
+
+		if err != nil || rate <= 0 {
+			continue
+		}
+
+		exchangeRateMap, exists := exchangeRateMaps[history.RateDate]
+
+		if !exists {
+			exchangeRateMap = make(ExchangeRateMap)
+			exchangeRateMaps[history.RateDate] = exchangeRateMap
+		}
+
+		exchangeRateMap[history.Currency] = rate
+
+		if history.BaseCurrency != "" {
+			exchangeRateMap[history.BaseCurrency] = 1
+		}
+	}
+
+	rateDates := make([]int32, 0, len(exchangeRateMaps))
+
+	for rateDate := range exchangeRateMaps {
+		rateDates = append(rateDates, rateDate)
+	}
+
+	sort.Slice(rateDates, func(i, j int) bool {
+		return rateDates[i] < rateDates[j]
+	})
+
+	return &HistoricalExchangeRateMap{
+		rateDates:             rateDates,
+		exchangeRateMaps:      exchangeRateMaps,
+		latestExchangeRateMap: latestExchangeRateMap,
+	}
+}
+
+// GetExchangeRateMap returns the exchange rates on the specified date, or on the nearest previous date if there are no exchange rates on that date (e.g. weekends and holidays), or on the earliest date if there are no exchange rates before that date
+func (m *HistoricalExchangeRateMap) GetExchangeRateMap(rateDate int32) ExchangeRateMap {
+	if len(m.rateDates) < 1 {
+		return m.latestExchangeRateMap
+	}
+
+	index := sort.Search(len(m.rateDates), func(i int) bool {
+		return m.rateDates[i] > rateDate
+	})
+
+	if index > 0 {
+		index = index - 1
+	}
+
+	return m.exchangeRateMaps[m.rateDates[index]]
+}
+
+// ExchangeAmount returns the amount exchanged from the source currency to the target currency at the exchange rates on the specified date, the latest exchange rates are used if any currency is missing on that date
+func (m *HistoricalExchangeRateMap) ExchangeAmount(amount int64, fromCurrency string, toCurrency string, rateDate int32) (int64, bool) {
+	exchangeRateMap := m.GetExchangeRateMap(rateDate)
+
+	if exchangeRateMap != nil {
+		if exchangedAmount, ok := exchangeRateMap.ExchangeAmount(amount, fromCurrency, toCurrency); ok {
+			return exchangedAmount, true
+		}
+	}
+
+	if m.latestExchangeRateMap != nil {
+		return m.latestExchangeRateMap.ExchangeAmount(amount, fromCurrency, toCurrency)
+	}
+
+	return 0, false
+}
+
+// NewTransactionAmountExchanger returns a new transaction amount exchanger according to the currencies of accounts and historical exchange rates
+func NewTransactionAmountExchanger(targetCurrency string, accounts []*Account, exchangeRates *HistoricalExchangeRateMap) *TransactionAmountExchanger {
+	accountCurrencies := make(map[int64]string, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountCurrencies[accounts[i].AccountId] = accounts[i].Currency
+	}
+
+	return &TransactionAmountExchanger{
+		TargetCurrency:    targetCurrency,
+		accountCurrencies: accountCurrencies,
+		exchangeRates:     exchangeRates,
+	}
+}
+
+// ExchangeTransactionAmount returns the transaction amount in the target currency at the exchange rates on the transaction date (in the transaction timezone)
+func (e *TransactionAmountExchanger) ExchangeTransactionAmount(transaction *Transaction) (int64, bool) {
+	currency, exists := e.accountCurrencies[transaction.AccountId]
+
+	if !exists {
+		return 0, false
+	}
+
+	if currency == e.TargetCurrency {
+		return transaction.Amount, true
+	}
+
+	timezone := time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+	rateDate := utils.FormatUnixTimeToNumericYearMonthDay(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), timezone)
+
+	return e.exchangeRates.ExchangeAmount(transaction.Amount, currency, e.TargetCurrency, rateDate)
+}
+
+// ParseNumericRateDate returns the start time of the specified rate date (in yyyymmdd format) in specified timezone
+func ParseNumericRateDate(rateDate int32, location *time.Location) time.Time {
+	return time.Date(int(rateDate/10000), time.Month(rateDate/100%100), int(rateDate%100), 0, 0, 0, 0, location)
+}
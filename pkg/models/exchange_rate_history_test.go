@@ -0,0 +1,83 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestHistoricalExchangeRateMapGetExchangeRateMap(t *testing.T) {
+	histories := []*ExchangeRateHistory{
+		{RateDate: 20240102, Currency: "USD", BaseCurrency: "EUR", Rate: "1.1"},
+		{RateDate: 20240105, Currency: "USD", BaseCurrency: "EUR", Rate: "1.2"},
+		{RateDate: 20240108, Currency: "USD", BaseCurrency: "EUR", Rate: "1.3"},
+	}
+	exchangeRates := NewHistoricalExchangeRateMap(histories, ExchangeRateMap{"EUR": 1, "USD": 1.5})
+
+	assert.Equal(t, ExchangeRateMap{"EUR": 1, "USD": 1.1}, exchangeRates.GetExchangeRateMap(20240101))
+	assert.Equal(t, ExchangeRateMap{"EUR": 1, "USD": 1.1}, exchangeRates.GetExchangeRateMap(20240102))
+	assert.Equal(t, ExchangeRateMap{"EUR": 1, "USD": 1.1}, exchangeRates.GetExchangeRateMap(20240104))
+	assert.Equal(t, ExchangeRateMap{"EUR": 1, "USD": 1.2}, exchangeRates.GetExchangeRateMap(20240105))
+	assert.Equal(t, ExchangeRateMap{"EUR": 1, "USD": 1.3}, exchangeRates.GetExchangeRateMap(20241231))
+
+	exchangeRates = NewHistoricalExchangeRateMap(nil, ExchangeRateMap{"EUR": 1, "USD": 1.5})
+	assert.Equal(t, ExchangeRateMap{"EUR": 1, "USD": 1.5}, exchangeRates.GetExchangeRateMap(20240101))
+}
+
+func TestHistoricalExchangeRateMapExchangeAmount(t *testing.T) {
+	histories := []*ExchangeRateHistory{
+		{RateDate: 20240102, Currency: "USD", BaseCurrency: "EUR", Rate: "1.1"},
+		{RateDate: 20240105, Currency: "USD", BaseCurrency: "EUR", Rate: "1.25"},
+	}
+	exchangeRates := NewHistoricalExchangeRateMap(histories, ExchangeRateMap{"EUR": 1, "USD": 1.5, "CNY": 8})
+
+	amount, ok := exchangeRates.ExchangeAmount(1100, "USD", "EUR", 20240103)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	amount, ok = exchangeRates.ExchangeAmount(1000, "EUR", "USD", 20240106)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1250), amount)
+
+	amount, ok = exchangeRates.ExchangeAmount(800, "CNY", "EUR", 20240106)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), amount)
+
+	_, ok = exchangeRates.ExchangeAmount(800, "JPY", "EUR", 20240106)
+	assert.False(t, ok)
+}
+
+func TestTransactionAmountExchangerExchangeTransactionAmount(t *testing.T) {
+	histories := []*ExchangeRateHistory{
+		{RateDate: 20240101, Currency: "USD", BaseCurrency: "EUR", Rate: "1.1"},
+		{RateDate: 20240102, Currency: "USD", BaseCurrency: "EUR", Rate: "1.25"},
+	}
+	accounts := []*Account{
+		{AccountId: 1, Currency: "EUR"},
+		{AccountId: 2, Currency: "USD"},
+	}
+	exchanger := NewTransactionAmountExchanger("EUR", accounts, NewHistoricalExchangeRateMap(histories, nil))
+
+	amount, ok := exchanger.ExchangeTransactionAmount(&Transaction{AccountId: 1, Amount: 1000, TransactionTime: getTestExchangeTransactionTime(2024, time.January, 1, 12)})
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	amount, ok = exchanger.ExchangeTransactionAmount(&Transaction{AccountId: 2, Amount: 1100, TransactionTime: getTestExchangeTransactionTime(2024, time.January, 1, 12)})
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	// it is already 2024-01-02 in the transaction timezone (UTC+8)
+	amount, ok = exchanger.ExchangeTransactionAmount(&Transaction{AccountId: 2, Amount: 1250, TransactionTime: getTestExchangeTransactionTime(2024, time.January, 1, 20), TimezoneUtcOffset: 480})
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	_, ok = exchanger.ExchangeTransactionAmount(&Transaction{AccountId: 3, Amount: 1000, TransactionTime: getTestExchangeTransactionTime(2024, time.January, 1, 12)})
+	assert.False(t, ok)
+}
+
+func getTestExchangeTransactionTime(year int, month time.Month, day int, hour int) int64 {
+	return utils.GetMinTransactionTimeFromUnixTime(time.Date(year, month, day, hour, 0, 0, 0, time.UTC).Unix())
+}
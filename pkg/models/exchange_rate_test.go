@@ -25,3 +25,44 @@ func TestLatestExchangeRateSliceLess(t *testing.T) {
 	assert.Equal(t, "EUR", latestExchangeRateSlice[1].Currency)
 	assert.Equal(t, "USD", latestExchangeRateSlice[2].Currency)
 }
+
+func TestLatestExchangeRateResponseToExchangeRateMap(t *testing.T) {
+	response := &LatestExchangeRateResponse{
+		BaseCurrency: "USD",
+		ExchangeRates: LatestExchangeRateSlice{
+			&LatestExchangeRate{Currency: "CNY", Rate: "7.2"},
+			&LatestExchangeRate{Currency: "EUR", Rate: "0.9"},
+			&LatestExchangeRate{Currency: "JPY", Rate: "invalid"},
+		},
+	}
+
+	exchangeRateMap := response.ToExchangeRateMap()
+
+	assert.Equal(t, 3, len(exchangeRateMap))
+	assert.Equal(t, float64(1), exchangeRateMap["USD"])
+	assert.Equal(t, 7.2, exchangeRateMap["CNY"])
+	assert.Equal(t, 0.9, exchangeRateMap["EUR"])
+}
+
+func TestExchangeRateMapExchangeAmount(t *testing.T) {
+	exchangeRateMap := ExchangeRateMap{
+		"USD": 1,
+		"CNY": 7.2,
+		"EUR": 0.9,
+	}
+
+	amount, ok := exchangeRateMap.ExchangeAmount(10000, "USD", "CNY")
+	assert.True(t, ok)
+	assert.Equal(t, int64(72000), amount)
+
+	amount, ok = exchangeRateMap.ExchangeAmount(72000, "CNY", "EUR")
+	assert.True(t, ok)
+	assert.Equal(t, int64(9000), amount)
+
+	amount, ok = exchangeRateMap.ExchangeAmount(123, "JPY", "JPY")
+	assert.True(t, ok)
+	assert.Equal(t, int64(123), amount)
+
+	_, ok = exchangeRateMap.ExchangeAmount(100, "JPY", "USD")
+	assert.False(t, ok)
+}
@@ -0,0 +1,1049 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MaximumInsightsExplorerQueryDays represents the maximum days of insights explorer query time range
+const MaximumInsightsExplorerQueryDays = 3660
+
+// InsightsExplorerConditionRelation represents the relation between a condition and the conditions before it
+type InsightsExplorerConditionRelation string
+
+// Insights explorer condition relations
+const (
+	INSIGHTS_EXPLORER_CONDITION_RELATION_FIRST InsightsExplorerConditionRelation = "first"
+	INSIGHTS_EXPLORER_CONDITION_RELATION_AND   InsightsExplorerConditionRelation = "and"
+	INSIGHTS_EXPLORER_CONDITION_RELATION_OR    InsightsExplorerConditionRelation = "or"
+)
+
+// InsightsExplorerConditionField represents the transaction field which the condition is applied to
+type InsightsExplorerConditionField string
+
+// Insights explorer condition fields
+const (
+	INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_TYPE     InsightsExplorerConditionField = "transactionType"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_CATEGORY InsightsExplorerConditionField = "transactionCategory"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_SOURCE_ACCOUNT       InsightsExplorerConditionField = "sourceAccount"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_DESTINATION_ACCOUNT  InsightsExplorerConditionField = "destinationAccount"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_SOURCE_AMOUNT        InsightsExplorerConditionField = "sourceAmount"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_DESTINATION_AMOUNT   InsightsExplorerConditionField = "destinationAmount"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_ITEM     InsightsExplorerConditionField = "transactionItem"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_TAG      InsightsExplorerConditionField = "transactionTag"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_PICTURES             InsightsExplorerConditionField = "pictures"
+	INSIGHTS_EXPLORER_CONDITION_FIELD_DESCRIPTION          InsightsExplorerConditionField = "description"
+)
+
+// InsightsExplorerConditionOperator represents the operator of the condition
+type InsightsExplorerConditionOperator string
+
+// Insights explorer condition operators
+const (
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IN              InsightsExplorerConditionOperator = "in"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_GREATER_THAN    InsightsExplorerConditionOperator = "greaterThan"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_LESS_THAN       InsightsExplorerConditionOperator = "lessThan"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_EQUALS          InsightsExplorerConditionOperator = "equals"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_EQUALS      InsightsExplorerConditionOperator = "notEquals"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_BETWEEN         InsightsExplorerConditionOperator = "between"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_BETWEEN     InsightsExplorerConditionOperator = "notBetween"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_HAS_ANY         InsightsExplorerConditionOperator = "hasAny"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_HAS_ALL         InsightsExplorerConditionOperator = "hasAll"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_HAS_ANY     InsightsExplorerConditionOperator = "notHasAny"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_HAS_ALL     InsightsExplorerConditionOperator = "notHasAll"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_EMPTY        InsightsExplorerConditionOperator = "isEmpty"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_NOT_EMPTY    InsightsExplorerConditionOperator = "isNotEmpty"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_CONTAINS        InsightsExplorerConditionOperator = "contains"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_CONTAINS    InsightsExplorerConditionOperator = "notContains"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_STARTS_WITH     InsightsExplorerConditionOperator = "startsWith"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_STARTS_WITH InsightsExplorerConditionOperator = "notStartsWith"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_ENDS_WITH       InsightsExplorerConditionOperator = "endsWith"
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_ENDS_WITH   InsightsExplorerConditionOperator = "notEndsWith"
+)
+
+var insightsExplorerAmountConditionOperators = map[InsightsExplorerConditionOperator]bool{
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_EQUALS:       true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_EQUALS:   true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_GREATER_THAN: true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_LESS_THAN:    true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_BETWEEN:      true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_BETWEEN:  true,
+}
+
+var insightsExplorerIdListConditionOperators = map[InsightsExplorerConditionOperator]bool{
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_HAS_ANY:      true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_HAS_ALL:      true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_HAS_ANY:  true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_HAS_ALL:  true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_EQUALS:       true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_EQUALS:   true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_EMPTY:     true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_NOT_EMPTY: true,
+}
+
+var insightsExplorerPicturesConditionOperators = map[InsightsExplorerConditionOperator]bool{
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_EMPTY:     true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_NOT_EMPTY: true,
+}
+
+var insightsExplorerDescriptionConditionOperators = map[InsightsExplorerConditionOperator]bool{
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_EMPTY:        true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_NOT_EMPTY:    true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_EQUALS:          true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_EQUALS:      true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_CONTAINS:        true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_CONTAINS:    true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_STARTS_WITH:     true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_STARTS_WITH: true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_ENDS_WITH:       true,
+	INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_ENDS_WITH:   true,
+}
+
+// InsightsExplorerDataDimension represents the dimension which the transactions are grouped by
+type InsightsExplorerDataDimension string
+
+// Insights explorer data dimensions
+const (
+	INSIGHTS_EXPLORER_DATA_DIMENSION_NONE                         InsightsExplorerDataDimension = "none"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_QUERY                        InsightsExplorerDataDimension = "query"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DATE_TIME                    InsightsExplorerDataDimension = "dateTime"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH_DAY               InsightsExplorerDataDimension = "dateTimeByYearMonthDay"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH                   InsightsExplorerDataDimension = "dateTimeByYearMonth"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_QUARTER                 InsightsExplorerDataDimension = "dateTimeByYearQuarter"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR                         InsightsExplorerDataDimension = "dateTimeByYear"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_FISCAL_YEAR                  InsightsExplorerDataDimension = "dateTimeByFiscalYear"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DAY_OF_WEEK                  InsightsExplorerDataDimension = "dateTimeByDayOfWeek"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DAY_OF_MONTH                 InsightsExplorerDataDimension = "dateTimeByDayOfMonth"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_MONTH_OF_YEAR                InsightsExplorerDataDimension = "dateTimeByMonthOfYear"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_QUARTER_OF_YEAR              InsightsExplorerDataDimension = "dateTimeByQuarterOfYear"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TYPE             InsightsExplorerDataDimension = "transactionType"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT               InsightsExplorerDataDimension = "sourceAccount"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT_CATEGORY      InsightsExplorerDataDimension = "sourceAccountCategory"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT_CURRENCY      InsightsExplorerDataDimension = "sourceAccountCurrency"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT          InsightsExplorerDataDimension = "destinationAccount"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT_CATEGORY InsightsExplorerDataDimension = "destinationAccountCategory"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT_CURRENCY InsightsExplorerDataDimension = "destinationAccountCurrency"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_AMOUNT                InsightsExplorerDataDimension = "sourceAmount"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_AMOUNT           InsightsExplorerDataDimension = "destinationAmount"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_PRIMARY_CATEGORY             InsightsExplorerDataDimension = "primaryCategory"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SECONDARY_CATEGORY           InsightsExplorerDataDimension = "secondaryCategory"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TAG              InsightsExplorerDataDimension = "transactionTag"
+	INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_ITEM             InsightsExplorerDataDimension = "transactionItem"
+)
+
+var insightsExplorerDataDimensions = map[InsightsExplorerDataDimension]bool{
+	INSIGHTS_EXPLORER_DATA_DIMENSION_NONE:                         true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_QUERY:                        true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DATE_TIME:                    true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH_DAY:               true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH:                   true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_QUARTER:                 true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR:                         true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_FISCAL_YEAR:                  true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DAY_OF_WEEK:                  true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DAY_OF_MONTH:                 true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_MONTH_OF_YEAR:                true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_QUARTER_OF_YEAR:              true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TYPE:             true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT:               true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT_CATEGORY:      true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT_CURRENCY:      true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT:          true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT_CATEGORY: true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT_CURRENCY: true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_AMOUNT:                true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_AMOUNT:           true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_PRIMARY_CATEGORY:             true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_SECONDARY_CATEGORY:           true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TAG:              true,
+	INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_ITEM:             true,
+}
+
+// IsValid returns whether the data dimension is supported
+func (d InsightsExplorerDataDimension) IsValid() bool {
+	return insightsExplorerDataDimensions[d]
+}
+
+// InsightsExplorerValueMetric represents the measure which is calculated for each group of transactions
+type InsightsExplorerValueMetric string
+
+// Insights explorer value metrics
+const (
+	INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT     InsightsExplorerValueMetric = "transactionCount"
+	INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_SUM     InsightsExplorerValueMetric = "sourceAmountSum"
+	INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_AVERAGE InsightsExplorerValueMetric = "sourceAmountAverage"
+	INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MEDIAN  InsightsExplorerValueMetric = "sourceAmountMedian"
+	INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MINIMUM InsightsExplorerValueMetric = "sourceAmountMinimum"
+	INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MAXIMUM InsightsExplorerValueMetric = "sourceAmountMaximum"
+)
+
+// IsValid returns whether the value metric is supported
+func (m InsightsExplorerValueMetric) IsValid() bool {
+	switch m {
+	case INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_SUM,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_AVERAGE,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MEDIAN,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MINIMUM,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MAXIMUM:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAmount returns whether the value of the metric is an amount
+func (m InsightsExplorerValueMetric) IsAmount() bool {
+	return m != INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT
+}
+
+// String returns a textual representation of the value metric
+func (m InsightsExplorerValueMetric) String() string {
+	switch m {
+	case INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT:
+		return "Transaction Count"
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_SUM:
+		return "Total Amount"
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_AVERAGE:
+		return "Average Amount"
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MEDIAN:
+		return "Median Amount"
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MINIMUM:
+		return "Minimum Amount"
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MAXIMUM:
+		return "Maximum Amount"
+	default:
+		return fmt.Sprintf("Invalid(%s)", string(m))
+	}
+}
+
+// InsightsExplorerTimezoneType represents the timezone which is used for grouping transactions by date and time
+type InsightsExplorerTimezoneType byte
+
+// Insights explorer timezone types
+const (
+	INSIGHTS_EXPLORER_TIMEZONE_TYPE_APPLICATION_TIMEZONE InsightsExplorerTimezoneType = 0
+	INSIGHTS_EXPLORER_TIMEZONE_TYPE_TRANSACTION_TIMEZONE InsightsExplorerTimezoneType = 1
+)
+
+// Insights explorer chart types which do not use series dimension
+const (
+	insightsExplorerChartTypePie   = "pie"
+	insightsExplorerChartTypeRadar = "radar"
+)
+
+const insightsExplorerDimensionNoneId = "none"
+
+// InsightsExplorerQueryDefinition represents the query definition which is saved in the data of insights explorer
+type InsightsExplorerQueryDefinition struct {
+	Queries                  []*InsightsExplorerTransactionQuery `json:"queries"`
+	TimezoneUsedForDateRange InsightsExplorerTimezoneType        `json:"timezoneUsedForDateRange"`
+	ChartType                string                              `json:"chartType"`
+	CategoryDimension        InsightsExplorerDataDimension       `json:"categoryDimension"`
+	SeriesDimension          InsightsExplorerDataDimension       `json:"seriesDimension"`
+	ValueMetric              InsightsExplorerValueMetric         `json:"valueMetric"`
+}
+
+// InsightsExplorerTransactionQuery represents a named query which contains a list of conditions
+type InsightsExplorerTransactionQuery struct {
+	Id         string                                   `json:"id"`
+	Name       string                                   `json:"name"`
+	Conditions []*InsightsExplorerConditionWithRelation `json:"conditions"`
+}
+
+// InsightsExplorerConditionWithRelation represents a condition and its relation to the conditions before it
+type InsightsExplorerConditionWithRelation struct {
+	Condition *InsightsExplorerCondition        `json:"condition"`
+	Relation  InsightsExplorerConditionRelation `json:"relation"`
+}
+
+// InsightsExplorerCondition represents a condition which is applied to one transaction field
+type InsightsExplorerCondition struct {
+	Field        InsightsExplorerConditionField    `json:"field"`
+	Operator     InsightsExplorerConditionOperator `json:"operator"`
+	Value        json.RawMessage                   `json:"value"`
+	typeValues   map[TransactionType]bool
+	idValues     map[int64]bool
+	amountValues [2]int64
+	textValue    string
+}
+
+// InsightsExplorerTransaction represents a transaction and all its related data which are used in insights explorer queries
+type InsightsExplorerTransaction struct {
+	Transaction             *Transaction
+	Type                    TransactionType
+	SourceAccount           *Account
+	DestinationAccount      *Account
+	PrimaryCategory         *TransactionCategory
+	SecondaryCategory       *TransactionCategory
+	TagIds                  []int64
+	ItemIds                 []int64
+	HasPictures             bool
+	AmountInDefaultCurrency int64
+	AmountExchanged         bool
+}
+
+// InsightsExplorerQueryRequest represents all parameters of insights explorer query request
+type InsightsExplorerQueryRequest struct {
+	Id                int64                         `json:"id,string" binding:"min=0"`
+	Data              map[string]any                `json:"data"`
+	CategoryDimension InsightsExplorerDataDimension `json:"categoryDimension"`
+	SeriesDimension   InsightsExplorerDataDimension `json:"seriesDimension"`
+	ValueMetric       InsightsExplorerValueMetric   `json:"valueMetric"`
+	StartTime         int64                         `json:"startTime" binding:"min=0"`
+	EndTime           int64                         `json:"endTime" binding:"min=0"`
+}
+
+// InsightsExplorerQueryResultResponse represents a view-object of insights explorer query result
+type InsightsExplorerQueryResultResponse struct {
+	CategoryDimension InsightsExplorerDataDimension              `json:"categoryDimension"`
+	SeriesDimension   InsightsExplorerDataDimension              `json:"seriesDimension"`
+	ValueMetric       InsightsExplorerValueMetric                `json:"valueMetric"`
+	Currency          string                                     `json:"currency,omitempty"`
+	TransactionCount  int                                        `json:"transactionCount"`
+	Items             []*InsightsExplorerQueryResultCategoryItem `json:"items"`
+}
+
+// InsightsExplorerQueryResultCategoryItem represents a view-object of the transactions group by category dimension
+type InsightsExplorerQueryResultCategoryItem struct {
+	CategoryId    string                                   `json:"categoryId"`
+	CategoryName  string                                   `json:"categoryName"`
+	Series        []*InsightsExplorerQueryResultSeriesItem `json:"series"`
+	displayOrders []int64
+	seriesMap     map[string]*InsightsExplorerQueryResultSeriesItem
+}
+
+// InsightsExplorerQueryResultSeriesItem represents a view-object of the transactions group by series dimension in a category
+type InsightsExplorerQueryResultSeriesItem struct {
+	SeriesId      string `json:"seriesId"`
+	SeriesName    string `json:"seriesName"`
+	Value         int64  `json:"value"`
+	displayOrders []int64
+	transactions  []*InsightsExplorerTransaction
+}
+
+type insightsExplorerDimensionValue struct {
+	id            string
+	name          string
+	displayOrders []int64
+}
+
+// InsightsExplorerQueryContext represents the user data which are required for grouping transactions
+type InsightsExplorerQueryContext struct {
+	DefaultCurrency string
+	FirstDayOfWeek  core.WeekDay
+	FiscalYearStart core.FiscalYearStart
+	Timezone        *time.Location
+	AccountMap      map[int64]*Account
+	TagMap          map[int64]*TransactionTag
+	ItemMap         map[int64]*TransactionItem
+}
+
+// GetQueryDefinition returns the query definition parsed from the saved data of insights explorer
+func (a *InsightsExplorer) GetQueryDefinition() (*InsightsExplorerQueryDefinition, error) {
+	if a.Data == "" {
+		return ParseInsightsExplorerQueryDefinition(nil)
+	}
+
+	return ParseInsightsExplorerQueryDefinition([]byte(a.Data))
+}
+
+// ParseInsightsExplorerQueryDefinitionFromMap returns the query definition parsed from the data of insights explorer request
+func ParseInsightsExplorerQueryDefinitionFromMap(data map[string]any) (*InsightsExplorerQueryDefinition, error) {
+	content, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, errs.ErrInsightsExplorerDataInvalid
+	}
+
+	return ParseInsightsExplorerQueryDefinition(content)
+}
+
+// ParseInsightsExplorerQueryDefinition returns the query definition parsed from the json data of insights explorer
+func ParseInsightsExplorerQueryDefinition(data []byte) (*InsightsExplorerQueryDefinition, error) {
+	definition := &InsightsExplorerQueryDefinition{}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, definition); err != nil {
+			return nil, errs.ErrInsightsExplorerDataInvalid
+		}
+	}
+
+	if definition.CategoryDimension == "" {
+		definition.CategoryDimension = INSIGHTS_EXPLORER_DATA_DIMENSION_QUERY
+	}
+
+	if definition.SeriesDimension == "" || definition.ChartType == "" || definition.ChartType == insightsExplorerChartTypePie || definition.ChartType == insightsExplorerChartTypeRadar {
+		definition.SeriesDimension = INSIGHTS_EXPLORER_DATA_DIMENSION_NONE
+	}
+
+	if definition.ValueMetric == "" {
+		definition.ValueMetric = INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_SUM
+	}
+
+	if err := definition.SetDimensionsAndValueMetric(definition.CategoryDimension, definition.SeriesDimension, definition.ValueMetric); err != nil {
+		return nil, err
+	}
+
+	if definition.TimezoneUsedForDateRange != INSIGHTS_EXPLORER_TIMEZONE_TYPE_APPLICATION_TIMEZONE && definition.TimezoneUsedForDateRange != INSIGHTS_EXPLORER_TIMEZONE_TYPE_TRANSACTION_TIMEZONE {
+		return nil, errs.ErrInsightsExplorerTimezoneTypeInvalid
+	}
+
+	for i := 0; i < len(definition.Queries); i++ {
+		query := definition.Queries[i]
+
+		if query == nil {
+			return nil, errs.ErrInsightsExplorerDataInvalid
+		}
+
+		for j := 0; j < len(query.Conditions); j++ {
+			conditionWithRelation := query.Conditions[j]
+
+			if conditionWithRelation == nil || conditionWithRelation.Condition == nil {
+				return nil, errs.ErrInsightsExplorerQueryConditionInvalid
+			}
+
+			if j == 0 && conditionWithRelation.Relation != INSIGHTS_EXPLORER_CONDITION_RELATION_FIRST {
+				return nil, errs.ErrInsightsExplorerQueryConditionInvalid
+			} else if j > 0 && conditionWithRelation.Relation != INSIGHTS_EXPLORER_CONDITION_RELATION_AND && conditionWithRelation.Relation != INSIGHTS_EXPLORER_CONDITION_RELATION_OR {
+				return nil, errs.ErrInsightsExplorerQueryConditionInvalid
+			}
+
+			if err := conditionWithRelation.Condition.parseValue(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return definition, nil
+}
+
+// SetDimensionsAndValueMetric sets the category dimension, series dimension and value metric of the query definition, the empty ones remain unchanged
+func (d *InsightsExplorerQueryDefinition) SetDimensionsAndValueMetric(categoryDimension InsightsExplorerDataDimension, seriesDimension InsightsExplorerDataDimension, valueMetric InsightsExplorerValueMetric) error {
+	if categoryDimension == "" {
+		categoryDimension = d.CategoryDimension
+	}
+
+	if seriesDimension == "" {
+		seriesDimension = d.SeriesDimension
+	}
+
+	if valueMetric == "" {
+		valueMetric = d.ValueMetric
+	}
+
+	if !categoryDimension.IsValid() || !seriesDimension.IsValid() {
+		return errs.ErrInsightsExplorerDataDimensionInvalid
+	}
+
+	if !valueMetric.IsValid() {
+		return errs.ErrInsightsExplorerValueMetricInvalid
+	}
+
+	d.CategoryDimension = categoryDimension
+	d.SeriesDimension = seriesDimension
+	d.ValueMetric = valueMetric
+
+	return nil
+}
+
+// HasConditionField returns whether any query of the definition contains a condition of the specified field
+func (d *InsightsExplorerQueryDefinition) HasConditionField(field InsightsExplorerConditionField) bool {
+	for i := 0; i < len(d.Queries); i++ {
+		for j := 0; j < len(d.Queries[i].Conditions); j++ {
+			if d.Queries[i].Conditions[j].Condition.Field == field {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Match returns whether the transaction matches all the conditions of the query, "and" takes precedence over "or"
+func (q *InsightsExplorerTransactionQuery) Match(transaction *InsightsExplorerTransaction) bool {
+	if len(q.Conditions) < 1 {
+		return true
+	}
+
+	result := false
+	currentAndGroupResult := true
+
+	for i := 0; i < len(q.Conditions); i++ {
+		conditionWithRelation := q.Conditions[i]
+
+		if i > 0 && conditionWithRelation.Relation == INSIGHTS_EXPLORER_CONDITION_RELATION_OR {
+			result = result || currentAndGroupResult
+			currentAndGroupResult = true
+		}
+
+		currentAndGroupResult = currentAndGroupResult && conditionWithRelation.Condition.Match(transaction)
+	}
+
+	return result || currentAndGroupResult
+}
+
+// Match returns whether the transaction matches the condition
+func (c *InsightsExplorerCondition) Match(transaction *InsightsExplorerTransaction) bool {
+	switch c.Field {
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_TYPE:
+		return c.typeValues[transaction.Type]
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_CATEGORY:
+		return (transaction.PrimaryCategory != nil && c.idValues[transaction.PrimaryCategory.CategoryId]) || c.idValues[transaction.Transaction.CategoryId]
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_SOURCE_ACCOUNT:
+		return c.idValues[transaction.Transaction.AccountId]
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_DESTINATION_ACCOUNT:
+		return transaction.Type == TRANSACTION_TYPE_TRANSFER && c.idValues[transaction.Transaction.RelatedAccountId]
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_SOURCE_AMOUNT:
+		return c.matchAmount(transaction.Transaction.Amount)
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_DESTINATION_AMOUNT:
+		return c.matchAmount(transaction.Transaction.RelatedAccountAmount)
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_ITEM:
+		return c.matchIds(transaction.ItemIds)
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_TAG:
+		return c.matchIds(transaction.TagIds)
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_PICTURES:
+		if c.Operator == INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_EMPTY {
+			return !transaction.HasPictures
+		}
+
+		return transaction.HasPictures
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_DESCRIPTION:
+		return c.matchDescription(transaction.Transaction.Comment)
+	default:
+		return false
+	}
+}
+
+func (c *InsightsExplorerCondition) parseValue() error {
+	switch c.Field {
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_TYPE:
+		var transactionTypes []TransactionType
+
+		if c.Operator != INSIGHTS_EXPLORER_CONDITION_OPERATOR_IN || json.Unmarshal(c.Value, &transactionTypes) != nil {
+			return errs.ErrInsightsExplorerQueryConditionInvalid
+		}
+
+		c.typeValues = make(map[TransactionType]bool, len(transactionTypes))
+
+		for i := 0; i < len(transactionTypes); i++ {
+			c.typeValues[transactionTypes[i]] = true
+		}
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_CATEGORY,
+		INSIGHTS_EXPLORER_CONDITION_FIELD_SOURCE_ACCOUNT,
+		INSIGHTS_EXPLORER_CONDITION_FIELD_DESTINATION_ACCOUNT:
+		if c.Operator != INSIGHTS_EXPLORER_CONDITION_OPERATOR_IN {
+			return errs.ErrInsightsExplorerQueryConditionInvalid
+		}
+
+		return c.parseIdValues()
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_ITEM,
+		INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_TAG:
+		if !insightsExplorerIdListConditionOperators[c.Operator] {
+			return errs.ErrInsightsExplorerQueryConditionInvalid
+		}
+
+		return c.parseIdValues()
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_SOURCE_AMOUNT,
+		INSIGHTS_EXPLORER_CONDITION_FIELD_DESTINATION_AMOUNT:
+		var amounts []int64
+
+		if !insightsExplorerAmountConditionOperators[c.Operator] || json.Unmarshal(c.Value, &amounts) != nil || len(amounts) != 2 {
+			return errs.ErrInsightsExplorerQueryConditionInvalid
+		}
+
+		c.amountValues = [2]int64{amounts[0], amounts[1]}
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_PICTURES:
+		if !insightsExplorerPicturesConditionOperators[c.Operator] {
+			return errs.ErrInsightsExplorerQueryConditionInvalid
+		}
+	case INSIGHTS_EXPLORER_CONDITION_FIELD_DESCRIPTION:
+		if !insightsExplorerDescriptionConditionOperators[c.Operator] || json.Unmarshal(c.Value, &c.textValue) != nil {
+			return errs.ErrInsightsExplorerQueryConditionInvalid
+		}
+	default:
+		return errs.ErrInsightsExplorerQueryConditionInvalid
+	}
+
+	return nil
+}
+
+func (c *InsightsExplorerCondition) parseIdValues() error {
+	var textualIds []string
+
+	if json.Unmarshal(c.Value, &textualIds) != nil {
+		return errs.ErrInsightsExplorerQueryConditionInvalid
+	}
+
+	c.idValues = make(map[int64]bool, len(textualIds))
+
+	for i := 0; i < len(textualIds); i++ {
+		id, err := utils.StringToInt64(textualIds[i])
+
+		if err != nil {
+			return errs.ErrInsightsExplorerQueryConditionInvalid
+		}
+
+		c.idValues[id] = true
+	}
+
+	return nil
+}
+
+func (c *InsightsExplorerCondition) matchAmount(amount int64) bool {
+	switch c.Operator {
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_GREATER_THAN:
+		return amount > c.amountValues[0]
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_LESS_THAN:
+		return amount < c.amountValues[0]
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_EQUALS:
+		return amount == c.amountValues[0]
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_EQUALS:
+		return amount != c.amountValues[0]
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_BETWEEN:
+		return amount >= c.amountValues[0] && amount <= c.amountValues[1]
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_BETWEEN:
+		return amount < c.amountValues[0] || amount > c.amountValues[1]
+	default:
+		return false
+	}
+}
+
+func (c *InsightsExplorerCondition) matchIds(ids []int64) bool {
+	if c.Operator == INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_NOT_EMPTY {
+		return len(ids) > 0
+	} else if c.Operator == INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_EMPTY || len(c.idValues) < 1 {
+		return len(ids) < 1
+	}
+
+	existedIds := make(map[int64]bool, len(ids))
+
+	for i := 0; i < len(ids); i++ {
+		existedIds[ids[i]] = true
+	}
+
+	hasAny := false
+	hasAll := true
+
+	for id := range c.idValues {
+		if existedIds[id] {
+			hasAny = true
+		} else {
+			hasAll = false
+		}
+	}
+
+	switch c.Operator {
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_EQUALS:
+		return hasAll && len(existedIds) == len(c.idValues)
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_EQUALS:
+		return !hasAll || len(existedIds) != len(c.idValues)
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_HAS_ANY:
+		return hasAny
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_HAS_ANY:
+		return !hasAny
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_HAS_ALL:
+		return hasAll
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_HAS_ALL:
+		return !hasAll
+	default:
+		return false
+	}
+}
+
+func (c *InsightsExplorerCondition) matchDescription(description string) bool {
+	switch c.Operator {
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_EMPTY:
+		return description == ""
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_IS_NOT_EMPTY:
+		return description != ""
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_EQUALS:
+		return description == c.textValue
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_EQUALS:
+		return description != c.textValue
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_CONTAINS:
+		return strings.Contains(description, c.textValue)
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_CONTAINS:
+		return !strings.Contains(description, c.textValue)
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_STARTS_WITH:
+		return strings.HasPrefix(description, c.textValue)
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_STARTS_WITH:
+		return !strings.HasPrefix(description, c.textValue)
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_ENDS_WITH:
+		return strings.HasSuffix(description, c.textValue)
+	case INSIGHTS_EXPLORER_CONDITION_OPERATOR_NOT_ENDS_WITH:
+		return !strings.HasSuffix(description, c.textValue)
+	default:
+		return false
+	}
+}
+
+// NewInsightsExplorerQueryResult returns the aggregated result of the transactions which match the queries of the definition,
+// the transactions are grouped by the category dimension and the series dimension, and the value metric is calculated for each group
+func NewInsightsExplorerQueryResult(definition *InsightsExplorerQueryDefinition, transactions []*InsightsExplorerTransaction, context *InsightsExplorerQueryContext) *InsightsExplorerQueryResultResponse {
+	categoryItemsMap := make(map[string]*InsightsExplorerQueryResultCategoryItem)
+	categoryItems := make([]*InsightsExplorerQueryResultCategoryItem, 0)
+	matchedTransactionCount := 0
+
+	addTransaction := func(transaction *InsightsExplorerTransaction, queryIndex int) {
+		for _, categoryValue := range definition.getDimensionValues(definition.CategoryDimension, queryIndex, transaction, context) {
+			categoryItem, exists := categoryItemsMap[categoryValue.id]
+
+			if !exists {
+				categoryItem = &InsightsExplorerQueryResultCategoryItem{
+					CategoryId:    categoryValue.id,
+					CategoryName:  categoryValue.name,
+					displayOrders: categoryValue.displayOrders,
+					seriesMap:     make(map[string]*InsightsExplorerQueryResultSeriesItem),
+				}
+
+				categoryItemsMap[categoryValue.id] = categoryItem
+				categoryItems = append(categoryItems, categoryItem)
+			}
+
+			for _, seriesValue := range definition.getDimensionValues(definition.SeriesDimension, queryIndex, transaction, context) {
+				seriesItem, exists := categoryItem.seriesMap[seriesValue.id]
+
+				if !exists {
+					seriesItem = &InsightsExplorerQueryResultSeriesItem{
+						SeriesId:      seriesValue.id,
+						SeriesName:    seriesValue.name,
+						displayOrders: seriesValue.displayOrders,
+					}
+
+					categoryItem.seriesMap[seriesValue.id] = seriesItem
+					categoryItem.Series = append(categoryItem.Series, seriesItem)
+				}
+
+				seriesItem.transactions = append(seriesItem.transactions, transaction)
+			}
+		}
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if len(definition.Queries) < 1 {
+			addTransaction(transaction, 0)
+			matchedTransactionCount++
+			continue
+		}
+
+		matched := false
+
+		for j := 0; j < len(definition.Queries); j++ {
+			if !definition.Queries[j].Match(transaction) {
+				continue
+			}
+
+			matched = true
+			addTransaction(transaction, j)
+
+			// one transaction can only be counted once unless each query is a separate category
+			if definition.CategoryDimension != INSIGHTS_EXPLORER_DATA_DIMENSION_QUERY {
+				break
+			}
+		}
+
+		if matched {
+			matchedTransactionCount++
+		}
+	}
+
+	for i := 0; i < len(categoryItems); i++ {
+		categoryItem := categoryItems[i]
+
+		for j := 0; j < len(categoryItem.Series); j++ {
+			seriesItem := categoryItem.Series[j]
+			seriesItem.Value = definition.ValueMetric.calculate(seriesItem.transactions)
+		}
+
+		sort.SliceStable(categoryItem.Series, func(i, j int) bool {
+			return compareInsightsExplorerDisplayOrders(categoryItem.Series[i].displayOrders, categoryItem.Series[i].SeriesName, categoryItem.Series[j].displayOrders, categoryItem.Series[j].SeriesName)
+		})
+	}
+
+	sort.SliceStable(categoryItems, func(i, j int) bool {
+		return compareInsightsExplorerDisplayOrders(categoryItems[i].displayOrders, categoryItems[i].CategoryName, categoryItems[j].displayOrders, categoryItems[j].CategoryName)
+	})
+
+	result := &InsightsExplorerQueryResultResponse{
+		CategoryDimension: definition.CategoryDimension,
+		SeriesDimension:   definition.SeriesDimension,
+		ValueMetric:       definition.ValueMetric,
+		TransactionCount:  matchedTransactionCount,
+		Items:             categoryItems,
+	}
+
+	if definition.ValueMetric.IsAmount() {
+		result.Currency = context.DefaultCurrency
+	}
+
+	return result
+}
+
+func (m InsightsExplorerValueMetric) calculate(transactions []*InsightsExplorerTransaction) int64 {
+	amounts := make([]int64, 0, len(transactions))
+	totalAmount := int64(0)
+
+	for i := 0; i < len(transactions); i++ {
+		// the transactions which cannot be exchanged to the default currency are not included, the same as in the web page
+		if !transactions[i].AmountExchanged {
+			continue
+		}
+
+		amounts = append(amounts, transactions[i].AmountInDefaultCurrency)
+		totalAmount += transactions[i].AmountInDefaultCurrency
+	}
+
+	if len(amounts) < 1 {
+		return 0
+	}
+
+	switch m {
+	case INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT:
+		return int64(len(amounts))
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_SUM:
+		return totalAmount
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_AVERAGE:
+		return totalAmount / int64(len(amounts))
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MEDIAN:
+		sort.Slice(amounts, func(i, j int) bool {
+			return amounts[i] < amounts[j]
+		})
+
+		return amounts[len(amounts)/2]
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MINIMUM:
+		minimumAmount := amounts[0]
+
+		for i := 1; i < len(amounts); i++ {
+			if amounts[i] < minimumAmount {
+				minimumAmount = amounts[i]
+			}
+		}
+
+		return minimumAmount
+	case INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MAXIMUM:
+		maximumAmount := amounts[0]
+
+		for i := 1; i < len(amounts); i++ {
+			if amounts[i] > maximumAmount {
+				maximumAmount = amounts[i]
+			}
+		}
+
+		return maximumAmount
+	default:
+		return 0
+	}
+}
+
+func (d *InsightsExplorerQueryDefinition) getDimensionValues(dimension InsightsExplorerDataDimension, queryIndex int, transaction *InsightsExplorerTransaction, context *InsightsExplorerQueryContext) []*insightsExplorerDimensionValue {
+	timezone := context.Timezone
+
+	if d.TimezoneUsedForDateRange == INSIGHTS_EXPLORER_TIMEZONE_TYPE_TRANSACTION_TIMEZONE {
+		timezone = time.FixedZone("Transaction Timezone", int(transaction.Transaction.TimezoneUtcOffset)*60)
+	}
+
+	unixTime := utils.GetUnixTimeFromTransactionTime(transaction.Transaction.TransactionTime)
+	dateTime := time.Unix(unixTime, 0).In(timezone)
+	isTransfer := transaction.Type == TRANSACTION_TYPE_TRANSFER
+
+	switch dimension {
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_NONE:
+		return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, d.ValueMetric.String(), 0)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_QUERY:
+		queryName := fmt.Sprintf("Query #%d", queryIndex+1)
+
+		if queryIndex < len(d.Queries) && d.Queries[queryIndex].Name != "" {
+			queryName = d.Queries[queryIndex].Name
+		}
+
+		return newInsightsExplorerDimensionValues(utils.IntToString(queryIndex+1), queryName, int64(queryIndex+1))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_DATE_TIME:
+		textualDateTime := dateTime.Format("2006-01-02 15:04:05")
+		return newInsightsExplorerDimensionValues(textualDateTime, textualDateTime, unixTime)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH_DAY:
+		textualDate := dateTime.Format("2006-01-02")
+		return newInsightsExplorerDimensionValues(textualDate, textualDate, unixTime)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH:
+		textualYearMonth := dateTime.Format("2006-01")
+		return newInsightsExplorerDimensionValues(textualYearMonth, textualYearMonth, unixTime)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_QUARTER:
+		textualYearQuarter := fmt.Sprintf("%d-%d", dateTime.Year(), (int(dateTime.Month())-1)/3+1)
+		return newInsightsExplorerDimensionValues(textualYearQuarter, textualYearQuarter, unixTime)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR:
+		textualYear := utils.IntToString(dateTime.Year())
+		return newInsightsExplorerDimensionValues(textualYear, textualYear, unixTime)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_FISCAL_YEAR:
+		fiscalYear := getInsightsExplorerFiscalYear(dateTime, context.FiscalYearStart)
+		return newInsightsExplorerDimensionValues(utils.IntToString(fiscalYear), utils.IntToString(fiscalYear), int64(fiscalYear))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_DAY_OF_WEEK:
+		weekDay := core.WeekDay(dateTime.Weekday())
+		return newInsightsExplorerDimensionValues(utils.IntToString(int(weekDay)), weekDay.String(), int64((int(weekDay)-int(context.FirstDayOfWeek)+7)%7))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_DAY_OF_MONTH:
+		return newInsightsExplorerDimensionValues(utils.IntToString(dateTime.Day()), utils.IntToString(dateTime.Day()), int64(dateTime.Day()))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_MONTH_OF_YEAR:
+		return newInsightsExplorerDimensionValues(utils.IntToString(int(dateTime.Month())), dateTime.Month().String(), int64(dateTime.Month()))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_QUARTER_OF_YEAR:
+		quarter := (int(dateTime.Month())-1)/3 + 1
+		return newInsightsExplorerDimensionValues(utils.IntToString(quarter), fmt.Sprintf("Q%d", quarter), int64(quarter))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TYPE:
+		return newInsightsExplorerDimensionValues(utils.IntToString(int(transaction.Type)), getInsightsExplorerTransactionTypeName(transaction.Type), int64(transaction.Type))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT:
+		return []*insightsExplorerDimensionValue{getInsightsExplorerAccountDimensionValue(transaction.SourceAccount, context.AccountMap)}
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT_CATEGORY:
+		return newInsightsExplorerDimensionValues(utils.IntToString(int(transaction.SourceAccount.Category)), transaction.SourceAccount.Category.String(), int64(transaction.SourceAccount.Category))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_ACCOUNT_CURRENCY:
+		return newInsightsExplorerDimensionValues(transaction.SourceAccount.Currency, transaction.SourceAccount.Currency, 0)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT:
+		if !isTransfer || transaction.DestinationAccount == nil {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		return []*insightsExplorerDimensionValue{getInsightsExplorerAccountDimensionValue(transaction.DestinationAccount, context.AccountMap)}
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT_CATEGORY:
+		if !isTransfer || transaction.DestinationAccount == nil {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		return newInsightsExplorerDimensionValues(utils.IntToString(int(transaction.DestinationAccount.Category)), transaction.DestinationAccount.Category.String(), int64(transaction.DestinationAccount.Category))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_ACCOUNT_CURRENCY:
+		if !isTransfer || transaction.DestinationAccount == nil {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		return newInsightsExplorerDimensionValues(transaction.DestinationAccount.Currency, transaction.DestinationAccount.Currency, 0)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_SOURCE_AMOUNT:
+		return newInsightsExplorerDimensionValues(utils.Int64ToString(transaction.Transaction.Amount), utils.FormatAmount(transaction.Transaction.Amount), transaction.Transaction.Amount)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_DESTINATION_AMOUNT:
+		if !isTransfer {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		return newInsightsExplorerDimensionValues(utils.Int64ToString(transaction.Transaction.RelatedAccountAmount), utils.FormatAmount(transaction.Transaction.RelatedAccountAmount), transaction.Transaction.RelatedAccountAmount)
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_PRIMARY_CATEGORY:
+		if transaction.PrimaryCategory == nil {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		return newInsightsExplorerDimensionValues(utils.Int64ToString(transaction.PrimaryCategory.CategoryId), transaction.PrimaryCategory.Name, int64(transaction.PrimaryCategory.Type), int64(transaction.PrimaryCategory.DisplayOrder))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_SECONDARY_CATEGORY:
+		if transaction.SecondaryCategory == nil {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		primaryCategoryDisplayOrder := int64(0)
+
+		if transaction.PrimaryCategory != nil {
+			primaryCategoryDisplayOrder = int64(transaction.PrimaryCategory.DisplayOrder)
+		}
+
+		return newInsightsExplorerDimensionValues(utils.Int64ToString(transaction.SecondaryCategory.CategoryId), transaction.SecondaryCategory.Name, int64(transaction.SecondaryCategory.Type), primaryCategoryDisplayOrder, int64(transaction.SecondaryCategory.DisplayOrder))
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TAG:
+		values := make([]*insightsExplorerDimensionValue, 0, len(transaction.TagIds))
+
+		for i := 0; i < len(transaction.TagIds); i++ {
+			if tag, exists := context.TagMap[transaction.TagIds[i]]; exists {
+				values = append(values, &insightsExplorerDimensionValue{id: utils.Int64ToString(tag.TagId), name: tag.Name, displayOrders: []int64{1, int64(tag.DisplayOrder)}})
+			}
+		}
+
+		if len(values) < 1 {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		return values
+	case INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_ITEM:
+		values := make([]*insightsExplorerDimensionValue, 0, len(transaction.ItemIds))
+
+		for i := 0; i < len(transaction.ItemIds); i++ {
+			if item, exists := context.ItemMap[transaction.ItemIds[i]]; exists {
+				values = append(values, &insightsExplorerDimensionValue{id: utils.Int64ToString(item.ItemId), name: item.Name, displayOrders: []int64{1, int64(item.DisplayOrder)}})
+			}
+		}
+
+		if len(values) < 1 {
+			return newInsightsExplorerDimensionValues(insightsExplorerDimensionNoneId, "None", 0)
+		}
+
+		return values
+	default:
+		return newInsightsExplorerDimensionValues("", "", 0)
+	}
+}
+
+func newInsightsExplorerDimensionValues(id string, name string, displayOrders ...int64) []*insightsExplorerDimensionValue {
+	return []*insightsExplorerDimensionValue{
+		{
+			id:            id,
+			name:          name,
+			displayOrders: displayOrders,
+		},
+	}
+}
+
+func getInsightsExplorerAccountDimensionValue(account *Account, accountMap map[int64]*Account) *insightsExplorerDimensionValue {
+	primaryAccount := account
+
+	if parentAccount, exists := accountMap[account.ParentAccountId]; exists {
+		primaryAccount = parentAccount
+	}
+
+	return &insightsExplorerDimensionValue{
+		id:            utils.Int64ToString(account.AccountId),
+		name:          account.Name,
+		displayOrders: []int64{int64(primaryAccount.Category), int64(primaryAccount.DisplayOrder), int64(account.DisplayOrder)},
+	}
+}
+
+func getInsightsExplorerTransactionTypeName(transactionType TransactionType) string {
+	switch transactionType {
+	case TRANSACTION_TYPE_MODIFY_BALANCE:
+		return "Modify Balance"
+	case TRANSACTION_TYPE_INCOME:
+		return "Income"
+	case TRANSACTION_TYPE_EXPENSE:
+		return "Expense"
+	case TRANSACTION_TYPE_TRANSFER:
+		return "Transfer"
+	default:
+		return "Unknown"
+	}
+}
+
+// getInsightsExplorerFiscalYear returns the fiscal year which the date belongs to, the fiscal year is named by the calendar year in which it ends
+func getInsightsExplorerFiscalYear(dateTime time.Time, fiscalYearStart core.FiscalYearStart) int {
+	fiscalYearStartMonth, fiscalYearStartDay, err := fiscalYearStart.GetMonthDay()
+
+	if err != nil || fiscalYearStart == core.FISCAL_YEAR_START_DEFAULT {
+		return dateTime.Year()
+	}
+
+	if int(dateTime.Month()) < int(fiscalYearStartMonth) || (int(dateTime.Month()) == int(fiscalYearStartMonth) && dateTime.Day() < int(fiscalYearStartDay)) {
+		return dateTime.Year()
+	}
+
+	return dateTime.Year() + 1
+}
+
+func compareInsightsExplorerDisplayOrders(displayOrders1 []int64, name1 string, displayOrders2 []int64, name2 string) bool {
+	for i := 0; i < len(displayOrders1) && i < len(displayOrders2); i++ {
+		if displayOrders1[i] != displayOrders2[i] {
+			return displayOrders1[i] < displayOrders2[i]
+		}
+	}
+
+	if len(displayOrders1) != len(displayOrders2) {
+		return len(displayOrders1) < len(displayOrders2)
+	}
+
+	return name1 < name2
+}
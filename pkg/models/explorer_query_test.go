@@ -0,0 +1,358 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestParseInsightsExplorerQueryDefinition_EmptyData(t *testing.T) {
+	definition, err := ParseInsightsExplorerQueryDefinition(nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(definition.Queries))
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_QUERY, definition.CategoryDimension)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_NONE, definition.SeriesDimension)
+	assert.Equal(t, INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_SUM, definition.ValueMetric)
+	assert.Equal(t, INSIGHTS_EXPLORER_TIMEZONE_TYPE_APPLICATION_TIMEZONE, definition.TimezoneUsedForDateRange)
+}
+
+func TestParseInsightsExplorerQueryDefinition_SeriesDimensionIgnoredForPieChart(t *testing.T) {
+	definition, err := ParseInsightsExplorerQueryDefinition([]byte(`{"chartType":"pie","categoryDimension":"primaryCategory","seriesDimension":"dateTimeByYearMonth"}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_PRIMARY_CATEGORY, definition.CategoryDimension)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_NONE, definition.SeriesDimension)
+
+	definition, err = ParseInsightsExplorerQueryDefinition([]byte(`{"chartType":"columnStacked","categoryDimension":"primaryCategory","seriesDimension":"dateTimeByYearMonth"}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH, definition.SeriesDimension)
+}
+
+func TestParseInsightsExplorerQueryDefinition_InvalidData(t *testing.T) {
+	_, err := ParseInsightsExplorerQueryDefinition([]byte(`{"queries":`))
+	assert.Equal(t, errs.ErrInsightsExplorerDataInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"categoryDimension":"unknown"}`))
+	assert.Equal(t, errs.ErrInsightsExplorerDataDimensionInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"valueMetric":"unknown"}`))
+	assert.Equal(t, errs.ErrInsightsExplorerValueMetricInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"timezoneUsedForDateRange":2}`))
+	assert.Equal(t, errs.ErrInsightsExplorerTimezoneTypeInvalid, err)
+}
+
+func TestParseInsightsExplorerQueryDefinition_InvalidCondition(t *testing.T) {
+	_, err := ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[{"condition":{"field":"transactionType","operator":"in","value":[2]},"relation":"and"}]}]}`))
+	assert.Equal(t, errs.ErrInsightsExplorerQueryConditionInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[{"condition":{"field":"transactionType","operator":"in","value":[2]},"relation":"first"},{"condition":{"field":"transactionType","operator":"in","value":[3]},"relation":"first"}]}]}`))
+	assert.Equal(t, errs.ErrInsightsExplorerQueryConditionInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[{"condition":{"field":"transactionType","operator":"equals","value":[2]},"relation":"first"}]}]}`))
+	assert.Equal(t, errs.ErrInsightsExplorerQueryConditionInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[{"condition":{"field":"sourceAccount","operator":"in","value":["abc"]},"relation":"first"}]}]}`))
+	assert.Equal(t, errs.ErrInsightsExplorerQueryConditionInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[{"condition":{"field":"sourceAmount","operator":"between","value":[100]},"relation":"first"}]}]}`))
+	assert.Equal(t, errs.ErrInsightsExplorerQueryConditionInvalid, err)
+
+	_, err = ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[{"condition":{"field":"unknown","operator":"in","value":[]},"relation":"first"}]}]}`))
+	assert.Equal(t, errs.ErrInsightsExplorerQueryConditionInvalid, err)
+}
+
+func TestInsightsExplorerQueryDefinitionSetDimensionsAndValueMetric(t *testing.T) {
+	definition, err := ParseInsightsExplorerQueryDefinition(nil)
+	assert.Nil(t, err)
+
+	err = definition.SetDimensionsAndValueMetric(INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TAG, INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH, INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT)
+	assert.Nil(t, err)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TAG, definition.CategoryDimension)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH, definition.SeriesDimension)
+	assert.Equal(t, INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT, definition.ValueMetric)
+
+	err = definition.SetDimensionsAndValueMetric("", "", INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MAXIMUM)
+	assert.Nil(t, err)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TAG, definition.CategoryDimension)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_YEAR_MONTH, definition.SeriesDimension)
+	assert.Equal(t, INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MAXIMUM, definition.ValueMetric)
+
+	err = definition.SetDimensionsAndValueMetric("unknown", INSIGHTS_EXPLORER_DATA_DIMENSION_NONE, INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT)
+	assert.Equal(t, errs.ErrInsightsExplorerDataDimensionInvalid, err)
+	assert.Equal(t, INSIGHTS_EXPLORER_DATA_DIMENSION_TRANSACTION_TAG, definition.CategoryDimension)
+
+	err = definition.SetDimensionsAndValueMetric(INSIGHTS_EXPLORER_DATA_DIMENSION_NONE, INSIGHTS_EXPLORER_DATA_DIMENSION_NONE, "unknown")
+	assert.Equal(t, errs.ErrInsightsExplorerValueMetricInvalid, err)
+}
+
+func TestInsightsExplorerQueryDefinitionHasConditionField(t *testing.T) {
+	definition, err := ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[]},{"conditions":[{"condition":{"field":"pictures","operator":"isNotEmpty"},"relation":"first"}]}]}`))
+
+	assert.Nil(t, err)
+	assert.True(t, definition.HasConditionField(INSIGHTS_EXPLORER_CONDITION_FIELD_PICTURES))
+	assert.False(t, definition.HasConditionField(INSIGHTS_EXPLORER_CONDITION_FIELD_TRANSACTION_TAG))
+}
+
+func TestInsightsExplorerTransactionQueryMatch_AndTakesPrecedenceOverOr(t *testing.T) {
+	// type in [income] or type in [expense] and description contains "coffee"
+	definition, err := ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[` +
+		`{"condition":{"field":"transactionType","operator":"in","value":[2]},"relation":"first"},` +
+		`{"condition":{"field":"transactionType","operator":"in","value":[3]},"relation":"or"},` +
+		`{"condition":{"field":"description","operator":"contains","value":"coffee"},"relation":"and"}]}]}`))
+	assert.Nil(t, err)
+
+	query := definition.Queries[0]
+
+	assert.True(t, query.Match(newTestInsightsExplorerTransaction(TRANSACTION_TYPE_INCOME, 100, "salary")))
+	assert.True(t, query.Match(newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "morning coffee")))
+	assert.False(t, query.Match(newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "lunch")))
+	assert.False(t, query.Match(newTestInsightsExplorerTransaction(TRANSACTION_TYPE_TRANSFER, 100, "coffee")))
+}
+
+func TestInsightsExplorerTransactionQueryMatch_EmptyConditions(t *testing.T) {
+	query := &InsightsExplorerTransactionQuery{}
+	assert.True(t, query.Match(newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "")))
+}
+
+func TestInsightsExplorerConditionMatch_Category(t *testing.T) {
+	condition := parseTestInsightsExplorerCondition(t, `{"field":"transactionCategory","operator":"in","value":["10"]}`)
+
+	transaction := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "")
+	transaction.Transaction.CategoryId = 11
+	transaction.PrimaryCategory = &TransactionCategory{CategoryId: 10}
+	assert.True(t, condition.Match(transaction))
+
+	transaction.Transaction.CategoryId = 10
+	transaction.PrimaryCategory = &TransactionCategory{CategoryId: 1}
+	assert.True(t, condition.Match(transaction))
+
+	transaction.Transaction.CategoryId = 12
+	assert.False(t, condition.Match(transaction))
+}
+
+func TestInsightsExplorerConditionMatch_Accounts(t *testing.T) {
+	sourceCondition := parseTestInsightsExplorerCondition(t, `{"field":"sourceAccount","operator":"in","value":["1"]}`)
+	destinationCondition := parseTestInsightsExplorerCondition(t, `{"field":"destinationAccount","operator":"in","value":["2"]}`)
+
+	transaction := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_TRANSFER, 100, "")
+	transaction.Transaction.AccountId = 1
+	transaction.Transaction.RelatedAccountId = 2
+	assert.True(t, sourceCondition.Match(transaction))
+	assert.True(t, destinationCondition.Match(transaction))
+
+	transaction.Type = TRANSACTION_TYPE_EXPENSE
+	assert.False(t, destinationCondition.Match(transaction))
+}
+
+func TestInsightsExplorerConditionMatch_Amount(t *testing.T) {
+	transaction := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 1000, "")
+
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"sourceAmount","operator":"equals","value":[1000,0]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"sourceAmount","operator":"notEquals","value":[1000,0]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"sourceAmount","operator":"greaterThan","value":[999,0]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"sourceAmount","operator":"lessThan","value":[1000,0]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"sourceAmount","operator":"between","value":[1000,2000]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"sourceAmount","operator":"notBetween","value":[1000,2000]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"destinationAmount","operator":"equals","value":[0,0]}`).Match(transaction))
+}
+
+func TestInsightsExplorerConditionMatch_Tags(t *testing.T) {
+	transaction := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "")
+	transaction.TagIds = []int64{1, 2}
+
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"hasAny","value":["2","3"]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"hasAll","value":["2","3"]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"hasAll","value":["1","2"]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"notHasAny","value":["2","3"]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"notHasAll","value":["2","3"]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"equals","value":["2","1"]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"equals","value":["1"]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"notEquals","value":["1"]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"isNotEmpty","value":[]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"isEmpty","value":[]}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"hasAny","value":[]}`).Match(transaction))
+
+	transaction.TagIds = nil
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"hasAny","value":[]}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"transactionTag","operator":"isEmpty","value":[]}`).Match(transaction))
+}
+
+func TestInsightsExplorerConditionMatch_PicturesAndDescription(t *testing.T) {
+	transaction := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "Coffee at station")
+	transaction.HasPictures = true
+
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"pictures","operator":"isNotEmpty"}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"pictures","operator":"isEmpty"}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"description","operator":"startsWith","value":"Coffee"}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"description","operator":"notStartsWith","value":"Coffee"}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"description","operator":"endsWith","value":"station"}`).Match(transaction))
+	assert.True(t, parseTestInsightsExplorerCondition(t, `{"field":"description","operator":"notContains","value":"tea"}`).Match(transaction))
+	assert.False(t, parseTestInsightsExplorerCondition(t, `{"field":"description","operator":"isEmpty","value":""}`).Match(transaction))
+}
+
+func TestNewInsightsExplorerQueryResult_QueryDimensionCountsTransactionInEachMatchedQuery(t *testing.T) {
+	definition, err := ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[` +
+		`{"name":"Expense","conditions":[{"condition":{"field":"transactionType","operator":"in","value":[3]},"relation":"first"}]},` +
+		`{"name":"Large","conditions":[{"condition":{"field":"sourceAmount","operator":"greaterThan","value":[500,0]},"relation":"first"}]}]}`))
+	assert.Nil(t, err)
+
+	transactions := []*InsightsExplorerTransaction{
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, ""),
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 1000, ""),
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_INCOME, 2000, ""),
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_INCOME, 200, ""),
+	}
+
+	result := NewInsightsExplorerQueryResult(definition, transactions, newTestInsightsExplorerQueryContext())
+
+	assert.Equal(t, "USD", result.Currency)
+	assert.Equal(t, 3, result.TransactionCount)
+	assert.Equal(t, 2, len(result.Items))
+	assert.Equal(t, "1", result.Items[0].CategoryId)
+	assert.Equal(t, "Expense", result.Items[0].CategoryName)
+	assert.Equal(t, int64(1100), result.Items[0].Series[0].Value)
+	assert.Equal(t, "2", result.Items[1].CategoryId)
+	assert.Equal(t, "Large", result.Items[1].CategoryName)
+	assert.Equal(t, int64(3000), result.Items[1].Series[0].Value)
+}
+
+func TestNewInsightsExplorerQueryResult_OtherDimensionCountsTransactionInFirstMatchedQuery(t *testing.T) {
+	definition, err := ParseInsightsExplorerQueryDefinition([]byte(`{"chartType":"columnStacked","categoryDimension":"transactionType","seriesDimension":"dateTimeByYearMonth","valueMetric":"transactionCount","queries":[` +
+		`{"conditions":[{"condition":{"field":"transactionType","operator":"in","value":[3]},"relation":"first"}]},` +
+		`{"conditions":[{"condition":{"field":"sourceAmount","operator":"greaterThan","value":[500,0]},"relation":"first"}]}]}`))
+	assert.Nil(t, err)
+
+	transaction1 := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 1000, "")
+	transaction1.Transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC).Unix())
+	transaction2 := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "")
+	transaction2.Transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 2, 15, 10, 0, 0, 0, time.UTC).Unix())
+	transaction3 := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_INCOME, 1000, "")
+	transaction3.Transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC).Unix())
+
+	result := NewInsightsExplorerQueryResult(definition, []*InsightsExplorerTransaction{transaction2, transaction3, transaction1}, newTestInsightsExplorerQueryContext())
+
+	assert.Equal(t, "", result.Currency)
+	assert.Equal(t, 3, result.TransactionCount)
+	assert.Equal(t, 2, len(result.Items))
+	assert.Equal(t, "Income", result.Items[0].CategoryName)
+	assert.Equal(t, 1, len(result.Items[0].Series))
+	assert.Equal(t, "2024-01", result.Items[0].Series[0].SeriesId)
+	assert.Equal(t, int64(1), result.Items[0].Series[0].Value)
+	assert.Equal(t, "Expense", result.Items[1].CategoryName)
+	assert.Equal(t, 2, len(result.Items[1].Series))
+	assert.Equal(t, "2024-01", result.Items[1].Series[0].SeriesId)
+	assert.Equal(t, int64(1), result.Items[1].Series[0].Value)
+	assert.Equal(t, "2024-02", result.Items[1].Series[1].SeriesId)
+	assert.Equal(t, int64(1), result.Items[1].Series[1].Value)
+}
+
+func TestNewInsightsExplorerQueryResult_TagDimension(t *testing.T) {
+	definition, err := ParseInsightsExplorerQueryDefinition([]byte(`{"categoryDimension":"transactionTag"}`))
+	assert.Nil(t, err)
+
+	transaction1 := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, "")
+	transaction1.TagIds = []int64{1, 2}
+	transaction2 := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 200, "")
+	transaction2.TagIds = []int64{2}
+	transaction3 := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 400, "")
+
+	context := newTestInsightsExplorerQueryContext()
+	context.TagMap = map[int64]*TransactionTag{
+		1: {TagId: 1, Name: "Travel", DisplayOrder: 2},
+		2: {TagId: 2, Name: "Food", DisplayOrder: 1},
+	}
+
+	result := NewInsightsExplorerQueryResult(definition, []*InsightsExplorerTransaction{transaction1, transaction2, transaction3}, context)
+
+	assert.Equal(t, 3, result.TransactionCount)
+	assert.Equal(t, 3, len(result.Items))
+	assert.Equal(t, "none", result.Items[0].CategoryId)
+	assert.Equal(t, int64(400), result.Items[0].Series[0].Value)
+	assert.Equal(t, "Food", result.Items[1].CategoryName)
+	assert.Equal(t, int64(300), result.Items[1].Series[0].Value)
+	assert.Equal(t, "Travel", result.Items[2].CategoryName)
+	assert.Equal(t, int64(100), result.Items[2].Series[0].Value)
+}
+
+func TestNewInsightsExplorerQueryResult_ValueMetrics(t *testing.T) {
+	transactions := []*InsightsExplorerTransaction{
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100, ""),
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 400, ""),
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 200, ""),
+		newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 105, ""),
+	}
+
+	notExchangedTransaction := newTestInsightsExplorerTransaction(TRANSACTION_TYPE_EXPENSE, 100000, "")
+	notExchangedTransaction.AmountExchanged = false
+	transactions = append(transactions, notExchangedTransaction)
+
+	expectedValues := map[InsightsExplorerValueMetric]int64{
+		INSIGHTS_EXPLORER_VALUE_METRIC_TRANSACTION_COUNT:     4,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_SUM:     805,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_AVERAGE: 201,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MEDIAN:  200,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MINIMUM: 100,
+		INSIGHTS_EXPLORER_VALUE_METRIC_SOURCE_AMOUNT_MAXIMUM: 400,
+	}
+
+	for valueMetric, expectedValue := range expectedValues {
+		definition, err := ParseInsightsExplorerQueryDefinition(nil)
+		assert.Nil(t, err)
+		assert.Nil(t, definition.SetDimensionsAndValueMetric(INSIGHTS_EXPLORER_DATA_DIMENSION_NONE, INSIGHTS_EXPLORER_DATA_DIMENSION_NONE, valueMetric))
+
+		result := NewInsightsExplorerQueryResult(definition, transactions, newTestInsightsExplorerQueryContext())
+
+		assert.Equal(t, 1, len(result.Items))
+		assert.Equal(t, expectedValue, result.Items[0].Series[0].Value, string(valueMetric))
+	}
+}
+
+func TestGetInsightsExplorerFiscalYear(t *testing.T) {
+	fiscalYearStart, err := core.NewFiscalYearStart(4, 1)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2024, getInsightsExplorerFiscalYear(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), fiscalYearStart))
+	assert.Equal(t, 2025, getInsightsExplorerFiscalYear(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), fiscalYearStart))
+	assert.Equal(t, 2024, getInsightsExplorerFiscalYear(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), core.FISCAL_YEAR_START_DEFAULT))
+}
+
+func parseTestInsightsExplorerCondition(t *testing.T, condition string) *InsightsExplorerCondition {
+	definition, err := ParseInsightsExplorerQueryDefinition([]byte(`{"queries":[{"conditions":[{"condition":` + condition + `,"relation":"first"}]}]}`))
+	assert.Nil(t, err)
+
+	return definition.Queries[0].Conditions[0].Condition
+}
+
+func newTestInsightsExplorerTransaction(transactionType TransactionType, amount int64, comment string) *InsightsExplorerTransaction {
+	return &InsightsExplorerTransaction{
+		Transaction: &Transaction{
+			Amount:          amount,
+			Comment:         comment,
+			TransactionTime: utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()),
+		},
+		Type:                    transactionType,
+		SourceAccount:           &Account{AccountId: 1, Name: "Cash", Currency: "USD"},
+		AmountInDefaultCurrency: amount,
+		AmountExchanged:         true,
+	}
+}
+
+func newTestInsightsExplorerQueryContext() *InsightsExplorerQueryContext {
+	return &InsightsExplorerQueryContext{
+		DefaultCurrency: "USD",
+		FirstDayOfWeek:  core.WEEKDAY_SUNDAY,
+		FiscalYearStart: core.FISCAL_YEAR_START_DEFAULT,
+		Timezone:        time.UTC,
+		AccountMap:      map[int64]*Account{},
+		TagMap:          map[int64]*TransactionTag{},
+		ItemMap:         map[int64]*TransactionItem{},
+	}
+}
@@ -0,0 +1,347 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// FinancialReportPeriodType represents the period type of financial report
+type FinancialReportPeriodType byte
+
+// Financial report period types
+const (
+	FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY FinancialReportPeriodType = 1
+	FINANCIAL_REPORT_PERIOD_TYPE_ANNUAL  FinancialReportPeriodType = 2
+)
+
+// String returns a textual representation of the financial report period type enum
+func (t FinancialReportPeriodType) String() string {
+	switch t {
+	case FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY:
+		return "Monthly"
+	case FINANCIAL_REPORT_PERIOD_TYPE_ANNUAL:
+		return "Annual"
+	default:
+		return fmt.Sprintf("Invalid(%d)", int(t))
+	}
+}
+
+// FinancialReportRequest represents all parameters of financial report request
+type FinancialReportRequest struct {
+	PeriodType FinancialReportPeriodType `form:"period_type" binding:"required,min=1,max=2"`
+	Year       int32                     `form:"year" binding:"required,min=1970,max=9999"`
+	Month      int32                     `form:"month" binding:"min=0,max=12"`
+}
+
+// FinancialReport represents the income statement, balance sheet and category breakdown of a period in the report currency
+type FinancialReport struct {
+	PeriodType        FinancialReportPeriodType
+	StartTime         time.Time
+	EndTime           time.Time
+	Currency          string
+	IncomeCategories  []*FinancialReportCategoryItem
+	ExpenseCategories []*FinancialReportCategoryItem
+	TotalIncome       int64
+	TotalExpense      int64
+	NetIncome         int64
+	Assets            []*FinancialReportAccountItem
+	Liabilities       []*FinancialReportAccountItem
+	TotalAssets       int64
+	TotalLiabilities  int64
+	NetAssets         int64
+}
+
+// FinancialReportCategoryItem represents the total amount of a transaction category within the report period
+type FinancialReportCategoryItem struct {
+	CategoryId    int64
+	Name          string
+	Amount        int64
+	Percentage    float64
+	SubCategories []*FinancialReportCategoryItem
+}
+
+// FinancialReportAccountItem represents the closing balance of an account at the end of the report period
+type FinancialReportAccountItem struct {
+	AccountId int64
+	Name      string
+	Currency  string
+	Balance   int64
+	Amount    int64
+}
+
+type financialReportAccountSortItem struct {
+	item                *FinancialReportAccountItem
+	displayOrder        int32
+	subAccountSortOrder int32
+}
+
+// GetTimeRange returns the start time and the end time (inclusive) of the report period in the specified timezone
+func (r *FinancialReportRequest) GetTimeRange(location *time.Location) (time.Time, time.Time, error) {
+	if r.PeriodType == FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY {
+		if r.Month < 1 || r.Month > 12 {
+			return time.Time{}, time.Time{}, errs.ErrReportPeriodInvalid
+		}
+
+		startTime := time.Date(int(r.Year), time.Month(r.Month), 1, 0, 0, 0, 0, location)
+		return startTime, startTime.AddDate(0, 1, 0).Add(-time.Second), nil
+	} else if r.PeriodType == FINANCIAL_REPORT_PERIOD_TYPE_ANNUAL {
+		startTime := time.Date(int(r.Year), time.January, 1, 0, 0, 0, 0, location)
+		return startTime, startTime.AddDate(1, 0, 0).Add(-time.Second), nil
+	}
+
+	return time.Time{}, time.Time{}, errs.ErrReportPeriodTypeInvalid
+}
+
+// GetAccountsClosingBalances returns the closing balance of every account on the last date which the account has balance in the daily account balances
+func GetAccountsClosingBalances(accountDailyBalances map[int32][]*TransactionWithAccountBalance) map[int64]int64 {
+	closingBalances := make(map[int64]int64)
+	closingBalanceDates := make(map[int64]int32)
+
+	for yearMonthDay, dailyAccountBalances := range accountDailyBalances {
+		for i := 0; i < len(dailyAccountBalances); i++ {
+			accountBalance := dailyAccountBalances[i]
+			lastDate, exists := closingBalanceDates[accountBalance.AccountId]
+
+			if exists && lastDate > yearMonthDay {
+				continue
+			}
+
+			closingBalances[accountBalance.AccountId] = accountBalance.AccountClosingBalance
+			closingBalanceDates[accountBalance.AccountId] = yearMonthDay
+		}
+	}
+
+	return closingBalances
+}
+
+// GetAccountsOpeningBalances returns the opening balance of every account on the first date which the account has balance in the daily account balances
+func GetAccountsOpeningBalances(accountDailyBalances map[int32][]*TransactionWithAccountBalance) map[int64]int64 {
+	openingBalances := make(map[int64]int64)
+	openingBalanceDates := make(map[int64]int32)
+
+	for yearMonthDay, dailyAccountBalances := range accountDailyBalances {
+		for i := 0; i < len(dailyAccountBalances); i++ {
+			accountBalance := dailyAccountBalances[i]
+			firstDate, exists := openingBalanceDates[accountBalance.AccountId]
+
+			if exists && firstDate < yearMonthDay {
+				continue
+			}
+
+			openingBalances[accountBalance.AccountId] = accountBalance.AccountOpeningBalance
+			openingBalanceDates[accountBalance.AccountId] = yearMonthDay
+		}
+	}
+
+	return openingBalances
+}
+
+// NewFinancialReport returns a financial report according to the category total amounts (already exchanged to the report currency) within the period
+// and the closing balances of accounts at the end of the period, the account balances are exchanged at the exchange rates on the last date of the period
+func NewFinancialReport(periodType FinancialReportPeriodType, startTime time.Time, endTime time.Time, currency string, categories []*TransactionCategory, categoryTotalAmounts []*Transaction, accounts []*Account, accountClosingBalances map[int64]int64, exchangeRates *HistoricalExchangeRateMap) (*FinancialReport, bool) {
+	report := &FinancialReport{
+		PeriodType:        periodType,
+		StartTime:         startTime,
+		EndTime:           endTime,
+		Currency:          currency,
+		IncomeCategories:  make([]*FinancialReportCategoryItem, 0),
+		ExpenseCategories: make([]*FinancialReportCategoryItem, 0),
+		Assets:            make([]*FinancialReportAccountItem, 0),
+		Liabilities:       make([]*FinancialReportAccountItem, 0),
+	}
+
+	report.IncomeCategories, report.TotalIncome = getFinancialReportCategoryItems(categories, categoryTotalAmounts, CATEGORY_TYPE_INCOME, TRANSACTION_DB_TYPE_INCOME)
+	report.ExpenseCategories, report.TotalExpense = getFinancialReportCategoryItems(categories, categoryTotalAmounts, CATEGORY_TYPE_EXPENSE, TRANSACTION_DB_TYPE_EXPENSE)
+	report.NetIncome = report.TotalIncome - report.TotalExpense
+
+	accountMap := make(map[int64]*Account, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountMap[accounts[i].AccountId] = accounts[i]
+	}
+
+	rateDate := utils.FormatUnixTimeToNumericYearMonthDay(endTime.Unix(), endTime.Location())
+	assets := make([]*financialReportAccountSortItem, 0)
+	liabilities := make([]*financialReportAccountSortItem, 0)
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.Type != ACCOUNT_TYPE_SINGLE_ACCOUNT {
+			continue
+		}
+
+		balance := accountClosingBalances[account.AccountId]
+
+		if balance == 0 {
+			continue
+		}
+
+		amount := balance
+
+		if account.Currency != currency {
+			if exchangeRates == nil {
+				return nil, false
+			}
+
+			exchangedAmount, ok := exchangeRates.ExchangeAmount(balance, account.Currency, currency, rateDate)
+
+			if !ok {
+				return nil, false
+			}
+
+			amount = exchangedAmount
+		}
+
+		sortItem := &financialReportAccountSortItem{
+			item: &FinancialReportAccountItem{
+				AccountId: account.AccountId,
+				Name:      account.Name,
+				Currency:  account.Currency,
+				Balance:   balance,
+				Amount:    amount,
+			},
+			displayOrder: account.DisplayOrder,
+		}
+
+		accountCategory := account.Category
+		parentAccount, exists := accountMap[account.ParentAccountId]
+
+		if account.ParentAccountId > 0 && exists {
+			sortItem.item.Name = parentAccount.Name + " / " + account.Name
+			sortItem.displayOrder = parentAccount.DisplayOrder
+			sortItem.subAccountSortOrder = account.DisplayOrder
+			accountCategory = parentAccount.Category
+		}
+
+		if accountCategory.IsLiability() {
+			sortItem.item.Amount = -sortItem.item.Amount
+			report.TotalLiabilities += sortItem.item.Amount
+			liabilities = append(liabilities, sortItem)
+		} else {
+			report.TotalAssets += sortItem.item.Amount
+			assets = append(assets, sortItem)
+		}
+	}
+
+	report.Assets = sortFinancialReportAccountItems(assets)
+	report.Liabilities = sortFinancialReportAccountItems(liabilities)
+	report.NetAssets = report.TotalAssets - report.TotalLiabilities
+
+	return report, true
+}
+
+func getFinancialReportCategoryItems(categories []*TransactionCategory, categoryTotalAmounts []*Transaction, categoryType TransactionCategoryType, transactionDbType TransactionDbType) ([]*FinancialReportCategoryItem, int64) {
+	categoryAmounts := make(map[int64]int64)
+	totalAmount := int64(0)
+
+	for i := 0; i < len(categoryTotalAmounts); i++ {
+		if categoryTotalAmounts[i].Type != transactionDbType {
+			continue
+		}
+
+		categoryAmounts[categoryTotalAmounts[i].CategoryId] += categoryTotalAmounts[i].Amount
+	}
+
+	primaryCategories := make([]*TransactionCategory, 0)
+	secondaryCategories := make(map[int64][]*TransactionCategory)
+
+	for i := 0; i < len(categories); i++ {
+		category := categories[i]
+
+		if category.Type != categoryType {
+			continue
+		}
+
+		if category.ParentCategoryId == LevelOneTransactionCategoryParentId {
+			primaryCategories = append(primaryCategories, category)
+		} else {
+			secondaryCategories[category.ParentCategoryId] = append(secondaryCategories[category.ParentCategoryId], category)
+		}
+	}
+
+	sort.SliceStable(primaryCategories, func(i, j int) bool {
+		return primaryCategories[i].DisplayOrder < primaryCategories[j].DisplayOrder
+	})
+
+	items := make([]*FinancialReportCategoryItem, 0)
+
+	for i := 0; i < len(primaryCategories); i++ {
+		primaryCategory := primaryCategories[i]
+		subCategories := secondaryCategories[primaryCategory.CategoryId]
+
+		sort.SliceStable(subCategories, func(i, j int) bool {
+			return subCategories[i].DisplayOrder < subCategories[j].DisplayOrder
+		})
+
+		item := &FinancialReportCategoryItem{
+			CategoryId:    primaryCategory.CategoryId,
+			Name:          primaryCategory.Name,
+			Amount:        categoryAmounts[primaryCategory.CategoryId],
+			SubCategories: make([]*FinancialReportCategoryItem, 0),
+		}
+
+		for j := 0; j < len(subCategories); j++ {
+			subCategory := subCategories[j]
+			amount, exists := categoryAmounts[subCategory.CategoryId]
+
+			if !exists || amount == 0 {
+				continue
+			}
+
+			item.Amount += amount
+			item.SubCategories = append(item.SubCategories, &FinancialReportCategoryItem{
+				CategoryId: subCategory.CategoryId,
+				Name:       subCategory.Name,
+				Amount:     amount,
+			})
+		}
+
+		if item.Amount == 0 {
+			continue
+		}
+
+		totalAmount += item.Amount
+		items = append(items, item)
+	}
+
+	for i := 0; i < len(items); i++ {
+		items[i].Percentage = getFinancialReportPercentage(items[i].Amount, totalAmount)
+
+		for j := 0; j < len(items[i].SubCategories); j++ {
+			items[i].SubCategories[j].Percentage = getFinancialReportPercentage(items[i].SubCategories[j].Amount, totalAmount)
+		}
+	}
+
+	return items, totalAmount
+}
+
+func getFinancialReportPercentage(amount int64, totalAmount int64) float64 {
+	if totalAmount == 0 {
+		return 0
+	}
+
+	return float64(amount) * 100 / float64(totalAmount)
+}
+
+func sortFinancialReportAccountItems(sortItems []*financialReportAccountSortItem) []*FinancialReportAccountItem {
+	sort.SliceStable(sortItems, func(i, j int) bool {
+		if sortItems[i].displayOrder != sortItems[j].displayOrder {
+			return sortItems[i].displayOrder < sortItems[j].displayOrder
+		}
+
+		return sortItems[i].subAccountSortOrder < sortItems[j].subAccountSortOrder
+	})
+
+	items := make([]*FinancialReportAccountItem, len(sortItems))
+
+	for i := 0; i < len(sortItems); i++ {
+		items[i] = sortItems[i].item
+	}
+
+	return items
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestFinancialReportRequestGetTimeRange_Monthly(t *testing.T) {
+	location := time.FixedZone("Test Timezone", 8*60*60)
+	req := &FinancialReportRequest{
+		PeriodType: FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY,
+		Year:       2024,
+		Month:      2,
+	}
+
+	startTime, endTime, err := req.GetTimeRange(location)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, location), startTime)
+	assert.Equal(t, time.Date(2024, 2, 29, 23, 59, 59, 0, location), endTime)
+
+	req.Month = 12
+	startTime, endTime, err = req.GetTimeRange(location)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, location), startTime)
+	assert.Equal(t, time.Date(2024, 12, 31, 23, 59, 59, 0, location), endTime)
+
+	req.Month = 0
+	_, _, err = req.GetTimeRange(location)
+	assert.Equal(t, errs.ErrReportPeriodInvalid, err)
+}
+
+func TestFinancialReportRequestGetTimeRange_Annual(t *testing.T) {
+	req := &FinancialReportRequest{
+		PeriodType: FINANCIAL_REPORT_PERIOD_TYPE_ANNUAL,
+		Year:       2023,
+	}
+
+	startTime, endTime, err := req.GetTimeRange(time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), endTime)
+
+	req.PeriodType = FinancialReportPeriodType(3)
+	_, _, err = req.GetTimeRange(time.UTC)
+	assert.Equal(t, errs.ErrReportPeriodTypeInvalid, err)
+}
+
+func TestGetAccountsClosingBalances(t *testing.T) {
+	accountDailyBalances := map[int32][]*TransactionWithAccountBalance{
+		20240101: {
+			{Transaction: &Transaction{AccountId: 1}, AccountClosingBalance: 100},
+			{Transaction: &Transaction{AccountId: 2}, AccountClosingBalance: 200},
+		},
+		20240115: {
+			{Transaction: &Transaction{AccountId: 1}, AccountClosingBalance: 150},
+		},
+		20240110: {
+			{Transaction: &Transaction{AccountId: 1}, AccountClosingBalance: 120},
+			{Transaction: &Transaction{AccountId: 2}, AccountClosingBalance: -50},
+		},
+	}
+
+	assert.Equal(t, map[int64]int64{1: 150, 2: -50}, GetAccountsClosingBalances(accountDailyBalances))
+}
+
+func TestNewFinancialReport_IncomeStatementAndCategoryBreakdown(t *testing.T) {
+	categories := []*TransactionCategory{
+		{CategoryId: 1, Type: CATEGORY_TYPE_EXPENSE, Name: "Food", DisplayOrder: 2},
+		{CategoryId: 2, Type: CATEGORY_TYPE_EXPENSE, Name: "Transport", DisplayOrder: 1},
+		{CategoryId: 11, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 1, Name: "Dinner", DisplayOrder: 2},
+		{CategoryId: 12, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 1, Name: "Lunch", DisplayOrder: 1},
+		{CategoryId: 21, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 2, Name: "Taxi", DisplayOrder: 1},
+		{CategoryId: 3, Type: CATEGORY_TYPE_INCOME, Name: "Salary", DisplayOrder: 1},
+		{CategoryId: 31, Type: CATEGORY_TYPE_INCOME, ParentCategoryId: 3, Name: "Wage", DisplayOrder: 1},
+		{CategoryId: 4, Type: CATEGORY_TYPE_INCOME, Name: "Other", DisplayOrder: 2},
+		{CategoryId: 41, Type: CATEGORY_TYPE_INCOME, ParentCategoryId: 4, Name: "Gift", DisplayOrder: 1},
+	}
+	totalAmounts := []*Transaction{
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 1, Amount: 3000},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 2, Amount: 1000},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 12, AccountId: 1, Amount: 2000},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 21, AccountId: 1, Amount: 4000},
+		{Type: TRANSACTION_DB_TYPE_INCOME, CategoryId: 31, AccountId: 1, Amount: 20000},
+		{Type: TRANSACTION_DB_TYPE_TRANSFER_OUT, CategoryId: 51, AccountId: 1, RelatedAccountId: 2, Amount: 5000},
+	}
+
+	report, ok := NewFinancialReport(FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC), "USD", categories, totalAmounts, nil, nil, nil)
+	assert.True(t, ok)
+
+	assert.Equal(t, int64(20000), report.TotalIncome)
+	assert.Equal(t, int64(10000), report.TotalExpense)
+	assert.Equal(t, int64(10000), report.NetIncome)
+
+	assert.Equal(t, 1, len(report.IncomeCategories))
+	assert.Equal(t, "Salary", report.IncomeCategories[0].Name)
+	assert.Equal(t, int64(20000), report.IncomeCategories[0].Amount)
+	assert.Equal(t, float64(100), report.IncomeCategories[0].Percentage)
+
+	assert.Equal(t, 2, len(report.ExpenseCategories))
+	assert.Equal(t, "Transport", report.ExpenseCategories[0].Name)
+	assert.Equal(t, int64(4000), report.ExpenseCategories[0].Amount)
+	assert.Equal(t, float64(40), report.ExpenseCategories[0].Percentage)
+	assert.Equal(t, "Food", report.ExpenseCategories[1].Name)
+	assert.Equal(t, int64(6000), report.ExpenseCategories[1].Amount)
+	assert.Equal(t, float64(60), report.ExpenseCategories[1].Percentage)
+
+	assert.Equal(t, 2, len(report.ExpenseCategories[1].SubCategories))
+	assert.Equal(t, "Lunch", report.ExpenseCategories[1].SubCategories[0].Name)
+	assert.Equal(t, int64(2000), report.ExpenseCategories[1].SubCategories[0].Amount)
+	assert.Equal(t, float64(20), report.ExpenseCategories[1].SubCategories[0].Percentage)
+	assert.Equal(t, "Dinner", report.ExpenseCategories[1].SubCategories[1].Name)
+	assert.Equal(t, int64(4000), report.ExpenseCategories[1].SubCategories[1].Amount)
+	assert.Equal(t, float64(40), report.ExpenseCategories[1].SubCategories[1].Percentage)
+}
+
+func TestNewFinancialReport_BalanceSheet(t *testing.T) {
+	accounts := []*Account{
+		{AccountId: 1, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CASH, Name: "Cash", Currency: "EUR", DisplayOrder: 2},
+		{AccountId: 2, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CREDIT_CARD, Name: "Credit Card", Currency: "EUR", DisplayOrder: 3},
+		{AccountId: 3, Type: ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS, Category: ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Name: "Bank", Currency: "EUR", DisplayOrder: 1},
+		{AccountId: 4, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, ParentAccountId: 3, Name: "USD", Currency: "USD", DisplayOrder: 2},
+		{AccountId: 5, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, ParentAccountId: 3, Name: "EUR", Currency: "EUR", DisplayOrder: 1},
+		{AccountId: 6, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CASH, Name: "Empty", Currency: "EUR", DisplayOrder: 4},
+	}
+	closingBalances := map[int64]int64{
+		1: 1000,
+		2: -3000,
+		4: 2400,
+		5: 500,
+	}
+	histories := []*ExchangeRateHistory{
+		{RateDate: 20240130, Currency: "USD", BaseCurrency: "EUR", Rate: "1.2"},
+	}
+	exchangeRates := NewHistoricalExchangeRateMap(histories, ExchangeRateMap{"EUR": 1, "USD": 1.5})
+
+	report, ok := NewFinancialReport(FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC), "EUR", nil, nil, accounts, closingBalances, exchangeRates)
+	assert.True(t, ok)
+
+	assert.Equal(t, 3, len(report.Assets))
+	assert.Equal(t, "Bank / EUR", report.Assets[0].Name)
+	assert.Equal(t, int64(500), report.Assets[0].Amount)
+	assert.Equal(t, "Bank / USD", report.Assets[1].Name)
+	assert.Equal(t, int64(2400), report.Assets[1].Balance)
+	assert.Equal(t, int64(2000), report.Assets[1].Amount)
+	assert.Equal(t, "Cash", report.Assets[2].Name)
+	assert.Equal(t, int64(1000), report.Assets[2].Amount)
+
+	assert.Equal(t, 1, len(report.Liabilities))
+	assert.Equal(t, "Credit Card", report.Liabilities[0].Name)
+	assert.Equal(t, int64(-3000), report.Liabilities[0].Balance)
+	assert.Equal(t, int64(3000), report.Liabilities[0].Amount)
+
+	assert.Equal(t, int64(3500), report.TotalAssets)
+	assert.Equal(t, int64(3000), report.TotalLiabilities)
+	assert.Equal(t, int64(500), report.NetAssets)
+}
+
+func TestNewFinancialReport_ExchangeRateNotFound(t *testing.T) {
+	accounts := []*Account{
+		{AccountId: 1, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CASH, Name: "Cash", Currency: "JPY"},
+	}
+	exchangeRates := NewHistoricalExchangeRateMap(nil, ExchangeRateMap{"EUR": 1, "USD": 1.5})
+
+	_, ok := NewFinancialReport(FINANCIAL_REPORT_PERIOD_TYPE_ANNUAL, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC), "EUR", nil, nil, accounts, map[int64]int64{1: 100}, exchangeRates)
+	assert.False(t, ok)
+
+	_, ok = NewFinancialReport(FINANCIAL_REPORT_PERIOD_TYPE_ANNUAL, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC), "EUR", nil, nil, accounts, map[int64]int64{1: 100}, nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// ImportBatch represents the record of one transaction import, stored in database
+type ImportBatch struct {
+	BatchId            int64  `xorm:"PK"`
+	Uid                int64  `xorm:"INDEX(IDX_import_batch_uid_deleted_time) INDEX(IDX_import_batch_uid_deleted_import_job_id) NOT NULL"`
+	Deleted            bool   `xorm:"INDEX(IDX_import_batch_uid_deleted_time) INDEX(IDX_import_batch_uid_deleted_import_job_id) NOT NULL"`
+	ImportJobId        int64  `xorm:"INDEX(IDX_import_batch_uid_deleted_import_job_id) NOT NULL"`
+	FileType           string `xorm:"VARCHAR(64) NOT NULL"`
+	FileHash           string `xorm:"VARCHAR(64) NOT NULL"`
+	TotalCount         int32  `xorm:"NOT NULL"`
+	ImportedCount      int32  `xorm:"NOT NULL"`
+	SkippedCount       int32  `xorm:"NOT NULL"`
+	CreatedAccountIds  string `xorm:"TEXT NOT NULL"`
+	CreatedCategoryIds string `xorm:"TEXT NOT NULL"`
+	CreatedTagIds      string `xorm:"TEXT NOT NULL"`
+	TransactionIds     string `xorm:"LONGTEXT NOT NULL"`
+	RolledBack         bool   `xorm:"NOT NULL"`
+	CreatedUnixTime    int64  `xorm:"INDEX(IDX_import_batch_uid_deleted_time)"`
+	UpdatedUnixTime    int64
+	RolledBackUnixTime int64
+	DeletedUnixTime    int64
+}
+
+// ImportBatchListRequest represents all parameters of import batch listing request
+type ImportBatchListRequest struct {
+	Count int32 `form:"count" binding:"omitempty,min=1,max=50"`
+}
+
+// ImportBatchRollbackRequest represents all parameters of import batch rolling back request
+type ImportBatchRollbackRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// ImportBatchInfoResponse represents a view-object of import batch
+type ImportBatchInfoResponse struct {
+	Id                 int64    `json:"id,string"`
+	FileType           string   `json:"fileType"`
+	FileHash           string   `json:"fileHash"`
+	TotalCount         int32    `json:"totalCount"`
+	ImportedCount      int32    `json:"importedCount"`
+	SkippedCount       int32    `json:"skippedCount"`
+	CreatedAccountIds  []string `json:"createdAccountIds"`
+	CreatedCategoryIds []string `json:"createdCategoryIds"`
+	CreatedTagIds      []string `json:"createdTagIds"`
+	TransactionIds     []string `json:"transactionIds"`
+	RolledBack         bool     `json:"rolledBack"`
+	CreatedTime        int64    `json:"createdTime"`
+	RolledBackTime     int64    `json:"rolledBackTime,omitempty"`
+}
+
+// ImportBatchRollbackResponse represents the result of rolling back import batch
+type ImportBatchRollbackResponse struct {
+	DeletedTransactionCount int `json:"deletedTransactionCount"`
+	DeletedAccountCount     int `json:"deletedAccountCount"`
+	DeletedCategoryCount    int `json:"deletedCategoryCount"`
+	DeletedTagCount         int `json:"deletedTagCount"`
+}
+
+// GetCreatedAccountIds returns the ids of accounts which are created for the import batch
+func (b *ImportBatch) GetCreatedAccountIds() []int64 {
+	return parseImportBatchIds(b.CreatedAccountIds)
+}
+
+// SetCreatedAccountIds sets the ids of accounts which are created for the import batch
+func (b *ImportBatch) SetCreatedAccountIds(accountIds []int64) {
+	b.CreatedAccountIds = formatImportBatchIds(accountIds)
+}
+
+// GetCreatedCategoryIds returns the ids of transaction categories which are created for the import batch
+func (b *ImportBatch) GetCreatedCategoryIds() []int64 {
+	return parseImportBatchIds(b.CreatedCategoryIds)
+}
+
+// SetCreatedCategoryIds sets the ids of transaction categories which are created for the import batch
+func (b *ImportBatch) SetCreatedCategoryIds(categoryIds []int64) {
+	b.CreatedCategoryIds = formatImportBatchIds(categoryIds)
+}
+
+// GetCreatedTagIds returns the ids of transaction tags which are created for the import batch
+func (b *ImportBatch) GetCreatedTagIds() []int64 {
+	return parseImportBatchIds(b.CreatedTagIds)
+}
+
+// SetCreatedTagIds sets the ids of transaction tags which are created for the import batch
+func (b *ImportBatch) SetCreatedTagIds(tagIds []int64) {
+	b.CreatedTagIds = formatImportBatchIds(tagIds)
+}
+
+// GetTransactionIds returns the ids of transactions which are imported in the import batch
+func (b *ImportBatch) GetTransactionIds() []int64 {
+	return parseImportBatchIds(b.TransactionIds)
+}
+
+// SetTransactionIds sets the ids of transactions which are imported in the import batch
+func (b *ImportBatch) SetTransactionIds(transactionIds []int64) {
+	b.TransactionIds = formatImportBatchIds(transactionIds)
+}
+
+// ToImportBatchInfoResponse returns a view-object according to database model
+func (b *ImportBatch) ToImportBatchInfoResponse() *ImportBatchInfoResponse {
+	return &ImportBatchInfoResponse{
+		Id:                 b.BatchId,
+		FileType:           b.FileType,
+		FileHash:           b.FileHash,
+		TotalCount:         b.TotalCount,
+		ImportedCount:      b.ImportedCount,
+		SkippedCount:       b.SkippedCount,
+		CreatedAccountIds:  utils.Int64ArrayToStringArray(b.GetCreatedAccountIds()),
+		CreatedCategoryIds: utils.Int64ArrayToStringArray(b.GetCreatedCategoryIds()),
+		CreatedTagIds:      utils.Int64ArrayToStringArray(b.GetCreatedTagIds()),
+		TransactionIds:     utils.Int64ArrayToStringArray(b.GetTransactionIds()),
+		RolledBack:         b.RolledBack,
+		CreatedTime:        b.CreatedUnixTime,
+		RolledBackTime:     b.RolledBackUnixTime,
+	}
+}
+
+func parseImportBatchIds(ids string) []int64 {
+	result := make([]int64, 0)
+
+	if ids == "" {
+		return result
+	}
+
+	items := strings.Split(ids, ",")
+
+	for i := 0; i < len(items); i++ {
+		id, err := utils.StringToInt64(items[i])
+
+		if err != nil || id <= 0 {
+			continue
+		}
+
+		result = append(result, id)
+	}
+
+	return result
+}
+
+func formatImportBatchIds(ids []int64) string {
+	return strings.Join(utils.Int64ArrayToStringArray(utils.ToUniqueInt64Slice(ids)), ",")
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportBatchGetTransactionIds(t *testing.T) {
+	importBatch := &ImportBatch{
+		TransactionIds: "1001,1002,1003",
+	}
+
+	assert.Equal(t, []int64{1001, 1002, 1003}, importBatch.GetTransactionIds())
+
+	importBatch.TransactionIds = "1001,a,0,-1,1002"
+	assert.Equal(t, []int64{1001, 1002}, importBatch.GetTransactionIds())
+
+	importBatch.TransactionIds = ""
+	assert.Equal(t, 0, len(importBatch.GetTransactionIds()))
+}
+
+func TestImportBatchSetTransactionIds(t *testing.T) {
+	importBatch := &ImportBatch{}
+	importBatch.SetTransactionIds([]int64{1003, 1001, 1003})
+
+	assert.Equal(t, "1003,1001", importBatch.TransactionIds)
+
+	importBatch.SetTransactionIds([]int64{})
+	assert.Equal(t, "", importBatch.TransactionIds)
+}
+
+func TestImportBatchToImportBatchInfoResponse(t *testing.T) {
+	importBatch := &ImportBatch{
+		BatchId:            1,
+		FileType:           "ezbookkeeping_csv",
+		FileHash:           "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		TotalCount:         3,
+		ImportedCount:      2,
+		SkippedCount:       1,
+		CreatedCategoryIds: "2001",
+		CreatedTagIds:      "3001,3002",
+		TransactionIds:     "1001,1002",
+		CreatedUnixTime:    1700000000,
+	}
+
+	actualResponse := importBatch.ToImportBatchInfoResponse()
+	assert.Equal(t, int64(1), actualResponse.Id)
+	assert.Equal(t, "ezbookkeeping_csv", actualResponse.FileType)
+	assert.Equal(t, int32(3), actualResponse.TotalCount)
+	assert.Equal(t, int32(2), actualResponse.ImportedCount)
+	assert.Equal(t, int32(1), actualResponse.SkippedCount)
+	assert.Equal(t, []string{}, actualResponse.CreatedAccountIds)
+	assert.Equal(t, []string{"2001"}, actualResponse.CreatedCategoryIds)
+	assert.Equal(t, []string{"3001", "3002"}, actualResponse.CreatedTagIds)
+	assert.Equal(t, []string{"1001", "1002"}, actualResponse.TransactionIds)
+	assert.False(t, actualResponse.RolledBack)
+	assert.Equal(t, int64(1700000000), actualResponse.CreatedTime)
+}
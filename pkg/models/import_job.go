@@ -0,0 +1,171 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+// MaximumImportJobErrorMessageLength represents the maximum length of the error message of import job
+const MaximumImportJobErrorMessageLength = 255
+
+// ImportJobStatus represents the processing status of import job
+type ImportJobStatus byte
+
+// Import job statuses
+const (
+	IMPORT_JOB_STATUS_PENDING    ImportJobStatus = 1
+	IMPORT_JOB_STATUS_PROCESSING ImportJobStatus = 2
+	IMPORT_JOB_STATUS_SUCCEEDED  ImportJobStatus = 3
+	IMPORT_JOB_STATUS_FAILED     ImportJobStatus = 4
+)
+
+// String returns a textual representation of the import job status enum
+func (s ImportJobStatus) String() string {
+	switch s {
+	case IMPORT_JOB_STATUS_PENDING:
+		return "Pending"
+	case IMPORT_JOB_STATUS_PROCESSING:
+		return "Processing"
+	case IMPORT_JOB_STATUS_SUCCEEDED:
+		return "Succeeded"
+	case IMPORT_JOB_STATUS_FAILED:
+		return "Failed"
+	default:
+		return fmt.Sprintf("Invalid(%d)", int(s))
+	}
+}
+
+// ImportJob represents the transaction import job which is processed by background workers, stored in database
+type ImportJob struct {
+	JobId            int64           `xorm:"PK"`
+	Uid              int64           `xorm:"INDEX(IDX_import_job_uid_deleted_client_session_id) NOT NULL"`
+	Deleted          bool            `xorm:"INDEX(IDX_import_job_uid_deleted_client_session_id) INDEX(IDX_import_job_deleted_status_updated_time) NOT NULL"`
+	ClientSessionId  string          `xorm:"INDEX(IDX_import_job_uid_deleted_client_session_id) VARCHAR(64) NOT NULL"`
+	Status           ImportJobStatus `xorm:"INDEX(IDX_import_job_deleted_status_updated_time) NOT NULL"`
+	Payload          string          `xorm:"LONGTEXT NOT NULL"`
+	TotalCount       int32           `xorm:"NOT NULL"`
+	Process          float64         `xorm:"NOT NULL"`
+	ImportedCount    int32           `xorm:"NOT NULL"`
+	ImportBatchId    int64           `xorm:"NOT NULL"`
+	ErrorCode        int32           `xorm:"NOT NULL"`
+	ErrorMessage     string          `xorm:"VARCHAR(255) NOT NULL"`
+	Attempts         int32           `xorm:"NOT NULL"`
+	CreatedUnixTime  int64
+	UpdatedUnixTime  int64 `xorm:"INDEX(IDX_import_job_deleted_status_updated_time)"`
+	StartedUnixTime  int64
+	FinishedUnixTime int64
+	DeletedUnixTime  int64
+}
+
+// ImportJobPayload represents the prepared data of import job, which is stored in import job as json
+type ImportJobPayload struct {
+	Transactions []*Transaction  `json:"transactions"`
+	TagIds       map[int][]int64 `json:"tagIds"`
+	ImportBatch  *ImportBatch    `json:"importBatch"`
+}
+
+// ImportJobGetRequest represents all parameters of import job getting request
+type ImportJobGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// ImportJobListRequest represents all parameters of import job listing request
+type ImportJobListRequest struct {
+	Count int32 `form:"count" binding:"omitempty,min=1,max=50"`
+}
+
+// ImportJobInfoResponse represents a view-object of import job
+type ImportJobInfoResponse struct {
+	Id            int64           `json:"id,string"`
+	Status        ImportJobStatus `json:"status"`
+	TotalCount    int32           `json:"totalCount"`
+	Process       float64         `json:"process"`
+	ImportedCount int32           `json:"importedCount"`
+	ImportBatchId int64           `json:"importBatchId,string,omitempty"`
+	ErrorCode     int32           `json:"errorCode,omitempty"`
+	ErrorMessage  string          `json:"errorMessage,omitempty"`
+	CreatedTime   int64           `json:"createdTime"`
+	StartedTime   int64           `json:"startedTime,omitempty"`
+	FinishedTime  int64           `json:"finishedTime,omitempty"`
+}
+
+// GetPayload returns the prepared data of import job
+func (j *ImportJob) GetPayload() (*ImportJobPayload, error) {
+	payload := &ImportJobPayload{}
+	err := json.Unmarshal([]byte(j.Payload), payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// SetPayload sets the prepared data of import job
+func (j *ImportJob) SetPayload(payload *ImportJobPayload) error {
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	j.Payload = string(data)
+	return nil
+}
+
+// IsFinished returns whether the import job has succeeded or failed
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == IMPORT_JOB_STATUS_SUCCEEDED || j.Status == IMPORT_JOB_STATUS_FAILED
+}
+
+// SetError sets the error code and error message of the failed import job
+func (j *ImportJob) SetError(err error) {
+	finalError := errs.Or(err, errs.ErrOperationFailed)
+	j.ErrorCode = finalError.Code()
+	j.ErrorMessage = finalError.Message
+
+	if len(j.ErrorMessage) > MaximumImportJobErrorMessageLength {
+		j.ErrorMessage = j.ErrorMessage[:MaximumImportJobErrorMessageLength]
+	}
+}
+
+// GetError returns the error of the failed import job, or nil if the import job does not fail,
+// the http status code of the returned error is derived from its error category
+func (j *ImportJob) GetError() *errs.Error {
+	if j.Status != IMPORT_JOB_STATUS_FAILED {
+		return nil
+	}
+
+	if j.ErrorCode <= 0 {
+		return errs.ErrOperationFailed
+	}
+
+	category := errs.ErrorCategory(j.ErrorCode / 100000)
+	httpStatusCode := http.StatusBadRequest
+
+	if category == errs.CATEGORY_SYSTEM {
+		httpStatusCode = http.StatusInternalServerError
+	}
+
+	return errs.New(category, j.ErrorCode%100000/1000, j.ErrorCode%1000, httpStatusCode, j.ErrorMessage)
+}
+
+// ToImportJobInfoResponse returns a view-object according to database model
+func (j *ImportJob) ToImportJobInfoResponse() *ImportJobInfoResponse {
+	return &ImportJobInfoResponse{
+		Id:            j.JobId,
+		Status:        j.Status,
+		TotalCount:    j.TotalCount,
+		Process:       j.Process,
+		ImportedCount: j.ImportedCount,
+		ImportBatchId: j.ImportBatchId,
+		ErrorCode:     j.ErrorCode,
+		ErrorMessage:  j.ErrorMessage,
+		CreatedTime:   j.CreatedUnixTime,
+		StartedTime:   j.StartedUnixTime,
+		FinishedTime:  j.FinishedUnixTime,
+	}
+}
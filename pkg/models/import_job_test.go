@@ -0,0 +1,113 @@
+package models
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestImportJobSetAndGetPayload(t *testing.T) {
+	importJob := &ImportJob{}
+	err := importJob.SetPayload(&ImportJobPayload{
+		Transactions: []*Transaction{
+			{
+				Uid:             1,
+				Type:            TRANSACTION_DB_TYPE_EXPENSE,
+				CategoryId:      2,
+				AccountId:       3,
+				TransactionTime: 1700000000000,
+				Amount:          1234,
+				Comment:         "lunch",
+			},
+		},
+		TagIds: map[int][]int64{
+			0: {4, 5},
+		},
+		ImportBatch: &ImportBatch{
+			Uid:               1,
+			FileType:          "gnucash",
+			TotalCount:        2,
+			SkippedCount:      1,
+			CreatedAccountIds: "3",
+		},
+	})
+	assert.Nil(t, err)
+
+	payload, err := importJob.GetPayload()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(payload.Transactions))
+	assert.Equal(t, TRANSACTION_DB_TYPE_EXPENSE, payload.Transactions[0].Type)
+	assert.Equal(t, int64(1700000000000), payload.Transactions[0].TransactionTime)
+	assert.Equal(t, int64(1234), payload.Transactions[0].Amount)
+	assert.Equal(t, "lunch", payload.Transactions[0].Comment)
+	assert.Equal(t, []int64{4, 5}, payload.TagIds[0])
+	assert.Equal(t, "gnucash", payload.ImportBatch.FileType)
+	assert.Equal(t, int32(1), payload.ImportBatch.SkippedCount)
+	assert.Equal(t, []int64{3}, payload.ImportBatch.GetCreatedAccountIds())
+}
+
+func TestImportJobGetPayload_InvalidPayload(t *testing.T) {
+	importJob := &ImportJob{
+		Payload: "{",
+	}
+
+	_, err := importJob.GetPayload()
+	assert.NotNil(t, err)
+}
+
+func TestImportJobSetAndGetError(t *testing.T) {
+	importJob := &ImportJob{
+		Status: IMPORT_JOB_STATUS_FAILED,
+	}
+	importJob.SetError(errs.ErrCannotCreateTransactionWithThisTransactionTime)
+
+	actualError := importJob.GetError()
+	assert.Equal(t, errs.ErrCannotCreateTransactionWithThisTransactionTime.Code(), actualError.Code())
+	assert.Equal(t, errs.ErrCannotCreateTransactionWithThisTransactionTime.Message, actualError.Message)
+	assert.Equal(t, http.StatusBadRequest, actualError.HttpStatusCode)
+
+	importJob.SetError(errs.ErrDatabaseOperationFailed)
+
+	actualError = importJob.GetError()
+	assert.Equal(t, errs.ErrDatabaseOperationFailed.Code(), actualError.Code())
+	assert.Equal(t, http.StatusInternalServerError, actualError.HttpStatusCode)
+}
+
+func TestImportJobSetError_NonCustomError(t *testing.T) {
+	importJob := &ImportJob{
+		Status: IMPORT_JOB_STATUS_FAILED,
+	}
+	importJob.SetError(http.ErrHandlerTimeout)
+
+	assert.Equal(t, errs.ErrOperationFailed.Code(), importJob.ErrorCode)
+	assert.Equal(t, errs.ErrOperationFailed.Code(), importJob.GetError().Code())
+}
+
+func TestImportJobSetError_TruncateLongMessage(t *testing.T) {
+	importJob := &ImportJob{
+		Status: IMPORT_JOB_STATUS_FAILED,
+	}
+	importJob.SetError(errs.NewNormalError(errs.NormalSubcategoryImportJob, 99, http.StatusBadRequest, strings.Repeat("a", 300)))
+
+	assert.Equal(t, MaximumImportJobErrorMessageLength, len(importJob.ErrorMessage))
+}
+
+func TestImportJobGetError_NotFailed(t *testing.T) {
+	importJob := &ImportJob{
+		Status:    IMPORT_JOB_STATUS_SUCCEEDED,
+		ErrorCode: errs.ErrOperationFailed.Code(),
+	}
+
+	assert.Nil(t, importJob.GetError())
+}
+
+func TestImportJobIsFinished(t *testing.T) {
+	assert.False(t, (&ImportJob{Status: IMPORT_JOB_STATUS_PENDING}).IsFinished())
+	assert.False(t, (&ImportJob{Status: IMPORT_JOB_STATUS_PROCESSING}).IsFinished())
+	assert.True(t, (&ImportJob{Status: IMPORT_JOB_STATUS_SUCCEEDED}).IsFinished())
+	assert.True(t, (&ImportJob{Status: IMPORT_JOB_STATUS_FAILED}).IsFinished())
+}
@@ -12,6 +12,7 @@ type ImportTransaction struct {
 	OriginalDestinationAccountName     string
 	OriginalDestinationAccountCurrency string
 	OriginalTagNames                   []string
+	ProbableDuplicateTransactionId     int64
 }
 
 // ImportTransactionRequest represents all parameters of the imported transaction data
@@ -53,12 +54,14 @@ type ImportTransactionResponse struct {
 	OriginalTagNames                   []string                        `json:"originalTagNames"`
 	Comment                            string                          `json:"comment"`
 	GeoLocation                        *TransactionGeoLocationResponse `json:"geoLocation,omitempty"`
+	ProbableDuplicateTransactionId     int64                           `json:"probableDuplicateTransactionId,string,omitempty"`
 }
 
 // ImportTransactionResponsePageWrapper represents a response of imported transaction which contains items and count
 type ImportTransactionResponsePageWrapper struct {
 	Items      []*ImportTransactionResponse `json:"items"`
 	TotalCount int64                        `json:"totalCount"`
+	FileHash   string                       `json:"fileHash,omitempty"`
 }
 
 // ToImportTransactionResponse returns the a view-objects according to imported transaction data
@@ -96,6 +99,7 @@ func (t ImportTransaction) ToImportTransactionResponse() *ImportTransactionRespo
 		OriginalTagNames:                   t.OriginalTagNames,
 		Comment:                            t.Comment,
 		GeoLocation:                        geoLocation,
+		ProbableDuplicateTransactionId:     t.ProbableDuplicateTransactionId,
 	}
 }
 
@@ -1,18 +1,24 @@
 package models
 
+// RecognizeTransactionTextRequest represents all parameters of recognizing transaction from natural-language text request
+type RecognizeTransactionTextRequest struct {
+	Text string `json:"text" binding:"required,notBlank"`
+}
+
 // RecognizedReceiptImageResponse represents a view-object of recognized receipt image response
 type RecognizedReceiptImageResponse struct {
-	Type                 TransactionType `json:"type"`
-	Time                 int64           `json:"time,omitempty"`
-	CategoryId           int64           `json:"categoryId,string,omitempty"`
-	SourceAccountId      int64           `json:"sourceAccountId,string,omitempty"`
-	DestinationAccountId int64           `json:"destinationAccountId,string,omitempty"`
-	SourceAmount         int64           `json:"sourceAmount,omitempty"`
-	DestinationAmount    int64           `json:"destinationAmount,omitempty"`
-	TagIds               []string        `json:"tagIds,omitempty"`
-	ItemIds              []string        `json:"itemIds,omitempty"`
-	Comment              string          `json:"comment,omitempty"`
-	AccountName          string          `json:"account,omitempty"`
+	Type                           TransactionType `json:"type"`
+	Time                           int64           `json:"time,omitempty"`
+	CategoryId                     int64           `json:"categoryId,string,omitempty"`
+	SourceAccountId                int64           `json:"sourceAccountId,string,omitempty"`
+	DestinationAccountId           int64           `json:"destinationAccountId,string,omitempty"`
+	SourceAmount                   int64           `json:"sourceAmount,omitempty"`
+	DestinationAmount              int64           `json:"destinationAmount,omitempty"`
+	TagIds                         []string        `json:"tagIds,omitempty"`
+	ItemIds                        []string        `json:"itemIds,omitempty"`
+	Comment                        string          `json:"comment,omitempty"`
+	AccountName                    string          `json:"account,omitempty"`
+	ProbableDuplicateTransactionId int64           `json:"probableDuplicateTransactionId,string,omitempty"`
 }
 
 // RecognizedReceiptImageListResponse represents a list of recognized transactions (e.g. from OCR bill list)
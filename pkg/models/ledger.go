@@ -0,0 +1,132 @@
+package models
+
+// LedgerMemberRole represents the role of shared ledger member
+type LedgerMemberRole byte
+
+// Shared ledger member roles
+const (
+	LEDGER_MEMBER_ROLE_OWNER  LedgerMemberRole = 1
+	LEDGER_MEMBER_ROLE_EDITOR LedgerMemberRole = 2
+	LEDGER_MEMBER_ROLE_VIEWER LedgerMemberRole = 3
+)
+
+// Ledger represents shared ledger data stored in database,
+// the data of a shared ledger is stored under its own data uid, which is not the uid of any user, so the personal data of the owner is not shared
+type Ledger struct {
+	LedgerId        int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_ledger_uid_deleted) NOT NULL"`
+	DataUid         int64  `xorm:"UNIQUE(UQE_ledger_data_uid) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_ledger_uid_deleted) NOT NULL"`
+	Name            string `xorm:"VARCHAR(64) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// LedgerMember represents the member of shared ledger stored in database, the owner of ledger is not stored as member
+type LedgerMember struct {
+	LedgerId        int64            `xorm:"PK"`
+	Uid             int64            `xorm:"PK INDEX(IDX_ledger_member_uid)"`
+	Role            LedgerMemberRole `xorm:"TINYINT NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+}
+
+// LedgerCreateRequest represents all parameters of shared ledger creation request
+type LedgerCreateRequest struct {
+	Name string `json:"name" binding:"required,notBlank,max=64"`
+}
+
+// LedgerModifyRequest represents all parameters of shared ledger modification request
+type LedgerModifyRequest struct {
+	Id   int64  `json:"id,string" binding:"required,min=1"`
+	Name string `json:"name" binding:"required,notBlank,max=64"`
+}
+
+// LedgerDeleteRequest represents all parameters of shared ledger deleting request
+type LedgerDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// LedgerMemberListRequest represents all parameters of shared ledger member listing request
+type LedgerMemberListRequest struct {
+	LedgerId int64 `form:"ledgerId,string" binding:"required,min=1"`
+}
+
+// LedgerMemberAddRequest represents all parameters of shared ledger member adding request
+type LedgerMemberAddRequest struct {
+	LedgerId  int64            `json:"ledgerId,string" binding:"required,min=1"`
+	LoginName string           `json:"loginName" binding:"required,notBlank,max=100"`
+	Role      LedgerMemberRole `json:"role" binding:"required"`
+}
+
+// LedgerMemberModifyRequest represents all parameters of shared ledger member role modification request
+type LedgerMemberModifyRequest struct {
+	LedgerId int64            `json:"ledgerId,string" binding:"required,min=1"`
+	Uid      int64            `json:"uid,string" binding:"required,min=1"`
+	Role     LedgerMemberRole `json:"role" binding:"required"`
+}
+
+// LedgerMemberDeleteRequest represents all parameters of shared ledger member removing request
+type LedgerMemberDeleteRequest struct {
+	LedgerId int64 `json:"ledgerId,string" binding:"required,min=1"`
+	Uid      int64 `json:"uid,string" binding:"required,min=1"`
+}
+
+// LedgerInfoResponse represents a view-object of shared ledger
+type LedgerInfoResponse struct {
+	Id            int64            `json:"id,string"`
+	Name          string           `json:"name"`
+	OwnerUsername string           `json:"ownerUsername"`
+	OwnerNickname string           `json:"ownerNickname"`
+	Role          LedgerMemberRole `json:"role"`
+}
+
+// LedgerMemberInfoResponse represents a view-object of shared ledger member
+type LedgerMemberInfoResponse struct {
+	Uid      int64            `json:"uid,string"`
+	Username string           `json:"username"`
+	Nickname string           `json:"nickname"`
+	Role     LedgerMemberRole `json:"role"`
+}
+
+// IsValidMemberRole returns whether the role can be assigned to ledger member
+func (r LedgerMemberRole) IsValidMemberRole() bool {
+	return r == LEDGER_MEMBER_ROLE_EDITOR || r == LEDGER_MEMBER_ROLE_VIEWER
+}
+
+// CanModifyData returns whether the role can modify the data of ledger, the editor can only modify transactions and the owner can modify all data
+func (r LedgerMemberRole) CanModifyData() bool {
+	return r == LEDGER_MEMBER_ROLE_OWNER || r == LEDGER_MEMBER_ROLE_EDITOR
+}
+
+// ToLedgerInfoResponse returns a view-object according to database model
+func (l *Ledger) ToLedgerInfoResponse(owner *User, role LedgerMemberRole) *LedgerInfoResponse {
+	resp := &LedgerInfoResponse{
+		Id:   l.LedgerId,
+		Name: l.Name,
+		Role: role,
+	}
+
+	if owner != nil {
+		resp.OwnerUsername = owner.Username
+		resp.OwnerNickname = owner.Nickname
+	}
+
+	return resp
+}
+
+// ToLedgerMemberInfoResponse returns a view-object according to database model
+func (m *LedgerMember) ToLedgerMemberInfoResponse(user *User) *LedgerMemberInfoResponse {
+	resp := &LedgerMemberInfoResponse{
+		Uid:  m.Uid,
+		Role: m.Role,
+	}
+
+	if user != nil {
+		resp.Username = user.Username
+		resp.Nickname = user.Nickname
+	}
+
+	return resp
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerMemberRoleIsValidMemberRole(t *testing.T) {
+	assert.False(t, LEDGER_MEMBER_ROLE_OWNER.IsValidMemberRole())
+	assert.True(t, LEDGER_MEMBER_ROLE_EDITOR.IsValidMemberRole())
+	assert.True(t, LEDGER_MEMBER_ROLE_VIEWER.IsValidMemberRole())
+	assert.False(t, LedgerMemberRole(0).IsValidMemberRole())
+	assert.False(t, LedgerMemberRole(4).IsValidMemberRole())
+}
+
+func TestLedgerMemberRoleCanModifyData(t *testing.T) {
+	assert.True(t, LEDGER_MEMBER_ROLE_OWNER.CanModifyData())
+	assert.True(t, LEDGER_MEMBER_ROLE_EDITOR.CanModifyData())
+	assert.False(t, LEDGER_MEMBER_ROLE_VIEWER.CanModifyData())
+	assert.False(t, LedgerMemberRole(0).CanModifyData())
+}
+
+func TestLedgerToLedgerInfoResponse(t *testing.T) {
+	ledger := &Ledger{
+		LedgerId: 1001,
+		Uid:      1,
+		Name:     "Family",
+	}
+
+	owner := &User{
+		Uid:      1,
+		Username: "owner",
+		Nickname: "Owner",
+	}
+
+	resp := ledger.ToLedgerInfoResponse(owner, LEDGER_MEMBER_ROLE_VIEWER)
+
+	assert.Equal(t, int64(1001), resp.Id)
+	assert.Equal(t, "Family", resp.Name)
+	assert.Equal(t, "owner", resp.OwnerUsername)
+	assert.Equal(t, "Owner", resp.OwnerNickname)
+	assert.Equal(t, LEDGER_MEMBER_ROLE_VIEWER, resp.Role)
+}
@@ -0,0 +1,308 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const summaryReportMaxTopCategoryCount = 5
+const summaryReportMaxBiggestTransactionCount = 5
+
+// SummaryReport represents the summary of income, expense, budgets and account balance changes within a period in the report currency
+type SummaryReport struct {
+	Frequency             SummaryEmailFrequency
+	StartTime             time.Time
+	EndTime               time.Time
+	Currency              string
+	TotalIncome           int64
+	TotalExpense          int64
+	NetIncome             int64
+	TopIncomeCategories   []*FinancialReportCategoryItem
+	TopExpenseCategories  []*FinancialReportCategoryItem
+	BiggestTransactions   []*SummaryReportTransactionItem
+	Budgets               []*SummaryReportBudgetItem
+	AccountBalanceChanges []*SummaryReportAccountItem
+}
+
+// SummaryReportTransactionItem represents an income or expense transaction within the summary period
+type SummaryReportTransactionItem struct {
+	TransactionId   int64
+	Type            TransactionDbType
+	TransactionTime time.Time
+	CategoryName    string
+	AccountName     string
+	Comment         string
+	Currency        string
+	OriginalAmount  int64
+	Amount          int64
+}
+
+// SummaryReportBudgetItem represents the progress of a budget in the budget period which contains the end of the summary period
+type SummaryReportBudgetItem struct {
+	BudgetId        int64
+	Name            string
+	Currency        string
+	AvailableAmount int64
+	SpentAmount     int64
+	RemainingAmount int64
+	Overspent       bool
+}
+
+// SummaryReportAccountItem represents the balance change of an account within the summary period in the account currency
+type SummaryReportAccountItem struct {
+	AccountId      int64
+	Name           string
+	Currency       string
+	OpeningBalance int64
+	ClosingBalance int64
+	Change         int64
+}
+
+type summaryReportAccountSortItem struct {
+	item                *SummaryReportAccountItem
+	displayOrder        int32
+	subAccountSortOrder int32
+}
+
+// GetSummaryReportPeriod returns the start time and the end time (inclusive) of the last complete period before the specified time,
+// and returns false if the summary report of the frequency should not be sent on the date of the specified time
+// (weekly summary is sent on the first day of week and monthly summary is sent on the first day of month)
+func GetSummaryReportPeriod(frequency SummaryEmailFrequency, currentTime time.Time, firstDayOfWeek core.WeekDay) (time.Time, time.Time, bool) {
+	year, month, day := currentTime.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, currentTime.Location())
+
+	if frequency == SUMMARY_EMAIL_FREQUENCY_WEEKLY {
+		if currentTime.Weekday() != time.Weekday(firstDayOfWeek) {
+			return time.Time{}, time.Time{}, false
+		}
+
+		return today.AddDate(0, 0, -7), today.Add(-time.Second), true
+	} else if frequency == SUMMARY_EMAIL_FREQUENCY_MONTHLY {
+		if day != 1 {
+			return time.Time{}, time.Time{}, false
+		}
+
+		return today.AddDate(0, -1, 0), today.Add(-time.Second), true
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// NewSummaryReport returns a summary report according to the category total amounts (already exchanged to the report currency), the income and expense transactions,
+// the budget progresses and the opening and closing balances of accounts within the period, the amount of transactions are exchanged at the exchange rates on the transaction date
+// and the transactions which cannot be exchanged are ignored
+func NewSummaryReport(frequency SummaryEmailFrequency, startTime time.Time, endTime time.Time, currency string, categories []*TransactionCategory, categoryTotalAmounts []*Transaction, transactions []*Transaction, accounts []*Account, accountOpeningBalances map[int64]int64, accountClosingBalances map[int64]int64, budgets []*Budget, budgetProgresses map[int64]*BudgetProgressResponse, exchangeRates *HistoricalExchangeRateMap) *SummaryReport {
+	report := &SummaryReport{
+		Frequency: frequency,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Currency:  currency,
+	}
+
+	var incomeCategories, expenseCategories []*FinancialReportCategoryItem
+	incomeCategories, report.TotalIncome = getFinancialReportCategoryItems(categories, categoryTotalAmounts, CATEGORY_TYPE_INCOME, TRANSACTION_DB_TYPE_INCOME)
+	expenseCategories, report.TotalExpense = getFinancialReportCategoryItems(categories, categoryTotalAmounts, CATEGORY_TYPE_EXPENSE, TRANSACTION_DB_TYPE_EXPENSE)
+	report.NetIncome = report.TotalIncome - report.TotalExpense
+	report.TopIncomeCategories = getSummaryReportTopCategoryItems(incomeCategories)
+	report.TopExpenseCategories = getSummaryReportTopCategoryItems(expenseCategories)
+
+	accountMap := make(map[int64]*Account, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountMap[accounts[i].AccountId] = accounts[i]
+	}
+
+	categoryMap := make(map[int64]*TransactionCategory, len(categories))
+
+	for i := 0; i < len(categories); i++ {
+		categoryMap[categories[i].CategoryId] = categories[i]
+	}
+
+	report.BiggestTransactions = getSummaryReportBiggestTransactionItems(transactions, currency, accountMap, categoryMap, startTime.Location(), exchangeRates)
+	report.Budgets = getSummaryReportBudgetItems(budgets, budgetProgresses)
+	report.AccountBalanceChanges = getSummaryReportAccountItems(accounts, accountMap, accountOpeningBalances, accountClosingBalances)
+
+	return report
+}
+
+func getSummaryReportTopCategoryItems(categoryItems []*FinancialReportCategoryItem) []*FinancialReportCategoryItem {
+	items := make([]*FinancialReportCategoryItem, len(categoryItems))
+	copy(items, categoryItems)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Amount > items[j].Amount
+	})
+
+	if len(items) > summaryReportMaxTopCategoryCount {
+		items = items[:summaryReportMaxTopCategoryCount]
+	}
+
+	return items
+}
+
+func getSummaryReportBiggestTransactionItems(transactions []*Transaction, currency string, accountMap map[int64]*Account, categoryMap map[int64]*TransactionCategory, location *time.Location, exchangeRates *HistoricalExchangeRateMap) []*SummaryReportTransactionItem {
+	items := make([]*SummaryReportTransactionItem, 0, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.Type != TRANSACTION_DB_TYPE_INCOME && transaction.Type != TRANSACTION_DB_TYPE_EXPENSE {
+			continue
+		}
+
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			continue
+		}
+
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+		amount := transaction.Amount
+
+		if account.Currency != currency {
+			if exchangeRates == nil {
+				continue
+			}
+
+			exchangedAmount, ok := exchangeRates.ExchangeAmount(transaction.Amount, account.Currency, currency, utils.FormatUnixTimeToNumericYearMonthDay(transactionUnixTime, location))
+
+			if !ok {
+				continue
+			}
+
+			amount = exchangedAmount
+		}
+
+		item := &SummaryReportTransactionItem{
+			TransactionId:   transaction.TransactionId,
+			Type:            transaction.Type,
+			TransactionTime: time.Unix(transactionUnixTime, 0).In(location),
+			AccountName:     account.Name,
+			Comment:         transaction.Comment,
+			Currency:        account.Currency,
+			OriginalAmount:  transaction.Amount,
+			Amount:          amount,
+		}
+
+		if category, exists := categoryMap[transaction.CategoryId]; exists {
+			item.CategoryName = category.Name
+		}
+
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Amount != items[j].Amount {
+			return items[i].Amount > items[j].Amount
+		}
+
+		return items[i].TransactionTime.After(items[j].TransactionTime)
+	})
+
+	if len(items) > summaryReportMaxBiggestTransactionCount {
+		items = items[:summaryReportMaxBiggestTransactionCount]
+	}
+
+	return items
+}
+
+func getSummaryReportBudgetItems(budgets []*Budget, budgetProgresses map[int64]*BudgetProgressResponse) []*SummaryReportBudgetItem {
+	sortedBudgets := make([]*Budget, 0, len(budgets))
+
+	for i := 0; i < len(budgets); i++ {
+		if budgets[i].Hidden {
+			continue
+		}
+
+		sortedBudgets = append(sortedBudgets, budgets[i])
+	}
+
+	sort.SliceStable(sortedBudgets, func(i, j int) bool {
+		return sortedBudgets[i].DisplayOrder < sortedBudgets[j].DisplayOrder
+	})
+
+	items := make([]*SummaryReportBudgetItem, 0, len(sortedBudgets))
+
+	for i := 0; i < len(sortedBudgets); i++ {
+		budget := sortedBudgets[i]
+		progress, exists := budgetProgresses[budget.BudgetId]
+
+		if !exists {
+			continue
+		}
+
+		items = append(items, &SummaryReportBudgetItem{
+			BudgetId:        budget.BudgetId,
+			Name:            budget.Name,
+			Currency:        progress.Currency,
+			AvailableAmount: progress.AvailableAmount,
+			SpentAmount:     progress.SpentAmount,
+			RemainingAmount: progress.RemainingAmount,
+			Overspent:       progress.Overspent,
+		})
+	}
+
+	return items
+}
+
+func getSummaryReportAccountItems(accounts []*Account, accountMap map[int64]*Account, accountOpeningBalances map[int64]int64, accountClosingBalances map[int64]int64) []*SummaryReportAccountItem {
+	sortItems := make([]*summaryReportAccountSortItem, 0)
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.Type != ACCOUNT_TYPE_SINGLE_ACCOUNT {
+			continue
+		}
+
+		openingBalance := accountOpeningBalances[account.AccountId]
+		closingBalance, exists := accountClosingBalances[account.AccountId]
+
+		if !exists {
+			closingBalance = openingBalance
+		}
+
+		if openingBalance == closingBalance {
+			continue
+		}
+
+		sortItem := &summaryReportAccountSortItem{
+			item: &SummaryReportAccountItem{
+				AccountId:      account.AccountId,
+				Name:           account.Name,
+				Currency:       account.Currency,
+				OpeningBalance: openingBalance,
+				ClosingBalance: closingBalance,
+				Change:         closingBalance - openingBalance,
+			},
+			displayOrder: account.DisplayOrder,
+		}
+
+		if parentAccount, exists := accountMap[account.ParentAccountId]; account.ParentAccountId > 0 && exists {
+			sortItem.item.Name = parentAccount.Name + " / " + account.Name
+			sortItem.displayOrder = parentAccount.DisplayOrder
+			sortItem.subAccountSortOrder = account.DisplayOrder
+		}
+
+		sortItems = append(sortItems, sortItem)
+	}
+
+	sort.SliceStable(sortItems, func(i, j int) bool {
+		if sortItems[i].displayOrder != sortItems[j].displayOrder {
+			return sortItems[i].displayOrder < sortItems[j].displayOrder
+		}
+
+		return sortItems[i].subAccountSortOrder < sortItems[j].subAccountSortOrder
+	})
+
+	items := make([]*SummaryReportAccountItem, len(sortItems))
+
+	for i := 0; i < len(sortItems); i++ {
+		items[i] = sortItems[i].item
+	}
+
+	return items
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestGetSummaryReportPeriod_Weekly(t *testing.T) {
+	location := time.FixedZone("Test Timezone", 8*60*60)
+
+	startTime, endTime, ok := GetSummaryReportPeriod(SUMMARY_EMAIL_FREQUENCY_WEEKLY, time.Date(2024, 3, 4, 8, 0, 0, 0, location), core.WEEKDAY_MONDAY)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 2, 26, 0, 0, 0, 0, location), startTime)
+	assert.Equal(t, time.Date(2024, 3, 3, 23, 59, 59, 0, location), endTime)
+
+	_, _, ok = GetSummaryReportPeriod(SUMMARY_EMAIL_FREQUENCY_WEEKLY, time.Date(2024, 3, 4, 8, 0, 0, 0, location), core.WEEKDAY_SUNDAY)
+	assert.False(t, ok)
+
+	startTime, endTime, ok = GetSummaryReportPeriod(SUMMARY_EMAIL_FREQUENCY_WEEKLY, time.Date(2024, 3, 3, 8, 0, 0, 0, location), core.WEEKDAY_SUNDAY)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 2, 25, 0, 0, 0, 0, location), startTime)
+	assert.Equal(t, time.Date(2024, 3, 2, 23, 59, 59, 0, location), endTime)
+}
+
+func TestGetSummaryReportPeriod_Monthly(t *testing.T) {
+	startTime, endTime, ok := GetSummaryReportPeriod(SUMMARY_EMAIL_FREQUENCY_MONTHLY, time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC), core.WEEKDAY_MONDAY)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC), endTime)
+
+	startTime, endTime, ok = GetSummaryReportPeriod(SUMMARY_EMAIL_FREQUENCY_MONTHLY, time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), core.WEEKDAY_MONDAY)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), startTime)
+	assert.Equal(t, time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), endTime)
+
+	_, _, ok = GetSummaryReportPeriod(SUMMARY_EMAIL_FREQUENCY_MONTHLY, time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC), core.WEEKDAY_MONDAY)
+	assert.False(t, ok)
+
+	_, _, ok = GetSummaryReportPeriod(SUMMARY_EMAIL_FREQUENCY_NONE, time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC), core.WEEKDAY_MONDAY)
+	assert.False(t, ok)
+}
+
+func TestGetAccountsOpeningBalances(t *testing.T) {
+	accountDailyBalances := map[int32][]*TransactionWithAccountBalance{
+		20240110: {
+			{Transaction: &Transaction{AccountId: 1}, AccountOpeningBalance: 120},
+			{Transaction: &Transaction{AccountId: 2}, AccountOpeningBalance: -50},
+		},
+		20240101: {
+			{Transaction: &Transaction{AccountId: 1}, AccountOpeningBalance: 100},
+		},
+	}
+
+	assert.Equal(t, map[int64]int64{1: 100, 2: -50}, GetAccountsOpeningBalances(accountDailyBalances))
+}
+
+func TestNewSummaryReport_IncomeExpenseAndTopCategories(t *testing.T) {
+	categories := []*TransactionCategory{
+		{CategoryId: 1, Type: CATEGORY_TYPE_EXPENSE, Name: "Food", DisplayOrder: 1},
+		{CategoryId: 11, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 1, Name: "Lunch", DisplayOrder: 1},
+		{CategoryId: 2, Type: CATEGORY_TYPE_EXPENSE, Name: "Transport", DisplayOrder: 2},
+		{CategoryId: 21, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 2, Name: "Taxi", DisplayOrder: 1},
+		{CategoryId: 3, Type: CATEGORY_TYPE_INCOME, Name: "Salary", DisplayOrder: 1},
+		{CategoryId: 31, Type: CATEGORY_TYPE_INCOME, ParentCategoryId: 3, Name: "Wage", DisplayOrder: 1},
+	}
+	categoryTotalAmounts := []*Transaction{
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, Amount: 300},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 21, Amount: 700},
+		{Type: TRANSACTION_DB_TYPE_INCOME, CategoryId: 31, Amount: 5000},
+	}
+
+	report := NewSummaryReport(SUMMARY_EMAIL_FREQUENCY_MONTHLY, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC), "EUR", categories, categoryTotalAmounts, nil, nil, nil, nil, nil, nil, nil)
+
+	assert.Equal(t, int64(5000), report.TotalIncome)
+	assert.Equal(t, int64(1000), report.TotalExpense)
+	assert.Equal(t, int64(4000), report.NetIncome)
+
+	assert.Equal(t, 1, len(report.TopIncomeCategories))
+	assert.Equal(t, "Salary", report.TopIncomeCategories[0].Name)
+
+	assert.Equal(t, 2, len(report.TopExpenseCategories))
+	assert.Equal(t, "Transport", report.TopExpenseCategories[0].Name)
+	assert.Equal(t, int64(700), report.TopExpenseCategories[0].Amount)
+	assert.Equal(t, 70.0, report.TopExpenseCategories[0].Percentage)
+	assert.Equal(t, "Food", report.TopExpenseCategories[1].Name)
+
+	assert.Equal(t, 0, len(report.BiggestTransactions))
+	assert.Equal(t, 0, len(report.Budgets))
+	assert.Equal(t, 0, len(report.AccountBalanceChanges))
+}
+
+func TestNewSummaryReport_BiggestTransactions(t *testing.T) {
+	accounts := []*Account{
+		{AccountId: 1, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Cash", Currency: "EUR"},
+		{AccountId: 2, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "USD Card", Currency: "USD"},
+		{AccountId: 3, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "JPY Cash", Currency: "JPY"},
+	}
+	categories := []*TransactionCategory{
+		{CategoryId: 11, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 1, Name: "Lunch"},
+		{CategoryId: 31, Type: CATEGORY_TYPE_INCOME, ParentCategoryId: 3, Name: "Wage"},
+	}
+	transactionTime := utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC).Unix())
+	transactions := []*Transaction{
+		{TransactionId: 1, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 1, Amount: 100, TransactionTime: transactionTime},
+		{TransactionId: 2, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 2, Amount: 240, TransactionTime: transactionTime},
+		{TransactionId: 3, Type: TRANSACTION_DB_TYPE_INCOME, CategoryId: 31, AccountId: 1, Amount: 150, Comment: "Bonus", TransactionTime: transactionTime},
+		{TransactionId: 4, Type: TRANSACTION_DB_TYPE_TRANSFER_OUT, AccountId: 1, Amount: 9999, TransactionTime: transactionTime},
+		{TransactionId: 5, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 3, Amount: 99999, TransactionTime: transactionTime},
+		{TransactionId: 6, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 1, Amount: 10, TransactionTime: transactionTime},
+		{TransactionId: 7, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 1, Amount: 20, TransactionTime: transactionTime},
+		{TransactionId: 8, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 1, Amount: 30, TransactionTime: transactionTime},
+		{TransactionId: 9, Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 11, AccountId: 1, Amount: 5, TransactionTime: transactionTime},
+	}
+	histories := []*ExchangeRateHistory{
+		{RateDate: 20240110, Currency: "USD", BaseCurrency: "EUR", Rate: "1.2"},
+	}
+	exchangeRates := NewHistoricalExchangeRateMap(histories, nil)
+
+	report := NewSummaryReport(SUMMARY_EMAIL_FREQUENCY_MONTHLY, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC), "EUR", categories, nil, transactions, accounts, nil, nil, nil, nil, exchangeRates)
+
+	assert.Equal(t, 5, len(report.BiggestTransactions))
+	assert.Equal(t, int64(2), report.BiggestTransactions[0].TransactionId)
+	assert.Equal(t, int64(240), report.BiggestTransactions[0].OriginalAmount)
+	assert.Equal(t, int64(200), report.BiggestTransactions[0].Amount)
+	assert.Equal(t, "USD", report.BiggestTransactions[0].Currency)
+	assert.Equal(t, "USD Card", report.BiggestTransactions[0].AccountName)
+	assert.Equal(t, "Lunch", report.BiggestTransactions[0].CategoryName)
+	assert.Equal(t, int64(3), report.BiggestTransactions[1].TransactionId)
+	assert.Equal(t, "Bonus", report.BiggestTransactions[1].Comment)
+	assert.Equal(t, int64(1), report.BiggestTransactions[2].TransactionId)
+	assert.Equal(t, int64(8), report.BiggestTransactions[3].TransactionId)
+	assert.Equal(t, int64(7), report.BiggestTransactions[4].TransactionId)
+	assert.Equal(t, time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC), report.BiggestTransactions[4].TransactionTime)
+}
+
+func TestNewSummaryReport_BudgetsAndAccountBalanceChanges(t *testing.T) {
+	accounts := []*Account{
+		{AccountId: 1, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Cash", Currency: "EUR", DisplayOrder: 2},
+		{AccountId: 2, Type: ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS, Name: "Bank", Currency: "EUR", DisplayOrder: 1},
+		{AccountId: 3, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, ParentAccountId: 2, Name: "USD", Currency: "USD", DisplayOrder: 1},
+		{AccountId: 4, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Unchanged", Currency: "EUR", DisplayOrder: 3},
+	}
+	openingBalances := map[int64]int64{1: 1000, 3: 500, 4: 300}
+	closingBalances := map[int64]int64{1: 800, 3: 900, 4: 300}
+	budgets := []*Budget{
+		{BudgetId: 1, Name: "Food", DisplayOrder: 2},
+		{BudgetId: 2, Name: "All", DisplayOrder: 1},
+		{BudgetId: 3, Name: "Hidden", DisplayOrder: 3, Hidden: true},
+		{BudgetId: 4, Name: "No Progress", DisplayOrder: 4},
+	}
+	budgetProgresses := map[int64]*BudgetProgressResponse{
+		1: {BudgetId: 1, Currency: "EUR", AvailableAmount: 500, SpentAmount: 600, RemainingAmount: -100, Overspent: true},
+		2: {BudgetId: 2, Currency: "EUR", AvailableAmount: 2000, SpentAmount: 1000, RemainingAmount: 1000},
+		3: {BudgetId: 3, Currency: "EUR", AvailableAmount: 100},
+	}
+
+	report := NewSummaryReport(SUMMARY_EMAIL_FREQUENCY_WEEKLY, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 23, 59, 59, 0, time.UTC), "EUR", nil, nil, nil, accounts, openingBalances, closingBalances, budgets, budgetProgresses, nil)
+
+	assert.Equal(t, 2, len(report.Budgets))
+	assert.Equal(t, "All", report.Budgets[0].Name)
+	assert.Equal(t, int64(1000), report.Budgets[0].RemainingAmount)
+	assert.False(t, report.Budgets[0].Overspent)
+	assert.Equal(t, "Food", report.Budgets[1].Name)
+	assert.True(t, report.Budgets[1].Overspent)
+
+	assert.Equal(t, 2, len(report.AccountBalanceChanges))
+	assert.Equal(t, "Bank / USD", report.AccountBalanceChanges[0].Name)
+	assert.Equal(t, "USD", report.AccountBalanceChanges[0].Currency)
+	assert.Equal(t, int64(400), report.AccountBalanceChanges[0].Change)
+	assert.Equal(t, "Cash", report.AccountBalanceChanges[1].Name)
+	assert.Equal(t, int64(1000), report.AccountBalanceChanges[1].OpeningBalance)
+	assert.Equal(t, int64(800), report.AccountBalanceChanges[1].ClosingBalance)
+	assert.Equal(t, int64(-200), report.AccountBalanceChanges[1].Change)
+}
@@ -13,6 +13,7 @@ type TokenRecord struct {
 	Secret           string         `xorm:"VARCHAR(10) NOT NULL"`
 	UserAgent        string         `xorm:"VARCHAR(255)"`
 	Context          string         `xorm:"BLOB"`
+	Scopes           string         `xorm:"VARCHAR(255)"`
 	CreatedUnixTime  int64          `xorm:"PK"`
 	ExpiredUnixTime  int64          `xorm:"INDEX(IDX_token_record_uid_type_expired_time) INDEX(IDX_token_record_expired_time)"`
 	LastSeenUnixTime int64
@@ -27,14 +28,16 @@ type OAuth2CallbackTokenContext struct {
 
 // TokenGenerateAPIRequest represents all parameters of api token generation request
 type TokenGenerateAPIRequest struct {
-	ExpiredInSeconds int64  `json:"expiresInSeconds" binding:"omitempty,min=0,max=4294967295"`
-	Password         string `json:"password" binding:"omitempty,min=6,max=128"`
+	ExpiredInSeconds int64    `json:"expiresInSeconds" binding:"omitempty,min=0,max=4294967295"`
+	Scopes           []string `json:"scopes" binding:"omitempty,max=10"`
+	Password         string   `json:"password" binding:"omitempty,min=6,max=128"`
 }
 
 // TokenGenerateMCPRequest represents all parameters of mcp token generation request
 type TokenGenerateMCPRequest struct {
-	ExpiredInSeconds int64  `json:"expiresInSeconds" binding:"omitempty,min=0,max=4294967295"`
-	Password         string `json:"password" binding:"omitempty,min=6,max=128"`
+	ExpiredInSeconds      int64  `json:"expiresInSeconds" binding:"omitempty,min=0,max=4294967295"`
+	AllowDestructiveTools bool   `json:"allowDestructiveTools"`
+	Password              string `json:"password" binding:"omitempty,min=6,max=128"`
 }
 
 // TokenRevokeRequest represents all parameters of token revoking request
@@ -65,11 +68,12 @@ type TokenRefreshResponse struct {
 
 // TokenInfoResponse represents a view-object of token
 type TokenInfoResponse struct {
-	TokenId   string         `json:"tokenId"`
-	TokenType core.TokenType `json:"tokenType"`
-	UserAgent string         `json:"userAgent"`
-	LastSeen  int64          `json:"lastSeen"`
-	IsCurrent bool           `json:"isCurrent"`
+	TokenId   string            `json:"tokenId"`
+	TokenType core.TokenType    `json:"tokenType"`
+	Scopes    []core.TokenScope `json:"scopes,omitempty"`
+	UserAgent string            `json:"userAgent"`
+	LastSeen  int64             `json:"lastSeen"`
+	IsCurrent bool              `json:"isCurrent"`
 }
 
 // TokenInfoResponseSlice represents the slice data structure of TokenInfoResponse
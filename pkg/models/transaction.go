@@ -12,6 +12,7 @@ import (
 const MaximumTagsCountOfTransaction = 10
 const MaximumItemsCountOfTransaction = 10
 const MaximumPicturesCountOfTransaction = 10
+const MaximumSplitsCountOfTransaction = 20
 
 // TransactionType represents transaction type
 type TransactionType byte
@@ -153,6 +154,8 @@ type Transaction struct {
 	GeoLongitude         float64           `xorm:"INDEX(IDX_transaction_uid_deleted_time_longitude_latitude)"`
 	GeoLatitude          float64           `xorm:"INDEX(IDX_transaction_uid_deleted_time_longitude_latitude)"`
 	CreatedIp            string            `xorm:"VARCHAR(39)"`
+	CreatedByUid         int64
+	UpdatedByUid         int64
 	ScheduledCreated     bool
 	CreatedUnixTime      int64
 	UpdatedUnixTime      int64
@@ -174,45 +177,55 @@ type TransactionGeoLocationRequest struct {
 
 // TransactionCreateRequest represents all parameters of transaction creation request
 type TransactionCreateRequest struct {
-	Type                 TransactionType                `json:"type" binding:"required"`
-	CategoryId           int64                          `json:"categoryId,string"`
-	Time                 int64                          `json:"time" binding:"required,min=1"`
-	UtcOffset            int16                          `json:"utcOffset" binding:"min=-720,max=840"`
-	SourceAccountId      int64                          `json:"sourceAccountId,string" binding:"required,min=1"`
-	DestinationAccountId int64                          `json:"destinationAccountId,string" binding:"min=0"`
-	SourceAmount         int64                          `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
-	DestinationAmount    int64                          `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
-	HideAmount           bool                           `json:"hideAmount"`
-	TagIds               []string                       `json:"tagIds"`
-	ItemIds              []string                       `json:"itemIds"`
-	PictureIds           []string                       `json:"pictureIds"`
-	Comment              string                         `json:"comment" binding:"max=255"`
-	GeoLocation          *TransactionGeoLocationRequest `json:"geoLocation" binding:"omitempty"`
-	ClientSessionId      string                         `json:"clientSessionId"`
+	Type                 TransactionType                 `json:"type" binding:"required"`
+	CategoryId           int64                           `json:"categoryId,string"`
+	Time                 int64                           `json:"time" binding:"required,min=1"`
+	UtcOffset            int16                           `json:"utcOffset" binding:"min=-720,max=840"`
+	SourceAccountId      int64                           `json:"sourceAccountId,string" binding:"required,min=1"`
+	DestinationAccountId int64                           `json:"destinationAccountId,string" binding:"min=0"`
+	SourceAmount         int64                           `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
+	DestinationAmount    int64                           `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
+	HideAmount           bool                            `json:"hideAmount"`
+	TagIds               []string                        `json:"tagIds"`
+	ItemIds              []string                        `json:"itemIds"`
+	ItemDetails          []*TransactionItemDetailRequest `json:"itemDetails" binding:"omitempty,dive"`
+	Splits               []*TransactionSplitRequest      `json:"splits" binding:"omitempty,dive"`
+	PictureIds           []string                        `json:"pictureIds"`
+	Comment              string                          `json:"comment" binding:"max=255"`
+	GeoLocation          *TransactionGeoLocationRequest  `json:"geoLocation" binding:"omitempty"`
+	ClientSessionId      string                          `json:"clientSessionId"`
 }
 
 // TransactionModifyRequest represents all parameters of transaction modification request
 type TransactionModifyRequest struct {
-	Id                   int64                          `json:"id,string" binding:"required,min=1"`
-	CategoryId           int64                          `json:"categoryId,string"`
-	Time                 int64                          `json:"time" binding:"required,min=1"`
-	UtcOffset            int16                          `json:"utcOffset" binding:"min=-720,max=840"`
-	SourceAccountId      int64                          `json:"sourceAccountId,string" binding:"required,min=1"`
-	DestinationAccountId int64                          `json:"destinationAccountId,string" binding:"min=0"`
-	SourceAmount         int64                          `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
-	DestinationAmount    int64                          `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
-	HideAmount           bool                           `json:"hideAmount"`
-	TagIds               []string                       `json:"tagIds"`
-	ItemIds              []string                       `json:"itemIds"`
-	PictureIds           []string                       `json:"pictureIds"`
-	Comment              string                         `json:"comment" binding:"max=255"`
-	GeoLocation          *TransactionGeoLocationRequest `json:"geoLocation" binding:"omitempty"`
+	Id                   int64                           `json:"id,string" binding:"required,min=1"`
+	CategoryId           int64                           `json:"categoryId,string"`
+	Time                 int64                           `json:"time" binding:"required,min=1"`
+	UtcOffset            int16                           `json:"utcOffset" binding:"min=-720,max=840"`
+	SourceAccountId      int64                           `json:"sourceAccountId,string" binding:"required,min=1"`
+	DestinationAccountId int64                           `json:"destinationAccountId,string" binding:"min=0"`
+	SourceAmount         int64                           `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
+	DestinationAmount    int64                           `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
+	HideAmount           bool                            `json:"hideAmount"`
+	TagIds               []string                        `json:"tagIds"`
+	ItemIds              []string                        `json:"itemIds"`
+	ItemDetails          []*TransactionItemDetailRequest `json:"itemDetails" binding:"omitempty,dive"`
+	Splits               []*TransactionSplitRequest      `json:"splits" binding:"omitempty,dive"`
+	PictureIds           []string                        `json:"pictureIds"`
+	Comment              string                          `json:"comment" binding:"max=255"`
+	GeoLocation          *TransactionGeoLocationRequest  `json:"geoLocation" binding:"omitempty"`
 }
 
 // TransactionImportRequest represents all parameters of transaction import request
 type TransactionImportRequest struct {
-	Transactions    []*TransactionCreateRequest `json:"transactions"`
-	ClientSessionId string                      `json:"clientSessionId"`
+	Transactions           []*TransactionCreateRequest `json:"transactions"`
+	ClientSessionId        string                      `json:"clientSessionId" binding:"max=64"`
+	SkipProbableDuplicates bool                        `json:"skipProbableDuplicates"`
+	FileType               string                      `json:"fileType" binding:"max=64"`
+	FileHash               string                      `json:"fileHash" binding:"max=64"`
+	CreatedAccountIds      []string                    `json:"createdAccountIds"`
+	CreatedCategoryIds     []string                    `json:"createdCategoryIds"`
+	CreatedTagIds          []string                    `json:"createdTagIds"`
 }
 
 // TransactionImportProcessRequest represents all parameters of transaction import process request
@@ -309,21 +322,23 @@ type TransactionReconciliationStatementRequest struct {
 
 // TransactionStatisticRequest represents all parameters of transaction statistic request
 type TransactionStatisticRequest struct {
-	StartTime              int64  `form:"start_time" binding:"min=0"`
-	EndTime                int64  `form:"end_time" binding:"min=0"`
-	TagFilter              string `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter             string `form:"item_filter" binding:"validItemFilter"`
-	Keyword                string `form:"keyword"`
-	UseTransactionTimezone bool   `form:"use_transaction_timezone"`
+	StartTime                 int64  `form:"start_time" binding:"min=0"`
+	EndTime                   int64  `form:"end_time" binding:"min=0"`
+	TagFilter                 string `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter                string `form:"item_filter" binding:"validItemFilter"`
+	Keyword                   string `form:"keyword"`
+	UseTransactionTimezone    bool   `form:"use_transaction_timezone"`
+	ExchangeAtTransactionDate bool   `form:"exchange_at_transaction_date"`
 }
 
 // TransactionStatisticTrendsRequest represents all parameters of transaction statistic trends request
 type TransactionStatisticTrendsRequest struct {
 	YearMonthRangeRequest
-	TagFilter              string `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter             string `form:"item_filter" binding:"validItemFilter"`
-	Keyword                string `form:"keyword"`
-	UseTransactionTimezone bool   `form:"use_transaction_timezone"`
+	TagFilter                 string `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter                string `form:"item_filter" binding:"validItemFilter"`
+	Keyword                   string `form:"keyword"`
+	UseTransactionTimezone    bool   `form:"use_transaction_timezone"`
+	ExchangeAtTransactionDate bool   `form:"exchange_at_transaction_date"`
 }
 
 // TransactionStatisticAssetTrendsRequest represents all parameters of transaction statistic asset trends request
@@ -400,9 +415,13 @@ type TransactionInfoResponse struct {
 	Tags                 []*TransactionTagInfoResponse            `json:"tags,omitempty"`
 	ItemIds              []string                                 `json:"itemIds"`
 	Items                []*TransactionItemInfoResponse           `json:"items,omitempty"`
+	ItemDetails          []*TransactionItemDetailResponse         `json:"itemDetails,omitempty"`
+	Splits               []*TransactionSplitInfoResponse          `json:"splits,omitempty"`
 	Pictures             TransactionPictureInfoBasicResponseSlice `json:"pictures,omitempty"`
 	Comment              string                                   `json:"comment"`
 	GeoLocation          *TransactionGeoLocationResponse          `json:"geoLocation,omitempty"`
+	CreatedByUid         int64                                    `json:"createdByUid,string,omitempty"`
+	UpdatedByUid         int64                                    `json:"updatedByUid,string,omitempty"`
 	Editable             bool                                     `json:"editable"`
 }
 
@@ -454,6 +473,7 @@ type TransactionStatisticResponseItem struct {
 	RelatedAccountId   int64                         `json:"relatedAccountId,string,omitempty"`
 	RelatedAccountType TransactionRelatedAccountType `json:"relatedAccountType,omitempty"`
 	TotalAmount        int64                         `json:"amount"`
+	Currency           string                        `json:"currency,omitempty"`
 }
 
 // TransactionStatisticTrendsResponseItem represents the data within each statistic interval
@@ -660,6 +680,8 @@ func (t *Transaction) ToTransactionInfoResponse(tagIds []int64, itemIds []int64,
 		ItemIds:              utils.Int64ArrayToStringArray(itemIds),
 		Comment:              t.Comment,
 		GeoLocation:          geoLocation,
+		CreatedByUid:         t.CreatedByUid,
+		UpdatedByUid:         t.UpdatedByUid,
 		Editable:             editable,
 	}
 }
@@ -0,0 +1,155 @@
+package models
+
+import "github.com/mayswind/ezbookkeeping/pkg/utils"
+
+// MaximumTransactionForecastDays represents the maximum days of transaction forecast window
+const MaximumTransactionForecastDays = 366
+
+// ScheduledTransactionForecast represents a transaction which will be created by scheduled transaction template in future
+type ScheduledTransactionForecast struct {
+	TemplateId           int64
+	Type                 TransactionType
+	CategoryId           int64
+	AccountId            int64
+	RelatedAccountId     int64
+	Amount               int64
+	RelatedAccountAmount int64
+	HideAmount           bool
+	TransactionUnixTime  int64
+	TimezoneUtcOffset    int16
+	Comment              string
+}
+
+// TransactionForecastRequest represents all parameters of transaction forecast request
+type TransactionForecastRequest struct {
+	EndTime int64 `form:"end_time" binding:"required,min=1"`
+}
+
+// TransactionForecastResponse represents the forecast of upcoming scheduled transactions and daily account balances
+type TransactionForecastResponse struct {
+	Transactions            []*ScheduledTransactionForecastResponseItem       `json:"transactions"`
+	DailyBalances           TransactionStatisticAssetTrendsResponseItemSlice  `json:"dailyBalances"`
+	NegativeBalanceAccounts []*TransactionForecastNegativeBalanceResponseItem `json:"negativeBalanceAccounts"`
+}
+
+// ScheduledTransactionForecastResponseItem represents a view-object of upcoming scheduled transaction
+type ScheduledTransactionForecastResponseItem struct {
+	TemplateId           int64           `json:"templateId,string"`
+	Type                 TransactionType `json:"type"`
+	CategoryId           int64           `json:"categoryId,string"`
+	Time                 int64           `json:"time"`
+	UtcOffset            int16           `json:"utcOffset"`
+	SourceAccountId      int64           `json:"sourceAccountId,string"`
+	DestinationAccountId int64           `json:"destinationAccountId,string,omitempty"`
+	SourceAmount         int64           `json:"sourceAmount"`
+	DestinationAmount    int64           `json:"destinationAmount,omitempty"`
+	HideAmount           bool            `json:"hideAmount"`
+	Comment              string          `json:"comment"`
+}
+
+// TransactionForecastNegativeBalanceResponseItem represents the first day when the projected balance of an asset account becomes negative
+type TransactionForecastNegativeBalanceResponseItem struct {
+	AccountId             int64 `json:"accountId,string"`
+	Year                  int32 `json:"year"`
+	Month                 int32 `json:"month"`
+	Day                   int32 `json:"day"`
+	AccountClosingBalance int64 `json:"accountClosingBalance"`
+}
+
+// ToTransactions returns the transaction models (transfer forecast will be converted to transfer out and transfer in transactions) for calculating account balance
+func (f *ScheduledTransactionForecast) ToTransactions() []*Transaction {
+	transactionTime := utils.GetMinTransactionTimeFromUnixTime(f.TransactionUnixTime)
+
+	if f.Type == TRANSACTION_TYPE_INCOME {
+		return []*Transaction{
+			{
+				Type:              TRANSACTION_DB_TYPE_INCOME,
+				CategoryId:        f.CategoryId,
+				AccountId:         f.AccountId,
+				Amount:            f.Amount,
+				TransactionTime:   transactionTime,
+				TimezoneUtcOffset: f.TimezoneUtcOffset,
+			},
+		}
+	} else if f.Type == TRANSACTION_TYPE_EXPENSE {
+		return []*Transaction{
+			{
+				Type:              TRANSACTION_DB_TYPE_EXPENSE,
+				CategoryId:        f.CategoryId,
+				AccountId:         f.AccountId,
+				Amount:            f.Amount,
+				TransactionTime:   transactionTime,
+				TimezoneUtcOffset: f.TimezoneUtcOffset,
+			},
+		}
+	} else if f.Type == TRANSACTION_TYPE_TRANSFER {
+		return []*Transaction{
+			{
+				Type:                 TRANSACTION_DB_TYPE_TRANSFER_OUT,
+				CategoryId:           f.CategoryId,
+				AccountId:            f.AccountId,
+				RelatedAccountId:     f.RelatedAccountId,
+				Amount:               f.Amount,
+				RelatedAccountAmount: f.RelatedAccountAmount,
+				TransactionTime:      transactionTime,
+				TimezoneUtcOffset:    f.TimezoneUtcOffset,
+			},
+			{
+				Type:                 TRANSACTION_DB_TYPE_TRANSFER_IN,
+				CategoryId:           f.CategoryId,
+				AccountId:            f.RelatedAccountId,
+				RelatedAccountId:     f.AccountId,
+				Amount:               f.RelatedAccountAmount,
+				RelatedAccountAmount: f.Amount,
+				TransactionTime:      transactionTime + 1,
+				TimezoneUtcOffset:    f.TimezoneUtcOffset,
+			},
+		}
+	}
+
+	return nil
+}
+
+// ToScheduledTransactionForecastResponseItem returns a view-object according to forecast model
+func (f *ScheduledTransactionForecast) ToScheduledTransactionForecastResponseItem() *ScheduledTransactionForecastResponseItem {
+	resp := &ScheduledTransactionForecastResponseItem{
+		TemplateId:      f.TemplateId,
+		Type:            f.Type,
+		CategoryId:      f.CategoryId,
+		Time:            f.TransactionUnixTime,
+		UtcOffset:       f.TimezoneUtcOffset,
+		SourceAccountId: f.AccountId,
+		SourceAmount:    f.Amount,
+		HideAmount:      f.HideAmount,
+		Comment:         f.Comment,
+	}
+
+	if f.Type == TRANSACTION_TYPE_TRANSFER {
+		resp.DestinationAccountId = f.RelatedAccountId
+		resp.DestinationAmount = f.RelatedAccountAmount
+	}
+
+	return resp
+}
+
+// ScheduledTransactionForecastSlice represents the slice data structure of ScheduledTransactionForecast
+type ScheduledTransactionForecastSlice []*ScheduledTransactionForecast
+
+// Len returns the count of items
+func (s ScheduledTransactionForecastSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s ScheduledTransactionForecastSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s ScheduledTransactionForecastSlice) Less(i, j int) bool {
+	if s[i].TransactionUnixTime != s[j].TransactionUnixTime {
+		return s[i].TransactionUnixTime < s[j].TransactionUnixTime
+	}
+
+	return s[i].TemplateId < s[j].TemplateId
+}
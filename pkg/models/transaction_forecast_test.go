@@ -0,0 +1,67 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledTransactionForecastToTransactions_Expense(t *testing.T) {
+	forecast := &ScheduledTransactionForecast{
+		TemplateId:          1,
+		Type:                TRANSACTION_TYPE_EXPENSE,
+		CategoryId:          2,
+		AccountId:           3,
+		Amount:              1000,
+		TransactionUnixTime: 1704067200,
+		TimezoneUtcOffset:   480,
+	}
+
+	transactions := forecast.ToTransactions()
+	assert.Equal(t, 1, len(transactions))
+	assert.Equal(t, TRANSACTION_DB_TYPE_EXPENSE, transactions[0].Type)
+	assert.Equal(t, int64(3), transactions[0].AccountId)
+	assert.Equal(t, int64(1000), transactions[0].Amount)
+	assert.Equal(t, int64(1704067200000), transactions[0].TransactionTime)
+}
+
+func TestScheduledTransactionForecastToTransactions_Transfer(t *testing.T) {
+	forecast := &ScheduledTransactionForecast{
+		TemplateId:           1,
+		Type:                 TRANSACTION_TYPE_TRANSFER,
+		CategoryId:           2,
+		AccountId:            3,
+		RelatedAccountId:     4,
+		Amount:               1000,
+		RelatedAccountAmount: 900,
+		TransactionUnixTime:  1704067200,
+	}
+
+	transactions := forecast.ToTransactions()
+	assert.Equal(t, 2, len(transactions))
+	assert.Equal(t, TRANSACTION_DB_TYPE_TRANSFER_OUT, transactions[0].Type)
+	assert.Equal(t, int64(3), transactions[0].AccountId)
+	assert.Equal(t, int64(1000), transactions[0].Amount)
+	assert.Equal(t, TRANSACTION_DB_TYPE_TRANSFER_IN, transactions[1].Type)
+	assert.Equal(t, int64(4), transactions[1].AccountId)
+	assert.Equal(t, int64(900), transactions[1].Amount)
+
+	resp := forecast.ToScheduledTransactionForecastResponseItem()
+	assert.Equal(t, int64(3), resp.SourceAccountId)
+	assert.Equal(t, int64(4), resp.DestinationAccountId)
+	assert.Equal(t, int64(900), resp.DestinationAmount)
+}
+
+func TestScheduledTransactionForecastSliceLess(t *testing.T) {
+	var forecastSlice ScheduledTransactionForecastSlice
+	forecastSlice = append(forecastSlice, &ScheduledTransactionForecast{TemplateId: 2, TransactionUnixTime: 200})
+	forecastSlice = append(forecastSlice, &ScheduledTransactionForecast{TemplateId: 3, TransactionUnixTime: 100})
+	forecastSlice = append(forecastSlice, &ScheduledTransactionForecast{TemplateId: 1, TransactionUnixTime: 200})
+
+	sort.Sort(forecastSlice)
+
+	assert.Equal(t, int64(3), forecastSlice[0].TemplateId)
+	assert.Equal(t, int64(1), forecastSlice[1].TemplateId)
+	assert.Equal(t, int64(2), forecastSlice[2].TemplateId)
+}
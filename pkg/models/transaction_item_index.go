@@ -1,14 +1,211 @@
 package models
 
+import (
+	"math"
+	"math/bits"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionItemQuantityScale represents the scale of transaction item quantity, quantity is stored in hundredths
+const TransactionItemQuantityScale = 100
+
+// MaximumTransactionItemAmount represents the maximum subtotal of transaction item
+const MaximumTransactionItemAmount = 99999999999
+
 // TransactionItemIndex represents transaction and transaction item relation stored in database
 type TransactionItemIndex struct {
-	ItemIndexId      int64 `xorm:"PK"`
-	Uid              int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
-	Deleted          bool  `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id) NOT NULL"`
-	TransactionTime  int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) NOT NULL"`
-	ItemId           int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id)"`
-	TransactionId    int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
-	CreatedUnixTime  int64
-	UpdatedUnixTime  int64
-	DeletedUnixTime  int64
+	ItemIndexId     int64 `xorm:"PK"`
+	Uid             int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
+	Deleted         bool  `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id) NOT NULL"`
+	TransactionTime int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) NOT NULL"`
+	ItemId          int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id)"`
+	TransactionId   int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
+	Quantity        int64 `xorm:"NOT NULL DEFAULT 0"`
+	UnitPrice       int64 `xorm:"NOT NULL DEFAULT 0"`
+	Amount          int64 `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// TransactionItemDetail represents the quantity, unit price and subtotal of a transaction item in a transaction
+type TransactionItemDetail struct {
+	Quantity  int64
+	UnitPrice int64
+	Amount    int64
+}
+
+// TransactionItemDetailRequest represents all parameters of transaction item detail in transaction creation or modification request
+type TransactionItemDetailRequest struct {
+	ItemId    int64 `json:"itemId,string" binding:"required,min=1"`
+	Quantity  int64 `json:"quantity" binding:"min=0,max=99999999999"`
+	UnitPrice int64 `json:"unitPrice" binding:"min=0,max=99999999999"`
+	Amount    int64 `json:"amount" binding:"min=0,max=99999999999"`
+}
+
+// TransactionItemDetailResponse represents a view-object of transaction item detail in transaction
+type TransactionItemDetailResponse struct {
+	ItemId    int64 `json:"itemId,string"`
+	Quantity  int64 `json:"quantity"`
+	UnitPrice int64 `json:"unitPrice"`
+	Amount    int64 `json:"amount"`
+}
+
+// TransactionItemStatisticRequest represents all parameters of transaction item statistic request
+type TransactionItemStatisticRequest struct {
+	ItemIds   string `form:"item_ids"`
+	StartTime int64  `form:"start_time" binding:"min=0"`
+	EndTime   int64  `form:"end_time" binding:"min=0"`
+}
+
+// TransactionItemPriceHistoryRequest represents all parameters of transaction item price history request
+type TransactionItemPriceHistoryRequest struct {
+	Id        int64 `form:"id,string" binding:"required,min=1"`
+	StartTime int64 `form:"start_time" binding:"min=0"`
+	EndTime   int64 `form:"end_time" binding:"min=0"`
+}
+
+// TransactionItemStatisticResponseItem represents the total quantity and subtotal of a transaction item in the specified time range
+type TransactionItemStatisticResponseItem struct {
+	ItemId           int64 `json:"itemId,string"`
+	TransactionCount int64 `json:"transactionCount"`
+	TotalQuantity    int64 `json:"totalQuantity"`
+	TotalAmount      int64 `json:"totalAmount"`
+	MinUnitPrice     int64 `json:"minUnitPrice"`
+	MaxUnitPrice     int64 `json:"maxUnitPrice"`
+	AverageUnitPrice int64 `json:"averageUnitPrice"`
+}
+
+// TransactionItemPriceHistoryResponseItem represents the quantity, unit price and subtotal of a transaction item in a transaction
+type TransactionItemPriceHistoryResponseItem struct {
+	TransactionId int64 `json:"transactionId,string"`
+	Time          int64 `json:"time"`
+	Quantity      int64 `json:"quantity"`
+	UnitPrice     int64 `json:"unitPrice"`
+	Amount        int64 `json:"amount"`
+}
+
+// ToTransactionItemDetail returns the transaction item detail, the subtotal is calculated by quantity and unit price if it is not set
+func (t *TransactionItemDetailRequest) ToTransactionItemDetail() (*TransactionItemDetail, error) {
+	amount := t.Amount
+
+	if amount == 0 && t.Quantity > 0 && t.UnitPrice > 0 {
+		high, low := bits.Mul64(uint64(t.Quantity), uint64(t.UnitPrice))
+
+		if high != 0 || low > math.MaxInt64-TransactionItemQuantityScale/2 {
+			return nil, errs.ErrTransactionItemSubtotalTooLarge
+		}
+
+		amount = (int64(low) + TransactionItemQuantityScale/2) / TransactionItemQuantityScale
+	}
+
+	if amount > MaximumTransactionItemAmount {
+		return nil, errs.ErrTransactionItemSubtotalTooLarge
+	}
+
+	return &TransactionItemDetail{
+		Quantity:  t.Quantity,
+		UnitPrice: t.UnitPrice,
+		Amount:    amount,
+	}, nil
+}
+
+// GetTransactionItemDetail returns the quantity, unit price and subtotal of this transaction item index
+func (t *TransactionItemIndex) GetTransactionItemDetail() *TransactionItemDetail {
+	return &TransactionItemDetail{
+		Quantity:  t.Quantity,
+		UnitPrice: t.UnitPrice,
+		Amount:    t.Amount,
+	}
+}
+
+// GetUnitPrice returns the unit price of this transaction item index, the unit price is calculated by subtotal and quantity if it is not set
+func (t *TransactionItemIndex) GetUnitPrice() int64 {
+	if t.UnitPrice > 0 || t.Quantity <= 0 {
+		return t.UnitPrice
+	}
+
+	return (t.Amount*TransactionItemQuantityScale + t.Quantity/2) / t.Quantity
+}
+
+// ToTransactionItemPriceHistoryResponseItem returns a view-object of transaction item price history according to database model
+func (t *TransactionItemIndex) ToTransactionItemPriceHistoryResponseItem() *TransactionItemPriceHistoryResponseItem {
+	return &TransactionItemPriceHistoryResponseItem{
+		TransactionId: t.TransactionId,
+		Time:          utils.GetUnixTimeFromTransactionTime(t.TransactionTime),
+		Quantity:      t.Quantity,
+		UnitPrice:     t.GetUnitPrice(),
+		Amount:        t.Amount,
+	}
+}
+
+// Equals returns whether this transaction item detail equals to the other one
+func (t *TransactionItemDetail) Equals(other *TransactionItemDetail) bool {
+	if t == nil || other == nil {
+		return t.IsEmpty() && other.IsEmpty()
+	}
+
+	return t.Quantity == other.Quantity && t.UnitPrice == other.UnitPrice && t.Amount == other.Amount
+}
+
+// IsEmpty returns whether this transaction item detail has no quantity, unit price and subtotal
+func (t *TransactionItemDetail) IsEmpty() bool {
+	return t == nil || (t.Quantity == 0 && t.UnitPrice == 0 && t.Amount == 0)
+}
+
+// GetTransactionItemDetails returns the transaction item details map by item id according to item detail requests, returns nil if item detail requests are not provided
+func GetTransactionItemDetails(itemDetailReqs []*TransactionItemDetailRequest, itemIds []int64) (map[int64]*TransactionItemDetail, error) {
+	if itemDetailReqs == nil {
+		return nil, nil
+	}
+
+	itemDetails := make(map[int64]*TransactionItemDetail, len(itemDetailReqs))
+	allItemIds := make(map[int64]bool, len(itemIds))
+
+	for i := 0; i < len(itemIds); i++ {
+		allItemIds[itemIds[i]] = true
+	}
+
+	for i := 0; i < len(itemDetailReqs); i++ {
+		itemDetailReq := itemDetailReqs[i]
+
+		if itemDetailReq == nil || itemDetailReq.Quantity < 0 || itemDetailReq.UnitPrice < 0 || itemDetailReq.Amount < 0 {
+			return nil, errs.ErrTransactionItemDetailInvalid
+		}
+
+		if _, exists := allItemIds[itemDetailReq.ItemId]; !exists {
+			return nil, errs.ErrTransactionItemDetailInvalid
+		}
+
+		if _, exists := itemDetails[itemDetailReq.ItemId]; exists {
+			return nil, errs.ErrTransactionItemDetailInvalid
+		}
+
+		itemDetail, err := itemDetailReq.ToTransactionItemDetail()
+
+		if err != nil {
+			return nil, err
+		}
+
+		itemDetails[itemDetailReq.ItemId] = itemDetail
+	}
+
+	return itemDetails, nil
+}
+
+// IsTransactionItemsAmountValid returns whether the sum of subtotals of all transaction items does not exceed the transaction amount
+func IsTransactionItemsAmountValid(itemIndexes []*TransactionItemIndex, transactionAmount int64) bool {
+	totalAmount := int64(0)
+
+	for i := 0; i < len(itemIndexes); i++ {
+		totalAmount += itemIndexes[i].Amount
+	}
+
+	if transactionAmount < 0 {
+		transactionAmount = -transactionAmount
+	}
+
+	return totalAmount <= transactionAmount
 }
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestTransactionItemDetailRequestToTransactionItemDetail(t *testing.T) {
+	itemDetail, err := (&TransactionItemDetailRequest{ItemId: 1, Quantity: 250, UnitPrice: 399, Amount: 1000}).ToTransactionItemDetail()
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionItemDetail{Quantity: 250, UnitPrice: 399, Amount: 1000}, itemDetail)
+
+	itemDetail, err = (&TransactionItemDetailRequest{ItemId: 1, Quantity: 250, UnitPrice: 399}).ToTransactionItemDetail()
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionItemDetail{Quantity: 250, UnitPrice: 399, Amount: 998}, itemDetail)
+
+	itemDetail, err = (&TransactionItemDetailRequest{ItemId: 1, UnitPrice: 399}).ToTransactionItemDetail()
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionItemDetail{UnitPrice: 399}, itemDetail)
+
+	itemDetail, err = (&TransactionItemDetailRequest{ItemId: 1, Quantity: 100, UnitPrice: MaximumTransactionItemAmount}).ToTransactionItemDetail()
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionItemDetail{Quantity: 100, UnitPrice: MaximumTransactionItemAmount, Amount: MaximumTransactionItemAmount}, itemDetail)
+}
+
+func TestTransactionItemDetailRequestToTransactionItemDetail_SubtotalTooLarge(t *testing.T) {
+	_, err := (&TransactionItemDetailRequest{ItemId: 1, Quantity: 99999999999, UnitPrice: 99999999999}).ToTransactionItemDetail()
+	assert.EqualError(t, err, errs.ErrTransactionItemSubtotalTooLarge.Message)
+
+	_, err = (&TransactionItemDetailRequest{ItemId: 1, Quantity: 101, UnitPrice: MaximumTransactionItemAmount}).ToTransactionItemDetail()
+	assert.EqualError(t, err, errs.ErrTransactionItemSubtotalTooLarge.Message)
+
+	_, err = GetTransactionItemDetails([]*TransactionItemDetailRequest{{ItemId: 1, Quantity: 99999999999, UnitPrice: 99999999999}}, []int64{1})
+	assert.EqualError(t, err, errs.ErrTransactionItemSubtotalTooLarge.Message)
+}
+
+func TestTransactionItemDetailEquals(t *testing.T) {
+	var nilItemDetail *TransactionItemDetail
+
+	assert.True(t, nilItemDetail.Equals(nil))
+	assert.True(t, nilItemDetail.Equals(&TransactionItemDetail{}))
+	assert.True(t, (&TransactionItemDetail{}).Equals(nil))
+	assert.False(t, nilItemDetail.Equals(&TransactionItemDetail{Amount: 1}))
+	assert.True(t, (&TransactionItemDetail{Quantity: 100, UnitPrice: 200, Amount: 200}).Equals(&TransactionItemDetail{Quantity: 100, UnitPrice: 200, Amount: 200}))
+	assert.False(t, (&TransactionItemDetail{Quantity: 100, UnitPrice: 200, Amount: 200}).Equals(&TransactionItemDetail{Quantity: 100, UnitPrice: 200, Amount: 201}))
+}
+
+func TestTransactionItemIndexGetUnitPrice(t *testing.T) {
+	assert.Equal(t, int64(399), (&TransactionItemIndex{Quantity: 250, UnitPrice: 399, Amount: 1000}).GetUnitPrice())
+	assert.Equal(t, int64(400), (&TransactionItemIndex{Quantity: 250, Amount: 1000}).GetUnitPrice())
+	assert.Equal(t, int64(333), (&TransactionItemIndex{Quantity: 300, Amount: 1000}).GetUnitPrice())
+	assert.Equal(t, int64(0), (&TransactionItemIndex{Amount: 1000}).GetUnitPrice())
+}
+
+func TestGetTransactionItemDetails(t *testing.T) {
+	itemDetails, err := GetTransactionItemDetails(nil, []int64{1, 2})
+	assert.Nil(t, err)
+	assert.Nil(t, itemDetails)
+
+	itemDetails, err = GetTransactionItemDetails([]*TransactionItemDetailRequest{}, []int64{1, 2})
+	assert.Nil(t, err)
+	assert.NotNil(t, itemDetails)
+	assert.Equal(t, 0, len(itemDetails))
+
+	itemDetails, err = GetTransactionItemDetails([]*TransactionItemDetailRequest{
+		{ItemId: 1, Quantity: 200, UnitPrice: 150},
+		{ItemId: 2, Amount: 500},
+	}, []int64{1, 2, 3})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(itemDetails))
+	assert.Equal(t, &TransactionItemDetail{Quantity: 200, UnitPrice: 150, Amount: 300}, itemDetails[1])
+	assert.Equal(t, &TransactionItemDetail{Amount: 500}, itemDetails[2])
+}
+
+func TestGetTransactionItemDetails_InvalidItemDetails(t *testing.T) {
+	_, err := GetTransactionItemDetails([]*TransactionItemDetailRequest{{ItemId: 4, Amount: 100}}, []int64{1, 2})
+	assert.EqualError(t, err, errs.ErrTransactionItemDetailInvalid.Message)
+
+	_, err = GetTransactionItemDetails([]*TransactionItemDetailRequest{{ItemId: 1, Amount: 100}, {ItemId: 1, Amount: 200}}, []int64{1, 2})
+	assert.EqualError(t, err, errs.ErrTransactionItemDetailInvalid.Message)
+
+	_, err = GetTransactionItemDetails([]*TransactionItemDetailRequest{{ItemId: 1, Amount: -100}}, []int64{1, 2})
+	assert.EqualError(t, err, errs.ErrTransactionItemDetailInvalid.Message)
+
+	_, err = GetTransactionItemDetails([]*TransactionItemDetailRequest{nil}, []int64{1, 2})
+	assert.EqualError(t, err, errs.ErrTransactionItemDetailInvalid.Message)
+}
+
+func TestIsTransactionItemsAmountValid(t *testing.T) {
+	itemIndexes := []*TransactionItemIndex{
+		{ItemId: 1, Amount: 300},
+		{ItemId: 2, Amount: 700},
+	}
+
+	assert.True(t, IsTransactionItemsAmountValid(nil, 0))
+	assert.True(t, IsTransactionItemsAmountValid(itemIndexes, 1000))
+	assert.True(t, IsTransactionItemsAmountValid(itemIndexes, -1000))
+	assert.True(t, IsTransactionItemsAmountValid(itemIndexes, 1200))
+	assert.False(t, IsTransactionItemsAmountValid(itemIndexes, 999))
+	assert.False(t, IsTransactionItemsAmountValid(itemIndexes, -999))
+}
@@ -0,0 +1,392 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MaximumTagsCountOfTransactionRule represents the maximum count of tags which transaction rule can add
+const MaximumTagsCountOfTransactionRule = 10
+
+// TransactionRuleKeywordMatchType represents how transaction rule matches the transaction description
+type TransactionRuleKeywordMatchType byte
+
+// Transaction rule keyword match types
+const (
+	TRANSACTION_RULE_KEYWORD_MATCH_TYPE_NONE        TransactionRuleKeywordMatchType = 0
+	TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS    TransactionRuleKeywordMatchType = 1
+	TRANSACTION_RULE_KEYWORD_MATCH_TYPE_EQUALS      TransactionRuleKeywordMatchType = 2
+	TRANSACTION_RULE_KEYWORD_MATCH_TYPE_STARTS_WITH TransactionRuleKeywordMatchType = 3
+	TRANSACTION_RULE_KEYWORD_MATCH_TYPE_ENDS_WITH   TransactionRuleKeywordMatchType = 4
+	TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX       TransactionRuleKeywordMatchType = 5
+)
+
+// TransactionRule represents user-defined rule which auto-categorises and tags transactions, stored in database
+type TransactionRule struct {
+	RuleId                    int64                           `xorm:"PK"`
+	Uid                       int64                           `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Deleted                   bool                            `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Name                      string                          `xorm:"VARCHAR(64) NOT NULL"`
+	ConditionTransactionType  TransactionType                 `xorm:"NOT NULL"`
+	ConditionKeywordMatchType TransactionRuleKeywordMatchType `xorm:"NOT NULL"`
+	ConditionKeyword          string                          `xorm:"VARCHAR(255) NOT NULL"`
+	ConditionMinAmount        *int64
+	ConditionMaxAmount        *int64
+	ConditionAccountId        int64  `xorm:"NOT NULL"`
+	ActionCategoryId          int64  `xorm:"NOT NULL"`
+	ActionTagIds              string `xorm:"VARCHAR(255) NOT NULL"`
+	ActionAccountId           int64  `xorm:"NOT NULL"`
+	DisplayOrder              int32  `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Disabled                  bool   `xorm:"NOT NULL"`
+	CreatedUnixTime           int64
+	UpdatedUnixTime           int64
+	DeletedUnixTime           int64
+	keywordRegex              *regexp.Regexp
+}
+
+// TransactionRuleMatchTarget represents the transaction fields which transaction rule matches against
+type TransactionRuleMatchTarget struct {
+	Type        TransactionType
+	Amount      int64
+	AccountId   int64
+	Description string
+}
+
+// TransactionRuleActionResult represents the changes which the matched transaction rules will apply to a transaction
+type TransactionRuleActionResult struct {
+	RuleIds    []int64
+	CategoryId int64
+	TagIds     []int64
+	AccountId  int64
+}
+
+// TransactionRuleApplyResult represents the changes which the matched transaction rules will apply to an existed transaction
+type TransactionRuleApplyResult struct {
+	Transaction *Transaction
+	RuleIds     []int64
+	CategoryId  int64
+	AddedTagIds []int64
+}
+
+// TransactionRuleGetRequest represents all parameters of transaction rule getting request
+type TransactionRuleGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// TransactionRuleCreateRequest represents all parameters of transaction rule creation request
+type TransactionRuleCreateRequest struct {
+	Name             string                          `json:"name" binding:"required,notBlank,max=64"`
+	TransactionType  TransactionType                 `json:"transactionType" binding:"min=0,max=4"`
+	KeywordMatchType TransactionRuleKeywordMatchType `json:"keywordMatchType" binding:"min=0,max=5"`
+	Keyword          string                          `json:"keyword" binding:"max=255"`
+	MinAmount        *int64                          `json:"minAmount" binding:"omitempty,min=0,max=99999999999"`
+	MaxAmount        *int64                          `json:"maxAmount" binding:"omitempty,min=0,max=99999999999"`
+	AccountId        int64                           `json:"accountId,string" binding:"min=0"`
+	TargetCategoryId int64                           `json:"targetCategoryId,string" binding:"min=0"`
+	TargetTagIds     []string                        `json:"targetTagIds"`
+	TargetAccountId  int64                           `json:"targetAccountId,string" binding:"min=0"`
+	Disabled         bool                            `json:"disabled"`
+}
+
+// TransactionRuleModifyRequest represents all parameters of transaction rule modification request
+type TransactionRuleModifyRequest struct {
+	Id               int64                           `json:"id,string" binding:"required,min=1"`
+	Name             string                          `json:"name" binding:"required,notBlank,max=64"`
+	TransactionType  TransactionType                 `json:"transactionType" binding:"min=0,max=4"`
+	KeywordMatchType TransactionRuleKeywordMatchType `json:"keywordMatchType" binding:"min=0,max=5"`
+	Keyword          string                          `json:"keyword" binding:"max=255"`
+	MinAmount        *int64                          `json:"minAmount" binding:"omitempty,min=0,max=99999999999"`
+	MaxAmount        *int64                          `json:"maxAmount" binding:"omitempty,min=0,max=99999999999"`
+	AccountId        int64                           `json:"accountId,string" binding:"min=0"`
+	TargetCategoryId int64                           `json:"targetCategoryId,string" binding:"min=0"`
+	TargetTagIds     []string                        `json:"targetTagIds"`
+	TargetAccountId  int64                           `json:"targetAccountId,string" binding:"min=0"`
+	Disabled         bool                            `json:"disabled"`
+}
+
+// TransactionRuleMoveRequest represents all parameters of transaction rule moving request
+type TransactionRuleMoveRequest struct {
+	NewDisplayOrders []*TransactionRuleNewDisplayOrderRequest `json:"newDisplayOrders" binding:"required,min=1"`
+}
+
+// TransactionRuleNewDisplayOrderRequest represents a data pair of id and display order
+type TransactionRuleNewDisplayOrderRequest struct {
+	Id           int64 `json:"id,string" binding:"required,min=1"`
+	DisplayOrder int32 `json:"displayOrder"`
+}
+
+// TransactionRuleDeleteRequest represents all parameters of transaction rule deleting request
+type TransactionRuleDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// TransactionRuleApplyRequest represents all parameters of applying transaction rules to existed transactions request
+type TransactionRuleApplyRequest struct {
+	RuleIds   []string `json:"ruleIds"`
+	StartTime int64    `json:"startTime" binding:"min=0"`
+	EndTime   int64    `json:"endTime" binding:"min=0"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+// TransactionRuleInfoResponse represents a view-object of transaction rule
+type TransactionRuleInfoResponse struct {
+	Id               int64                           `json:"id,string"`
+	Name             string                          `json:"name"`
+	TransactionType  TransactionType                 `json:"transactionType"`
+	KeywordMatchType TransactionRuleKeywordMatchType `json:"keywordMatchType"`
+	Keyword          string                          `json:"keyword"`
+	MinAmount        *int64                          `json:"minAmount,omitempty"`
+	MaxAmount        *int64                          `json:"maxAmount,omitempty"`
+	AccountId        int64                           `json:"accountId,string"`
+	TargetCategoryId int64                           `json:"targetCategoryId,string"`
+	TargetTagIds     []string                        `json:"targetTagIds"`
+	TargetAccountId  int64                           `json:"targetAccountId,string"`
+	DisplayOrder     int32                           `json:"displayOrder"`
+	Disabled         bool                            `json:"disabled"`
+}
+
+// TransactionRuleApplyResponse represents the result of applying transaction rules to existed transactions
+type TransactionRuleApplyResponse struct {
+	DryRun       bool                                `json:"dryRun"`
+	MatchedCount int                                 `json:"matchedCount"`
+	Items        []*TransactionRuleApplyResponseItem `json:"items"`
+}
+
+// TransactionRuleApplyResponseItem represents the changes of a transaction by applying transaction rules
+type TransactionRuleApplyResponseItem struct {
+	TransactionId      int64    `json:"transactionId,string"`
+	Time               int64    `json:"time"`
+	Comment            string   `json:"comment"`
+	RuleIds            []string `json:"ruleIds"`
+	OriginalCategoryId int64    `json:"originalCategoryId,string"`
+	CategoryId         int64    `json:"categoryId,string"`
+	AddedTagIds        []string `json:"addedTagIds"`
+}
+
+// GetActionTagIds returns all tag ids which the transaction rule will add
+func (r *TransactionRule) GetActionTagIds() []int64 {
+	tagIds := make([]string, 0)
+
+	if r.ActionTagIds != "" {
+		tagIds = strings.Split(r.ActionTagIds, ",")
+	}
+
+	result, _ := utils.StringArrayToInt64Array(tagIds)
+
+	return result
+}
+
+// IsKeywordValid returns whether the keyword condition of the transaction rule is valid
+func (r *TransactionRule) IsKeywordValid() bool {
+	if r.ConditionKeywordMatchType == TRANSACTION_RULE_KEYWORD_MATCH_TYPE_NONE {
+		return r.ConditionKeyword == ""
+	}
+
+	if r.ConditionKeywordMatchType > TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX || r.ConditionKeyword == "" {
+		return false
+	}
+
+	if r.ConditionKeywordMatchType == TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX {
+		_, err := regexp.Compile(r.ConditionKeyword)
+		return err == nil
+	}
+
+	return true
+}
+
+// HasAction returns whether the transaction rule will change any field of transaction
+func (r *TransactionRule) HasAction() bool {
+	return r.ActionCategoryId > 0 || r.ActionTagIds != "" || r.ActionAccountId > 0
+}
+
+// IsMatched returns whether the transaction rule matches the specified transaction
+func (r *TransactionRule) IsMatched(target *TransactionRuleMatchTarget) bool {
+	if r.Disabled || target == nil {
+		return false
+	}
+
+	if r.ConditionTransactionType > 0 && r.ConditionTransactionType != target.Type {
+		return false
+	}
+
+	if r.ConditionAccountId > 0 && r.ConditionAccountId != target.AccountId {
+		return false
+	}
+
+	amount := target.Amount
+
+	if amount < 0 {
+		amount = -amount
+	}
+
+	if r.ConditionMinAmount != nil && amount < *r.ConditionMinAmount {
+		return false
+	}
+
+	if r.ConditionMaxAmount != nil && amount > *r.ConditionMaxAmount {
+		return false
+	}
+
+	return r.isKeywordMatched(target.Description)
+}
+
+// ToTransactionRuleInfoResponse returns a view-object according to database model
+func (r *TransactionRule) ToTransactionRuleInfoResponse() *TransactionRuleInfoResponse {
+	return &TransactionRuleInfoResponse{
+		Id:               r.RuleId,
+		Name:             r.Name,
+		TransactionType:  r.ConditionTransactionType,
+		KeywordMatchType: r.ConditionKeywordMatchType,
+		Keyword:          r.ConditionKeyword,
+		MinAmount:        r.ConditionMinAmount,
+		MaxAmount:        r.ConditionMaxAmount,
+		AccountId:        r.ConditionAccountId,
+		TargetCategoryId: r.ActionCategoryId,
+		TargetTagIds:     utils.Int64ArrayToStringArray(r.GetActionTagIds()),
+		TargetAccountId:  r.ActionAccountId,
+		DisplayOrder:     r.DisplayOrder,
+		Disabled:         r.Disabled,
+	}
+}
+
+func (r *TransactionRule) isKeywordMatched(description string) bool {
+	switch r.ConditionKeywordMatchType {
+	case TRANSACTION_RULE_KEYWORD_MATCH_TYPE_NONE:
+		return true
+	case TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS:
+		return strings.Contains(strings.ToLower(description), strings.ToLower(r.ConditionKeyword))
+	case TRANSACTION_RULE_KEYWORD_MATCH_TYPE_EQUALS:
+		return strings.EqualFold(strings.TrimSpace(description), r.ConditionKeyword)
+	case TRANSACTION_RULE_KEYWORD_MATCH_TYPE_STARTS_WITH:
+		return strings.HasPrefix(strings.ToLower(strings.TrimSpace(description)), strings.ToLower(r.ConditionKeyword))
+	case TRANSACTION_RULE_KEYWORD_MATCH_TYPE_ENDS_WITH:
+		return strings.HasSuffix(strings.ToLower(strings.TrimSpace(description)), strings.ToLower(r.ConditionKeyword))
+	case TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX:
+		if r.keywordRegex == nil {
+			keywordRegex, err := regexp.Compile(r.ConditionKeyword)
+
+			if err != nil {
+				return false
+			}
+
+			r.keywordRegex = keywordRegex
+		}
+
+		return r.keywordRegex.MatchString(description)
+	}
+
+	return false
+}
+
+// GetTransactionRuleActionResult returns the changes which the matched transaction rules (in display order) will apply to the specified transaction, returns nil if no rule matches
+// The category and account are set by the first matched rule which provides a valid one, and the tags of all matched rules are added
+func GetTransactionRuleActionResult(rules []*TransactionRule, target *TransactionRuleMatchTarget, categoryMap map[int64]*TransactionCategory, accountMap map[int64]*Account) *TransactionRuleActionResult {
+	var result *TransactionRuleActionResult
+	tagIdExists := make(map[int64]bool)
+
+	for i := 0; i < len(rules); i++ {
+		rule := rules[i]
+
+		if !rule.IsMatched(target) {
+			continue
+		}
+
+		if result == nil {
+			result = &TransactionRuleActionResult{
+				RuleIds: make([]int64, 0, 1),
+				TagIds:  make([]int64, 0),
+			}
+		}
+
+		result.RuleIds = append(result.RuleIds, rule.RuleId)
+
+		if result.CategoryId == 0 && rule.ActionCategoryId > 0 {
+			category, exists := categoryMap[rule.ActionCategoryId]
+
+			if exists && isTransactionRuleCategoryTypeMatched(category, target.Type) {
+				result.CategoryId = rule.ActionCategoryId
+			}
+		}
+
+		if result.AccountId == 0 && rule.ActionAccountId > 0 {
+			if _, exists := accountMap[rule.ActionAccountId]; exists {
+				result.AccountId = rule.ActionAccountId
+			}
+		}
+
+		tagIds := rule.GetActionTagIds()
+
+		for j := 0; j < len(tagIds); j++ {
+			if tagIdExists[tagIds[j]] {
+				continue
+			}
+
+			tagIdExists[tagIds[j]] = true
+			result.TagIds = append(result.TagIds, tagIds[j])
+		}
+	}
+
+	return result
+}
+
+func isTransactionRuleCategoryTypeMatched(category *TransactionCategory, transactionType TransactionType) bool {
+	if category == nil || category.Hidden || category.ParentCategoryId == LevelOneTransactionCategoryParentId {
+		return false
+	}
+
+	switch transactionType {
+	case TRANSACTION_TYPE_INCOME:
+		return category.Type == CATEGORY_TYPE_INCOME
+	case TRANSACTION_TYPE_EXPENSE:
+		return category.Type == CATEGORY_TYPE_EXPENSE
+	case TRANSACTION_TYPE_TRANSFER:
+		return category.Type == CATEGORY_TYPE_TRANSFER
+	}
+
+	return false
+}
+
+// ToTransactionRuleApplyResponseItem returns a view-object according to transaction rule apply result
+func (r *TransactionRuleApplyResult) ToTransactionRuleApplyResponseItem() *TransactionRuleApplyResponseItem {
+	ruleIds := make([]string, len(r.RuleIds))
+
+	for i := 0; i < len(r.RuleIds); i++ {
+		ruleIds[i] = utils.Int64ToString(r.RuleIds[i])
+	}
+
+	addedTagIds := make([]string, len(r.AddedTagIds))
+
+	for i := 0; i < len(r.AddedTagIds); i++ {
+		addedTagIds[i] = utils.Int64ToString(r.AddedTagIds[i])
+	}
+
+	return &TransactionRuleApplyResponseItem{
+		TransactionId:      r.Transaction.TransactionId,
+		Time:               utils.GetUnixTimeFromTransactionTime(r.Transaction.TransactionTime),
+		Comment:            r.Transaction.Comment,
+		RuleIds:            ruleIds,
+		OriginalCategoryId: r.Transaction.CategoryId,
+		CategoryId:         r.CategoryId,
+		AddedTagIds:        addedTagIds,
+	}
+}
+
+// TransactionRuleInfoResponseSlice represents the slice data structure of TransactionRuleInfoResponse
+type TransactionRuleInfoResponseSlice []*TransactionRuleInfoResponse
+
+// Len returns the count of items
+func (s TransactionRuleInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionRuleInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionRuleInfoResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
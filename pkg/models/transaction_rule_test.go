@@ -0,0 +1,108 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionRuleGetActionTagIds(t *testing.T) {
+	rule := &TransactionRule{
+		ActionTagIds: "1,2,3",
+	}
+
+	assert.Equal(t, []int64{1, 2, 3}, rule.GetActionTagIds())
+
+	rule.ActionTagIds = ""
+	assert.Equal(t, 0, len(rule.GetActionTagIds()))
+}
+
+func TestTransactionRuleIsKeywordValid(t *testing.T) {
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_NONE}).IsKeywordValid())
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_NONE, ConditionKeyword: "a"}).IsKeywordValid())
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "a"}).IsKeywordValid())
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS}).IsKeywordValid())
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX, ConditionKeyword: "^a.*b$"}).IsKeywordValid())
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX, ConditionKeyword: "(a"}).IsKeywordValid())
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TransactionRuleKeywordMatchType(6), ConditionKeyword: "a"}).IsKeywordValid())
+}
+
+func TestTransactionRuleIsMatched_Keyword(t *testing.T) {
+	target := &TransactionRuleMatchTarget{Type: TRANSACTION_TYPE_EXPENSE, Amount: 3500, AccountId: 1, Description: "美团外卖 Lunch Order"}
+
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_NONE}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "美团"}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "lunch"}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "dinner"}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_EQUALS, ConditionKeyword: "美团外卖 lunch order"}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_EQUALS, ConditionKeyword: "美团外卖"}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_STARTS_WITH, ConditionKeyword: "美团"}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_STARTS_WITH, ConditionKeyword: "order"}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_ENDS_WITH, ConditionKeyword: "order"}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX, ConditionKeyword: "^美团.*Order$"}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX, ConditionKeyword: "^Lunch"}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_REGEX, ConditionKeyword: "(美团"}).IsMatched(target))
+}
+
+func TestTransactionRuleIsMatched_OtherConditions(t *testing.T) {
+	target := &TransactionRuleMatchTarget{Type: TRANSACTION_TYPE_EXPENSE, Amount: 3500, AccountId: 1, Description: "美团"}
+	minAmount := int64(3500)
+	maxAmount := int64(9999)
+	tooSmallMaxAmount := int64(3499)
+
+	assert.True(t, (&TransactionRule{ConditionTransactionType: TRANSACTION_TYPE_EXPENSE}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionTransactionType: TRANSACTION_TYPE_INCOME}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionAccountId: 1}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionAccountId: 2}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionMinAmount: &minAmount, ConditionMaxAmount: &maxAmount}).IsMatched(target))
+	assert.False(t, (&TransactionRule{ConditionMaxAmount: &tooSmallMaxAmount}).IsMatched(target))
+	assert.True(t, (&TransactionRule{ConditionMinAmount: &minAmount}).IsMatched(&TransactionRuleMatchTarget{Type: TRANSACTION_TYPE_EXPENSE, Amount: -3500}))
+	assert.False(t, (&TransactionRule{Disabled: true}).IsMatched(target))
+	assert.False(t, (&TransactionRule{}).IsMatched(nil))
+}
+
+func TestGetTransactionRuleActionResult(t *testing.T) {
+	maxAmount := int64(9999)
+	rules := []*TransactionRule{
+		{RuleId: 1, ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "美团", ConditionMaxAmount: &maxAmount, ActionCategoryId: 101, ActionTagIds: "201", ActionAccountId: 301},
+		{RuleId: 2, ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "外卖", ActionCategoryId: 102, ActionTagIds: "201,202", ActionAccountId: 302},
+		{RuleId: 3, ConditionKeywordMatchType: TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "taxi", ActionCategoryId: 103},
+	}
+	categoryMap := map[int64]*TransactionCategory{
+		101: {CategoryId: 101, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 100},
+		102: {CategoryId: 102, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 100},
+		103: {CategoryId: 103, Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 100},
+	}
+	accountMap := map[int64]*Account{
+		302: {AccountId: 302},
+	}
+
+	result := GetTransactionRuleActionResult(rules, &TransactionRuleMatchTarget{Type: TRANSACTION_TYPE_EXPENSE, Amount: 2500, Description: "美团外卖"}, categoryMap, accountMap)
+	assert.NotNil(t, result)
+	assert.Equal(t, []int64{1, 2}, result.RuleIds)
+	assert.Equal(t, int64(101), result.CategoryId)
+	assert.Equal(t, []int64{201, 202}, result.TagIds)
+	assert.Equal(t, int64(302), result.AccountId)
+
+	result = GetTransactionRuleActionResult(rules, &TransactionRuleMatchTarget{Type: TRANSACTION_TYPE_EXPENSE, Amount: 12500, Description: "美团外卖"}, categoryMap, accountMap)
+	assert.NotNil(t, result)
+	assert.Equal(t, []int64{2}, result.RuleIds)
+	assert.Equal(t, int64(102), result.CategoryId)
+
+	result = GetTransactionRuleActionResult(rules, &TransactionRuleMatchTarget{Type: TRANSACTION_TYPE_INCOME, Amount: 12500, Description: "taxi refund"}, categoryMap, accountMap)
+	assert.NotNil(t, result)
+	assert.Equal(t, []int64{3}, result.RuleIds)
+	assert.Equal(t, int64(0), result.CategoryId)
+
+	result = GetTransactionRuleActionResult(rules, &TransactionRuleMatchTarget{Type: TRANSACTION_TYPE_EXPENSE, Amount: 100, Description: "coffee"}, categoryMap, accountMap)
+	assert.Nil(t, result)
+}
+
+func TestIsTransactionRuleCategoryTypeMatched(t *testing.T) {
+	assert.True(t, isTransactionRuleCategoryTypeMatched(&TransactionCategory{Type: CATEGORY_TYPE_INCOME, ParentCategoryId: 1}, TRANSACTION_TYPE_INCOME))
+	assert.True(t, isTransactionRuleCategoryTypeMatched(&TransactionCategory{Type: CATEGORY_TYPE_TRANSFER, ParentCategoryId: 1}, TRANSACTION_TYPE_TRANSFER))
+	assert.False(t, isTransactionRuleCategoryTypeMatched(&TransactionCategory{Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 1}, TRANSACTION_TYPE_INCOME))
+	assert.False(t, isTransactionRuleCategoryTypeMatched(&TransactionCategory{Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: LevelOneTransactionCategoryParentId}, TRANSACTION_TYPE_EXPENSE))
+	assert.False(t, isTransactionRuleCategoryTypeMatched(&TransactionCategory{Type: CATEGORY_TYPE_EXPENSE, ParentCategoryId: 1, Hidden: true}, TRANSACTION_TYPE_EXPENSE))
+	assert.False(t, isTransactionRuleCategoryTypeMatched(nil, TRANSACTION_TYPE_EXPENSE))
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionSplit represents a split line of transaction stored in database, each split line has its own category, amount, tags and comment
+type TransactionSplit struct {
+	SplitId         int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_id) INDEX(IDX_transaction_split_uid_deleted_transaction_time) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_id) INDEX(IDX_transaction_split_uid_deleted_transaction_time) NOT NULL"`
+	TransactionId   int64  `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_id) NOT NULL"`
+	TransactionTime int64  `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_time) NOT NULL"`
+	CategoryId      int64  `xorm:"NOT NULL"`
+	Amount          int64  `xorm:"NOT NULL"`
+	TagIds          string `xorm:"VARCHAR(255) NOT NULL"`
+	Comment         string `xorm:"VARCHAR(255) NOT NULL"`
+	DisplayOrder    int32  `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// TransactionSplitRequest represents all parameters of transaction split line in transaction creation or modification request
+type TransactionSplitRequest struct {
+	CategoryId int64    `json:"categoryId,string" binding:"required,min=1"`
+	Amount     int64    `json:"amount" binding:"min=-99999999999,max=99999999999"`
+	TagIds     []string `json:"tagIds"`
+	Comment    string   `json:"comment" binding:"max=255"`
+}
+
+// TransactionSplitInfoResponse represents a view-object of transaction split line
+type TransactionSplitInfoResponse struct {
+	Id         int64    `json:"id,string"`
+	CategoryId int64    `json:"categoryId,string"`
+	Amount     int64    `json:"amount"`
+	TagIds     []string `json:"tagIds"`
+	Comment    string   `json:"comment"`
+}
+
+// GetTagIds returns all tag ids of the transaction split line
+func (t *TransactionSplit) GetTagIds() []int64 {
+	tagIds := make([]string, 0)
+
+	if t.TagIds != "" {
+		tagIds = strings.Split(t.TagIds, ",")
+	}
+
+	result, _ := utils.StringArrayToInt64Array(tagIds)
+
+	return result
+}
+
+// ToTransactionSplitInfoResponse returns a view-object according to database model
+func (t *TransactionSplit) ToTransactionSplitInfoResponse() *TransactionSplitInfoResponse {
+	return &TransactionSplitInfoResponse{
+		Id:         t.SplitId,
+		CategoryId: t.CategoryId,
+		Amount:     t.Amount,
+		TagIds:     utils.Int64ArrayToStringArray(t.GetTagIds()),
+		Comment:    t.Comment,
+	}
+}
+
+// GetTransactionSplits returns the transaction split line models according to split line requests, returns nil if split line requests are not provided
+func GetTransactionSplits(splitReqs []*TransactionSplitRequest) ([]*TransactionSplit, error) {
+	if splitReqs == nil {
+		return nil, nil
+	}
+
+	if len(splitReqs) > MaximumSplitsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManySplits
+	}
+
+	if len(splitReqs) == 1 {
+		return nil, errs.ErrTransactionHasTooFewSplits
+	}
+
+	splits := make([]*TransactionSplit, len(splitReqs))
+
+	for i := 0; i < len(splitReqs); i++ {
+		splitReq := splitReqs[i]
+
+		if splitReq == nil || splitReq.CategoryId <= 0 {
+			return nil, errs.ErrTransactionCategoryIdInvalid
+		}
+
+		tagIds, err := utils.StringArrayToInt64Array(splitReq.TagIds)
+
+		if err != nil {
+			return nil, errs.ErrTransactionTagIdInvalid
+		}
+
+		tagIds = utils.ToUniqueInt64Slice(tagIds)
+
+		if len(tagIds) > MaximumTagsCountOfTransaction {
+			return nil, errs.ErrTransactionHasTooManyTags
+		}
+
+		splits[i] = &TransactionSplit{
+			CategoryId:   splitReq.CategoryId,
+			Amount:       splitReq.Amount,
+			TagIds:       strings.Join(utils.Int64ArrayToStringArray(tagIds), ","),
+			Comment:      splitReq.Comment,
+			DisplayOrder: int32(i + 1),
+		}
+	}
+
+	return splits, nil
+}
+
+// GetTransactionSplitsTotalAmount returns the sum of amounts of all transaction split lines
+func GetTransactionSplitsTotalAmount(splits []*TransactionSplit) int64 {
+	totalAmount := int64(0)
+
+	for i := 0; i < len(splits); i++ {
+		totalAmount += splits[i].Amount
+	}
+
+	return totalAmount
+}
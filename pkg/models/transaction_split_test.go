@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestTransactionSplitGetTagIds(t *testing.T) {
+	split := &TransactionSplit{}
+	assert.Equal(t, []int64{}, split.GetTagIds())
+
+	split.TagIds = "1,2,3"
+	assert.Equal(t, []int64{1, 2, 3}, split.GetTagIds())
+}
+
+func TestTransactionSplitToTransactionSplitInfoResponse(t *testing.T) {
+	split := &TransactionSplit{
+		SplitId:    1001,
+		CategoryId: 2001,
+		Amount:     1234,
+		TagIds:     "3001,3002",
+		Comment:    "Groceries",
+	}
+
+	expectedResponse := &TransactionSplitInfoResponse{
+		Id:         1001,
+		CategoryId: 2001,
+		Amount:     1234,
+		TagIds:     []string{"3001", "3002"},
+		Comment:    "Groceries",
+	}
+
+	assert.Equal(t, expectedResponse, split.ToTransactionSplitInfoResponse())
+}
+
+func TestGetTransactionSplits(t *testing.T) {
+	splits, err := GetTransactionSplits(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, splits)
+
+	splits, err = GetTransactionSplits([]*TransactionSplitRequest{})
+	assert.Nil(t, err)
+	assert.NotNil(t, splits)
+	assert.Equal(t, 0, len(splits))
+
+	splits, err = GetTransactionSplits([]*TransactionSplitRequest{
+		{CategoryId: 2001, Amount: 1000, TagIds: []string{"3001", "3002", "3001"}, Comment: "Groceries"},
+		{CategoryId: 2002, Amount: 500},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(splits))
+	assert.Equal(t, &TransactionSplit{CategoryId: 2001, Amount: 1000, TagIds: "3001,3002", Comment: "Groceries", DisplayOrder: 1}, splits[0])
+	assert.Equal(t, &TransactionSplit{CategoryId: 2002, Amount: 500, DisplayOrder: 2}, splits[1])
+}
+
+func TestGetTransactionSplits_InvalidSplits(t *testing.T) {
+	_, err := GetTransactionSplits([]*TransactionSplitRequest{{CategoryId: 2001, Amount: 1000}})
+	assert.EqualError(t, err, errs.ErrTransactionHasTooFewSplits.Message)
+
+	splitReqs := make([]*TransactionSplitRequest, MaximumSplitsCountOfTransaction+1)
+
+	for i := 0; i < len(splitReqs); i++ {
+		splitReqs[i] = &TransactionSplitRequest{CategoryId: 2001, Amount: 1}
+	}
+
+	_, err = GetTransactionSplits(splitReqs)
+	assert.EqualError(t, err, errs.ErrTransactionHasTooManySplits.Message)
+
+	_, err = GetTransactionSplits([]*TransactionSplitRequest{{CategoryId: 2001, Amount: 1000}, {CategoryId: 0, Amount: 500}})
+	assert.EqualError(t, err, errs.ErrTransactionCategoryIdInvalid.Message)
+
+	_, err = GetTransactionSplits([]*TransactionSplitRequest{{CategoryId: 2001, Amount: 1000}, {CategoryId: 2002, Amount: 500, TagIds: []string{"abc"}}})
+	assert.EqualError(t, err, errs.ErrTransactionTagIdInvalid.Message)
+}
+
+func TestGetTransactionSplitsTotalAmount(t *testing.T) {
+	assert.Equal(t, int64(0), GetTransactionSplitsTotalAmount(nil))
+	assert.Equal(t, int64(1500), GetTransactionSplitsTotalAmount([]*TransactionSplit{{Amount: 1000}, {Amount: 500}}))
+}
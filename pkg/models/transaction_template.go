@@ -21,9 +21,15 @@ type TransactionScheduleFrequencyType byte
 
 // Transaction template schedule frequency types
 const (
-	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED TransactionScheduleFrequencyType = 0
-	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY   TransactionScheduleFrequencyType = 1
-	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY  TransactionScheduleFrequencyType = 2
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED            TransactionScheduleFrequencyType = 0
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY              TransactionScheduleFrequencyType = 1
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY             TransactionScheduleFrequencyType = 2
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY               TransactionScheduleFrequencyType = 3
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS        TransactionScheduleFrequencyType = 4
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY              TransactionScheduleFrequencyType = 5
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_LAST_DAY    TransactionScheduleFrequencyType = 6
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_NTH_WEEKDAY TransactionScheduleFrequencyType = 7
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE               TransactionScheduleFrequencyType = 8
 )
 
 // TransactionTemplate represents transaction template stored in database
@@ -42,6 +48,8 @@ type TransactionTemplate struct {
 	ScheduledEndTime           *int64                           `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
 	ScheduledAt                int16                            `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
 	ScheduledTimezoneUtcOffset int16
+	ScheduledMaxOccurrences    int32  `xorm:"NOT NULL DEFAULT 0"`
+	ScheduledOccurrenceCount   int32  `xorm:"NOT NULL DEFAULT 0"`
 	TagIds                     string `xorm:"VARCHAR(255) NOT NULL"`
 	Amount                     int64  `xorm:"NOT NULL"`
 	RelatedAccountId           int64  `xorm:"NOT NULL"`
@@ -83,6 +91,7 @@ type TransactionTemplateCreateRequest struct {
 	ScheduledStartDate         *string                           `json:"scheduledStartDate" binding:"omitempty"`
 	ScheduledEndDate           *string                           `json:"scheduledEndDate" binding:"omitempty"`
 	ScheduledTimezoneUtcOffset *int16                            `json:"utcOffset" binding:"omitempty,min=-720,max=840"`
+	ScheduledMaxOccurrences    *int32                            `json:"scheduledMaxOccurrences" binding:"omitempty,min=0,max=99999"`
 	ClientSessionId            string                            `json:"clientSessionId"`
 }
 
@@ -110,6 +119,7 @@ type TransactionTemplateModifyRequest struct {
 	ScheduledStartDate         *string                           `json:"scheduledStartDate" binding:"omitempty"`
 	ScheduledEndDate           *string                           `json:"scheduledEndDate" binding:"omitempty"`
 	ScheduledTimezoneUtcOffset *int16                            `json:"utcOffset" binding:"omitempty,min=-720,max=840"`
+	ScheduledMaxOccurrences    *int32                            `json:"scheduledMaxOccurrences" binding:"omitempty,min=0,max=99999"`
 }
 
 // TransactionTemplateHideRequest represents all parameters of transaction template hiding request
@@ -136,15 +146,17 @@ type TransactionTemplateDeleteRequest struct {
 
 type TransactionTemplateInfoResponse struct {
 	*TransactionInfoResponse
-	TemplateType           TransactionTemplateType           `json:"templateType"`
-	Name                   string                            `json:"name"`
-	ScheduledFrequencyType *TransactionScheduleFrequencyType `json:"scheduledFrequencyType,omitempty"`
-	ScheduledFrequency     *string                           `json:"scheduledFrequency,omitempty"`
-	ScheduledStartDate     *string                           `json:"scheduledStartDate" binding:"omitempty"`
-	ScheduledEndDate       *string                           `json:"scheduledEndDate" binding:"omitempty"`
-	ScheduledAt            *int16                            `json:"scheduledAt,omitempty"`
-	DisplayOrder           int32                             `json:"displayOrder"`
-	Hidden                 bool                              `json:"hidden"`
+	TemplateType             TransactionTemplateType           `json:"templateType"`
+	Name                     string                            `json:"name"`
+	ScheduledFrequencyType   *TransactionScheduleFrequencyType `json:"scheduledFrequencyType,omitempty"`
+	ScheduledFrequency       *string                           `json:"scheduledFrequency,omitempty"`
+	ScheduledStartDate       *string                           `json:"scheduledStartDate" binding:"omitempty"`
+	ScheduledEndDate         *string                           `json:"scheduledEndDate" binding:"omitempty"`
+	ScheduledAt              *int16                            `json:"scheduledAt,omitempty"`
+	ScheduledMaxOccurrences  *int32                            `json:"scheduledMaxOccurrences,omitempty"`
+	ScheduledOccurrenceCount *int32                            `json:"scheduledOccurrenceCount,omitempty"`
+	DisplayOrder             int32                             `json:"displayOrder"`
+	Hidden                   bool                              `json:"hidden"`
 }
 
 // GetTagIds returns all tag ids of the transaction template
@@ -180,6 +192,8 @@ func (t *TransactionTemplate) ToTransactionTemplateInfoResponse(serverUtcOffset
 		response.ScheduledFrequencyType = &t.ScheduledFrequencyType
 		response.ScheduledFrequency = &t.ScheduledFrequency
 		response.ScheduledAt = &t.ScheduledAt
+		response.ScheduledMaxOccurrences = &t.ScheduledMaxOccurrences
+		response.ScheduledOccurrenceCount = &t.ScheduledOccurrenceCount
 
 		templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
 
@@ -0,0 +1,686 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const maximumScheduledFrequencyLength = 100
+const maximumScheduledEveryNDays = 366
+const maximumScheduledNthWeekdayOrdinal = 5
+const scheduledLastWeekdayOrdinal = -1
+
+// TransactionScheduleRecurrenceFrequency represents the frequency of RFC 5545 style recurrence rule
+type TransactionScheduleRecurrenceFrequency string
+
+// Transaction schedule recurrence rule frequencies
+const (
+	TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_DAILY   TransactionScheduleRecurrenceFrequency = "DAILY"
+	TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_WEEKLY  TransactionScheduleRecurrenceFrequency = "WEEKLY"
+	TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_MONTHLY TransactionScheduleRecurrenceFrequency = "MONTHLY"
+	TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_YEARLY  TransactionScheduleRecurrenceFrequency = "YEARLY"
+)
+
+var transactionScheduleRecurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// TransactionScheduleRecurrenceWeekday represents a weekday with optional ordinal (e.g. 2MO, -1FR) in recurrence rule
+type TransactionScheduleRecurrenceWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// TransactionScheduleRecurrenceRule represents a parsed RFC 5545 style recurrence rule of scheduled transaction template
+type TransactionScheduleRecurrenceRule struct {
+	Frequency  TransactionScheduleRecurrenceFrequency
+	Interval   int
+	Count      int
+	UntilDate  int64
+	WeekStart  time.Weekday
+	ByMonth    []int
+	ByMonthDay []int
+	ByDay      []*TransactionScheduleRecurrenceWeekday
+}
+
+// ParseTransactionScheduleRecurrenceRule returns the parsed recurrence rule according to the RFC 5545 style rule text (e.g. FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;COUNT=26)
+func ParseTransactionScheduleRecurrenceRule(rule string) (*TransactionScheduleRecurrenceRule, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+
+	if rule == "" {
+		return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+	}
+
+	recurrenceRule := &TransactionScheduleRecurrenceRule{
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+
+	existedKeys := make(map[string]bool)
+	parts := strings.Split(rule, ";")
+
+	for i := 0; i < len(parts); i++ {
+		if parts[i] == "" {
+			continue
+		}
+
+		keyValue := strings.SplitN(parts[i], "=", 2)
+
+		if len(keyValue) != 2 || keyValue[1] == "" || existedKeys[keyValue[0]] {
+			return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+		}
+
+		key := keyValue[0]
+		value := keyValue[1]
+		existedKeys[key] = true
+
+		var err error
+
+		switch key {
+		case "FREQ":
+			recurrenceRule.Frequency = TransactionScheduleRecurrenceFrequency(value)
+
+			if recurrenceRule.Frequency != TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_DAILY &&
+				recurrenceRule.Frequency != TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_WEEKLY &&
+				recurrenceRule.Frequency != TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_MONTHLY &&
+				recurrenceRule.Frequency != TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_YEARLY {
+				return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+			}
+		case "INTERVAL":
+			recurrenceRule.Interval, err = parseRecurrenceRuleInteger(value, 1, 9999)
+		case "COUNT":
+			recurrenceRule.Count, err = parseRecurrenceRuleInteger(value, 1, 99999)
+		case "UNTIL":
+			recurrenceRule.UntilDate, err = parseRecurrenceRuleUntilDate(value)
+		case "WKST":
+			weekday, exists := transactionScheduleRecurrenceWeekdays[value]
+
+			if !exists {
+				return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+			}
+
+			recurrenceRule.WeekStart = weekday
+		case "BYMONTH":
+			recurrenceRule.ByMonth, err = parseRecurrenceRuleIntegerList(value, 1, 12, false)
+		case "BYMONTHDAY":
+			recurrenceRule.ByMonthDay, err = parseRecurrenceRuleIntegerList(value, -31, 31, true)
+		case "BYDAY":
+			recurrenceRule.ByDay, err = parseRecurrenceRuleWeekdays(value)
+		default:
+			return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if recurrenceRule.Frequency == "" {
+		return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+	}
+
+	if recurrenceRule.Count > 0 && recurrenceRule.UntilDate > 0 {
+		return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+	}
+
+	if recurrenceRule.Frequency == TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_WEEKLY && len(recurrenceRule.ByMonthDay) > 0 {
+		return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+	}
+
+	if recurrenceRule.Frequency == TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_DAILY || recurrenceRule.Frequency == TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_WEEKLY {
+		for i := 0; i < len(recurrenceRule.ByDay); i++ {
+			if recurrenceRule.ByDay[i].Ordinal != 0 {
+				return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+			}
+		}
+	}
+
+	return recurrenceRule, nil
+}
+
+// IsOccurrenceDate returns whether the specified date is an occurrence of the recurrence rule which starts from the specified start date
+func (r *TransactionScheduleRecurrenceRule) IsOccurrenceDate(date time.Time, startDate time.Time) bool {
+	days := getCivilDays(date)
+	startDays := getCivilDays(startDate)
+
+	if days < startDays {
+		return false
+	}
+
+	if r.UntilDate > 0 && days > r.UntilDate {
+		return false
+	}
+
+	interval := int64(r.Interval)
+
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_DAILY:
+		if (days-startDays)%interval != 0 {
+			return false
+		}
+	case TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_WEEKLY:
+		weekStartOffset := int64((int(startDate.Weekday()) - int(r.WeekStart) + 7) % 7)
+		firstWeekStartDays := startDays - weekStartOffset
+
+		if ((days-firstWeekStartDays)/7)%interval != 0 {
+			return false
+		}
+
+		if len(r.ByDay) < 1 && date.Weekday() != startDate.Weekday() {
+			return false
+		}
+	case TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_MONTHLY:
+		months := int64(date.Year()*12+int(date.Month())) - int64(startDate.Year()*12+int(startDate.Month()))
+
+		if months%interval != 0 {
+			return false
+		}
+
+		if len(r.ByDay) < 1 && len(r.ByMonthDay) < 1 && date.Day() != startDate.Day() {
+			return false
+		}
+	case TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_YEARLY:
+		if int64(date.Year()-startDate.Year())%interval != 0 {
+			return false
+		}
+
+		if len(r.ByDay) < 1 && len(r.ByMonthDay) < 1 {
+			if len(r.ByMonth) < 1 && date.Month() != startDate.Month() {
+				return false
+			}
+
+			if date.Day() != startDate.Day() {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+
+	if len(r.ByMonth) > 0 && !containsInt(r.ByMonth, int(date.Month())) {
+		return false
+	}
+
+	if len(r.ByMonthDay) > 0 && !isMonthDayMatched(date, r.ByMonthDay) {
+		return false
+	}
+
+	if len(r.ByDay) > 0 {
+		yearlyScope := r.Frequency == TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_YEARLY && len(r.ByMonth) < 1
+		matched := false
+
+		for i := 0; i < len(r.ByDay); i++ {
+			if isRecurrenceWeekdayMatched(date, r.ByDay[i], yearlyScope) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsScheduledFrequencyValid returns whether the scheduled frequency value is valid for the specified frequency type
+func IsScheduledFrequencyValid(frequencyType TransactionScheduleFrequencyType, frequency string, hasStartDate bool) error {
+	if len(frequency) > maximumScheduledFrequencyLength {
+		return errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	switch frequencyType {
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_LAST_DAY:
+		if frequency != "" {
+			return errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		return nil
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE:
+		recurrenceRule, err := ParseTransactionScheduleRecurrenceRule(frequency)
+
+		if err != nil {
+			return err
+		}
+
+		if !hasStartDate && recurrenceRule.isStartDateRequired() {
+			return errs.ErrScheduledTransactionStartDateRequired
+		}
+
+		return nil
+	}
+
+	values, err := parseScheduledFrequencyValues(frequency)
+
+	if err != nil || len(values) < 1 {
+		return errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	switch frequencyType {
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY:
+		for i := 0; i < len(values); i++ {
+			if values[i] < int(time.Sunday) || values[i] > int(time.Saturday) {
+				return errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		}
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY:
+		for i := 0; i < len(values); i++ {
+			if values[i] < 1 || values[i] > 31 {
+				return errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		}
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS:
+		if len(values) != 1 || values[0] < 1 || values[0] > maximumScheduledEveryNDays {
+			return errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		if !hasStartDate {
+			return errs.ErrScheduledTransactionStartDateRequired
+		}
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY:
+		for i := 0; i < len(values); i++ {
+			month := values[i] / 100
+			day := values[i] % 100
+
+			if month < 1 || month > 12 || day < 1 || day > getDaysInMonth(2000, time.Month(month)) {
+				return errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		}
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_NTH_WEEKDAY:
+		for i := 0; i < len(values); i++ {
+			ordinal, weekday := getScheduledNthWeekday(values[i])
+
+			if (ordinal != scheduledLastWeekdayOrdinal && (ordinal < 1 || ordinal > maximumScheduledNthWeekdayOrdinal)) ||
+				weekday < int(time.Sunday) || weekday > int(time.Saturday) {
+				return errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		}
+	default:
+		return errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	return nil
+}
+
+// IsScheduledDate returns whether the scheduled transaction template should create transaction on the specified date (in template timezone)
+func (t *TransactionTemplate) IsScheduledDate(date time.Time) (bool, error) {
+	var startDate *time.Time
+
+	if t.ScheduledStartTime != nil {
+		templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
+		scheduledStartDate := time.Unix(*t.ScheduledStartTime, 0).In(templateTimeZone)
+		startDate = &scheduledStartDate
+	}
+
+	if err := IsScheduledFrequencyValid(t.ScheduledFrequencyType, t.ScheduledFrequency, startDate != nil); err != nil {
+		return false, err
+	}
+
+	if t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED {
+		return false, nil
+	}
+
+	if t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE {
+		recurrenceRule, err := ParseTransactionScheduleRecurrenceRule(t.ScheduledFrequency)
+
+		if err != nil {
+			return false, err
+		}
+
+		if startDate == nil {
+			startDate = &date
+		}
+
+		return recurrenceRule.IsOccurrenceDate(date, *startDate), nil
+	}
+
+	if t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY {
+		return true, nil
+	} else if t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_LAST_DAY {
+		return date.Day() == getDaysInMonth(date.Year(), date.Month()), nil
+	}
+
+	values, err := parseScheduledFrequencyValues(t.ScheduledFrequency)
+
+	if err != nil {
+		return false, err
+	}
+
+	switch t.ScheduledFrequencyType {
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY:
+		return containsInt(values, int(date.Weekday())), nil
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY:
+		return containsInt(values, date.Day()), nil
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS:
+		days := getCivilDays(date) - getCivilDays(*startDate)
+		return days >= 0 && days%int64(values[0]) == 0, nil
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY:
+		return containsInt(values, int(date.Month())*100+date.Day()), nil
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_NTH_WEEKDAY:
+		for i := 0; i < len(values); i++ {
+			ordinal, weekday := getScheduledNthWeekday(values[i])
+
+			if isRecurrenceWeekdayMatched(date, &TransactionScheduleRecurrenceWeekday{Ordinal: ordinal, Weekday: time.Weekday(weekday)}, false) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return false, errs.ErrScheduledTransactionFrequencyInvalid
+}
+
+// GetScheduledTransactionUnixTimes returns the unix times of all transactions which the scheduled transaction template will create
+// from the beginning of the day which contains the start time in template timezone to the end time, the unix times in the created unix times are skipped,
+// the transaction time of each day is the same as the time when the scheduled transaction is created (the first time of the day in UTC plus scheduled at)
+func (t *TransactionTemplate) GetScheduledTransactionUnixTimes(startUnixTime int64, endUnixTime int64, createdUnixTimes map[int64]bool) ([]int64, error) {
+	if t.TemplateType != TRANSACTION_TEMPLATE_TYPE_SCHEDULE || t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED || startUnixTime > endUnixTime {
+		return nil, nil
+	}
+
+	maxOccurrences := t.GetScheduledMaxOccurrences()
+	remainingOccurrences := int32(-1)
+
+	if maxOccurrences > 0 {
+		remainingOccurrences = maxOccurrences - t.ScheduledOccurrenceCount
+
+		if remainingOccurrences <= 0 {
+			return nil, nil
+		}
+	}
+
+	templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
+	startTime := time.Unix(startUnixTime, 0).In(templateTimeZone)
+	todayFirstUnixTime := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, templateTimeZone).Unix()
+	todayFirstTimeInUTC := time.Unix(todayFirstUnixTime, 0).In(time.UTC)
+	dateInUTC := time.Date(todayFirstTimeInUTC.Year(), todayFirstTimeInUTC.Month(), todayFirstTimeInUTC.Day(), 0, 0, 0, 0, time.UTC)
+	unixTimes := make([]int64, 0)
+
+	for ; ; dateInUTC = dateInUTC.AddDate(0, 0, 1) {
+		transactionUnixTime := dateInUTC.Unix() + int64(t.ScheduledAt)*60
+
+		if transactionUnixTime > endUnixTime {
+			break
+		}
+
+		if transactionUnixTime < todayFirstUnixTime || createdUnixTimes[transactionUnixTime] {
+			continue
+		}
+
+		if t.ScheduledStartTime != nil && *t.ScheduledStartTime > transactionUnixTime {
+			continue
+		}
+
+		if t.ScheduledEndTime != nil && *t.ScheduledEndTime < transactionUnixTime {
+			break
+		}
+
+		isScheduledDate, err := t.IsScheduledDate(time.Unix(transactionUnixTime, 0).In(templateTimeZone))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !isScheduledDate {
+			continue
+		}
+
+		unixTimes = append(unixTimes, transactionUnixTime)
+
+		if remainingOccurrences > 0 && int32(len(unixTimes)) >= remainingOccurrences {
+			break
+		}
+	}
+
+	return unixTimes, nil
+}
+
+// GetScheduledMaxOccurrences returns the maximum count of transactions which the scheduled transaction template can create, 0 means unlimited
+func (t *TransactionTemplate) GetScheduledMaxOccurrences() int32 {
+	maxOccurrences := t.ScheduledMaxOccurrences
+
+	if t.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE {
+		recurrenceRule, err := ParseTransactionScheduleRecurrenceRule(t.ScheduledFrequency)
+
+		if err == nil && recurrenceRule.Count > 0 && (maxOccurrences <= 0 || int32(recurrenceRule.Count) < maxOccurrences) {
+			maxOccurrences = int32(recurrenceRule.Count)
+		}
+	}
+
+	if maxOccurrences < 0 {
+		return 0
+	}
+
+	return maxOccurrences
+}
+
+// HasReachedScheduledMaxOccurrences returns whether the scheduled transaction template has created all transactions it should create
+func (t *TransactionTemplate) HasReachedScheduledMaxOccurrences() bool {
+	maxOccurrences := t.GetScheduledMaxOccurrences()
+	return maxOccurrences > 0 && t.ScheduledOccurrenceCount >= maxOccurrences
+}
+
+// IsScheduledOccurrenceDefinitionChanged returns whether the frequency, start time or maximum occurrences of the scheduled transaction template differs from the specified one,
+// the occurrences created under the previous definition should not be counted towards the new one
+func (t *TransactionTemplate) IsScheduledOccurrenceDefinitionChanged(other *TransactionTemplate) bool {
+	if t.ScheduledFrequencyType != other.ScheduledFrequencyType ||
+		t.ScheduledFrequency != other.ScheduledFrequency ||
+		t.ScheduledMaxOccurrences != other.ScheduledMaxOccurrences {
+		return true
+	}
+
+	if (t.ScheduledStartTime == nil) != (other.ScheduledStartTime == nil) {
+		return true
+	}
+
+	return t.ScheduledStartTime != nil && *t.ScheduledStartTime != *other.ScheduledStartTime
+}
+
+func (r *TransactionScheduleRecurrenceRule) isStartDateRequired() bool {
+	if r.Interval > 1 {
+		return true
+	}
+
+	switch r.Frequency {
+	case TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_WEEKLY:
+		return len(r.ByDay) < 1
+	case TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_MONTHLY:
+		return len(r.ByDay) < 1 && len(r.ByMonthDay) < 1
+	case TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_YEARLY:
+		return len(r.ByDay) < 1 && len(r.ByMonthDay) < 1
+	}
+
+	return false
+}
+
+func parseScheduledFrequencyValues(frequency string) ([]int, error) {
+	if frequency == "" {
+		return nil, nil
+	}
+
+	items := strings.Split(frequency, ",")
+	values := make([]int, len(items))
+
+	for i := 0; i < len(items); i++ {
+		value, err := utils.StringToInt(items[i])
+
+		if err != nil {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+func getScheduledNthWeekday(value int) (int, int) {
+	ordinal := value / 10
+	weekday := value % 10
+
+	if weekday < 0 {
+		weekday = -weekday
+	}
+
+	return ordinal, weekday
+}
+
+func parseRecurrenceRuleInteger(value string, minValue int, maxValue int) (int, error) {
+	result, err := utils.StringToInt(value)
+
+	if err != nil || result < minValue || result > maxValue {
+		return 0, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+	}
+
+	return result, nil
+}
+
+func parseRecurrenceRuleIntegerList(value string, minValue int, maxValue int, disallowZero bool) ([]int, error) {
+	items := strings.Split(value, ",")
+	result := make([]int, len(items))
+
+	for i := 0; i < len(items); i++ {
+		item, err := parseRecurrenceRuleInteger(items[i], minValue, maxValue)
+
+		if err != nil || (disallowZero && item == 0) {
+			return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+		}
+
+		result[i] = item
+	}
+
+	return result, nil
+}
+
+func parseRecurrenceRuleUntilDate(value string) (int64, error) {
+	if len(value) > 8 {
+		if value[8] != 'T' {
+			return 0, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+		}
+
+		value = value[0:8]
+	}
+
+	date, err := time.Parse("20060102", value)
+
+	if err != nil {
+		return 0, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+	}
+
+	return getCivilDays(date), nil
+}
+
+func parseRecurrenceRuleWeekdays(value string) ([]*TransactionScheduleRecurrenceWeekday, error) {
+	items := strings.Split(value, ",")
+	result := make([]*TransactionScheduleRecurrenceWeekday, len(items))
+
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+
+		if len(item) < 2 {
+			return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+		}
+
+		weekday, exists := transactionScheduleRecurrenceWeekdays[item[len(item)-2:]]
+
+		if !exists {
+			return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+		}
+
+		ordinal := 0
+
+		if len(item) > 2 {
+			var err error
+			ordinal, err = parseRecurrenceRuleInteger(item[0:len(item)-2], -53, 53)
+
+			if err != nil || ordinal == 0 {
+				return nil, errs.ErrScheduledTransactionRecurrenceRuleInvalid
+			}
+		}
+
+		result[i] = &TransactionScheduleRecurrenceWeekday{
+			Ordinal: ordinal,
+			Weekday: weekday,
+		}
+	}
+
+	return result, nil
+}
+
+func isMonthDayMatched(date time.Time, monthDays []int) bool {
+	daysInMonth := getDaysInMonth(date.Year(), date.Month())
+
+	for i := 0; i < len(monthDays); i++ {
+		monthDay := monthDays[i]
+
+		if monthDay < 0 {
+			monthDay = daysInMonth + monthDay + 1
+		}
+
+		if monthDay == date.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRecurrenceWeekdayMatched(date time.Time, weekday *TransactionScheduleRecurrenceWeekday, yearlyScope bool) bool {
+	if date.Weekday() != weekday.Weekday {
+		return false
+	}
+
+	if weekday.Ordinal == 0 {
+		return true
+	}
+
+	var dayOfPeriod int
+	var daysInPeriod int
+
+	if yearlyScope {
+		dayOfPeriod = date.YearDay()
+		daysInPeriod = time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	} else {
+		dayOfPeriod = date.Day()
+		daysInPeriod = getDaysInMonth(date.Year(), date.Month())
+	}
+
+	if weekday.Ordinal > 0 {
+		return (dayOfPeriod-1)/7+1 == weekday.Ordinal
+	}
+
+	return (daysInPeriod-dayOfPeriod)/7+1 == -weekday.Ordinal
+}
+
+func getDaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func getCivilDays(date time.Time) int64 {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+func containsInt(values []int, value int) bool {
+	for i := 0; i < len(values); i++ {
+		if values[i] == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,318 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func getTestScheduledTemplate(frequencyType TransactionScheduleFrequencyType, frequency string, startDate string) *TransactionTemplate {
+	template := &TransactionTemplate{
+		TemplateType:               TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		ScheduledFrequencyType:     frequencyType,
+		ScheduledFrequency:         frequency,
+		ScheduledAt:                16 * 60,
+		ScheduledTimezoneUtcOffset: 480,
+	}
+
+	if startDate != "" {
+		startTime, _ := time.ParseInLocation("2006-01-02", startDate, time.FixedZone("Template Timezone", 480*60))
+		startUnixTime := startTime.Unix()
+		template.ScheduledStartTime = &startUnixTime
+	}
+
+	return template
+}
+
+func getTestScheduledDate(date string) time.Time {
+	result, _ := time.ParseInLocation("2006-01-02", date, time.FixedZone("Template Timezone", 480*60))
+	return result
+}
+
+func getTestScheduledDates(t *testing.T, template *TransactionTemplate, startDate string, endDate string) []string {
+	result := make([]string, 0)
+	date := getTestScheduledDate(startDate)
+	lastDate := getTestScheduledDate(endDate)
+
+	for !date.After(lastDate) {
+		isScheduledDate, err := template.IsScheduledDate(date)
+		assert.Nil(t, err)
+
+		if isScheduledDate {
+			result = append(result, date.Format("2006-01-02"))
+		}
+
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return result
+}
+
+func TestIsScheduledFrequencyValid(t *testing.T) {
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, "", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, "0,6", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "1,31", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, "14", true))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY, "101,229,1231", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_LAST_DAY, "", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_NTH_WEEKDAY, "11,56,-15", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=MONTHLY;BYDAY=-1FR", false))
+	assert.Nil(t, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;COUNT=26", true))
+
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, "1", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, "", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, "7", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "0", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "1", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, "7,14", true))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, "0", true))
+	assert.Equal(t, errs.ErrScheduledTransactionStartDateRequired, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, "14", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY, "230", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY, "1301", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_LAST_DAY, "31", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_NTH_WEEKDAY, "61", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_NTH_WEEKDAY, "17", false))
+	assert.Equal(t, errs.ErrScheduledTransactionRecurrenceRuleInvalid, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "INTERVAL=2", false))
+	assert.Equal(t, errs.ErrScheduledTransactionStartDateRequired, IsScheduledFrequencyValid(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", false))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, IsScheduledFrequencyValid(TransactionScheduleFrequencyType(99), "1", false))
+}
+
+func TestParseTransactionScheduleRecurrenceRule(t *testing.T) {
+	rule, err := ParseTransactionScheduleRecurrenceRule("RRULE:FREQ=YEARLY;INTERVAL=2;BYMONTH=1,7;BYMONTHDAY=-1;UNTIL=20301231T000000Z;WKST=SU")
+	assert.Nil(t, err)
+	assert.Equal(t, TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_YEARLY, rule.Frequency)
+	assert.Equal(t, 2, rule.Interval)
+	assert.Equal(t, []int{1, 7}, rule.ByMonth)
+	assert.Equal(t, []int{-1}, rule.ByMonthDay)
+	assert.Equal(t, time.Sunday, rule.WeekStart)
+	assert.Equal(t, getCivilDays(time.Date(2030, 12, 31, 0, 0, 0, 0, time.UTC)), rule.UntilDate)
+
+	rule, err = ParseTransactionScheduleRecurrenceRule("freq=monthly;byday=2mo,-1fr;count=12")
+	assert.Nil(t, err)
+	assert.Equal(t, TRANSACTION_SCHEDULE_RECURRENCE_FREQUENCY_MONTHLY, rule.Frequency)
+	assert.Equal(t, 1, rule.Interval)
+	assert.Equal(t, 12, rule.Count)
+	assert.Equal(t, 2, len(rule.ByDay))
+	assert.Equal(t, 2, rule.ByDay[0].Ordinal)
+	assert.Equal(t, time.Monday, rule.ByDay[0].Weekday)
+	assert.Equal(t, -1, rule.ByDay[1].Ordinal)
+	assert.Equal(t, time.Friday, rule.ByDay[1].Weekday)
+}
+
+func TestParseTransactionScheduleRecurrenceRule_InvalidRule(t *testing.T) {
+	invalidRules := []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20301231",
+		"FREQ=DAILY;UNTIL=2030-12-31",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=YEARLY;BYMONTH=13",
+	}
+
+	for i := 0; i < len(invalidRules); i++ {
+		_, err := ParseTransactionScheduleRecurrenceRule(invalidRules[i])
+		assert.Equal(t, errs.ErrScheduledTransactionRecurrenceRuleInvalid, err, invalidRules[i])
+	}
+}
+
+func TestTransactionTemplateIsScheduledDate_Weekly(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, "1,5", "")
+	assert.Equal(t, []string{"2024-01-01", "2024-01-05", "2024-01-08"}, getTestScheduledDates(t, template, "2024-01-01", "2024-01-10"))
+}
+
+func TestTransactionTemplateIsScheduledDate_Monthly(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "1,30", "")
+	assert.Equal(t, []string{"2024-01-30", "2024-02-01", "2024-03-01"}, getTestScheduledDates(t, template, "2024-01-15", "2024-03-15"))
+}
+
+func TestTransactionTemplateIsScheduledDate_Daily(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "", "")
+	assert.Equal(t, []string{"2024-02-28", "2024-02-29", "2024-03-01"}, getTestScheduledDates(t, template, "2024-02-28", "2024-03-01"))
+}
+
+func TestTransactionTemplateIsScheduledDate_EveryNDays(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, "14", "2024-01-05")
+	assert.Equal(t, []string{"2024-01-05", "2024-01-19", "2024-02-02", "2024-02-16"}, getTestScheduledDates(t, template, "2024-01-01", "2024-02-20"))
+}
+
+func TestTransactionTemplateIsScheduledDate_Yearly(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_YEARLY, "229,315", "")
+	assert.Equal(t, []string{"2023-03-15", "2024-02-29", "2024-03-15"}, getTestScheduledDates(t, template, "2023-01-01", "2024-12-31"))
+}
+
+func TestTransactionTemplateIsScheduledDate_MonthlyLastDay(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_LAST_DAY, "", "")
+	assert.Equal(t, []string{"2023-02-28", "2023-03-31", "2023-04-30"}, getTestScheduledDates(t, template, "2023-02-01", "2023-05-15"))
+	assert.Equal(t, []string{"2024-02-29"}, getTestScheduledDates(t, template, "2024-02-01", "2024-03-15"))
+}
+
+func TestTransactionTemplateIsScheduledDate_MonthlyNthWeekday(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY_NTH_WEEKDAY, "21,-15", "")
+	assert.Equal(t, []string{"2024-01-08", "2024-01-26", "2024-02-12", "2024-02-23"}, getTestScheduledDates(t, template, "2024-01-01", "2024-02-29"))
+}
+
+func TestTransactionTemplateIsScheduledDate_RecurrenceRuleBiweekly(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR", "2024-01-03")
+	assert.Equal(t, []string{"2024-01-05", "2024-01-19", "2024-02-02"}, getTestScheduledDates(t, template, "2024-01-01", "2024-02-10"))
+}
+
+func TestTransactionTemplateIsScheduledDate_RecurrenceRuleMonthly(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=MONTHLY;INTERVAL=2", "2024-01-31")
+	assert.Equal(t, []string{"2024-01-31", "2024-03-31", "2024-05-31", "2024-07-31"}, getTestScheduledDates(t, template, "2024-01-01", "2024-12-31"))
+
+	template = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=MONTHLY;BYMONTHDAY=-2", "")
+	assert.Equal(t, []string{"2024-02-28", "2024-03-30"}, getTestScheduledDates(t, template, "2024-02-01", "2024-03-31"))
+}
+
+func TestTransactionTemplateIsScheduledDate_RecurrenceRuleYearly(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=YEARLY", "2023-06-15")
+	assert.Equal(t, []string{"2023-06-15", "2024-06-15", "2025-06-15"}, getTestScheduledDates(t, template, "2023-01-01", "2025-12-31"))
+
+	template = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "")
+	assert.Equal(t, []string{"2024-11-28"}, getTestScheduledDates(t, template, "2024-01-01", "2024-12-31"))
+
+	template = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=YEARLY;BYDAY=-1MO", "")
+	assert.Equal(t, []string{"2024-12-30"}, getTestScheduledDates(t, template, "2024-01-01", "2024-12-31"))
+}
+
+func TestTransactionTemplateIsScheduledDate_RecurrenceRuleUntil(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=DAILY;INTERVAL=3;UNTIL=20240110", "2024-01-01")
+	assert.Equal(t, []string{"2024-01-01", "2024-01-04", "2024-01-07", "2024-01-10"}, getTestScheduledDates(t, template, "2023-12-25", "2024-01-31"))
+}
+
+func TestTransactionTemplateIsScheduledDate_Disabled(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, "", "")
+	assert.Equal(t, []string{}, getTestScheduledDates(t, template, "2024-01-01", "2024-01-31"))
+}
+
+func TestTransactionTemplateIsScheduledDate_InvalidFrequency(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_EVERY_N_DAYS, "14", "")
+	_, err := template.IsScheduledDate(getTestScheduledDate("2024-01-01"))
+	assert.Equal(t, errs.ErrScheduledTransactionStartDateRequired, err)
+
+	template = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, "", "")
+	_, err = template.IsScheduledDate(getTestScheduledDate("2024-01-01"))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+}
+
+func TestTransactionTemplateHasReachedScheduledMaxOccurrences(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "1", "")
+	template.ScheduledOccurrenceCount = 100
+	assert.Equal(t, int32(0), template.GetScheduledMaxOccurrences())
+	assert.False(t, template.HasReachedScheduledMaxOccurrences())
+
+	template.ScheduledMaxOccurrences = 12
+	template.ScheduledOccurrenceCount = 11
+	assert.False(t, template.HasReachedScheduledMaxOccurrences())
+
+	template.ScheduledOccurrenceCount = 12
+	assert.True(t, template.HasReachedScheduledMaxOccurrences())
+
+	template = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;BYDAY=FR;COUNT=3", "")
+	template.ScheduledMaxOccurrences = 10
+	template.ScheduledOccurrenceCount = 3
+	assert.Equal(t, int32(3), template.GetScheduledMaxOccurrences())
+	assert.True(t, template.HasReachedScheduledMaxOccurrences())
+}
+
+func TestTransactionTemplateIsScheduledOccurrenceDefinitionChanged(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;BYDAY=FR", "2024-01-10")
+	template.ScheduledMaxOccurrences = 10
+
+	newTemplate := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;BYDAY=FR", "2024-01-10")
+	newTemplate.ScheduledMaxOccurrences = 10
+	newTemplate.ScheduledAt = template.ScheduledAt + 60
+	assert.False(t, newTemplate.IsScheduledOccurrenceDefinitionChanged(template))
+
+	newTemplate.ScheduledFrequency = "FREQ=WEEKLY;BYDAY=MO"
+	assert.True(t, newTemplate.IsScheduledOccurrenceDefinitionChanged(template))
+
+	newTemplate = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;BYDAY=FR", "2024-01-10")
+	newTemplate.ScheduledMaxOccurrences = 20
+	assert.True(t, newTemplate.IsScheduledOccurrenceDefinitionChanged(template))
+
+	newTemplate = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;BYDAY=FR", "2024-02-10")
+	newTemplate.ScheduledMaxOccurrences = 10
+	assert.True(t, newTemplate.IsScheduledOccurrenceDefinitionChanged(template))
+
+	newTemplate = getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE, "FREQ=WEEKLY;BYDAY=FR", "")
+	newTemplate.ScheduledMaxOccurrences = 10
+	assert.True(t, newTemplate.IsScheduledOccurrenceDefinitionChanged(template))
+}
+
+func TestTransactionTemplateGetScheduledTransactionUnixTimes(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "1,15", "2024-01-10")
+	template.ScheduledMaxOccurrences = 5
+	template.ScheduledOccurrenceCount = 2
+
+	unixTimes, err := template.GetScheduledTransactionUnixTimes(getTestScheduledDate("2024-01-01").Unix(), getTestScheduledDate("2024-12-31").Unix(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{
+		getTestScheduledDate("2024-01-15").Unix(),
+		getTestScheduledDate("2024-02-01").Unix(),
+		getTestScheduledDate("2024-02-15").Unix(),
+	}, unixTimes)
+
+	endUnixTime := getTestScheduledDate("2024-02-10").Unix()
+	template.ScheduledEndTime = &endUnixTime
+
+	unixTimes, err = template.GetScheduledTransactionUnixTimes(getTestScheduledDate("2024-01-01").Unix()+3600, getTestScheduledDate("2024-12-31").Unix(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{
+		getTestScheduledDate("2024-01-15").Unix(),
+		getTestScheduledDate("2024-02-01").Unix(),
+	}, unixTimes)
+
+	template.ScheduledOccurrenceCount = 5
+
+	unixTimes, err = template.GetScheduledTransactionUnixTimes(getTestScheduledDate("2024-01-01").Unix(), getTestScheduledDate("2024-12-31").Unix(), nil)
+	assert.Nil(t, err)
+	assert.Nil(t, unixTimes)
+}
+
+func TestTransactionTemplateGetScheduledTransactionUnixTimes_StartFromBeginningOfToday(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "", "")
+
+	unixTimes, err := template.GetScheduledTransactionUnixTimes(getTestScheduledDate("2024-01-01").Unix()+3600, getTestScheduledDate("2024-01-03").Unix(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{
+		getTestScheduledDate("2024-01-01").Unix(),
+		getTestScheduledDate("2024-01-02").Unix(),
+		getTestScheduledDate("2024-01-03").Unix(),
+	}, unixTimes)
+
+	template.ScheduledMaxOccurrences = 3
+	template.ScheduledOccurrenceCount = 1
+
+	unixTimes, err = template.GetScheduledTransactionUnixTimes(getTestScheduledDate("2024-01-01").Unix()+3600, getTestScheduledDate("2024-01-05").Unix(), map[int64]bool{
+		getTestScheduledDate("2024-01-01").Unix(): true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{
+		getTestScheduledDate("2024-01-02").Unix(),
+		getTestScheduledDate("2024-01-03").Unix(),
+	}, unixTimes)
+}
+
+func TestTransactionTemplateGetScheduledTransactionUnixTimes_ScheduledAt(t *testing.T) {
+	template := getTestScheduledTemplate(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "", "")
+	template.ScheduledAt = 18 * 60
+
+	// the transactions are created at 18:00 UTC, which is 02:00 of the next day in template timezone
+	unixTimes, err := template.GetScheduledTransactionUnixTimes(getTestScheduledDate("2024-01-01").Unix()+3600, getTestScheduledDate("2024-01-03").Unix(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{
+		getTestScheduledDate("2024-01-01").Unix() + 2*3600,
+		getTestScheduledDate("2024-01-02").Unix() + 2*3600,
+	}, unixTimes)
+}
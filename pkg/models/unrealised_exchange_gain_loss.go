@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MaximumUnrealisedExchangeGainLossDays represents the maximum days of unrealised exchange gain and loss report
+const MaximumUnrealisedExchangeGainLossDays = 3660
+
+// UnrealisedExchangeGainLossRequest represents all parameters of unrealised exchange gain and loss request
+type UnrealisedExchangeGainLossRequest struct {
+	StartTime int64 `form:"start_time" binding:"required,min=1"`
+	EndTime   int64 `form:"end_time" binding:"required,min=1"`
+}
+
+// UnrealisedExchangeGainLossResponse represents the default currency values of all foreign currency accounts within the time range
+type UnrealisedExchangeGainLossResponse struct {
+	Currency string                                       `json:"currency"`
+	Accounts []*AccountUnrealisedExchangeGainLossResponse `json:"accounts"`
+}
+
+// AccountUnrealisedExchangeGainLossResponse represents the default currency value changes of a foreign currency account within the time range
+type AccountUnrealisedExchangeGainLossResponse struct {
+	AccountId        int64                                             `json:"accountId,string"`
+	Currency         string                                            `json:"currency"`
+	OpeningBalance   int64                                             `json:"openingBalance"`
+	ClosingBalance   int64                                             `json:"closingBalance"`
+	OpeningValue     int64                                             `json:"openingValue"`
+	ClosingValue     int64                                             `json:"closingValue"`
+	FlowValue        int64                                             `json:"flowValue"`
+	RevaluationValue int64                                             `json:"revaluationValue"`
+	Items            []*AccountDailyUnrealisedExchangeGainLossResponse `json:"items"`
+}
+
+// AccountDailyUnrealisedExchangeGainLossResponse represents the default currency value changes of a foreign currency account on a specified date
+type AccountDailyUnrealisedExchangeGainLossResponse struct {
+	Year             int32 `json:"year"`
+	Month            int32 `json:"month"`
+	Day              int32 `json:"day"`
+	ClosingBalance   int64 `json:"closingBalance"`
+	ClosingValue     int64 `json:"closingValue"`
+	FlowValue        int64 `json:"flowValue"`
+	RevaluationValue int64 `json:"revaluationValue"`
+}
+
+// CalculateAccountUnrealisedExchangeGainLoss returns the daily default currency values of the account from the start date to the end date,
+// the value change on each date is split into the flow value (balance changes by transactions at the exchange rate on that date)
+// and the revaluation value (opening balance value changes by exchange rate movement from the previous date),
+// the daily balances only contain the dates which have transactions, the closing balance of the previous date is used for other dates
+func CalculateAccountUnrealisedExchangeGainLoss(account *Account, targetCurrency string, dailyBalances map[int32]*TransactionWithAccountBalance, startTime time.Time, endTime time.Time, exchangeRates *HistoricalExchangeRateMap) (*AccountUnrealisedExchangeGainLossResponse, bool) {
+	result := &AccountUnrealisedExchangeGainLossResponse{
+		AccountId: account.AccountId,
+		Currency:  account.Currency,
+		Items:     make([]*AccountDailyUnrealisedExchangeGainLossResponse, 0),
+	}
+
+	previousClosingBalance := int64(0)
+	previousClosingValue := int64(0)
+
+	for date := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location()); !date.After(endTime); date = date.AddDate(0, 0, 1) {
+		yearMonthDay := utils.FormatUnixTimeToNumericYearMonthDay(date.Unix(), date.Location())
+		openingBalance := previousClosingBalance
+		closingBalance := previousClosingBalance
+
+		if dailyBalance, exists := dailyBalances[yearMonthDay]; exists {
+			openingBalance = dailyBalance.AccountOpeningBalance
+			closingBalance = dailyBalance.AccountClosingBalance
+		}
+
+		openingValue, ok := exchangeRates.ExchangeAmount(openingBalance, account.Currency, targetCurrency, yearMonthDay)
+
+		if !ok {
+			return nil, false
+		}
+
+		closingValue, ok := exchangeRates.ExchangeAmount(closingBalance, account.Currency, targetCurrency, yearMonthDay)
+
+		if !ok {
+			return nil, false
+		}
+
+		revaluationValue := int64(0)
+
+		if len(result.Items) < 1 {
+			result.OpeningBalance = openingBalance
+			result.OpeningValue = openingValue
+		} else {
+			revaluationValue = openingValue - previousClosingValue
+		}
+
+		flowValue := closingValue - openingValue
+
+		result.FlowValue += flowValue
+		result.RevaluationValue += revaluationValue
+		result.Items = append(result.Items, &AccountDailyUnrealisedExchangeGainLossResponse{
+			Year:             yearMonthDay / 10000,
+			Month:            (yearMonthDay % 10000) / 100,
+			Day:              yearMonthDay % 100,
+			ClosingBalance:   closingBalance,
+			ClosingValue:     closingValue,
+			FlowValue:        flowValue,
+			RevaluationValue: revaluationValue,
+		})
+
+		previousClosingBalance = closingBalance
+		previousClosingValue = closingValue
+	}
+
+	result.ClosingBalance = previousClosingBalance
+	result.ClosingValue = previousClosingValue
+
+	return result, true
+}
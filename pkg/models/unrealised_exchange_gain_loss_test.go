@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateAccountUnrealisedExchangeGainLoss(t *testing.T) {
+	histories := []*ExchangeRateHistory{
+		{RateDate: 20240101, Currency: "USD", BaseCurrency: "EUR", Rate: "1"},
+		{RateDate: 20240102, Currency: "USD", BaseCurrency: "EUR", Rate: "0.5"},
+		{RateDate: 20240104, Currency: "USD", BaseCurrency: "EUR", Rate: "0.25"},
+	}
+	dailyBalances := map[int32]*TransactionWithAccountBalance{
+		20240101: {AccountOpeningBalance: 1000, AccountClosingBalance: 1000},
+		20240102: {AccountOpeningBalance: 1000, AccountClosingBalance: 1500},
+		20240104: {AccountOpeningBalance: 1500, AccountClosingBalance: 1000},
+	}
+	account := &Account{AccountId: 1, Currency: "USD"}
+	startTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, time.January, 4, 12, 0, 0, 0, time.UTC)
+
+	result, ok := CalculateAccountUnrealisedExchangeGainLoss(account, "EUR", dailyBalances, startTime, endTime, NewHistoricalExchangeRateMap(histories, nil))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), result.OpeningBalance)
+	assert.Equal(t, int64(1000), result.ClosingBalance)
+	assert.Equal(t, int64(1000), result.OpeningValue)
+	assert.Equal(t, int64(4000), result.ClosingValue)
+	assert.Equal(t, int64(-1000), result.FlowValue)
+	assert.Equal(t, int64(4000), result.RevaluationValue)
+	assert.Equal(t, result.ClosingValue-result.OpeningValue, result.FlowValue+result.RevaluationValue)
+
+	assert.Equal(t, 4, len(result.Items))
+
+	assert.Equal(t, int32(1), result.Items[0].Day)
+	assert.Equal(t, int64(1000), result.Items[0].ClosingValue)
+	assert.Equal(t, int64(0), result.Items[0].FlowValue)
+	assert.Equal(t, int64(0), result.Items[0].RevaluationValue)
+
+	assert.Equal(t, int32(2), result.Items[1].Day)
+	assert.Equal(t, int64(3000), result.Items[1].ClosingValue)
+	assert.Equal(t, int64(1000), result.Items[1].FlowValue)
+	assert.Equal(t, int64(1000), result.Items[1].RevaluationValue)
+
+	// no transactions on 2024-01-03, the previous closing balance and exchange rate are used
+	assert.Equal(t, int32(3), result.Items[2].Day)
+	assert.Equal(t, int64(1500), result.Items[2].ClosingBalance)
+	assert.Equal(t, int64(3000), result.Items[2].ClosingValue)
+	assert.Equal(t, int64(0), result.Items[2].FlowValue)
+	assert.Equal(t, int64(0), result.Items[2].RevaluationValue)
+
+	assert.Equal(t, int32(4), result.Items[3].Day)
+	assert.Equal(t, int64(4000), result.Items[3].ClosingValue)
+	assert.Equal(t, int64(-2000), result.Items[3].FlowValue)
+	assert.Equal(t, int64(3000), result.Items[3].RevaluationValue)
+}
+
+func TestCalculateAccountUnrealisedExchangeGainLoss_ExchangeRateNotFound(t *testing.T) {
+	account := &Account{AccountId: 1, Currency: "JPY"}
+	startTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	_, ok := CalculateAccountUnrealisedExchangeGainLoss(account, "EUR", nil, startTime, endTime, NewHistoricalExchangeRateMap(nil, ExchangeRateMap{"EUR": 1, "USD": 1.1}))
+	assert.False(t, ok)
+}
@@ -80,6 +80,33 @@ func (s AmountColorType) String() string {
 	}
 }
 
+// SummaryEmailFrequency represents the frequency of summary report email
+type SummaryEmailFrequency byte
+
+// Summary Email Frequencies
+const (
+	SUMMARY_EMAIL_FREQUENCY_NONE    SummaryEmailFrequency = 0
+	SUMMARY_EMAIL_FREQUENCY_WEEKLY  SummaryEmailFrequency = 1
+	SUMMARY_EMAIL_FREQUENCY_MONTHLY SummaryEmailFrequency = 2
+	SUMMARY_EMAIL_FREQUENCY_INVALID SummaryEmailFrequency = 255
+)
+
+// String returns a textual representation of the summary email frequency enum
+func (f SummaryEmailFrequency) String() string {
+	switch f {
+	case SUMMARY_EMAIL_FREQUENCY_NONE:
+		return "None"
+	case SUMMARY_EMAIL_FREQUENCY_WEEKLY:
+		return "Weekly"
+	case SUMMARY_EMAIL_FREQUENCY_MONTHLY:
+		return "Monthly"
+	case SUMMARY_EMAIL_FREQUENCY_INVALID:
+		return "Invalid"
+	default:
+		return fmt.Sprintf("Invalid(%d)", int(f))
+	}
+}
+
 // User represents user data stored in database
 type User struct {
 	Uid                   int64  `xorm:"PK"`
@@ -110,6 +137,8 @@ type User struct {
 	CoordinateDisplayType core.CoordinateDisplayType `xorm:"TINYINT"`
 	ExpenseAmountColor    AmountColorType            `xorm:"TINYINT"`
 	IncomeAmountColor     AmountColorType            `xorm:"TINYINT"`
+	SummaryEmailFrequency SummaryEmailFrequency      `xorm:"TINYINT"`
+	SummaryEmailUtcOffset int16                      `xorm:"SMALLINT"`
 	FeatureRestriction    core.UserFeatureRestrictions
 	Disabled              bool
 	Deleted               bool `xorm:"NOT NULL"`
@@ -148,6 +177,7 @@ type UserBasicInfo struct {
 	CoordinateDisplayType core.CoordinateDisplayType `json:"coordinateDisplayType"`
 	ExpenseAmountColor    AmountColorType            `json:"expenseAmountColor"`
 	IncomeAmountColor     AmountColorType            `json:"incomeAmountColor"`
+	SummaryEmailFrequency SummaryEmailFrequency      `json:"summaryEmailFrequency"`
 	EmailVerified         bool                       `json:"emailVerified"`
 }
 
@@ -214,6 +244,7 @@ type UserProfileUpdateRequest struct {
 	CoordinateDisplayType *core.CoordinateDisplayType `json:"coordinateDisplayType" binding:"omitempty,min=0,max=6"`
 	ExpenseAmountColor    *AmountColorType            `json:"expenseAmountColor" binding:"omitempty,min=0,max=4"`
 	IncomeAmountColor     *AmountColorType            `json:"incomeAmountColor" binding:"omitempty,min=0,max=4"`
+	SummaryEmailFrequency *SummaryEmailFrequency      `json:"summaryEmailFrequency" binding:"omitempty,min=0,max=2"`
 }
 
 // UserProfileUpdateResponse represents the data returns to frontend after updating profile
@@ -270,6 +301,11 @@ func (u *User) CanEditTransactionByTransactionTime(transactionTime int64, client
 	return false
 }
 
+// GetSummaryEmailTimezone returns the timezone which the summary report periods of this user are calculated in
+func (u *User) GetSummaryEmailTimezone() *time.Location {
+	return time.FixedZone("Summary Email Timezone", int(u.SummaryEmailUtcOffset)*60)
+}
+
 // ToUserBasicInfo returns a user basic view-object according to database model
 func (u *User) ToUserBasicInfo(avatarProvider core.UserAvatarProviderType, avatarUrl string) *UserBasicInfo {
 	fiscalYearStart := u.FiscalYearStart
@@ -305,6 +341,7 @@ func (u *User) ToUserBasicInfo(avatarProvider core.UserAvatarProviderType, avata
 		CoordinateDisplayType: u.CoordinateDisplayType,
 		ExpenseAmountColor:    u.ExpenseAmountColor,
 		IncomeAmountColor:     u.IncomeAmountColor,
+		SummaryEmailFrequency: u.SummaryEmailFrequency,
 		EmailVerified:         u.EmailVerified,
 	}
 }
@@ -112,3 +112,17 @@ func TestUserCanEditTransactionByTransactionTime_ScopeIsThisYearOrLater(t *testi
 	assert.Equal(t, true, user.CanEditTransactionByTransactionTime(utils.GetMinTransactionTimeFromUnixTime(thisYearLastDatetime.Unix()), timezone))
 	assert.Equal(t, false, user.CanEditTransactionByTransactionTime(utils.GetMinTransactionTimeFromUnixTime(lastYearLastDatetime.Unix()), timezone))
 }
+
+func TestUserGetSummaryEmailTimezone(t *testing.T) {
+	user := &User{
+		SummaryEmailFrequency: SUMMARY_EMAIL_FREQUENCY_MONTHLY,
+		SummaryEmailUtcOffset: 480,
+	}
+
+	// it is still the last day of February in UTC, but it is already the first day of March in user timezone
+	currentTime := time.Date(2024, 2, 29, 16, 30, 0, 0, time.UTC)
+	startTime, endTime, ok := GetSummaryReportPeriod(user.SummaryEmailFrequency, currentTime.In(user.GetSummaryEmailTimezone()), core.WEEKDAY_MONDAY)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 31, 16, 0, 0, 0, time.UTC).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2024, 2, 29, 15, 59, 59, 0, time.UTC).Unix(), endTime.Unix())
+}
@@ -0,0 +1,330 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// WebhookSignatureHeaderName represents the header name of the webhook payload signature
+const WebhookSignatureHeaderName = "X-Ezbookkeeping-Signature"
+
+// WebhookTimestampHeaderName represents the header name of the unix time when the webhook payload is signed
+const WebhookTimestampHeaderName = "X-Ezbookkeeping-Timestamp"
+
+// WebhookEventHeaderName represents the header name of the webhook event name
+const WebhookEventHeaderName = "X-Ezbookkeeping-Event"
+
+// WebhookDeliveryHeaderName represents the header name of the webhook delivery id
+const WebhookDeliveryHeaderName = "X-Ezbookkeeping-Delivery"
+
+// MaximumWebhookCountPerUser represents the maximum count of webhooks which one user can register
+const MaximumWebhookCountPerUser = 10
+
+// MaximumWebhookDeliveryLastErrorLength represents the maximum length of the last error message of webhook delivery
+const MaximumWebhookDeliveryLastErrorLength = 255
+
+const webhookDeliveryMinRetryDelay = time.Minute
+const webhookDeliveryMaxRetryDelay = 6 * time.Hour
+
+// WebhookEventType represents the event type which triggers webhook
+type WebhookEventType byte
+
+// Webhook event types
+const (
+	WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED           WebhookEventType = 1
+	WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED          WebhookEventType = 2
+	WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED           WebhookEventType = 3
+	WEBHOOK_EVENT_TYPE_TRANSACTIONS_IMPORTED         WebhookEventType = 4
+	WEBHOOK_EVENT_TYPE_SCHEDULED_TRANSACTION_CREATED WebhookEventType = 5
+)
+
+// String returns a textual representation of the webhook event type enum
+func (t WebhookEventType) String() string {
+	switch t {
+	case WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED:
+		return "transaction.created"
+	case WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED:
+		return "transaction.modified"
+	case WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED:
+		return "transaction.deleted"
+	case WEBHOOK_EVENT_TYPE_TRANSACTIONS_IMPORTED:
+		return "transactions.imported"
+	case WEBHOOK_EVENT_TYPE_SCHEDULED_TRANSACTION_CREATED:
+		return "scheduled_transaction.created"
+	default:
+		return fmt.Sprintf("Invalid(%d)", int(t))
+	}
+}
+
+// WebhookDeliveryStatus represents the delivery status of webhook event
+type WebhookDeliveryStatus byte
+
+// Webhook delivery statuses
+const (
+	WEBHOOK_DELIVERY_STATUS_PENDING   WebhookDeliveryStatus = 1
+	WEBHOOK_DELIVERY_STATUS_SUCCEEDED WebhookDeliveryStatus = 2
+	WEBHOOK_DELIVERY_STATUS_FAILED    WebhookDeliveryStatus = 3
+)
+
+// Webhook represents user-registered webhook endpoint which receives signed event payloads, stored in database
+type Webhook struct {
+	WebhookId       int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_webhook_uid_deleted) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_webhook_uid_deleted) NOT NULL"`
+	Name            string `xorm:"VARCHAR(64) NOT NULL"`
+	Url             string `xorm:"VARCHAR(1024) NOT NULL"`
+	Secret          string `xorm:"VARCHAR(64) NOT NULL"`
+	EventTypes      string `xorm:"VARCHAR(64) NOT NULL"`
+	Disabled        bool   `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// WebhookDelivery represents the delivery log of a webhook event, stored in database
+type WebhookDelivery struct {
+	DeliveryId             int64                 `xorm:"PK"`
+	Uid                    int64                 `xorm:"INDEX(IDX_webhook_delivery_uid_deleted_webhook_id_time) NOT NULL"`
+	Deleted                bool                  `xorm:"INDEX(IDX_webhook_delivery_uid_deleted_webhook_id_time) INDEX(IDX_webhook_delivery_deleted_status_next_attempt_time) NOT NULL"`
+	WebhookId              int64                 `xorm:"INDEX(IDX_webhook_delivery_uid_deleted_webhook_id_time) NOT NULL"`
+	EventType              WebhookEventType      `xorm:"NOT NULL"`
+	Payload                string                `xorm:"TEXT NOT NULL"`
+	Status                 WebhookDeliveryStatus `xorm:"INDEX(IDX_webhook_delivery_deleted_status_next_attempt_time) NOT NULL"`
+	Attempts               int32                 `xorm:"NOT NULL"`
+	NextAttemptUnixTime    int64                 `xorm:"INDEX(IDX_webhook_delivery_deleted_status_next_attempt_time) NOT NULL"`
+	LastResponseStatusCode int32                 `xorm:"NOT NULL"`
+	LastError              string                `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime        int64                 `xorm:"INDEX(IDX_webhook_delivery_uid_deleted_webhook_id_time)"`
+	UpdatedUnixTime        int64
+	DeletedUnixTime        int64
+}
+
+// WebhookEventPayload represents the json payload which is sent to webhook endpoint
+type WebhookEventPayload struct {
+	Id        int64  `json:"id,string"`
+	Event     string `json:"event"`
+	CreatedAt int64  `json:"createdAt"`
+	Data      any    `json:"data"`
+}
+
+// WebhookTransactionEventData represents the transaction data of transaction created, modified, deleted and scheduled transaction created events
+type WebhookTransactionEventData struct {
+	Id                   int64           `json:"id,string"`
+	Type                 TransactionType `json:"type"`
+	CategoryId           int64           `json:"categoryId,string"`
+	Time                 int64           `json:"time"`
+	UtcOffset            int16           `json:"utcOffset"`
+	SourceAccountId      int64           `json:"sourceAccountId,string"`
+	DestinationAccountId int64           `json:"destinationAccountId,string,omitempty"`
+	SourceAmount         int64           `json:"sourceAmount"`
+	DestinationAmount    int64           `json:"destinationAmount,omitempty"`
+	HideAmount           bool            `json:"hideAmount"`
+	TagIds               []string        `json:"tagIds"`
+	Comment              string          `json:"comment"`
+}
+
+// WebhookTransactionsImportedEventData represents the data of transactions imported event
+type WebhookTransactionsImportedEventData struct {
+	Count int `json:"count"`
+}
+
+// WebhookGetRequest represents all parameters of webhook getting request
+type WebhookGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// WebhookCreateRequest represents all parameters of webhook creation request
+type WebhookCreateRequest struct {
+	Name       string             `json:"name" binding:"required,notBlank,max=64"`
+	Url        string             `json:"url" binding:"required,notBlank,max=1024"`
+	EventTypes []WebhookEventType `json:"eventTypes" binding:"required,min=1,max=5,dive,min=1,max=5"`
+	Disabled   bool               `json:"disabled"`
+}
+
+// WebhookModifyRequest represents all parameters of webhook modification request
+type WebhookModifyRequest struct {
+	Id               int64              `json:"id,string" binding:"required,min=1"`
+	Name             string             `json:"name" binding:"required,notBlank,max=64"`
+	Url              string             `json:"url" binding:"required,notBlank,max=1024"`
+	EventTypes       []WebhookEventType `json:"eventTypes" binding:"required,min=1,max=5,dive,min=1,max=5"`
+	Disabled         bool               `json:"disabled"`
+	RegenerateSecret bool               `json:"regenerateSecret"`
+}
+
+// WebhookDeleteRequest represents all parameters of webhook deleting request
+type WebhookDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// WebhookDeliveryListRequest represents all parameters of webhook delivery log listing request
+type WebhookDeliveryListRequest struct {
+	Id    int64 `form:"id,string" binding:"required,min=1"`
+	Count int32 `form:"count" binding:"omitempty,min=1,max=50"`
+}
+
+// WebhookInfoResponse represents a view-object of webhook
+type WebhookInfoResponse struct {
+	Id         int64              `json:"id,string"`
+	Name       string             `json:"name"`
+	Url        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	Disabled   bool               `json:"disabled"`
+}
+
+// WebhookDeliveryInfoResponse represents a view-object of webhook delivery log
+type WebhookDeliveryInfoResponse struct {
+	Id                     int64                 `json:"id,string"`
+	WebhookId              int64                 `json:"webhookId,string"`
+	EventType              WebhookEventType      `json:"eventType"`
+	Payload                string                `json:"payload"`
+	Status                 WebhookDeliveryStatus `json:"status"`
+	Attempts               int32                 `json:"attempts"`
+	NextAttemptTime        int64                 `json:"nextAttemptTime,omitempty"`
+	LastResponseStatusCode int32                 `json:"lastResponseStatusCode"`
+	LastError              string                `json:"lastError"`
+	CreatedTime            int64                 `json:"createdTime"`
+}
+
+// GetEventTypes returns all event types which the webhook subscribes
+func (w *Webhook) GetEventTypes() []WebhookEventType {
+	eventTypes := make([]WebhookEventType, 0)
+
+	if w.EventTypes == "" {
+		return eventTypes
+	}
+
+	items := strings.Split(w.EventTypes, ",")
+
+	for i := 0; i < len(items); i++ {
+		eventType, err := utils.StringToInt(items[i])
+
+		if err != nil || eventType < int(WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED) || eventType > int(WEBHOOK_EVENT_TYPE_SCHEDULED_TRANSACTION_CREATED) {
+			continue
+		}
+
+		eventTypes = append(eventTypes, WebhookEventType(eventType))
+	}
+
+	return eventTypes
+}
+
+// SetEventTypes sets the event types which the webhook subscribes, the duplicate event types will be removed
+func (w *Webhook) SetEventTypes(eventTypes []WebhookEventType) {
+	existedEventTypes := make(map[WebhookEventType]bool, len(eventTypes))
+	items := make([]string, 0, len(eventTypes))
+
+	for i := 0; i < len(eventTypes); i++ {
+		if existedEventTypes[eventTypes[i]] {
+			continue
+		}
+
+		existedEventTypes[eventTypes[i]] = true
+		items = append(items, utils.IntToString(int(eventTypes[i])))
+	}
+
+	w.EventTypes = strings.Join(items, ",")
+}
+
+// IsSubscribed returns whether the webhook is enabled and subscribes the specified event type
+func (w *Webhook) IsSubscribed(eventType WebhookEventType) bool {
+	if w.Disabled {
+		return false
+	}
+
+	eventTypes := w.GetEventTypes()
+
+	for i := 0; i < len(eventTypes); i++ {
+		if eventTypes[i] == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToWebhookInfoResponse returns a view-object according to database model
+func (w *Webhook) ToWebhookInfoResponse() *WebhookInfoResponse {
+	return &WebhookInfoResponse{
+		Id:         w.WebhookId,
+		Name:       w.Name,
+		Url:        w.Url,
+		Secret:     w.Secret,
+		EventTypes: w.GetEventTypes(),
+		Disabled:   w.Disabled,
+	}
+}
+
+// GetSignature returns the hex encoded HMAC-SHA256 signature of the timestamp and payload of the webhook delivery
+func (d *WebhookDelivery) GetSignature(secret string, timestamp int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, d.Payload)))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GetNextRetryDelay returns the delay before next delivery attempt, the delay doubles after every failed attempt
+func (d *WebhookDelivery) GetNextRetryDelay() time.Duration {
+	delay := webhookDeliveryMinRetryDelay
+
+	for i := int32(1); i < d.Attempts; i++ {
+		delay = delay * 2
+
+		if delay >= webhookDeliveryMaxRetryDelay {
+			return webhookDeliveryMaxRetryDelay
+		}
+	}
+
+	return delay
+}
+
+// ToWebhookDeliveryInfoResponse returns a view-object according to database model
+func (d *WebhookDelivery) ToWebhookDeliveryInfoResponse() *WebhookDeliveryInfoResponse {
+	resp := &WebhookDeliveryInfoResponse{
+		Id:                     d.DeliveryId,
+		WebhookId:              d.WebhookId,
+		EventType:              d.EventType,
+		Payload:                d.Payload,
+		Status:                 d.Status,
+		Attempts:               d.Attempts,
+		LastResponseStatusCode: d.LastResponseStatusCode,
+		LastError:              d.LastError,
+		CreatedTime:            d.CreatedUnixTime,
+	}
+
+	if d.Status == WEBHOOK_DELIVERY_STATUS_PENDING {
+		resp.NextAttemptTime = d.NextAttemptUnixTime
+	}
+
+	return resp
+}
+
+// NewWebhookTransactionEventData returns the webhook event data of specified transaction
+func NewWebhookTransactionEventData(transaction *Transaction, tagIds []int64) *WebhookTransactionEventData {
+	transactionResp := transaction.ToTransactionInfoResponse(tagIds, nil, false)
+
+	if transactionResp == nil {
+		return nil
+	}
+
+	return &WebhookTransactionEventData{
+		Id:                   transactionResp.Id,
+		Type:                 transactionResp.Type,
+		CategoryId:           transactionResp.CategoryId,
+		Time:                 transactionResp.Time,
+		UtcOffset:            transactionResp.UtcOffset,
+		SourceAccountId:      transactionResp.SourceAccountId,
+		DestinationAccountId: transactionResp.DestinationAccountId,
+		SourceAmount:         transactionResp.SourceAmount,
+		DestinationAmount:    transactionResp.DestinationAmount,
+		HideAmount:           transactionResp.HideAmount,
+		TagIds:               transactionResp.TagIds,
+		Comment:              transactionResp.Comment,
+	}
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookGetEventTypes(t *testing.T) {
+	webhook := &Webhook{
+		EventTypes: "1,3,5",
+	}
+
+	assert.Equal(t, []WebhookEventType{WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED, WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED, WEBHOOK_EVENT_TYPE_SCHEDULED_TRANSACTION_CREATED}, webhook.GetEventTypes())
+
+	webhook.EventTypes = "0,2,6,a"
+	assert.Equal(t, []WebhookEventType{WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED}, webhook.GetEventTypes())
+
+	webhook.EventTypes = ""
+	assert.Equal(t, 0, len(webhook.GetEventTypes()))
+}
+
+func TestWebhookSetEventTypes(t *testing.T) {
+	webhook := &Webhook{}
+	webhook.SetEventTypes([]WebhookEventType{WEBHOOK_EVENT_TYPE_TRANSACTIONS_IMPORTED, WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED, WEBHOOK_EVENT_TYPE_TRANSACTIONS_IMPORTED})
+
+	assert.Equal(t, "4,1", webhook.EventTypes)
+
+	webhook.SetEventTypes([]WebhookEventType{})
+	assert.Equal(t, "", webhook.EventTypes)
+}
+
+func TestWebhookIsSubscribed(t *testing.T) {
+	webhook := &Webhook{
+		EventTypes: "1,2",
+	}
+
+	assert.True(t, webhook.IsSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED))
+	assert.True(t, webhook.IsSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED))
+	assert.False(t, webhook.IsSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED))
+
+	webhook.Disabled = true
+	assert.False(t, webhook.IsSubscribed(WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED))
+}
+
+func TestWebhookEventTypeString(t *testing.T) {
+	assert.Equal(t, "transaction.created", WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED.String())
+	assert.Equal(t, "transaction.modified", WEBHOOK_EVENT_TYPE_TRANSACTION_MODIFIED.String())
+	assert.Equal(t, "transaction.deleted", WEBHOOK_EVENT_TYPE_TRANSACTION_DELETED.String())
+	assert.Equal(t, "transactions.imported", WEBHOOK_EVENT_TYPE_TRANSACTIONS_IMPORTED.String())
+	assert.Equal(t, "scheduled_transaction.created", WEBHOOK_EVENT_TYPE_SCHEDULED_TRANSACTION_CREATED.String())
+	assert.Equal(t, "Invalid(6)", WebhookEventType(6).String())
+}
+
+func TestWebhookDeliveryGetSignature(t *testing.T) {
+	delivery := &WebhookDelivery{
+		Payload: "{\"id\":\"1\"}",
+	}
+
+	assert.Equal(t, "sha256=086f6aff7bd084c98679825129c5a64dbad88c760016d6d2c0fb123f27951d54", delivery.GetSignature("secret", 1700000000))
+	assert.NotEqual(t, delivery.GetSignature("secret", 1700000000), delivery.GetSignature("secret", 1700000001))
+	assert.NotEqual(t, delivery.GetSignature("secret", 1700000000), delivery.GetSignature("secret2", 1700000000))
+}
+
+func TestWebhookDeliveryGetNextRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, (&WebhookDelivery{Attempts: 0}).GetNextRetryDelay())
+	assert.Equal(t, time.Minute, (&WebhookDelivery{Attempts: 1}).GetNextRetryDelay())
+	assert.Equal(t, 2*time.Minute, (&WebhookDelivery{Attempts: 2}).GetNextRetryDelay())
+	assert.Equal(t, 4*time.Minute, (&WebhookDelivery{Attempts: 3}).GetNextRetryDelay())
+	assert.Equal(t, 256*time.Minute, (&WebhookDelivery{Attempts: 9}).GetNextRetryDelay())
+	assert.Equal(t, 6*time.Hour, (&WebhookDelivery{Attempts: 10}).GetNextRetryDelay())
+	assert.Equal(t, 6*time.Hour, (&WebhookDelivery{Attempts: 20}).GetNextRetryDelay())
+}
+
+func TestNewWebhookTransactionEventData_TransferOutTransaction(t *testing.T) {
+	transaction := &Transaction{
+		TransactionId:        100,
+		Type:                 TRANSACTION_DB_TYPE_TRANSFER_OUT,
+		CategoryId:           10,
+		TransactionTime:      1700000000000,
+		TimezoneUtcOffset:    480,
+		AccountId:            1,
+		Amount:               1000,
+		RelatedAccountId:     2,
+		RelatedAccountAmount: 900,
+		Comment:              "test",
+	}
+
+	data := NewWebhookTransactionEventData(transaction, []int64{5, 6})
+
+	assert.Equal(t, int64(100), data.Id)
+	assert.Equal(t, TRANSACTION_TYPE_TRANSFER, data.Type)
+	assert.Equal(t, int64(10), data.CategoryId)
+	assert.Equal(t, int64(1700000000), data.Time)
+	assert.Equal(t, int16(480), data.UtcOffset)
+	assert.Equal(t, int64(1), data.SourceAccountId)
+	assert.Equal(t, int64(2), data.DestinationAccountId)
+	assert.Equal(t, int64(1000), data.SourceAmount)
+	assert.Equal(t, int64(900), data.DestinationAmount)
+	assert.Equal(t, []string{"5", "6"}, data.TagIds)
+	assert.Equal(t, "test", data.Comment)
+}
+
+func TestWebhookDeliveryToWebhookDeliveryInfoResponse(t *testing.T) {
+	delivery := &WebhookDelivery{
+		DeliveryId:          1,
+		WebhookId:           2,
+		EventType:           WEBHOOK_EVENT_TYPE_TRANSACTION_CREATED,
+		Status:              WEBHOOK_DELIVERY_STATUS_PENDING,
+		Attempts:            1,
+		NextAttemptUnixTime: 1700000060,
+		CreatedUnixTime:     1700000000,
+	}
+
+	assert.Equal(t, int64(1700000060), delivery.ToWebhookDeliveryInfoResponse().NextAttemptTime)
+
+	delivery.Status = WEBHOOK_DELIVERY_STATUS_FAILED
+	assert.Equal(t, int64(0), delivery.ToWebhookDeliveryInfoResponse().NextAttemptTime)
+}
@@ -0,0 +1,69 @@
+package ocr
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const alipayBillListDefaultAccountName = "支付宝"
+
+var alipayBillListLayoutKeywords = []string{"支付宝", "花呗", "余额宝", "订单筛选"}
+var alipayBillListIncomeKeywords = []string{"退款", "收款", "收益发放", "转入"}
+
+// AlipayBillListTextParser represents the parser for Alipay bill list layout, each transaction has a "merchant amount" line
+// followed by a "category date time" line, e.g. "美团外卖 -35.50" and "餐饮美食 昨天 12:31"
+type AlipayBillListTextParser struct{}
+
+// Initialize an alipay bill list text parser singleton instance
+var (
+	AlipayBillListTextParserInstance = &AlipayBillListTextParser{}
+)
+
+// Name returns the layout name of the parser
+func (p *AlipayBillListTextParser) Name() string {
+	return "alipay"
+}
+
+// IsLayoutMatched returns whether the text lines are in the layout which the parser supports
+func (p *AlipayBillListTextParser) IsLayoutMatched(lines []string) bool {
+	return containsAnyBillListKeyword(lines, alipayBillListLayoutKeywords)
+}
+
+// Parse returns the transactions parsed from the text lines
+func (p *AlipayBillListTextParser) Parse(lines []string, refTime time.Time) []*models.RecognizedReceiptImageResult {
+	var results []*models.RecognizedReceiptImageResult
+
+	for i := 0; i < len(lines)-1; i++ {
+		merchant, amountText, ok := splitBillListTrailingAmount(lines[i])
+
+		if !ok || merchant == "" {
+			continue
+		}
+
+		category, dateTime, ok := parseBillListChineseDateTime(lines[i+1], refTime)
+
+		if !ok {
+			continue
+		}
+
+		amount, sign, ok := parseBillListAmount(amountText)
+
+		if !ok {
+			continue
+		}
+
+		results = append(results, &models.RecognizedReceiptImageResult{
+			Type:         getBillListTransactionType(sign, merchant+" "+category, alipayBillListIncomeKeywords, "expense"),
+			Time:         dateTime,
+			Amount:       amount,
+			AccountName:  alipayBillListDefaultAccountName,
+			CategoryName: category,
+			Description:  merchant,
+		})
+
+		i++
+	}
+
+	return results
+}
@@ -0,0 +1,160 @@
+package ocr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+var (
+	bankAppBillListDateTimeRegex   = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})(?:\s+(\d{1,2}):(\d{2})(?::\d{2})?)?(?:\s+(.*))?$`)
+	bankAppBillListBankNameRegex   = regexp.MustCompile(`\p{Han}{2,}银行`)
+	bankAppBillListCardTailRegex   = regexp.MustCompile(`(?:尾号|末四位|\*{2,})\s*(\d{4})`)
+	bankAppBillListIncomeKeywords  = []string{"收入", "存入", "工资", "代发", "退款", "利息", "转入"}
+	bankAppBillListBalancePrefixes = []string{"余额", "可用余额", "账户余额"}
+)
+
+// BankAppBillListTextParser represents the parser for bank app transaction detail list layout, each transaction starts
+// with a "YYYY-MM-DD [HH:MM[:SS]]" line, followed by merchant line and amount line, balance lines are ignored
+type BankAppBillListTextParser struct{}
+
+// Initialize a bank app bill list text parser singleton instance
+var (
+	BankAppBillListTextParserInstance = &BankAppBillListTextParser{}
+)
+
+// Name returns the layout name of the parser
+func (p *BankAppBillListTextParser) Name() string {
+	return "bank_app"
+}
+
+// IsLayoutMatched returns whether the text lines are in the layout which the parser supports
+func (p *BankAppBillListTextParser) IsLayoutMatched(lines []string) bool {
+	hasBankName := false
+	hasDate := false
+
+	for i := 0; i < len(lines); i++ {
+		if bankAppBillListBankNameRegex.MatchString(lines[i]) {
+			hasBankName = true
+		}
+
+		if bankAppBillListDateTimeRegex.MatchString(lines[i]) {
+			hasDate = true
+		}
+	}
+
+	return hasBankName && hasDate
+}
+
+// Parse returns the transactions parsed from the text lines, the payment account is the bank name with card tail number
+func (p *BankAppBillListTextParser) Parse(lines []string, refTime time.Time) []*models.RecognizedReceiptImageResult {
+	var results []*models.RecognizedReceiptImageResult
+	accountName := p.getAccountName(lines)
+
+	for i := 0; i < len(lines); i++ {
+		dateMatches := bankAppBillListDateTimeRegex.FindStringSubmatch(lines[i])
+
+		if dateMatches == nil {
+			continue
+		}
+
+		year, _ := strconv.Atoi(dateMatches[1])
+		month, _ := strconv.Atoi(dateMatches[2])
+		day, _ := strconv.Atoi(dateMatches[3])
+		hour, _ := strconv.Atoi(dateMatches[4])
+		min, _ := strconv.Atoi(dateMatches[5])
+
+		if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || min > 59 {
+			continue
+		}
+
+		merchant := ""
+		amountText := ""
+		recordLines := make([]string, 0, 3)
+
+		if dateMatches[6] != "" {
+			recordLines = append(recordLines, strings.TrimSpace(dateMatches[6]))
+		}
+
+		for j := i + 1; j < len(lines) && !bankAppBillListDateTimeRegex.MatchString(lines[j]); j++ {
+			recordLines = append(recordLines, lines[j])
+		}
+
+		for j := 0; j < len(recordLines); j++ {
+			line := recordLines[j]
+
+			if p.isBalanceLine(line) {
+				continue
+			}
+
+			if _, _, isAmount := parseBillListAmount(line); isAmount {
+				if amountText == "" {
+					amountText = line
+				}
+
+				continue
+			}
+
+			if lineWithoutAmount, trailingAmount, hasAmount := splitBillListTrailingAmount(line); hasAmount && amountText == "" {
+				amountText = trailingAmount
+				line = lineWithoutAmount
+			}
+
+			if merchant == "" {
+				merchant = line
+			}
+		}
+
+		amount, sign, ok := parseBillListAmount(amountText)
+
+		if !ok || merchant == "" {
+			continue
+		}
+
+		results = append(results, &models.RecognizedReceiptImageResult{
+			Type:        getBillListTransactionType(sign, merchant, bankAppBillListIncomeKeywords, "expense"),
+			Time:        formatBillListDateToLongDateTime(year, month, day, hour, min),
+			Amount:      amount,
+			AccountName: accountName,
+			Description: merchant,
+		})
+	}
+
+	return results
+}
+
+func (p *BankAppBillListTextParser) getAccountName(lines []string) string {
+	bankName := ""
+	cardTail := ""
+
+	for i := 0; i < len(lines); i++ {
+		if bankName == "" {
+			bankName = bankAppBillListBankNameRegex.FindString(lines[i])
+		}
+
+		if cardTail == "" {
+			if matches := bankAppBillListCardTailRegex.FindStringSubmatch(lines[i]); matches != nil {
+				cardTail = matches[1]
+			}
+		}
+	}
+
+	if bankName != "" && cardTail != "" {
+		return bankName + "(" + cardTail + ")"
+	}
+
+	return bankName
+}
+
+func (p *BankAppBillListTextParser) isBalanceLine(line string) bool {
+	for i := 0; i < len(bankAppBillListBalancePrefixes); i++ {
+		if strings.HasPrefix(line, bankAppBillListBalancePrefixes[i]) {
+			return true
+		}
+	}
+
+	return false
+}
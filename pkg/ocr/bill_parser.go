@@ -10,65 +10,200 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 )
 
-func formatBillListDateToLongDateTime(year, month, day, hour, min int) string {
-	return fmt.Sprintf("%04d-%02d-%02d %02d:%02d:00", year, month, day, hour, min)
+// BillListTextParser defines the structure of parser for the text recognized from bill list screenshot of a specific layout
+type BillListTextParser interface {
+	// Name returns the layout name of the parser
+	Name() string
+
+	// IsLayoutMatched returns whether the text lines are in the layout which the parser supports
+	IsLayoutMatched(lines []string) bool
+
+	// Parse returns the transactions parsed from the text lines
+	Parse(lines []string, refTime time.Time) []*models.RecognizedReceiptImageResult
 }
 
+// Bill list text parsers in the order of layout detection, the default parser is used when no parser matches.
+// Parsers detecting layout by date format go first, because bank app lists may also mention payment apps (e.g. "财付通-微信转账")
+var (
+	billListTextParsers = []BillListTextParser{
+		BankAppBillListTextParserInstance,
+		EnglishBillListTextParserInstance,
+		AlipayBillListTextParserInstance,
+		WeChatPayBillListTextParserInstance,
+	}
+	defaultBillListTextParser BillListTextParser = DefaultBillListTextParserInstance
+)
+
+var (
+	billListChineseDateTimeRegex = regexp.MustCompile(`^(?:(.*?)\s*)?(今天|昨天|前天|\d{1,2}月\d{1,2}日|\d{1,2}-\d{1,2})\s*(\d{1,2}):(\d{2})$`)
+	billListChineseDateRegex     = regexp.MustCompile(`^(\d{1,2})(?:月|-)(\d{1,2})日?$`)
+	billListAmountRegex          = regexp.MustCompile(`^\(?([+\-−]?)\s*[¥￥$]?\s*([+\-−]?)(\d{1,3}(?:,\d{3})+(?:\.\d{1,2})?|\d+(?:\.\d{1,2})?)\)?$`)
+	billListTrailingAmountRegex  = regexp.MustCompile(`^(.*?)\s+(\(?[+\-−]?\s*[¥￥$]?\s*[+\-−]?(?:\d{1,3}(?:,\d{3})+|\d+)\.\d{1,2}\)?)$`)
+)
+
 // ParseBillListText parses OCR text from a bill/transaction list screenshot (e.g. 账单)
-// and returns a list of recognized transaction results.
-// Date format expected: "M月D日 HH:MM" (e.g. "2月7日 21:49"). Year is taken from refTime.
+// and returns a list of recognized transaction results using the parser which matches the layout of the text.
 func ParseBillListText(text string, refTime time.Time) []*models.RecognizedReceiptImageResult {
-	year := refTime.Year()
-	lines := strings.Split(text, "\n")
-	var results []*models.RecognizedReceiptImageResult
-
-	// Amount at end of line: -123.45 or 123.45
-	amountRe := regexp.MustCompile(`(-?\d+\.?\d*)\s*$`)
-	// Chinese date time: 2月7日 21:49 or 12月31日 09:00
-	dateRe := regexp.MustCompile(`(\d{1,2})月(\d{1,2})日\s*(\d{1,2}):(\d{2})`)
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
+	lines := splitBillListTextLines(text)
+	return GetBillListTextParser(lines).Parse(lines, refTime)
+}
+
+// GetBillListTextParser returns the first parser which matches the layout of the text lines, or the default parser
+func GetBillListTextParser(lines []string) BillListTextParser {
+	for i := 0; i < len(billListTextParsers); i++ {
+		if billListTextParsers[i].IsLayoutMatched(lines) {
+			return billListTextParsers[i]
 		}
-		amountIdx := amountRe.FindStringSubmatchIndex(line)
-		if amountIdx == nil {
-			continue
+	}
+
+	return defaultBillListTextParser
+}
+
+func splitBillListTextLines(text string) []string {
+	rawLines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	lines := make([]string, 0, len(rawLines))
+
+	for i := 0; i < len(rawLines); i++ {
+		line := strings.TrimSpace(rawLines[i])
+
+		if line != "" {
+			lines = append(lines, line)
 		}
-		amountStr := strings.TrimSpace(line[amountIdx[2]:amountIdx[3]])
-		rest := strings.TrimSpace(line[:amountIdx[0]])
-		dateIdx := dateRe.FindStringSubmatchIndex(rest)
-		if dateIdx == nil {
-			continue
+	}
+
+	return lines
+}
+
+func containsAnyBillListKeyword(lines []string, keywords []string) bool {
+	for i := 0; i < len(lines); i++ {
+		if containsAnyKeyword(lines[i], keywords) {
+			return true
 		}
-		month, _ := strconv.Atoi(rest[dateIdx[2]:dateIdx[3]])
-		day, _ := strconv.Atoi(rest[dateIdx[4]:dateIdx[5]])
-		hour, _ := strconv.Atoi(rest[dateIdx[6]:dateIdx[7]])
-		min, _ := strconv.Atoi(rest[dateIdx[8]:dateIdx[9]])
-		description := strings.TrimSpace(rest[:dateIdx[0]])
-		if description == "" {
-			description = rest[dateIdx[1]:]
-			description = strings.TrimSpace(description)
+	}
+
+	return false
+}
+
+func containsAnyKeyword(text string, keywords []string) bool {
+	lowerText := strings.ToLower(text)
+
+	for i := 0; i < len(keywords); i++ {
+		if strings.Contains(lowerText, strings.ToLower(keywords[i])) {
+			return true
 		}
-		if description == "" {
-			description = "OCR"
+	}
+
+	return false
+}
+
+// parseBillListAmount returns the absolute amount without currency symbol and thousands separator, and the sign (-1, 0 for unsigned or 1) of the amount text
+func parseBillListAmount(text string) (string, int, bool) {
+	text = strings.TrimSpace(text)
+	matches := billListAmountRegex.FindStringSubmatch(text)
+
+	if matches == nil {
+		return "", 0, false
+	}
+
+	amount := strings.ReplaceAll(matches[3], ",", "")
+	sign := matches[1]
+
+	if sign == "" {
+		sign = matches[2]
+	}
+
+	if sign == "-" || sign == "−" || (strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")")) {
+		return amount, -1, true
+	} else if sign == "+" {
+		return amount, 1, true
+	}
+
+	return amount, 0, true
+}
+
+// splitBillListTrailingAmount returns the text before the trailing amount and the trailing amount of the line
+func splitBillListTrailingAmount(line string) (string, string, bool) {
+	matches := billListTrailingAmountRegex.FindStringSubmatch(line)
+
+	if matches == nil {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(matches[1]), matches[2], true
+}
+
+// getBillListTransactionType returns the transaction type according to the sign of amount, and uses the income keywords when the amount is unsigned
+func getBillListTransactionType(sign int, text string, incomeKeywords []string, unsignedDefaultType string) string {
+	if sign < 0 {
+		return "expense"
+	} else if sign > 0 {
+		return "income"
+	}
+
+	if containsAnyKeyword(text, incomeKeywords) {
+		return "income"
+	}
+
+	return unsignedDefaultType
+}
+
+// parseBillListChineseDateTime returns the text before the date time, and the long date time of the line like "今天 12:31", "2月7日 21:49" or "02-07 12:31"
+func parseBillListChineseDateTime(line string, refTime time.Time) (string, string, bool) {
+	matches := billListChineseDateTimeRegex.FindStringSubmatch(line)
+
+	if matches == nil {
+		return "", "", false
+	}
+
+	hour, _ := strconv.Atoi(matches[3])
+	min, _ := strconv.Atoi(matches[4])
+
+	if hour > 23 || min > 59 {
+		return "", "", false
+	}
+
+	var date time.Time
+
+	switch matches[2] {
+	case "今天":
+		date = refTime
+	case "昨天":
+		date = refTime.AddDate(0, 0, -1)
+	case "前天":
+		date = refTime.AddDate(0, 0, -2)
+	default:
+		dateMatches := billListChineseDateRegex.FindStringSubmatch(matches[2])
+
+		if dateMatches == nil {
+			return "", "", false
 		}
-		// Build long date time YYYY-MM-DD HH:mm:00
-		longDateTime := formatBillListDateToLongDateTime(year, month, day, hour, min)
-		// Default type: expense if amount is negative
-		txType := "expense"
-		if len(amountStr) > 0 && amountStr[0] == '+' {
-			txType = "income"
-		} else if len(amountStr) > 0 && amountStr[0] != '-' {
-			txType = "income"
+
+		month, _ := strconv.Atoi(dateMatches[1])
+		day, _ := strconv.Atoi(dateMatches[2])
+
+		if month < 1 || month > 12 || day < 1 || day > 31 {
+			return "", "", false
 		}
-		results = append(results, &models.RecognizedReceiptImageResult{
-			Type:        txType,
-			Time:        longDateTime,
-			Amount:      amountStr,
-			Description: description,
-		})
+
+		return strings.TrimSpace(matches[1]), formatBillListDateTime(month, day, hour, min, refTime), true
+	}
+
+	return strings.TrimSpace(matches[1]), formatBillListDateToLongDateTime(date.Year(), int(date.Month()), date.Day(), hour, min), true
+}
+
+// formatBillListDateTime returns the long date time of the date without year, the year is taken from refTime
+// and the previous year is used if the date time is later than refTime (e.g. December bills viewed in January)
+func formatBillListDateTime(month, day, hour, min int, refTime time.Time) string {
+	year := refTime.Year()
+	dateTime := time.Date(year, time.Month(month), day, hour, min, 0, 0, refTime.Location())
+
+	if dateTime.After(refTime.AddDate(0, 0, 1)) {
+		year--
 	}
-	return results
+
+	return formatBillListDateToLongDateTime(year, month, day, hour, min)
+}
+
+func formatBillListDateToLongDateTime(year, month, day, hour, min int) string {
+	return fmt.Sprintf("%04d-%02d-%02d %02d:%02d:00", year, month, day, hour, min)
 }
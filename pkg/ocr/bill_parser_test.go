@@ -0,0 +1,109 @@
+package ocr
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+var billListTestReferenceTime = time.Date(2026, 2, 7, 23, 0, 0, 0, time.UTC)
+
+func TestParseBillListText_AlipayLayout(t *testing.T) {
+	testParseBillListTextWithGoldenFile(t, "alipay", AlipayBillListTextParserInstance)
+}
+
+func TestParseBillListText_WeChatPayLayout(t *testing.T) {
+	testParseBillListTextWithGoldenFile(t, "wechat_pay", WeChatPayBillListTextParserInstance)
+}
+
+func TestParseBillListText_BankAppLayout(t *testing.T) {
+	testParseBillListTextWithGoldenFile(t, "bank_app", BankAppBillListTextParserInstance)
+}
+
+func TestParseBillListText_EnglishLayout(t *testing.T) {
+	testParseBillListTextWithGoldenFile(t, "english", EnglishBillListTextParserInstance)
+}
+
+func TestParseBillListText_DefaultLayout(t *testing.T) {
+	testParseBillListTextWithGoldenFile(t, "default", DefaultBillListTextParserInstance)
+}
+
+func TestParseBillListText_EmptyText(t *testing.T) {
+	assert.Nil(t, ParseBillListText("", billListTestReferenceTime))
+	assert.Nil(t, ParseBillListText("\n  \n", billListTestReferenceTime))
+}
+
+func TestParseBillListAmount(t *testing.T) {
+	amount, sign, ok := parseBillListAmount("-1,282.00")
+	assert.True(t, ok)
+	assert.Equal(t, "1282.00", amount)
+	assert.Equal(t, -1, sign)
+
+	amount, sign, ok = parseBillListAmount("+¥0.52")
+	assert.True(t, ok)
+	assert.Equal(t, "0.52", amount)
+	assert.Equal(t, 1, sign)
+
+	amount, sign, ok = parseBillListAmount("$-5.75")
+	assert.True(t, ok)
+	assert.Equal(t, "5.75", amount)
+	assert.Equal(t, -1, sign)
+
+	amount, sign, ok = parseBillListAmount("(45.10)")
+	assert.True(t, ok)
+	assert.Equal(t, "45.10", amount)
+	assert.Equal(t, -1, sign)
+
+	amount, sign, ok = parseBillListAmount("66.80")
+	assert.True(t, ok)
+	assert.Equal(t, "66.80", amount)
+	assert.Equal(t, 0, sign)
+
+	_, _, ok = parseBillListAmount("美团 -35.50")
+	assert.False(t, ok)
+
+	_, _, ok = parseBillListAmount("12:31")
+	assert.False(t, ok)
+}
+
+func TestParseBillListChineseDateTime(t *testing.T) {
+	prefix, dateTime, ok := parseBillListChineseDateTime("餐饮美食 今天 12:31", billListTestReferenceTime)
+	assert.True(t, ok)
+	assert.Equal(t, "餐饮美食", prefix)
+	assert.Equal(t, "2026-02-07 12:31:00", dateTime)
+
+	prefix, dateTime, ok = parseBillListChineseDateTime("前天 08:00", billListTestReferenceTime)
+	assert.True(t, ok)
+	assert.Equal(t, "", prefix)
+	assert.Equal(t, "2026-02-05 08:00:00", dateTime)
+
+	_, dateTime, ok = parseBillListChineseDateTime("12月31日 23:59", billListTestReferenceTime)
+	assert.True(t, ok)
+	assert.Equal(t, "2025-12-31 23:59:00", dateTime)
+
+	_, _, ok = parseBillListChineseDateTime("13月01日 10:00", billListTestReferenceTime)
+	assert.False(t, ok)
+
+	_, _, ok = parseBillListChineseDateTime("02-07 25:00", billListTestReferenceTime)
+	assert.False(t, ok)
+}
+
+func testParseBillListTextWithGoldenFile(t *testing.T, layoutName string, expectedParser BillListTextParser) {
+	text, err := os.ReadFile("../../testdata/ocr_bill_list_" + layoutName + ".txt")
+	assert.Nil(t, err)
+
+	goldenData, err := os.ReadFile("../../testdata/ocr_bill_list_" + layoutName + "_golden.json")
+	assert.Nil(t, err)
+
+	var expectedResults []*models.RecognizedReceiptImageResult
+	err = json.Unmarshal(goldenData, &expectedResults)
+	assert.Nil(t, err)
+
+	assert.Equal(t, expectedParser, GetBillListTextParser(splitBillListTextLines(string(text))))
+	assert.Equal(t, expectedResults, ParseBillListText(string(text), billListTestReferenceTime))
+}
@@ -0,0 +1,84 @@
+package ocr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// DefaultBillListTextParser represents the parser for the generic "description M月D日 HH:MM amount" bill list layout
+type DefaultBillListTextParser struct{}
+
+// Initialize a default bill list text parser singleton instance
+var (
+	DefaultBillListTextParserInstance = &DefaultBillListTextParser{}
+)
+
+var (
+	// Amount at end of line: -123.45 or 123.45
+	defaultBillListAmountRegex = regexp.MustCompile(`([+\-]?\d+\.?\d*)\s*$`)
+	// Chinese date time: 2月7日 21:49 or 12月31日 09:00
+	defaultBillListDateRegex = regexp.MustCompile(`(\d{1,2})月(\d{1,2})日\s*(\d{1,2}):(\d{2})`)
+)
+
+// Name returns the layout name of the parser
+func (p *DefaultBillListTextParser) Name() string {
+	return "default"
+}
+
+// IsLayoutMatched returns whether the text lines are in the layout which the parser supports
+func (p *DefaultBillListTextParser) IsLayoutMatched(lines []string) bool {
+	return true
+}
+
+// Parse returns the transactions parsed from the text lines, the amount without sign is regarded as income
+func (p *DefaultBillListTextParser) Parse(lines []string, refTime time.Time) []*models.RecognizedReceiptImageResult {
+	var results []*models.RecognizedReceiptImageResult
+
+	for _, line := range lines {
+		amountIdx := defaultBillListAmountRegex.FindStringSubmatchIndex(line)
+
+		if amountIdx == nil {
+			continue
+		}
+
+		amount, sign, ok := parseBillListAmount(line[amountIdx[2]:amountIdx[3]])
+
+		if !ok {
+			continue
+		}
+
+		rest := strings.TrimSpace(line[:amountIdx[0]])
+		dateIdx := defaultBillListDateRegex.FindStringSubmatchIndex(rest)
+
+		if dateIdx == nil {
+			continue
+		}
+
+		month, _ := strconv.Atoi(rest[dateIdx[2]:dateIdx[3]])
+		day, _ := strconv.Atoi(rest[dateIdx[4]:dateIdx[5]])
+		hour, _ := strconv.Atoi(rest[dateIdx[6]:dateIdx[7]])
+		min, _ := strconv.Atoi(rest[dateIdx[8]:dateIdx[9]])
+		description := strings.TrimSpace(rest[:dateIdx[0]])
+
+		if description == "" {
+			description = strings.TrimSpace(rest[dateIdx[1]:])
+		}
+
+		if description == "" {
+			description = "OCR"
+		}
+
+		results = append(results, &models.RecognizedReceiptImageResult{
+			Type:        getBillListTransactionType(sign, description, nil, "income"),
+			Time:        formatBillListDateTime(month, day, hour, min, refTime),
+			Amount:      amount,
+			Description: description,
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,103 @@
+package ocr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+var (
+	englishBillListLineRegex      = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{4})(?:\s+(\d{1,2}):(\d{2})\s*([AaPp][Mm])?)?\s+(.+?)\s+(\(?[+\-−]?\s*\$?\s*[+\-−]?(?:\d{1,3}(?:,\d{3})+|\d+)\.\d{2}\)?)(?:\s+(CR|DR))?$`)
+	englishBillListAccountRegex   = regexp.MustCompile(`(?i)^(?:account|card)\s*:\s*(.+)$`)
+	englishBillListIncomeKeywords = []string{"deposit", "payroll", "salary", "refund", "interest", "credit", "transfer from"}
+)
+
+// EnglishBillListTextParser represents the parser for English-locale bill list layout, each transaction is in one line
+// like "MM/DD/YYYY [HH:MM [AM|PM]] merchant amount [CR|DR]", the amount in parentheses is regarded as negative
+type EnglishBillListTextParser struct{}
+
+// Initialize an english bill list text parser singleton instance
+var (
+	EnglishBillListTextParserInstance = &EnglishBillListTextParser{}
+)
+
+// Name returns the layout name of the parser
+func (p *EnglishBillListTextParser) Name() string {
+	return "english"
+}
+
+// IsLayoutMatched returns whether the text lines are in the layout which the parser supports
+func (p *EnglishBillListTextParser) IsLayoutMatched(lines []string) bool {
+	for i := 0; i < len(lines); i++ {
+		if englishBillListLineRegex.MatchString(lines[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse returns the transactions parsed from the text lines, the payment account is taken from "Account: xxx" or "Card: xxx" line
+func (p *EnglishBillListTextParser) Parse(lines []string, refTime time.Time) []*models.RecognizedReceiptImageResult {
+	var results []*models.RecognizedReceiptImageResult
+	accountName := ""
+
+	for i := 0; i < len(lines); i++ {
+		if accountName == "" {
+			if matches := englishBillListAccountRegex.FindStringSubmatch(lines[i]); matches != nil {
+				accountName = strings.TrimSpace(matches[1])
+				continue
+			}
+		}
+
+		matches := englishBillListLineRegex.FindStringSubmatch(lines[i])
+
+		if matches == nil {
+			continue
+		}
+
+		month, _ := strconv.Atoi(matches[1])
+		day, _ := strconv.Atoi(matches[2])
+		year, _ := strconv.Atoi(matches[3])
+		hour, _ := strconv.Atoi(matches[4])
+		min, _ := strconv.Atoi(matches[5])
+		meridiem := strings.ToUpper(matches[6])
+
+		if meridiem == "PM" && hour < 12 {
+			hour += 12
+		} else if meridiem == "AM" && hour == 12 {
+			hour = 0
+		}
+
+		if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || min > 59 {
+			continue
+		}
+
+		amount, sign, ok := parseBillListAmount(matches[8])
+
+		if !ok {
+			continue
+		}
+
+		if matches[9] == "DR" {
+			sign = -1
+		} else if matches[9] == "CR" {
+			sign = 1
+		}
+
+		merchant := strings.TrimSpace(matches[7])
+
+		results = append(results, &models.RecognizedReceiptImageResult{
+			Type:        getBillListTransactionType(sign, merchant, englishBillListIncomeKeywords, "expense"),
+			Time:        formatBillListDateToLongDateTime(year, month, day, hour, min),
+			Amount:      amount,
+			AccountName: accountName,
+			Description: merchant,
+		})
+	}
+
+	return results
+}
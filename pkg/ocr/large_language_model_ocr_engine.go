@@ -0,0 +1,96 @@
+package ocr
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/llm"
+	"github.com/mayswind/ezbookkeeping/pkg/llm/data"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/templates"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// LargeLanguageModelOCREngine represents the ocr engine which uses the large language model configured for receipt image recognition
+type LargeLanguageModelOCREngine struct{}
+
+// Initialize a large language model ocr engine singleton instance
+var (
+	LargeLanguageModelOCREngineInstance = &LargeLanguageModelOCREngine{}
+)
+
+// Name returns the name of the ocr engine
+func (e *LargeLanguageModelOCREngine) Name() string {
+	return settings.LLMOCREngine
+}
+
+// RecognizeBillImage returns the transaction recognized from the bill image by the large language model
+func (e *LargeLanguageModelOCREngine) RecognizeBillImage(c core.Context, uid int64, currentConfig *settings.Config, request *OCRRequest) ([]*models.RecognizedReceiptImageResult, error) {
+	systemPrompt, err := e.getSystemPrompt(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	llmRequest := &data.LargeLanguageModelRequest{
+		Stream:                 false,
+		SystemPrompt:           systemPrompt,
+		UserPrompt:             request.ImageData,
+		UserPromptType:         data.LARGE_LANGUAGE_MODEL_REQUEST_PROMPT_TYPE_IMAGE_URL,
+		UserPromptContentType:  request.ImageContentType,
+		ResponseJsonObjectType: reflect.TypeOf(&models.RecognizedReceiptImageResult{}),
+	}
+
+	llmResponse, err := llm.Container.GetJsonResponseByReceiptImageRecognitionModel(c, uid, currentConfig, llmRequest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if llmResponse == nil || strings.TrimSpace(llmResponse.Content) == "" {
+		return nil, nil
+	}
+
+	result := &models.RecognizedReceiptImageResult{}
+	err = json.Unmarshal([]byte(llmResponse.Content), result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Type == "" {
+		return nil, nil
+	}
+
+	return []*models.RecognizedReceiptImageResult{result}, nil
+}
+
+func (e *LargeLanguageModelOCREngine) getSystemPrompt(request *OCRRequest) (string, error) {
+	tmpl, err := templates.GetTemplate(templates.SYSTEM_PROMPT_RECEIPT_IMAGE_RECOGNITION)
+
+	if err != nil {
+		return "", err
+	}
+
+	templateParams := map[string]any{
+		"CurrentDateTime":          utils.FormatUnixTimeToLongDateTime(request.ReferenceTime.Unix(), request.ReferenceTime.Location()),
+		"AllExpenseCategoryNames":  strings.Join(request.ExpenseCategoryNames, "\n"),
+		"AllIncomeCategoryNames":   strings.Join(request.IncomeCategoryNames, "\n"),
+		"AllTransferCategoryNames": strings.Join(request.TransferCategoryNames, "\n"),
+		"AllAccountNames":          strings.Join(request.AccountNames, "\n"),
+		"AllTagNames":              strings.Join(request.TagNames, "\n"),
+	}
+
+	var promptBuffer bytes.Buffer
+	err = tmpl.Execute(&promptBuffer, templateParams)
+
+	if err != nil {
+		return "", err
+	}
+
+	return promptBuffer.String(), nil
+}
@@ -0,0 +1,32 @@
+package ocr
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// OCREngine defines the structure of optical character recognition engine for bill images
+type OCREngine interface {
+	// Name returns the name of the ocr engine
+	Name() string
+
+	// RecognizeBillImage returns the transactions recognized from the bill image
+	RecognizeBillImage(c core.Context, uid int64, currentConfig *settings.Config, request *OCRRequest) ([]*models.RecognizedReceiptImageResult, error)
+}
+
+// OCRRequest represents the request to ocr engine
+type OCRRequest struct {
+	ImageData        []byte
+	ImageContentType string
+	ReferenceTime    time.Time
+
+	// Names below are only used as hints by the engines which support them
+	ExpenseCategoryNames  []string
+	IncomeCategoryNames   []string
+	TransferCategoryNames []string
+	AccountNames          []string
+	TagNames              []string
+}
@@ -0,0 +1,70 @@
+package ocr
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// OCREngineContainer contains the ocr engines in the configured order
+type OCREngineContainer struct {
+	engines []OCREngine
+}
+
+// Initialize an ocr engine container singleton instance
+var (
+	Container = &OCREngineContainer{}
+)
+
+// InitializeOCREngine initializes the ocr engines according to the config
+func InitializeOCREngine(config *settings.Config) error {
+	engines := make([]OCREngine, 0, len(config.OCREngines))
+
+	for i := 0; i < len(config.OCREngines); i++ {
+		if config.OCREngines[i] == settings.PaddleOCREngine {
+			engines = append(engines, PaddleOCREngineInstance)
+		} else if config.OCREngines[i] == settings.TesseractOCREngine {
+			engines = append(engines, TesseractOCREngineInstance)
+		} else if config.OCREngines[i] == settings.LLMOCREngine {
+			engines = append(engines, LargeLanguageModelOCREngineInstance)
+		} else {
+			return errs.ErrInvalidOCREngine
+		}
+	}
+
+	Container.engines = engines
+
+	return nil
+}
+
+// RecognizeBillImage returns the transactions recognized from the bill image by the first ocr engine which succeeds, the rest engines are used as fallback
+func (o *OCREngineContainer) RecognizeBillImage(c core.Context, uid int64, currentConfig *settings.Config, request *OCRRequest) ([]*models.RecognizedReceiptImageResult, error) {
+	if len(o.engines) < 1 {
+		return nil, errs.ErrInvalidOCREngine
+	}
+
+	var lastErr error
+
+	for i := 0; i < len(o.engines); i++ {
+		engine := o.engines[i]
+		results, err := engine.RecognizeBillImage(c, uid, currentConfig, request)
+
+		if err != nil {
+			log.Warnf(c, "[ocr_engine_container.RecognizeBillImage] ocr engine \"%s\" failed to recognize image for user \"uid:%d\", because %s", engine.Name(), uid, err.Error())
+			lastErr = err
+			continue
+		}
+
+		if len(results) < 1 {
+			log.Infof(c, "[ocr_engine_container.RecognizeBillImage] ocr engine \"%s\" recognized no transaction for user \"uid:%d\"", engine.Name(), uid)
+			lastErr = errs.ErrNoTransactionInformationInImage
+			continue
+		}
+
+		return results, nil
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,91 @@
+package ocr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+type testOCREngine struct {
+	name    string
+	results []*models.RecognizedReceiptImageResult
+	err     error
+	called  int
+}
+
+func (e *testOCREngine) Name() string {
+	return e.name
+}
+
+func (e *testOCREngine) RecognizeBillImage(c core.Context, uid int64, currentConfig *settings.Config, request *OCRRequest) ([]*models.RecognizedReceiptImageResult, error) {
+	e.called++
+	return e.results, e.err
+}
+
+func TestOCREngineContainerRecognizeBillImage_FirstEngineSucceeds(t *testing.T) {
+	engine1 := &testOCREngine{name: "engine1", results: []*models.RecognizedReceiptImageResult{{Type: "expense", Amount: "-1.00"}}}
+	engine2 := &testOCREngine{name: "engine2", results: []*models.RecognizedReceiptImageResult{{Type: "income", Amount: "2.00"}}}
+	container := &OCREngineContainer{engines: []OCREngine{engine1, engine2}}
+
+	results, err := container.RecognizeBillImage(core.NewNullContext(), 1, &settings.Config{}, &OCRRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "-1.00", results[0].Amount)
+	assert.Equal(t, 1, engine1.called)
+	assert.Equal(t, 0, engine2.called)
+}
+
+func TestOCREngineContainerRecognizeBillImage_FallbackWhenEngineFails(t *testing.T) {
+	engine1 := &testOCREngine{name: "engine1", err: errs.ErrTesseractNotAvailable}
+	engine2 := &testOCREngine{name: "engine2", results: []*models.RecognizedReceiptImageResult{}}
+	engine3 := &testOCREngine{name: "engine3", results: []*models.RecognizedReceiptImageResult{{Type: "income", Amount: "2.00"}}}
+	container := &OCREngineContainer{engines: []OCREngine{engine1, engine2, engine3}}
+
+	results, err := container.RecognizeBillImage(core.NewNullContext(), 1, &settings.Config{}, &OCRRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "2.00", results[0].Amount)
+	assert.Equal(t, 1, engine1.called)
+	assert.Equal(t, 1, engine2.called)
+	assert.Equal(t, 1, engine3.called)
+}
+
+func TestOCREngineContainerRecognizeBillImage_AllEnginesFail(t *testing.T) {
+	expectedErr := errors.New("engine2 failed")
+	engine1 := &testOCREngine{name: "engine1", results: []*models.RecognizedReceiptImageResult{}}
+	engine2 := &testOCREngine{name: "engine2", err: expectedErr}
+	container := &OCREngineContainer{engines: []OCREngine{engine1, engine2}}
+
+	results, err := container.RecognizeBillImage(core.NewNullContext(), 1, &settings.Config{}, &OCRRequest{})
+	assert.Nil(t, results)
+	assert.Equal(t, expectedErr, err)
+
+	container = &OCREngineContainer{engines: []OCREngine{engine2, engine1}}
+
+	results, err = container.RecognizeBillImage(core.NewNullContext(), 1, &settings.Config{}, &OCRRequest{})
+	assert.Nil(t, results)
+	assert.Equal(t, errs.ErrNoTransactionInformationInImage, err)
+}
+
+func TestOCREngineContainerRecognizeBillImage_NoEngine(t *testing.T) {
+	container := &OCREngineContainer{}
+
+	results, err := container.RecognizeBillImage(core.NewNullContext(), 1, &settings.Config{}, &OCRRequest{})
+	assert.Nil(t, results)
+	assert.Equal(t, errs.ErrInvalidOCREngine, err)
+}
+
+func TestInitializeOCREngine(t *testing.T) {
+	err := InitializeOCREngine(&settings.Config{OCREngines: []string{settings.TesseractOCREngine, settings.PaddleOCREngine, settings.LLMOCREngine}})
+	assert.Nil(t, err)
+	assert.Equal(t, []OCREngine{TesseractOCREngineInstance, PaddleOCREngineInstance, LargeLanguageModelOCREngineInstance}, Container.engines)
+
+	err = InitializeOCREngine(&settings.Config{OCREngines: []string{"unknown"}})
+	assert.Equal(t, errs.ErrInvalidOCREngine, err)
+}
@@ -0,0 +1,85 @@
+package ocr
+
+import (
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// PaddleOCREngine represents the ocr engine which uses the external PaddleOCR HTTP service
+type PaddleOCREngine struct{}
+
+// Initialize a paddle ocr engine singleton instance
+var (
+	PaddleOCREngineInstance = &PaddleOCREngine{}
+)
+
+// Name returns the name of the ocr engine
+func (e *PaddleOCREngine) Name() string {
+	return settings.PaddleOCREngine
+}
+
+// RecognizeBillImage returns the transactions recognized from the bill image by the external PaddleOCR HTTP service
+func (e *PaddleOCREngine) RecognizeBillImage(c core.Context, uid int64, currentConfig *settings.Config, request *OCRRequest) ([]*models.RecognizedReceiptImageResult, error) {
+	rawItems, err := RunPaddleBillOCR(request.ImageData, currentConfig.PaddleBillOCREndpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.RecognizedReceiptImageResult, 0, len(rawItems))
+
+	for i := 0; i < len(rawItems); i++ {
+		result := e.toRecognizedReceiptImageResult(rawItems[i], request)
+
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func (e *PaddleOCREngine) toRecognizedReceiptImageResult(raw PaddleBillOCRRawItem, request *OCRRequest) *models.RecognizedReceiptImageResult {
+	amount := strings.TrimSpace(raw.Amount)
+
+	// Transaction cannot be created without amount
+	if amount == "" {
+		return nil
+	}
+
+	result := &models.RecognizedReceiptImageResult{
+		Amount:      amount,
+		Type:        "income",
+		AccountName: strings.TrimSpace(raw.Account),
+		Description: strings.TrimSpace(raw.Text),
+	}
+
+	if amount[0] == '-' {
+		result.Type = "expense"
+	}
+
+	if date := strings.TrimSpace(raw.Date); date != "" {
+		result.Time = date
+	} else {
+		result.Time = request.ReferenceTime.Format("2006-01-02 15:04:05")
+	}
+
+	// Use the last part of classify as category name, e.g. "食品饮料-食品" => "食品"
+	if classify := strings.TrimSpace(raw.Classify); classify != "" {
+		parts := strings.Split(classify, "-")
+		result.CategoryName = strings.TrimSpace(parts[len(parts)-1])
+	}
+
+	if label := strings.TrimSpace(raw.Label); label != "" {
+		result.TagNames = []string{label}
+	}
+
+	if project := strings.TrimSpace(raw.Project); project != "" {
+		result.ItemNames = []string{project}
+	}
+
+	return result
+}
@@ -0,0 +1,50 @@
+package ocr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaddleOCREngineToRecognizedReceiptImageResult(t *testing.T) {
+	request := &OCRRequest{
+		ReferenceTime: time.Date(2026, 2, 11, 8, 30, 0, 0, time.UTC),
+	}
+
+	result := PaddleOCREngineInstance.toRecognizedReceiptImageResult(PaddleBillOCRRawItem{
+		Amount:   " -123.45 ",
+		Classify: "食品饮料-食品",
+		Account:  "微信",
+		Date:     "2026-02-10 22:31:24",
+		Project:  "项目1",
+		Label:    "标签1",
+		Text:     "2月7日 21:49 京东超市 -100.00",
+	}, request)
+
+	assert.Equal(t, "expense", result.Type)
+	assert.Equal(t, "-123.45", result.Amount)
+	assert.Equal(t, "食品", result.CategoryName)
+	assert.Equal(t, "微信", result.AccountName)
+	assert.Equal(t, "2026-02-10 22:31:24", result.Time)
+	assert.Equal(t, []string{"项目1"}, result.ItemNames)
+	assert.Equal(t, []string{"标签1"}, result.TagNames)
+	assert.Equal(t, "2月7日 21:49 京东超市 -100.00", result.Description)
+
+	result = PaddleOCREngineInstance.toRecognizedReceiptImageResult(PaddleBillOCRRawItem{
+		Amount: "88.00",
+	}, request)
+
+	assert.Equal(t, "income", result.Type)
+	assert.Equal(t, "2026-02-11 08:30:00", result.Time)
+	assert.Equal(t, "", result.CategoryName)
+	assert.Nil(t, result.TagNames)
+	assert.Nil(t, result.ItemNames)
+
+	result = PaddleOCREngineInstance.toRecognizedReceiptImageResult(PaddleBillOCRRawItem{
+		Amount: "  ",
+		Text:   "no amount",
+	}, request)
+
+	assert.Nil(t, result)
+}
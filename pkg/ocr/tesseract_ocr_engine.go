@@ -0,0 +1,66 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+const tesseractCommandTimeout = 30 * time.Second
+
+// TesseractOCREngine represents the ocr engine which uses the local tesseract command line tool
+type TesseractOCREngine struct{}
+
+// Initialize a tesseract ocr engine singleton instance
+var (
+	TesseractOCREngineInstance = &TesseractOCREngine{}
+)
+
+// Name returns the name of the ocr engine
+func (e *TesseractOCREngine) Name() string {
+	return settings.TesseractOCREngine
+}
+
+// RecognizeBillImage returns the transactions parsed from the text which local tesseract recognized from the bill image
+func (e *TesseractOCREngine) RecognizeBillImage(c core.Context, uid int64, currentConfig *settings.Config, request *OCRRequest) ([]*models.RecognizedReceiptImageResult, error) {
+	if len(request.ImageData) == 0 {
+		return nil, fmt.Errorf("image data is empty")
+	}
+
+	tesseractPath, err := exec.LookPath(currentConfig.TesseractPath)
+
+	if err != nil {
+		return nil, errs.ErrTesseractNotAvailable
+	}
+
+	ctx, cancel := context.WithTimeout(c, tesseractCommandTimeout)
+	defer cancel()
+
+	args := []string{"stdin", "stdout"}
+
+	if currentConfig.TesseractLanguages != "" {
+		args = append(args, "-l", currentConfig.TesseractLanguages)
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, tesseractPath, args...)
+	cmd.Stdin = bytes.NewReader(request.ImageData)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run tesseract failed: %w, %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return ParseBillListText(stdout.String(), request.ReferenceTime), nil
+}
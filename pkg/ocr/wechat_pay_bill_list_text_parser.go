@@ -0,0 +1,80 @@
+package ocr
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const wechatPayBillListDefaultAccountName = "微信"
+
+var wechatPayBillListLayoutKeywords = []string{"微信", "零钱", "扫二维码付款", "财付通"}
+var wechatPayBillListIncomeKeywords = []string{"退款", "收款", "来自", "转入"}
+
+// WeChatPayBillListTextParser represents the parser for WeChat Pay bill list layout, each transaction has a merchant line,
+// a "M月D日 HH:MM" line and an amount line, e.g. "美团", "2月6日 21:49" and "-150.00"
+type WeChatPayBillListTextParser struct{}
+
+// Initialize a wechat pay bill list text parser singleton instance
+var (
+	WeChatPayBillListTextParserInstance = &WeChatPayBillListTextParser{}
+)
+
+// Name returns the layout name of the parser
+func (p *WeChatPayBillListTextParser) Name() string {
+	return "wechat_pay"
+}
+
+// IsLayoutMatched returns whether the text lines are in the layout which the parser supports
+func (p *WeChatPayBillListTextParser) IsLayoutMatched(lines []string) bool {
+	return containsAnyBillListKeyword(lines, wechatPayBillListLayoutKeywords)
+}
+
+// Parse returns the transactions parsed from the text lines, the amount can also be at the end of merchant line
+func (p *WeChatPayBillListTextParser) Parse(lines []string, refTime time.Time) []*models.RecognizedReceiptImageResult {
+	var results []*models.RecognizedReceiptImageResult
+
+	for i := 1; i < len(lines); i++ {
+		prefix, dateTime, ok := parseBillListChineseDateTime(lines[i], refTime)
+
+		if !ok || prefix != "" {
+			continue
+		}
+
+		merchant := lines[i-1]
+		amountText := ""
+
+		if i+1 < len(lines) {
+			if _, _, isAmount := parseBillListAmount(lines[i+1]); isAmount {
+				amountText = lines[i+1]
+			}
+		}
+
+		if amountText == "" {
+			merchantWithoutAmount, trailingAmount, hasAmount := splitBillListTrailingAmount(merchant)
+
+			if !hasAmount {
+				continue
+			}
+
+			merchant = merchantWithoutAmount
+			amountText = trailingAmount
+		}
+
+		amount, sign, ok := parseBillListAmount(amountText)
+
+		if !ok || merchant == "" {
+			continue
+		}
+
+		results = append(results, &models.RecognizedReceiptImageResult{
+			Type:        getBillListTransactionType(sign, merchant, wechatPayBillListIncomeKeywords, "expense"),
+			Time:        dateTime,
+			Amount:      amount,
+			AccountName: wechatPayBillListDefaultAccountName,
+			Description: merchant,
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,80 @@
+package reports
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// FinancialReportGenerator represents financial report generator
+type FinancialReportGenerator struct {
+	transactions          *services.TransactionService
+	transactionCategories *services.TransactionCategoryService
+	accounts              *services.AccountService
+}
+
+// Initialize a financial report generator singleton instance
+var (
+	FinancialReports = &FinancialReportGenerator{
+		transactions:          services.Transactions,
+		transactionCategories: services.TransactionCategories,
+		accounts:              services.Accounts,
+	}
+)
+
+// GenerateFinancialReport returns the financial report of the user within the specified period, all the amounts are exchanged to the default currency of the user
+func (g *FinancialReportGenerator) GenerateFinancialReport(c core.Context, user *models.User, periodType models.FinancialReportPeriodType, startTime time.Time, endTime time.Time, currentConfig *settings.Config) (*models.FinancialReport, error) {
+	uid := user.Uid
+	accounts, err := g.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[financial_report_generator.GenerateFinancialReport] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	categories, err := g.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[financial_report_generator.GenerateFinancialReport] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	exchangeRates, err := exchangerates.Container.GetHistoricalExchangeRateMap(c, uid, startTime.Unix(), endTime.Unix(), currentConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	amountExchanger := models.NewTransactionAmountExchanger(user.DefaultCurrency, accounts, exchangeRates)
+	categoryTotalAmounts, err := g.transactions.GetAccountsAndCategoriesTotalInflowAndOutflow(c, uid, startTime.Unix(), endTime.Unix(), nil, false, nil, false, "", startTime.Location(), false, amountExchanger)
+
+	if err != nil {
+		log.Errorf(c, "[financial_report_generator.GenerateFinancialReport] failed to get categories total amounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(endTime.Unix())
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(startTime.Unix())
+	accountDailyBalances, err := g.transactions.GetAllAccountsDailyOpeningAndClosingBalance(c, uid, maxTransactionTime, minTransactionTime, startTime.Location())
+
+	if err != nil {
+		log.Errorf(c, "[financial_report_generator.GenerateFinancialReport] failed to get account balances for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	report, ok := models.NewFinancialReport(periodType, startTime, endTime, user.DefaultCurrency, categories, categoryTotalAmounts, accounts, models.GetAccountsClosingBalances(accountDailyBalances), exchangeRates)
+
+	if !ok {
+		log.Warnf(c, "[financial_report_generator.GenerateFinancialReport] cannot exchange account balances to \"%s\" for user \"uid:%d\"", user.DefaultCurrency, uid)
+		return nil, errs.ErrExchangeRateNotFoundForAccountBalance
+	}
+
+	return report, nil
+}
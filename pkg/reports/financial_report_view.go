@@ -0,0 +1,112 @@
+package reports
+
+import (
+	"fmt"
+
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// financialReportView represents the formatted financial report which is used by all the report renderers
+type financialReportView struct {
+	Language         string
+	AppName          string
+	Text             *locales.FinancialReportTextItems
+	Period           string
+	AsOfDate         string
+	Currency         string
+	IncomeItems      []*financialReportViewItem
+	ExpenseItems     []*financialReportViewItem
+	TotalIncome      string
+	TotalExpense     string
+	NetIncome        string
+	AssetItems       []*financialReportViewItem
+	LiabilityItems   []*financialReportViewItem
+	TotalAssets      string
+	TotalLiabilities string
+	NetAssets        string
+}
+
+// financialReportViewItem represents a formatted category or account row in the financial report
+type financialReportViewItem struct {
+	Name           string
+	Amount         string
+	Percentage     string
+	OriginalAmount string
+	SubItems       []*financialReportViewItem
+}
+
+func newFinancialReportView(report *models.FinancialReport, user *models.User, backupLocale string) *financialReportView {
+	locale := getReportLocale(user, backupLocale)
+	localeTextItems := locales.GetLocaleTextItems(locale)
+	formatter := NewReportFormatter(user, localeTextItems)
+	textItems := localeTextItems.FinancialReportTextItems
+
+	return &financialReportView{
+		Language:         locale,
+		AppName:          localeTextItems.GlobalTextItems.AppName,
+		Text:             textItems,
+		Period:           formatter.FormatDate(report.StartTime) + " - " + formatter.FormatDate(report.EndTime),
+		AsOfDate:         fmt.Sprintf(textItems.AsOfDateFormat, formatter.FormatDate(report.EndTime)),
+		Currency:         report.Currency,
+		IncomeItems:      getFinancialReportCategoryViewItems(report.IncomeCategories, formatter),
+		ExpenseItems:     getFinancialReportCategoryViewItems(report.ExpenseCategories, formatter),
+		TotalIncome:      formatter.FormatAmount(report.TotalIncome),
+		TotalExpense:     formatter.FormatAmount(report.TotalExpense),
+		NetIncome:        formatter.FormatAmount(report.NetIncome),
+		AssetItems:       getFinancialReportAccountViewItems(report.Assets, report.Currency, formatter),
+		LiabilityItems:   getFinancialReportAccountViewItems(report.Liabilities, report.Currency, formatter),
+		TotalAssets:      formatter.FormatAmount(report.TotalAssets),
+		TotalLiabilities: formatter.FormatAmount(report.TotalLiabilities),
+		NetAssets:        formatter.FormatAmount(report.NetAssets),
+	}
+}
+
+func getFinancialReportCategoryViewItems(categoryItems []*models.FinancialReportCategoryItem, formatter *ReportFormatter) []*financialReportViewItem {
+	viewItems := make([]*financialReportViewItem, len(categoryItems))
+
+	for i := 0; i < len(categoryItems); i++ {
+		categoryItem := categoryItems[i]
+		viewItems[i] = &financialReportViewItem{
+			Name:       categoryItem.Name,
+			Amount:     formatter.FormatAmount(categoryItem.Amount),
+			Percentage: formatter.FormatPercentage(categoryItem.Percentage),
+			SubItems:   getFinancialReportCategoryViewItems(categoryItem.SubCategories, formatter),
+		}
+	}
+
+	return viewItems
+}
+
+func getFinancialReportAccountViewItems(accountItems []*models.FinancialReportAccountItem, currency string, formatter *ReportFormatter) []*financialReportViewItem {
+	viewItems := make([]*financialReportViewItem, len(accountItems))
+
+	for i := 0; i < len(accountItems); i++ {
+		accountItem := accountItems[i]
+		viewItems[i] = &financialReportViewItem{
+			Name:     accountItem.Name,
+			Amount:   formatter.FormatAmount(accountItem.Amount),
+			SubItems: make([]*financialReportViewItem, 0),
+		}
+
+		if accountItem.Currency != currency {
+			viewItems[i].OriginalAmount = formatter.FormatAmountWithCurrency(accountItem.Balance, accountItem.Currency)
+		}
+	}
+
+	return viewItems
+}
+
+func getReportLocale(user *models.User, backupLocale string) string {
+	locale := user.Language
+
+	if locale == "" {
+		locale = backupLocale
+	}
+
+	if _, exists := locales.AllLanguages[locale]; !exists {
+		locale = "en"
+	}
+
+	return locale
+}
@@ -0,0 +1,138 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// ReportFormatter formats the amounts and dates in reports according to the user preferences,
+// the default types of the user language are used if the user does not set the preferences
+type ReportFormatter struct {
+	decimalSeparator    string
+	digitGroupingSymbol string
+	digitGrouping       core.DigitGroupingType
+	longDateFormat      core.LongDateFormat
+}
+
+// NewReportFormatter returns a new report formatter according to the user preferences and the locale text items of the user language
+func NewReportFormatter(user *models.User, localeTextItems *locales.LocaleTextItems) *ReportFormatter {
+	decimalSeparator := user.DecimalSeparator
+	digitGroupingSymbol := user.DigitGroupingSymbol
+	longDateFormat := user.LongDateFormat
+
+	if decimalSeparator == core.DECIMAL_SEPARATOR_DEFAULT {
+		decimalSeparator = localeTextItems.DefaultTypes.DecimalSeparator
+	}
+
+	if digitGroupingSymbol == core.DIGIT_GROUPING_SYMBOL_DEFAULT {
+		digitGroupingSymbol = localeTextItems.DefaultTypes.DigitGroupingSymbol
+	}
+
+	if longDateFormat == core.LONG_DATE_FORMAT_DEFAULT {
+		longDateFormat = localeTextItems.DefaultTypes.LongDateFormat
+	}
+
+	return &ReportFormatter{
+		decimalSeparator:    getDecimalSeparatorText(decimalSeparator),
+		digitGroupingSymbol: getDigitGroupingSymbolText(digitGroupingSymbol),
+		digitGrouping:       user.DigitGrouping,
+		longDateFormat:      longDateFormat,
+	}
+}
+
+// FormatAmount returns the textual representation of the amount with the decimal separator and the digit grouping symbol
+func (f *ReportFormatter) FormatAmount(amount int64) string {
+	displayAmount := utils.FormatAmount(amount)
+	negative := displayAmount[0] == '-'
+
+	if negative {
+		displayAmount = displayAmount[1:]
+	}
+
+	integer := displayAmount[:len(displayAmount)-3]
+	decimals := displayAmount[len(displayAmount)-2:]
+	result := f.groupDigits(integer) + f.decimalSeparator + decimals
+
+	if negative {
+		return "-" + result
+	}
+
+	return result
+}
+
+// FormatAmountWithCurrency returns the textual representation of the amount with the currency code before it
+func (f *ReportFormatter) FormatAmountWithCurrency(amount int64, currency string) string {
+	return currency + " " + f.FormatAmount(amount)
+}
+
+// FormatPercentage returns the textual representation of the percentage with two decimal places
+func (f *ReportFormatter) FormatPercentage(percentage float64) string {
+	return strings.Replace(fmt.Sprintf("%.2f", percentage), ".", f.decimalSeparator, 1) + "%"
+}
+
+// FormatDate returns the textual representation of the date in the long date format
+func (f *ReportFormatter) FormatDate(date time.Time) string {
+	switch f.longDateFormat {
+	case core.LONG_DATE_FORMAT_M_D_YYYY:
+		return date.Format("01/02/2006")
+	case core.LONG_DATE_FORMAT_D_M_YYYY:
+		return date.Format("02/01/2006")
+	default:
+		return date.Format("2006-01-02")
+	}
+}
+
+func (f *ReportFormatter) groupDigits(integer string) string {
+	if f.digitGrouping == core.DIGIT_GROUPING_TYPE_NONE || len(integer) <= 3 {
+		return integer
+	}
+
+	groups := make([]string, 0, len(integer)/2+1)
+	groups = append(groups, integer[len(integer)-3:])
+	integer = integer[:len(integer)-3]
+	groupSize := 3
+
+	if f.digitGrouping == core.DIGIT_GROUPING_TYPE_INDIAN_NUMBER_GROUPING {
+		groupSize = 2
+	}
+
+	for len(integer) > groupSize {
+		groups = append(groups, integer[len(integer)-groupSize:])
+		integer = integer[:len(integer)-groupSize]
+	}
+
+	groups = append(groups, integer)
+
+	for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
+		groups[i], groups[j] = groups[j], groups[i]
+	}
+
+	return strings.Join(groups, f.digitGroupingSymbol)
+}
+
+func getDecimalSeparatorText(decimalSeparator core.DecimalSeparator) string {
+	if decimalSeparator == core.DECIMAL_SEPARATOR_COMMA {
+		return ","
+	}
+
+	return "."
+}
+
+func getDigitGroupingSymbolText(digitGroupingSymbol core.DigitGroupingSymbol) string {
+	switch digitGroupingSymbol {
+	case core.DIGIT_GROUPING_SYMBOL_DOT:
+		return "."
+	case core.DIGIT_GROUPING_SYMBOL_SPACE:
+		return " "
+	case core.DIGIT_GROUPING_SYMBOL_APOSTROPHE:
+		return "'"
+	default:
+		return ","
+	}
+}
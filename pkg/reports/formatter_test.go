@@ -0,0 +1,72 @@
+package reports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestReportFormatterFormatAmount_DefaultTypes(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("en"))
+
+	assert.Equal(t, "0.00", formatter.FormatAmount(0))
+	assert.Equal(t, "0.05", formatter.FormatAmount(5))
+	assert.Equal(t, "-0.50", formatter.FormatAmount(-50))
+	assert.Equal(t, "999.99", formatter.FormatAmount(99999))
+	assert.Equal(t, "1,000.00", formatter.FormatAmount(100000))
+	assert.Equal(t, "-1,234,567.89", formatter.FormatAmount(-123456789))
+
+	formatter = NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("de"))
+	assert.Equal(t, "1.234.567,89", formatter.FormatAmount(123456789))
+
+	formatter = NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("fr"))
+	assert.Equal(t, "1 234 567,89", formatter.FormatAmount(123456789))
+}
+
+func TestReportFormatterFormatAmount_UserPreferences(t *testing.T) {
+	user := &models.User{
+		DecimalSeparator:    core.DECIMAL_SEPARATOR_DOT,
+		DigitGroupingSymbol: core.DIGIT_GROUPING_SYMBOL_APOSTROPHE,
+	}
+	formatter := NewReportFormatter(user, locales.GetLocaleTextItems("de"))
+	assert.Equal(t, "1'234'567.89", formatter.FormatAmount(123456789))
+
+	user.DigitGrouping = core.DIGIT_GROUPING_TYPE_NONE
+	formatter = NewReportFormatter(user, locales.GetLocaleTextItems("de"))
+	assert.Equal(t, "1234567.89", formatter.FormatAmount(123456789))
+
+	user.DigitGrouping = core.DIGIT_GROUPING_TYPE_INDIAN_NUMBER_GROUPING
+	user.DigitGroupingSymbol = core.DIGIT_GROUPING_SYMBOL_COMMA
+	formatter = NewReportFormatter(user, locales.GetLocaleTextItems("de"))
+	assert.Equal(t, "1,23,45,678.90", formatter.FormatAmount(1234567890))
+	assert.Equal(t, "-12,345.67", formatter.FormatAmount(-1234567))
+}
+
+func TestReportFormatterFormatPercentage(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("en"))
+	assert.Equal(t, "12.35%", formatter.FormatPercentage(12.345678))
+	assert.Equal(t, "100.00%", formatter.FormatPercentage(100))
+
+	formatter = NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("de"))
+	assert.Equal(t, "33,33%", formatter.FormatPercentage(100.0/3))
+}
+
+func TestReportFormatterFormatDate(t *testing.T) {
+	date := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "03/09/2024", NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("en")).FormatDate(date))
+	assert.Equal(t, "09/03/2024", NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("de")).FormatDate(date))
+	assert.Equal(t, "2024-03-09", NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("zh-Hans")).FormatDate(date))
+	assert.Equal(t, "2024-03-09", NewReportFormatter(&models.User{LongDateFormat: core.LONG_DATE_FORMAT_YYYY_M_D}, locales.GetLocaleTextItems("en")).FormatDate(date))
+}
+
+func TestReportFormatterFormatAmountWithCurrency(t *testing.T) {
+	formatter := NewReportFormatter(&models.User{}, locales.GetLocaleTextItems("en"))
+	assert.Equal(t, "USD 1,234.56", formatter.FormatAmountWithCurrency(123456, "USD"))
+	assert.Equal(t, "EUR -0.05", formatter.FormatAmountWithCurrency(-5, "EUR"))
+}
@@ -0,0 +1,26 @@
+package reports
+
+import (
+	"bytes"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/templates"
+)
+
+// RenderFinancialReportToHtml returns the html document of the financial report in the user language and formats
+func RenderFinancialReportToHtml(report *models.FinancialReport, user *models.User, backupLocale string) ([]byte, error) {
+	tmpl, err := templates.GetTemplate(templates.TEMPLATE_FINANCIAL_REPORT)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyBuffer bytes.Buffer
+	err = tmpl.Execute(&bodyBuffer, newFinancialReportView(report, user, backupLocale))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bodyBuffer.Bytes(), nil
+}
@@ -0,0 +1,207 @@
+package reports
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const pageCountForInsightsExplorerQuery = 1000
+
+// InsightsExplorerQueryExecutor represents insights explorer query executor
+type InsightsExplorerQueryExecutor struct {
+	transactions          *services.TransactionService
+	transactionCategories *services.TransactionCategoryService
+	transactionTags       *services.TransactionTagService
+	transactionItems      *services.TransactionItemService
+	transactionPictures   *services.TransactionPictureService
+	accounts              *services.AccountService
+}
+
+// Initialize an insights explorer query executor singleton instance
+var (
+	InsightsExplorerQueries = &InsightsExplorerQueryExecutor{
+		transactions:          services.Transactions,
+		transactionCategories: services.TransactionCategories,
+		transactionTags:       services.TransactionTags,
+		transactionItems:      services.TransactionItems,
+		transactionPictures:   services.TransactionPictures,
+		accounts:              services.Accounts,
+	}
+)
+
+// ExecuteQuery returns the aggregated result of the transactions of the user within the specified time range which match the query definition,
+// the start time and end time are unix time, zero end time means now and zero start time means the maximum query days before the end time,
+// the split transactions are replaced by their split lines, and all the amounts are exchanged to the default currency of the user
+func (e *InsightsExplorerQueryExecutor) ExecuteQuery(c core.Context, user *models.User, definition *models.InsightsExplorerQueryDefinition, startTime int64, endTime int64, timezone *time.Location, currentConfig *settings.Config) (*models.InsightsExplorerQueryResultResponse, error) {
+	uid := user.Uid
+	maxQuerySeconds := int64(models.MaximumInsightsExplorerQueryDays) * 24 * 60 * 60
+
+	if endTime <= 0 {
+		endTime = time.Now().Unix()
+	}
+
+	if startTime <= 0 {
+		startTime = endTime - maxQuerySeconds
+	}
+
+	if endTime < startTime || endTime-startTime > maxQuerySeconds {
+		log.Warnf(c, "[insights_explorer_query_executor.ExecuteQuery] query time range from \"%d\" to \"%d\" is invalid", startTime, endTime)
+		return nil, errs.ErrInsightsExplorerQueryTimeRangeInvalid
+	}
+
+	accounts, err := e.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	categories, err := e.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	tags, err := e.transactionTags.GetAllTagsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get tags for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	items, err := e.transactionItems.GetAllItemsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get items for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(endTime)
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(max(startTime, 0))
+
+	transactions, err := e.transactions.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, 0, nil, nil, nil, false, nil, false, "", "", pageCountForInsightsExplorerQuery, true)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	allSplits, err := e.transactions.GetTransactionSplitsMapByTimeRange(c, uid, maxTransactionTime, minTransactionTime)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get transaction split lines for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	queryContext := &models.InsightsExplorerQueryContext{
+		DefaultCurrency: user.DefaultCurrency,
+		FirstDayOfWeek:  user.FirstDayOfWeek,
+		FiscalYearStart: user.FiscalYearStart,
+		Timezone:        timezone,
+		AccountMap:      e.accounts.GetAccountMapByList(accounts),
+		TagMap:          e.transactionTags.GetTagMapByList(tags),
+		ItemMap:         e.transactionItems.GetItemMapByList(items),
+	}
+
+	if len(transactions) < 1 {
+		return models.NewInsightsExplorerQueryResult(definition, nil, queryContext), nil
+	}
+
+	transactionIds := make([]int64, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transactionIds[i] = transactions[i].TransactionId
+	}
+
+	allTransactionTagIds, err := e.transactionTags.GetAllTagIdsOfTransactions(c, uid, transactionIds)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get transactions tag ids for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	allTransactionItemIds, err := e.transactionItems.GetAllItemIdsOfTransactions(c, uid, transactionIds)
+
+	if err != nil {
+		log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get transactions item ids for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	var allTransactionPictureInfos map[int64][]*models.TransactionPictureInfo
+
+	if currentConfig.EnableTransactionPictures && definition.HasConditionField(models.INSIGHTS_EXPLORER_CONDITION_FIELD_PICTURES) {
+		allTransactionPictureInfos, err = e.transactionPictures.GetPictureInfosByTransactionIds(c, uid, transactionIds)
+
+		if err != nil {
+			log.Errorf(c, "[insights_explorer_query_executor.ExecuteQuery] failed to get transactions pictures for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, err
+		}
+	}
+
+	// the transactions are sorted by transaction time in descending order
+	minUnixTime := utils.GetUnixTimeFromTransactionTime(transactions[len(transactions)-1].TransactionTime)
+	maxUnixTime := utils.GetUnixTimeFromTransactionTime(transactions[0].TransactionTime)
+	exchangeRates, err := exchangerates.Container.GetHistoricalExchangeRateMap(c, uid, minUnixTime, maxUnixTime, currentConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactions = e.transactions.GetSplitTransactions(transactions, allSplits)
+	amountExchanger := models.NewTransactionAmountExchanger(user.DefaultCurrency, accounts, exchangeRates)
+	categoryMap := e.transactionCategories.GetCategoryMapByList(categories)
+	explorerTransactions := make([]*models.InsightsExplorerTransaction, 0, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		transactionType, err := transaction.Type.ToTransactionType()
+
+		if err != nil {
+			log.Warnf(c, "[insights_explorer_query_executor.ExecuteQuery] transaction \"id:%d\" of user \"uid:%d\" has invalid type, because %s", transaction.TransactionId, uid, err.Error())
+			continue
+		}
+
+		sourceAccount, exists := queryContext.AccountMap[transaction.AccountId]
+
+		if !exists {
+			log.Warnf(c, "[insights_explorer_query_executor.ExecuteQuery] account \"id:%d\" of transaction \"id:%d\" does not exist for user \"uid:%d\"", transaction.AccountId, transaction.TransactionId, uid)
+			continue
+		}
+
+		explorerTransaction := &models.InsightsExplorerTransaction{
+			Transaction:   transaction,
+			Type:          transactionType,
+			SourceAccount: sourceAccount,
+			TagIds:        allTransactionTagIds[transaction.TransactionId],
+			ItemIds:       allTransactionItemIds[transaction.TransactionId],
+			HasPictures:   len(allTransactionPictureInfos[transaction.TransactionId]) > 0,
+		}
+
+		if transactionType == models.TRANSACTION_TYPE_TRANSFER {
+			explorerTransaction.DestinationAccount = queryContext.AccountMap[transaction.RelatedAccountId]
+		}
+
+		if category, exists := categoryMap[transaction.CategoryId]; exists {
+			if parentCategory, exists := categoryMap[category.ParentCategoryId]; exists {
+				explorerTransaction.PrimaryCategory = parentCategory
+				explorerTransaction.SecondaryCategory = category
+			} else {
+				explorerTransaction.PrimaryCategory = category
+			}
+		}
+
+		explorerTransaction.AmountInDefaultCurrency, explorerTransaction.AmountExchanged = amountExchanger.ExchangeTransactionAmount(transaction)
+		explorerTransactions = append(explorerTransactions, explorerTransaction)
+	}
+
+	return models.NewInsightsExplorerQueryResult(definition, explorerTransactions, queryContext), nil
+}
@@ -0,0 +1,314 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const pdfPageWidth = 595.28
+const pdfPageHeight = 841.89
+
+const pdfRegularFontName = "F1"
+const pdfBoldFontName = "F2"
+const pdfUnicodeFontName = "F3"
+
+// pdfUnicodeFont represents a predefined CJK font of the pdf reader, which is used for the text cannot be encoded in WinAnsiEncoding
+type pdfUnicodeFont struct {
+	baseFont   string
+	encoding   string
+	ordering   string
+	supplement int
+}
+
+var pdfSimplifiedChineseFont = &pdfUnicodeFont{baseFont: "STSong-Light", encoding: "UniGB-UCS2-H", ordering: "GB1", supplement: 2}
+var pdfTraditionalChineseFont = &pdfUnicodeFont{baseFont: "MSung-Light", encoding: "UniCNS-UCS2-H", ordering: "CNS1", supplement: 0}
+var pdfJapaneseFont = &pdfUnicodeFont{baseFont: "HeiseiMin-W3", encoding: "UniJIS-UCS2-H", ordering: "Japan1", supplement: 2}
+var pdfKoreanFont = &pdfUnicodeFont{baseFont: "HYSMyeongJo-Medium", encoding: "UniKS-UCS2-H", ordering: "Korea1", supplement: 1}
+
+var pdfUnicodeFonts = map[string]*pdfUnicodeFont{
+	"zh-Hans": pdfSimplifiedChineseFont,
+	"zh-Hant": pdfTraditionalChineseFont,
+	"ja":      pdfJapaneseFont,
+	"ko":      pdfKoreanFont,
+}
+
+// The widths of printable ascii characters (from 0x20 to 0x7e) in Helvetica font
+var pdfHelveticaCharWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+const pdfHelveticaDefaultCharWidth = 556
+const pdfUnicodeFontCharWidth = 1000
+
+// The characters of WinAnsiEncoding which are not the same as ISO-8859-1
+var pdfWinAnsiSpecialChars = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b,
+	'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// pdfDocument represents a minimal pdf document writer which only supports drawing text, lines and rectangles on A4 pages,
+// the text which can be encoded in WinAnsiEncoding is drawn with the standard Helvetica fonts,
+// and the other text is drawn with the predefined CJK font of the document language, the fonts are not embedded so the glyphs depend on the pdf reader
+type pdfDocument struct {
+	title       string
+	producer    string
+	unicodeFont *pdfUnicodeFont
+	pages       []*bytes.Buffer
+}
+
+type pdfTextRun struct {
+	unicode bool
+	content []byte
+}
+
+func newPdfDocument(locale string, title string, producer string) *pdfDocument {
+	unicodeFont, exists := pdfUnicodeFonts[locale]
+
+	if !exists {
+		unicodeFont = pdfSimplifiedChineseFont
+	}
+
+	return &pdfDocument{
+		title:       title,
+		producer:    producer,
+		unicodeFont: unicodeFont,
+		pages:       make([]*bytes.Buffer, 0),
+	}
+}
+
+// addPage appends a new empty page to the document, all the subsequent drawing will be on this page
+func (d *pdfDocument) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// drawText draws the text whose baseline starts at the specified position
+func (d *pdfDocument) drawText(x float64, y float64, fontSize float64, bold bool, gray float64, text string) {
+	page := d.currentPage()
+	runs := d.splitTextRuns(text)
+
+	if len(runs) < 1 {
+		return
+	}
+
+	page.WriteString(fmt.Sprintf("BT %s g 1 0 0 1 %s %s Tm\n", formatPdfNumber(gray), formatPdfNumber(x), formatPdfNumber(y)))
+
+	for i := 0; i < len(runs); i++ {
+		run := runs[i]
+
+		if run.unicode {
+			page.WriteString(fmt.Sprintf("/%s %s Tf <%X> Tj\n", pdfUnicodeFontName, formatPdfNumber(fontSize), run.content))
+		} else {
+			fontName := pdfRegularFontName
+
+			if bold {
+				fontName = pdfBoldFontName
+			}
+
+			page.WriteString(fmt.Sprintf("/%s %s Tf (%s) Tj\n", fontName, formatPdfNumber(fontSize), escapePdfString(run.content)))
+		}
+	}
+
+	page.WriteString("ET\n")
+}
+
+// drawTextRightAligned draws the text whose baseline ends at the specified position
+func (d *pdfDocument) drawTextRightAligned(x float64, y float64, fontSize float64, bold bool, gray float64, text string) {
+	d.drawText(x-d.getTextWidth(text, fontSize), y, fontSize, bold, gray, text)
+}
+
+// drawLine draws a straight line between the specified positions
+func (d *pdfDocument) drawLine(x1 float64, y1 float64, x2 float64, y2 float64, lineWidth float64, gray float64) {
+	d.currentPage().WriteString(fmt.Sprintf("%s w %s G %s %s m %s %s l S\n", formatPdfNumber(lineWidth), formatPdfNumber(gray), formatPdfNumber(x1), formatPdfNumber(y1), formatPdfNumber(x2), formatPdfNumber(y2)))
+}
+
+// fillRect fills the rectangle whose lower left corner is at the specified position
+func (d *pdfDocument) fillRect(x float64, y float64, width float64, height float64, gray float64) {
+	d.currentPage().WriteString(fmt.Sprintf("%s g %s %s %s %s re f\n", formatPdfNumber(gray), formatPdfNumber(x), formatPdfNumber(y), formatPdfNumber(width), formatPdfNumber(height)))
+}
+
+// getTextWidth returns the estimated width of the text in the specified font size
+func (d *pdfDocument) getTextWidth(text string, fontSize float64) float64 {
+	width := 0
+
+	for _, ch := range text {
+		if ch >= 0x20 && ch <= 0x7e {
+			width += pdfHelveticaCharWidths[ch-0x20]
+		} else if _, ok := encodeWinAnsiChar(ch); ok {
+			width += pdfHelveticaDefaultCharWidth
+		} else {
+			width += pdfUnicodeFontCharWidth
+		}
+	}
+
+	return float64(width) * fontSize / 1000
+}
+
+// truncateText returns the text which is truncated with ellipsis to fit the maximum width in the specified font size
+func (d *pdfDocument) truncateText(text string, maxWidth float64, fontSize float64) string {
+	if d.getTextWidth(text, fontSize) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		truncatedText := string(runes) + "..."
+
+		if d.getTextWidth(truncatedText, fontSize) <= maxWidth {
+			return truncatedText
+		}
+	}
+
+	return ""
+}
+
+// bytes returns the whole content of the pdf document
+func (d *pdfDocument) bytes() []byte {
+	if len(d.pages) < 1 {
+		d.addPage()
+	}
+
+	const firstPageObjectId = 9
+	objects := make([]string, 0, firstPageObjectId-1+len(d.pages)*2)
+	pageIds := make([]string, len(d.pages))
+
+	for i := 0; i < len(d.pages); i++ {
+		pageIds[i] = fmt.Sprintf("%d 0 R", firstPageObjectId+i*2)
+	}
+
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageIds, " "), len(d.pages)))
+	objects = append(objects, fmt.Sprintf("<< /Title %s /Producer %s >>", encodePdfTextString(d.title), encodePdfTextString(d.producer)))
+	objects = append(objects, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	objects = append(objects, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	objects = append(objects, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /%s /DescendantFonts [7 0 R] >>", d.unicodeFont.baseFont, d.unicodeFont.encoding))
+	objects = append(objects, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (%s) /Supplement %d >> /FontDescriptor 8 0 R /DW %d >>", d.unicodeFont.baseFont, d.unicodeFont.ordering, d.unicodeFont.supplement, pdfUnicodeFontCharWidth))
+	objects = append(objects, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 6 /FontBBox [-25 -254 1000 880] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>", d.unicodeFont.baseFont))
+
+	for i := 0; i < len(d.pages); i++ {
+		content := d.pages[i].String()
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s 4 0 R /%s 5 0 R /%s 6 0 R >> >> /Contents %d 0 R >>", formatPdfNumber(pdfPageWidth), formatPdfNumber(pdfPageHeight), pdfRegularFontName, pdfBoldFontName, pdfUnicodeFontName, firstPageObjectId+i*2+1))
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content))
+	}
+
+	var buffer bytes.Buffer
+	offsets := make([]int, len(objects))
+
+	buffer.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	for i := 0; i < len(objects); i++ {
+		offsets[i] = buffer.Len()
+		buffer.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, objects[i]))
+	}
+
+	xrefOffset := buffer.Len()
+	buffer.WriteString(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+
+	for i := 0; i < len(offsets); i++ {
+		buffer.WriteString(fmt.Sprintf("%010d 00000 n \n", offsets[i]))
+	}
+
+	buffer.WriteString(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset))
+
+	return buffer.Bytes()
+}
+
+func (d *pdfDocument) currentPage() *bytes.Buffer {
+	if len(d.pages) < 1 {
+		d.addPage()
+	}
+
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *pdfDocument) splitTextRuns(text string) []*pdfTextRun {
+	runs := make([]*pdfTextRun, 0)
+	var lastRun *pdfTextRun
+
+	for _, ch := range text {
+		if ch < 0x20 {
+			ch = ' '
+		}
+
+		unicode := false
+		var content []byte
+
+		if encodedChar, ok := encodeWinAnsiChar(ch); ok {
+			content = []byte{encodedChar}
+		} else if ch <= 0xffff && !utf16.IsSurrogate(ch) {
+			unicode = true
+			content = []byte{byte(ch >> 8), byte(ch)}
+		} else {
+			content = []byte{'?'}
+		}
+
+		if lastRun == nil || lastRun.unicode != unicode {
+			lastRun = &pdfTextRun{
+				unicode: unicode,
+			}
+			runs = append(runs, lastRun)
+		}
+
+		lastRun.content = append(lastRun.content, content...)
+	}
+
+	return runs
+}
+
+func encodeWinAnsiChar(ch rune) (byte, bool) {
+	if (ch >= 0x20 && ch <= 0x7e) || (ch >= 0xa0 && ch <= 0xff) {
+		return byte(ch), true
+	}
+
+	encodedChar, exists := pdfWinAnsiSpecialChars[ch]
+
+	return encodedChar, exists
+}
+
+func escapePdfString(content []byte) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\\' || content[i] == '(' || content[i] == ')' {
+			builder.WriteByte('\\')
+		}
+
+		builder.WriteByte(content[i])
+	}
+
+	return builder.String()
+}
+
+func encodePdfTextString(text string) string {
+	var builder strings.Builder
+	builder.WriteString("<FEFF")
+
+	for _, unit := range utf16.Encode([]rune(text)) {
+		builder.WriteString(fmt.Sprintf("%04X", unit))
+	}
+
+	builder.WriteString(">")
+
+	return builder.String()
+}
+
+func formatPdfNumber(value float64) string {
+	number := strconv.FormatFloat(value, 'f', 2, 64)
+
+	if strings.Contains(number, ".") {
+		number = strings.TrimRight(strings.TrimRight(number, "0"), ".")
+	}
+
+	return number
+}
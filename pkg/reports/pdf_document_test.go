@@ -0,0 +1,114 @@
+package reports
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestPdfDocumentBytes_ValidStructure(t *testing.T) {
+	document := newPdfDocument("en", "Title", "ezBookkeeping")
+	document.addPage()
+	document.drawText(50, 700, 10, false, 0, "Hello (World)")
+	document.addPage()
+	document.drawText(50, 700, 10, true, 0, "Page 2")
+
+	content := string(document.bytes())
+
+	assert.True(t, strings.HasPrefix(content, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(content, "%%EOF\n"))
+	assert.Contains(t, content, "/Count 2")
+	assert.Contains(t, content, "(Hello \\(World\\)) Tj")
+
+	xrefIndex := strings.LastIndex(content, "\nxref\n") + 1
+	startXrefIndex := strings.LastIndex(content, "startxref\n")
+	assert.True(t, xrefIndex > 0)
+
+	startXref, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(content[startXrefIndex+len("startxref\n"):], "%%EOF\n")))
+	assert.Nil(t, err)
+	assert.Equal(t, xrefIndex, startXref)
+
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(content, -1)
+	assert.Equal(t, 12, len(offsets))
+
+	for i := 0; i < len(offsets); i++ {
+		offset, err := strconv.Atoi(offsets[i][1])
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(content[offset:], fmt.Sprintf("%d 0 obj\n", i+1)))
+	}
+}
+
+func TestPdfDocumentBytes_EmptyDocument(t *testing.T) {
+	document := newPdfDocument("en", "", "")
+	content := string(document.bytes())
+
+	assert.Contains(t, content, "/Count 1")
+}
+
+func TestPdfDocumentSplitTextRuns(t *testing.T) {
+	document := newPdfDocument("zh-Hans", "", "")
+	runs := document.splitTextRuns("Café 餐饮€")
+
+	assert.Equal(t, 3, len(runs))
+	assert.False(t, runs[0].unicode)
+	assert.Equal(t, []byte{'C', 'a', 'f', 0xe9, ' '}, runs[0].content)
+	assert.True(t, runs[1].unicode)
+	assert.Equal(t, []byte{0x99, 0x10, 0x99, 0x6e}, runs[1].content)
+	assert.False(t, runs[2].unicode)
+	assert.Equal(t, []byte{0x80}, runs[2].content)
+}
+
+func TestPdfDocumentTruncateText(t *testing.T) {
+	document := newPdfDocument("en", "", "")
+
+	assert.Equal(t, "Short", document.truncateText("Short", 100, 10))
+
+	truncatedText := document.truncateText(strings.Repeat("Long text ", 20), 100, 10)
+	assert.True(t, strings.HasSuffix(truncatedText, "..."))
+	assert.True(t, document.getTextWidth(truncatedText, 10) <= 100)
+}
+
+func TestEncodePdfTextString(t *testing.T) {
+	assert.Equal(t, "<FEFF0041>", encodePdfTextString("A"))
+	assert.Equal(t, "<FEFF62A58868>", encodePdfTextString("报表"))
+}
+
+func TestFormatPdfNumber(t *testing.T) {
+	assert.Equal(t, "0", formatPdfNumber(0))
+	assert.Equal(t, "595.28", formatPdfNumber(595.28))
+	assert.Equal(t, "12.5", formatPdfNumber(12.5))
+	assert.Equal(t, "-3", formatPdfNumber(-3.001))
+}
+
+func TestRenderFinancialReportToPdf_MultiplePages(t *testing.T) {
+	report := &models.FinancialReport{
+		PeriodType: models.FINANCIAL_REPORT_PERIOD_TYPE_MONTHLY,
+		StartTime:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:    time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC),
+		Currency:   "USD",
+	}
+
+	for i := 0; i < 100; i++ {
+		report.ExpenseCategories = append(report.ExpenseCategories, &models.FinancialReportCategoryItem{
+			CategoryId: int64(i + 1),
+			Name:       fmt.Sprintf("Category %d", i+1),
+			Amount:     1000,
+			Percentage: 1,
+		})
+	}
+
+	result, err := RenderFinancialReportToPdf(report, &models.User{Language: "en"}, "")
+	assert.Nil(t, err)
+
+	content := string(result)
+	assert.True(t, strings.HasPrefix(content, "%PDF-1.4\n"))
+	assert.NotContains(t, content, "/Count 1 ")
+	assert.Contains(t, content, "(Category 100) Tj")
+}
@@ -0,0 +1,197 @@
+package reports
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const pdfPageMargin = 50
+const pdfContentWidth = pdfPageWidth - pdfPageMargin*2
+const pdfAmountColumnWidth = 120
+const pdfPercentageColumnWidth = 80
+const pdfSubItemIndent = 15
+
+const pdfTitleFontSize = 20
+const pdfSectionTitleFontSize = 14
+const pdfSubSectionTitleFontSize = 12
+const pdfTextFontSize = 10
+const pdfSmallTextFontSize = 8
+
+const pdfTextColor = 0.2
+const pdfSecondaryTextColor = 0.45
+const pdfTableHeaderBackgroundColor = 0.93
+const pdfLineColor = 0.75
+
+// pdfFinancialReportWriter writes the financial report into the pdf document from top to bottom, and adds new pages when the current page is full
+type pdfFinancialReportWriter struct {
+	document *pdfDocument
+	view     *financialReportView
+	y        float64
+}
+
+// RenderFinancialReportToPdf returns the pdf document of the financial report in the user language and formats
+func RenderFinancialReportToPdf(report *models.FinancialReport, user *models.User, backupLocale string) ([]byte, error) {
+	view := newFinancialReportView(report, user, backupLocale)
+	writer := &pdfFinancialReportWriter{
+		document: newPdfDocument(view.Language, view.Text.Title+" ("+view.Period+")", view.AppName),
+		view:     view,
+	}
+
+	writer.newPage()
+	writer.writeHeader()
+
+	writer.writeSectionTitle(view.Text.IncomeStatement)
+	writer.writeTable(view.Text.Income, view.IncomeItems, view.Text.Total, view.TotalIncome, false)
+	writer.writeTable(view.Text.Expense, view.ExpenseItems, view.Text.Total, view.TotalExpense, false)
+	writer.writeTotalRow(view.Text.NetIncome, view.NetIncome)
+
+	writer.writeSectionTitle(view.Text.BalanceSheet)
+	writer.writeText(view.AsOfDate, pdfTextFontSize, pdfSecondaryTextColor)
+	writer.writeTable(view.Text.Assets, view.AssetItems, view.Text.Total, view.TotalAssets, false)
+	writer.writeTable(view.Text.Liabilities, view.LiabilityItems, view.Text.Total, view.TotalLiabilities, false)
+	writer.writeTotalRow(view.Text.NetAssets, view.NetAssets)
+
+	writer.writeSectionTitle(view.Text.CategoryBreakdown)
+	writer.writeSubSectionTitle(view.Text.Income)
+	writer.writeTable(view.Text.Category, view.IncomeItems, "", "", true)
+	writer.writeSubSectionTitle(view.Text.Expense)
+	writer.writeTable(view.Text.Category, view.ExpenseItems, "", "", true)
+
+	return writer.document.bytes(), nil
+}
+
+func (w *pdfFinancialReportWriter) writeHeader() {
+	w.moveDown(pdfTextFontSize)
+	w.document.drawText(pdfPageMargin, w.y, pdfTextFontSize, true, pdfSecondaryTextColor, w.view.AppName)
+	w.moveDown(pdfTitleFontSize + 8)
+	w.document.drawText(pdfPageMargin, w.y, pdfTitleFontSize, true, pdfTextColor, w.view.Text.Title)
+	w.moveDown(8)
+	w.writeText(w.view.Text.Period+": "+w.view.Period, pdfTextFontSize, pdfSecondaryTextColor)
+	w.writeText(w.view.Text.Currency+": "+w.view.Currency, pdfTextFontSize, pdfSecondaryTextColor)
+}
+
+func (w *pdfFinancialReportWriter) writeSectionTitle(title string) {
+	w.ensureSpace(pdfSectionTitleFontSize + 60)
+	w.moveDown(pdfSectionTitleFontSize + 20)
+	w.document.drawText(pdfPageMargin, w.y, pdfSectionTitleFontSize, true, pdfTextColor, title)
+	w.moveDown(6)
+	w.document.drawLine(pdfPageMargin, w.y, pdfPageMargin+pdfContentWidth, w.y, 1.5, pdfTextColor)
+	w.moveDown(6)
+}
+
+func (w *pdfFinancialReportWriter) writeSubSectionTitle(title string) {
+	w.ensureSpace(pdfSubSectionTitleFontSize + 50)
+	w.moveDown(pdfSubSectionTitleFontSize + 8)
+	w.document.drawText(pdfPageMargin, w.y, pdfSubSectionTitleFontSize, true, pdfTextColor, title)
+	w.moveDown(4)
+}
+
+func (w *pdfFinancialReportWriter) writeText(text string, fontSize float64, gray float64) {
+	w.ensureSpace(fontSize + 6)
+	w.moveDown(fontSize + 6)
+	w.document.drawText(pdfPageMargin, w.y, fontSize, false, gray, text)
+}
+
+func (w *pdfFinancialReportWriter) writeTable(title string, items []*financialReportViewItem, totalTitle string, totalAmount string, showPercentage bool) {
+	w.ensureSpace(60)
+	w.moveDown(8)
+	w.writeTableHeader(title, showPercentage)
+
+	if len(items) < 1 {
+		w.writeTableRow(w.view.Text.NoData, "", "", "", false, showPercentage, pdfSecondaryTextColor)
+	}
+
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+		w.writeTableRow(item.Name, item.OriginalAmount, item.Amount, item.Percentage, false, showPercentage, pdfTextColor)
+
+		if !showPercentage {
+			continue
+		}
+
+		for j := 0; j < len(item.SubItems); j++ {
+			subItem := item.SubItems[j]
+			w.writeTableRow(subItem.Name, subItem.OriginalAmount, subItem.Amount, subItem.Percentage, true, showPercentage, pdfSecondaryTextColor)
+		}
+	}
+
+	if totalTitle != "" {
+		w.writeTotalRow(totalTitle, totalAmount)
+	}
+}
+
+func (w *pdfFinancialReportWriter) writeTableHeader(title string, showPercentage bool) {
+	rowHeight := float64(pdfTextFontSize + 10)
+	w.ensureSpace(rowHeight * 2)
+	w.document.fillRect(pdfPageMargin, w.y-rowHeight, pdfContentWidth, rowHeight, pdfTableHeaderBackgroundColor)
+	w.moveDown(rowHeight - 6)
+
+	amountRight := float64(pdfPageMargin + pdfContentWidth - 6)
+
+	if showPercentage {
+		w.document.drawTextRightAligned(amountRight, w.y, pdfTextFontSize, true, pdfTextColor, w.view.Text.Percentage)
+		amountRight -= pdfPercentageColumnWidth
+	}
+
+	w.document.drawText(pdfPageMargin+6, w.y, pdfTextFontSize, true, pdfTextColor, title)
+	w.document.drawTextRightAligned(amountRight, w.y, pdfTextFontSize, true, pdfTextColor, w.view.Text.Amount)
+	w.moveDown(6)
+}
+
+func (w *pdfFinancialReportWriter) writeTableRow(name string, originalAmount string, amount string, percentage string, subItem bool, showPercentage bool, gray float64) {
+	rowHeight := float64(pdfTextFontSize + 8)
+	w.ensureSpace(rowHeight)
+	w.moveDown(rowHeight - 5)
+
+	nameLeft := float64(pdfPageMargin + 6)
+	amountRight := float64(pdfPageMargin + pdfContentWidth - 6)
+
+	if subItem {
+		nameLeft += pdfSubItemIndent
+	}
+
+	if showPercentage {
+		w.document.drawTextRightAligned(amountRight, w.y, pdfTextFontSize, false, gray, percentage)
+		amountRight -= pdfPercentageColumnWidth
+	}
+
+	nameMaxWidth := amountRight - pdfAmountColumnWidth - nameLeft
+
+	if originalAmount != "" {
+		originalAmount = "(" + originalAmount + ")"
+		originalAmountWidth := w.document.getTextWidth(originalAmount, pdfSmallTextFontSize)
+		name = w.document.truncateText(name, nameMaxWidth-originalAmountWidth-6, pdfTextFontSize)
+		w.document.drawText(nameLeft+w.document.getTextWidth(name, pdfTextFontSize)+6, w.y, pdfSmallTextFontSize, false, pdfSecondaryTextColor, originalAmount)
+	} else {
+		name = w.document.truncateText(name, nameMaxWidth, pdfTextFontSize)
+	}
+
+	w.document.drawText(nameLeft, w.y, pdfTextFontSize, false, gray, name)
+	w.document.drawTextRightAligned(amountRight, w.y, pdfTextFontSize, false, gray, amount)
+	w.moveDown(5)
+	w.document.drawLine(pdfPageMargin, w.y, pdfPageMargin+pdfContentWidth, w.y, 0.5, pdfTableHeaderBackgroundColor)
+}
+
+func (w *pdfFinancialReportWriter) writeTotalRow(title string, amount string) {
+	rowHeight := float64(pdfTextFontSize + 8)
+	w.ensureSpace(rowHeight)
+	w.document.drawLine(pdfPageMargin, w.y, pdfPageMargin+pdfContentWidth, w.y, 0.75, pdfLineColor)
+	w.moveDown(rowHeight - 5)
+	w.document.drawText(pdfPageMargin+6, w.y, pdfTextFontSize, true, pdfTextColor, title)
+	w.document.drawTextRightAligned(pdfPageMargin+pdfContentWidth-6, w.y, pdfTextFontSize, true, pdfTextColor, amount)
+	w.moveDown(5)
+}
+
+func (w *pdfFinancialReportWriter) ensureSpace(height float64) {
+	if w.y-height < pdfPageMargin {
+		w.newPage()
+	}
+}
+
+func (w *pdfFinancialReportWriter) newPage() {
+	w.document.addPage()
+	w.y = pdfPageHeight - pdfPageMargin
+}
+
+func (w *pdfFinancialReportWriter) moveDown(height float64) {
+	w.y -= height
+}
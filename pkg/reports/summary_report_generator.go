@@ -0,0 +1,243 @@
+package reports
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/mail"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const pageCountForSummaryReport = 1000
+
+// SummaryReportGenerator represents summary report generator
+type SummaryReportGenerator struct {
+	users                 *services.UserService
+	transactions          *services.TransactionService
+	transactionCategories *services.TransactionCategoryService
+	accounts              *services.AccountService
+	budgets               *services.BudgetService
+}
+
+// Initialize a summary report generator singleton instance
+var (
+	SummaryReports = &SummaryReportGenerator{
+		users:                 services.Users,
+		transactions:          services.Transactions,
+		transactionCategories: services.TransactionCategories,
+		accounts:              services.Accounts,
+		budgets:               services.Budgets,
+	}
+)
+
+// SendSummaryReportEmails sends the summary report emails of the last period to all users who have enabled the summary report email and should receive it on the date of the specified time
+func (g *SummaryReportGenerator) SendSummaryReportEmails(c *core.CronContext, currentTime time.Time, currentConfig *settings.Config) error {
+	if !currentConfig.EnableSMTP {
+		log.Warnf(c, "[summary_report_generator.SendSummaryReportEmails] SMTP is not enabled, skip sending summary report emails")
+		return errs.ErrSMTPServerNotEnabled
+	}
+
+	users, err := g.users.GetAllSummaryEmailEnabledUsers(c)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.SendSummaryReportEmails] failed to get users who enabled summary report email, because %s", err.Error())
+		return err
+	}
+
+	successCount := 0
+	skipCount := 0
+	failedCount := 0
+
+	for i := 0; i < len(users); i++ {
+		user := users[i]
+		// the periods are calculated in the timezone of user, so the week and month boundaries are the same as the user sees
+		startTime, endTime, shouldSend := models.GetSummaryReportPeriod(user.SummaryEmailFrequency, currentTime.In(user.GetSummaryEmailTimezone()), user.FirstDayOfWeek)
+
+		if !shouldSend {
+			skipCount++
+			continue
+		}
+
+		if currentConfig.EnableUserVerifyEmail && !user.EmailVerified {
+			skipCount++
+			log.Infof(c, "[summary_report_generator.SendSummaryReportEmails] skip sending %s summary report email to user \"uid:%d\", because the email is not verified", user.SummaryEmailFrequency, user.Uid)
+			continue
+		}
+
+		report, err := g.GenerateSummaryReport(c, user, user.SummaryEmailFrequency, startTime, endTime, currentConfig)
+
+		if err != nil {
+			failedCount++
+			log.Errorf(c, "[summary_report_generator.SendSummaryReportEmails] failed to generate %s summary report for user \"uid:%d\", because %s", user.SummaryEmailFrequency, user.Uid, err.Error())
+			continue
+		}
+
+		subject, body, err := RenderSummaryReportMail(report, user, "")
+
+		if err != nil {
+			failedCount++
+			log.Errorf(c, "[summary_report_generator.SendSummaryReportEmails] failed to render %s summary report email for user \"uid:%d\", because %s", user.SummaryEmailFrequency, user.Uid, err.Error())
+			continue
+		}
+
+		err = mail.Container.SendMail(&mail.MailMessage{
+			To:      user.Email,
+			Subject: subject,
+			Body:    string(body),
+		})
+
+		if err != nil {
+			failedCount++
+			log.Errorf(c, "[summary_report_generator.SendSummaryReportEmails] failed to send %s summary report email to user \"uid:%d\", because %s", user.SummaryEmailFrequency, user.Uid, err.Error())
+			continue
+		}
+
+		successCount++
+		log.Infof(c, "[summary_report_generator.SendSummaryReportEmails] %s summary report email has been sent to user \"uid:%d\"", user.SummaryEmailFrequency, user.Uid)
+	}
+
+	log.Infof(c, "[summary_report_generator.SendSummaryReportEmails] %d summary report emails has been sent successfully, %d users does not need to receive summary report emails today and %d emails failed to send", successCount, skipCount, failedCount)
+
+	return nil
+}
+
+// GenerateSummaryReport returns the summary report of the user within the specified period, all the amounts except account balances are exchanged to the default currency of the user
+func (g *SummaryReportGenerator) GenerateSummaryReport(c core.Context, user *models.User, frequency models.SummaryEmailFrequency, startTime time.Time, endTime time.Time, currentConfig *settings.Config) (*models.SummaryReport, error) {
+	uid := user.Uid
+	accounts, err := g.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.GenerateSummaryReport] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	categories, err := g.transactionCategories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.GenerateSummaryReport] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	budgets, err := g.budgets.GetAllBudgetsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.GenerateSummaryReport] failed to get budgets for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	budgetsStartTime := g.getBudgetsEarliestPeriodStartTime(user, budgets, endTime)
+	exchangeRates, err := exchangerates.Container.GetHistoricalExchangeRateMap(c, uid, budgetsStartTime.Unix(), endTime.Unix(), currentConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	amountExchanger := models.NewTransactionAmountExchanger(user.DefaultCurrency, accounts, exchangeRates)
+	categoryTotalAmounts, err := g.transactions.GetAccountsAndCategoriesTotalInflowAndOutflow(c, uid, startTime.Unix(), endTime.Unix(), nil, false, nil, false, "", startTime.Location(), false, amountExchanger)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.GenerateSummaryReport] failed to get categories total amounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(endTime.Unix())
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(startTime.Unix())
+	transactions, err := g.transactions.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, 0, nil, nil, nil, false, nil, false, "", "", pageCountForSummaryReport, true)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.GenerateSummaryReport] failed to get transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	accountDailyBalances, err := g.transactions.GetAllAccountsDailyOpeningAndClosingBalance(c, uid, maxTransactionTime, minTransactionTime, startTime.Location())
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.GenerateSummaryReport] failed to get account balances for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	budgetProgresses, err := g.getBudgetProgresses(c, user, budgets, budgetsStartTime, endTime, accounts, exchangeRates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewSummaryReport(frequency, startTime, endTime, user.DefaultCurrency, categories, categoryTotalAmounts, transactions, accounts, models.GetAccountsOpeningBalances(accountDailyBalances), models.GetAccountsClosingBalances(accountDailyBalances), budgets, budgetProgresses, exchangeRates), nil
+}
+
+func (g *SummaryReportGenerator) getBudgetsEarliestPeriodStartTime(user *models.User, budgets []*models.Budget, currentTime time.Time) time.Time {
+	earliestStartTime := currentTime
+
+	for i := 0; i < len(budgets); i++ {
+		budget := budgets[i]
+
+		if budget.Hidden || !budget.PeriodType.IsValid() {
+			continue
+		}
+
+		periodStartTimes := g.budgets.GetBudgetPeriodStartTimes(budget, user.FirstDayOfWeek, user.FiscalYearStart, currentTime)
+
+		if periodStartTimes[0].Before(earliestStartTime) {
+			earliestStartTime = periodStartTimes[0]
+		}
+	}
+
+	return earliestStartTime
+}
+
+func (g *SummaryReportGenerator) getBudgetProgresses(c core.Context, user *models.User, budgets []*models.Budget, budgetsStartTime time.Time, currentTime time.Time, accounts []*models.Account, exchangeRates *models.HistoricalExchangeRateMap) (map[int64]*models.BudgetProgressResponse, error) {
+	budgetProgresses := make(map[int64]*models.BudgetProgressResponse)
+
+	if len(budgets) < 1 {
+		return budgetProgresses, nil
+	}
+
+	uid := user.Uid
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(currentTime.Unix())
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(budgetsStartTime.Unix())
+	transactions, err := g.transactions.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, models.TRANSACTION_TYPE_EXPENSE, nil, nil, nil, false, nil, false, "", "", pageCountForSummaryReport, true)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.getBudgetProgresses] failed to get expense transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	allSplits, err := g.transactions.GetTransactionSplitsMapByTimeRange(c, uid, maxTransactionTime, minTransactionTime)
+
+	if err != nil {
+		log.Errorf(c, "[summary_report_generator.getBudgetProgresses] failed to get transaction split lines for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
+	accountMap := g.accounts.GetAccountMapByList(accounts)
+	exchangeRateMap := exchangeRates.GetExchangeRateMap(utils.FormatUnixTimeToNumericYearMonthDay(currentTime.Unix(), currentTime.Location()))
+
+	if exchangeRateMap == nil {
+		exchangeRateMap = models.ExchangeRateMap{}
+	}
+
+	for i := 0; i < len(budgets); i++ {
+		budget := budgets[i]
+
+		if budget.Hidden || !budget.PeriodType.IsValid() {
+			continue
+		}
+
+		categoryIds, err := g.budgets.GetBudgetCategoryIds(c, uid, budget)
+
+		if err != nil {
+			log.Errorf(c, "[summary_report_generator.getBudgetProgresses] failed to get category ids of budget \"id:%d\" for user \"uid:%d\", because %s", budget.BudgetId, uid, err.Error())
+			return nil, err
+		}
+
+		budgetProgresses[budget.BudgetId] = g.budgets.GetBudgetProgress(budget, transactions, allSplits, accountMap, categoryIds, exchangeRateMap, user.FirstDayOfWeek, user.FiscalYearStart, currentTime)
+	}
+
+	return budgetProgresses, nil
+}
@@ -0,0 +1,28 @@
+package reports
+
+import (
+	"bytes"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/templates"
+)
+
+// RenderSummaryReportMail returns the subject and the html body of the summary report mail in the user language and formats
+func RenderSummaryReportMail(report *models.SummaryReport, user *models.User, backupLocale string) (string, []byte, error) {
+	tmpl, err := templates.GetTemplate(templates.TEMPLATE_SUMMARY_REPORT)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	view := newSummaryReportView(report, user, backupLocale)
+
+	var bodyBuffer bytes.Buffer
+	err = tmpl.Execute(&bodyBuffer, view)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return view.Title + " (" + view.Period + ")", bodyBuffer.Bytes(), nil
+}
@@ -0,0 +1,147 @@
+package reports
+
+import (
+	"fmt"
+
+	"github.com/mayswind/ezbookkeeping/pkg/locales"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// summaryReportView represents the formatted summary report which is used by the summary report mail renderer
+type summaryReportView struct {
+	Language              string
+	AppName               string
+	Text                  *locales.SummaryReportMailTextItems
+	Title                 string
+	Period                string
+	Salutation            string
+	Description           string
+	TotalIncome           string
+	TotalExpense          string
+	NetIncome             string
+	TopIncomeCategories   []*financialReportViewItem
+	TopExpenseCategories  []*financialReportViewItem
+	BiggestTransactions   []*summaryReportTransactionViewItem
+	Budgets               []*summaryReportBudgetViewItem
+	AccountBalanceChanges []*summaryReportAccountViewItem
+}
+
+// summaryReportTransactionViewItem represents a formatted transaction row in the summary report
+type summaryReportTransactionViewItem struct {
+	Date           string
+	CategoryName   string
+	AccountName    string
+	Comment        string
+	Amount         string
+	OriginalAmount string
+	Income         bool
+}
+
+// summaryReportBudgetViewItem represents a formatted budget row in the summary report
+type summaryReportBudgetViewItem struct {
+	Name            string
+	AvailableAmount string
+	SpentAmount     string
+	RemainingAmount string
+	Overspent       bool
+}
+
+// summaryReportAccountViewItem represents a formatted account balance change row in the summary report
+type summaryReportAccountViewItem struct {
+	Name           string
+	OpeningBalance string
+	ClosingBalance string
+	Change         string
+}
+
+func newSummaryReportView(report *models.SummaryReport, user *models.User, backupLocale string) *summaryReportView {
+	locale := getReportLocale(user, backupLocale)
+	localeTextItems := locales.GetLocaleTextItems(locale)
+	formatter := NewReportFormatter(user, localeTextItems)
+	textItems := localeTextItems.SummaryReportMailTextItems
+	period := formatter.FormatDate(report.StartTime) + " - " + formatter.FormatDate(report.EndTime)
+	title := textItems.MonthlyTitle
+
+	if report.Frequency == models.SUMMARY_EMAIL_FREQUENCY_WEEKLY {
+		title = textItems.WeeklyTitle
+	}
+
+	return &summaryReportView{
+		Language:              locale,
+		AppName:               localeTextItems.GlobalTextItems.AppName,
+		Text:                  textItems,
+		Title:                 title,
+		Period:                period,
+		Salutation:            fmt.Sprintf(textItems.SalutationFormat, user.Nickname),
+		Description:           fmt.Sprintf(textItems.DescriptionFormat, period),
+		TotalIncome:           formatter.FormatAmountWithCurrency(report.TotalIncome, report.Currency),
+		TotalExpense:          formatter.FormatAmountWithCurrency(report.TotalExpense, report.Currency),
+		NetIncome:             formatter.FormatAmountWithCurrency(report.NetIncome, report.Currency),
+		TopIncomeCategories:   getFinancialReportCategoryViewItems(report.TopIncomeCategories, formatter),
+		TopExpenseCategories:  getFinancialReportCategoryViewItems(report.TopExpenseCategories, formatter),
+		BiggestTransactions:   getSummaryReportTransactionViewItems(report.BiggestTransactions, report.Currency, formatter),
+		Budgets:               getSummaryReportBudgetViewItems(report.Budgets, formatter),
+		AccountBalanceChanges: getSummaryReportAccountViewItems(report.AccountBalanceChanges, formatter),
+	}
+}
+
+func getSummaryReportTransactionViewItems(transactionItems []*models.SummaryReportTransactionItem, currency string, formatter *ReportFormatter) []*summaryReportTransactionViewItem {
+	viewItems := make([]*summaryReportTransactionViewItem, len(transactionItems))
+
+	for i := 0; i < len(transactionItems); i++ {
+		transactionItem := transactionItems[i]
+		viewItems[i] = &summaryReportTransactionViewItem{
+			Date:         formatter.FormatDate(transactionItem.TransactionTime),
+			CategoryName: transactionItem.CategoryName,
+			AccountName:  transactionItem.AccountName,
+			Comment:      transactionItem.Comment,
+			Amount:       formatter.FormatAmountWithCurrency(transactionItem.Amount, currency),
+			Income:       transactionItem.Type == models.TRANSACTION_DB_TYPE_INCOME,
+		}
+
+		if transactionItem.Currency != currency {
+			viewItems[i].OriginalAmount = formatter.FormatAmountWithCurrency(transactionItem.OriginalAmount, transactionItem.Currency)
+		}
+	}
+
+	return viewItems
+}
+
+func getSummaryReportBudgetViewItems(budgetItems []*models.SummaryReportBudgetItem, formatter *ReportFormatter) []*summaryReportBudgetViewItem {
+	viewItems := make([]*summaryReportBudgetViewItem, len(budgetItems))
+
+	for i := 0; i < len(budgetItems); i++ {
+		budgetItem := budgetItems[i]
+		viewItems[i] = &summaryReportBudgetViewItem{
+			Name:            budgetItem.Name,
+			AvailableAmount: formatter.FormatAmountWithCurrency(budgetItem.AvailableAmount, budgetItem.Currency),
+			SpentAmount:     formatter.FormatAmountWithCurrency(budgetItem.SpentAmount, budgetItem.Currency),
+			RemainingAmount: formatter.FormatAmountWithCurrency(budgetItem.RemainingAmount, budgetItem.Currency),
+			Overspent:       budgetItem.Overspent,
+		}
+	}
+
+	return viewItems
+}
+
+func getSummaryReportAccountViewItems(accountItems []*models.SummaryReportAccountItem, formatter *ReportFormatter) []*summaryReportAccountViewItem {
+	viewItems := make([]*summaryReportAccountViewItem, len(accountItems))
+
+	for i := 0; i < len(accountItems); i++ {
+		accountItem := accountItems[i]
+		change := formatter.FormatAmountWithCurrency(accountItem.Change, accountItem.Currency)
+
+		if accountItem.Change > 0 {
+			change = accountItem.Currency + " +" + formatter.FormatAmount(accountItem.Change)
+		}
+
+		viewItems[i] = &summaryReportAccountViewItem{
+			Name:           accountItem.Name,
+			OpeningBalance: formatter.FormatAmountWithCurrency(accountItem.OpeningBalance, accountItem.Currency),
+			ClosingBalance: formatter.FormatAmountWithCurrency(accountItem.ClosingBalance, accountItem.Currency),
+			Change:         change,
+		}
+	}
+
+	return viewItems
+}
@@ -0,0 +1,64 @@
+package reports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestNewSummaryReportView(t *testing.T) {
+	report := &models.SummaryReport{
+		Frequency:    models.SUMMARY_EMAIL_FREQUENCY_WEEKLY,
+		StartTime:    time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC),
+		EndTime:      time.Date(2024, 3, 3, 23, 59, 59, 0, time.UTC),
+		Currency:     "EUR",
+		TotalIncome:  500000,
+		TotalExpense: 123456,
+		NetIncome:    376544,
+		BiggestTransactions: []*models.SummaryReportTransactionItem{
+			{Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: time.Date(2024, 2, 27, 12, 0, 0, 0, time.UTC), CategoryName: "Lunch", AccountName: "Card", Currency: "USD", OriginalAmount: 1200, Amount: 1000},
+			{Type: models.TRANSACTION_DB_TYPE_INCOME, TransactionTime: time.Date(2024, 2, 28, 12, 0, 0, 0, time.UTC), CategoryName: "Wage", AccountName: "Bank", Currency: "EUR", OriginalAmount: 500, Amount: 500},
+		},
+		Budgets: []*models.SummaryReportBudgetItem{
+			{Name: "All", Currency: "EUR", AvailableAmount: 100, SpentAmount: 200, RemainingAmount: -100, Overspent: true},
+		},
+		AccountBalanceChanges: []*models.SummaryReportAccountItem{
+			{Name: "Cash", Currency: "EUR", OpeningBalance: 100, ClosingBalance: 300, Change: 200},
+			{Name: "Card", Currency: "USD", OpeningBalance: 300, ClosingBalance: 100, Change: -200},
+		},
+	}
+
+	view := newSummaryReportView(report, &models.User{Language: "en", Nickname: "Alice"}, "")
+
+	assert.Equal(t, "Weekly Summary", view.Title)
+	assert.Equal(t, "02/26/2024 - 03/03/2024", view.Period)
+	assert.Equal(t, "Hi Alice,", view.Salutation)
+	assert.Equal(t, "Here is your financial summary for 02/26/2024 - 03/03/2024.", view.Description)
+	assert.Equal(t, "EUR 5,000.00", view.TotalIncome)
+	assert.Equal(t, "EUR 1,234.56", view.TotalExpense)
+	assert.Equal(t, "EUR 3,765.44", view.NetIncome)
+
+	assert.Equal(t, 2, len(view.BiggestTransactions))
+	assert.Equal(t, "02/27/2024", view.BiggestTransactions[0].Date)
+	assert.Equal(t, "EUR 10.00", view.BiggestTransactions[0].Amount)
+	assert.Equal(t, "USD 12.00", view.BiggestTransactions[0].OriginalAmount)
+	assert.False(t, view.BiggestTransactions[0].Income)
+	assert.Equal(t, "", view.BiggestTransactions[1].OriginalAmount)
+	assert.True(t, view.BiggestTransactions[1].Income)
+
+	assert.Equal(t, 1, len(view.Budgets))
+	assert.Equal(t, "EUR -1.00", view.Budgets[0].RemainingAmount)
+	assert.True(t, view.Budgets[0].Overspent)
+
+	assert.Equal(t, 2, len(view.AccountBalanceChanges))
+	assert.Equal(t, "EUR +2.00", view.AccountBalanceChanges[0].Change)
+	assert.Equal(t, "USD -2.00", view.AccountBalanceChanges[1].Change)
+	assert.Equal(t, "USD 3.00", view.AccountBalanceChanges[1].OpeningBalance)
+
+	report.Frequency = models.SUMMARY_EMAIL_FREQUENCY_MONTHLY
+	view = newSummaryReportView(report, &models.User{Language: "zh-Hans", Nickname: "Alice"}, "")
+	assert.Equal(t, "每月摘要", view.Title)
+}
@@ -1,7 +1,6 @@
 package services
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
@@ -668,15 +667,9 @@ func (s *AccountService) DeleteAccount(c core.Context, uid int64, accountId int6
 			return errs.ErrAccountNotFound
 		}
 
-		var accountAndSubAccountIdsConditions strings.Builder
 		accountAndSubAccountIds := make([]int64, len(accountAndSubAccounts))
 
 		for i := 0; i < len(accountAndSubAccounts); i++ {
-			if accountAndSubAccountIdsConditions.Len() > 0 {
-				accountAndSubAccountIdsConditions.WriteString(",")
-			}
-
-			accountAndSubAccountIdsConditions.WriteString("?")
 			accountAndSubAccountIds[i] = accountAndSubAccounts[i].AccountId
 		}
 
@@ -703,28 +696,11 @@ func (s *AccountService) DeleteAccount(c core.Context, uid int64, accountId int6
 			}
 		}
 
-		transactionTemplateQueryCondition := fmt.Sprintf("uid=? AND deleted=? AND (template_type=? OR (template_type=? AND scheduled_frequency_type<>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?))) AND (account_id IN (%s) OR related_account_id IN (%s))", accountAndSubAccountIdsConditions.String(), accountAndSubAccountIdsConditions.String())
-		transactionTemplateQueryConditionParams := make([]any, 0, len(accountAndSubAccountIds)*2+6)
-		transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, uid)
-		transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, false)
-		transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, models.TRANSACTION_TEMPLATE_TYPE_NORMAL)
-		transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE)
-		transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED)
-		transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, now)
-
-		for i := 0; i < len(accountAndSubAccountIds); i++ {
-			transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, accountAndSubAccountIds[i])
-		}
-
-		for i := 0; i < len(accountAndSubAccountIds); i++ {
-			transactionTemplateQueryConditionParams = append(transactionTemplateQueryConditionParams, accountAndSubAccountIds[i])
-		}
-
-		exists, err := sess.Cols("uid", "deleted", "account_id", "related_account_id", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where(transactionTemplateQueryCondition, transactionTemplateQueryConditionParams...).Limit(1).Exist(&models.TransactionTemplate{})
+		inUse, err := s.isAccountsUsedByOtherData(sess, uid, accountAndSubAccountIds, now)
 
 		if err != nil {
 			return err
-		} else if exists {
+		} else if inUse {
 			return errs.ErrAccountInUseCannotBeDeleted
 		}
 
@@ -809,11 +785,11 @@ func (s *AccountService) DeleteSubAccount(c core.Context, uid int64, accountId i
 			}
 		}
 
-		exists, err := sess.Cols("uid", "deleted", "account_id", "related_account_id", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where("uid=? AND deleted=? AND (template_type=? OR (template_type=? AND scheduled_frequency_type<>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?))) AND (account_id=? OR related_account_id=?)", uid, false, models.TRANSACTION_TEMPLATE_TYPE_NORMAL, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, now, accountId, accountId).Limit(1).Exist(&models.TransactionTemplate{})
+		inUse, err := s.isAccountsUsedByOtherData(sess, uid, []int64{accountId}, now)
 
 		if err != nil {
 			return err
-		} else if exists {
+		} else if inUse {
 			return errs.ErrSubAccountInUseCannotBeDeleted
 		}
 
@@ -851,6 +827,37 @@ func (s *AccountService) DeleteSubAccount(c core.Context, uid int64, accountId i
 	})
 }
 
+// isAccountsUsedByOtherData returns whether any of the accounts is used by transaction templates, transaction rules or credit card statements
+func (s *AccountService) isAccountsUsedByOtherData(sess *xorm.Session, uid int64, accountIds []int64, now int64) (bool, error) {
+	transactionTemplateQueryCondition := "uid=? AND deleted=? AND (template_type=? OR (template_type=? AND scheduled_frequency_type<>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?)))"
+
+	exists, err := sess.Cols("uid", "deleted", "account_id", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where(transactionTemplateQueryCondition, uid, false, models.TRANSACTION_TEMPLATE_TYPE_NORMAL, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, now).In("account_id", accountIds).Limit(1).Exist(&models.TransactionTemplate{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	exists, err = sess.Cols("uid", "deleted", "related_account_id", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where(transactionTemplateQueryCondition, uid, false, models.TRANSACTION_TEMPLATE_TYPE_NORMAL, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, now).In("related_account_id", accountIds).Limit(1).Exist(&models.TransactionTemplate{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	exists, err = sess.Cols("uid", "deleted", "condition_account_id").Where("uid=? AND deleted=?", uid, false).In("condition_account_id", accountIds).Limit(1).Exist(&models.TransactionRule{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	exists, err = sess.Cols("uid", "deleted", "action_account_id").Where("uid=? AND deleted=?", uid, false).In("action_account_id", accountIds).Limit(1).Exist(&models.TransactionRule{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	return sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=?", uid, false).In("account_id", accountIds).Limit(1).Exist(&models.CreditCardOverdueStatement{})
+}
+
 // GetAccountMapByList returns an account map by a list
 func (s *AccountService) GetAccountMapByList(accounts []*models.Account) map[int64]*models.Account {
 	accountMap := make(map[int64]*models.Account)
@@ -0,0 +1,356 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const maxBudgetRolloverPeriodCount = 1000
+
+// BudgetService represents budget service
+type BudgetService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a budget service singleton instance
+var (
+	Budgets = &BudgetService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllBudgetsByUid returns all budget models of user
+func (s *BudgetService) GetAllBudgetsByUid(c core.Context, uid int64) ([]*models.Budget, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var budgets []*models.Budget
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("display_order asc").Find(&budgets)
+
+	return budgets, err
+}
+
+// GetBudgetByBudgetId returns a budget model according to budget id
+func (s *BudgetService) GetBudgetByBudgetId(c core.Context, uid int64, budgetId int64) (*models.Budget, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if budgetId <= 0 {
+		return nil, errs.ErrBudgetIdInvalid
+	}
+
+	budget := &models.Budget{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(budgetId).Where("uid=? AND deleted=?", uid, false).Get(budget)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrBudgetNotFound
+	}
+
+	return budget, nil
+}
+
+// GetMaxDisplayOrder returns the max display order
+func (s *BudgetService) GetMaxDisplayOrder(c core.Context, uid int64) (int32, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	budget := &models.Budget{}
+	has, err := s.UserDataDB(uid).NewSession(c).Cols("uid", "deleted", "display_order").Where("uid=? AND deleted=?", uid, false).OrderBy("display_order desc").Limit(1).Get(budget)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if has {
+		return budget.DisplayOrder, nil
+	} else {
+		return 0, nil
+	}
+}
+
+// CreateBudget saves a new budget model to database
+func (s *BudgetService) CreateBudget(c core.Context, budget *models.Budget) error {
+	if budget.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	budget.BudgetId = s.GenerateUuid(uuid.UUID_TYPE_BUDGET)
+
+	if budget.BudgetId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	budget.Deleted = false
+	budget.CreatedUnixTime = time.Now().Unix()
+	budget.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(budget.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(budget)
+		return err
+	})
+}
+
+// ModifyBudget saves an existed budget model to database
+func (s *BudgetService) ModifyBudget(c core.Context, budget *models.Budget) error {
+	if budget.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	budget.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(budget.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(budget.BudgetId).Cols("name", "category_id", "period_type", "amount", "currency", "enable_rollover", "start_time", "comment", "hidden", "updated_unix_time").Where("uid=? AND deleted=?", budget.Uid, false).Update(budget)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrBudgetNotFound
+		}
+
+		return err
+	})
+}
+
+// HideBudget updates hidden field of given budget ids
+func (s *BudgetService) HideBudget(c core.Context, uid int64, ids []int64, hidden bool) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Budget{
+		Hidden:          hidden,
+		UpdatedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.Cols("hidden", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).In("budget_id", ids).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrBudgetNotFound
+		}
+
+		return err
+	})
+}
+
+// ModifyBudgetDisplayOrders updates display order of given budgets
+func (s *BudgetService) ModifyBudgetDisplayOrders(c core.Context, uid int64, budgets []*models.Budget) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	for i := 0; i < len(budgets); i++ {
+		budgets[i].UpdatedUnixTime = time.Now().Unix()
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(budgets); i++ {
+			budget := budgets[i]
+			updatedRows, err := sess.ID(budget.BudgetId).Cols("display_order", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(budget)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrBudgetNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteBudget deletes an existed budget from database
+func (s *BudgetService) DeleteBudget(c core.Context, uid int64, budgetId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Budget{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(budgetId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrBudgetNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllBudgets deletes all existed budgets from database
+func (s *BudgetService) DeleteAllBudgets(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Budget{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// GetBudgetCategoryIds returns the ids of the budget category and its sub-categories, or an empty map if the budget is for all expense categories
+func (s *BudgetService) GetBudgetCategoryIds(c core.Context, uid int64, budget *models.Budget) (map[int64]bool, error) {
+	categoryIds := make(map[int64]bool)
+
+	if budget.CategoryId == models.BudgetAllExpenseCategoriesId {
+		return categoryIds, nil
+	}
+
+	allCategoryIds, err := TransactionCategories.GetCategoryOrSubCategoryIds(c, utils.Int64ToString(budget.CategoryId), uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(allCategoryIds); i++ {
+		categoryIds[allCategoryIds[i]] = true
+	}
+
+	return categoryIds, nil
+}
+
+// GetBudgetProgress returns the progress of budget in the period which contains the specified time according to the given expense transactions,
+// the split transactions are replaced by their split lines, so only the split lines in the budget categories are counted,
+// the amount of transactions in other currencies is exchanged to budget currency by the given exchange rates, and the transactions which cannot be exchanged are ignored
+func (s *BudgetService) GetBudgetProgress(budget *models.Budget, transactions []*models.Transaction, allSplits map[int64][]*models.TransactionSplit, accountMap map[int64]*models.Account, categoryIds map[int64]bool, exchangeRateMap models.ExchangeRateMap, firstDayOfWeek core.WeekDay, fiscalYearStart core.FiscalYearStart, currentTime time.Time) *models.BudgetProgressResponse {
+	transactions = Transactions.GetSplitTransactions(transactions, allSplits)
+	periodStartTimes := s.GetBudgetPeriodStartTimes(budget, firstDayOfWeek, fiscalYearStart, currentTime)
+	_, currentPeriodEndTime := budget.PeriodType.GetPeriodRange(currentTime, firstDayOfWeek, fiscalYearStart)
+	periodSpentAmounts := make([]int64, len(periodStartTimes))
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+			continue
+		}
+
+		if budget.CategoryId != models.BudgetAllExpenseCategoriesId && !categoryIds[transaction.CategoryId] {
+			continue
+		}
+
+		account, exists := accountMap[transaction.AccountId]
+
+		if !exists {
+			continue
+		}
+
+		amount, exchanged := exchangeRateMap.ExchangeAmount(transaction.Amount, account.Currency, budget.Currency)
+
+		if !exchanged {
+			continue
+		}
+
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+
+		if transactionUnixTime >= currentPeriodEndTime.Unix() {
+			continue
+		}
+
+		for j := len(periodStartTimes) - 1; j >= 0; j-- {
+			if transactionUnixTime >= periodStartTimes[j].Unix() {
+				periodSpentAmounts[j] += amount
+				break
+			}
+		}
+	}
+
+	rolloverAmount := int64(0)
+
+	if budget.EnableRollover {
+		for i := 0; i < len(periodStartTimes)-1; i++ {
+			rolloverAmount = rolloverAmount + budget.Amount - periodSpentAmounts[i]
+
+			if rolloverAmount < 0 {
+				rolloverAmount = 0
+			}
+		}
+	}
+
+	currentPeriodIndex := len(periodStartTimes) - 1
+	availableAmount := budget.Amount + rolloverAmount
+	spentAmount := periodSpentAmounts[currentPeriodIndex]
+
+	return &models.BudgetProgressResponse{
+		BudgetId:        budget.BudgetId,
+		PeriodStartTime: periodStartTimes[currentPeriodIndex].Unix(),
+		PeriodEndTime:   currentPeriodEndTime.Unix() - 1,
+		Currency:        budget.Currency,
+		Amount:          budget.Amount,
+		RolloverAmount:  rolloverAmount,
+		AvailableAmount: availableAmount,
+		SpentAmount:     spentAmount,
+		RemainingAmount: availableAmount - spentAmount,
+		Overspent:       spentAmount > availableAmount,
+	}
+}
+
+// GetBudgetPeriodStartTimes returns the start times of all budget periods which need to be calculated until the period contains the specified time,
+// only the current period is returned if the budget does not enable rollover
+func (s *BudgetService) GetBudgetPeriodStartTimes(budget *models.Budget, firstDayOfWeek core.WeekDay, fiscalYearStart core.FiscalYearStart, currentTime time.Time) []time.Time {
+	currentPeriodStartTime, _ := budget.PeriodType.GetPeriodRange(currentTime, firstDayOfWeek, fiscalYearStart)
+
+	if !budget.EnableRollover || budget.StartTime <= 0 || budget.StartTime >= currentPeriodStartTime.Unix() {
+		return []time.Time{currentPeriodStartTime}
+	}
+
+	periodStartTime, periodEndTime := budget.PeriodType.GetPeriodRange(time.Unix(budget.StartTime, 0).In(currentTime.Location()), firstDayOfWeek, fiscalYearStart)
+	periodStartTimes := make([]time.Time, 0, 16)
+
+	for periodStartTime.Before(currentPeriodStartTime) {
+		periodStartTimes = append(periodStartTimes, periodStartTime)
+		periodStartTime, periodEndTime = budget.PeriodType.GetPeriodRange(periodEndTime, firstDayOfWeek, fiscalYearStart)
+	}
+
+	periodStartTimes = append(periodStartTimes, currentPeriodStartTime)
+
+	if len(periodStartTimes) > maxBudgetRolloverPeriodCount {
+		periodStartTimes = periodStartTimes[len(periodStartTimes)-maxBudgetRolloverPeriodCount:]
+	}
+
+	return periodStartTimes
+}
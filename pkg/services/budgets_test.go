@@ -0,0 +1,159 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestGetBudgetProgress_WithoutRollover(t *testing.T) {
+	budget := &models.Budget{
+		BudgetId:   1,
+		CategoryId: 2001,
+		PeriodType: models.BUDGET_PERIOD_TYPE_MONTHLY,
+		Amount:     100000,
+		Currency:   "CNY",
+		StartTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	accountMap := map[int64]*models.Account{
+		1001: {AccountId: 1001, Currency: "CNY"},
+		1002: {AccountId: 1002, Currency: "USD"},
+		1003: {AccountId: 1003, Currency: "JPY"},
+	}
+
+	transactions := []*models.Transaction{
+		createBudgetTestTransaction(1001, 2001, 30000, time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)),
+		createBudgetTestTransaction(1002, 2001, 1000, time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC)),
+		createBudgetTestTransaction(1003, 2001, 5000, time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)),
+		createBudgetTestTransaction(1001, 2002, 50000, time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)),
+		createBudgetTestTransaction(1001, 2001, 50000, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)),
+	}
+
+	exchangeRateMap := models.ExchangeRateMap{
+		"USD": 1,
+		"CNY": 7,
+	}
+
+	progress := Budgets.GetBudgetProgress(budget, transactions, nil, accountMap, map[int64]bool{2001: true}, exchangeRateMap, core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT, time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC).Unix(), progress.PeriodStartTime)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Unix()-1, progress.PeriodEndTime)
+	assert.Equal(t, int64(0), progress.RolloverAmount)
+	assert.Equal(t, int64(100000), progress.AvailableAmount)
+	assert.Equal(t, int64(37000), progress.SpentAmount)
+	assert.Equal(t, int64(63000), progress.RemainingAmount)
+	assert.False(t, progress.Overspent)
+}
+
+func TestGetBudgetProgress_WithRollover(t *testing.T) {
+	budget := &models.Budget{
+		BudgetId:       1,
+		CategoryId:     models.BudgetAllExpenseCategoriesId,
+		PeriodType:     models.BUDGET_PERIOD_TYPE_MONTHLY,
+		Amount:         100000,
+		Currency:       "CNY",
+		EnableRollover: true,
+		StartTime:      time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	accountMap := map[int64]*models.Account{
+		1001: {AccountId: 1001, Currency: "CNY"},
+	}
+
+	transactions := []*models.Transaction{
+		createBudgetTestTransaction(1001, 2001, 60000, time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)),
+		createBudgetTestTransaction(1001, 2002, 180000, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)),
+		createBudgetTestTransaction(1001, 2003, 70000, time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)),
+		createBudgetTestTransaction(1001, 2001, 140000, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)),
+	}
+
+	progress := Budgets.GetBudgetProgress(budget, transactions, nil, accountMap, nil, models.ExchangeRateMap{}, core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT, time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC))
+
+	// February: 40000 left, March: overspent and reset to 0, April: 30000 left
+	assert.Equal(t, int64(30000), progress.RolloverAmount)
+	assert.Equal(t, int64(130000), progress.AvailableAmount)
+	assert.Equal(t, int64(140000), progress.SpentAmount)
+	assert.Equal(t, int64(-10000), progress.RemainingAmount)
+	assert.True(t, progress.Overspent)
+}
+
+func TestGetBudgetProgress_WithSplitTransactions(t *testing.T) {
+	budget := &models.Budget{
+		BudgetId:   1,
+		CategoryId: 2002,
+		PeriodType: models.BUDGET_PERIOD_TYPE_MONTHLY,
+		Amount:     100000,
+		Currency:   "CNY",
+		StartTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	accountMap := map[int64]*models.Account{
+		1001: {AccountId: 1001, Currency: "CNY"},
+	}
+
+	splitTransaction := createBudgetTestTransaction(1001, 2001, 50000, time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC))
+	splitTransaction.TransactionId = 10
+	anotherSplitTransaction := createBudgetTestTransaction(1001, 2002, 40000, time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC))
+	anotherSplitTransaction.TransactionId = 11
+
+	transactions := []*models.Transaction{
+		splitTransaction,
+		anotherSplitTransaction,
+		createBudgetTestTransaction(1001, 2002, 5000, time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC)),
+	}
+
+	allSplits := map[int64][]*models.TransactionSplit{
+		10: {
+			{TransactionId: 10, CategoryId: 2001, Amount: 30000},
+			{TransactionId: 10, CategoryId: 2002, Amount: 20000},
+		},
+		11: {
+			{TransactionId: 11, CategoryId: 2002, Amount: 15000},
+			{TransactionId: 11, CategoryId: 2003, Amount: 25000},
+		},
+	}
+
+	progress := Budgets.GetBudgetProgress(budget, transactions, allSplits, accountMap, map[int64]bool{2002: true}, models.ExchangeRateMap{}, core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT, time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC))
+
+	// only the split lines in the budget category are counted
+	assert.Equal(t, int64(40000), progress.SpentAmount)
+	assert.Equal(t, int64(60000), progress.RemainingAmount)
+	assert.False(t, progress.Overspent)
+}
+
+func TestGetBudgetPeriodStartTimes(t *testing.T) {
+	budget := &models.Budget{
+		PeriodType:     models.BUDGET_PERIOD_TYPE_WEEKLY,
+		EnableRollover: true,
+		StartTime:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	periodStartTimes := Budgets.GetBudgetPeriodStartTimes(budget, core.WEEKDAY_MONDAY, core.FISCAL_YEAR_START_DEFAULT, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 3, len(periodStartTimes))
+	assert.Equal(t, time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), periodStartTimes[0])
+	assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), periodStartTimes[1])
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), periodStartTimes[2])
+
+	budget.EnableRollover = false
+	periodStartTimes = Budgets.GetBudgetPeriodStartTimes(budget, core.WEEKDAY_MONDAY, core.FISCAL_YEAR_START_DEFAULT, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 1, len(periodStartTimes))
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), periodStartTimes[0])
+}
+
+func createBudgetTestTransaction(accountId int64, categoryId int64, amount int64, transactionTime time.Time) *models.Transaction {
+	return &models.Transaction{
+		Type:            models.TRANSACTION_DB_TYPE_EXPENSE,
+		AccountId:       accountId,
+		CategoryId:      categoryId,
+		Amount:          amount,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(transactionTime.Unix()),
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// CreditCardStatementService represents credit card statement service
+type CreditCardStatementService struct {
+	ServiceUsingDB
+}
+
+// Initialize a credit card statement service singleton instance
+var (
+	CreditCardStatements = &CreditCardStatementService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetLatestStatements returns the latest closed statements of specified credit card account (the latest one is the first)
+func (s *CreditCardStatementService) GetLatestStatements(c core.Context, account *models.Account, count int, now time.Time) ([]*models.CreditCardStatement, error) {
+	if account == nil || account.Uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if account.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD || account.ParentAccountId != models.LevelOneAccountParentId || account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+		return nil, errs.ErrAccountIsNotCreditCard
+	}
+
+	statementDate, paymentDueDate, hasSettings := account.GetCreditCardStatementSettings()
+
+	if !hasSettings {
+		return nil, errs.ErrCreditCardStatementSettingsNotSet
+	}
+
+	cycles := models.GetLatestClosedCreditCardStatementCycles(statementDate, paymentDueDate, now, count)
+	transactions, err := s.getAccountTransactionsByMaxTime(c, account.Uid, account.AccountId, utils.GetMaxTransactionTimeFromUnixTime(cycles[0].DueUnixTime))
+
+	if err != nil {
+		return nil, err
+	}
+
+	statements := make([]*models.CreditCardStatement, len(cycles))
+
+	for i := 0; i < len(cycles); i++ {
+		statements[i] = models.CalculateCreditCardStatement(account.AccountId, transactions, cycles[i], now.Unix())
+	}
+
+	return statements, nil
+}
+
+// GetAllOverdueStatementsByUid returns all flagged overdue credit card statement models of user
+func (s *CreditCardStatementService) GetAllOverdueStatementsByUid(c core.Context, uid int64) ([]*models.CreditCardOverdueStatement, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var overdueStatements []*models.CreditCardOverdueStatement
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("due_unix_time desc").Find(&overdueStatements)
+
+	return overdueStatements, err
+}
+
+// CheckOverdueStatements flags the latest credit card statements which are still unpaid after the due date for all users, and unflags the statements which have been paid,
+// the accounts failed to check are skipped and all the errors are returned after checking the other accounts
+func (s *CreditCardStatementService) CheckOverdueStatements(c core.Context, now time.Time) error {
+	var allAccounts []*models.Account
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var accounts []*models.Account
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND category=? AND type=? AND parent_account_id=?", false, models.ACCOUNT_CATEGORY_CREDIT_CARD, models.ACCOUNT_TYPE_SINGLE_ACCOUNT, models.LevelOneAccountParentId).Find(&accounts)
+
+		if err != nil {
+			return err
+		}
+
+		allAccounts = append(allAccounts, accounts...)
+	}
+
+	flaggedCount := 0
+	unflaggedCount := 0
+	var failedErrors []error
+
+	for i := 0; i < len(allAccounts); i++ {
+		account := allAccounts[i]
+		statementDate, paymentDueDate, hasSettings := account.GetCreditCardStatementSettings()
+
+		if !hasSettings {
+			continue
+		}
+
+		var cycle *models.CreditCardStatementCycle
+		cycles := models.GetLatestClosedCreditCardStatementCycles(statementDate, paymentDueDate, now, 2)
+
+		for j := 0; j < len(cycles); j++ {
+			if cycles[j].DueUnixTime < now.Unix() {
+				cycle = cycles[j]
+				break
+			}
+		}
+
+		if cycle == nil {
+			continue
+		}
+
+		transactions, err := s.getAccountTransactionsByMaxTime(c, account.Uid, account.AccountId, utils.GetMaxTransactionTimeFromUnixTime(cycle.DueUnixTime))
+
+		if err != nil {
+			log.Errorf(c, "[credit_card_statements.CheckOverdueStatements] failed to get transactions of account \"id:%d\" for user \"uid:%d\", because %s", account.AccountId, account.Uid, err.Error())
+			failedErrors = append(failedErrors, err)
+			continue
+		}
+
+		statement := models.CalculateCreditCardStatement(account.AccountId, transactions, cycle, now.Unix())
+		changed, err := s.saveOverdueStatement(c, account.Uid, statement)
+
+		if err != nil {
+			log.Errorf(c, "[credit_card_statements.CheckOverdueStatements] failed to save overdue statement of account \"id:%d\" for user \"uid:%d\", because %s", account.AccountId, account.Uid, err.Error())
+			failedErrors = append(failedErrors, err)
+			continue
+		}
+
+		if changed && statement.Status == models.CREDIT_CARD_STATEMENT_STATUS_OVERDUE {
+			flaggedCount++
+			log.Infof(c, "[credit_card_statements.CheckOverdueStatements] statement of account \"id:%d\" for user \"uid:%d\" is overdue, unpaid amount is %d", account.AccountId, account.Uid, statement.StatementBalance-statement.PaidAmount)
+		} else if changed {
+			unflaggedCount++
+		}
+	}
+
+	log.Infof(c, "[credit_card_statements.CheckOverdueStatements] %d credit card accounts checked, %d statements flagged as overdue, %d statements unflagged and %d accounts failed to check", len(allAccounts), flaggedCount, unflaggedCount, len(failedErrors))
+
+	return errors.Join(failedErrors...)
+}
+
+// DeleteAllOverdueStatements deletes all flagged overdue credit card statements of user from database
+func (s *CreditCardStatementService) DeleteAllOverdueStatements(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.CreditCardOverdueStatement{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		return err
+	})
+}
+
+func (s *CreditCardStatementService) saveOverdueStatement(c core.Context, uid int64, statement *models.CreditCardStatement) (bool, error) {
+	changed := false
+	now := time.Now().Unix()
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		existedStatement := &models.CreditCardOverdueStatement{}
+		has, err := sess.Where("uid=? AND account_id=? AND statement_unix_time=?", uid, statement.AccountId, statement.EndUnixTime).Get(existedStatement)
+
+		if err != nil {
+			return err
+		}
+
+		if statement.Status != models.CREDIT_CARD_STATEMENT_STATUS_OVERDUE {
+			if !has || existedStatement.Deleted {
+				return nil
+			}
+
+			updateModel := &models.CreditCardOverdueStatement{
+				Deleted:         true,
+				PaidAmount:      statement.PaidAmount,
+				UpdatedUnixTime: now,
+				DeletedUnixTime: now,
+			}
+
+			_, err = sess.Cols("deleted", "paid_amount", "updated_unix_time", "deleted_unix_time").Where("uid=? AND account_id=? AND statement_unix_time=?", uid, statement.AccountId, statement.EndUnixTime).Update(updateModel)
+			changed = err == nil
+
+			return err
+		}
+
+		if has {
+			if !existedStatement.Deleted && existedStatement.PaidAmount == statement.PaidAmount {
+				return nil
+			}
+
+			updateModel := &models.CreditCardOverdueStatement{
+				Deleted:         false,
+				PaidAmount:      statement.PaidAmount,
+				UpdatedUnixTime: now,
+				DeletedUnixTime: 0,
+			}
+
+			_, err = sess.Cols("deleted", "paid_amount", "updated_unix_time", "deleted_unix_time").Where("uid=? AND account_id=? AND statement_unix_time=?", uid, statement.AccountId, statement.EndUnixTime).Update(updateModel)
+			changed = err == nil && existedStatement.Deleted
+
+			return err
+		}
+
+		overdueStatement := &models.CreditCardOverdueStatement{
+			Uid:               uid,
+			AccountId:         statement.AccountId,
+			StatementUnixTime: statement.EndUnixTime,
+			Deleted:           false,
+			DueUnixTime:       statement.DueUnixTime,
+			StatementBalance:  statement.StatementBalance,
+			MinimumDue:        statement.MinimumDue,
+			PaidAmount:        statement.PaidAmount,
+			CreatedUnixTime:   now,
+			UpdatedUnixTime:   now,
+		}
+
+		_, err = sess.Insert(overdueStatement)
+		changed = err == nil
+
+		return err
+	})
+
+	return changed, err
+}
+
+func (s *CreditCardStatementService) getAccountTransactionsByMaxTime(c core.Context, uid int64, accountId int64, maxTransactionTime int64) ([]*models.Transaction, error) {
+	var transactions []*models.Transaction
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND account_id=? AND transaction_time<=?", uid, false, accountId, maxTransactionTime).OrderBy("transaction_time asc").Find(&transactions)
+
+	return transactions, err
+}
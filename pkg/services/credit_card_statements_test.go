@@ -0,0 +1,86 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+func initializeServicesTestDataStore(t *testing.T, beans ...any) {
+	config := &settings.Config{
+		DatabaseConfig: &settings.DatabaseConfig{
+			DatabaseType:      settings.Sqlite3DbType,
+			DatabasePath:      filepath.Join(t.TempDir(), "ezbookkeeping.db"),
+			MaxOpenConnection: 2,
+		},
+		UuidGeneratorType: settings.InternalUuidGeneratorType,
+	}
+
+	settings.SetCurrentConfig(config)
+	assert.Nil(t, datastore.InitializeDataStore(config))
+	assert.Nil(t, uuid.InitializeUuidGenerator(config))
+	assert.Nil(t, datastore.Container.UserDataStore.SyncStructs(beans...))
+}
+
+func TestCheckOverdueStatements_ContinueCheckingOtherAccountsAfterFailure(t *testing.T) {
+	initializeServicesTestDataStore(t, new(models.Account), new(models.Transaction), new(models.CreditCardOverdueStatement))
+
+	uid := int64(1)
+	statementDate := 5
+	paymentDueDate := 25
+	transactionTime := utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, time.February, 10, 12, 0, 0, 0, time.UTC).Unix())
+
+	sess := datastore.Container.UserDataStore.Choose(uid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	for i, accountId := range []int64{101, 102} {
+		_, err := sess.Insert(&models.Account{
+			AccountId:       accountId,
+			Uid:             uid,
+			Category:        models.ACCOUNT_CATEGORY_CREDIT_CARD,
+			Type:            models.ACCOUNT_TYPE_SINGLE_ACCOUNT,
+			ParentAccountId: models.LevelOneAccountParentId,
+			Name:            "Credit Card " + utils.IntToString(i+1),
+			DisplayOrder:    int32(i + 1),
+			Currency:        "USD",
+			Extend: &models.AccountExtend{
+				CreditCardStatementDate:  &statementDate,
+				CreditCardPaymentDueDate: &paymentDueDate,
+			},
+		})
+		assert.Nil(t, err)
+
+		_, err = sess.Insert(&models.Transaction{
+			TransactionId:   accountId * 10,
+			Uid:             uid,
+			Type:            models.TRANSACTION_DB_TYPE_EXPENSE,
+			TransactionTime: transactionTime + int64(i),
+			AccountId:       accountId,
+			Amount:          2000,
+		})
+		assert.Nil(t, err)
+	}
+
+	// make saving the overdue statement of the first account fail
+	_, err := sess.Exec("CREATE TRIGGER reject_overdue_statement BEFORE INSERT ON credit_card_overdue_statement WHEN NEW.account_id = 101 BEGIN SELECT RAISE(ABORT, 'rejected'); END")
+	assert.Nil(t, err)
+
+	err = CreditCardStatements.CheckOverdueStatements(core.NewNullContext(), time.Date(2024, time.March, 26, 0, 0, 0, 0, time.UTC))
+	assert.ErrorContains(t, err, "rejected")
+
+	var overdueStatements []*models.CreditCardOverdueStatement
+	err = sess.Where("uid=? AND deleted=?", uid, false).Find(&overdueStatements)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(overdueStatements))
+	assert.Equal(t, int64(102), overdueStatements[0].AccountId)
+	assert.Equal(t, int64(2000), overdueStatements[0].StatementBalance)
+}
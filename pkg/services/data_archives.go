@@ -0,0 +1,856 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/storage"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// DataArchiveService represents ezbookkeeping data archive service
+type DataArchiveService struct {
+	ServiceUsingDB
+	ServiceUsingConfig
+	ServiceUsingUuid
+	ServiceUsingStorage
+}
+
+// Initialize a data archive service singleton instance
+var (
+	DataArchives = &DataArchiveService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingConfig: ServiceUsingConfig{
+			container: settings.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+		ServiceUsingStorage: ServiceUsingStorage{
+			container: storage.Container,
+		},
+	}
+)
+
+// ExportDataArchive returns the ezbookkeeping data archive file content which contains all book data and transaction pictures of user
+func (s *DataArchiveService) ExportDataArchive(c core.Context, uid int64) ([]byte, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	archive, err := s.getDataArchive(c, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pictureInfos := make([]*models.TransactionPictureInfo, 0, len(archive.TransactionPictureInfos))
+	pictureContents := make(map[int64][]byte, len(archive.TransactionPictureInfos))
+
+	for i := 0; i < len(archive.TransactionPictureInfos); i++ {
+		pictureInfo := archive.TransactionPictureInfos[i]
+		pictureData, err := s.readTransactionPictureData(c, pictureInfo)
+
+		if os.IsNotExist(err) {
+			log.Warnf(c, "[data_archives.ExportDataArchive] transaction picture \"id:%d\" of user \"uid:%d\" does not exist in storage, skip this picture", pictureInfo.PictureId, uid)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		pictureInfos = append(pictureInfos, pictureInfo)
+		pictureContents[pictureInfo.PictureId] = pictureData
+	}
+
+	archive.TransactionPictureInfos = pictureInfos
+
+	return writeDataArchiveFile(archive, pictureContents)
+}
+
+// ImportDataArchive imports all book data and transaction pictures in the ezbookkeeping data archive file into the empty book of user
+func (s *DataArchiveService) ImportDataArchive(c core.Context, uid int64, data []byte) (*models.DataArchiveImportResponse, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	currentConfig := s.CurrentConfig()
+	archive, pictureContents, err := readDataArchiveFile(data, uint64(currentConfig.MaxImportArchiveUncompressedSize), uint64(currentConfig.MaxTransactionPictureFileSize))
+
+	if err != nil {
+		return nil, err
+	}
+
+	empty, err := s.isBookEmpty(c, uid)
+
+	if err != nil {
+		return nil, err
+	} else if !empty {
+		return nil, errs.ErrDataArchiveBookNotEmpty
+	}
+
+	pictureContentsByInfo := make(map[*models.TransactionPictureInfo][]byte, len(archive.TransactionPictureInfos))
+
+	for i := 0; i < len(archive.TransactionPictureInfos); i++ {
+		pictureInfo := archive.TransactionPictureInfos[i]
+		pictureContentsByInfo[pictureInfo] = pictureContents[pictureInfo.PictureId]
+	}
+
+	err = remapDataArchiveIds(archive, uid, s.GenerateUuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	savedPictureInfos := make([]*models.TransactionPictureInfo, 0, len(archive.TransactionPictureInfos))
+
+	for i := 0; i < len(archive.TransactionPictureInfos); i++ {
+		pictureInfo := archive.TransactionPictureInfos[i]
+		err = s.SaveTransactionPicture(c, uid, pictureInfo.PictureId, storage.NewByteSliceObject(pictureContentsByInfo[pictureInfo]), pictureInfo.PictureExtension)
+
+		if err != nil {
+			s.deleteSavedTransactionPictures(c, uid, savedPictureInfos)
+			return nil, err
+		}
+
+		savedPictureInfos = append(savedPictureInfos, pictureInfo)
+	}
+
+	err = s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		beans := make([]any, 0)
+
+		for i := 0; i < len(archive.Accounts); i++ {
+			beans = append(beans, archive.Accounts[i])
+		}
+
+		for i := 0; i < len(archive.TransactionCategories); i++ {
+			beans = append(beans, archive.TransactionCategories[i])
+		}
+
+		for i := 0; i < len(archive.TransactionTagGroups); i++ {
+			beans = append(beans, archive.TransactionTagGroups[i])
+		}
+
+		for i := 0; i < len(archive.TransactionTags); i++ {
+			beans = append(beans, archive.TransactionTags[i])
+		}
+
+		for i := 0; i < len(archive.TransactionItemGroups); i++ {
+			beans = append(beans, archive.TransactionItemGroups[i])
+		}
+
+		for i := 0; i < len(archive.TransactionItems); i++ {
+			beans = append(beans, archive.TransactionItems[i])
+		}
+
+		for i := 0; i < len(archive.Transactions); i++ {
+			beans = append(beans, archive.Transactions[i])
+		}
+
+		for i := 0; i < len(archive.TransactionTagIndexes); i++ {
+			beans = append(beans, archive.TransactionTagIndexes[i])
+		}
+
+		for i := 0; i < len(archive.TransactionItemIndexes); i++ {
+			beans = append(beans, archive.TransactionItemIndexes[i])
+		}
+
+		for i := 0; i < len(archive.TransactionSplits); i++ {
+			beans = append(beans, archive.TransactionSplits[i])
+		}
+
+		for i := 0; i < len(archive.TransactionPictureInfos); i++ {
+			beans = append(beans, archive.TransactionPictureInfos[i])
+		}
+
+		for i := 0; i < len(archive.TransactionTemplates); i++ {
+			beans = append(beans, archive.TransactionTemplates[i])
+		}
+
+		for i := 0; i < len(archive.TransactionRules); i++ {
+			beans = append(beans, archive.TransactionRules[i])
+		}
+
+		for i := 0; i < len(archive.Budgets); i++ {
+			beans = append(beans, archive.Budgets[i])
+		}
+
+		for i := 0; i < len(archive.CreditCardOverdueStatements); i++ {
+			beans = append(beans, archive.CreditCardOverdueStatements[i])
+		}
+
+		for i := 0; i < len(archive.Webhooks); i++ {
+			beans = append(beans, archive.Webhooks[i])
+		}
+
+		for i := 0; i < len(beans); i++ {
+			_, err := sess.Insert(beans[i])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		s.deleteSavedTransactionPictures(c, uid, savedPictureInfos)
+		return nil, err
+	}
+
+	return &models.DataArchiveImportResponse{
+		AccountCount:             len(archive.Accounts),
+		TransactionCategoryCount: len(archive.TransactionCategories),
+		TransactionTagCount:      len(archive.TransactionTags),
+		TransactionItemCount:     len(archive.TransactionItems),
+		TransactionCount:         len(archive.Transactions),
+		TransactionPictureCount:  len(archive.TransactionPictureInfos),
+		TransactionTemplateCount: len(archive.TransactionTemplates),
+		TransactionRuleCount:     len(archive.TransactionRules),
+		BudgetCount:              len(archive.Budgets),
+		WebhookCount:             len(archive.Webhooks),
+	}, nil
+}
+
+func (s *DataArchiveService) getDataArchive(c core.Context, uid int64) (*models.DataArchive, error) {
+	archive := &models.DataArchive{
+		Version:          models.DataArchiveCurrentVersion,
+		ExportedUnixTime: time.Now().Unix(),
+	}
+
+	sess := s.UserDataDB(uid).NewSession(c)
+	defer sess.Close()
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("account_id asc").Find(&archive.Accounts); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("category_id asc").Find(&archive.TransactionCategories); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("tag_group_id asc").Find(&archive.TransactionTagGroups); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("tag_id asc").Find(&archive.TransactionTags); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("item_group_id asc").Find(&archive.TransactionItemGroups); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("item_id asc").Find(&archive.TransactionItems); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("transaction_time asc").Find(&archive.Transactions); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("tag_index_id asc").Find(&archive.TransactionTagIndexes); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("item_index_id asc").Find(&archive.TransactionItemIndexes); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("transaction_id asc, display_order asc").Find(&archive.TransactionSplits); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("picture_id asc").Find(&archive.TransactionPictureInfos); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("template_id asc").Find(&archive.TransactionTemplates); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("display_order asc, rule_id asc").Find(&archive.TransactionRules); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("budget_id asc").Find(&archive.Budgets); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("account_id asc, statement_unix_time asc").Find(&archive.CreditCardOverdueStatements); err != nil {
+		return nil, err
+	}
+
+	if err := sess.Where("uid=? AND deleted=?", uid, false).OrderBy("webhook_id asc").Find(&archive.Webhooks); err != nil {
+		return nil, err
+	}
+
+	// the webhook secrets are not exported, new secrets would be generated when importing
+	for i := 0; i < len(archive.Webhooks); i++ {
+		archive.Webhooks[i].Secret = ""
+	}
+
+	return archive, nil
+}
+
+func (s *DataArchiveService) readTransactionPictureData(c core.Context, pictureInfo *models.TransactionPictureInfo) ([]byte, error) {
+	pictureFile, err := s.ReadTransactionPicture(c, pictureInfo.Uid, pictureInfo.PictureId, pictureInfo.PictureExtension)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer pictureFile.Close()
+
+	return io.ReadAll(pictureFile)
+}
+
+// deleteSavedTransactionPictures removes the transaction pictures which have been saved to storage when the data archive import fails
+func (s *DataArchiveService) deleteSavedTransactionPictures(c core.Context, uid int64, pictureInfos []*models.TransactionPictureInfo) {
+	for i := 0; i < len(pictureInfos); i++ {
+		pictureInfo := pictureInfos[i]
+		err := s.DeleteTransactionPicture(c, uid, pictureInfo.PictureId, pictureInfo.PictureExtension)
+
+		if err != nil {
+			log.Warnf(c, "[data_archives.deleteSavedTransactionPictures] failed to delete transaction picture \"id:%d\" of user \"uid:%d\" after data archive import failed, because %s", pictureInfo.PictureId, uid, err.Error())
+		}
+	}
+}
+
+func (s *DataArchiveService) isBookEmpty(c core.Context, uid int64) (bool, error) {
+	sess := s.UserDataDB(uid).NewSession(c)
+	defer sess.Close()
+
+	beans := []any{
+		&models.Account{},
+		&models.TransactionCategory{},
+		&models.TransactionTag{},
+		&models.TransactionItem{},
+		&models.Transaction{},
+	}
+
+	for i := 0; i < len(beans); i++ {
+		count, err := sess.Where("uid=? AND deleted=?", uid, false).Count(beans[i])
+
+		if err != nil {
+			return false, err
+		} else if count > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func getDataArchivePictureFileName(pictureInfo *models.TransactionPictureInfo) string {
+	return fmt.Sprintf("%s%d.%s", models.DataArchivePictureDirectory, pictureInfo.PictureId, pictureInfo.PictureExtension)
+}
+
+func writeDataArchiveFile(archive *models.DataArchive, pictureContents map[int64][]byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+
+	dataWriter, err := zipWriter.Create(models.DataArchiveDataFileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.NewEncoder(dataWriter).Encode(archive)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(archive.TransactionPictureInfos); i++ {
+		pictureInfo := archive.TransactionPictureInfos[i]
+		pictureWriter, err := zipWriter.Create(getDataArchivePictureFileName(pictureInfo))
+
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = pictureWriter.Write(pictureContents[pictureInfo.PictureId])
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = zipWriter.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// readDataArchiveFile returns the book data and transaction pictures in the data archive file,
+// the total uncompressed size of all read files cannot exceed maxTotalSize and each picture cannot exceed maxPictureSize
+func readDataArchiveFile(data []byte, maxTotalSize uint64, maxPictureSize uint64) (*models.DataArchive, map[int64][]byte, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+
+	if err != nil {
+		return nil, nil, errs.ErrInvalidDataArchiveFile
+	}
+
+	files := make(map[string]*zip.File, len(zipReader.File))
+
+	for i := 0; i < len(zipReader.File); i++ {
+		files[zipReader.File[i].Name] = zipReader.File[i]
+	}
+
+	dataFile, exists := files[models.DataArchiveDataFileName]
+
+	if !exists {
+		return nil, nil, errs.ErrInvalidDataArchiveFile
+	}
+
+	remainingSize := maxTotalSize
+	dataContent, err := readDataArchiveZipFileContent(dataFile, remainingSize)
+
+	if err == errs.ErrDataArchiveTooLarge {
+		return nil, nil, err
+	} else if err != nil {
+		return nil, nil, errs.ErrInvalidDataArchiveFile
+	}
+
+	remainingSize -= uint64(len(dataContent))
+
+	archive := &models.DataArchive{}
+	err = json.Unmarshal(dataContent, archive)
+
+	if err != nil {
+		return nil, nil, errs.ErrInvalidDataArchiveFile
+	}
+
+	if archive.Version < models.DataArchiveMinimumSupportedVersion || archive.Version > models.DataArchiveCurrentVersion {
+		return nil, nil, errs.ErrDataArchiveNotSupported
+	}
+
+	pictureContents := make(map[int64][]byte, len(archive.TransactionPictureInfos))
+
+	for i := 0; i < len(archive.TransactionPictureInfos); i++ {
+		pictureInfo := archive.TransactionPictureInfos[i]
+
+		// the picture extension is used in the storage path, so only the supported image extensions are allowed
+		if !isDataArchivePictureExtensionValid(pictureInfo.PictureExtension) {
+			return nil, nil, errs.ErrInvalidDataArchiveFile
+		}
+
+		pictureFile, exists := files[getDataArchivePictureFileName(pictureInfo)]
+
+		if !exists {
+			return nil, nil, errs.ErrInvalidDataArchiveFile
+		}
+
+		pictureContent, err := readDataArchiveZipFileContent(pictureFile, min(maxPictureSize, remainingSize))
+
+		if err == errs.ErrDataArchiveTooLarge {
+			return nil, nil, err
+		} else if err != nil {
+			return nil, nil, errs.ErrInvalidDataArchiveFile
+		}
+
+		remainingSize -= uint64(len(pictureContent))
+		pictureContents[pictureInfo.PictureId] = pictureContent
+	}
+
+	return archive, pictureContents, nil
+}
+
+func isDataArchivePictureExtensionValid(pictureExtension string) bool {
+	if pictureExtension == "" || strings.Contains(pictureExtension, "..") || strings.ContainsAny(pictureExtension, "/\\") {
+		return false
+	}
+
+	return utils.GetImageContentType(pictureExtension) != ""
+}
+
+func readDataArchiveZipFileContent(file *zip.File, maxSize uint64) ([]byte, error) {
+	if file.UncompressedSize64 > maxSize {
+		return nil, errs.ErrDataArchiveTooLarge
+	}
+
+	reader, err := file.Open()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	// the uncompressed size in zip header may be forged, so read one more byte to detect the actual content exceeds the limit
+	content, err := io.ReadAll(io.LimitReader(reader, int64(maxSize)+1))
+
+	if err != nil {
+		return nil, err
+	} else if uint64(len(content)) > maxSize {
+		return nil, errs.ErrDataArchiveTooLarge
+	}
+
+	return content, nil
+}
+
+// remapDataArchiveIds replaces all the ids in the data archive with new generated ids and sets the owner to the specified user
+func remapDataArchiveIds(archive *models.DataArchive, uid int64, generateUuid func(uuidType uuid.UuidType) int64) error {
+	accountIds := make(map[int64]int64, len(archive.Accounts))
+	categoryIds := make(map[int64]int64, len(archive.TransactionCategories))
+	tagGroupIds := make(map[int64]int64, len(archive.TransactionTagGroups))
+	tagIds := make(map[int64]int64, len(archive.TransactionTags))
+	itemGroupIds := make(map[int64]int64, len(archive.TransactionItemGroups))
+	itemIds := make(map[int64]int64, len(archive.TransactionItems))
+	transactionIds := make(map[int64]int64, len(archive.Transactions))
+
+	newId := func(uuidType uuid.UuidType, ids map[int64]int64, oldId int64) (int64, error) {
+		id := generateUuid(uuidType)
+
+		if id < 1 {
+			return 0, errs.ErrSystemIsBusy
+		}
+
+		if ids != nil {
+			ids[oldId] = id
+		}
+
+		return id, nil
+	}
+
+	var err error
+
+	for i := 0; i < len(archive.Accounts); i++ {
+		if archive.Accounts[i].AccountId, err = newId(uuid.UUID_TYPE_ACCOUNT, accountIds, archive.Accounts[i].AccountId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionCategories); i++ {
+		if archive.TransactionCategories[i].CategoryId, err = newId(uuid.UUID_TYPE_CATEGORY, categoryIds, archive.TransactionCategories[i].CategoryId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionTagGroups); i++ {
+		if archive.TransactionTagGroups[i].TagGroupId, err = newId(uuid.UUID_TYPE_TAG_GROUP, tagGroupIds, archive.TransactionTagGroups[i].TagGroupId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionTags); i++ {
+		if archive.TransactionTags[i].TagId, err = newId(uuid.UUID_TYPE_TAG, tagIds, archive.TransactionTags[i].TagId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionItemGroups); i++ {
+		if archive.TransactionItemGroups[i].ItemGroupId, err = newId(uuid.UUID_TYPE_ITEM_GROUP, itemGroupIds, archive.TransactionItemGroups[i].ItemGroupId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionItems); i++ {
+		if archive.TransactionItems[i].ItemId, err = newId(uuid.UUID_TYPE_ITEM, itemIds, archive.TransactionItems[i].ItemId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.Transactions); i++ {
+		if archive.Transactions[i].TransactionId, err = newId(uuid.UUID_TYPE_TRANSACTION, transactionIds, archive.Transactions[i].TransactionId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.Accounts); i++ {
+		account := archive.Accounts[i]
+		account.Uid = uid
+		account.Deleted = false
+
+		if account.ParentAccountId, err = getRemappedDataArchiveId(accountIds, account.ParentAccountId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionCategories); i++ {
+		category := archive.TransactionCategories[i]
+		category.Uid = uid
+		category.Deleted = false
+
+		if category.ParentCategoryId, err = getRemappedDataArchiveId(categoryIds, category.ParentCategoryId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionTagGroups); i++ {
+		archive.TransactionTagGroups[i].Uid = uid
+		archive.TransactionTagGroups[i].Deleted = false
+	}
+
+	for i := 0; i < len(archive.TransactionTags); i++ {
+		tag := archive.TransactionTags[i]
+		tag.Uid = uid
+		tag.Deleted = false
+
+		if tag.TagGroupId, err = getRemappedDataArchiveId(tagGroupIds, tag.TagGroupId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionItemGroups); i++ {
+		archive.TransactionItemGroups[i].Uid = uid
+		archive.TransactionItemGroups[i].Deleted = false
+	}
+
+	for i := 0; i < len(archive.TransactionItems); i++ {
+		item := archive.TransactionItems[i]
+		item.Uid = uid
+		item.Deleted = false
+
+		if item.ItemGroupId, err = getRemappedDataArchiveId(itemGroupIds, item.ItemGroupId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.Transactions); i++ {
+		transaction := archive.Transactions[i]
+		transaction.Uid = uid
+		transaction.Deleted = false
+
+		if transaction.CreatedByUid != 0 {
+			transaction.CreatedByUid = uid
+		}
+
+		if transaction.UpdatedByUid != 0 {
+			transaction.UpdatedByUid = uid
+		}
+
+		if transaction.CategoryId, err = getRemappedDataArchiveId(categoryIds, transaction.CategoryId); err != nil {
+			return err
+		}
+
+		if transaction.AccountId, err = getRemappedDataArchiveId(accountIds, transaction.AccountId); err != nil {
+			return err
+		}
+
+		if transaction.RelatedAccountId, err = getRemappedDataArchiveId(accountIds, transaction.RelatedAccountId); err != nil {
+			return err
+		}
+
+		if transaction.RelatedId, err = getRemappedDataArchiveId(transactionIds, transaction.RelatedId); err != nil {
+			return err
+		}
+	}
+
+	tagIndexes := make([]*models.TransactionTagIndex, 0, len(archive.TransactionTagIndexes))
+
+	for i := 0; i < len(archive.TransactionTagIndexes); i++ {
+		tagIndex := archive.TransactionTagIndexes[i]
+		newTagId, tagExists := tagIds[tagIndex.TagId]
+		newTransactionId, transactionExists := transactionIds[tagIndex.TransactionId]
+
+		if !tagExists || !transactionExists {
+			continue
+		}
+
+		if tagIndex.TagIndexId, err = newId(uuid.UUID_TYPE_TAG_INDEX, nil, 0); err != nil {
+			return err
+		}
+
+		tagIndex.Uid = uid
+		tagIndex.Deleted = false
+		tagIndex.TagId = newTagId
+		tagIndex.TransactionId = newTransactionId
+		tagIndexes = append(tagIndexes, tagIndex)
+	}
+
+	archive.TransactionTagIndexes = tagIndexes
+
+	itemIndexes := make([]*models.TransactionItemIndex, 0, len(archive.TransactionItemIndexes))
+
+	for i := 0; i < len(archive.TransactionItemIndexes); i++ {
+		itemIndex := archive.TransactionItemIndexes[i]
+		newItemId, itemExists := itemIds[itemIndex.ItemId]
+		newTransactionId, transactionExists := transactionIds[itemIndex.TransactionId]
+
+		if !itemExists || !transactionExists {
+			continue
+		}
+
+		if itemIndex.ItemIndexId, err = newId(uuid.UUID_TYPE_ITEM_INDEX, nil, 0); err != nil {
+			return err
+		}
+
+		itemIndex.Uid = uid
+		itemIndex.Deleted = false
+		itemIndex.ItemId = newItemId
+		itemIndex.TransactionId = newTransactionId
+		itemIndexes = append(itemIndexes, itemIndex)
+	}
+
+	archive.TransactionItemIndexes = itemIndexes
+
+	for i := 0; i < len(archive.TransactionSplits); i++ {
+		split := archive.TransactionSplits[i]
+		split.Uid = uid
+		split.Deleted = false
+
+		if split.SplitId, err = newId(uuid.UUID_TYPE_TRANSACTION, nil, 0); err != nil {
+			return err
+		}
+
+		if split.TransactionId, err = getRemappedDataArchiveId(transactionIds, split.TransactionId); err != nil {
+			return err
+		}
+
+		if split.CategoryId, err = getRemappedDataArchiveId(categoryIds, split.CategoryId); err != nil {
+			return err
+		}
+
+		split.TagIds = getRemappedDataArchiveTagIds(tagIds, split.GetTagIds())
+	}
+
+	for i := 0; i < len(archive.TransactionPictureInfos); i++ {
+		pictureInfo := archive.TransactionPictureInfos[i]
+		pictureInfo.Uid = uid
+		pictureInfo.Deleted = false
+
+		if pictureInfo.PictureId, err = newId(uuid.UUID_TYPE_PICTURE, nil, 0); err != nil {
+			return err
+		}
+
+		if pictureInfo.TransactionId, err = getRemappedDataArchiveId(transactionIds, pictureInfo.TransactionId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionTemplates); i++ {
+		template := archive.TransactionTemplates[i]
+		template.Uid = uid
+		template.Deleted = false
+
+		if template.TemplateId, err = newId(uuid.UUID_TYPE_TEMPLATE, nil, 0); err != nil {
+			return err
+		}
+
+		if template.CategoryId, err = getRemappedDataArchiveId(categoryIds, template.CategoryId); err != nil {
+			return err
+		}
+
+		if template.AccountId, err = getRemappedDataArchiveId(accountIds, template.AccountId); err != nil {
+			return err
+		}
+
+		if template.RelatedAccountId, err = getRemappedDataArchiveId(accountIds, template.RelatedAccountId); err != nil {
+			return err
+		}
+
+		template.TagIds = getRemappedDataArchiveTagIds(tagIds, template.GetTagIds())
+	}
+
+	for i := 0; i < len(archive.Budgets); i++ {
+		budget := archive.Budgets[i]
+		budget.Uid = uid
+		budget.Deleted = false
+
+		if budget.BudgetId, err = newId(uuid.UUID_TYPE_BUDGET, nil, 0); err != nil {
+			return err
+		}
+
+		if budget.CategoryId, err = getRemappedDataArchiveId(categoryIds, budget.CategoryId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.TransactionRules); i++ {
+		rule := archive.TransactionRules[i]
+		rule.Uid = uid
+		rule.Deleted = false
+
+		if rule.RuleId, err = newId(uuid.UUID_TYPE_TEMPLATE, nil, 0); err != nil {
+			return err
+		}
+
+		if rule.ConditionAccountId, err = getRemappedDataArchiveId(accountIds, rule.ConditionAccountId); err != nil {
+			return err
+		}
+
+		if rule.ActionCategoryId, err = getRemappedDataArchiveId(categoryIds, rule.ActionCategoryId); err != nil {
+			return err
+		}
+
+		if rule.ActionAccountId, err = getRemappedDataArchiveId(accountIds, rule.ActionAccountId); err != nil {
+			return err
+		}
+
+		rule.ActionTagIds = getRemappedDataArchiveTagIds(tagIds, rule.GetActionTagIds())
+	}
+
+	for i := 0; i < len(archive.CreditCardOverdueStatements); i++ {
+		statement := archive.CreditCardOverdueStatements[i]
+		statement.Uid = uid
+		statement.Deleted = false
+
+		if statement.AccountId, err = getRemappedDataArchiveId(accountIds, statement.AccountId); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(archive.Webhooks); i++ {
+		webhook := archive.Webhooks[i]
+		webhook.Uid = uid
+		webhook.Deleted = false
+
+		if webhook.WebhookId, err = newId(uuid.UUID_TYPE_DEFAULT, nil, 0); err != nil {
+			return err
+		}
+
+		if webhook.Secret, err = Webhooks.GenerateSecret(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getRemappedDataArchiveTagIds(tagIds map[int64]int64, oldTagIds []int64) string {
+	newTagIds := make([]string, 0, len(oldTagIds))
+
+	for i := 0; i < len(oldTagIds); i++ {
+		if newTagId, exists := tagIds[oldTagIds[i]]; exists {
+			newTagIds = append(newTagIds, utils.Int64ToString(newTagId))
+		}
+	}
+
+	return strings.Join(newTagIds, ",")
+}
+
+func getRemappedDataArchiveId(ids map[int64]int64, oldId int64) (int64, error) {
+	if oldId == 0 {
+		return 0, nil
+	}
+
+	newId, exists := ids[oldId]
+
+	if !exists {
+		return 0, errs.ErrInvalidDataArchiveFile
+	}
+
+	return newId, nil
+}
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const dataArchiveTestMaxTotalSize = 1048576
+const dataArchiveTestMaxPictureSize = 1024
+
+func getDataArchiveTestData() *models.DataArchive {
+	return &models.DataArchive{
+		Version: models.DataArchiveCurrentVersion,
+		Accounts: []*models.Account{
+			{AccountId: 101, Uid: 1, Name: "Parent Account", Category: models.ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Type: models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS},
+			{AccountId: 102, Uid: 1, ParentAccountId: 101, Name: "Sub Account", Currency: "CNY", Balance: 12345},
+			{AccountId: 103, Uid: 1, Name: "Test Card", Currency: "USD", Balance: -100},
+		},
+		TransactionCategories: []*models.TransactionCategory{
+			{CategoryId: 201, Uid: 1, Name: "Food", Type: models.CATEGORY_TYPE_EXPENSE},
+			{CategoryId: 202, Uid: 1, ParentCategoryId: 201, Name: "Dinner", Type: models.CATEGORY_TYPE_EXPENSE},
+			{CategoryId: 203, Uid: 1, Name: "Transfer", Type: models.CATEGORY_TYPE_TRANSFER},
+		},
+		TransactionTagGroups: []*models.TransactionTagGroup{
+			{TagGroupId: 301, Uid: 1, Name: "Tag Group"},
+		},
+		TransactionTags: []*models.TransactionTag{
+			{TagId: 401, Uid: 1, TagGroupId: 301, Name: "Tag"},
+		},
+		TransactionItemGroups: []*models.TransactionItemGroup{
+			{ItemGroupId: 501, Uid: 1, Name: "Item Group"},
+		},
+		TransactionItems: []*models.TransactionItem{
+			{ItemId: 601, Uid: 1, ItemGroupId: 501, Name: "Item"},
+		},
+		Transactions: []*models.Transaction{
+			{TransactionId: 701, Uid: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 202, AccountId: 103, TransactionTime: 1725148800000, Amount: 100, GeoLongitude: 116.4, GeoLatitude: 39.9, Comment: "Dinner"},
+			{TransactionId: 702, Uid: 1, Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, CategoryId: 203, AccountId: 102, TransactionTime: 1725235200000, Amount: 700, RelatedId: 703, RelatedAccountId: 103, RelatedAccountAmount: 100},
+			{TransactionId: 703, Uid: 1, Type: models.TRANSACTION_DB_TYPE_TRANSFER_IN, CategoryId: 203, AccountId: 103, TransactionTime: 1725235200001, Amount: 100, RelatedId: 702, RelatedAccountId: 102, RelatedAccountAmount: 700},
+		},
+		TransactionTagIndexes: []*models.TransactionTagIndex{
+			{TagIndexId: 801, Uid: 1, TagId: 401, TransactionId: 701, TransactionTime: 1725148800000},
+			{TagIndexId: 802, Uid: 1, TagId: 499, TransactionId: 701, TransactionTime: 1725148800000},
+		},
+		TransactionItemIndexes: []*models.TransactionItemIndex{
+			{ItemIndexId: 901, Uid: 1, ItemId: 601, TransactionId: 701, TransactionTime: 1725148800000},
+		},
+		TransactionSplits: []*models.TransactionSplit{
+			{SplitId: 951, Uid: 1, TransactionId: 701, TransactionTime: 1725148800000, CategoryId: 202, Amount: 60, TagIds: "401,499", DisplayOrder: 1},
+			{SplitId: 952, Uid: 1, TransactionId: 701, TransactionTime: 1725148800000, CategoryId: 201, Amount: 40, Comment: "Drink", DisplayOrder: 2},
+		},
+		TransactionPictureInfos: []*models.TransactionPictureInfo{
+			{PictureId: 1001, Uid: 1, TransactionId: 701, PictureExtension: "jpg"},
+		},
+		TransactionTemplates: []*models.TransactionTemplate{
+			{TemplateId: 1101, Uid: 1, Name: "Template", CategoryId: 202, AccountId: 103, TagIds: "401,499", ScheduledMaxOccurrences: 12, ScheduledOccurrenceCount: 3},
+		},
+		TransactionRules: []*models.TransactionRule{
+			{RuleId: 1301, Uid: 1, Name: "Rule", ConditionKeywordMatchType: models.TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "Dinner", ConditionAccountId: 103, ActionCategoryId: 202, ActionTagIds: "401,499", ActionAccountId: 102, DisplayOrder: 1},
+		},
+		Budgets: []*models.Budget{
+			{BudgetId: 1201, Uid: 1, Name: "Budget", CategoryId: 201},
+		},
+		CreditCardOverdueStatements: []*models.CreditCardOverdueStatement{
+			{Uid: 1, AccountId: 103, StatementUnixTime: 1725148800, DueUnixTime: 1726876800, StatementBalance: 100, MinimumDue: 10, PaidAmount: 5},
+		},
+		Webhooks: []*models.Webhook{
+			{WebhookId: 1401, Uid: 1, Name: "Webhook", Url: "https://example.com/webhook", Secret: "secret", EventTypes: "1"},
+		},
+	}
+}
+
+func getDataArchiveTestUuidGenerator() func(uuidType uuid.UuidType) int64 {
+	lastId := int64(0)
+
+	return func(uuidType uuid.UuidType) int64 {
+		lastId++
+		return int64(uuidType)*10000 + lastId
+	}
+}
+
+func TestWriteAndReadDataArchiveFile(t *testing.T) {
+	archive := getDataArchiveTestData()
+	pictureContents := map[int64][]byte{
+		1001: {0xFF, 0xD8, 0xFF, 0xE0},
+	}
+
+	content, err := writeDataArchiveFile(archive, pictureContents)
+	assert.Nil(t, err)
+
+	actualArchive, actualPictureContents, err := readDataArchiveFile(content, dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.Nil(t, err)
+
+	assert.Equal(t, archive, actualArchive)
+	assert.Equal(t, pictureContents, actualPictureContents)
+}
+
+func TestReadDataArchiveFile_InvalidFile(t *testing.T) {
+	_, _, err := readDataArchiveFile([]byte("not a zip file"), dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrInvalidDataArchiveFile.Message)
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	_, _ = zipWriter.Create("other.json")
+	_ = zipWriter.Close()
+
+	_, _, err = readDataArchiveFile(buffer.Bytes(), dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrInvalidDataArchiveFile.Message)
+}
+
+func TestReadDataArchiveFile_MissingPictureFile(t *testing.T) {
+	archive := getDataArchiveTestData()
+	data, err := json.Marshal(archive)
+	assert.Nil(t, err)
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	dataWriter, _ := zipWriter.Create(models.DataArchiveDataFileName)
+	_, _ = dataWriter.Write(data)
+	_ = zipWriter.Close()
+
+	_, _, err = readDataArchiveFile(buffer.Bytes(), dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrInvalidDataArchiveFile.Message)
+}
+
+func TestReadDataArchiveFile_PictureTooLarge(t *testing.T) {
+	archive := getDataArchiveTestData()
+	content, err := writeDataArchiveFile(archive, map[int64][]byte{
+		1001: bytes.Repeat([]byte{0xFF}, dataArchiveTestMaxPictureSize+1),
+	})
+	assert.Nil(t, err)
+
+	_, _, err = readDataArchiveFile(content, dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrDataArchiveTooLarge.Message)
+
+	_, actualPictureContents, err := readDataArchiveFile(content, dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize+1)
+	assert.Nil(t, err)
+	assert.Equal(t, dataArchiveTestMaxPictureSize+1, len(actualPictureContents[1001]))
+}
+
+func TestReadDataArchiveFile_TotalSizeTooLarge(t *testing.T) {
+	archive := getDataArchiveTestData()
+	pictureContents := map[int64][]byte{
+		1001: bytes.Repeat([]byte{0xFF}, dataArchiveTestMaxPictureSize),
+	}
+	content, err := writeDataArchiveFile(archive, pictureContents)
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(archive)
+	assert.Nil(t, err)
+
+	// the data file can be read but there is no enough space left for the picture
+	_, _, err = readDataArchiveFile(content, uint64(len(data))+dataArchiveTestMaxPictureSize/2, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrDataArchiveTooLarge.Message)
+
+	// the data file itself exceeds the limit
+	_, _, err = readDataArchiveFile(content, 16, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrDataArchiveTooLarge.Message)
+}
+
+func TestReadDataArchiveZipFileContent_ForgedUncompressedSize(t *testing.T) {
+	content := bytes.Repeat([]byte{'a'}, 2048)
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	fileWriter, err := zipWriter.CreateRaw(&zip.FileHeader{
+		Name:               models.DataArchiveDataFileName,
+		Method:             zip.Store,
+		CompressedSize64:   uint64(len(content)),
+		UncompressedSize64: 16,
+	})
+	assert.Nil(t, err)
+	_, _ = fileWriter.Write(content)
+	_ = zipWriter.Close()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.Nil(t, err)
+
+	actualContent, err := readDataArchiveZipFileContent(zipReader.File[0], 1024)
+	assert.NotNil(t, err)
+	assert.Nil(t, actualContent)
+}
+
+func TestReadDataArchiveFile_InvalidPictureExtension(t *testing.T) {
+	invalidExtensions := []string{"", "exe", "png/../../../../etc/cron.d/x", "..", "png\\..\\x", "PNG/x"}
+
+	for i := 0; i < len(invalidExtensions); i++ {
+		archive := getDataArchiveTestData()
+		archive.TransactionPictureInfos[0].PictureExtension = invalidExtensions[i]
+
+		content, err := writeDataArchiveFile(archive, map[int64][]byte{
+			1001: {0xFF, 0xD8, 0xFF, 0xE0},
+		})
+		assert.Nil(t, err)
+
+		_, _, err = readDataArchiveFile(content, dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+		assert.EqualError(t, err, errs.ErrInvalidDataArchiveFile.Message)
+	}
+}
+
+func TestIsDataArchivePictureExtensionValid(t *testing.T) {
+	assert.True(t, isDataArchivePictureExtensionValid("jpg"))
+	assert.True(t, isDataArchivePictureExtensionValid("webp"))
+	assert.False(t, isDataArchivePictureExtensionValid(""))
+	assert.False(t, isDataArchivePictureExtensionValid("svg"))
+	assert.False(t, isDataArchivePictureExtensionValid("png/../x"))
+	assert.False(t, isDataArchivePictureExtensionValid("png\\x"))
+}
+
+func TestReadDataArchiveFile_NotSupportedVersion(t *testing.T) {
+	content, err := writeDataArchiveFile(&models.DataArchive{Version: models.DataArchiveCurrentVersion + 1}, nil)
+	assert.Nil(t, err)
+
+	_, _, err = readDataArchiveFile(content, dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrDataArchiveNotSupported.Message)
+
+	content, err = writeDataArchiveFile(&models.DataArchive{Version: models.DataArchiveMinimumSupportedVersion - 1}, nil)
+	assert.Nil(t, err)
+
+	_, _, err = readDataArchiveFile(content, dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.EqualError(t, err, errs.ErrDataArchiveNotSupported.Message)
+}
+
+func TestReadDataArchiveFile_PreviousVersion(t *testing.T) {
+	content, err := writeDataArchiveFile(&models.DataArchive{Version: 1}, nil)
+	assert.Nil(t, err)
+
+	archive, _, err := readDataArchiveFile(content, dataArchiveTestMaxTotalSize, dataArchiveTestMaxPictureSize)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, archive.Version)
+	assert.Nil(t, archive.TransactionRules)
+	assert.Nil(t, archive.Webhooks)
+}
+
+func TestRemapDataArchiveIds_MissingReferencedStatementAccount(t *testing.T) {
+	archive := getDataArchiveTestData()
+	archive.CreditCardOverdueStatements[0].AccountId = 199
+
+	err := remapDataArchiveIds(archive, 2, getDataArchiveTestUuidGenerator())
+	assert.EqualError(t, err, errs.ErrInvalidDataArchiveFile.Message)
+}
+
+func TestRemapDataArchiveIds(t *testing.T) {
+	archive := getDataArchiveTestData()
+
+	err := remapDataArchiveIds(archive, 2, getDataArchiveTestUuidGenerator())
+	assert.Nil(t, err)
+
+	assert.Equal(t, int64(20001), archive.Accounts[0].AccountId)
+	assert.Equal(t, int64(2), archive.Accounts[0].Uid)
+	assert.Equal(t, int64(0), archive.Accounts[0].ParentAccountId)
+	assert.Equal(t, int64(20002), archive.Accounts[1].AccountId)
+	assert.Equal(t, int64(20001), archive.Accounts[1].ParentAccountId)
+	assert.Equal(t, int64(12345), archive.Accounts[1].Balance)
+
+	assert.Equal(t, int64(40005), archive.TransactionCategories[1].CategoryId)
+	assert.Equal(t, int64(40004), archive.TransactionCategories[1].ParentCategoryId)
+
+	assert.Equal(t, int64(100007), archive.TransactionTagGroups[0].TagGroupId)
+	assert.Equal(t, int64(50008), archive.TransactionTags[0].TagId)
+	assert.Equal(t, int64(100007), archive.TransactionTags[0].TagGroupId)
+
+	assert.Equal(t, int64(110009), archive.TransactionItemGroups[0].ItemGroupId)
+	assert.Equal(t, int64(120010), archive.TransactionItems[0].ItemId)
+	assert.Equal(t, int64(110009), archive.TransactionItems[0].ItemGroupId)
+
+	assert.Equal(t, int64(30011), archive.Transactions[0].TransactionId)
+	assert.Equal(t, int64(2), archive.Transactions[0].Uid)
+	assert.Equal(t, int64(40005), archive.Transactions[0].CategoryId)
+	assert.Equal(t, int64(20003), archive.Transactions[0].AccountId)
+	assert.Equal(t, 116.4, archive.Transactions[0].GeoLongitude)
+	assert.Equal(t, 39.9, archive.Transactions[0].GeoLatitude)
+
+	assert.Equal(t, int64(30012), archive.Transactions[1].TransactionId)
+	assert.Equal(t, int64(30013), archive.Transactions[1].RelatedId)
+	assert.Equal(t, int64(20003), archive.Transactions[1].RelatedAccountId)
+	assert.Equal(t, int64(30013), archive.Transactions[2].TransactionId)
+	assert.Equal(t, int64(30012), archive.Transactions[2].RelatedId)
+	assert.Equal(t, int64(20002), archive.Transactions[2].RelatedAccountId)
+
+	assert.Equal(t, 1, len(archive.TransactionTagIndexes))
+	assert.Equal(t, int64(60014), archive.TransactionTagIndexes[0].TagIndexId)
+	assert.Equal(t, int64(50008), archive.TransactionTagIndexes[0].TagId)
+	assert.Equal(t, int64(30011), archive.TransactionTagIndexes[0].TransactionId)
+
+	assert.Equal(t, 1, len(archive.TransactionItemIndexes))
+	assert.Equal(t, int64(130015), archive.TransactionItemIndexes[0].ItemIndexId)
+	assert.Equal(t, int64(120010), archive.TransactionItemIndexes[0].ItemId)
+	assert.Equal(t, int64(30011), archive.TransactionItemIndexes[0].TransactionId)
+
+	assert.Equal(t, int64(30016), archive.TransactionSplits[0].SplitId)
+	assert.Equal(t, int64(30011), archive.TransactionSplits[0].TransactionId)
+	assert.Equal(t, int64(40005), archive.TransactionSplits[0].CategoryId)
+	assert.Equal(t, "50008", archive.TransactionSplits[0].TagIds)
+	assert.Equal(t, int64(30017), archive.TransactionSplits[1].SplitId)
+	assert.Equal(t, int64(40004), archive.TransactionSplits[1].CategoryId)
+	assert.Equal(t, int64(2), archive.TransactionSplits[1].Uid)
+
+	assert.Equal(t, int64(80018), archive.TransactionPictureInfos[0].PictureId)
+	assert.Equal(t, int64(30011), archive.TransactionPictureInfos[0].TransactionId)
+
+	assert.Equal(t, int64(70019), archive.TransactionTemplates[0].TemplateId)
+	assert.Equal(t, int64(40005), archive.TransactionTemplates[0].CategoryId)
+	assert.Equal(t, int64(20003), archive.TransactionTemplates[0].AccountId)
+	assert.Equal(t, "50008", archive.TransactionTemplates[0].TagIds)
+	assert.Equal(t, int32(12), archive.TransactionTemplates[0].ScheduledMaxOccurrences)
+	assert.Equal(t, int32(3), archive.TransactionTemplates[0].ScheduledOccurrenceCount)
+
+	assert.Equal(t, int64(140020), archive.Budgets[0].BudgetId)
+	assert.Equal(t, int64(40004), archive.Budgets[0].CategoryId)
+	assert.Equal(t, int64(2), archive.Budgets[0].Uid)
+
+	assert.Equal(t, int64(70021), archive.TransactionRules[0].RuleId)
+	assert.Equal(t, int64(2), archive.TransactionRules[0].Uid)
+	assert.Equal(t, int64(20003), archive.TransactionRules[0].ConditionAccountId)
+	assert.Equal(t, int64(40005), archive.TransactionRules[0].ActionCategoryId)
+	assert.Equal(t, int64(20002), archive.TransactionRules[0].ActionAccountId)
+	assert.Equal(t, "50008", archive.TransactionRules[0].ActionTagIds)
+
+	assert.Equal(t, int64(2), archive.CreditCardOverdueStatements[0].Uid)
+	assert.Equal(t, int64(20003), archive.CreditCardOverdueStatements[0].AccountId)
+	assert.Equal(t, int64(1725148800), archive.CreditCardOverdueStatements[0].StatementUnixTime)
+
+	assert.Equal(t, int64(22), archive.Webhooks[0].WebhookId)
+	assert.Equal(t, int64(2), archive.Webhooks[0].Uid)
+	assert.Equal(t, "https://example.com/webhook", archive.Webhooks[0].Url)
+	assert.Equal(t, 32, len(archive.Webhooks[0].Secret))
+	assert.NotEqual(t, "secret", archive.Webhooks[0].Secret)
+}
+
+func TestRemapDataArchiveIds_MissingReferencedAccount(t *testing.T) {
+	archive := getDataArchiveTestData()
+	archive.Transactions[0].AccountId = 199
+
+	err := remapDataArchiveIds(archive, 2, getDataArchiveTestUuidGenerator())
+	assert.EqualError(t, err, errs.ErrInvalidDataArchiveFile.Message)
+}
+
+func TestRemapDataArchiveIds_GenerateUuidFailed(t *testing.T) {
+	archive := getDataArchiveTestData()
+
+	err := remapDataArchiveIds(archive, 2, func(uuidType uuid.UuidType) int64 {
+		return 0
+	})
+	assert.EqualError(t, err, errs.ErrSystemIsBusy.Message)
+}
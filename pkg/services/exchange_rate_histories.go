@@ -0,0 +1,157 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// ExchangeRateHistoryService represents exchange rates history service
+type ExchangeRateHistoryService struct {
+	ServiceUsingDB
+}
+
+// Initialize a exchange rates history service singleton instance
+var (
+	ExchangeRateHistories = &ExchangeRateHistoryService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetLatestRateDate returns the latest date (in yyyymmdd format) which has saved exchange rates history of specified data source, returns 0 if there is no history
+func (s *ExchangeRateHistoryService) GetLatestRateDate(c core.Context, uid int64, dataSource string) (int32, error) {
+	if uid < 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	history := &models.ExchangeRateHistory{}
+	has, err := s.UserDataDB(uid).NewSession(c).Cols("rate_date").Where("uid=? AND data_source=?", uid, dataSource).OrderBy("rate_date desc").Limit(1).Get(history)
+
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, nil
+	}
+
+	return history.RateDate, nil
+}
+
+// GetExchangeRatesHistoryByDateRange returns the exchange rates history models of specified data source between the specified dates (in yyyymmdd format, 0 means no limit), the exchange rates on the nearest date before the start date are also returned
+func (s *ExchangeRateHistoryService) GetExchangeRatesHistoryByDateRange(c core.Context, uid int64, dataSource string, minRateDate int32, maxRateDate int32) ([]*models.ExchangeRateHistory, error) {
+	if uid < 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if minRateDate > 0 {
+		previousHistory := &models.ExchangeRateHistory{}
+		has, err := s.UserDataDB(uid).NewSession(c).Cols("rate_date").Where("uid=? AND data_source=? AND rate_date<=?", uid, dataSource, minRateDate).OrderBy("rate_date desc").Limit(1).Get(previousHistory)
+
+		if err != nil {
+			return nil, err
+		} else if has {
+			minRateDate = previousHistory.RateDate
+		}
+	}
+
+	condition := "uid=? AND data_source=?"
+	conditionParams := make([]any, 0, 4)
+	conditionParams = append(conditionParams, uid)
+	conditionParams = append(conditionParams, dataSource)
+
+	if minRateDate > 0 {
+		condition = condition + " AND rate_date>=?"
+		conditionParams = append(conditionParams, minRateDate)
+	}
+
+	if maxRateDate > 0 {
+		condition = condition + " AND rate_date<=?"
+		conditionParams = append(conditionParams, maxRateDate)
+	}
+
+	var histories []*models.ExchangeRateHistory
+	err := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...).OrderBy("rate_date asc").Find(&histories)
+
+	return histories, err
+}
+
+// SaveExchangeRatesHistory saves the exchange rates of each date of specified data source to database, the existed exchange rates on the same date are overwritten, returns the count of saved exchange rates
+func (s *ExchangeRateHistoryService) SaveExchangeRatesHistory(c core.Context, uid int64, dataSource string, allHistoricalExchangeRates []*models.HistoricalExchangeRates) (int, error) {
+	if uid < 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+	savedCount := 0
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(allHistoricalExchangeRates); i++ {
+			historicalExchangeRates := allHistoricalExchangeRates[i]
+
+			var existedHistories []*models.ExchangeRateHistory
+			err := sess.Where("uid=? AND data_source=? AND rate_date=?", uid, dataSource, historicalExchangeRates.RateDate).Find(&existedHistories)
+
+			if err != nil {
+				return err
+			}
+
+			existedHistoriesMap := make(map[string]*models.ExchangeRateHistory, len(existedHistories))
+
+			for j := 0; j < len(existedHistories); j++ {
+				existedHistoriesMap[existedHistories[j].Currency] = existedHistories[j]
+			}
+
+			histories := historicalExchangeRates.ToExchangeRateHistories(uid, dataSource)
+
+			for j := 0; j < len(histories); j++ {
+				history := histories[j]
+				existedHistory, exists := existedHistoriesMap[history.Currency]
+
+				if exists && existedHistory.BaseCurrency == history.BaseCurrency && existedHistory.Rate == history.Rate {
+					continue
+				}
+
+				history.UpdatedUnixTime = now
+
+				if exists {
+					_, err = sess.Cols("base_currency", "rate", "updated_unix_time").Where("uid=? AND data_source=? AND rate_date=? AND currency=?", uid, dataSource, history.RateDate, history.Currency).Update(history)
+				} else {
+					history.CreatedUnixTime = now
+					_, err = sess.Insert(history)
+				}
+
+				if err != nil {
+					return err
+				}
+
+				savedCount++
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return savedCount, nil
+}
+
+// DeleteAllExchangeRatesHistory deletes all exchange rates history saved from the custom exchange rates of user from database
+func (s *ExchangeRateHistoryService) DeleteAllExchangeRatesHistory(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Where("uid=?", uid).Delete(&models.ExchangeRateHistory{})
+		return err
+	})
+}
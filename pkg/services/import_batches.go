@@ -0,0 +1,499 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const importBatchTransactionIdsQueryPageSize = 500
+
+// ImportBatchService represents import batch service
+type ImportBatchService struct {
+	ServiceUsingDB
+}
+
+// Initialize an import batch service singleton instance
+var (
+	ImportBatches = &ImportBatchService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetLatestImportBatchesByUid returns the latest import batch models of user (the latest one is the first)
+func (s *ImportBatchService) GetLatestImportBatchesByUid(c core.Context, uid int64, count int32) ([]*models.ImportBatch, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var importBatches []*models.ImportBatch
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("created_unix_time desc, batch_id desc").Limit(int(count)).Find(&importBatches)
+
+	return importBatches, err
+}
+
+// GetImportBatchByBatchId returns an import batch model according to import batch id
+func (s *ImportBatchService) GetImportBatchByBatchId(c core.Context, uid int64, batchId int64) (*models.ImportBatch, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if batchId <= 0 {
+		return nil, errs.ErrImportBatchIdInvalid
+	}
+
+	importBatch := &models.ImportBatch{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(batchId).Where("uid=? AND deleted=?", uid, false).Get(importBatch)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrImportBatchNotFound
+	}
+
+	return importBatch, nil
+}
+
+// GetImportBatchByImportJobId returns the import batch model created by the specified import job, or nil if the import job has not created any import batch
+func (s *ImportBatchService) GetImportBatchByImportJobId(c core.Context, uid int64, jobId int64) (*models.ImportBatch, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if jobId <= 0 {
+		return nil, errs.ErrImportJobIdInvalid
+	}
+
+	importBatch := &models.ImportBatch{}
+	has, err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND import_job_id=?", uid, false, jobId).Get(importBatch)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+
+	return importBatch, nil
+}
+
+// GetImportedTransactionsByImportBatch returns the transaction models which are imported in the import batch and have not been deleted
+func (s *ImportBatchService) GetImportedTransactionsByImportBatch(c core.Context, uid int64, importBatch *models.ImportBatch) ([]*models.Transaction, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	return s.getTransactionsByTransactionIds(s.UserDataDB(uid).NewSession(c), uid, importBatch.GetTransactionIds())
+}
+
+// RollbackImportBatch deletes all transactions imported in the import batch and reverts the account balances,
+// the accounts, transaction categories and transaction tags created for the import batch are also deleted if they are not in use
+func (s *ImportBatchService) RollbackImportBatch(c core.Context, uid int64, batchId int64) (*models.ImportBatchRollbackResponse, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if batchId <= 0 {
+		return nil, errs.ErrImportBatchIdInvalid
+	}
+
+	now := time.Now().Unix()
+	result := &models.ImportBatchRollbackResponse{}
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		importBatch := &models.ImportBatch{}
+		has, err := sess.ID(batchId).Where("uid=? AND deleted=?", uid, false).Get(importBatch)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrImportBatchNotFound
+		} else if importBatch.RolledBack {
+			return errs.ErrImportBatchAlreadyRolledBack
+		}
+
+		transactions, err := s.getTransactionsByTransactionIds(sess, uid, importBatch.GetTransactionIds())
+
+		if err != nil {
+			return err
+		}
+
+		err = s.deleteImportedTransactions(c, sess, uid, transactions, now)
+
+		if err != nil {
+			return err
+		}
+
+		result.DeletedTransactionCount = len(transactions)
+
+		result.DeletedTagCount, err = s.deleteUnusedCreatedTags(sess, uid, importBatch.GetCreatedTagIds(), now)
+
+		if err != nil {
+			return err
+		}
+
+		result.DeletedCategoryCount, err = s.deleteUnusedCreatedCategories(sess, uid, importBatch.GetCreatedCategoryIds(), now)
+
+		if err != nil {
+			return err
+		}
+
+		result.DeletedAccountCount, err = s.deleteUnusedCreatedAccounts(sess, uid, importBatch.GetCreatedAccountIds(), now)
+
+		if err != nil {
+			return err
+		}
+
+		importBatch.RolledBack = true
+		importBatch.RolledBackUnixTime = now
+		importBatch.UpdatedUnixTime = now
+
+		updatedRows, err := sess.ID(importBatch.BatchId).Cols("rolled_back", "rolled_back_unix_time", "updated_unix_time").Where("uid=? AND deleted=? AND rolled_back=?", uid, false, false).Update(importBatch)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrImportBatchAlreadyRolledBack
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *ImportBatchService) getTransactionsByTransactionIds(sess *xorm.Session, uid int64, transactionIds []int64) ([]*models.Transaction, error) {
+	allTransactions := make([]*models.Transaction, 0, len(transactionIds))
+
+	for i := 0; i < len(transactionIds); i += importBatchTransactionIdsQueryPageSize {
+		var transactions []*models.Transaction
+		err := sess.Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds[i:min(i+importBatchTransactionIdsQueryPageSize, len(transactionIds))]).Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactions = append(allTransactions, transactions...)
+	}
+
+	return allTransactions, nil
+}
+
+func (s *ImportBatchService) deleteImportedTransactions(c core.Context, sess *xorm.Session, uid int64, transactions []*models.Transaction, now int64) error {
+	if len(transactions) < 1 {
+		return nil
+	}
+
+	accountBalanceChanges, err := s.getAccountBalanceChanges(transactions)
+
+	if err != nil {
+		return err
+	}
+
+	accountIds := make([]int64, 0, len(accountBalanceChanges))
+
+	for accountId := range accountBalanceChanges {
+		accountIds = append(accountIds, accountId)
+	}
+
+	sort.Slice(accountIds, func(i, j int) bool {
+		return accountIds[i] < accountIds[j]
+	})
+
+	var accounts []*models.Account
+	err = sess.Where("uid=? AND deleted=?", uid, false).In("account_id", accountIds).Find(&accounts)
+
+	if err != nil {
+		return err
+	} else if len(accounts) < len(accountIds) {
+		return errs.ErrAccountNotFound
+	}
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i].Hidden {
+			return errs.ErrCannotDeleteTransactionInHiddenAccount
+		} else if accounts[i].Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS {
+			return errs.ErrCannotDeleteTransactionInParentAccount
+		}
+	}
+
+	transactionIds := make([]int64, 0, len(transactions)*2)
+
+	for i := 0; i < len(transactions); i++ {
+		transactionIds = append(transactionIds, transactions[i].TransactionId)
+
+		if transactions[i].Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			transactionIds = append(transactionIds, transactions[i].RelatedId)
+		}
+	}
+
+	updateModel := &models.Transaction{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	tagIndexUpdateModel := &models.TransactionTagIndex{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	itemIndexUpdateModel := &models.TransactionItemIndex{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	splitUpdateModel := &models.TransactionSplit{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	pictureUpdateModel := &models.TransactionPictureInfo{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	for i := 0; i < len(transactionIds); i += importBatchTransactionIdsQueryPageSize {
+		pageTransactionIds := transactionIds[i:min(i+importBatchTransactionIdsQueryPageSize, len(transactionIds))]
+
+		// Update transaction rows to deleted
+		deletedRows, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", pageTransactionIds).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < int64(len(pageTransactionIds)) {
+			return errs.ErrTransactionNotFound
+		}
+
+		// Update transaction tag index
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", pageTransactionIds).Update(tagIndexUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction item index
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", pageTransactionIds).Update(itemIndexUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction split lines
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", pageTransactionIds).Update(splitUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction picture
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", pageTransactionIds).Update(pictureUpdateModel)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Update account table
+	for i := 0; i < len(accountIds); i++ {
+		accountId := accountIds[i]
+		balanceChange := accountBalanceChanges[accountId]
+
+		if balanceChange == 0 {
+			continue
+		}
+
+		accountUpdateModel := &models.Account{
+			UpdatedUnixTime: now,
+		}
+
+		updatedRows, err := sess.ID(accountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", balanceChange)).Cols("updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(accountUpdateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			log.Errorf(c, "[import_batches.deleteImportedTransactions] failed to update account \"id:%d\" balance", accountId)
+			return errs.ErrDatabaseOperationFailed
+		}
+	}
+
+	return nil
+}
+
+// getAccountBalanceChanges returns the balance change of each account when the specified transactions are deleted
+func (s *ImportBatchService) getAccountBalanceChanges(transactions []*models.Transaction) (map[int64]int64, error) {
+	accountBalanceChanges := make(map[int64]int64)
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		switch transaction.Type {
+		case models.TRANSACTION_DB_TYPE_MODIFY_BALANCE:
+			accountBalanceChanges[transaction.AccountId] -= transaction.RelatedAccountAmount
+		case models.TRANSACTION_DB_TYPE_INCOME:
+			accountBalanceChanges[transaction.AccountId] -= transaction.Amount
+		case models.TRANSACTION_DB_TYPE_EXPENSE:
+			accountBalanceChanges[transaction.AccountId] += transaction.Amount
+		case models.TRANSACTION_DB_TYPE_TRANSFER_OUT:
+			accountBalanceChanges[transaction.AccountId] += transaction.Amount
+			accountBalanceChanges[transaction.RelatedAccountId] -= transaction.RelatedAccountAmount
+		default:
+			return nil, errs.ErrTransactionTypeInvalid
+		}
+	}
+
+	return accountBalanceChanges, nil
+}
+
+func (s *ImportBatchService) deleteUnusedCreatedTags(sess *xorm.Session, uid int64, tagIds []int64, now int64) (int, error) {
+	if len(tagIds) < 1 {
+		return 0, nil
+	}
+
+	updateModel := &models.TransactionTag{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	deletedCount := 0
+
+	for i := 0; i < len(tagIds); i++ {
+		tagId := tagIds[i]
+
+		inUse, err := TransactionTags.isTagInUse(sess, uid, tagId, now)
+
+		if err != nil {
+			return 0, err
+		} else if inUse {
+			continue
+		}
+
+		deletedRows, err := sess.ID(tagId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return 0, err
+		}
+
+		deletedCount += int(deletedRows)
+	}
+
+	return deletedCount, nil
+}
+
+func (s *ImportBatchService) deleteUnusedCreatedCategories(sess *xorm.Session, uid int64, categoryIds []int64, now int64) (int, error) {
+	if len(categoryIds) < 1 {
+		return 0, nil
+	}
+
+	var categories []*models.TransactionCategory
+	err := sess.Where("uid=? AND deleted=?", uid, false).In("category_id", categoryIds).Find(&categories)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// delete the secondary categories first, so that the primary categories created for the import batch may have no sub categories
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].ParentCategoryId != models.LevelOneTransactionCategoryParentId && categories[j].ParentCategoryId == models.LevelOneTransactionCategoryParentId
+	})
+
+	updateModel := &models.TransactionCategory{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	deletedCount := 0
+
+	for i := 0; i < len(categories); i++ {
+		categoryId := categories[i].CategoryId
+
+		exists, err := sess.Cols("uid", "deleted", "parent_category_id").Where("uid=? AND deleted=? AND parent_category_id=?", uid, false, categoryId).Limit(1).Exist(&models.TransactionCategory{})
+
+		if err != nil {
+			return 0, err
+		} else if exists {
+			continue
+		}
+
+		inUse, err := TransactionCategories.isCategoriesInUse(sess, uid, []int64{categoryId}, now)
+
+		if err != nil {
+			return 0, err
+		} else if inUse {
+			continue
+		}
+
+		deletedRows, err := sess.ID(categoryId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return 0, err
+		}
+
+		deletedCount += int(deletedRows)
+	}
+
+	return deletedCount, nil
+}
+
+func (s *ImportBatchService) deleteUnusedCreatedAccounts(sess *xorm.Session, uid int64, accountIds []int64, now int64) (int, error) {
+	if len(accountIds) < 1 {
+		return 0, nil
+	}
+
+	var accounts []*models.Account
+	err := sess.Where("uid=? AND deleted=? AND type=?", uid, false, models.ACCOUNT_TYPE_SINGLE_ACCOUNT).In("account_id", accountIds).Find(&accounts)
+
+	if err != nil {
+		return 0, err
+	}
+
+	updateModel := &models.Account{
+		Balance:         0,
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	deletedCount := 0
+
+	for i := 0; i < len(accounts); i++ {
+		accountId := accounts[i].AccountId
+
+		exists, err := sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=? AND account_id=?", uid, false, accountId).Limit(1).Exist(&models.Transaction{})
+
+		if err != nil {
+			return 0, err
+		} else if exists {
+			continue
+		}
+
+		inUse, err := Accounts.isAccountsUsedByOtherData(sess, uid, []int64{accountId}, now)
+
+		if err != nil {
+			return 0, err
+		} else if inUse {
+			continue
+		}
+
+		deletedRows, err := sess.ID(accountId).Cols("balance", "deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return 0, err
+		}
+
+		deletedCount += int(deletedRows)
+	}
+
+	return deletedCount, nil
+}
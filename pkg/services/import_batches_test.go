@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestGetAccountBalanceChanges(t *testing.T) {
+	transactions := []*models.Transaction{
+		{Type: models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, AccountId: 101, RelatedAccountAmount: 10000},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 101, Amount: 1000},
+		{Type: models.TRANSACTION_DB_TYPE_INCOME, AccountId: 102, Amount: 500},
+		{Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, AccountId: 101, Amount: 200, RelatedAccountId: 103, RelatedAccountAmount: 30},
+	}
+
+	actualChanges, err := ImportBatches.getAccountBalanceChanges(transactions)
+	assert.Nil(t, err)
+	assert.Equal(t, map[int64]int64{
+		101: -10000 + 1000 + 200,
+		102: -500,
+		103: -30,
+	}, actualChanges)
+}
+
+func TestGetAccountBalanceChanges_TransferInTransaction(t *testing.T) {
+	transactions := []*models.Transaction{
+		{Type: models.TRANSACTION_DB_TYPE_TRANSFER_IN, AccountId: 101, Amount: 200, RelatedAccountId: 102, RelatedAccountAmount: 200},
+	}
+
+	_, err := ImportBatches.getAccountBalanceChanges(transactions)
+	assert.Equal(t, errs.ErrTransactionTypeInvalid, err)
+}
+
+func TestRollbackImportBatch_KeepCreatedDataInUse(t *testing.T) {
+	initializeServicesTestDataStore(t, new(models.Account), new(models.Transaction), new(models.TransactionCategory), new(models.TransactionTag),
+		new(models.TransactionTagIndex), new(models.TransactionTemplate), new(models.TransactionSplit), new(models.TransactionRule), new(models.Budget),
+		new(models.CreditCardOverdueStatement), new(models.ImportBatch))
+
+	uid := int64(1)
+
+	sess := datastore.Container.UserDataStore.Choose(uid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	for _, accountId := range []int64{101, 102, 103} {
+		_, err := sess.Insert(&models.Account{AccountId: accountId, Uid: uid, Category: models.ACCOUNT_CATEGORY_CASH, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Account", Currency: "USD"})
+		assert.Nil(t, err)
+	}
+
+	for _, categoryId := range []int64{201, 202, 203, 204} {
+		_, err := sess.Insert(&models.TransactionCategory{CategoryId: categoryId, Uid: uid, Type: models.CATEGORY_TYPE_EXPENSE, Name: "Category"})
+		assert.Nil(t, err)
+	}
+
+	for _, tagId := range []int64{301, 302, 303} {
+		_, err := sess.Insert(&models.TransactionTag{TagId: tagId, Uid: uid, Name: "Tag" + utils.Int64ToString(tagId)})
+		assert.Nil(t, err)
+	}
+
+	_, err := sess.Insert(&models.TransactionSplit{SplitId: 401, Uid: uid, TransactionId: 501, CategoryId: 201, Amount: 100, TagIds: "301"})
+	assert.Nil(t, err)
+	_, err = sess.Insert(&models.TransactionRule{RuleId: 601, Uid: uid, Name: "Rule", ConditionAccountId: 101, ActionCategoryId: 202, ActionTagIds: "302"})
+	assert.Nil(t, err)
+	_, err = sess.Insert(&models.Budget{BudgetId: 701, Uid: uid, Name: "Budget", CategoryId: 203, Currency: "USD"})
+	assert.Nil(t, err)
+	_, err = sess.Insert(&models.CreditCardOverdueStatement{Uid: uid, AccountId: 102, StatementUnixTime: 1, DueUnixTime: 2})
+	assert.Nil(t, err)
+
+	importBatch := &models.ImportBatch{BatchId: 801, Uid: uid, FileType: "csv"}
+	importBatch.SetCreatedAccountIds([]int64{101, 102, 103})
+	importBatch.SetCreatedCategoryIds([]int64{201, 202, 203, 204})
+	importBatch.SetCreatedTagIds([]int64{301, 302, 303})
+	_, err = sess.Insert(importBatch)
+	assert.Nil(t, err)
+
+	result, err := ImportBatches.RollbackImportBatch(core.NewNullContext(), uid, importBatch.BatchId)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.DeletedAccountCount)
+	assert.Equal(t, 1, result.DeletedCategoryCount)
+	assert.Equal(t, 1, result.DeletedTagCount)
+
+	exists, err := sess.Where("uid=? AND deleted=? AND account_id=?", uid, true, 103).Exist(&models.Account{})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	exists, err = sess.Where("uid=? AND deleted=? AND category_id=?", uid, true, 204).Exist(&models.TransactionCategory{})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	exists, err = sess.Where("uid=? AND deleted=? AND tag_id=?", uid, true, 303).Exist(&models.TransactionTag{})
+	assert.Nil(t, err)
+	assert.True(t, exists)
+}
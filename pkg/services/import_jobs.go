@@ -0,0 +1,275 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// ImportJobService represents import job service
+type ImportJobService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize an import job service singleton instance
+var (
+	ImportJobs = &ImportJobService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetLatestImportJobsByUid returns the latest import job models of user (the latest one is the first), the payload is not loaded
+func (s *ImportJobService) GetLatestImportJobsByUid(c core.Context, uid int64, count int32) ([]*models.ImportJob, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var importJobs []*models.ImportJob
+	err := s.UserDataDB(uid).NewSession(c).Omit("payload").Where("uid=? AND deleted=?", uid, false).OrderBy("created_unix_time desc, job_id desc").Limit(int(count)).Find(&importJobs)
+
+	return importJobs, err
+}
+
+// GetImportJobByJobId returns an import job model according to import job id, the payload is not loaded
+func (s *ImportJobService) GetImportJobByJobId(c core.Context, uid int64, jobId int64) (*models.ImportJob, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if jobId <= 0 {
+		return nil, errs.ErrImportJobIdInvalid
+	}
+
+	importJob := &models.ImportJob{}
+	has, err := s.UserDataDB(uid).NewSession(c).Omit("payload").ID(jobId).Where("uid=? AND deleted=?", uid, false).Get(importJob)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrImportJobNotFound
+	}
+
+	return importJob, nil
+}
+
+// GetImportJobByClientSessionId returns the latest import job model submitted with the specified client session id, the payload is not loaded
+func (s *ImportJobService) GetImportJobByClientSessionId(c core.Context, uid int64, clientSessionId string) (*models.ImportJob, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if clientSessionId == "" {
+		return nil, errs.ErrImportJobNotFound
+	}
+
+	importJob := &models.ImportJob{}
+	has, err := s.UserDataDB(uid).NewSession(c).Omit("payload").Where("uid=? AND deleted=? AND client_session_id=?", uid, false, clientSessionId).OrderBy("created_unix_time desc, job_id desc").Get(importJob)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrImportJobNotFound
+	}
+
+	return importJob, nil
+}
+
+// CreateImportJob saves a new pending import job model to database
+func (s *ImportJobService) CreateImportJob(c core.Context, importJob *models.ImportJob) error {
+	if importJob.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	importJob.JobId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if importJob.JobId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	importJob.Deleted = false
+	importJob.Status = models.IMPORT_JOB_STATUS_PENDING
+	importJob.Process = 0
+	importJob.Attempts = 0
+	importJob.CreatedUnixTime = time.Now().Unix()
+	importJob.UpdatedUnixTime = importJob.CreatedUnixTime
+
+	_, err := s.UserDataDB(importJob.Uid).NewSession(c).Insert(importJob)
+
+	return err
+}
+
+// ClaimNextPendingImportJob marks the earliest pending import job of all users as processing and returns it with payload, or returns nil if there is no pending import job
+func (s *ImportJobService) ClaimNextPendingImportJob(c core.Context) (*models.ImportJob, error) {
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		for {
+			importJob := &models.ImportJob{}
+			has, err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND status=?", false, models.IMPORT_JOB_STATUS_PENDING).OrderBy("updated_unix_time asc, job_id asc").Get(importJob)
+
+			if err != nil {
+				return nil, err
+			} else if !has {
+				break
+			}
+
+			now := time.Now().Unix()
+			updateModel := &models.ImportJob{
+				Status:          models.IMPORT_JOB_STATUS_PROCESSING,
+				Attempts:        importJob.Attempts + 1,
+				StartedUnixTime: now,
+				UpdatedUnixTime: now,
+			}
+
+			// only one worker can change the status from pending to processing, other workers will try the next pending import job
+			updatedRows, err := s.UserDataDB(importJob.Uid).NewSession(c).ID(importJob.JobId).Cols("status", "attempts", "started_unix_time", "updated_unix_time").Where("uid=? AND deleted=? AND status=? AND attempts=?", importJob.Uid, false, models.IMPORT_JOB_STATUS_PENDING, importJob.Attempts).Update(updateModel)
+
+			if err != nil {
+				return nil, err
+			} else if updatedRows < 1 {
+				continue
+			}
+
+			importJob.Status = updateModel.Status
+			importJob.Attempts = updateModel.Attempts
+			importJob.StartedUnixTime = updateModel.StartedUnixTime
+			importJob.UpdatedUnixTime = updateModel.UpdatedUnixTime
+
+			return importJob, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// UpdateImportJobProcess saves the current process of the processing import job, it also indicates the import job is still alive
+func (s *ImportJobService) UpdateImportJobProcess(c core.Context, importJob *models.ImportJob, process float64) error {
+	if importJob.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.ImportJob{
+		Process:         min(max(process, 0), 100),
+		UpdatedUnixTime: time.Now().Unix(),
+	}
+
+	_, err := s.UserDataDB(importJob.Uid).NewSession(c).ID(importJob.JobId).Cols("process", "updated_unix_time").Where("uid=? AND deleted=? AND status=? AND attempts=?", importJob.Uid, false, models.IMPORT_JOB_STATUS_PROCESSING, importJob.Attempts).Update(updateModel)
+
+	return err
+}
+
+// markImportJobImported saves the import batch of the import job in the database transaction which imports transactions,
+// it returns error if the import job is no longer processed by the current attempt, so the database transaction will be rolled back
+func (s *ImportJobService) markImportJobImported(sess *xorm.Session, importJob *models.ImportJob, importBatch *models.ImportBatch) error {
+	updateModel := &models.ImportJob{
+		ImportedCount:   importBatch.ImportedCount,
+		ImportBatchId:   importBatch.BatchId,
+		UpdatedUnixTime: time.Now().Unix(),
+	}
+
+	updatedRows, err := sess.ID(importJob.JobId).Cols("imported_count", "import_batch_id", "updated_unix_time").Where("uid=? AND deleted=? AND status=? AND attempts=?", importJob.Uid, false, models.IMPORT_JOB_STATUS_PROCESSING, importJob.Attempts).Update(updateModel)
+
+	if err != nil {
+		return err
+	} else if updatedRows < 1 {
+		return errs.ErrImportJobClaimedByOthers
+	}
+
+	return nil
+}
+
+// SucceedImportJob marks the processing import job as succeeded and clears its payload
+func (s *ImportJobService) SucceedImportJob(c core.Context, importJob *models.ImportJob, importBatch *models.ImportBatch) error {
+	if importJob.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	importJob.Status = models.IMPORT_JOB_STATUS_SUCCEEDED
+	importJob.Payload = ""
+	importJob.Process = 100
+	importJob.ErrorCode = 0
+	importJob.ErrorMessage = ""
+	importJob.FinishedUnixTime = now
+	importJob.UpdatedUnixTime = now
+
+	if importBatch != nil {
+		importJob.ImportedCount = importBatch.ImportedCount
+		importJob.ImportBatchId = importBatch.BatchId
+	}
+
+	_, err := s.UserDataDB(importJob.Uid).NewSession(c).ID(importJob.JobId).Cols("status", "payload", "process", "imported_count", "import_batch_id", "error_code", "error_message", "finished_unix_time", "updated_unix_time").Where("uid=? AND deleted=? AND status=? AND attempts=?", importJob.Uid, false, models.IMPORT_JOB_STATUS_PROCESSING, importJob.Attempts).Update(importJob)
+
+	return err
+}
+
+// FailImportJob marks the processing import job as failed with the specified error and clears its payload
+func (s *ImportJobService) FailImportJob(c core.Context, importJob *models.ImportJob, jobError error) error {
+	if importJob.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	importJob.Status = models.IMPORT_JOB_STATUS_FAILED
+	importJob.Payload = ""
+	importJob.FinishedUnixTime = now
+	importJob.UpdatedUnixTime = now
+	importJob.SetError(jobError)
+
+	_, err := s.UserDataDB(importJob.Uid).NewSession(c).ID(importJob.JobId).Cols("status", "payload", "error_code", "error_message", "finished_unix_time", "updated_unix_time").Where("uid=? AND deleted=? AND status=? AND attempts=?", importJob.Uid, false, models.IMPORT_JOB_STATUS_PROCESSING, importJob.Attempts).Update(importJob)
+
+	return err
+}
+
+// ResetStaleImportJobs puts the processing import jobs which have not been updated since the specified time back to pending,
+// or marks them as failed if they have been attempted too many times, and returns the count of reset import jobs and failed import jobs
+func (s *ImportJobService) ResetStaleImportJobs(c core.Context, staleTime time.Time, maxAttempts int32) (int64, int64, error) {
+	var totalResetCount, totalFailedCount int64
+	interruptedError := errs.ErrImportJobInterrupted
+	now := time.Now().Unix()
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		failedModel := &models.ImportJob{
+			Status:           models.IMPORT_JOB_STATUS_FAILED,
+			Payload:          "",
+			ErrorCode:        interruptedError.Code(),
+			ErrorMessage:     interruptedError.Message,
+			FinishedUnixTime: now,
+			UpdatedUnixTime:  now,
+		}
+
+		failedCount, err := s.UserDataDBByIndex(i).NewSession(c).Cols("status", "payload", "error_code", "error_message", "finished_unix_time", "updated_unix_time").Where("deleted=? AND status=? AND updated_unix_time<? AND attempts>=?", false, models.IMPORT_JOB_STATUS_PROCESSING, staleTime.Unix(), maxAttempts).Update(failedModel)
+
+		if err != nil {
+			return totalResetCount, totalFailedCount, err
+		}
+
+		resetModel := &models.ImportJob{
+			Status:          models.IMPORT_JOB_STATUS_PENDING,
+			UpdatedUnixTime: now,
+		}
+
+		resetCount, err := s.UserDataDBByIndex(i).NewSession(c).Cols("status", "updated_unix_time").Where("deleted=? AND status=? AND updated_unix_time<? AND attempts<?", false, models.IMPORT_JOB_STATUS_PROCESSING, staleTime.Unix(), maxAttempts).Update(resetModel)
+
+		if err != nil {
+			return totalResetCount, totalFailedCount, err
+		}
+
+		totalFailedCount += failedCount
+		totalResetCount += resetCount
+	}
+
+	return totalResetCount, totalFailedCount, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func initializeImportJobTestData(t *testing.T, uid int64, importJob *models.ImportJob) {
+	initializeServicesTestDataStore(t, new(models.Account), new(models.Transaction), new(models.TransactionCategory), new(models.TransactionTag),
+		new(models.TransactionTagIndex), new(models.ImportBatch), new(models.ImportJob))
+
+	sess := datastore.Container.UserDataStore.Choose(uid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	_, err := sess.Insert(&models.Account{AccountId: 101, Uid: uid, Category: models.ACCOUNT_CATEGORY_CASH, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Account", Currency: "USD"})
+	assert.Nil(t, err)
+	_, err = sess.Insert([]*models.TransactionCategory{
+		{CategoryId: 201, Uid: uid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: models.LevelOneTransactionCategoryParentId, Name: "Category"},
+		{CategoryId: 202, Uid: uid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 201, Name: "Sub Category"},
+	})
+	assert.Nil(t, err)
+	_, err = sess.Insert(importJob)
+	assert.Nil(t, err)
+}
+
+func createImportJobTestTransactions(uid int64) []*models.Transaction {
+	transactionTime := utils.GetMinTransactionTimeFromUnixTime(time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC).Unix())
+
+	return []*models.Transaction{
+		{Uid: uid, Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 101, CategoryId: 202, Amount: 100, TransactionTime: transactionTime},
+		{Uid: uid, Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 101, CategoryId: 202, Amount: 200, TransactionTime: transactionTime + 1000},
+	}
+}
+
+func TestBatchCreateTransactionsByImportJob_SaveImportBatchOfImportJob(t *testing.T) {
+	uid := int64(1)
+	importJob := &models.ImportJob{JobId: 901, Uid: uid, Status: models.IMPORT_JOB_STATUS_PROCESSING, Attempts: 1}
+	initializeImportJobTestData(t, uid, importJob)
+
+	importBatch := &models.ImportBatch{Uid: uid, TotalCount: 2}
+	err := Transactions.BatchCreateTransactionsByImportJob(core.NewNullContext(), importJob, createImportJobTestTransactions(uid), nil, importBatch, nil)
+	assert.Nil(t, err)
+
+	savedImportJob, err := ImportJobs.GetImportJobByJobId(core.NewNullContext(), uid, importJob.JobId)
+	assert.Nil(t, err)
+	assert.Equal(t, importBatch.BatchId, savedImportJob.ImportBatchId)
+	assert.Equal(t, int32(2), savedImportJob.ImportedCount)
+
+	savedImportBatch, err := ImportBatches.GetImportBatchByImportJobId(core.NewNullContext(), uid, importJob.JobId)
+	assert.Nil(t, err)
+	assert.NotNil(t, savedImportBatch)
+	assert.Equal(t, importBatch.BatchId, savedImportBatch.BatchId)
+}
+
+func TestBatchCreateTransactionsByImportJob_RollbackWhenImportJobClaimedAgain(t *testing.T) {
+	uid := int64(1)
+	initializeImportJobTestData(t, uid, &models.ImportJob{JobId: 901, Uid: uid, Status: models.IMPORT_JOB_STATUS_PROCESSING, Attempts: 2})
+
+	// the import job has been reset and claimed by the second attempt while the first attempt is still importing
+	importJob := &models.ImportJob{JobId: 901, Uid: uid, Status: models.IMPORT_JOB_STATUS_PROCESSING, Attempts: 1}
+	err := Transactions.BatchCreateTransactionsByImportJob(core.NewNullContext(), importJob, createImportJobTestTransactions(uid), nil, &models.ImportBatch{Uid: uid, TotalCount: 2}, nil)
+	assert.Equal(t, errs.ErrImportJobClaimedByOthers, err)
+
+	sess := datastore.Container.UserDataStore.Choose(uid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	transactionCount, err := sess.Where("uid=? AND deleted=?", uid, false).Count(&models.Transaction{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), transactionCount)
+
+	importBatch, err := ImportBatches.GetImportBatchByImportJobId(core.NewNullContext(), uid, importJob.JobId)
+	assert.Nil(t, err)
+	assert.Nil(t, importBatch)
+}
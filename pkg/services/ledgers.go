@@ -0,0 +1,281 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// LedgerService represents shared ledger service
+type LedgerService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a shared ledger service singleton instance
+var (
+	Ledgers = &LedgerService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetLedgerByLedgerId returns a shared ledger model according to ledger id
+func (s *LedgerService) GetLedgerByLedgerId(c core.Context, ledgerId int64) (*models.Ledger, error) {
+	if ledgerId <= 0 {
+		return nil, errs.ErrLedgerIdInvalid
+	}
+
+	ledger := &models.Ledger{}
+	has, err := s.UserDB().NewSession(c).ID(ledgerId).Where("deleted=?", false).Get(ledger)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrLedgerNotFound
+	}
+
+	return ledger, nil
+}
+
+// GetAllLedgersByOwnerUid returns all shared ledger models owned by the specified user
+func (s *LedgerService) GetAllLedgersByOwnerUid(c core.Context, uid int64) ([]*models.Ledger, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var ledgers []*models.Ledger
+	err := s.UserDB().NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("created_unix_time asc").Find(&ledgers)
+
+	return ledgers, err
+}
+
+// GetLedgersByLedgerIds returns shared ledger models according to ledger ids
+func (s *LedgerService) GetLedgersByLedgerIds(c core.Context, ledgerIds []int64) (map[int64]*models.Ledger, error) {
+	if len(ledgerIds) <= 0 {
+		return make(map[int64]*models.Ledger), nil
+	}
+
+	var ledgers []*models.Ledger
+	err := s.UserDB().NewSession(c).Where("deleted=?", false).In("ledger_id", ledgerIds).Find(&ledgers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerMap := make(map[int64]*models.Ledger, len(ledgers))
+
+	for i := 0; i < len(ledgers); i++ {
+		ledgerMap[ledgers[i].LedgerId] = ledgers[i]
+	}
+
+	return ledgerMap, nil
+}
+
+// GetAllJoinedLedgerMembersByUid returns all shared ledger member models of the specified user
+func (s *LedgerService) GetAllJoinedLedgerMembersByUid(c core.Context, uid int64) ([]*models.LedgerMember, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var members []*models.LedgerMember
+	err := s.UserDB().NewSession(c).Where("uid=?", uid).Find(&members)
+
+	return members, err
+}
+
+// GetAllLedgerMembers returns all member models of the specified shared ledger
+func (s *LedgerService) GetAllLedgerMembers(c core.Context, ledgerId int64) ([]*models.LedgerMember, error) {
+	if ledgerId <= 0 {
+		return nil, errs.ErrLedgerIdInvalid
+	}
+
+	var members []*models.LedgerMember
+	err := s.UserDB().NewSession(c).Where("ledger_id=?", ledgerId).OrderBy("created_unix_time asc").Find(&members)
+
+	return members, err
+}
+
+// GetLedgerMemberRole returns the role of the specified user in the specified shared ledger and the ledger model
+func (s *LedgerService) GetLedgerMemberRole(c core.Context, ledgerId int64, uid int64) (models.LedgerMemberRole, *models.Ledger, error) {
+	if uid <= 0 {
+		return 0, nil, errs.ErrUserIdInvalid
+	}
+
+	ledger, err := s.GetLedgerByLedgerId(c, ledgerId)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if ledger.Uid == uid {
+		return models.LEDGER_MEMBER_ROLE_OWNER, ledger, nil
+	}
+
+	member := &models.LedgerMember{}
+	has, err := s.UserDB().NewSession(c).Where("ledger_id=? AND uid=?", ledgerId, uid).Get(member)
+
+	if err != nil {
+		return 0, nil, err
+	} else if !has {
+		return 0, nil, errs.ErrLedgerNotFound
+	}
+
+	return member.Role, ledger, nil
+}
+
+// CreateLedger saves a new shared ledger model to database
+func (s *LedgerService) CreateLedger(c core.Context, ledger *models.Ledger) error {
+	if ledger.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	ledger.LedgerId = s.GenerateUuid(uuid.UUID_TYPE_LEDGER)
+
+	if ledger.LedgerId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	// the data uid is generated as a user uid, so it never conflicts with the uid of any real user
+	ledger.DataUid = s.GenerateUuid(uuid.UUID_TYPE_USER)
+
+	if ledger.DataUid < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	ledger.Deleted = false
+	ledger.CreatedUnixTime = time.Now().Unix()
+	ledger.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(ledger)
+		return err
+	})
+}
+
+// ModifyLedger saves an existed shared ledger model to database
+func (s *LedgerService) ModifyLedger(c core.Context, ledger *models.Ledger) error {
+	if ledger.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	ledger.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(ledger.LedgerId).Cols("name", "updated_unix_time").Where("uid=? AND deleted=?", ledger.Uid, false).Update(ledger)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrLedgerNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteLedger deletes an existed shared ledger and all its members from database
+func (s *LedgerService) DeleteLedger(c core.Context, uid int64, ledgerId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Ledger{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(ledgerId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrLedgerNotFound
+		}
+
+		_, err = sess.Where("ledger_id=?", ledgerId).Delete(&models.LedgerMember{})
+
+		return err
+	})
+}
+
+// AddLedgerMember saves a new shared ledger member model to database
+func (s *LedgerService) AddLedgerMember(c core.Context, ledger *models.Ledger, member *models.LedgerMember) error {
+	if member.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if member.Uid == ledger.Uid {
+		return errs.ErrCannotAddLedgerOwnerAsMember
+	}
+
+	if !member.Role.IsValidMemberRole() {
+		return errs.ErrLedgerMemberRoleInvalid
+	}
+
+	member.LedgerId = ledger.LedgerId
+	member.CreatedUnixTime = time.Now().Unix()
+	member.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Where("ledger_id=? AND uid=?", member.LedgerId, member.Uid).Exist(&models.LedgerMember{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrLedgerMemberAlreadyExists
+		}
+
+		_, err = sess.Insert(member)
+		return err
+	})
+}
+
+// ModifyLedgerMemberRole updates the role of an existed shared ledger member
+func (s *LedgerService) ModifyLedgerMemberRole(c core.Context, member *models.LedgerMember) error {
+	if !member.Role.IsValidMemberRole() {
+		return errs.ErrLedgerMemberRoleInvalid
+	}
+
+	member.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.Cols("role", "updated_unix_time").Where("ledger_id=? AND uid=?", member.LedgerId, member.Uid).Update(member)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrLedgerMemberNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteLedgerMember removes an existed member from shared ledger
+func (s *LedgerService) DeleteLedgerMember(c core.Context, ledgerId int64, uid int64) error {
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.Where("ledger_id=? AND uid=?", ledgerId, uid).Delete(&models.LedgerMember{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrLedgerMemberNotFound
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestCreateLedger_EachLedgerHasItsOwnDataUid(t *testing.T) {
+	initializeServicesTestDataStore(t)
+	assert.Nil(t, datastore.Container.UserStore.SyncStructs(new(models.Ledger), new(models.LedgerMember)))
+
+	ownerUid := int64(1)
+	c := core.NewNullContext()
+
+	firstLedger := &models.Ledger{Uid: ownerUid, Name: "Family"}
+	assert.Nil(t, Ledgers.CreateLedger(c, firstLedger))
+
+	secondLedger := &models.Ledger{Uid: ownerUid, Name: "Travel"}
+	assert.Nil(t, Ledgers.CreateLedger(c, secondLedger))
+
+	assert.Greater(t, firstLedger.DataUid, int64(0))
+	assert.Greater(t, secondLedger.DataUid, int64(0))
+	assert.NotEqual(t, ownerUid, firstLedger.DataUid)
+	assert.NotEqual(t, ownerUid, secondLedger.DataUid)
+	assert.NotEqual(t, firstLedger.DataUid, secondLedger.DataUid)
+
+	ledgers, err := Ledgers.GetAllLedgersByOwnerUid(c, ownerUid)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ledgers))
+
+	role, ledger, err := Ledgers.GetLedgerMemberRole(c, secondLedger.LedgerId, ownerUid)
+	assert.Nil(t, err)
+	assert.Equal(t, models.LEDGER_MEMBER_ROLE_OWNER, role)
+	assert.Equal(t, secondLedger.DataUid, ledger.DataUid)
+}
@@ -70,7 +70,7 @@ func (s *TokenService) GetAllUnexpiredNormalAndMCPTokensByUid(c core.Context, ui
 	now := time.Now().Unix()
 
 	var tokenRecords []*models.TokenRecord
-	err := s.TokenDB(uid).NewSession(c).Cols("uid", "user_token_id", "token_type", "user_agent", "created_unix_time", "expired_unix_time", "last_seen_unix_time").Where("uid=? AND (token_type=? OR token_type=? OR token_type=?) AND expired_unix_time>?", uid, core.USER_TOKEN_TYPE_NORMAL, core.USER_TOKEN_TYPE_MCP, core.USER_TOKEN_TYPE_API, now).Find(&tokenRecords)
+	err := s.TokenDB(uid).NewSession(c).Cols("uid", "user_token_id", "token_type", "scopes", "user_agent", "created_unix_time", "expired_unix_time", "last_seen_unix_time").Where("uid=? AND (token_type=? OR token_type=? OR token_type=?) AND expired_unix_time>?", uid, core.USER_TOKEN_TYPE_NORMAL, core.USER_TOKEN_TYPE_MCP, core.USER_TOKEN_TYPE_API, now).Find(&tokenRecords)
 
 	return tokenRecords, err
 }
@@ -82,42 +82,42 @@ func (s *TokenService) ParseToken(c core.Context, token string) (*jwt.Token, *co
 
 // CreateToken generates a new normal token and saves to database
 func (s *TokenService) CreateToken(c *core.WebContext, user *models.User) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_NORMAL, s.getUserAgent(c), "", s.CurrentConfig().TokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_NORMAL, s.getUserAgent(c), "", nil, s.CurrentConfig().TokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreateRequire2FAToken generates a new token requiring user to verify 2fa passcode and saves to database
 func (s *TokenService) CreateRequire2FAToken(c *core.WebContext, user *models.User) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_REQUIRE_2FA, s.getUserAgent(c), "", s.CurrentConfig().TemporaryTokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_REQUIRE_2FA, s.getUserAgent(c), "", nil, s.CurrentConfig().TemporaryTokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreateEmailVerifyToken generates a new email verify token and saves to database
 func (s *TokenService) CreateEmailVerifyToken(c *core.WebContext, user *models.User) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_EMAIL_VERIFY, s.getUserAgent(c), "", s.CurrentConfig().EmailVerifyTokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_EMAIL_VERIFY, s.getUserAgent(c), "", nil, s.CurrentConfig().EmailVerifyTokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreateEmailVerifyTokenWithoutUserAgent generates a new email verify token and saves to database
 func (s *TokenService) CreateEmailVerifyTokenWithoutUserAgent(c core.Context, user *models.User) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_EMAIL_VERIFY, "", "", s.CurrentConfig().EmailVerifyTokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_EMAIL_VERIFY, "", "", nil, s.CurrentConfig().EmailVerifyTokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreatePasswordResetToken generates a new password reset token and saves to database
 func (s *TokenService) CreatePasswordResetToken(c *core.WebContext, user *models.User) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_PASSWORD_RESET, s.getUserAgent(c), "", s.CurrentConfig().PasswordResetTokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_PASSWORD_RESET, s.getUserAgent(c), "", nil, s.CurrentConfig().PasswordResetTokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreatePasswordResetTokenWithoutUserAgent generates a new password reset token and saves to database
 func (s *TokenService) CreatePasswordResetTokenWithoutUserAgent(c core.Context, user *models.User) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_PASSWORD_RESET, "", "", s.CurrentConfig().PasswordResetTokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_PASSWORD_RESET, "", "", nil, s.CurrentConfig().PasswordResetTokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreateAPIToken generates a new API token and saves to database
-func (s *TokenService) CreateAPIToken(c *core.WebContext, user *models.User, expiresInSeconds int64) (string, *core.UserTokenClaims, error) {
+func (s *TokenService) CreateAPIToken(c *core.WebContext, user *models.User, expiresInSeconds int64, scopes []core.TokenScope) (string, *core.UserTokenClaims, error) {
 	var tokenExpiredTimeDuration time.Duration
 
 	if expiresInSeconds > 0 {
@@ -126,12 +126,12 @@ func (s *TokenService) CreateAPIToken(c *core.WebContext, user *models.User, exp
 		tokenExpiredTimeDuration = time.Unix(tokenMaxExpiredAtUnixTime, 0).Sub(time.Now())
 	}
 
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_API, s.getUserAgent(c), "", tokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_API, s.getUserAgent(c), "", scopes, tokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreateAPITokenViaCli generates a new API token and saves to database
-func (s *TokenService) CreateAPITokenViaCli(c *core.CliContext, user *models.User, expiresInSeconds int64) (string, *models.TokenRecord, error) {
+func (s *TokenService) CreateAPITokenViaCli(c *core.CliContext, user *models.User, expiresInSeconds int64, scopes []core.TokenScope) (string, *models.TokenRecord, error) {
 	var tokenExpiredTimeDuration time.Duration
 
 	if expiresInSeconds > 0 {
@@ -140,12 +140,12 @@ func (s *TokenService) CreateAPITokenViaCli(c *core.CliContext, user *models.Use
 		tokenExpiredTimeDuration = time.Unix(tokenMaxExpiredAtUnixTime, 0).Sub(time.Now())
 	}
 
-	token, _, tokenRecord, err := s.createToken(c, user, core.USER_TOKEN_TYPE_API, TokenUserAgentCreatedViaCli, "", tokenExpiredTimeDuration)
+	token, _, tokenRecord, err := s.createToken(c, user, core.USER_TOKEN_TYPE_API, TokenUserAgentCreatedViaCli, "", scopes, tokenExpiredTimeDuration)
 	return token, tokenRecord, err
 }
 
-// CreateMCPToken generates a new MCP token and saves to database
-func (s *TokenService) CreateMCPToken(c *core.WebContext, user *models.User, expiresInSeconds int64) (string, *core.UserTokenClaims, error) {
+// CreateMCPToken generates a new MCP token with specified scopes and saves to database
+func (s *TokenService) CreateMCPToken(c *core.WebContext, user *models.User, expiresInSeconds int64, scopes []core.TokenScope) (string, *core.UserTokenClaims, error) {
 	var tokenExpiredTimeDuration time.Duration
 
 	if expiresInSeconds > 0 {
@@ -154,12 +154,12 @@ func (s *TokenService) CreateMCPToken(c *core.WebContext, user *models.User, exp
 		tokenExpiredTimeDuration = time.Unix(tokenMaxExpiredAtUnixTime, 0).Sub(time.Now())
 	}
 
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_MCP, s.getUserAgent(c), "", tokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_MCP, s.getUserAgent(c), "", scopes, tokenExpiredTimeDuration)
 	return token, claims, err
 }
 
-// CreateMCPTokenViaCli generates a new MCP token and saves to database
-func (s *TokenService) CreateMCPTokenViaCli(c *core.CliContext, user *models.User, expiresInSeconds int64) (string, *models.TokenRecord, error) {
+// CreateMCPTokenViaCli generates a new MCP token with specified scopes and saves to database
+func (s *TokenService) CreateMCPTokenViaCli(c *core.CliContext, user *models.User, expiresInSeconds int64, scopes []core.TokenScope) (string, *models.TokenRecord, error) {
 	var tokenExpiredTimeDuration time.Duration
 
 	if expiresInSeconds > 0 {
@@ -168,19 +168,19 @@ func (s *TokenService) CreateMCPTokenViaCli(c *core.CliContext, user *models.Use
 		tokenExpiredTimeDuration = time.Unix(tokenMaxExpiredAtUnixTime, 0).Sub(time.Now())
 	}
 
-	token, _, tokenRecord, err := s.createToken(c, user, core.USER_TOKEN_TYPE_MCP, TokenUserAgentCreatedViaCli, "", tokenExpiredTimeDuration)
+	token, _, tokenRecord, err := s.createToken(c, user, core.USER_TOKEN_TYPE_MCP, TokenUserAgentCreatedViaCli, "", scopes, tokenExpiredTimeDuration)
 	return token, tokenRecord, err
 }
 
 // CreateOAuth2CallbackRequireVerifyToken generates a new OAuth 2.0 callback token requiring user to verify and saves to database
 func (s *TokenService) CreateOAuth2CallbackRequireVerifyToken(c *core.WebContext, user *models.User, context string) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_OAUTH2_CALLBACK_REQUIRE_VERIFY, s.getUserAgent(c), context, s.CurrentConfig().TemporaryTokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_OAUTH2_CALLBACK_REQUIRE_VERIFY, s.getUserAgent(c), context, nil, s.CurrentConfig().TemporaryTokenExpiredTimeDuration)
 	return token, claims, err
 }
 
 // CreateOAuth2CallbackToken generates a new OAuth 2.0 callback token and saves to database
 func (s *TokenService) CreateOAuth2CallbackToken(c *core.WebContext, user *models.User, context string) (string, *core.UserTokenClaims, error) {
-	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_OAUTH2_CALLBACK, s.getUserAgent(c), context, s.CurrentConfig().TemporaryTokenExpiredTimeDuration)
+	token, claims, _, err := s.createToken(c, user, core.USER_TOKEN_TYPE_OAUTH2_CALLBACK, s.getUserAgent(c), context, nil, s.CurrentConfig().TemporaryTokenExpiredTimeDuration)
 	return token, claims, err
 }
 
@@ -427,7 +427,7 @@ func (s *TokenService) parseToken(c core.Context, tokenString string) (*jwt.Toke
 	return token, claims, tokenContext, err
 }
 
-func (s *TokenService) createToken(c core.Context, user *models.User, tokenType core.TokenType, userAgent string, context string, expiryDate time.Duration) (string, *core.UserTokenClaims, *models.TokenRecord, error) {
+func (s *TokenService) createToken(c core.Context, user *models.User, tokenType core.TokenType, userAgent string, context string, scopes []core.TokenScope, expiryDate time.Duration) (string, *core.UserTokenClaims, *models.TokenRecord, error) {
 	var err error
 	now := time.Now()
 
@@ -437,6 +437,7 @@ func (s *TokenService) createToken(c core.Context, user *models.User, tokenType
 		TokenType:        tokenType,
 		UserAgent:        userAgent,
 		Context:          context,
+		Scopes:           core.FormatTokenScopes(scopes),
 		CreatedUnixTime:  now.Unix(),
 		ExpiredUnixTime:  now.Add(expiryDate).Unix(),
 		LastSeenUnixTime: now.Unix(),
@@ -451,6 +452,7 @@ func (s *TokenService) createToken(c core.Context, user *models.User, tokenType
 		Uid:         tokenRecord.Uid,
 		Username:    user.Username,
 		Type:        tokenRecord.TokenType,
+		Scopes:      scopes,
 		IssuedAt:    tokenRecord.CreatedUnixTime,
 		ExpiresAt:   tokenRecord.ExpiredUnixTime,
 	}
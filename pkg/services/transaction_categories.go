@@ -389,19 +389,11 @@ func (s *TransactionCategoryService) DeleteCategory(c core.Context, uid int64, c
 			categoryAndSubCategoryIds[i] = categoryAndSubCategories[i].CategoryId
 		}
 
-		exists, err := sess.Cols("uid", "deleted", "category_id").Where("uid=? AND deleted=?", uid, false).In("category_id", categoryAndSubCategoryIds).Limit(1).Exist(&models.Transaction{})
+		inUse, err := s.isCategoriesInUse(sess, uid, categoryAndSubCategoryIds, now)
 
 		if err != nil {
 			return err
-		} else if exists {
-			return errs.ErrTransactionCategoryInUseCannotBeDeleted
-		}
-
-		exists, err = sess.Cols("uid", "deleted", "category_id", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where("uid=? AND deleted=? AND (template_type=? OR (template_type=? AND scheduled_frequency_type<>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?)))", uid, false, models.TRANSACTION_TEMPLATE_TYPE_NORMAL, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, now).In("category_id", categoryAndSubCategoryIds).Limit(1).Exist(&models.TransactionTemplate{})
-
-		if err != nil {
-			return err
-		} else if exists {
+		} else if inUse {
 			return errs.ErrTransactionCategoryInUseCannotBeDeleted
 		}
 
@@ -449,6 +441,35 @@ func (s *TransactionCategoryService) DeleteAllCategories(c core.Context, uid int
 	})
 }
 
+// isCategoriesInUse returns whether any of the transaction categories is used by transactions, transaction splits, transaction templates, transaction rules or budgets
+func (s *TransactionCategoryService) isCategoriesInUse(sess *xorm.Session, uid int64, categoryIds []int64, now int64) (bool, error) {
+	exists, err := sess.Cols("uid", "deleted", "category_id").Where("uid=? AND deleted=?", uid, false).In("category_id", categoryIds).Limit(1).Exist(&models.Transaction{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	exists, err = sess.Cols("uid", "deleted", "category_id").Where("uid=? AND deleted=?", uid, false).In("category_id", categoryIds).Limit(1).Exist(&models.TransactionSplit{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	exists, err = sess.Cols("uid", "deleted", "category_id", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where("uid=? AND deleted=? AND (template_type=? OR (template_type=? AND scheduled_frequency_type<>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?)))", uid, false, models.TRANSACTION_TEMPLATE_TYPE_NORMAL, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, now).In("category_id", categoryIds).Limit(1).Exist(&models.TransactionTemplate{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	exists, err = sess.Cols("uid", "deleted", "action_category_id").Where("uid=? AND deleted=?", uid, false).In("action_category_id", categoryIds).Limit(1).Exist(&models.TransactionRule{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	return sess.Cols("uid", "deleted", "category_id").Where("uid=? AND deleted=?", uid, false).In("category_id", categoryIds).Limit(1).Exist(&models.Budget{})
+}
+
 // GetCategoryMapByList returns a transaction category map by a list
 func (s *TransactionCategoryService) GetCategoryMapByList(categories []*models.TransactionCategory) map[int64]*models.TransactionCategory {
 	categoryMap := make(map[int64]*models.TransactionCategory)
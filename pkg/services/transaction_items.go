@@ -132,6 +132,99 @@ func (s *TransactionItemService) GetAllItemIdsOfTransactions(c core.Context, uid
 	return allTransactionItemIds, err
 }
 
+// GetAllItemIndexesOfTransactions returns all transaction item indexes of transactions
+func (s *TransactionItemService) GetAllItemIndexesOfTransactions(c core.Context, uid int64, transactionIds []int64) ([]*models.TransactionItemIndex, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var itemIndexes []*models.TransactionItemIndex
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).OrderBy("transaction_id asc, item_index_id asc").Find(&itemIndexes)
+
+	return itemIndexes, err
+}
+
+// GetGroupedTransactionItemDetails returns a map of transaction item details grouped by transaction id and item id, transaction item indexes without any detail are ignored
+func (s *TransactionItemService) GetGroupedTransactionItemDetails(itemIndexes []*models.TransactionItemIndex) map[int64]map[int64]*models.TransactionItemDetail {
+	allTransactionItemDetails := make(map[int64]map[int64]*models.TransactionItemDetail)
+
+	for i := 0; i < len(itemIndexes); i++ {
+		itemIndex := itemIndexes[i]
+		itemDetail := itemIndex.GetTransactionItemDetail()
+
+		if itemDetail.IsEmpty() {
+			continue
+		}
+
+		transactionItemDetails, exists := allTransactionItemDetails[itemIndex.TransactionId]
+
+		if !exists {
+			transactionItemDetails = make(map[int64]*models.TransactionItemDetail)
+			allTransactionItemDetails[itemIndex.TransactionId] = transactionItemDetails
+		}
+
+		transactionItemDetails[itemIndex.ItemId] = itemDetail
+	}
+
+	return allTransactionItemDetails
+}
+
+// GetItemStatistics returns the total quantity and subtotal of each transaction item in the specified time range
+func (s *TransactionItemService) GetItemStatistics(c core.Context, uid int64, itemIds []int64, maxTransactionTime int64, minTransactionTime int64) ([]*models.TransactionItemStatisticResponseItem, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	sess := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false)
+
+	if len(itemIds) > 0 {
+		sess = sess.In("item_id", itemIds)
+	}
+
+	if maxTransactionTime > 0 {
+		sess = sess.And("transaction_time<=?", maxTransactionTime)
+	}
+
+	if minTransactionTime > 0 {
+		sess = sess.And("transaction_time>=?", minTransactionTime)
+	}
+
+	var itemIndexes []*models.TransactionItemIndex
+	err := sess.Find(&itemIndexes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getItemStatistics(itemIndexes), nil
+}
+
+// GetItemPriceHistory returns all transaction item indexes which have unit price or subtotal of the specified transaction item in the specified time range
+func (s *TransactionItemService) GetItemPriceHistory(c core.Context, uid int64, itemId int64, maxTransactionTime int64, minTransactionTime int64) ([]*models.TransactionItemIndex, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if itemId <= 0 {
+		return nil, errs.ErrTransactionItemIdInvalid
+	}
+
+	sess := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND item_id=? AND (unit_price>0 OR amount>0)", uid, false, itemId)
+
+	if maxTransactionTime > 0 {
+		sess = sess.And("transaction_time<=?", maxTransactionTime)
+	}
+
+	if minTransactionTime > 0 {
+		sess = sess.And("transaction_time>=?", minTransactionTime)
+	}
+
+	var itemIndexes []*models.TransactionItemIndex
+	err := sess.OrderBy("transaction_time asc").Find(&itemIndexes)
+
+	return itemIndexes, err
+}
+
 // GetGroupedTransactionItemIds returns a map of transaction item ids grouped by transaction id
 func (s *TransactionItemService) GetGroupedTransactionItemIds(itemIndexes []*models.TransactionItemIndex) map[int64][]int64 {
 	allTransactionItemIds := make(map[int64][]int64)
@@ -393,6 +486,61 @@ func (s *TransactionItemService) GetItemMapByList(items []*models.TransactionIte
 	return itemMap
 }
 
+func (s *TransactionItemService) getItemStatistics(itemIndexes []*models.TransactionItemIndex) []*models.TransactionItemStatisticResponseItem {
+	allStatistics := make([]*models.TransactionItemStatisticResponseItem, 0)
+	statisticMap := make(map[int64]*models.TransactionItemStatisticResponseItem)
+	pricedQuantities := make(map[int64]int64)
+	pricedAmounts := make(map[int64]int64)
+
+	for i := 0; i < len(itemIndexes); i++ {
+		itemIndex := itemIndexes[i]
+		statistic, exists := statisticMap[itemIndex.ItemId]
+
+		if !exists {
+			statistic = &models.TransactionItemStatisticResponseItem{
+				ItemId: itemIndex.ItemId,
+			}
+
+			statisticMap[itemIndex.ItemId] = statistic
+			allStatistics = append(allStatistics, statistic)
+		}
+
+		statistic.TransactionCount++
+		statistic.TotalQuantity += itemIndex.Quantity
+		statistic.TotalAmount += itemIndex.Amount
+
+		unitPrice := itemIndex.GetUnitPrice()
+
+		if unitPrice <= 0 {
+			continue
+		}
+
+		if statistic.MinUnitPrice == 0 || unitPrice < statistic.MinUnitPrice {
+			statistic.MinUnitPrice = unitPrice
+		}
+
+		if unitPrice > statistic.MaxUnitPrice {
+			statistic.MaxUnitPrice = unitPrice
+		}
+
+		if itemIndex.Quantity > 0 && itemIndex.Amount > 0 {
+			pricedQuantities[itemIndex.ItemId] += itemIndex.Quantity
+			pricedAmounts[itemIndex.ItemId] += itemIndex.Amount
+		}
+	}
+
+	for i := 0; i < len(allStatistics); i++ {
+		statistic := allStatistics[i]
+		pricedQuantity := pricedQuantities[statistic.ItemId]
+
+		if pricedQuantity > 0 {
+			statistic.AverageUnitPrice = (pricedAmounts[statistic.ItemId]*models.TransactionItemQuantityScale + pricedQuantity/2) / pricedQuantity
+		}
+	}
+
+	return allStatistics
+}
+
 // DeleteAllItemIndexes soft-deletes all transaction item indexes for user
 func (s *TransactionItemService) DeleteAllItemIndexes(c core.Context, uid int64) error {
 	if uid <= 0 {
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestGetItemStatistics_EmptyList(t *testing.T) {
+	actualStatistics := TransactionItems.getItemStatistics(nil)
+
+	assert.NotNil(t, actualStatistics)
+	assert.Equal(t, 0, len(actualStatistics))
+}
+
+func TestGetItemStatistics_MultipleItems(t *testing.T) {
+	itemIndexes := []*models.TransactionItemIndex{
+		{ItemId: 1001, TransactionId: 1, Quantity: 200, UnitPrice: 450, Amount: 900},
+		{ItemId: 1002, TransactionId: 1, Amount: 1200},
+		{ItemId: 1001, TransactionId: 2, Quantity: 100, Amount: 500},
+		{ItemId: 1001, TransactionId: 3},
+		{ItemId: 1003, TransactionId: 3, UnitPrice: 800},
+	}
+
+	actualStatistics := TransactionItems.getItemStatistics(itemIndexes)
+	assert.Equal(t, 3, len(actualStatistics))
+
+	assert.Equal(t, &models.TransactionItemStatisticResponseItem{
+		ItemId:           1001,
+		TransactionCount: 3,
+		TotalQuantity:    300,
+		TotalAmount:      1400,
+		MinUnitPrice:     450,
+		MaxUnitPrice:     500,
+		AverageUnitPrice: 467,
+	}, actualStatistics[0])
+
+	assert.Equal(t, &models.TransactionItemStatisticResponseItem{
+		ItemId:           1002,
+		TransactionCount: 1,
+		TotalAmount:      1200,
+	}, actualStatistics[1])
+
+	assert.Equal(t, &models.TransactionItemStatisticResponseItem{
+		ItemId:           1003,
+		TransactionCount: 1,
+		MinUnitPrice:     800,
+		MaxUnitPrice:     800,
+	}, actualStatistics[2])
+}
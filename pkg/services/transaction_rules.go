@@ -0,0 +1,540 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const pageCountForApplyTransactionRules = 100
+
+// TransactionRuleService represents transaction rule service
+type TransactionRuleService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a transaction rule service singleton instance
+var (
+	TransactionRules = &TransactionRuleService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllRulesByUid returns all transaction rule models of user ordered by display order
+func (s *TransactionRuleService) GetAllRulesByUid(c core.Context, uid int64) ([]*models.TransactionRule, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var rules []*models.TransactionRule
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("display_order asc").Find(&rules)
+
+	return rules, err
+}
+
+// GetAllEnabledRulesByUid returns all enabled transaction rule models of user ordered by display order
+func (s *TransactionRuleService) GetAllEnabledRulesByUid(c core.Context, uid int64) ([]*models.TransactionRule, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var rules []*models.TransactionRule
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND disabled=?", uid, false, false).OrderBy("display_order asc").Find(&rules)
+
+	return rules, err
+}
+
+// GetRuleByRuleId returns a transaction rule model according to transaction rule id
+func (s *TransactionRuleService) GetRuleByRuleId(c core.Context, uid int64, ruleId int64) (*models.TransactionRule, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if ruleId <= 0 {
+		return nil, errs.ErrTransactionRuleIdInvalid
+	}
+
+	rule := &models.TransactionRule{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(ruleId).Where("uid=? AND deleted=?", uid, false).Get(rule)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrTransactionRuleNotFound
+	}
+
+	return rule, nil
+}
+
+// GetMaxDisplayOrder returns the max display order
+func (s *TransactionRuleService) GetMaxDisplayOrder(c core.Context, uid int64) (int32, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	rule := &models.TransactionRule{}
+	has, err := s.UserDataDB(uid).NewSession(c).Cols("uid", "deleted", "display_order").Where("uid=? AND deleted=?", uid, false).OrderBy("display_order desc").Limit(1).Get(rule)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if has {
+		return rule.DisplayOrder, nil
+	} else {
+		return 0, nil
+	}
+}
+
+// CreateRule saves a new transaction rule model to database
+func (s *TransactionRuleService) CreateRule(c core.Context, rule *models.TransactionRule) error {
+	if rule.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	rule.RuleId = s.GenerateUuid(uuid.UUID_TYPE_TEMPLATE)
+
+	if rule.RuleId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	rule.Deleted = false
+	rule.CreatedUnixTime = time.Now().Unix()
+	rule.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(rule.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(rule)
+		return err
+	})
+}
+
+// ModifyRule saves an existed transaction rule model to database
+func (s *TransactionRuleService) ModifyRule(c core.Context, rule *models.TransactionRule) error {
+	if rule.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	rule.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(rule.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(rule.RuleId).Cols("name", "condition_transaction_type", "condition_keyword_match_type", "condition_keyword", "condition_min_amount", "condition_max_amount", "condition_account_id", "action_category_id", "action_tag_ids", "action_account_id", "disabled", "updated_unix_time").Where("uid=? AND deleted=?", rule.Uid, false).Update(rule)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrTransactionRuleNotFound
+		}
+
+		return err
+	})
+}
+
+// ModifyRuleDisplayOrders updates display order of given transaction rules
+func (s *TransactionRuleService) ModifyRuleDisplayOrders(c core.Context, uid int64, rules []*models.TransactionRule) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	for i := 0; i < len(rules); i++ {
+		rules[i].UpdatedUnixTime = time.Now().Unix()
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(rules); i++ {
+			rule := rules[i]
+			updatedRows, err := sess.ID(rule.RuleId).Cols("display_order", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(rule)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrTransactionRuleNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteRule deletes an existed transaction rule from database
+func (s *TransactionRuleService) DeleteRule(c core.Context, uid int64, ruleId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.TransactionRule{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(ruleId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTransactionRuleNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllRules deletes all existed transaction rules from database
+func (s *TransactionRuleService) DeleteAllRules(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.TransactionRule{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		return err
+	})
+}
+
+// ApplyRulesToImportTransactions applies transaction rules to the parsed import transactions and returns the count of changed transactions
+func (s *TransactionRuleService) ApplyRulesToImportTransactions(rules []*models.TransactionRule, transactions []*models.ImportTransaction, categoryMap map[int64]*models.TransactionCategory, accountMap map[int64]*models.Account, tagMap map[int64]*models.TransactionTag) int {
+	if len(rules) < 1 {
+		return 0
+	}
+
+	changedCount := 0
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		transactionType, err := transaction.Type.ToTransactionType()
+
+		if err != nil || transactionType == models.TRANSACTION_TYPE_MODIFY_BALANCE {
+			continue
+		}
+
+		result := models.GetTransactionRuleActionResult(rules, &models.TransactionRuleMatchTarget{
+			Type:        transactionType,
+			Amount:      transaction.Amount,
+			AccountId:   transaction.AccountId,
+			Description: transaction.Comment,
+		}, categoryMap, accountMap)
+
+		if result == nil {
+			continue
+		}
+
+		if result.CategoryId > 0 {
+			transaction.CategoryId = result.CategoryId
+		}
+
+		if result.AccountId > 0 {
+			transaction.AccountId = result.AccountId
+		}
+
+		transaction.TagIds = s.getMergedTagIds(transaction.TagIds, result.TagIds, tagMap)
+		changedCount++
+	}
+
+	return changedCount
+}
+
+// ApplyRulesToRecognizedReceiptImageResponses applies transaction rules to the recognized transactions and returns the count of changed transactions
+func (s *TransactionRuleService) ApplyRulesToRecognizedReceiptImageResponses(rules []*models.TransactionRule, transactions []models.RecognizedReceiptImageResponse, categoryMap map[int64]*models.TransactionCategory, accountMap map[int64]*models.Account, tagMap map[int64]*models.TransactionTag) int {
+	if len(rules) < 1 {
+		return 0
+	}
+
+	changedCount := 0
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := &transactions[i]
+
+		result := models.GetTransactionRuleActionResult(rules, &models.TransactionRuleMatchTarget{
+			Type:        transaction.Type,
+			Amount:      transaction.SourceAmount,
+			AccountId:   transaction.SourceAccountId,
+			Description: transaction.Comment,
+		}, categoryMap, accountMap)
+
+		if result == nil {
+			continue
+		}
+
+		if result.CategoryId > 0 {
+			transaction.CategoryId = result.CategoryId
+		}
+
+		if result.AccountId > 0 {
+			transaction.SourceAccountId = result.AccountId
+		}
+
+		transaction.TagIds = s.getMergedTagIds(transaction.TagIds, result.TagIds, tagMap)
+		changedCount++
+	}
+
+	return changedCount
+}
+
+// ApplyRulesToTransactions applies transaction rules to existed income and expense transactions within time range, the changes will not be saved if dry run
+func (s *TransactionRuleService) ApplyRulesToTransactions(c core.Context, uid int64, rules []*models.TransactionRule, maxTransactionTime int64, minTransactionTime int64, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, dryRun bool) ([]*models.TransactionRuleApplyResult, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if len(rules) < 1 {
+		return make([]*models.TransactionRuleApplyResult, 0), nil
+	}
+
+	if maxTransactionTime <= 0 {
+		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(time.Now().Unix())
+	}
+
+	condition := "uid=? AND deleted=? AND (type=? OR type=?) AND transaction_time<=?"
+
+	if minTransactionTime > 0 {
+		condition = condition + " AND transaction_time>=?"
+	}
+
+	results := make([]*models.TransactionRuleApplyResult, 0)
+
+	for maxTransactionTime > 0 {
+		conditionParams := []any{uid, false, models.TRANSACTION_DB_TYPE_INCOME, models.TRANSACTION_DB_TYPE_EXPENSE, maxTransactionTime}
+
+		if minTransactionTime > 0 {
+			conditionParams = append(conditionParams, minTransactionTime)
+		}
+
+		var transactions []*models.Transaction
+		err := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...).Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(transactions) > 0 {
+			existedTagIds, err := s.getExistedTagIdsByTransactions(c, uid, transactions)
+
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, s.getTransactionRuleApplyResults(rules, transactions, existedTagIds, categoryMap, tagMap)...)
+		}
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			maxTransactionTime = 0
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	if dryRun || len(results) < 1 {
+		return results, nil
+	}
+
+	now := time.Now().Unix()
+	addedTagIndexes := make([]*models.TransactionTagIndex, 0)
+	categoryTransactionIds := make(map[int64][]int64)
+
+	for i := 0; i < len(results); i++ {
+		result := results[i]
+
+		if result.CategoryId != result.Transaction.CategoryId {
+			categoryTransactionIds[result.CategoryId] = append(categoryTransactionIds[result.CategoryId], result.Transaction.TransactionId)
+		}
+
+		if len(result.AddedTagIds) < 1 {
+			continue
+		}
+
+		tagIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_TAG_INDEX, uint16(len(result.AddedTagIds)))
+
+		if len(tagIndexUuids) < len(result.AddedTagIds) {
+			return nil, errs.ErrSystemIsBusy
+		}
+
+		for j := 0; j < len(result.AddedTagIds); j++ {
+			addedTagIndexes = append(addedTagIndexes, &models.TransactionTagIndex{
+				TagIndexId:      tagIndexUuids[j],
+				Uid:             uid,
+				Deleted:         false,
+				TransactionTime: result.Transaction.TransactionTime,
+				TagId:           result.AddedTagIds[j],
+				TransactionId:   result.Transaction.TransactionId,
+				CreatedUnixTime: now,
+				UpdatedUnixTime: now,
+			})
+		}
+	}
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for categoryId, transactionIds := range categoryTransactionIds {
+			for i := 0; i < len(transactionIds); i += pageCountForApplyTransactionRules {
+				batchTransactionIds := transactionIds[i:min(i+pageCountForApplyTransactionRules, len(transactionIds))]
+				updateModel := &models.Transaction{
+					CategoryId:      categoryId,
+					UpdatedUnixTime: now,
+				}
+
+				updatedRows, err := sess.Cols("category_id", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", batchTransactionIds).Update(updateModel)
+
+				if err != nil {
+					return err
+				} else if updatedRows < int64(len(batchTransactionIds)) {
+					return errs.ErrTransactionNotFound
+				}
+			}
+		}
+
+		for i := 0; i < len(addedTagIndexes); i += pageCountForApplyTransactionRules {
+			_, err := sess.Insert(addedTagIndexes[i:min(i+pageCountForApplyTransactionRules, len(addedTagIndexes))])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *TransactionRuleService) getExistedTagIdsByTransactions(c core.Context, uid int64, transactions []*models.Transaction) (map[int64]map[int64]bool, error) {
+	transactionIds := make([]int64, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transactionIds[i] = transactions[i].TransactionId
+	}
+
+	var tagIndexes []*models.TransactionTagIndex
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).Find(&tagIndexes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	existedTagIds := make(map[int64]map[int64]bool, len(transactions))
+
+	for i := 0; i < len(tagIndexes); i++ {
+		tagIndex := tagIndexes[i]
+
+		if _, exists := existedTagIds[tagIndex.TransactionId]; !exists {
+			existedTagIds[tagIndex.TransactionId] = make(map[int64]bool)
+		}
+
+		existedTagIds[tagIndex.TransactionId][tagIndex.TagId] = true
+	}
+
+	return existedTagIds, nil
+}
+
+func (s *TransactionRuleService) getTransactionRuleApplyResults(rules []*models.TransactionRule, transactions []*models.Transaction, existedTagIds map[int64]map[int64]bool, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag) []*models.TransactionRuleApplyResult {
+	results := make([]*models.TransactionRuleApplyResult, 0)
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		transactionType, err := transaction.Type.ToTransactionType()
+
+		if err != nil {
+			continue
+		}
+
+		actionResult := models.GetTransactionRuleActionResult(rules, &models.TransactionRuleMatchTarget{
+			Type:        transactionType,
+			Amount:      transaction.Amount,
+			AccountId:   transaction.AccountId,
+			Description: transaction.Comment,
+		}, categoryMap, nil)
+
+		if actionResult == nil {
+			continue
+		}
+
+		result := &models.TransactionRuleApplyResult{
+			Transaction: transaction,
+			RuleIds:     actionResult.RuleIds,
+			CategoryId:  transaction.CategoryId,
+			AddedTagIds: make([]int64, 0),
+		}
+
+		if actionResult.CategoryId > 0 {
+			result.CategoryId = actionResult.CategoryId
+		}
+
+		existedTagCount := len(existedTagIds[transaction.TransactionId])
+
+		for j := 0; j < len(actionResult.TagIds); j++ {
+			tagId := actionResult.TagIds[j]
+
+			if existedTagCount+len(result.AddedTagIds) >= models.MaximumTagsCountOfTransaction {
+				break
+			}
+
+			if existedTagIds[transaction.TransactionId][tagId] {
+				continue
+			}
+
+			if tag, exists := tagMap[tagId]; !exists || tag.Hidden {
+				continue
+			}
+
+			result.AddedTagIds = append(result.AddedTagIds, tagId)
+		}
+
+		if result.CategoryId == transaction.CategoryId && len(result.AddedTagIds) < 1 {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (s *TransactionRuleService) getMergedTagIds(tagIds []string, newTagIds []int64, tagMap map[int64]*models.TransactionTag) []string {
+	tagIdExists := make(map[string]bool, len(tagIds))
+
+	for i := 0; i < len(tagIds); i++ {
+		tagIdExists[tagIds[i]] = true
+	}
+
+	for i := 0; i < len(newTagIds); i++ {
+		if tag, exists := tagMap[newTagIds[i]]; !exists || tag.Hidden {
+			continue
+		}
+
+		tagId := utils.Int64ToString(newTagIds[i])
+
+		if tagIdExists[tagId] {
+			continue
+		}
+
+		tagIdExists[tagId] = true
+		tagIds = append(tagIds, tagId)
+	}
+
+	return tagIds
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestApplyRulesToImportTransactions(t *testing.T) {
+	rules := []*models.TransactionRule{
+		{RuleId: 1, ConditionKeywordMatchType: models.TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "starbucks", ActionCategoryId: 101, ActionTagIds: "201,202"},
+	}
+	categoryMap := map[int64]*models.TransactionCategory{
+		101: {CategoryId: 101, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 100},
+	}
+	tagMap := map[int64]*models.TransactionTag{
+		201: {TagId: 201},
+		202: {TagId: 202, Hidden: true},
+	}
+	transactions := []*models.ImportTransaction{
+		{Transaction: &models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 109, Amount: 3000, Comment: "STARBUCKS COFFEE"}, TagIds: []string{"203"}},
+		{Transaction: &models.Transaction{Type: models.TRANSACTION_DB_TYPE_INCOME, CategoryId: 110, Amount: 3000, Comment: "Starbucks refund"}},
+		{Transaction: &models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 111, Amount: 3000, Comment: "Taxi"}},
+	}
+
+	changedCount := TransactionRules.ApplyRulesToImportTransactions(rules, transactions, categoryMap, nil, tagMap)
+
+	assert.Equal(t, 2, changedCount)
+	assert.Equal(t, int64(101), transactions[0].CategoryId)
+	assert.Equal(t, []string{"203", "201"}, transactions[0].TagIds)
+	assert.Equal(t, int64(110), transactions[1].CategoryId)
+	assert.Equal(t, []string{"201"}, transactions[1].TagIds)
+	assert.Equal(t, int64(111), transactions[2].CategoryId)
+	assert.Nil(t, transactions[2].TagIds)
+}
+
+func TestGetTransactionRuleApplyResults(t *testing.T) {
+	rules := []*models.TransactionRule{
+		{RuleId: 1, ConditionKeywordMatchType: models.TRANSACTION_RULE_KEYWORD_MATCH_TYPE_STARTS_WITH, ConditionKeyword: "uber", ActionCategoryId: 101, ActionTagIds: "201"},
+	}
+	categoryMap := map[int64]*models.TransactionCategory{
+		101: {CategoryId: 101, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 100},
+	}
+	tagMap := map[int64]*models.TransactionTag{
+		201: {TagId: 201},
+	}
+	transactions := []*models.Transaction{
+		{TransactionId: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 109, Comment: "Uber trip"},
+		{TransactionId: 2, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 101, Comment: "Uber trip"},
+		{TransactionId: 3, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 101, Comment: "Uber eats"},
+		{TransactionId: 4, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 109, Comment: "Lunch"},
+	}
+	existedTagIds := map[int64]map[int64]bool{
+		2: {201: true},
+	}
+
+	results := TransactionRules.getTransactionRuleApplyResults(rules, transactions, existedTagIds, categoryMap, tagMap)
+
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, int64(1), results[0].Transaction.TransactionId)
+	assert.Equal(t, int64(101), results[0].CategoryId)
+	assert.Equal(t, []int64{201}, results[0].AddedTagIds)
+	assert.Equal(t, int64(3), results[1].Transaction.TransactionId)
+	assert.Equal(t, int64(101), results[1].CategoryId)
+	assert.Equal(t, []int64{201}, results[1].AddedTagIds)
+}
+
+func TestGetTransactionRuleApplyResults_ExceedMaxTagCount(t *testing.T) {
+	rules := []*models.TransactionRule{
+		{RuleId: 1, ConditionKeywordMatchType: models.TRANSACTION_RULE_KEYWORD_MATCH_TYPE_CONTAINS, ConditionKeyword: "taxi", ActionTagIds: "201,202,203"},
+	}
+	tagMap := map[int64]*models.TransactionTag{
+		201: {TagId: 201},
+		202: {TagId: 202},
+		203: {TagId: 203},
+	}
+	transactions := []*models.Transaction{
+		{TransactionId: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 109, Comment: "Taxi"},
+		{TransactionId: 2, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 109, Comment: "Taxi"},
+		{TransactionId: 3, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 109, Comment: "Taxi"},
+	}
+	existedTagIds := map[int64]map[int64]bool{
+		2: {},
+		3: {},
+	}
+
+	for i := int64(0); i < models.MaximumTagsCountOfTransaction-1; i++ {
+		existedTagIds[2][1000+i] = true
+		existedTagIds[3][1000+i] = true
+	}
+
+	existedTagIds[3][2000] = true
+
+	results := TransactionRules.getTransactionRuleApplyResults(rules, transactions, existedTagIds, nil, tagMap)
+
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, int64(1), results[0].Transaction.TransactionId)
+	assert.Equal(t, []int64{201, 202, 203}, results[0].AddedTagIds)
+	assert.Equal(t, int64(2), results[1].Transaction.TransactionId)
+	assert.Equal(t, []int64{201}, results[1].AddedTagIds)
+}
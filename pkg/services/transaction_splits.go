@@ -0,0 +1,69 @@
+package services
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// TransactionSplitService represents transaction split line service
+type TransactionSplitService struct {
+	ServiceUsingDB
+}
+
+// Initialize a transaction split line service singleton instance
+var (
+	TransactionSplits = &TransactionSplitService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetAllSplitsOfTransactions returns all transaction split lines grouped by transaction id
+func (s *TransactionSplitService) GetAllSplitsOfTransactions(c core.Context, uid int64, transactionIds []int64) (map[int64][]*models.TransactionSplit, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if len(transactionIds) < 1 {
+		return make(map[int64][]*models.TransactionSplit), nil
+	}
+
+	var splits []*models.TransactionSplit
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).OrderBy("transaction_id asc, display_order asc").Find(&splits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetGroupedTransactionSplits(splits), nil
+}
+
+// GetGroupedTransactionSplits returns a map of transaction split lines grouped by transaction id
+func (s *TransactionSplitService) GetGroupedTransactionSplits(splits []*models.TransactionSplit) map[int64][]*models.TransactionSplit {
+	allTransactionSplits := make(map[int64][]*models.TransactionSplit)
+
+	for i := 0; i < len(splits); i++ {
+		split := splits[i]
+		allTransactionSplits[split.TransactionId] = append(allTransactionSplits[split.TransactionId], split)
+	}
+
+	return allTransactionSplits
+}
+
+// GetTransactionSplitInfoResponses returns the view-objects of transaction split lines
+func (s *TransactionSplitService) GetTransactionSplitInfoResponses(splits []*models.TransactionSplit) []*models.TransactionSplitInfoResponse {
+	if len(splits) < 1 {
+		return nil
+	}
+
+	splitResps := make([]*models.TransactionSplitInfoResponse, len(splits))
+
+	for i := 0; i < len(splits); i++ {
+		splitResps[i] = splits[i].ToTransactionSplitInfoResponse()
+	}
+
+	return splitResps
+}
@@ -390,36 +390,14 @@ func (s *TransactionTagService) DeleteTag(c core.Context, uid int64, tagId int64
 	}
 
 	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
-		exists, err := sess.Cols("uid", "tag_id").Where("uid=? AND deleted=? AND tag_id=?", uid, false, tagId).Limit(1).Exist(&models.TransactionTagIndex{})
+		inUse, err := s.isTagInUse(sess, uid, tagId, now)
 
 		if err != nil {
 			return err
-		} else if exists {
+		} else if inUse {
 			return errs.ErrTransactionTagInUseCannotBeDeleted
 		}
 
-		var relatedTransactionTemplatesByTag []*models.TransactionTemplate
-		err = sess.Cols("uid", "deleted", "tag_ids", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where("uid=? AND deleted=? AND (template_type=? OR (template_type=? AND scheduled_frequency_type<>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?))) AND tag_ids LIKE ?", uid, false, models.TRANSACTION_TEMPLATE_TYPE_NORMAL, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, now, "%%"+utils.Int64ToString(tagId)+"%%").Find(&relatedTransactionTemplatesByTag)
-
-		if err != nil {
-			return err
-		}
-
-		for i := 0; i < len(relatedTransactionTemplatesByTag); i++ {
-			template := relatedTransactionTemplatesByTag[i]
-			tagIds, err := s.GetTagIds(template.TagIds)
-
-			if err != nil {
-				return err
-			}
-
-			for j := 0; j < len(tagIds); j++ {
-				if tagIds[j] == tagId {
-					return errs.ErrTransactionTagInUseCannotBeDeleted
-				}
-			}
-		}
-
 		deletedRows, err := sess.ID(tagId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
 
 		if err != nil {
@@ -499,6 +477,58 @@ func (s *TransactionTagService) ModifyTagIndexTransactionTime(c core.Context, ui
 	})
 }
 
+// isTagInUse returns whether the transaction tag is used by transactions, transaction splits, transaction templates or transaction rules
+func (s *TransactionTagService) isTagInUse(sess *xorm.Session, uid int64, tagId int64, now int64) (bool, error) {
+	exists, err := sess.Cols("uid", "tag_id").Where("uid=? AND deleted=? AND tag_id=?", uid, false, tagId).Limit(1).Exist(&models.TransactionTagIndex{})
+
+	if err != nil || exists {
+		return exists, err
+	}
+
+	tagIdLikeCondition := "%%" + utils.Int64ToString(tagId) + "%%"
+
+	var relatedTransactionTemplatesByTag []*models.TransactionTemplate
+	err = sess.Cols("uid", "deleted", "tag_ids", "template_type", "scheduled_frequency_type", "scheduled_end_time").Where("uid=? AND deleted=? AND (template_type=? OR (template_type=? AND scheduled_frequency_type<>? AND (scheduled_end_time IS NULL OR scheduled_end_time>=?))) AND tag_ids LIKE ?", uid, false, models.TRANSACTION_TEMPLATE_TYPE_NORMAL, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, now, tagIdLikeCondition).Find(&relatedTransactionTemplatesByTag)
+
+	if err != nil {
+		return false, err
+	}
+
+	for i := 0; i < len(relatedTransactionTemplatesByTag); i++ {
+		if utils.ToSet(relatedTransactionTemplatesByTag[i].GetTagIds())[tagId] {
+			return true, nil
+		}
+	}
+
+	var relatedTransactionSplitsByTag []*models.TransactionSplit
+	err = sess.Cols("uid", "deleted", "tag_ids").Where("uid=? AND deleted=? AND tag_ids LIKE ?", uid, false, tagIdLikeCondition).Find(&relatedTransactionSplitsByTag)
+
+	if err != nil {
+		return false, err
+	}
+
+	for i := 0; i < len(relatedTransactionSplitsByTag); i++ {
+		if utils.ToSet(relatedTransactionSplitsByTag[i].GetTagIds())[tagId] {
+			return true, nil
+		}
+	}
+
+	var relatedTransactionRulesByTag []*models.TransactionRule
+	err = sess.Cols("uid", "deleted", "action_tag_ids").Where("uid=? AND deleted=? AND action_tag_ids LIKE ?", uid, false, tagIdLikeCondition).Find(&relatedTransactionRulesByTag)
+
+	if err != nil {
+		return false, err
+	}
+
+	for i := 0; i < len(relatedTransactionRulesByTag); i++ {
+		if utils.ToSet(relatedTransactionRulesByTag[i].GetActionTagIds())[tagId] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // GetTagMapByList returns a transaction tag map by a list
 func (s *TransactionTagService) GetTagMapByList(tags []*models.TransactionTag) map[int64]*models.TransactionTag {
 	tagMap := make(map[int64]*models.TransactionTag)
@@ -149,7 +149,26 @@ func (s *TransactionTemplateService) ModifyTemplate(c core.Context, template *mo
 			return err
 		}
 
-		updatedRows, err := sess.ID(template.TemplateId).Cols("name", "type", "category_id", "account_id", "scheduled_frequency_type", "scheduled_frequency", "scheduled_start_time", "scheduled_end_time", "scheduled_at", "scheduled_timezone_utc_offset", "tag_ids", "amount", "related_account_id", "related_account_amount", "hide_amount", "comment", "updated_unix_time").Where("uid=? AND deleted=?", template.Uid, false).Update(template)
+		oldTemplate := &models.TransactionTemplate{}
+		has, err := sess.ID(template.TemplateId).Where("uid=? AND deleted=?", template.Uid, false).Get(oldTemplate)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTransactionTemplateNotFound
+		}
+
+		updateCols := []string{"name", "type", "category_id", "account_id", "scheduled_frequency_type", "scheduled_frequency", "scheduled_start_time", "scheduled_end_time", "scheduled_at", "scheduled_timezone_utc_offset", "scheduled_max_occurrences", "tag_ids", "amount", "related_account_id", "related_account_amount", "hide_amount", "comment", "updated_unix_time"}
+
+		// restart counting the occurrences if the schedule is redefined, otherwise keep the count which is increased by the scheduled transaction creator
+		if oldTemplate.TemplateType == models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE && template.IsScheduledOccurrenceDefinitionChanged(oldTemplate) {
+			template.ScheduledOccurrenceCount = 0
+			updateCols = append(updateCols, "scheduled_occurrence_count")
+		} else {
+			template.ScheduledOccurrenceCount = oldTemplate.ScheduledOccurrenceCount
+		}
+
+		updatedRows, err := sess.ID(template.TemplateId).Cols(updateCols...).Where("uid=? AND deleted=?", template.Uid, false).Update(template)
 
 		if err != nil {
 			return err
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func initializeTransactionTemplateTestData(t *testing.T, uid int64) *models.TransactionTemplate {
+	initializeServicesTestDataStore(t, new(models.Account), new(models.TransactionCategory), new(models.TransactionTag), new(models.TransactionTemplate))
+
+	sess := datastore.Container.UserDataStore.Choose(uid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	_, err := sess.Insert(&models.Account{AccountId: 101, Uid: uid, Category: models.ACCOUNT_CATEGORY_CASH, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, Name: "Account", Currency: "USD"})
+	assert.Nil(t, err)
+	_, err = sess.Insert([]*models.TransactionCategory{
+		{CategoryId: 201, Uid: uid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: models.LevelOneTransactionCategoryParentId, Name: "Category"},
+		{CategoryId: 202, Uid: uid, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 201, Name: "Sub Category"},
+	})
+	assert.Nil(t, err)
+
+	template := &models.TransactionTemplate{
+		TemplateId:               301,
+		Uid:                      uid,
+		TemplateType:             models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		Name:                     "Rent",
+		Type:                     models.TRANSACTION_TYPE_EXPENSE,
+		CategoryId:               202,
+		AccountId:                101,
+		ScheduledFrequencyType:   models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY,
+		ScheduledFrequency:       "1",
+		ScheduledMaxOccurrences:  12,
+		ScheduledOccurrenceCount: 5,
+		Amount:                   100000,
+	}
+	_, err = sess.Insert(template)
+	assert.Nil(t, err)
+
+	return template
+}
+
+func TestModifyTemplate_KeepScheduledOccurrenceCountWhenScheduleUnchanged(t *testing.T) {
+	uid := int64(1)
+	template := initializeTransactionTemplateTestData(t, uid)
+
+	newTemplate := *template
+	newTemplate.ScheduledOccurrenceCount = 0
+	newTemplate.Amount = 120000
+	err := TransactionTemplates.ModifyTemplate(core.NewNullContext(), &newTemplate)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(5), newTemplate.ScheduledOccurrenceCount)
+
+	savedTemplate, err := TransactionTemplates.GetTemplateByTemplateId(core.NewNullContext(), uid, template.TemplateId)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(120000), savedTemplate.Amount)
+	assert.Equal(t, int32(5), savedTemplate.ScheduledOccurrenceCount)
+}
+
+func TestModifyTemplate_ResetScheduledOccurrenceCountWhenScheduleRedefined(t *testing.T) {
+	uid := int64(1)
+	template := initializeTransactionTemplateTestData(t, uid)
+
+	newTemplate := *template
+	newTemplate.ScheduledFrequencyType = models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RRULE
+	newTemplate.ScheduledFrequency = "FREQ=MONTHLY;BYMONTHDAY=15"
+	err := TransactionTemplates.ModifyTemplate(core.NewNullContext(), &newTemplate)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), newTemplate.ScheduledOccurrenceCount)
+
+	savedTemplate, err := TransactionTemplates.GetTemplateByTemplateId(core.NewNullContext(), uid, template.TemplateId)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), savedTemplate.ScheduledOccurrenceCount)
+
+	// the scheduled transaction creator has created some transactions under the new schedule
+	sess := datastore.Container.UserDataStore.Choose(uid).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	_, err = sess.ID(template.TemplateId).Cols("scheduled_occurrence_count").Update(&models.TransactionTemplate{ScheduledOccurrenceCount: 3})
+	assert.Nil(t, err)
+
+	newTemplate = *savedTemplate
+	newTemplate.ScheduledMaxOccurrences = 24
+	err = TransactionTemplates.ModifyTemplate(core.NewNullContext(), &newTemplate)
+	assert.Nil(t, err)
+
+	savedTemplate, err = TransactionTemplates.GetTemplateByTemplateId(core.NewNullContext(), uid, template.TemplateId)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(24), savedTemplate.ScheduledMaxOccurrences)
+	assert.Equal(t, int32(0), savedTemplate.ScheduledOccurrenceCount)
+}
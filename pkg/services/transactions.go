@@ -3,8 +3,10 @@ package services
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"xorm.io/builder"
 	"xorm.io/xorm"
@@ -20,6 +22,23 @@ import (
 
 const pageCountForLoadTransactionAmounts = 1000
 
+const duplicateTransactionMaxTimeDifference = 24 * 60 * 60 // 1 day
+const duplicateTransactionNearTimeDifference = 60 * 60     // 1 hour
+const duplicateTransactionCloseTimeDifference = 5 * 60     // 5 minutes
+const duplicateTransactionMinScore = 2
+
+// duplicateTransactionKey represents the type and amount which the probable duplicate transactions must have in common
+type duplicateTransactionKey struct {
+	transactionType models.TransactionDbType
+	amount          int64
+}
+
+// duplicateTransactionCandidate represents an existing transaction and its normalized description for duplicate detection
+type duplicateTransactionCandidate struct {
+	transaction       *models.Transaction
+	normalizedComment string
+}
+
 // TransactionService represents transaction service
 type TransactionService struct {
 	ServiceUsingDB
@@ -203,6 +222,130 @@ func (s *TransactionService) GetAllAccountsDailyOpeningAndClosingBalance(c core.
 		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(time.Now().Unix())
 	}
 
+	allTransactions, err := s.getAllTransactionsByMaxTime(c, uid, maxTransactionTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getAccountsDailyOpeningAndClosingBalance(c, allTransactions, minTransactionTime, clientTimezone)
+}
+
+// GetScheduledTransactionForecasts returns all transactions which will be created by scheduled transaction templates from the beginning of today in template timezone to the end time,
+// the transactions which have already been created by scheduled transaction templates are not included
+func (s *TransactionService) GetScheduledTransactionForecasts(c core.Context, uid int64, startUnixTime int64, endUnixTime int64) ([]*models.ScheduledTransactionForecast, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var templates []*models.TransactionTemplate
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND template_type=? AND scheduled_frequency_type<>? AND (scheduled_start_time IS NULL OR scheduled_start_time<=?) AND (scheduled_end_time IS NULL OR scheduled_end_time>=?)", uid, false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, endUnixTime, startUnixTime-24*60*60).Find(&templates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) < 1 {
+		return make([]*models.ScheduledTransactionForecast, 0), nil
+	}
+
+	// the forecasts start from the beginning of today in template timezone, so the scheduled transactions created today should be skipped
+	var createdTransactions []*models.Transaction
+	err = s.UserDataDB(uid).NewSession(c).Cols("account_id", "transaction_time").Where("uid=? AND deleted=? AND scheduled_created=? AND (type=? OR type=? OR type=?) AND transaction_time>=? AND transaction_time<=?", uid, false, true, models.TRANSACTION_DB_TYPE_INCOME, models.TRANSACTION_DB_TYPE_EXPENSE, models.TRANSACTION_DB_TYPE_TRANSFER_OUT, utils.GetMinTransactionTimeFromUnixTime(startUnixTime-24*60*60), utils.GetMaxTransactionTimeFromUnixTime(endUnixTime)).Find(&createdTransactions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	createdUnixTimesByAccount := make(map[int64]map[int64]bool)
+
+	for i := 0; i < len(createdTransactions); i++ {
+		createdTransaction := createdTransactions[i]
+		createdUnixTimes, exists := createdUnixTimesByAccount[createdTransaction.AccountId]
+
+		if !exists {
+			createdUnixTimes = make(map[int64]bool)
+			createdUnixTimesByAccount[createdTransaction.AccountId] = createdUnixTimes
+		}
+
+		createdUnixTimes[utils.GetUnixTimeFromTransactionTime(createdTransaction.TransactionTime)] = true
+	}
+
+	forecasts := make(models.ScheduledTransactionForecastSlice, 0)
+
+	for i := 0; i < len(templates); i++ {
+		template := templates[i]
+
+		if template.Type != models.TRANSACTION_TYPE_INCOME && template.Type != models.TRANSACTION_TYPE_EXPENSE && template.Type != models.TRANSACTION_TYPE_TRANSFER {
+			continue
+		}
+
+		transactionUnixTimes, err := template.GetScheduledTransactionUnixTimes(startUnixTime, endUnixTime, createdUnixTimesByAccount[template.AccountId])
+
+		if err != nil {
+			log.Warnf(c, "[transactions.GetScheduledTransactionForecasts] transaction template \"id:%d\" has invalid scheduled transaction frequency, because %s", template.TemplateId, err.Error())
+			continue
+		}
+
+		for j := 0; j < len(transactionUnixTimes); j++ {
+			forecast := &models.ScheduledTransactionForecast{
+				TemplateId:          template.TemplateId,
+				Type:                template.Type,
+				CategoryId:          template.CategoryId,
+				AccountId:           template.AccountId,
+				Amount:              template.Amount,
+				HideAmount:          template.HideAmount,
+				TransactionUnixTime: transactionUnixTimes[j],
+				TimezoneUtcOffset:   template.ScheduledTimezoneUtcOffset,
+				Comment:             template.Comment,
+			}
+
+			if template.Type == models.TRANSACTION_TYPE_TRANSFER {
+				forecast.RelatedAccountId = template.RelatedAccountId
+				forecast.RelatedAccountAmount = template.RelatedAccountAmount
+			}
+
+			forecasts = append(forecasts, forecast)
+		}
+	}
+
+	sort.Sort(forecasts)
+
+	return forecasts, nil
+}
+
+// GetAllAccountsForecastDailyOpeningAndClosingBalance returns projected daily opening and closing balance of all accounts within time range, including the upcoming scheduled transactions
+func (s *TransactionService) GetAllAccountsForecastDailyOpeningAndClosingBalance(c core.Context, uid int64, forecasts []*models.ScheduledTransactionForecast, startUnixTime int64, endUnixTime int64, clientTimezone *time.Location) (map[int32][]*models.TransactionWithAccountBalance, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	allTransactions, err := s.getAllTransactionsByMaxTime(c, uid, utils.GetMaxTransactionTimeFromUnixTime(endUnixTime))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(forecasts); i++ {
+		allTransactions = append(allTransactions, forecasts[i].ToTransactions()...)
+	}
+
+	sort.SliceStable(allTransactions, func(i, j int) bool {
+		return allTransactions[i].TransactionTime > allTransactions[j].TransactionTime
+	})
+
+	accountDailyBalances, err := s.getAccountsDailyOpeningAndClosingBalance(c, allTransactions, utils.GetMinTransactionTimeFromUnixTime(startUnixTime), clientTimezone)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.fillAccountsDailyOpeningAndClosingBalance(accountDailyBalances, startUnixTime, endUnixTime, clientTimezone)
+
+	return accountDailyBalances, nil
+}
+
+func (s *TransactionService) getAllTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64) ([]*models.Transaction, error) {
 	var allTransactions []*models.Transaction
 
 	for maxTransactionTime > 0 {
@@ -215,13 +358,16 @@ func (s *TransactionService) GetAllAccountsDailyOpeningAndClosingBalance(c core.
 		allTransactions = append(allTransactions, transactions...)
 
 		if len(transactions) < pageCountForLoadTransactionAmounts {
-			maxTransactionTime = 0
 			break
 		}
 
 		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
 	}
 
+	return allTransactions, nil
+}
+
+func (s *TransactionService) getAccountsDailyOpeningAndClosingBalance(c core.Context, allTransactions []*models.Transaction, minTransactionTime int64, clientTimezone *time.Location) (map[int32][]*models.TransactionWithAccountBalance, error) {
 	accountDailyLastBalances := make(map[string]*models.TransactionWithAccountBalance)
 	accountDailyBalances := make(map[int32][]*models.TransactionWithAccountBalance)
 
@@ -322,6 +468,50 @@ func (s *TransactionService) GetAllAccountsDailyOpeningAndClosingBalance(c core.
 	return accountDailyBalances, nil
 }
 
+func (s *TransactionService) fillAccountsDailyOpeningAndClosingBalance(accountDailyBalances map[int32][]*models.TransactionWithAccountBalance, startUnixTime int64, endUnixTime int64, clientTimezone *time.Location) {
+	accountLastClosingBalances := make(map[int64]int64)
+	accountIds := make([]int64, 0)
+	startTime := time.Unix(startUnixTime, 0).In(clientTimezone)
+	date := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, clientTimezone)
+
+	for ; date.Unix() <= endUnixTime; date = date.AddDate(0, 0, 1) {
+		yearMonthDay := int32(date.Year()*10000 + int(date.Month())*100 + date.Day())
+		dailyAccountBalances := accountDailyBalances[yearMonthDay]
+		dailyAccountIds := make(map[int64]bool, len(dailyAccountBalances))
+
+		for i := 0; i < len(dailyAccountBalances); i++ {
+			accountBalance := dailyAccountBalances[i]
+			dailyAccountIds[accountBalance.AccountId] = true
+
+			if _, exists := accountLastClosingBalances[accountBalance.AccountId]; !exists {
+				accountIds = append(accountIds, accountBalance.AccountId)
+			}
+
+			accountLastClosingBalances[accountBalance.AccountId] = accountBalance.AccountClosingBalance
+		}
+
+		for i := 0; i < len(accountIds); i++ {
+			accountId := accountIds[i]
+
+			if dailyAccountIds[accountId] {
+				continue
+			}
+
+			dailyAccountBalances = append(dailyAccountBalances, &models.TransactionWithAccountBalance{
+				Transaction: &models.Transaction{
+					AccountId: accountId,
+				},
+				AccountOpeningBalance: accountLastClosingBalances[accountId],
+				AccountClosingBalance: accountLastClosingBalances[accountId],
+			})
+		}
+
+		if len(dailyAccountBalances) > 0 {
+			accountDailyBalances[yearMonthDay] = dailyAccountBalances
+		}
+	}
+}
+
 // GetTransactionsByMaxTime returns transactions before given time
 func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, itemFilters []*models.TransactionItemFilter, noItems bool, amountFilter string, keyword string, page int32, count int32, needOneMoreItem bool, noDuplicated bool) ([]*models.Transaction, error) {
 	if uid <= 0 {
@@ -467,7 +657,12 @@ func (s *TransactionService) GetTransactionCount(c core.Context, uid int64, maxT
 }
 
 // CreateTransaction saves a new transaction to database
-func (s *TransactionService) CreateTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, itemIds []int64, pictureIds []int64) error {
+func (s *TransactionService) CreateTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, itemIds []int64, itemDetails map[int64]*models.TransactionItemDetail, splits []*models.TransactionSplit, pictureIds []int64) error {
+	return s.createTransaction(c, transaction, tagIds, itemIds, itemDetails, splits, pictureIds, nil)
+}
+
+// createTransaction saves a new transaction to database, and calls the after created function in the same database transaction if it is not nil
+func (s *TransactionService) createTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, itemIds []int64, itemDetails map[int64]*models.TransactionItemDetail, splits []*models.TransactionSplit, pictureIds []int64, afterCreatedFunc func(sess *xorm.Session) error) error {
 	if transaction.Uid <= 0 {
 		return errs.ErrUserIdInvalid
 	}
@@ -546,6 +741,39 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 			CreatedUnixTime: now,
 			UpdatedUnixTime: now,
 		}
+
+		if itemDetail, exists := itemDetails[itemIds[i]]; exists && itemDetail != nil {
+			transactionItemIndexes[i].Quantity = itemDetail.Quantity
+			transactionItemIndexes[i].UnitPrice = itemDetail.UnitPrice
+			transactionItemIndexes[i].Amount = itemDetail.Amount
+		}
+	}
+
+	if !models.IsTransactionItemsAmountValid(transactionItemIndexes, transaction.Amount) {
+		return errs.ErrTransactionItemsAmountExceedsTransactionAmount
+	}
+
+	if len(splits) > 0 {
+		err = s.isSplitsAmountValid(transaction, splits)
+
+		if err != nil {
+			return err
+		}
+
+		splitUuids := s.GenerateUuids(uuid.UUID_TYPE_TRANSACTION, uint16(len(splits)))
+
+		if len(splitUuids) < len(splits) {
+			return errs.ErrSystemIsBusy
+		}
+
+		for i := 0; i < len(splits); i++ {
+			splits[i].SplitId = splitUuids[i]
+			splits[i].Uid = transaction.Uid
+			splits[i].Deleted = false
+			splits[i].TransactionId = transaction.TransactionId
+			splits[i].CreatedUnixTime = now
+			splits[i].UpdatedUnixTime = now
+		}
 	}
 
 	pictureUpdateModel := &models.TransactionPictureInfo{
@@ -556,12 +784,38 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 	userDataDb := s.UserDataDB(transaction.Uid)
 
 	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
-		return s.doCreateTransaction(c, userDataDb, sess, transaction, transactionTagIndexes, transactionItemIndexes, tagIds, itemIds, pictureIds, pictureUpdateModel)
+		err := s.doCreateTransaction(c, userDataDb, sess, transaction, transactionTagIndexes, transactionItemIndexes, splits, tagIds, itemIds, pictureIds, pictureUpdateModel)
+
+		if err != nil || afterCreatedFunc == nil {
+			return err
+		}
+
+		return afterCreatedFunc(sess)
+	})
+}
+
+// BatchCreateTransactions saves new transactions to database, and saves the import batch record in the same database transaction if it is not nil
+func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64, transactions []*models.Transaction, allTagIds map[int][]int64, importBatch *models.ImportBatch, processHandler core.TaskProcessUpdateHandler) error {
+	return s.batchCreateTransactions(c, uid, transactions, allTagIds, importBatch, processHandler, nil)
+}
+
+// BatchCreateTransactionsByImportJob saves new transactions and the import batch record of the processing import job to database,
+// the import job is checked to be still claimed by the current attempt in the same database transaction,
+// so the transactions will not be imported twice when the import job is reset and claimed again during importing
+func (s *TransactionService) BatchCreateTransactionsByImportJob(c core.Context, importJob *models.ImportJob, transactions []*models.Transaction, allTagIds map[int][]int64, importBatch *models.ImportBatch, processHandler core.TaskProcessUpdateHandler) error {
+	if importBatch == nil {
+		return errs.ErrOperationFailed
+	}
+
+	importBatch.ImportJobId = importJob.JobId
+
+	return s.batchCreateTransactions(c, importJob.Uid, transactions, allTagIds, importBatch, processHandler, func(sess *xorm.Session) error {
+		return ImportJobs.markImportJobImported(sess, importJob, importBatch)
 	})
 }
 
-// BatchCreateTransactions saves new transactions to database
-func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64, transactions []*models.Transaction, allTagIds map[int][]int64, processHandler core.TaskProcessUpdateHandler) error {
+// batchCreateTransactions saves new transactions and the import batch record to database, and calls the after created function in the same database transaction if it is not nil
+func (s *TransactionService) batchCreateTransactions(c core.Context, uid int64, transactions []*models.Transaction, allTagIds map[int][]int64, importBatch *models.ImportBatch, processHandler core.TaskProcessUpdateHandler, afterCreatedFunc func(sess *xorm.Session) error) error {
 	now := time.Now().Unix()
 	currentProcess := float64(0)
 	processUpdateStep := int(math.Max(100.0, float64(len(transactions)/100.0)))
@@ -661,6 +915,25 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 		allTransactionTagIds[transaction.TransactionId] = uniqueTagIds
 	}
 
+	if importBatch != nil {
+		if importBatch.Uid != uid {
+			return errs.ErrUserIdInvalid
+		}
+
+		importBatch.BatchId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+		if importBatch.BatchId < 1 {
+			return errs.ErrSystemIsBusy
+		}
+
+		importBatch.ImportedCount = int32(len(transactions))
+		importBatch.SetTransactionIds(s.GetTransactionIds(transactions))
+		importBatch.Deleted = false
+		importBatch.RolledBack = false
+		importBatch.CreatedUnixTime = now
+		importBatch.UpdatedUnixTime = now
+	}
+
 	userDataDb := s.UserDataDB(uid)
 
 	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
@@ -668,7 +941,7 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 			transaction := transactions[i]
 			transactionTagIndexes := allTransactionTagIndexes[transaction.TransactionId]
 			transactionTagIds := allTransactionTagIds[transaction.TransactionId]
-			err := s.doCreateTransaction(c, userDataDb, sess, transaction, transactionTagIndexes, nil, transactionTagIds, nil, nil, nil)
+			err := s.doCreateTransaction(c, userDataDb, sess, transaction, transactionTagIndexes, nil, nil, transactionTagIds, nil, nil, nil)
 
 			currentProcess = float64(i) / float64(len(transactions)) * 100
 
@@ -684,12 +957,25 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 			}
 		}
 
+		if importBatch != nil {
+			_, err := sess.Insert(importBatch)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.BatchCreateTransactions] failed to create import batch \"id:%d\"", importBatch.BatchId)
+				return err
+			}
+		}
+
+		if afterCreatedFunc != nil {
+			return afterCreatedFunc(sess)
+		}
+
 		return nil
 	})
 }
 
-// CreateScheduledTransactions saves all scheduled transactions that should be created now
-func (s *TransactionService) CreateScheduledTransactions(c core.Context, currentUnixTime int64, interval time.Duration) error {
+// CreateScheduledTransactions saves all scheduled transactions that should be created now, the callback function will be called after each transaction is created if it is not nil
+func (s *TransactionService) CreateScheduledTransactions(c core.Context, currentUnixTime int64, interval time.Duration, transactionCreatedFunc func(transaction *models.Transaction, tagIds []int64)) error {
 	var allTemplates []*models.TransactionTemplate
 	intervalMinute := int(interval / time.Minute)
 	currentTime := time.Unix(currentUnixTime, 0)
@@ -708,7 +994,7 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 
 	for i := 0; i < s.UserDataDBCount(); i++ {
 		var templates []*models.TransactionTemplate
-		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND (scheduled_frequency_type=? OR scheduled_frequency_type=?) AND (scheduled_start_time IS NULL OR scheduled_start_time<=?) AND (scheduled_end_time IS NULL OR scheduled_end_time>=?) AND scheduled_at>=? AND scheduled_at<?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, startTime.Unix(), startTime.Unix(), minScheduledAt, maxScheduledAt).Find(&templates)
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND scheduled_frequency_type<>? AND (scheduled_start_time IS NULL OR scheduled_start_time<=?) AND (scheduled_end_time IS NULL OR scheduled_end_time>=?) AND scheduled_at>=? AND scheduled_at<?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, startTime.Unix(), startTime.Unix(), minScheduledAt, maxScheduledAt).Find(&templates)
 
 		if err != nil {
 			return err
@@ -736,15 +1022,16 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 			continue
 		}
 
-		if (template.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY &&
-			template.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY) ||
-			template.ScheduledFrequency == "" {
+		if template.HasReachedScheduledMaxOccurrences() {
 			skipCount++
-			log.Warnf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has invalid scheduled transaction frequency", template.TemplateId)
+			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, it has created all %d occurrences", template.TemplateId, template.GetScheduledMaxOccurrences())
 			continue
 		}
 
-		frequencyValues, err := utils.StringArrayToInt64Array(strings.Split(template.ScheduledFrequency, ","))
+		templateTimeZone := time.FixedZone("Template Timezone", int(template.ScheduledTimezoneUtcOffset)*60)
+		transactionUnixTime := todayFirstUnixTimeInUTC + int64(template.ScheduledAt)*60
+		transactionTime := time.Unix(transactionUnixTime, 0).In(templateTimeZone)
+		isScheduledDate, err := template.IsScheduledDate(transactionTime)
 
 		if err != nil {
 			skipCount++
@@ -752,18 +1039,9 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 			continue
 		}
 
-		frequencyValueSet := utils.ToSet(frequencyValues)
-		templateTimeZone := time.FixedZone("Template Timezone", int(template.ScheduledTimezoneUtcOffset)*60)
-		transactionUnixTime := todayFirstUnixTimeInUTC + int64(template.ScheduledAt)*60
-		transactionTime := time.Unix(transactionUnixTime, 0).In(templateTimeZone)
-
-		if template.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY && !frequencyValueSet[int64(transactionTime.Weekday())] {
-			skipCount++
-			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %s", template.TemplateId, startTimeInUTC.Weekday())
-			continue
-		} else if template.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY && !frequencyValueSet[int64(transactionTime.Day())] {
+		if !isScheduledDate {
 			skipCount++
-			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %d of month", template.TemplateId, startTimeInUTC.Day())
+			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %s", template.TemplateId, utils.FormatUnixTimeToLongDate(transactionUnixTime, templateTimeZone))
 			continue
 		}
 
@@ -813,11 +1091,37 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 		}
 
 		tagIds := template.GetTagIds()
-		err = s.CreateTransaction(c, transaction, tagIds, nil, nil)
+		maxOccurrences := template.GetScheduledMaxOccurrences()
+
+		// increase the occurrence count in the same database transaction, and only when the template has not created all occurrences by other process
+		err = s.createTransaction(c, transaction, tagIds, nil, nil, nil, nil, func(sess *xorm.Session) error {
+			sess.ID(template.TemplateId).Where("uid=? AND deleted=?", template.Uid, false)
+
+			if maxOccurrences > 0 {
+				sess.And("scheduled_occurrence_count<?", maxOccurrences)
+			}
+
+			updatedRows, err := sess.Incr("scheduled_occurrence_count").Update(&models.TransactionTemplate{})
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrScheduledTransactionMaxOccurrencesReached
+			}
+
+			return nil
+		})
 
 		if err == nil {
 			successCount++
 			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has created a new trasaction \"id:%d\"", template.TemplateId, transaction.TransactionId)
+
+			if transactionCreatedFunc != nil {
+				transactionCreatedFunc(transaction, tagIds)
+			}
+		} else if err == errs.ErrScheduledTransactionMaxOccurrencesReached {
+			skipCount++
+			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, it has created all %d occurrences", template.TemplateId, maxOccurrences)
 		} else {
 			failedCount++
 			log.Errorf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" failed to create new trasaction, because %s", template.TemplateId, err.Error())
@@ -830,7 +1134,7 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 }
 
 // ModifyTransaction saves an existed transaction to database
-func (s *TransactionService) ModifyTransaction(c core.Context, transaction *models.Transaction, currentTagIdsCount int, addTagIds []int64, removeTagIds []int64, addItemIds []int64, removeItemIds []int64, addPictureIds []int64, removePictureIds []int64) error {
+func (s *TransactionService) ModifyTransaction(c core.Context, transaction *models.Transaction, currentTagIdsCount int, addTagIds []int64, removeTagIds []int64, addItemIds []int64, removeItemIds []int64, itemDetails map[int64]*models.TransactionItemDetail, splits []*models.TransactionSplit, addPictureIds []int64, removePictureIds []int64) error {
 	if transaction.Uid <= 0 {
 		return errs.ErrUserIdInvalid
 	}
@@ -849,6 +1153,13 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 		return errs.ErrSystemIsBusy
 	}
 
+	needSplitUuidCount := uint16(len(splits))
+	splitUuids := s.GenerateUuids(uuid.UUID_TYPE_TRANSACTION, needSplitUuidCount)
+
+	if len(splitUuids) < int(needSplitUuidCount) {
+		return errs.ErrSystemIsBusy
+	}
+
 	updateCols := make([]string, 0, 16)
 
 	now := time.Now().Unix()
@@ -857,6 +1168,10 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 	transaction.UpdatedUnixTime = now
 	updateCols = append(updateCols, "updated_unix_time")
 
+	if transaction.UpdatedByUid > 0 {
+		updateCols = append(updateCols, "updated_by_uid")
+	}
+
 	addTagIds = utils.ToUniqueInt64Slice(addTagIds)
 	removeTagIds = utils.ToUniqueInt64Slice(removeTagIds)
 	addItemIds = utils.ToUniqueInt64Slice(addItemIds)
@@ -888,6 +1203,21 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			CreatedUnixTime: now,
 			UpdatedUnixTime: now,
 		}
+
+		if itemDetail, exists := itemDetails[addItemIds[i]]; exists && itemDetail != nil {
+			transactionItemIndexes[i].Quantity = itemDetail.Quantity
+			transactionItemIndexes[i].UnitPrice = itemDetail.UnitPrice
+			transactionItemIndexes[i].Amount = itemDetail.Amount
+		}
+	}
+
+	for i := 0; i < len(splits); i++ {
+		splits[i].SplitId = splitUuids[i]
+		splits[i].Uid = transaction.Uid
+		splits[i].Deleted = false
+		splits[i].TransactionId = transaction.TransactionId
+		splits[i].CreatedUnixTime = now
+		splits[i].UpdatedUnixTime = now
 	}
 
 	err := s.UserDataDB(transaction.Uid).DoTransaction(c, func(sess *xorm.Session) error {
@@ -1160,6 +1490,45 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			}
 		}
 
+		var keptItemIndexes []*models.TransactionItemIndex
+		err = sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Find(&keptItemIndexes)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to get transaction item indexes, because %s", err.Error())
+			return err
+		}
+
+		if itemDetails != nil {
+			for i := 0; i < len(keptItemIndexes); i++ {
+				keptItemIndex := keptItemIndexes[i]
+				itemDetail := itemDetails[keptItemIndex.ItemId]
+
+				if keptItemIndex.GetTransactionItemDetail().Equals(itemDetail) {
+					continue
+				}
+
+				if itemDetail == nil {
+					itemDetail = &models.TransactionItemDetail{}
+				}
+
+				keptItemIndex.Quantity = itemDetail.Quantity
+				keptItemIndex.UnitPrice = itemDetail.UnitPrice
+				keptItemIndex.Amount = itemDetail.Amount
+				keptItemIndex.UpdatedUnixTime = now
+
+				_, err := sess.ID(keptItemIndex.ItemIndexId).Cols("quantity", "unit_price", "amount", "updated_unix_time").Where("uid=? AND deleted=?", transaction.Uid, false).Update(keptItemIndex)
+
+				if err != nil {
+					log.Errorf(c, "[transactions.ModifyTransaction] failed to update transaction item index, because %s", err.Error())
+					return err
+				}
+			}
+		}
+
+		if !models.IsTransactionItemsAmountValid(append(keptItemIndexes, transactionItemIndexes...), transaction.Amount) {
+			return errs.ErrTransactionItemsAmountExceedsTransactionAmount
+		}
+
 		if len(transactionItemIndexes) > 0 {
 			for i := 0; i < len(transactionItemIndexes); i++ {
 				transactionItemIndex := transactionItemIndexes[i]
@@ -1174,6 +1543,72 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			}
 		}
 
+		// Update transaction split lines
+		if splits != nil {
+			if len(splits) > 0 {
+				err = s.isSplitsAmountValid(transaction, splits)
+
+				if err != nil {
+					return err
+				}
+
+				err = s.isSplitsValid(sess, transaction, splits)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			splitUpdateModel := &models.TransactionSplit{
+				Deleted:         true,
+				DeletedUnixTime: now,
+			}
+
+			_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Update(splitUpdateModel)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.ModifyTransaction] failed to remove transaction split lines, because %s", err.Error())
+				return err
+			}
+
+			for i := 0; i < len(splits); i++ {
+				transactionSplit := splits[i]
+				transactionSplit.TransactionTime = transaction.TransactionTime
+
+				_, err := sess.Insert(transactionSplit)
+
+				if err != nil {
+					log.Errorf(c, "[transactions.ModifyTransaction] failed to add transaction split line, because %s", err.Error())
+					return err
+				}
+			}
+		} else {
+			var existingSplits []*models.TransactionSplit
+			err = sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Find(&existingSplits)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.ModifyTransaction] failed to get transaction split lines, because %s", err.Error())
+				return err
+			}
+
+			if len(existingSplits) > 0 && models.GetTransactionSplitsTotalAmount(existingSplits) != transaction.Amount {
+				return errs.ErrTransactionSplitsAmountNotEqual
+			}
+
+			if len(existingSplits) > 0 && modifyTransactionTime {
+				splitUpdateModel := &models.TransactionSplit{
+					TransactionTime: transaction.TransactionTime,
+				}
+
+				_, err := sess.Cols("transaction_time").Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Update(splitUpdateModel)
+
+				if err != nil {
+					log.Errorf(c, "[transactions.ModifyTransaction] failed to update transaction split lines, because %s", err.Error())
+					return err
+				}
+			}
+		}
+
 		// Update transaction picture
 		if len(removePictureIds) > 0 {
 			pictureUpdateModel := &models.TransactionPictureInfo{
@@ -1608,6 +2043,11 @@ func (s *TransactionService) DeleteTransaction(c core.Context, uid int64, transa
 		DeletedUnixTime: now,
 	}
 
+	splitUpdateModel := &models.TransactionSplit{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
 	pictureUpdateModel := &models.TransactionPictureInfo{
 		Deleted:         true,
 		DeletedUnixTime: now,
@@ -1665,6 +2105,13 @@ func (s *TransactionService) DeleteTransaction(c core.Context, uid int64, transa
 			return err
 		}
 
+		// Update transaction split lines
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(splitUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
 		// Update transaction picture
 		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(pictureUpdateModel)
 
@@ -1764,6 +2211,11 @@ func (s *TransactionService) DeleteAllTransactions(c core.Context, uid int64, de
 		DeletedUnixTime: now,
 	}
 
+	splitUpdateModel := &models.TransactionSplit{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
 	pictureUpdateModel := &models.TransactionPictureInfo{
 		Deleted:         true,
 		DeletedUnixTime: now,
@@ -1797,6 +2249,13 @@ func (s *TransactionService) DeleteAllTransactions(c core.Context, uid int64, de
 			return err
 		}
 
+		// Update all transaction split lines to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(splitUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
 		// Update all transaction pictures to deleted
 		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(pictureUpdateModel)
 
@@ -1884,6 +2343,8 @@ func (s *TransactionService) GetRelatedTransferTransaction(originalTransaction *
 		GeoLongitude:         originalTransaction.GeoLongitude,
 		GeoLatitude:          originalTransaction.GeoLatitude,
 		CreatedIp:            originalTransaction.CreatedIp,
+		CreatedByUid:         originalTransaction.CreatedByUid,
+		UpdatedByUid:         originalTransaction.UpdatedByUid,
 		CreatedUnixTime:      originalTransaction.CreatedUnixTime,
 		UpdatedUnixTime:      originalTransaction.UpdatedUnixTime,
 		DeletedUnixTime:      originalTransaction.DeletedUnixTime,
@@ -2017,7 +2478,7 @@ func (s *TransactionService) GetAccountsTotalIncomeAndExpense(c core.Context, ui
 }
 
 // GetAccountsAndCategoriesTotalInflowAndOutflow returns the every accounts and categories total inflows and outflows amount by specific date range
-func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, tagFilters []*models.TransactionTagFilter, noTags bool, itemFilters []*models.TransactionItemFilter, noItems bool, keyword string, clientTimezone *time.Location, useTransactionTimezone bool) ([]*models.Transaction, error) {
+func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, tagFilters []*models.TransactionTagFilter, noTags bool, itemFilters []*models.TransactionItemFilter, noItems bool, keyword string, clientTimezone *time.Location, useTransactionTimezone bool, amountExchanger *models.TransactionAmountExchanger) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -2071,7 +2532,7 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 			finalConditionParams = append(finalConditionParams, "%%"+keyword+"%%")
 		}
 
-		sess := s.UserDataDB(uid).NewSession(c).Select("type, category_id, account_id, related_account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
+		sess := s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, category_id, account_id, related_account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
 		sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
 		sess = s.appendFilterItemIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, itemFilters, noItems)
 
@@ -2091,6 +2552,14 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
 	}
 
+	allSplits, err := s.GetTransactionSplitsMapByTimeRange(c, uid, endTransactionTime, startTransactionTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allTransactions = s.GetSplitTransactions(allTransactions, allSplits)
+
 	transactionTotalAmountsMap := make(map[string]*models.Transaction)
 
 	for i := 0; i < len(allTransactions); i++ {
@@ -2107,6 +2576,18 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 			continue
 		}
 
+		amount := transaction.Amount
+
+		if amountExchanger != nil {
+			exchangedAmount, ok := amountExchanger.ExchangeTransactionAmount(transaction)
+
+			if !ok {
+				return nil, errs.ErrExchangeRateNotFoundForTransaction
+			}
+
+			amount = exchangedAmount
+		}
+
 		groupKey := fmt.Sprintf("%d_%d", transaction.CategoryId, transaction.AccountId)
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
@@ -2127,7 +2608,7 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 			transactionTotalAmountsMap[groupKey] = totalAmounts
 		}
 
-		totalAmounts.Amount += transaction.Amount
+		totalAmounts.Amount += amount
 	}
 
 	transactionTotalAmounts := make([]*models.Transaction, 0, len(transactionTotalAmountsMap))
@@ -2140,7 +2621,7 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 }
 
 // GetAccountsAndCategoriesMonthlyInflowAndOutflow returns the every accounts monthly inflows and outflows amount by specific date range
-func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, tagFilters []*models.TransactionTagFilter, noTags bool, itemFilters []*models.TransactionItemFilter, noItems bool, keyword string, clientTimezone *time.Location, useTransactionTimezone bool) (map[int32][]*models.Transaction, error) {
+func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, tagFilters []*models.TransactionTagFilter, noTags bool, itemFilters []*models.TransactionItemFilter, noItems bool, keyword string, clientTimezone *time.Location, useTransactionTimezone bool, amountExchanger *models.TransactionAmountExchanger) (map[int32][]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -2199,7 +2680,7 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 			finalConditionParams = append(finalConditionParams, "%%"+keyword+"%%")
 		}
 
-		sess := s.UserDataDB(uid).NewSession(c).Select("type, category_id, account_id, related_account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
+		sess := s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, category_id, account_id, related_account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
 		sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
 		sess = s.appendFilterItemIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, itemFilters, noItems)
 
@@ -2219,6 +2700,14 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
 	}
 
+	allSplits, err := s.GetTransactionSplitsMapByTimeRange(c, uid, endTransactionTime, startTransactionTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allTransactions = s.GetSplitTransactions(allTransactions, allSplits)
+
 	startYearMonth := startYear*100 + startMonth
 	endYearMonth := endYear*100 + endMonth
 	transactionsMonthlyAmountsMap := make(map[string]*models.Transaction)
@@ -2238,6 +2727,18 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 			continue
 		}
 
+		amount := transaction.Amount
+
+		if amountExchanger != nil {
+			exchangedAmount, ok := amountExchanger.ExchangeTransactionAmount(transaction)
+
+			if !ok {
+				return nil, errs.ErrExchangeRateNotFoundForTransaction
+			}
+
+			amount = exchangedAmount
+		}
+
 		groupKey := fmt.Sprintf("%d_%d_%d", yearMonth, transaction.CategoryId, transaction.AccountId)
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
@@ -2258,7 +2759,7 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 			transactionsMonthlyAmountsMap[groupKey] = transactionAmounts
 		}
 
-		transactionAmounts.Amount += transaction.Amount
+		transactionAmounts.Amount += amount
 	}
 
 	for groupKey, transaction := range transactionsMonthlyAmountsMap {
@@ -2300,7 +2801,154 @@ func (s *TransactionService) GetTransactionIds(transactions []*models.Transactio
 	return transactionIds
 }
 
-func (s *TransactionService) doCreateTransaction(c core.Context, database *datastore.Database, sess *xorm.Session, transaction *models.Transaction, transactionTagIndexes []*models.TransactionTagIndex, transactionItemIndexes []*models.TransactionItemIndex, tagIds []int64, itemIds []int64, pictureIds []int64, pictureUpdateModel *models.TransactionPictureInfo) error {
+// GetProbableDuplicateTransactionIds returns the ids of existing transactions which the new transactions are probably duplicates of, the id is 0 if there is no probable duplicate
+func (s *TransactionService) GetProbableDuplicateTransactionIds(c core.Context, uid int64, newTransactions []*models.Transaction) ([]int64, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if len(newTransactions) < 1 {
+		return []int64{}, nil
+	}
+
+	minTransactionUnixTime := int64(math.MaxInt64)
+	maxTransactionUnixTime := int64(0)
+
+	for i := 0; i < len(newTransactions); i++ {
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(newTransactions[i].TransactionTime)
+
+		if transactionUnixTime < minTransactionUnixTime {
+			minTransactionUnixTime = transactionUnixTime
+		}
+
+		if transactionUnixTime > maxTransactionUnixTime {
+			maxTransactionUnixTime = transactionUnixTime
+		}
+	}
+
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(maxTransactionUnixTime + duplicateTransactionMaxTimeDifference)
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(max(minTransactionUnixTime-duplicateTransactionMaxTimeDifference, 1))
+	existingTransactions, err := s.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, 0, nil, nil, nil, false, nil, false, "", "", pageCountForLoadTransactionAmounts, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getProbableDuplicateTransactionIds(existingTransactions, newTransactions), nil
+}
+
+func (s *TransactionService) getProbableDuplicateTransactionIds(existingTransactions []*models.Transaction, newTransactions []*models.Transaction) []int64 {
+	duplicateTransactionIds := make([]int64, len(newTransactions))
+	matchedTransactionIds := make(map[int64]bool, len(newTransactions))
+	existingTransactionsByTypeAndAmount := make(map[duplicateTransactionKey][]*duplicateTransactionCandidate)
+
+	for i := 0; i < len(existingTransactions); i++ {
+		existingTransaction := existingTransactions[i]
+		key := duplicateTransactionKey{transactionType: existingTransaction.Type, amount: existingTransaction.Amount}
+		existingTransactionsByTypeAndAmount[key] = append(existingTransactionsByTypeAndAmount[key], &duplicateTransactionCandidate{
+			transaction:       existingTransaction,
+			normalizedComment: s.getNormalizedTransactionComment(existingTransaction.Comment),
+		})
+	}
+
+	for i := 0; i < len(newTransactions); i++ {
+		newTransaction := newTransactions[i]
+		newComment := s.getNormalizedTransactionComment(newTransaction.Comment)
+		candidates := existingTransactionsByTypeAndAmount[duplicateTransactionKey{transactionType: newTransaction.Type, amount: newTransaction.Amount}]
+		bestScore := 0
+		bestTimeDifference := int64(math.MaxInt64)
+
+		for j := 0; j < len(candidates); j++ {
+			candidate := candidates[j]
+
+			if matchedTransactionIds[candidate.transaction.TransactionId] {
+				continue
+			}
+
+			score, timeDifference := s.getDuplicateTransactionScore(candidate.transaction, candidate.normalizedComment, newTransaction, newComment)
+
+			if score < duplicateTransactionMinScore {
+				continue
+			}
+
+			if score > bestScore || (score == bestScore && timeDifference < bestTimeDifference) {
+				duplicateTransactionIds[i] = candidate.transaction.TransactionId
+				bestScore = score
+				bestTimeDifference = timeDifference
+			}
+		}
+
+		if duplicateTransactionIds[i] != 0 {
+			matchedTransactionIds[duplicateTransactionIds[i]] = true
+		}
+	}
+
+	return duplicateTransactionIds
+}
+
+// getDuplicateTransactionScore returns the similarity score and the time difference (in seconds) of the two transactions,
+// the type and amount must be the same, the account and the non-empty descriptions must not conflict, and the time, account and description adjust the score
+func (s *TransactionService) getDuplicateTransactionScore(existingTransaction *models.Transaction, existingComment string, newTransaction *models.Transaction, newComment string) (int, int64) {
+	if existingTransaction.Type != newTransaction.Type || existingTransaction.Amount != newTransaction.Amount {
+		return 0, 0
+	}
+
+	timeDifference := utils.GetUnixTimeFromTransactionTime(existingTransaction.TransactionTime) - utils.GetUnixTimeFromTransactionTime(newTransaction.TransactionTime)
+
+	if timeDifference < 0 {
+		timeDifference = -timeDifference
+	}
+
+	if timeDifference > duplicateTransactionMaxTimeDifference {
+		return 0, timeDifference
+	}
+
+	score := 0
+
+	if timeDifference <= duplicateTransactionCloseTimeDifference {
+		score += 2
+	} else if timeDifference <= duplicateTransactionNearTimeDifference {
+		score++
+	}
+
+	if existingTransaction.AccountId != 0 && newTransaction.AccountId != 0 {
+		if existingTransaction.AccountId != newTransaction.AccountId {
+			return 0, timeDifference
+		}
+
+		score++
+	}
+
+	if existingComment != "" && newComment != "" {
+		if !strings.Contains(existingComment, newComment) && !strings.Contains(newComment, existingComment) {
+			return 0, timeDifference
+		}
+
+		score++
+	}
+
+	return score, timeDifference
+}
+
+func (s *TransactionService) getNormalizedTransactionComment(comment string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, comment)
+}
+
+func (s *TransactionService) doCreateTransaction(c core.Context, database *datastore.Database, sess *xorm.Session, transaction *models.Transaction, transactionTagIndexes []*models.TransactionTagIndex, transactionItemIndexes []*models.TransactionItemIndex, transactionSplits []*models.TransactionSplit, tagIds []int64, itemIds []int64, pictureIds []int64, pictureUpdateModel *models.TransactionPictureInfo) error {
+	if transaction.CreatedByUid <= 0 {
+		transaction.CreatedByUid = transaction.Uid
+	}
+
+	if transaction.UpdatedByUid <= 0 {
+		transaction.UpdatedByUid = transaction.CreatedByUid
+	}
+
 	// Get and verify source and destination account
 	sourceAccount, destinationAccount, err := s.getAccountModels(sess, transaction)
 
@@ -2347,6 +2995,13 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 		return err
 	}
 
+	// Get and verify split lines
+	err = s.isSplitsValid(sess, transaction, transactionSplits)
+
+	if err != nil {
+		return err
+	}
+
 	// Get and verify pictures
 	err = s.isPicturesValid(sess, transaction, pictureIds)
 
@@ -2493,6 +3148,21 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 		}
 	}
 
+	// Insert transaction split lines
+	if len(transactionSplits) > 0 {
+		for i := 0; i < len(transactionSplits); i++ {
+			transactionSplit := transactionSplits[i]
+			transactionSplit.TransactionTime = transaction.TransactionTime
+
+			_, err := sess.Insert(transactionSplit)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.doCreateTransaction] failed to add transaction split line, because %s", err.Error())
+				return err
+			}
+		}
+	}
+
 	// Update transaction picture
 	if len(pictureIds) > 0 {
 		_, err = sess.Cols("transaction_id", "updated_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, models.TransactionPictureNewPictureTransactionId).In("picture_id", pictureIds).Update(pictureUpdateModel)
@@ -3101,6 +3771,135 @@ func (s *TransactionService) isItemsValid(sess *xorm.Session, transaction *model
 	return nil
 }
 
+// GetTransactionSplitsMapByTimeRange returns the split lines of all transactions in the specified transaction time range, grouped by transaction id
+func (s *TransactionService) GetTransactionSplitsMapByTimeRange(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64) (map[int64][]*models.TransactionSplit, error) {
+	sess := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false)
+
+	if maxTransactionTime > 0 {
+		sess = sess.And("transaction_time<=?", maxTransactionTime)
+	}
+
+	if minTransactionTime > 0 {
+		sess = sess.And("transaction_time>=?", minTransactionTime)
+	}
+
+	var splits []*models.TransactionSplit
+	err := sess.OrderBy("transaction_id asc, display_order asc").Find(&splits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allSplits := make(map[int64][]*models.TransactionSplit)
+
+	for i := 0; i < len(splits); i++ {
+		allSplits[splits[i].TransactionId] = append(allSplits[splits[i].TransactionId], splits[i])
+	}
+
+	return allSplits, nil
+}
+
+// GetSplitTransactions returns the transactions which each split transaction is replaced by its split lines with their own categories and amounts
+func (s *TransactionService) GetSplitTransactions(transactions []*models.Transaction, allSplits map[int64][]*models.TransactionSplit) []*models.Transaction {
+	if len(allSplits) < 1 {
+		return transactions
+	}
+
+	splitTransactions := make([]*models.Transaction, 0, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		splits, exists := allSplits[transaction.TransactionId]
+
+		if !exists || len(splits) < 1 || (transaction.Type != models.TRANSACTION_DB_TYPE_INCOME && transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE) {
+			splitTransactions = append(splitTransactions, transaction)
+			continue
+		}
+
+		for j := 0; j < len(splits); j++ {
+			splitTransaction := *transaction
+			splitTransaction.CategoryId = splits[j].CategoryId
+			splitTransaction.Amount = splits[j].Amount
+			splitTransactions = append(splitTransactions, &splitTransaction)
+		}
+	}
+
+	return splitTransactions
+}
+
+func (s *TransactionService) isSplitsAmountValid(transaction *models.Transaction, splits []*models.TransactionSplit) error {
+	if len(splits) < 1 {
+		return nil
+	}
+
+	if transaction.Type != models.TRANSACTION_DB_TYPE_INCOME && transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+		return errs.ErrTransactionSplitsNotSupported
+	}
+
+	if len(splits) < 2 {
+		return errs.ErrTransactionHasTooFewSplits
+	}
+
+	if models.GetTransactionSplitsTotalAmount(splits) != transaction.Amount {
+		return errs.ErrTransactionSplitsAmountNotEqual
+	}
+
+	return nil
+}
+
+func (s *TransactionService) isSplitsValid(sess *xorm.Session, transaction *models.Transaction, splits []*models.TransactionSplit) error {
+	if len(splits) < 1 {
+		return nil
+	}
+
+	var allTagIds []int64
+
+	for i := 0; i < len(splits); i++ {
+		splitTransaction := &models.Transaction{
+			Uid:        transaction.Uid,
+			Type:       transaction.Type,
+			CategoryId: splits[i].CategoryId,
+		}
+
+		err := s.isCategoryValid(sess, splitTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		allTagIds = append(allTagIds, splits[i].GetTagIds()...)
+	}
+
+	allTagIds = utils.ToUniqueInt64Slice(allTagIds)
+
+	if len(allTagIds) > 0 {
+		var tags []*models.TransactionTag
+		err := sess.Where("uid=? AND deleted=?", transaction.Uid, false).In("tag_id", allTagIds).Find(&tags)
+
+		if err != nil {
+			return err
+		}
+
+		tagMap := make(map[int64]*models.TransactionTag)
+
+		for i := 0; i < len(tags); i++ {
+			if tags[i].Hidden {
+				return errs.ErrCannotUseHiddenTransactionTag
+			}
+
+			tagMap[tags[i].TagId] = tags[i]
+		}
+
+		for i := 0; i < len(allTagIds); i++ {
+			if _, exists := tagMap[allTagIds[i]]; !exists {
+				return errs.ErrTransactionTagNotFound
+			}
+		}
+	}
+
+	return nil
+}
+
 func (s *TransactionService) isPicturesValid(sess *xorm.Session, transaction *models.Transaction, pictureIds []int64) error {
 	if len(pictureIds) > 0 {
 		var pictureInfos []*models.TransactionPictureInfo
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+func TestGetSplitTransactions_NoSplits(t *testing.T) {
+	transactions := []*models.Transaction{
+		{TransactionId: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 101, AccountId: 201, Amount: 1000},
+	}
+
+	actualTransactions := Transactions.GetSplitTransactions(transactions, nil)
+	assert.Equal(t, transactions, actualTransactions)
+}
+
+func TestGetSplitTransactions_WithSplits(t *testing.T) {
+	transactions := []*models.Transaction{
+		{TransactionId: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 101, AccountId: 201, TransactionTime: 1000, Amount: 1500},
+		{TransactionId: 2, Type: models.TRANSACTION_DB_TYPE_INCOME, CategoryId: 102, AccountId: 201, TransactionTime: 2000, Amount: 800},
+		{TransactionId: 3, Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, CategoryId: 103, AccountId: 201, RelatedAccountId: 202, TransactionTime: 3000, Amount: 300},
+	}
+
+	allSplits := map[int64][]*models.TransactionSplit{
+		1: {
+			{TransactionId: 1, CategoryId: 104, Amount: 1000},
+			{TransactionId: 1, CategoryId: 105, Amount: 500},
+		},
+		3: {
+			{TransactionId: 3, CategoryId: 106, Amount: 300},
+		},
+	}
+
+	actualTransactions := Transactions.GetSplitTransactions(transactions, allSplits)
+	assert.Equal(t, 4, len(actualTransactions))
+
+	assert.Equal(t, int64(1), actualTransactions[0].TransactionId)
+	assert.Equal(t, int64(104), actualTransactions[0].CategoryId)
+	assert.Equal(t, int64(201), actualTransactions[0].AccountId)
+	assert.Equal(t, int64(1000), actualTransactions[0].TransactionTime)
+	assert.Equal(t, int64(1000), actualTransactions[0].Amount)
+
+	assert.Equal(t, int64(1), actualTransactions[1].TransactionId)
+	assert.Equal(t, int64(105), actualTransactions[1].CategoryId)
+	assert.Equal(t, int64(500), actualTransactions[1].Amount)
+
+	assert.Equal(t, transactions[1], actualTransactions[2])
+	assert.Equal(t, transactions[2], actualTransactions[3])
+
+	assert.Equal(t, int64(101), transactions[0].CategoryId)
+	assert.Equal(t, int64(1500), transactions[0].Amount)
+}
+
+func TestIsSplitsAmountValid(t *testing.T) {
+	splits := []*models.TransactionSplit{
+		{CategoryId: 101, Amount: 1000},
+		{CategoryId: 102, Amount: 500},
+	}
+
+	err := Transactions.isSplitsAmountValid(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, Amount: 1500}, nil)
+	assert.Nil(t, err)
+
+	err = Transactions.isSplitsAmountValid(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, Amount: 1500}, splits)
+	assert.Nil(t, err)
+
+	err = Transactions.isSplitsAmountValid(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_INCOME, Amount: 1500}, splits)
+	assert.Nil(t, err)
+
+	err = Transactions.isSplitsAmountValid(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, Amount: 1501}, splits)
+	assert.EqualError(t, err, errs.ErrTransactionSplitsAmountNotEqual.Message)
+
+	err = Transactions.isSplitsAmountValid(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, Amount: 1500}, splits)
+	assert.EqualError(t, err, errs.ErrTransactionSplitsNotSupported.Message)
+
+	err = Transactions.isSplitsAmountValid(&models.Transaction{Type: models.TRANSACTION_DB_TYPE_EXPENSE, Amount: 1000}, splits[:1])
+	assert.EqualError(t, err, errs.ErrTransactionHasTooFewSplits.Message)
+}
+
+func TestGetAccountsDailyOpeningAndClosingBalance_WithForecasts(t *testing.T) {
+	timezone := time.UTC
+	startUnixTime := time.Date(2024, 3, 1, 0, 0, 0, 0, timezone).Unix()
+	endUnixTime := time.Date(2024, 3, 4, 23, 59, 59, 0, timezone).Unix()
+
+	transactions := []*models.Transaction{
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 1, TransactionTime: time.Date(2024, 2, 20, 10, 0, 0, 0, timezone).Unix() * 1000, Amount: 300},
+		{Type: models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, AccountId: 1, TransactionTime: time.Date(2024, 1, 1, 0, 0, 0, 0, timezone).Unix() * 1000, RelatedAccountAmount: 1000},
+	}
+
+	forecasts := []*models.ScheduledTransactionForecast{
+		{TemplateId: 11, Type: models.TRANSACTION_TYPE_EXPENSE, AccountId: 1, Amount: 500, TransactionUnixTime: time.Date(2024, 3, 2, 0, 0, 0, 0, timezone).Unix()},
+		{TemplateId: 12, Type: models.TRANSACTION_TYPE_TRANSFER, AccountId: 1, RelatedAccountId: 2, Amount: 400, RelatedAccountAmount: 400, TransactionUnixTime: time.Date(2024, 3, 3, 0, 0, 0, 0, timezone).Unix()},
+	}
+
+	for i := 0; i < len(forecasts); i++ {
+		transactions = append(forecasts[i].ToTransactions(), transactions...)
+	}
+
+	accountDailyBalances, err := Transactions.getAccountsDailyOpeningAndClosingBalance(core.NewNullContext(), transactions, startUnixTime*1000, timezone)
+	assert.Nil(t, err)
+
+	Transactions.fillAccountsDailyOpeningAndClosingBalance(accountDailyBalances, startUnixTime, endUnixTime, timezone)
+
+	getAccountBalance := func(yearMonthDay int32, accountId int64) *models.TransactionWithAccountBalance {
+		for _, accountBalance := range accountDailyBalances[yearMonthDay] {
+			if accountBalance.AccountId == accountId {
+				return accountBalance
+			}
+		}
+
+		return nil
+	}
+
+	assert.Equal(t, int64(700), getAccountBalance(20240301, 1).AccountOpeningBalance)
+	assert.Equal(t, int64(700), getAccountBalance(20240301, 1).AccountClosingBalance)
+	assert.Equal(t, int64(700), getAccountBalance(20240302, 1).AccountOpeningBalance)
+	assert.Equal(t, int64(200), getAccountBalance(20240302, 1).AccountClosingBalance)
+	assert.Equal(t, int64(200), getAccountBalance(20240303, 1).AccountOpeningBalance)
+	assert.Equal(t, int64(-200), getAccountBalance(20240303, 1).AccountClosingBalance)
+	assert.Equal(t, int64(-200), getAccountBalance(20240304, 1).AccountOpeningBalance)
+	assert.Equal(t, int64(-200), getAccountBalance(20240304, 1).AccountClosingBalance)
+
+	assert.Nil(t, getAccountBalance(20240302, 2))
+	assert.Equal(t, int64(0), getAccountBalance(20240303, 2).AccountOpeningBalance)
+	assert.Equal(t, int64(400), getAccountBalance(20240303, 2).AccountClosingBalance)
+	assert.Equal(t, int64(400), getAccountBalance(20240304, 2).AccountClosingBalance)
+	assert.Nil(t, accountDailyBalances[20240305])
+}
+
+func TestGetProbableDuplicateTransactionIds_SameTimeAndAmount(t *testing.T) {
+	existingTransactions := []*models.Transaction{
+		{TransactionId: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700000000000, Amount: 3550, Comment: "美团外卖"},
+		{TransactionId: 2, Type: models.TRANSACTION_DB_TYPE_INCOME, AccountId: 201, TransactionTime: 1700000000000, Amount: 3550},
+	}
+
+	newTransactions := []*models.Transaction{
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: 1700000060000, Amount: 3550},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, TransactionTime: 1700000000000, Amount: 3551},
+		{Type: models.TRANSACTION_DB_TYPE_TRANSFER_OUT, TransactionTime: 1700000000000, Amount: 3550},
+	}
+
+	actualIds := Transactions.getProbableDuplicateTransactionIds(existingTransactions, newTransactions)
+	assert.Equal(t, []int64{1, 0, 0}, actualIds)
+}
+
+func TestGetProbableDuplicateTransactionIds_AccountAndDescription(t *testing.T) {
+	existingTransactions := []*models.Transaction{
+		{TransactionId: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700000000000, Amount: 1000, Comment: "Starbucks Coffee"},
+		{TransactionId: 2, Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 202, TransactionTime: 1700000000000, Amount: 2000, Comment: "Taxi"},
+	}
+
+	newTransactions := []*models.Transaction{
+		// 3 hours later, same account and similar description
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700010800000, Amount: 1000, Comment: "starbucks-coffee #123"},
+		// same time, different account
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700000000000, Amount: 2000, Comment: "Taxi"},
+		// same time and account, conflicting description
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 202, TransactionTime: 1700000000000, Amount: 2000, Comment: "Hotel"},
+		// more than 1 day later
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 202, TransactionTime: 1700090000000, Amount: 2000, Comment: "Taxi"},
+		// same time and account, empty description
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 202, TransactionTime: 1700000000000, Amount: 2000},
+	}
+
+	actualIds := Transactions.getProbableDuplicateTransactionIds(existingTransactions, newTransactions)
+	assert.Equal(t, []int64{1, 0, 0, 0, 2}, actualIds)
+}
+
+func TestGetProbableDuplicateTransactionIds_EachExistingTransactionMatchedOnce(t *testing.T) {
+	existingTransactions := []*models.Transaction{
+		{TransactionId: 1, Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700000000000, Amount: 500, Comment: "Coffee"},
+		{TransactionId: 2, Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700003600000, Amount: 500, Comment: "Coffee"},
+	}
+
+	newTransactions := []*models.Transaction{
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700003600000, Amount: 500, Comment: "Coffee"},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700003600000, Amount: 500, Comment: "Coffee"},
+		{Type: models.TRANSACTION_DB_TYPE_EXPENSE, AccountId: 201, TransactionTime: 1700003600000, Amount: 500, Comment: "Coffee"},
+	}
+
+	actualIds := Transactions.getProbableDuplicateTransactionIds(existingTransactions, newTransactions)
+	assert.Equal(t, []int64{2, 1, 0}, actualIds)
+}
+
+func TestCreateScheduledTransactions_IncreaseOccurrenceCount(t *testing.T) {
+	initializeScheduledTransactionsTestData(t)
+
+	createdTransactions := make([]*models.Transaction, 0)
+	err := Transactions.CreateScheduledTransactions(core.NewNullContext(), time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC).Unix(), 15*time.Minute, func(transaction *models.Transaction, tagIds []int64) {
+		createdTransactions = append(createdTransactions, transaction)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(createdTransactions))
+
+	transactionCount, err := datastore.Container.UserDataStore.Choose(1).NewSession(core.NewNullContext()).Where("uid=? AND deleted=?", 1, false).Count(&models.Transaction{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), transactionCount)
+	assert.Equal(t, int32(1), getScheduledTransactionsTestOccurrenceCount(t))
+}
+
+func TestCreateScheduledTransactions_RollbackWhenReachedMaxOccurrencesConcurrently(t *testing.T) {
+	initializeScheduledTransactionsTestData(t)
+
+	// simulate another process has created all occurrences after the template was loaded
+	_, err := datastore.Container.UserDataStore.Choose(1).NewSession(core.NewNullContext()).Exec("CREATE TRIGGER reach_max_occurrences AFTER INSERT ON \"transaction\" BEGIN UPDATE transaction_template SET scheduled_occurrence_count = scheduled_max_occurrences WHERE template_id = 501; END")
+	assert.Nil(t, err)
+
+	createdTransactions := make([]*models.Transaction, 0)
+	err = Transactions.CreateScheduledTransactions(core.NewNullContext(), time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC).Unix(), 15*time.Minute, func(transaction *models.Transaction, tagIds []int64) {
+		createdTransactions = append(createdTransactions, transaction)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(createdTransactions))
+
+	transactionCount, err := datastore.Container.UserDataStore.Choose(1).NewSession(core.NewNullContext()).Where("uid=? AND deleted=?", 1, false).Count(&models.Transaction{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), transactionCount)
+	assert.Equal(t, int32(0), getScheduledTransactionsTestOccurrenceCount(t))
+}
+
+func initializeScheduledTransactionsTestData(t *testing.T) {
+	initializeServicesTestDataStore(t, new(models.Account), new(models.Transaction), new(models.TransactionCategory), new(models.TransactionTemplate),
+		new(models.TransactionTagIndex), new(models.TransactionItemIndex), new(models.TransactionSplit), new(models.TransactionPictureInfo))
+
+	sess := datastore.Container.UserDataStore.Choose(1).NewSession(core.NewNullContext())
+	defer sess.Close()
+
+	_, err := sess.Insert(&models.Account{AccountId: 101, Uid: 1, Category: models.ACCOUNT_CATEGORY_CASH, Type: models.ACCOUNT_TYPE_SINGLE_ACCOUNT, ParentAccountId: models.LevelOneAccountParentId, Name: "Cash", DisplayOrder: 1, Currency: "USD"})
+	assert.Nil(t, err)
+
+	_, err = sess.Insert([]*models.TransactionCategory{
+		{CategoryId: 201, Uid: 1, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: models.LevelOneTransactionCategoryParentId, Name: "Food", DisplayOrder: 1},
+		{CategoryId: 202, Uid: 1, Type: models.CATEGORY_TYPE_EXPENSE, ParentCategoryId: 201, Name: "Dining", DisplayOrder: 1},
+	})
+	assert.Nil(t, err)
+
+	_, err = sess.Insert(&models.TransactionTemplate{
+		TemplateId:              501,
+		Uid:                     1,
+		TemplateType:            models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+		Name:                    "Daily Lunch",
+		Type:                    models.TRANSACTION_TYPE_EXPENSE,
+		CategoryId:              202,
+		AccountId:               101,
+		ScheduledFrequencyType:  models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY,
+		ScheduledAt:             12 * 60,
+		ScheduledMaxOccurrences: 2,
+		Amount:                  1000,
+	})
+	assert.Nil(t, err)
+}
+
+func getScheduledTransactionsTestOccurrenceCount(t *testing.T) int32 {
+	template := &models.TransactionTemplate{}
+	has, err := datastore.Container.UserDataStore.Choose(1).NewSession(core.NewNullContext()).ID(501).Get(template)
+	assert.Nil(t, err)
+	assert.True(t, has)
+
+	return template.ScheduledOccurrenceCount
+}
@@ -36,6 +36,26 @@ func (s *UserCustomExchangeRatesService) GetAllCustomExchangeRatesByUid(c core.C
 	return customExchangeRates, err
 }
 
+// GetAllUidsWithCustomExchangeRates returns the uids of all users who have user custom exchange rates
+func (s *UserCustomExchangeRatesService) GetAllUidsWithCustomExchangeRates(c core.Context) ([]int64, error) {
+	var uids []int64
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var customExchangeRates []*models.UserCustomExchangeRate
+		err := s.UserDataDBByIndex(i).NewSession(c).Distinct("uid").Where("deleted_unix_time=?", 0).Find(&customExchangeRates)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for j := 0; j < len(customExchangeRates); j++ {
+			uids = append(uids, customExchangeRates[j].Uid)
+		}
+	}
+
+	return uids, nil
+}
+
 // UpdateCustomExchangeRate updates user exchange rate data model to database
 func (s *UserCustomExchangeRatesService) UpdateCustomExchangeRate(c core.Context, uid int64, currency string, rate string, defaultCurrency string) (*models.UserCustomExchangeRate, *models.UserCustomExchangeRate, error) {
 	if uid <= 0 {
@@ -103,6 +103,36 @@ func (s *UserService) GetUserById(c core.Context, uid int64) (*models.User, erro
 	return user, nil
 }
 
+// GetUsersByUids returns the user models according to user uids
+func (s *UserService) GetUsersByUids(c core.Context, uids []int64) (map[int64]*models.User, error) {
+	var users []*models.User
+	err := s.UserDB().NewSession(c).Where("deleted=?", false).In("uid", uids).Find(&users)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userMap := make(map[int64]*models.User, len(users))
+
+	for i := 0; i < len(users); i++ {
+		userMap[users[i].Uid] = users[i]
+	}
+
+	return userMap, nil
+}
+
+// GetAllSummaryEmailEnabledUsers returns all the enabled user models which have enabled the summary report email
+func (s *UserService) GetAllSummaryEmailEnabledUsers(c core.Context) ([]*models.User, error) {
+	var users []*models.User
+	err := s.UserDB().NewSession(c).Where("deleted=? AND disabled=? AND summary_email_frequency>? AND summary_email_frequency<>?", false, false, models.SUMMARY_EMAIL_FREQUENCY_NONE, models.SUMMARY_EMAIL_FREQUENCY_INVALID).Find(&users)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // GetUserByUsername returns the user model according to user name
 func (s *UserService) GetUserByUsername(c core.Context, username string) (*models.User, error) {
 	if username == "" {
@@ -357,6 +387,11 @@ func (s *UserService) UpdateUser(c core.Context, user *models.User, modifyUserLa
 		updateCols = append(updateCols, "income_amount_color")
 	}
 
+	if models.SUMMARY_EMAIL_FREQUENCY_NONE <= user.SummaryEmailFrequency && user.SummaryEmailFrequency <= models.SUMMARY_EMAIL_FREQUENCY_MONTHLY {
+		updateCols = append(updateCols, "summary_email_frequency")
+		updateCols = append(updateCols, "summary_email_utc_offset")
+	}
+
 	user.UpdatedUnixTime = now
 	updateCols = append(updateCols, "updated_unix_time")
 
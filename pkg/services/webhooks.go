@@ -0,0 +1,529 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/httpclient"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+const webhookSecretLength = 32
+const webhookPendingDeliveriesBatchSize = 500
+const webhookMaxResponseBodySize = 65536
+
+// WebhookService represents webhook service
+type WebhookService struct {
+	ServiceUsingDB
+	ServiceUsingConfig
+	ServiceUsingUuid
+}
+
+// Initialize a webhook service singleton instance
+var (
+	Webhooks = &WebhookService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingConfig: ServiceUsingConfig{
+			container: settings.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllWebhooksByUid returns all webhook models of user
+func (s *WebhookService) GetAllWebhooksByUid(c core.Context, uid int64) ([]*models.Webhook, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var webhooks []*models.Webhook
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("created_unix_time asc").Find(&webhooks)
+
+	return webhooks, err
+}
+
+// GetWebhookByWebhookId returns a webhook model according to webhook id
+func (s *WebhookService) GetWebhookByWebhookId(c core.Context, uid int64, webhookId int64) (*models.Webhook, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if webhookId <= 0 {
+		return nil, errs.ErrWebhookIdInvalid
+	}
+
+	webhook := &models.Webhook{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(webhookId).Where("uid=? AND deleted=?", uid, false).Get(webhook)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// GetLatestDeliveriesByWebhookId returns the latest delivery log models of specified webhook (the latest one is the first)
+func (s *WebhookService) GetLatestDeliveriesByWebhookId(c core.Context, uid int64, webhookId int64, count int32) ([]*models.WebhookDelivery, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if webhookId <= 0 {
+		return nil, errs.ErrWebhookIdInvalid
+	}
+
+	var deliveries []*models.WebhookDelivery
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND webhook_id=?", uid, false, webhookId).OrderBy("created_unix_time desc, delivery_id desc").Limit(int(count)).Find(&deliveries)
+
+	return deliveries, err
+}
+
+// GenerateSecret returns a new random secret which is used for signing webhook payloads
+func (s *WebhookService) GenerateSecret() (string, error) {
+	return utils.GetRandomNumberOrLetter(webhookSecretLength)
+}
+
+// CreateWebhook saves a new webhook model to database
+func (s *WebhookService) CreateWebhook(c core.Context, webhook *models.Webhook) error {
+	if webhook.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	webhook.WebhookId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if webhook.WebhookId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	webhook.Deleted = false
+	webhook.CreatedUnixTime = time.Now().Unix()
+	webhook.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(webhook.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(webhook)
+		return err
+	})
+}
+
+// ModifyWebhook saves an existed webhook model to database
+func (s *WebhookService) ModifyWebhook(c core.Context, webhook *models.Webhook) error {
+	if webhook.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	webhook.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(webhook.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(webhook.WebhookId).Cols("name", "url", "secret", "event_types", "disabled", "updated_unix_time").Where("uid=? AND deleted=?", webhook.Uid, false).Update(webhook)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrWebhookNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteWebhook deletes an existed webhook and its delivery logs from database
+func (s *WebhookService) DeleteWebhook(c core.Context, uid int64, webhookId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Webhook{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	updateDeliveryModel := &models.WebhookDelivery{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(webhookId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrWebhookNotFound
+		}
+
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND webhook_id=?", uid, false, webhookId).Update(updateDeliveryModel)
+
+		return err
+	})
+}
+
+// DeleteAllWebhooks deletes all existed webhooks and delivery logs from database
+func (s *WebhookService) DeleteAllWebhooks(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Webhook{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	updateDeliveryModel := &models.WebhookDelivery{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateDeliveryModel)
+
+		return err
+	})
+}
+
+// TriggerEvent saves the delivery logs of specified event for all enabled webhooks of user which subscribe the event, and then delivers them in background
+func (s *WebhookService) TriggerEvent(c core.Context, uid int64, eventType models.WebhookEventType, data any) error {
+	if !s.CurrentConfig().EnableWebhook {
+		return nil
+	}
+
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	allWebhooks, err := s.GetAllWebhooksByUid(c, uid)
+
+	if err != nil {
+		return err
+	}
+
+	webhooks := make([]*models.Webhook, 0, len(allWebhooks))
+
+	for i := 0; i < len(allWebhooks); i++ {
+		if allWebhooks[i].IsSubscribed(eventType) {
+			webhooks = append(webhooks, allWebhooks[i])
+		}
+	}
+
+	if len(webhooks) < 1 {
+		return nil
+	}
+
+	deliveryIds := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, uint16(len(webhooks)))
+
+	if len(deliveryIds) < len(webhooks) {
+		return errs.ErrSystemIsBusy
+	}
+
+	now := time.Now().Unix()
+	deliveries := make([]*models.WebhookDelivery, len(webhooks))
+
+	for i := 0; i < len(webhooks); i++ {
+		payload, err := json.Marshal(&models.WebhookEventPayload{
+			Id:        deliveryIds[i],
+			Event:     eventType.String(),
+			CreatedAt: now,
+			Data:      data,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		deliveries[i] = &models.WebhookDelivery{
+			DeliveryId:      deliveryIds[i],
+			Uid:             uid,
+			Deleted:         false,
+			WebhookId:       webhooks[i].WebhookId,
+			EventType:       eventType,
+			Payload:         string(payload),
+			Status:          models.WEBHOOK_DELIVERY_STATUS_PENDING,
+			Attempts:        0,
+			CreatedUnixTime: now,
+			UpdatedUnixTime: now,
+		}
+
+		// the first attempt is made immediately in background, the retry job will take over it if the attempt is not completed
+		deliveries[i].NextAttemptUnixTime = now + int64(deliveries[i].GetNextRetryDelay()/time.Second)
+	}
+
+	err = s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(deliveries); i++ {
+			_, err := sess.Insert(deliveries[i])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ctx := core.NewNullContext()
+		client := s.getHttpClient()
+
+		for i := 0; i < len(deliveries); i++ {
+			s.deliver(ctx, client, webhooks[i], deliveries[i])
+		}
+	}()
+
+	return nil
+}
+
+// DeliverPendingDeliveries delivers all pending webhook events of which next attempt time has arrived for all users
+func (s *WebhookService) DeliverPendingDeliveries(c core.Context, now time.Time) error {
+	var allDeliveries []*models.WebhookDelivery
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var deliveries []*models.WebhookDelivery
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND status=? AND next_attempt_unix_time<=?", false, models.WEBHOOK_DELIVERY_STATUS_PENDING, now.Unix()).OrderBy("next_attempt_unix_time asc").Limit(webhookPendingDeliveriesBatchSize).Find(&deliveries)
+
+		if err != nil {
+			return err
+		}
+
+		allDeliveries = append(allDeliveries, deliveries...)
+	}
+
+	if len(allDeliveries) < 1 {
+		return nil
+	}
+
+	log.Infof(c, "[webhooks.DeliverPendingDeliveries] should deliver %d pending webhook events now", len(allDeliveries))
+
+	client := s.getHttpClient()
+	webhooks := make(map[int64]*models.Webhook)
+	successCount := 0
+	failedCount := 0
+
+	for i := 0; i < len(allDeliveries); i++ {
+		delivery := allDeliveries[i]
+
+		// postpone the next attempt time first to prevent the delivery from being processed repeatedly
+		updateModel := &models.WebhookDelivery{
+			NextAttemptUnixTime: now.Unix() + int64(delivery.GetNextRetryDelay()/time.Second),
+		}
+
+		updatedRows, err := s.UserDataDB(delivery.Uid).NewSession(c).ID(delivery.DeliveryId).Cols("next_attempt_unix_time").Where("uid=? AND deleted=? AND status=? AND next_attempt_unix_time=?", delivery.Uid, false, models.WEBHOOK_DELIVERY_STATUS_PENDING, delivery.NextAttemptUnixTime).Update(updateModel)
+
+		if err != nil {
+			log.Errorf(c, "[webhooks.DeliverPendingDeliveries] failed to update next attempt time of webhook delivery \"id:%d\", because %s", delivery.DeliveryId, err.Error())
+			continue
+		} else if updatedRows < 1 {
+			continue
+		}
+
+		webhook, exists := webhooks[delivery.WebhookId]
+
+		if !exists {
+			webhook, err = s.GetWebhookByWebhookId(c, delivery.Uid, delivery.WebhookId)
+
+			if err != nil && err != errs.ErrWebhookNotFound {
+				log.Errorf(c, "[webhooks.DeliverPendingDeliveries] failed to get webhook \"id:%d\" of webhook delivery \"id:%d\", because %s", delivery.WebhookId, delivery.DeliveryId, err.Error())
+				continue
+			}
+
+			webhooks[delivery.WebhookId] = webhook
+		}
+
+		if s.deliver(c, client, webhook, delivery) {
+			successCount++
+		} else {
+			failedCount++
+		}
+	}
+
+	log.Infof(c, "[webhooks.DeliverPendingDeliveries] %d webhook events has been delivered successfully and %d webhook events failed to deliver", successCount, failedCount)
+
+	return nil
+}
+
+func (s *WebhookService) deliver(c core.Context, client *http.Client, webhook *models.Webhook, delivery *models.WebhookDelivery) bool {
+	delivery.Attempts++
+	delivery.LastResponseStatusCode = 0
+	delivery.LastError = ""
+
+	if webhook == nil || webhook.Disabled {
+		delivery.LastError = "webhook has been deleted or disabled"
+	} else {
+		statusCode, err := s.sendRequest(c, client, webhook, delivery)
+		delivery.LastResponseStatusCode = int32(statusCode)
+
+		if err != nil {
+			delivery.LastError = utils.SubString(err.Error(), 0, models.MaximumWebhookDeliveryLastErrorLength)
+		} else if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+			delivery.LastError = fmt.Sprintf("webhook endpoint responded with status code %d", statusCode)
+		}
+	}
+
+	now := time.Now().Unix()
+
+	if delivery.LastError == "" {
+		delivery.Status = models.WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+		delivery.NextAttemptUnixTime = 0
+	} else if webhook == nil || webhook.Disabled || delivery.Attempts >= int32(s.CurrentConfig().WebhookMaxDeliveryAttempts) {
+		delivery.Status = models.WEBHOOK_DELIVERY_STATUS_FAILED
+		delivery.NextAttemptUnixTime = 0
+	} else {
+		delivery.Status = models.WEBHOOK_DELIVERY_STATUS_PENDING
+		delivery.NextAttemptUnixTime = now + int64(delivery.GetNextRetryDelay()/time.Second)
+	}
+
+	delivery.UpdatedUnixTime = now
+
+	if delivery.Status == models.WEBHOOK_DELIVERY_STATUS_SUCCEEDED {
+		log.Infof(c, "[webhooks.deliver] webhook delivery \"id:%d\" of user \"uid:%d\" has been delivered successfully", delivery.DeliveryId, delivery.Uid)
+	} else {
+		log.Warnf(c, "[webhooks.deliver] webhook delivery \"id:%d\" of user \"uid:%d\" failed to deliver (attempt %d), because %s", delivery.DeliveryId, delivery.Uid, delivery.Attempts, delivery.LastError)
+	}
+
+	_, err := s.UserDataDB(delivery.Uid).NewSession(c).ID(delivery.DeliveryId).Cols("status", "attempts", "next_attempt_unix_time", "last_response_status_code", "last_error", "updated_unix_time").Where("uid=? AND deleted=?", delivery.Uid, false).Update(delivery)
+
+	if err != nil {
+		log.Errorf(c, "[webhooks.deliver] failed to update webhook delivery \"id:%d\" of user \"uid:%d\", because %s", delivery.DeliveryId, delivery.Uid, err.Error())
+	}
+
+	return delivery.Status == models.WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+}
+
+func (s *WebhookService) sendRequest(c core.Context, client *http.Client, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodPost, webhook.Url, bytes.NewReader([]byte(delivery.Payload)))
+
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(models.WebhookEventHeaderName, delivery.EventType.String())
+	req.Header.Set(models.WebhookDeliveryHeaderName, utils.Int64ToString(delivery.DeliveryId))
+	req.Header.Set(models.WebhookTimestampHeaderName, utils.Int64ToString(timestamp))
+	req.Header.Set(models.WebhookSignatureHeaderName, delivery.GetSignature(webhook.Secret, timestamp))
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxResponseBodySize))
+
+	return resp.StatusCode, nil
+}
+
+// CheckWebhookUrl returns error if the webhook url is invalid or its host is (or resolves to) an address which is not allowed
+func (s *WebhookService) CheckWebhookUrl(c core.Context, webhookUrl string) error {
+	parsedUrl, err := url.Parse(webhookUrl)
+
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Hostname() == "" {
+		return errs.ErrWebhookUrlInvalid
+	}
+
+	host := parsedUrl.Hostname()
+	ips := make([]net.IP, 0, 1)
+
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		ipAddrs, err := net.DefaultResolver.LookupIPAddr(c, host)
+
+		if err != nil || len(ipAddrs) < 1 {
+			return errs.ErrWebhookUrlInvalid
+		}
+
+		for i := 0; i < len(ipAddrs); i++ {
+			ips = append(ips, ipAddrs[i].IP)
+		}
+	}
+
+	allowedPrivateIPs := s.CurrentConfig().WebhookAllowedPrivateIPs
+
+	for i := 0; i < len(ips); i++ {
+		if !isWebhookIPAddressAllowed(ips[i], allowedPrivateIPs) {
+			return errs.ErrWebhookUrlNotAllowed
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) getHttpClient() *http.Client {
+	config := s.CurrentConfig()
+	return httpclient.NewHttpClientWithDialControl(config.WebhookRequestTimeout, config.WebhookProxy, config.WebhookSkipTLSVerify, settings.GetUserAgent(), false, getWebhookDialControl(config.WebhookAllowedPrivateIPs))
+}
+
+// getWebhookDialControl returns the dial control function which checks the resolved address again before connecting, so that the webhook url cannot be rebound to a private address by dns
+func getWebhookDialControl(allowedPrivateIPs []*core.IPPattern) func(network string, address string, conn syscall.RawConn) error {
+	return func(network string, address string, conn syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+
+		if err != nil {
+			return err
+		}
+
+		ip := net.ParseIP(host)
+
+		if ip == nil || !isWebhookIPAddressAllowed(ip, allowedPrivateIPs) {
+			return errs.ErrWebhookUrlNotAllowed
+		}
+
+		return nil
+	}
+}
+
+// isWebhookIPAddressAllowed returns whether webhook can be delivered to the ip address, the loopback, private, link-local and unspecified addresses are only allowed when they are in the allowed list
+func isWebhookIPAddressAllowed(ip net.IP, allowedPrivateIPs []*core.IPPattern) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	if !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsUnspecified() && (len(ip) != net.IPv4len || ip[0] != 0) {
+		return true
+	}
+
+	for i := 0; i < len(allowedPrivateIPs); i++ {
+		if allowedPrivateIPs[i].Match(ip.String()) {
+			return true
+		}
+	}
+
+	return false
+}
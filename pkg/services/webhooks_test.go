@@ -0,0 +1,94 @@
+package services
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/httpclient"
+)
+
+func TestIsWebhookIPAddressAllowed(t *testing.T) {
+	assert.True(t, isWebhookIPAddressAllowed(net.ParseIP("8.8.8.8"), nil))
+	assert.True(t, isWebhookIPAddressAllowed(net.ParseIP("2001:4860:4860::8888"), nil))
+
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("127.0.0.1"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("::1"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("10.0.0.1"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("172.16.0.1"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("192.168.1.1"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("fd00::1"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("169.254.169.254"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("fe80::1"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("0.0.0.0"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("0.1.2.3"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("::"), nil))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("::ffff:127.0.0.1"), nil))
+}
+
+func TestIsWebhookIPAddressAllowed_AllowedPrivateIPs(t *testing.T) {
+	pattern, err := core.ParseIPPattern("192.168.1.*")
+	assert.Nil(t, err)
+
+	allowedPrivateIPs := []*core.IPPattern{pattern}
+
+	assert.True(t, isWebhookIPAddressAllowed(net.ParseIP("192.168.1.10"), allowedPrivateIPs))
+	assert.True(t, isWebhookIPAddressAllowed(net.ParseIP("::ffff:192.168.1.10"), allowedPrivateIPs))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("192.168.2.10"), allowedPrivateIPs))
+	assert.False(t, isWebhookIPAddressAllowed(net.ParseIP("127.0.0.1"), allowedPrivateIPs))
+}
+
+func TestGetWebhookDialControl(t *testing.T) {
+	dialControl := getWebhookDialControl(nil)
+
+	assert.Nil(t, dialControl("tcp4", "8.8.8.8:443", nil))
+	assert.EqualError(t, dialControl("tcp4", "127.0.0.1:80", nil), errs.ErrWebhookUrlNotAllowed.Message)
+	assert.EqualError(t, dialControl("tcp6", "[::1]:80", nil), errs.ErrWebhookUrlNotAllowed.Message)
+	assert.EqualError(t, dialControl("tcp4", "169.254.169.254:80", nil), errs.ErrWebhookUrlNotAllowed.Message)
+}
+
+func TestGetWebhookDialControl_RejectLoopbackServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := httpclient.NewHttpClientWithDialControl(1000, "none", false, "", false, getWebhookDialControl(nil))
+	_, err := client.Get(server.URL)
+	assert.NotNil(t, err)
+
+	pattern, err := core.ParseIPPattern("127.0.0.1")
+	assert.Nil(t, err)
+
+	client = httpclient.NewHttpClientWithDialControl(1000, "none", false, "", false, getWebhookDialControl([]*core.IPPattern{pattern}))
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
+func TestGetWebhookDialControl_CheckTargetHostBeforeSendingViaProxy(t *testing.T) {
+	requestUrls := make([]string, 0, 2)
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestUrls = append(requestUrls, r.URL.String())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxyServer.Close()
+
+	client := httpclient.NewHttpClientWithDialControl(1000, proxyServer.URL, false, "", false, getWebhookDialControl(nil))
+
+	_, err := client.Get("http://169.254.169.254/latest/meta-data")
+	assert.ErrorContains(t, err, errs.ErrWebhookUrlNotAllowed.Message)
+	assert.Equal(t, 0, len(requestUrls))
+
+	resp, err := client.Get("http://8.8.8.8/webhook")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"http://8.8.8.8/webhook"}, requestUrls)
+	_ = resp.Body.Close()
+}
@@ -79,6 +79,13 @@ const (
 	GoogleAILLMProvider            string = "google_ai"
 )
 
+// OCR engine types
+const (
+	PaddleOCREngine    string = "paddle"
+	TesseractOCREngine string = "tesseract"
+	LLMOCREngine       string = "llm"
+)
+
 // Uuid generator types
 const (
 	InternalUuidGeneratorType string = "internal"
@@ -165,9 +172,14 @@ const (
 	defaultWebDAVRequestTimeout uint32 = 10000 // 10 seconds
 
 	defaultAIRecognitionPictureMaxSize                 uint32 = 10485760 // 10MB
+	defaultAIRecognitionTextMaxLength                  uint32 = 500
 	defaultAnthropicLargeLanguageModelAPIMaximumTokens uint32 = 1024
 	defaultLargeLanguageModelAPIRequestTimeout         uint32 = 60000 // 60 seconds
 
+	defaultOCREngines         string = PaddleOCREngine
+	defaultTesseractPath      string = "tesseract"
+	defaultTesseractLanguages string = "chi_sim+eng"
+
 	defaultInMemoryDuplicateCheckerCleanupInterval uint32 = 60  // 1 minutes
 	defaultDuplicateSubmissionsInterval            uint32 = 300 // 5 minutes
 
@@ -186,9 +198,18 @@ const (
 	defaultTransactionPictureFileMaxSize uint32 = 10485760 // 10MB
 	defaultUserAvatarFileMaxSize         uint32 = 1048576  // 1MB
 
-	defaultImportFileMaxSize uint32 = 10485760 // 10MB
+	defaultImportFileMaxSize                uint32 = 10485760  // 10MB
+	defaultImportArchiveMaxUncompressedSize uint32 = 268435456 // 256MB
+	defaultImportJobWorkerCount             uint32 = 2
+	maxImportJobWorkerCount                 uint32 = 16
+
+	defaultExchangeRatesDataRequestTimeout  uint32 = 10000 // 10 seconds
+	defaultExchangeRatesHistoryBackfillDays uint32 = 90
+	maxExchangeRatesHistoryBackfillDays     uint32 = 3650
 
-	defaultExchangeRatesDataRequestTimeout uint32 = 10000 // 10 seconds
+	defaultWebhookRequestTimeout      uint32 = 10000 // 10 seconds
+	defaultWebhookMaxDeliveryAttempts uint32 = 8
+	maxWebhookMaxDeliveryAttempts     uint32 = 20
 )
 
 // DatabaseConfig represents the database setting config
@@ -339,11 +360,17 @@ type Config struct {
 	// Large Language Model
 	TransactionFromAIImageRecognition bool
 	TransactionFromOCRImageRecognition bool
+	TransactionFromAITextRecognition  bool
 	MaxAIRecognitionPictureFileSize   uint32
+	MaxAIRecognitionTextLength        uint32
 
 	// OCR via external PaddleOCR HTTP service (for bill / transaction list screenshots)
 	// The endpoint should accept multipart/form-data "image" and return JSON with at least { "success": true, "text": "..." }.
 	PaddleBillOCREndpoint string
+	// OCR engines used for bill recognition, the later ones are used as fallback when the former ones fail
+	OCREngines         []string
+	TesseractPath      string
+	TesseractLanguages string
 	// OCR bill recognition UI settings
 	OCRBillRecognitionHideCategoryColumn bool
 	OCRBillRecognitionHideItemsColumn   bool
@@ -365,8 +392,12 @@ type Config struct {
 	DuplicateSubmissionsIntervalDuration            time.Duration
 
 	// Cron
-	EnableRemoveExpiredTokens        bool
-	EnableCreateScheduledTransaction bool
+	EnableRemoveExpiredTokens              bool
+	EnableCreateScheduledTransaction       bool
+	EnableCheckOverdueCreditCardStatements bool
+	EnableSaveExchangeRatesHistory         bool
+	EnableSendSummaryReportEmails          bool
+	SendSummaryReportEmailsHour            uint32
 
 	// Backup
 	EnableDailyEmailBackup         bool
@@ -426,9 +457,12 @@ type Config struct {
 	DefaultFeatureRestrictions    core.UserFeatureRestrictions
 
 	// Data
-	EnableDataExport  bool
-	EnableDataImport  bool
-	MaxImportFileSize uint32
+	EnableDataExport                 bool
+	EnableDataImport                 bool
+	EnableFinancialReport            bool
+	MaxImportFileSize                uint32
+	MaxImportArchiveUncompressedSize uint32
+	ImportJobWorkerCount             uint32
 
 	// Tip
 	LoginPageTips MultiLanguageContentConfig
@@ -462,6 +496,15 @@ type Config struct {
 	ExchangeRatesRequestTimeoutExceedDefaultValue bool
 	ExchangeRatesProxy                            string
 	ExchangeRatesSkipTLSVerify                    bool
+	ExchangeRatesHistoryBackfillDays              uint32
+
+	// Webhook
+	EnableWebhook              bool
+	WebhookRequestTimeout      uint32
+	WebhookProxy               string
+	WebhookSkipTLSVerify       bool
+	WebhookMaxDeliveryAttempts uint32
+	WebhookAllowedPrivateIPs   []*core.IPPattern
 }
 
 // LoadConfiguration loads setting config from given config file path
@@ -610,6 +653,12 @@ func LoadConfiguration(configFilePath string) (*Config, error) {
 		return nil, err
 	}
 
+	err = loadWebhookConfiguration(config, cfgFile, "webhook")
+
+	if err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -885,12 +934,34 @@ func loadStorageConfiguration(config *Config, configFile *ini.File, sectionName
 func loadLLMGlobalConfiguration(config *Config, configFile *ini.File, sectionName string) error {
 	config.TransactionFromAIImageRecognition = getConfigItemBoolValue(configFile, sectionName, "transaction_from_ai_image_recognition", false)
 	config.TransactionFromOCRImageRecognition = getConfigItemBoolValue(configFile, sectionName, "transaction_from_ocr_image_recognition", false)
+	config.TransactionFromAITextRecognition = getConfigItemBoolValue(configFile, sectionName, "transaction_from_ai_text_recognition", false)
 	config.MaxAIRecognitionPictureFileSize = getConfigItemUint32Value(configFile, sectionName, "max_ai_recognition_picture_size", defaultAIRecognitionPictureMaxSize)
+	config.MaxAIRecognitionTextLength = getConfigItemUint32Value(configFile, sectionName, "max_ai_recognition_text_length", defaultAIRecognitionTextMaxLength)
 
 	// Optional: external PaddleOCR HTTP endpoint for bill / transaction list screenshots.
 	// Example: http://127.0.0.1:8866/api/ocr/bill
 	config.PaddleBillOCREndpoint = getConfigItemStringValue(configFile, sectionName, "paddle_bill_ocr_endpoint")
 
+	ocrEngines := strings.Split(getConfigItemStringValue(configFile, sectionName, "ocr_engines", defaultOCREngines), ",")
+	config.OCREngines = make([]string, 0, len(ocrEngines))
+
+	for i := 0; i < len(ocrEngines); i++ {
+		ocrEngine := strings.TrimSpace(ocrEngines[i])
+
+		if ocrEngine == "" {
+			continue
+		}
+
+		if ocrEngine != PaddleOCREngine && ocrEngine != TesseractOCREngine && ocrEngine != LLMOCREngine {
+			return errs.ErrInvalidOCREngine
+		}
+
+		config.OCREngines = append(config.OCREngines, ocrEngine)
+	}
+
+	config.TesseractPath = getConfigItemStringValue(configFile, sectionName, "tesseract_path", defaultTesseractPath)
+	config.TesseractLanguages = getConfigItemStringValue(configFile, sectionName, "tesseract_languages", defaultTesseractLanguages)
+
 	// OCR bill recognition UI settings
 	config.OCRBillRecognitionHideCategoryColumn = getConfigItemBoolValue(configFile, sectionName, "ocr_bill_recognition_hide_category_column", true)
 	config.OCRBillRecognitionHideItemsColumn = getConfigItemBoolValue(configFile, sectionName, "ocr_bill_recognition_hide_items_column", true)
@@ -1007,6 +1078,14 @@ func loadDuplicateCheckerConfiguration(config *Config, configFile *ini.File, sec
 func loadCronConfiguration(config *Config, configFile *ini.File, sectionName string) error {
 	config.EnableRemoveExpiredTokens = getConfigItemBoolValue(configFile, sectionName, "enable_remove_expired_tokens", false)
 	config.EnableCreateScheduledTransaction = getConfigItemBoolValue(configFile, sectionName, "enable_create_scheduled_transaction", false)
+	config.EnableCheckOverdueCreditCardStatements = getConfigItemBoolValue(configFile, sectionName, "enable_check_overdue_credit_card_statements", false)
+	config.EnableSaveExchangeRatesHistory = getConfigItemBoolValue(configFile, sectionName, "enable_save_exchange_rates_history", false)
+	config.EnableSendSummaryReportEmails = getConfigItemBoolValue(configFile, sectionName, "enable_send_summary_report_emails", false)
+	config.SendSummaryReportEmailsHour = getConfigItemUint32Value(configFile, sectionName, "send_summary_report_emails_hour", 8)
+
+	if config.SendSummaryReportEmailsHour > 23 {
+		config.SendSummaryReportEmailsHour = 8
+	}
 
 	return nil
 }
@@ -1167,7 +1246,16 @@ func loadUserConfiguration(config *Config, configFile *ini.File, sectionName str
 func loadDataConfiguration(config *Config, configFile *ini.File, sectionName string) error {
 	config.EnableDataExport = getConfigItemBoolValue(configFile, sectionName, "enable_export", false)
 	config.EnableDataImport = getConfigItemBoolValue(configFile, sectionName, "enable_import", false)
+	config.EnableFinancialReport = getConfigItemBoolValue(configFile, sectionName, "enable_financial_report", false)
 	config.MaxImportFileSize = getConfigItemUint32Value(configFile, sectionName, "max_import_file_size", defaultImportFileMaxSize)
+	config.MaxImportArchiveUncompressedSize = getConfigItemUint32Value(configFile, sectionName, "max_import_archive_uncompressed_size", defaultImportArchiveMaxUncompressedSize)
+	config.ImportJobWorkerCount = getConfigItemUint32Value(configFile, sectionName, "import_job_workers", defaultImportJobWorkerCount)
+
+	if config.ImportJobWorkerCount < 1 {
+		config.ImportJobWorkerCount = 1
+	} else if config.ImportJobWorkerCount > maxImportJobWorkerCount {
+		config.ImportJobWorkerCount = maxImportJobWorkerCount
+	}
 
 	return nil
 }
@@ -1283,6 +1371,51 @@ func loadExchangeRatesConfiguration(config *Config, configFile *ini.File, sectio
 	}
 
 	config.ExchangeRatesSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "skip_tls_verify", false)
+	config.ExchangeRatesHistoryBackfillDays = getConfigItemUint32Value(configFile, sectionName, "history_backfill_days", defaultExchangeRatesHistoryBackfillDays)
+
+	if config.ExchangeRatesHistoryBackfillDays > maxExchangeRatesHistoryBackfillDays {
+		config.ExchangeRatesHistoryBackfillDays = maxExchangeRatesHistoryBackfillDays
+	}
+
+	return nil
+}
+
+func loadWebhookConfiguration(config *Config, configFile *ini.File, sectionName string) error {
+	config.EnableWebhook = getConfigItemBoolValue(configFile, sectionName, "enable_webhook", false)
+	config.WebhookRequestTimeout = getConfigItemUint32Value(configFile, sectionName, "request_timeout", defaultWebhookRequestTimeout)
+	config.WebhookProxy = getConfigItemStringValue(configFile, sectionName, "proxy", "system")
+	config.WebhookSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "skip_tls_verify", false)
+	config.WebhookMaxDeliveryAttempts = getConfigItemUint32Value(configFile, sectionName, "max_delivery_attempts", defaultWebhookMaxDeliveryAttempts)
+
+	if config.WebhookMaxDeliveryAttempts < 1 {
+		config.WebhookMaxDeliveryAttempts = 1
+	} else if config.WebhookMaxDeliveryAttempts > maxWebhookMaxDeliveryAttempts {
+		config.WebhookMaxDeliveryAttempts = maxWebhookMaxDeliveryAttempts
+	}
+
+	allowedPrivateIps := getConfigItemStringValue(configFile, sectionName, "allowed_private_ips", "")
+
+	if allowedPrivateIps != "" {
+		privateIPs := strings.Split(allowedPrivateIps, ",")
+		config.WebhookAllowedPrivateIPs = make([]*core.IPPattern, 0, len(privateIPs))
+
+		for i := 0; i < len(privateIPs); i++ {
+			ip := strings.TrimSpace(privateIPs[i])
+			pattern, err := core.ParseIPPattern(ip)
+
+			if err != nil {
+				return err
+			}
+
+			if pattern == nil {
+				continue
+			}
+
+			config.WebhookAllowedPrivateIPs = append(config.WebhookAllowedPrivateIPs, pattern)
+		}
+	} else {
+		config.WebhookAllowedPrivateIPs = nil
+	}
 
 	return nil
 }
@@ -166,6 +166,12 @@ func MD5EncodeToString(data []byte) string {
 	return hex.EncodeToString(hash)
 }
 
+// SHA256EncodeToString returns a hashed string by sha256
+func SHA256EncodeToString(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
 // AESGCMEncrypt returns a encrypted string by aes-gcm
 func AESGCMEncrypt(key []byte, plainText []byte) ([]byte, error) {
 	block, err := aes.NewCipher(key)
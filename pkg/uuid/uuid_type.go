@@ -19,4 +19,6 @@ const (
 	UUID_TYPE_ITEM_GROUP  UuidType = 11
 	UUID_TYPE_ITEM        UuidType = 12
 	UUID_TYPE_ITEM_INDEX  UuidType = 13
+	UUID_TYPE_BUDGET      UuidType = 14
+	UUID_TYPE_LEDGER      UuidType = 15
 )
@@ -59,6 +59,10 @@ export function isTransactionFromAIImageRecognitionEnabled(): boolean {
     return getServerSetting('llmt') === 1;
 }
 
+export function isTransactionFromAITextRecognitionEnabled(): boolean {
+    return getServerSetting('llmx') === 1;
+}
+
 export function isTransactionFromOCRImageRecognitionEnabled(): boolean {
     const v = getServerSetting('llmo');
     return v === 1 || v === '1' || v === true;
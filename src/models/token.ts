@@ -7,11 +7,13 @@ export const TOKEN_TYPE_MCP: number = 5;
 
 export interface TokenGenerateAPIRequest {
     readonly expiresInSeconds: number;
+    readonly scopes?: string[];
     readonly password: string;
 }
 
 export interface TokenGenerateMCPRequest {
     readonly expiresInSeconds: number;
+    readonly allowDestructiveTools?: boolean;
     readonly password: string;
 }
 
@@ -43,6 +45,7 @@ export interface TokenInfoResponse {
     readonly userAgent: string;
     readonly lastSeen: number;
     readonly isCurrent: boolean;
+    readonly scopes?: string[];
 }
 
 export enum SessionDeviceType {